@@ -1,12 +1,21 @@
 package main
 
 import (
+	"context"
 	"crypto/ed25519"
 	"fmt"
 	"github.com/a-novel/auth-service/config"
+	"github.com/a-novel/auth-service/pkg/dao"
 	"github.com/a-novel/auth-service/pkg/handlers"
 	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
 	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	sendgridproxy "github.com/a-novel/sendgrid-proxy"
+	"github.com/gin-gonic/gin"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+	"net/http"
+	"time"
 )
 
 func keyGen() (ed25519.PrivateKey, error) {
@@ -14,29 +23,180 @@ func keyGen() (ed25519.PrivateKey, error) {
 	return private, err
 }
 
+func getFrontendURL(value string) string {
+	return config.App.Frontend.URLs[0] + value
+}
+
 func main() {
+	ctx := context.Background()
 	logger := config.GetInternalLogger()
 
-	secretKeysDAO, logger := config.GetSecretsRepository(logger)
+	// Migrations are run by the public API.
+	postgres, sql, err := bunovel.NewClient(ctx, bunovel.Config{
+		Driver:                &bunovel.PGDriver{DSN: config.Postgres.DSN, AppName: config.App.Name},
+		DiscardUnknownColumns: true,
+	})
+	if err != nil {
+		logger.Fatal().Err(err).Msg("error connecting to postgres")
+	}
+	defer func() {
+		_ = postgres.Close()
+		_ = sql.Close()
+	}()
+
+	mailSender := mail.NewEmail(config.Mailer.Sender.Name, config.Mailer.Sender.Email)
+	mailClient := sendgridproxy.NewMailer(config.Mailer.APIKey, mailSender, config.Mailer.Sandbox, logger)
+
+	secretKeysDAO, logger := config.GetSecretsRepository(logger, postgres)
+	credentialsDAO := dao.NewCredentialsRepository(postgres)
+	identityDAO := dao.NewIdentityRepository(postgres)
+	profileDAO := dao.NewProfileRepository(postgres)
+	refreshTokensDAO := dao.NewRefreshTokensRepository(postgres)
+	revokedTokensDAO := dao.NewRevokedTokensRepository(postgres)
+	sessionsDAO := dao.NewSessionsRepository(postgres)
+	revokedSignatureKeysDAO := dao.NewRevokedSignatureKeysRepository(postgres)
+	locksDAO := dao.NewLocksRepository(postgres)
+	// Revoked keys must stop being trusted right away, so they are filtered on top of the keys kept in memory.
+	secretKeysDAO = dao.NewRevocationFilteredSecretKeysRepository(secretKeysDAO, revokedSignatureKeysDAO)
+	loginFailuresDAO, logger := config.GetLoginFailuresRepository(logger, postgres)
+	userDAO := dao.NewUserRepository(postgres)
+	dataExportsDAO := dao.NewDataExportsRepository(postgres)
+	auditEventsDAO := dao.NewAuditEventsRepository(postgres)
 
-	generateTokenService := services.NewGenerateTokenService(secretKeysDAO, config.Tokens.TTL)
-	getTokenService := services.NewGetTokenStatusService(secretKeysDAO)
-	introspectTokenService := services.NewIntrospectTokenService(generateTokenService, getTokenService, config.Tokens.RenewDelta)
-	rotateSecretKeysService := services.NewRotateSecretKeysService(secretKeysDAO, keyGen, config.Secrets.Backups)
+	permissionsClient := config.GetPermissionsClient(logger)
+
+	generateTokenService := services.NewGenerateTokenService(secretKeysDAO, sessionsDAO, config.Tokens.TTL, config.Tokens.Issuer, config.Tokens.Audience)
+	getTokenService := services.NewGetTokenStatusService(secretKeysDAO, revokedTokensDAO, credentialsDAO, config.Tokens.Issuer, config.Tokens.Audience, config.Tokens.AcceptLegacy)
+	introspectTokenService := services.NewIntrospectTokenService(generateTokenService, getTokenService, refreshTokensDAO, sessionsDAO, config.Tokens.RenewDelta, config.Tokens.LastSeenThrottle)
+	rotateSecretKeysService := services.NewRotateSecretKeysService(secretKeysDAO, auditEventsDAO, keyGen, config.Secrets.Backups, config.Secrets.ActivationDelay)
+	revokeSignatureKeyService := services.NewRevokeSignatureKeyService(secretKeysDAO, revokedSignatureKeysDAO, sessionsDAO, auditEventsDAO, rotateSecretKeysService)
+	scheduleSecretKeysRotationService := services.NewScheduleSecretKeysRotationService(secretKeysDAO, locksDAO, rotateSecretKeysService, config.Secrets.RotationInterval)
+	getJWKSService := services.NewGetJWKSService(secretKeysDAO)
+	revokeUserTokensService := services.NewRevokeUserTokensService(credentialsDAO, revokedTokensDAO, refreshTokensDAO, config.Tokens.TTL)
+	pruneRevokedTokensService := services.NewPruneRevokedTokensService(revokedTokensDAO)
+	unlockAccountService := services.NewUnlockAccountService(loginFailuresDAO)
+	pruneLoginFailuresService := services.NewPruneLoginFailuresService(loginFailuresDAO, config.GetLoginThrottle().Retention())
+	pruneDataExportsService := services.NewPruneDataExportsService(dataExportsDAO)
+	suspendUserService := services.NewSuspendUserService(credentialsDAO)
+	unsuspendUserService := services.NewUnsuspendUserService(credentialsDAO)
+	listSuspendedUsersService := services.NewListSuspendedUsersService(credentialsDAO)
+	listAuditEventsService := services.NewListAuditEventsService(auditEventsDAO)
+	resetPasswordService := services.NewResetPasswordService(credentialsDAO, identityDAO, mailClient, goframework.GenerateCode, getFrontendURL(config.App.Frontend.Routes.ResetPassword), config.Mailer.Templates.PasswordReset)
+	adminLookupUserService := services.NewAdminLookupUserService(credentialsDAO, identityDAO, profileDAO, auditEventsDAO)
+	adminValidateEmailService := services.NewAdminValidateEmailService(credentialsDAO, permissionsClient)
+	adminResetPasswordService := services.NewAdminResetPasswordService(credentialsDAO, auditEventsDAO, resetPasswordService)
+	adminCancelNewEmailService := services.NewAdminCancelNewEmailService(credentialsDAO)
+	adminUpdateProfileService := services.NewAdminUpdateProfileService(profileDAO, auditEventsDAO)
+	purgeDeletedUsersService := services.NewPurgeDeletedUsersService(credentialsDAO, userDAO, permissionsClient, config.AccountDeletion.GracePeriod)
+
+	authenticator, logger := config.GetInternalAuthenticator(logger)
+	tlsConfig, err := config.GetInternalTLSConfig()
+	if err != nil {
+		logger.Fatal().Err(err).Msg("error loading internal TLS configuration")
+	}
+
+	// allow restricts a route to the given caller identities.
+	allow := func(identities []string) gin.HandlerFunc {
+		return handlers.NewInternalAuthHandler(authenticator, identities, logger).Handle
+	}
 
 	introspectTokenHandler := handlers.NewIntrospectTokenHandler(introspectTokenService)
 	rotateSecretKeysHandler := handlers.NewRotateSecretKeysHandler(rotateSecretKeysService)
+	revokeSignatureKeyHandler := handlers.NewRevokeSignatureKeyHandler(revokeSignatureKeyService)
+	getJWKSHandler := handlers.NewGetJWKSHandler(getJWKSService, config.Secrets.JWKSMaxAge)
+	revokeUserTokensHandler := handlers.NewRevokeUserTokensHandler(revokeUserTokensService)
+	pruneRevokedTokensHandler := handlers.NewPruneRevokedTokensHandler(pruneRevokedTokensService)
+	unlockAccountHandler := handlers.NewUnlockAccountHandler(unlockAccountService)
+	pruneLoginFailuresHandler := handlers.NewPruneLoginFailuresHandler(pruneLoginFailuresService)
+	pruneDataExportsHandler := handlers.NewPruneDataExportsHandler(pruneDataExportsService)
+	suspendUserHandler := handlers.NewSuspendUserHandler(suspendUserService)
+	unsuspendUserHandler := handlers.NewUnsuspendUserHandler(unsuspendUserService)
+	listSuspendedUsersHandler := handlers.NewListSuspendedUsersHandler(listSuspendedUsersService)
+	listAuditEventsHandler := handlers.NewListAuditEventsHandler(listAuditEventsService)
+	adminLookupUserHandler := handlers.NewAdminLookupUserHandler(adminLookupUserService)
+	adminValidateEmailHandler := handlers.NewAdminValidateEmailHandler(adminValidateEmailService)
+	adminResetPasswordHandler := handlers.NewAdminResetPasswordHandler(adminResetPasswordService)
+	adminCancelNewEmailHandler := handlers.NewAdminCancelNewEmailHandler(adminCancelNewEmailService)
+	adminUpdateProfileHandler := handlers.NewAdminUpdateProfileHandler(adminUpdateProfileService)
+
+	go func() {
+		ticker := time.NewTicker(config.Secrets.RotationCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			rotated, err := scheduleSecretKeysRotationService.ScheduleSecretKeysRotation(ctx, time.Now())
+			if err != nil {
+				logger.Error().Err(err).Msg("error running scheduled secret keys rotation")
+			} else if rotated {
+				logger.Info().Msg("secret keys rotated")
+			}
+
+			<-ticker.C
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(config.AccountDeletion.PurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			purged, err := purgeDeletedUsersService.PurgeDeletedUsers(ctx, time.Now())
+			if err != nil {
+				logger.Error().Err(err).Msg("error purging deleted accounts")
+			} else if purged > 0 {
+				logger.Info().Int("count", purged).Msg("deleted accounts purged")
+			}
+
+			<-ticker.C
+		}
+	}()
 
 	router := apis.GetRouter(apis.RouterConfig{
 		Logger:    logger,
 		ProjectID: config.Deploy.ProjectID,
 		Prod:      config.ENV == config.ProdENV,
+		Health: map[string]apis.HealthChecker{
+			"postgres": func() error {
+				return postgres.PingContext(ctx)
+			},
+			"permissions-client": func() error {
+				return permissionsClient.Ping(ctx)
+			},
+		},
 	})
 
-	router.GET("/auth", introspectTokenHandler.Handle)
-	router.POST("/rotate-keys", rotateSecretKeysHandler.Handle)
+	router.GET("/.well-known/jwks.json", allow(config.InternalAuth.Routes.JWKS), getJWKSHandler.Handle)
+	router.GET("/auth", allow(config.InternalAuth.Routes.Auth), introspectTokenHandler.Handle)
+	router.POST("/rotate-keys", allow(config.InternalAuth.Routes.RotateKeys), rotateSecretKeysHandler.Handle)
+	router.POST("/revoke-signature-key", allow(config.InternalAuth.Routes.RevokeSignatureKey), revokeSignatureKeyHandler.Handle)
+	router.POST("/revoke-tokens", allow(config.InternalAuth.Routes.RevokeTokens), revokeUserTokensHandler.Handle)
+	router.POST("/prune-revoked-tokens", allow(config.InternalAuth.Routes.PruneRevokedTokens), pruneRevokedTokensHandler.Handle)
+	router.POST("/unlock-account", allow(config.InternalAuth.Routes.UnlockAccount), unlockAccountHandler.Handle)
+	router.POST("/prune-login-failures", allow(config.InternalAuth.Routes.PruneLoginFailures), pruneLoginFailuresHandler.Handle)
+	router.POST("/prune-data-exports", allow(config.InternalAuth.Routes.PruneDataExports), pruneDataExportsHandler.Handle)
+	router.POST("/suspend-user", allow(config.InternalAuth.Routes.SuspendUser), suspendUserHandler.Handle)
+	router.POST("/unsuspend-user", allow(config.InternalAuth.Routes.UnsuspendUser), unsuspendUserHandler.Handle)
+	router.GET("/suspended-users", allow(config.InternalAuth.Routes.ListSuspendedUsers), listSuspendedUsersHandler.Handle)
+	router.GET("/audit-events", allow(config.InternalAuth.Routes.ListAuditEvents), listAuditEventsHandler.Handle)
+
+	admin := router.Group("/admin", allow(config.InternalAuth.Routes.Admin))
+	admin.GET("/users", adminLookupUserHandler.Handle)
+	admin.PATCH("/users/:id/email/validation", adminValidateEmailHandler.Handle)
+	admin.DELETE("/users/:id/email", adminCancelNewEmailHandler.Handle)
+	admin.DELETE("/users/:id/password", adminResetPasswordHandler.Handle)
+	admin.PATCH("/users/:id/profile", adminUpdateProfileHandler.Handle)
+
+	addr := fmt.Sprintf(":%d", config.API.PortInternal)
+
+	if tlsConfig != nil {
+		server := &http.Server{Addr: addr, Handler: router, TLSConfig: tlsConfig}
+		// Certificates are already loaded in the TLS configuration.
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		err = router.Run(addr)
+	}
 
-	if err := router.Run(fmt.Sprintf(":%d", config.API.PortInternal)); err != nil {
+	if err != nil {
 		logger.Fatal().Err(err).Msg("a fatal error occurred while running the internal API, and the server had to shut down")
 	}
 }
@@ -41,43 +41,99 @@ func main() {
 	mailSender := mail.NewEmail(config.Mailer.Sender.Name, config.Mailer.Sender.Email)
 	mailClient := sendgridproxy.NewMailer(config.Mailer.APIKey, mailSender, config.Mailer.Sandbox, logger)
 
-	secretKeysDAO, logger := config.GetSecretsRepository(logger)
+	secretKeysDAO, logger := config.GetSecretsRepository(logger, postgres)
 	credentialsDAO := dao.NewCredentialsRepository(postgres)
 	identityDAO := dao.NewIdentityRepository(postgres)
 	profileDAO := dao.NewProfileRepository(postgres)
 	userDAO := dao.NewUserRepository(postgres)
+	refreshTokensDAO := dao.NewRefreshTokensRepository(postgres)
+	revokedTokensDAO := dao.NewRevokedTokensRepository(postgres)
+	sessionsDAO := dao.NewSessionsRepository(postgres)
+	revokedSignatureKeysDAO := dao.NewRevokedSignatureKeysRepository(postgres)
+	// Revoked keys must stop being trusted right away, so they are filtered on top of the keys kept in memory.
+	secretKeysDAO = dao.NewRevocationFilteredSecretKeysRepository(secretKeysDAO, revokedSignatureKeysDAO)
+	loginFailuresDAO, logger := config.GetLoginFailuresRepository(logger, postgres)
+	totpDAO := dao.NewTOTPRepository(postgres)
+	mfaChallengesDAO := dao.NewMFAChallengesRepository(postgres)
+	passkeysDAO := dao.NewPasskeysRepository(postgres)
+	webAuthnChallengesDAO := dao.NewWebAuthnChallengesRepository(postgres)
+	loginLinksDAO := dao.NewLoginLinksRepository(postgres)
+	dataExportsDAO := dao.NewDataExportsRepository(postgres)
+	auditEventsDAO := dao.NewAuditEventsRepository(postgres)
+	breachedPasswordsDAO, logger := config.GetBreachedPasswordsRepository(logger)
 
-	generateTokenService := services.NewGenerateTokenService(secretKeysDAO, config.Tokens.TTL)
-	getTokenService := services.NewGetTokenStatusService(secretKeysDAO)
-	introspectTokenService := services.NewIntrospectTokenService(generateTokenService, getTokenService, config.Tokens.RenewDelta)
+	webAuthnRP := config.GetWebAuthnRelyingParty()
+	passwordHasher := config.GetPasswordHasher()
+
+	generateTokenService := services.NewGenerateTokenService(secretKeysDAO, sessionsDAO, config.Tokens.TTL, config.Tokens.Issuer, config.Tokens.Audience)
+	getTokenService := services.NewGetTokenStatusService(secretKeysDAO, revokedTokensDAO, credentialsDAO, config.Tokens.Issuer, config.Tokens.Audience, config.Tokens.AcceptLegacy)
+	introspectTokenService := services.NewIntrospectTokenService(generateTokenService, getTokenService, refreshTokensDAO, sessionsDAO, config.Tokens.RenewDelta, config.Tokens.LastSeenThrottle)
+	createRefreshTokenService := services.NewCreateRefreshTokenService(refreshTokensDAO, goframework.GenerateCode, config.Tokens.RefreshTTL)
+	sendSecurityAlertService := services.NewSendSecurityAlertService(credentialsDAO, identityDAO, mailClient, services.SecurityAlertTemplates{
+		services.SecurityAlertPasswordChanged:      config.Mailer.Templates.PasswordChanged,
+		services.SecurityAlertEmailChangeRequested: config.Mailer.Templates.EmailChangeRequested,
+		services.SecurityAlertNewDevice:            config.Mailer.Templates.NewDevice,
+	})
+	createSessionService := services.NewCreateSessionService(credentialsDAO, sessionsDAO, auditEventsDAO, generateTokenService, createRefreshTokenService, sendSecurityAlertService)
+	createMFAChallengeService := services.NewCreateMFAChallengeService(mfaChallengesDAO, goframework.GenerateCode, config.MFA.ChallengeTTL)
+	checkPasswordPolicyService := services.NewCheckPasswordPolicyService(breachedPasswordsDAO, config.GetPasswordPolicy())
+	checkStepUpService := services.NewCheckStepUpService(credentialsDAO, loginFailuresDAO, auditEventsDAO, passwordHasher, config.GetStepUpThresholds(), config.GetLoginThrottle())
 
 	cancelNewEmailService := services.NewCancelNewEmailService(credentialsDAO, introspectTokenService)
 	emailExistsService := services.NewEmailExistsService(credentialsDAO)
 	listService := services.NewListService(userDAO)
-	loginService := services.NewLoginService(credentialsDAO, generateTokenService)
-	previewService := services.NewPreviewService(profileDAO, identityDAO)
+	loginService := services.NewLoginService(credentialsDAO, loginFailuresDAO, totpDAO, passkeysDAO, auditEventsDAO, createSessionService, createMFAChallengeService, passwordHasher, config.GetLoginThrottle())
+	logoutService := services.NewLogoutService(revokedTokensDAO, refreshTokensDAO, introspectTokenService, config.Tokens.TTL)
+	listSessionsService := services.NewListSessionsService(sessionsDAO, introspectTokenService)
+	listSecurityActivityService := services.NewListSecurityActivityService(auditEventsDAO, introspectTokenService)
+	revokeSessionService := services.NewRevokeSessionService(sessionsDAO, refreshTokensDAO, introspectTokenService, checkStepUpService)
+	refreshTokenService := services.NewRefreshTokenService(refreshTokensDAO, credentialsDAO, generateTokenService, createRefreshTokenService)
+	previewService := services.NewPreviewService(credentialsDAO, profileDAO, identityDAO)
 	previewPrivateService := services.NewPreviewPrivateService(credentialsDAO, profileDAO, identityDAO, introspectTokenService)
-	registerService := services.NewRegisterService(credentialsDAO, profileDAO, userDAO, mailClient, goframework.GenerateCode, generateTokenService, getFrontendURL(config.App.Frontend.Routes.ValidateEmail), config.Mailer.Templates.EmailValidation)
+	registerService := services.NewRegisterService(credentialsDAO, profileDAO, userDAO, mailClient, goframework.GenerateCode, createSessionService, checkPasswordPolicyService, passwordHasher, getFrontendURL(config.App.Frontend.Routes.ValidateEmail), config.Mailer.Templates.EmailValidation)
 	resendEmailValidationService := services.NewResendEmailValidationService(credentialsDAO, identityDAO, mailClient, goframework.GenerateCode, introspectTokenService, getFrontendURL(config.App.Frontend.Routes.ValidateEmail), config.Mailer.Templates.EmailValidation)
 	resendNewEmailValidationService := services.NewResendNewEmailValidationService(credentialsDAO, identityDAO, mailClient, goframework.GenerateCode, introspectTokenService, getFrontendURL(config.App.Frontend.Routes.ValidateNewEmail), config.Mailer.Templates.EmailUpdate)
 	resetPasswordService := services.NewResetPasswordService(credentialsDAO, identityDAO, mailClient, goframework.GenerateCode, getFrontendURL(config.App.Frontend.Routes.ResetPassword), config.Mailer.Templates.PasswordReset)
 	searchService := services.NewSearchService(userDAO)
 	slugExistsService := services.NewSlugExistsService(profileDAO)
-	updateEmailService := services.NewUpdateEmailService(credentialsDAO, identityDAO, mailClient, goframework.GenerateCode, introspectTokenService, getFrontendURL(config.App.Frontend.Routes.ValidateNewEmail), config.Mailer.Templates.EmailUpdate)
+	updateEmailService := services.NewUpdateEmailService(credentialsDAO, identityDAO, mailClient, goframework.GenerateCode, introspectTokenService, checkStepUpService, sendSecurityAlertService, getFrontendURL(config.App.Frontend.Routes.ValidateNewEmail), config.Mailer.Templates.EmailUpdate, getFrontendURL(config.App.Frontend.Routes.ReportEmailChange))
 	updateIdentityService := services.NewUpdateIdentityService(identityDAO, introspectTokenService)
-	updatePasswordService := services.NewUpdatePasswordService(credentialsDAO)
+	updatePasswordService := services.NewUpdatePasswordService(credentialsDAO, identityDAO, profileDAO, loginFailuresDAO, auditEventsDAO, checkPasswordPolicyService, sendSecurityAlertService, passwordHasher, config.ValidationCodes.PasswordResetTTL, config.GetLoginThrottle())
 	updateProfileService := services.NewUpdateProfileService(profileDAO, introspectTokenService)
-	validateEmailService := services.NewValidateEmailService(credentialsDAO, permissionsClient)
-	validateNewEmailService := services.NewValidateNewEmailService(credentialsDAO, permissionsClient)
+	validateEmailService := services.NewValidateEmailService(credentialsDAO, permissionsClient, config.ValidationCodes.EmailValidationTTL)
+	validateNewEmailService := services.NewValidateNewEmailService(credentialsDAO, permissionsClient, config.ValidationCodes.EmailUpdateTTL)
+	reportEmailChangeService := services.NewReportEmailChangeService(credentialsDAO, resetPasswordService)
+	deleteAccountService := services.NewDeleteAccountService(credentialsDAO, identityDAO, mailClient, goframework.GenerateCode, introspectTokenService, checkStepUpService, getFrontendURL(config.App.Frontend.Routes.CancelAccountDeletion), config.Mailer.Templates.AccountDeleted)
+	cancelAccountDeletionService := services.NewCancelAccountDeletionService(credentialsDAO, config.AccountDeletion.GracePeriod)
+	exportUserDataService := services.NewExportUserDataService(credentialsDAO, identityDAO, profileDAO, sessionsDAO, loginFailuresDAO, dataExportsDAO, mailClient, goframework.GenerateCode, introspectTokenService, config.DataExport.InlineThreshold, config.DataExport.TTL, getFrontendURL(config.App.Frontend.Routes.DownloadDataExport), config.Mailer.Templates.DataExportReady)
+	downloadDataExportService := services.NewDownloadDataExportService(dataExportsDAO)
 	getCredentialsService := services.NewGetCredentialsService(credentialsDAO, introspectTokenService)
 	getIdentityService := services.NewGetIdentityService(identityDAO, introspectTokenService)
 	getProfileService := services.NewGetProfileService(profileDAO, introspectTokenService)
+	getJWKSService := services.NewGetJWKSService(secretKeysDAO)
+	enrollTOTPService := services.NewEnrollTOTPService(totpDAO, credentialsDAO, services.GenerateTOTPSecret, services.GenerateRecoveryCode, introspectTokenService, checkStepUpService, config.MFA.Issuer)
+	confirmTOTPService := services.NewConfirmTOTPService(totpDAO, introspectTokenService)
+	disableTOTPService := services.NewDisableTOTPService(totpDAO, credentialsDAO, loginFailuresDAO, auditEventsDAO, introspectTokenService, checkStepUpService, config.GetLoginThrottle())
+	verifyMFAService := services.NewVerifyMFAService(mfaChallengesDAO, totpDAO, credentialsDAO, loginFailuresDAO, auditEventsDAO, createSessionService, config.GetLoginThrottle())
+	beginPasskeyRegistrationService := services.NewBeginPasskeyRegistrationService(webAuthnChallengesDAO, passkeysDAO, credentialsDAO, introspectTokenService, checkStepUpService, webAuthnRP)
+	finishPasskeyRegistrationService := services.NewFinishPasskeyRegistrationService(webAuthnChallengesDAO, passkeysDAO, introspectTokenService, webAuthnRP)
+	beginPasskeyLoginService := services.NewBeginPasskeyLoginService(webAuthnChallengesDAO, webAuthnRP)
+	finishPasskeyLoginService := services.NewFinishPasskeyLoginService(webAuthnChallengesDAO, passkeysDAO, createSessionService, webAuthnRP)
+	beginPasskeyMFAService := services.NewBeginPasskeyMFAService(mfaChallengesDAO, webAuthnChallengesDAO, passkeysDAO, credentialsDAO, loginFailuresDAO, webAuthnRP, config.GetLoginThrottle())
+	verifyMFAPasskeyService := services.NewVerifyMFAPasskeyService(mfaChallengesDAO, webAuthnChallengesDAO, passkeysDAO, credentialsDAO, loginFailuresDAO, auditEventsDAO, createSessionService, webAuthnRP, config.GetLoginThrottle())
+	sendLoginLinkService := services.NewSendLoginLinkService(credentialsDAO, identityDAO, loginLinksDAO, mailClient, goframework.GenerateCode, config.Login.LinkTTL, getFrontendURL(config.App.Frontend.Routes.LoginLink), config.Mailer.Templates.LoginLink)
+	consumeLoginLinkService := services.NewConsumeLoginLinkService(loginLinksDAO, totpDAO, passkeysDAO, createSessionService, createMFAChallengeService)
 
 	introspectTokenHandler := handlers.NewIntrospectTokenHandler(introspectTokenService)
 	cancelNewEmailHandler := handlers.NewCancelNewEmailHandler(cancelNewEmailService)
 	emailExistsHandler := handlers.NewEmailExistsHandler(emailExistsService)
 	listHandler := handlers.NewListHandler(listService)
 	loginHandler := handlers.NewLoginHandler(loginService)
+	logoutHandler := handlers.NewLogoutHandler(logoutService)
+	listSessionsHandler := handlers.NewListSessionsHandler(listSessionsService)
+	listSecurityActivityHandler := handlers.NewListSecurityActivityHandler(listSecurityActivityService)
+	revokeSessionHandler := handlers.NewRevokeSessionHandler(revokeSessionService)
+	refreshTokenHandler := handlers.NewRefreshTokenHandler(refreshTokenService)
 	previewHandler := handlers.NewPreviewHandler(previewService)
 	previewPrivateHandler := handlers.NewPreviewPrivateHandler(previewPrivateService)
 	registerHandler := handlers.NewRegisterHandler(registerService)
@@ -92,9 +148,27 @@ func main() {
 	updateProfileHandler := handlers.NewUpdateProfileHandler(updateProfileService)
 	validateEmailHandler := handlers.NewValidateEmailHandler(validateEmailService)
 	validateNewEmailHandler := handlers.NewValidateNewEmailHandler(validateNewEmailService)
+	reportEmailChangeHandler := handlers.NewReportEmailChangeHandler(reportEmailChangeService)
+	deleteAccountHandler := handlers.NewDeleteAccountHandler(deleteAccountService)
+	cancelAccountDeletionHandler := handlers.NewCancelAccountDeletionHandler(cancelAccountDeletionService)
+	exportUserDataHandler := handlers.NewExportUserDataHandler(exportUserDataService)
+	downloadDataExportHandler := handlers.NewDownloadDataExportHandler(downloadDataExportService)
 	getCredentialsHandler := handlers.NewGetCredentialsHandler(getCredentialsService)
 	getIdentityHandler := handlers.NewGetIdentityHandler(getIdentityService)
 	getProfileHandler := handlers.NewGetProfileHandler(getProfileService)
+	getJWKSHandler := handlers.NewGetJWKSHandler(getJWKSService, config.Secrets.JWKSMaxAge)
+	enrollTOTPHandler := handlers.NewEnrollTOTPHandler(enrollTOTPService)
+	confirmTOTPHandler := handlers.NewConfirmTOTPHandler(confirmTOTPService)
+	disableTOTPHandler := handlers.NewDisableTOTPHandler(disableTOTPService)
+	verifyMFAHandler := handlers.NewVerifyMFAHandler(verifyMFAService)
+	beginPasskeyRegistrationHandler := handlers.NewBeginPasskeyRegistrationHandler(beginPasskeyRegistrationService)
+	finishPasskeyRegistrationHandler := handlers.NewFinishPasskeyRegistrationHandler(finishPasskeyRegistrationService)
+	beginPasskeyLoginHandler := handlers.NewBeginPasskeyLoginHandler(beginPasskeyLoginService)
+	finishPasskeyLoginHandler := handlers.NewFinishPasskeyLoginHandler(finishPasskeyLoginService)
+	beginPasskeyMFAHandler := handlers.NewBeginPasskeyMFAHandler(beginPasskeyMFAService)
+	verifyMFAPasskeyHandler := handlers.NewVerifyMFAPasskeyHandler(verifyMFAPasskeyService)
+	sendLoginLinkHandler := handlers.NewSendLoginLinkHandler(sendLoginLinkService)
+	consumeLoginLinkHandler := handlers.NewConsumeLoginLinkHandler(consumeLoginLinkService)
 
 	router := apis.GetRouter(apis.RouterConfig{
 		Logger:    logger,
@@ -111,10 +185,33 @@ func main() {
 		},
 	})
 
+	// /.well-known
+	router.GET("/.well-known/jwks.json", getJWKSHandler.Handle)
 	// /auth
 	router.GET("/auth", introspectTokenHandler.Handle)
 	router.POST("/auth", loginHandler.Handle)
 	router.PUT("/auth", registerHandler.Handle)
+	router.DELETE("/auth", logoutHandler.Handle)
+	router.POST("/auth/refresh", refreshTokenHandler.Handle)
+	router.POST("/auth/mfa", verifyMFAHandler.Handle)
+	router.POST("/auth/passkey/options", beginPasskeyLoginHandler.Handle)
+	router.POST("/auth/passkey", finishPasskeyLoginHandler.Handle)
+	router.POST("/auth/mfa/passkey/options", beginPasskeyMFAHandler.Handle)
+	router.POST("/auth/mfa/passkey", verifyMFAPasskeyHandler.Handle)
+	router.POST("/auth/link", sendLoginLinkHandler.Handle)
+	router.POST("/auth/link/consume", consumeLoginLinkHandler.Handle)
+	// /mfa/totp
+	router.PUT("/mfa/totp", enrollTOTPHandler.Handle)
+	router.PATCH("/mfa/totp", confirmTOTPHandler.Handle)
+	router.DELETE("/mfa/totp", disableTOTPHandler.Handle)
+	// /passkeys
+	router.POST("/passkeys/options", beginPasskeyRegistrationHandler.Handle)
+	router.PUT("/passkeys", finishPasskeyRegistrationHandler.Handle)
+	// /sessions
+	router.GET("/sessions", listSessionsHandler.Handle)
+	router.DELETE("/sessions/:id", revokeSessionHandler.Handle)
+	// /security-activity
+	router.GET("/security-activity", listSecurityActivityHandler.Handle)
 	// /email
 	router.DELETE("/email", cancelNewEmailHandler.Handle)
 	router.PATCH("/email", updateEmailHandler.Handle)
@@ -135,6 +232,7 @@ func main() {
 	// //email/pending/validation
 	router.PATCH("/email/pending/validation", resendNewEmailValidationHandler.Handle)
 	router.GET("/email/pending/validation", validateNewEmailHandler.Handle)
+	router.GET("/email/pending/report", reportEmailChangeHandler.Handle)
 	// /email/exists
 	router.GET("/email/exists", emailExistsHandler.Handle)
 	// /slug/exists
@@ -147,6 +245,13 @@ func main() {
 	router.GET("/user", previewHandler.Handle)
 	// /user/me
 	router.GET("/user/me", previewPrivateHandler.Handle)
+	router.DELETE("/user/me", deleteAccountHandler.Handle)
+	// /user/deletion/cancel
+	router.GET("/user/deletion/cancel", cancelAccountDeletionHandler.Handle)
+	// /user/me/export
+	router.GET("/user/me/export", exportUserDataHandler.Handle)
+	// /user/export/download
+	router.GET("/user/export/download", downloadDataExportHandler.Handle)
 
 	if err := router.Run(fmt.Sprintf(":%d", config.API.Port)); err != nil {
 		logger.Fatal().Err(err).Msg("a fatal error occurred while running the API, and the server had to shut down")
@@ -0,0 +1,29 @@
+package config
+
+import (
+	_ "embed"
+	"log"
+	"time"
+)
+
+//go:embed account-deletion.yml
+var accountDeletionFile []byte
+
+type AccountDeletionConfig struct {
+	// GracePeriod is the time a user has to cancel the deletion of their account, before it is purged.
+	GracePeriod time.Duration `yaml:"gracePeriod"`
+	// PurgeInterval is the delay between two purges of the accounts whose grace period is over.
+	PurgeInterval time.Duration `yaml:"purgeInterval"`
+}
+
+var AccountDeletion *AccountDeletionConfig
+
+func init() {
+	cfg := new(AccountDeletionConfig)
+
+	if err := loadEnv(EnvLoader{DefaultENV: accountDeletionFile}, cfg); err != nil {
+		log.Fatalf("error loading account deletion configuration: %v\n", err)
+	}
+
+	AccountDeletion = cfg
+}
@@ -19,9 +19,13 @@ type AppConfig struct {
 	Frontend struct {
 		URLs   []string `yaml:"urls"`
 		Routes struct {
-			ValidateEmail    string `yaml:"validateEmail"`
-			ValidateNewEmail string `yaml:"validateNewEmail"`
-			ResetPassword    string `yaml:"resetPassword"`
+			ValidateEmail         string `yaml:"validateEmail"`
+			ValidateNewEmail      string `yaml:"validateNewEmail"`
+			ResetPassword         string `yaml:"resetPassword"`
+			LoginLink             string `yaml:"loginLink"`
+			ReportEmailChange     string `yaml:"reportEmailChange"`
+			CancelAccountDeletion string `yaml:"cancelAccountDeletion"`
+			DownloadDataExport    string `yaml:"downloadDataExport"`
 		} `yaml:"routes"`
 	} `yaml:"frontend"`
 }
@@ -0,0 +1,30 @@
+package config
+
+import (
+	_ "embed"
+	"log"
+	"time"
+)
+
+//go:embed data-export.yml
+var dataExportFile []byte
+
+type DataExportConfig struct {
+	// InlineThreshold is the maximum number of records an archive can hold to be returned directly. Larger archives
+	// are built in the background, and sent by email.
+	InlineThreshold int `yaml:"inlineThreshold"`
+	// TTL is the time during which an archive sent by email can be downloaded.
+	TTL time.Duration `yaml:"ttl"`
+}
+
+var DataExport *DataExportConfig
+
+func init() {
+	cfg := new(DataExportConfig)
+
+	if err := loadEnv(EnvLoader{DefaultENV: dataExportFile}, cfg); err != nil {
+		log.Fatalf("error loading data export configuration: %v\n", err)
+	}
+
+	DataExport = cfg
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	_ "embed"
+	"fmt"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/rs/zerolog"
+	"log"
+	"os"
+)
+
+//go:embed internal-auth-dev.yml
+var internalAuthDevFile []byte
+
+//go:embed internal-auth-prod.yml
+var internalAuthProdFile []byte
+
+const (
+	// InternalAuthModeSecret authenticates callers with a shared secret, sent in a header.
+	InternalAuthModeSecret = "secret"
+	// InternalAuthModeMTLS authenticates callers with a client certificate.
+	InternalAuthModeMTLS = "mtls"
+)
+
+type InternalAuthConfig struct {
+	Mode string `yaml:"mode"`
+	// Header carries the secret, in secret mode.
+	Header string `yaml:"header"`
+	// Callers maps the identity of each caller to its secret, in secret mode.
+	Callers map[string]string `yaml:"callers"`
+	// TLS holds the paths to the PEM files used in mtls mode.
+	TLS struct {
+		// ClientCA is the certificate authority that signs the client certificates.
+		ClientCA string `yaml:"clientCA"`
+		Cert     string `yaml:"cert"`
+		Key      string `yaml:"key"`
+	} `yaml:"tls"`
+	// Routes lists the identities allowed to call each route.
+	Routes struct {
+		JWKS               []string `yaml:"jwks"`
+		Auth               []string `yaml:"auth"`
+		RotateKeys         []string `yaml:"rotateKeys"`
+		RevokeSignatureKey []string `yaml:"revokeSignatureKey"`
+		RevokeTokens       []string `yaml:"revokeTokens"`
+		PruneRevokedTokens []string `yaml:"pruneRevokedTokens"`
+		UnlockAccount      []string `yaml:"unlockAccount"`
+		PruneLoginFailures []string `yaml:"pruneLoginFailures"`
+		PruneDataExports   []string `yaml:"pruneDataExports"`
+		SuspendUser        []string `yaml:"suspendUser"`
+		UnsuspendUser      []string `yaml:"unsuspendUser"`
+		ListSuspendedUsers []string `yaml:"listSuspendedUsers"`
+		ListAuditEvents    []string `yaml:"listAuditEvents"`
+		// Admin guards every route of the admin router, used by support staff to manage users.
+		Admin []string `yaml:"admin"`
+	} `yaml:"routes"`
+}
+
+var InternalAuth *InternalAuthConfig
+
+func init() {
+	cfg := new(InternalAuthConfig)
+
+	if err := loadEnv(EnvLoader{DevENV: internalAuthDevFile, ProdENV: internalAuthProdFile}, cfg); err != nil {
+		log.Fatalf("error loading internal auth configuration: %v\n", err)
+	}
+
+	InternalAuth = cfg
+}
+
+// GetInternalAuthenticator returns the authenticator for the configured mode. In mtls mode, the server must also use
+// the configuration returned by GetInternalTLSConfig, so client certificates are verified.
+func GetInternalAuthenticator(logger zerolog.Logger) (handlers.InternalAuthenticator, zerolog.Logger) {
+	switch InternalAuth.Mode {
+	case InternalAuthModeSecret:
+		logger = logger.With().
+			Dict("internal_auth", zerolog.Dict().Str("mode", InternalAuth.Mode).Str("header", InternalAuth.Header)).
+			Logger()
+
+		return handlers.NewSharedSecretAuthenticator(InternalAuth.Header, InternalAuth.Callers), logger
+	case InternalAuthModeMTLS:
+		logger = logger.With().
+			Dict("internal_auth", zerolog.Dict().Str("mode", InternalAuth.Mode).Str("client_ca", InternalAuth.TLS.ClientCA)).
+			Logger()
+
+		return handlers.NewMutualTLSAuthenticator(), logger
+	default:
+		logger.Fatal().Str("mode", InternalAuth.Mode).Msg("unknown internal auth mode")
+		return nil, logger
+	}
+}
+
+// GetInternalTLSConfig returns the TLS configuration of the internal server, or nil if TLS is not required by the
+// authentication mode.
+//
+// Client certificates are only verified when provided, so health checks keep working without one. Authenticated
+// routes reject calls without a verified certificate.
+func GetInternalTLSConfig() (*tls.Config, error) {
+	if InternalAuth.Mode != InternalAuthModeMTLS {
+		return nil, nil
+	}
+
+	caData, err := os.ReadFile(InternalAuth.TLS.ClientCA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA %q: %w", InternalAuth.TLS.ClientCA, err)
+	}
+
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caData) {
+		return nil, fmt.Errorf("no certificate found in client CA %q", InternalAuth.TLS.ClientCA)
+	}
+
+	cert, err := tls.LoadX509KeyPair(InternalAuth.TLS.Cert, InternalAuth.TLS.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
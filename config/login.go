@@ -0,0 +1,77 @@
+package config
+
+import (
+	_ "embed"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/rs/zerolog"
+	"github.com/uptrace/bun"
+	"log"
+	"time"
+)
+
+//go:embed login.yml
+var loginFile []byte
+
+const (
+	// LoginFailuresStoragePostgres shares the failed attempts between every instance.
+	LoginFailuresStoragePostgres = "postgres"
+	// LoginFailuresStorageMemory keeps the failed attempts in each instance.
+	LoginFailuresStorageMemory = "memory"
+)
+
+type LoginConfig struct {
+	// Storage is the backend that stores failed attempts. When empty, attempts are stored in Postgres.
+	Storage       string        `yaml:"storage"`
+	FreeFailures  int           `yaml:"freeFailures"`
+	BaseLockout   time.Duration `yaml:"baseLockout"`
+	MaxLockout    time.Duration `yaml:"maxLockout"`
+	AccountWindow time.Duration `yaml:"accountWindow"`
+	IPMaxFailures int           `yaml:"ipMaxFailures"`
+	IPWindow      time.Duration `yaml:"ipWindow"`
+	// LinkTTL is the time a login link, sent by email, remains valid.
+	LinkTTL time.Duration `yaml:"linkTTL"`
+}
+
+var Login *LoginConfig
+
+func init() {
+	cfg := new(LoginConfig)
+
+	if err := loadEnv(EnvLoader{DefaultENV: loginFile}, cfg); err != nil {
+		log.Fatalf("error loading login configuration: %v\n", err)
+	}
+
+	Login = cfg
+}
+
+// GetLoginThrottle returns the limits applied to failed login attempts.
+func GetLoginThrottle() services.LoginThrottle {
+	return services.LoginThrottle{
+		FreeFailures:  Login.FreeFailures,
+		BaseLockout:   Login.BaseLockout,
+		MaxLockout:    Login.MaxLockout,
+		AccountWindow: Login.AccountWindow,
+		IPMaxFailures: Login.IPMaxFailures,
+		IPWindow:      Login.IPWindow,
+	}
+}
+
+func GetLoginFailuresRepository(logger zerolog.Logger, db bun.IDB) (dao.LoginFailuresRepository, zerolog.Logger) {
+	storage := Login.Storage
+	if storage == "" {
+		storage = LoginFailuresStoragePostgres
+	}
+
+	logger = logger.With().Dict("login_failures", zerolog.Dict().Str("storage", storage)).Logger()
+
+	switch storage {
+	case LoginFailuresStoragePostgres:
+		return dao.NewLoginFailuresRepository(db), logger
+	case LoginFailuresStorageMemory:
+		return dao.NewMemoryLoginFailuresRepository(GetLoginThrottle().Retention()), logger
+	default:
+		logger.Fatal().Str("storage", storage).Msg("unknown login failures storage")
+		return nil, logger
+	}
+}
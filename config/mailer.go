@@ -19,9 +19,15 @@ type MailerConfig struct {
 		Name  string `yaml:"name"`
 	} `yaml:"sender"`
 	Templates struct {
-		EmailValidation string `yaml:"emailValidation"`
-		EmailUpdate     string `yaml:"emailUpdate"`
-		PasswordReset   string `yaml:"passwordReset"`
+		EmailValidation      string `yaml:"emailValidation"`
+		EmailUpdate          string `yaml:"emailUpdate"`
+		PasswordReset        string `yaml:"passwordReset"`
+		LoginLink            string `yaml:"loginLink"`
+		PasswordChanged      string `yaml:"passwordChanged"`
+		EmailChangeRequested string `yaml:"emailChangeRequested"`
+		NewDevice            string `yaml:"newDevice"`
+		AccountDeleted       string `yaml:"accountDeleted"`
+		DataExportReady      string `yaml:"dataExportReady"`
 	} `yaml:"templates"`
 }
 
@@ -0,0 +1,28 @@
+package config
+
+import (
+	_ "embed"
+	"log"
+	"time"
+)
+
+//go:embed mfa.yml
+var mfaFile []byte
+
+type MFAConfig struct {
+	// Issuer is the name shown next to the account in authenticator apps.
+	Issuer       string        `yaml:"issuer"`
+	ChallengeTTL time.Duration `yaml:"challengeTTL"`
+}
+
+var MFA *MFAConfig
+
+func init() {
+	cfg := new(MFAConfig)
+
+	if err := loadEnv(EnvLoader{DefaultENV: mfaFile}, cfg); err != nil {
+		log.Fatalf("error loading mfa configuration: %v\n", err)
+	}
+
+	MFA = cfg
+}
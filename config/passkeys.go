@@ -0,0 +1,49 @@
+package config
+
+import (
+	_ "embed"
+	"github.com/a-novel/auth-service/pkg/services"
+	"log"
+	"time"
+)
+
+//go:embed passkeys.yml
+var passkeysFile []byte
+
+//go:embed passkeys-dev.yml
+var passkeysDevFile []byte
+
+//go:embed passkeys-prod.yml
+var passkeysProdFile []byte
+
+type PasskeysConfig struct {
+	// RPID is the domain passkeys are bound to. The frontend URLs must belong to this domain.
+	RPID string `yaml:"rpID"`
+	// Name is the name of the service, as shown by authenticators.
+	Name         string        `yaml:"name"`
+	ChallengeTTL time.Duration `yaml:"challengeTTL"`
+}
+
+var Passkeys *PasskeysConfig
+
+func init() {
+	cfg := new(PasskeysConfig)
+
+	loader := EnvLoader{DefaultENV: passkeysFile, DevENV: passkeysDevFile, ProdENV: passkeysProdFile}
+	if err := loadEnv(loader, cfg); err != nil {
+		log.Fatalf("error loading passkeys configuration: %v\n", err)
+	}
+
+	Passkeys = cfg
+}
+
+// GetWebAuthnRelyingParty returns the relying party used in passkey ceremonies. Passkeys can only be used from the
+// frontend URLs.
+func GetWebAuthnRelyingParty() services.WebAuthnRelyingParty {
+	return services.WebAuthnRelyingParty{
+		ID:           Passkeys.RPID,
+		Name:         Passkeys.Name,
+		Origins:      App.Frontend.URLs,
+		ChallengeTTL: Passkeys.ChallengeTTL,
+	}
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	_ "embed"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/rs/zerolog"
+	"log"
+	"os"
+)
+
+//go:embed passwords.yml
+var passwordsFile []byte
+
+type PasswordsConfig struct {
+	MinLength        int  `yaml:"minLength"`
+	MaxLength        int  `yaml:"maxLength"`
+	RequireLowercase bool `yaml:"requireLowercase"`
+	RequireUppercase bool `yaml:"requireUppercase"`
+	RequireDigit     bool `yaml:"requireDigit"`
+	RequireSymbol    bool `yaml:"requireSymbol"`
+	MinStrength      int  `yaml:"minStrength"`
+	RejectUserInfo   bool `yaml:"rejectUserInfo"`
+	// BreachedPasswordsDir holds the breached passwords range files. When empty, the check is disabled.
+	BreachedPasswordsDir string `yaml:"breachedPasswordsDir"`
+	Hashing              struct {
+		Algorithm       string `yaml:"algorithm"`
+		BcryptCost      int    `yaml:"bcryptCost"`
+		Argon2idMemory  uint32 `yaml:"argon2idMemory"`
+		Argon2idTime    uint32 `yaml:"argon2idTime"`
+		Argon2idThreads uint8  `yaml:"argon2idThreads"`
+	} `yaml:"hashing"`
+}
+
+var Passwords *PasswordsConfig
+
+func init() {
+	cfg := new(PasswordsConfig)
+
+	if err := loadEnv(EnvLoader{DefaultENV: passwordsFile}, cfg); err != nil {
+		log.Fatalf("error loading passwords configuration: %v\n", err)
+	}
+
+	switch cfg.Hashing.Algorithm {
+	case services.PasswordAlgorithmArgon2id, services.PasswordAlgorithmBcrypt:
+	default:
+		log.Fatalf("error loading passwords configuration: unknown hashing algorithm %q\n", cfg.Hashing.Algorithm)
+	}
+
+	Passwords = cfg
+}
+
+// GetPasswordPolicy returns the rules new passwords must follow.
+func GetPasswordPolicy() services.PasswordPolicy {
+	return services.PasswordPolicy{
+		MinLength:        Passwords.MinLength,
+		MaxLength:        Passwords.MaxLength,
+		RequireLowercase: Passwords.RequireLowercase,
+		RequireUppercase: Passwords.RequireUppercase,
+		RequireDigit:     Passwords.RequireDigit,
+		RequireSymbol:    Passwords.RequireSymbol,
+		MinStrength:      Passwords.MinStrength,
+		RejectUserInfo:   Passwords.RejectUserInfo,
+	}
+}
+
+// GetPasswordHasher returns the hasher used for new passwords, and to verify existing ones.
+func GetPasswordHasher() services.PasswordHasher {
+	return services.NewPasswordHasher(services.PasswordHashing{
+		Algorithm:       Passwords.Hashing.Algorithm,
+		BcryptCost:      Passwords.Hashing.BcryptCost,
+		Argon2idMemory:  Passwords.Hashing.Argon2idMemory,
+		Argon2idTime:    Passwords.Hashing.Argon2idTime,
+		Argon2idThreads: Passwords.Hashing.Argon2idThreads,
+	})
+}
+
+// GetBreachedPasswordsRepository returns the list of breached passwords, or nil if the check is disabled.
+func GetBreachedPasswordsRepository(logger zerolog.Logger) (dao.BreachedPasswordsRepository, zerolog.Logger) {
+	dir := Passwords.BreachedPasswordsDir
+
+	logger = logger.With().Dict("breached_passwords", zerolog.Dict().Bool("enabled", dir != "")).Logger()
+
+	if dir == "" {
+		return nil, logger
+	}
+
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		logger.Fatal().Err(err).Str("dir", dir).Msg("breached passwords directory is not readable")
+		return nil, logger
+	}
+
+	return dao.NewBreachedPasswordsRepository(os.DirFS(dir)), logger
+}
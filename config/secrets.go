@@ -1,11 +1,14 @@
 package config
 
 import (
-	"cloud.google.com/go/storage"
+	gcs "cloud.google.com/go/storage"
 	"context"
 	_ "embed"
+	"encoding/base64"
 	"github.com/a-novel/auth-service/pkg/dao"
 	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
 	"log"
 	"os"
 	"path"
@@ -15,10 +18,34 @@ import (
 //go:embed secrets.yml
 var secretsFile []byte
 
+const (
+	// SecretsStorageFileSystem stores the keys in the .secrets directory. It is meant for development.
+	SecretsStorageFileSystem = "filesystem"
+	// SecretsStorageGCS stores the keys in a Google Cloud Storage bucket.
+	SecretsStorageGCS = "gcs"
+	// SecretsStoragePostgres stores the keys in the main database, encrypted with the master key.
+	SecretsStoragePostgres = "postgres"
+)
+
 type SecretsConfig struct {
+	// Storage is the backend that stores the keys. When empty, keys are stored on GCS in production, and on the file
+	// system otherwise.
+	Storage string `yaml:"storage"`
+	// MasterKey is the base64 encoded, 32 bytes long, key used to encrypt the keys in Postgres.
+	MasterKey      string        `yaml:"masterKey"`
 	Prefix         string        `yaml:"prefix"`
 	Backups        int           `yaml:"backups"`
 	UpdateInterval time.Duration `yaml:"updateInterval"`
+	// RotationInterval is the age from which the most recent key is replaced by the rotation scheduler.
+	RotationInterval time.Duration `yaml:"rotationInterval"`
+	// RotationCheckInterval is how often the rotation scheduler checks the age of the keys.
+	RotationCheckInterval time.Duration `yaml:"rotationCheckInterval"`
+	// ActivationDelay is the time between the publication of a new key, and its use for signing.
+	ActivationDelay time.Duration `yaml:"activationDelay"`
+	// RefreshCooldown is the minimum delay between 2 forced reloads of the keys, triggered by unknown key IDs.
+	RefreshCooldown time.Duration `yaml:"refreshCooldown"`
+	// JWKSMaxAge is how long clients may cache the published key set.
+	JWKSMaxAge time.Duration `yaml:"jwksMaxAge"`
 }
 
 var Secrets *SecretsConfig
@@ -33,43 +60,76 @@ func init() {
 	Secrets = cfg
 }
 
-func GetSecretsRepository(logger zerolog.Logger) (dao.SecretKeysRepository, zerolog.Logger) {
-	if ENV == ProdENV {
-		client, err := storage.NewClient(context.Background())
+func GetSecretsRepository(logger zerolog.Logger, db bun.IDB) (dao.SecretKeysRepository, zerolog.Logger) {
+	storage := Secrets.Storage
+	if storage == "" {
+		storage = lo.Ternary(ENV == ProdENV, SecretsStorageGCS, SecretsStorageFileSystem)
+	}
+
+	secretsLogger := zerolog.Dict().
+		Int("backups", Secrets.Backups).
+		Dur("update_interval", Secrets.UpdateInterval).
+		Dur("refresh_cooldown", Secrets.RefreshCooldown).
+		Dur("rotation_interval", Secrets.RotationInterval).
+		Dur("activation_delay", Secrets.ActivationDelay)
+
+	switch storage {
+	case SecretsStorageGCS:
+		client, err := gcs.NewClient(context.Background())
 		if err != nil {
 			logger.Fatal().Err(err).Msg("error initializing GCP client")
 		}
 
+		logger = logger.With().Dict("secrets_manager", secretsLogger.Str("type", "GCP Datastore")).Logger()
+
+		return cacheSecretsRepository(dao.NewGoogleDatastoreSecretKeysRepository(client.Bucket(Deploy.Buckets.SecretKeys)), logger), logger
+	case SecretsStoragePostgres:
+		masterKey, err := base64.StdEncoding.DecodeString(Secrets.MasterKey)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("error decoding secrets master key")
+		}
+
+		repository, err := dao.NewPostgresSecretKeysRepository(db, masterKey)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("error initializing postgres secrets repository")
+		}
+
+		logger = logger.With().Dict("secrets_manager", secretsLogger.Str("type", "postgres")).Logger()
+
+		return cacheSecretsRepository(repository, logger), logger
+	case SecretsStorageFileSystem:
+		wd, err := os.Getwd()
+		if err != nil {
+			logger.Fatal().Err(err).Msg("error retrieving working directory")
+		}
+
+		keysPath := path.Join(wd, ".secrets")
 		logger = logger.With().
 			Dict(
 				"secrets_manager",
-				zerolog.Dict().
-					Int("backups", Secrets.Backups).
-					Dur("update_interval", Secrets.UpdateInterval).
-					Str("type", "GCP Datastore"),
+				secretsLogger.
+					Str("type", "local storage").
+					Str("path", keysPath).
+					Str("prefix", Secrets.Prefix),
 			).
 			Logger()
 
-		return dao.NewGoogleDatastoreSecretKeysRepository(client.Bucket(Deploy.Buckets.SecretKeys)), logger
-	}
-
-	wd, err := os.Getwd()
-	if err != nil {
-		logger.Fatal().Err(err).Msg("error retrieving working directory")
+		return cacheSecretsRepository(dao.NewFileSystemSecretKeysRepository(keysPath, Secrets.Prefix), logger), logger
+	default:
+		logger.Fatal().Str("storage", storage).Msg("unknown secrets storage")
+		return nil, logger
 	}
+}
 
-	keysPath := path.Join(wd, ".secrets")
-	logger = logger.With().
-		Dict(
-			"secrets_manager",
-			zerolog.Dict().
-				Int("backups", Secrets.Backups).
-				Dur("update_interval", Secrets.UpdateInterval).
-				Str("type", "local storage").
-				Str("path", keysPath).
-				Str("prefix", Secrets.Prefix),
-		).
-		Logger()
-
-	return dao.NewFileSystemSecretKeysRepository(keysPath, Secrets.Prefix), logger
+// cacheSecretsRepository keeps the keys in memory for the lifetime of the process.
+func cacheSecretsRepository(source dao.SecretKeysRepository, logger zerolog.Logger) dao.SecretKeysRepository {
+	return dao.NewCachedSecretKeysRepository(
+		context.Background(),
+		source,
+		Secrets.UpdateInterval,
+		Secrets.RefreshCooldown,
+		func(err error) {
+			logger.Error().Err(err).Msg("error refreshing secret keys, serving the previous keys")
+		},
+	)
 }
@@ -0,0 +1,42 @@
+package config
+
+import (
+	_ "embed"
+	"github.com/a-novel/auth-service/pkg/services"
+	"log"
+	"time"
+)
+
+//go:embed step-up.yml
+var stepUpFile []byte
+
+type StepUpConfig struct {
+	// UpdateEmail is the delay to request a new email.
+	UpdateEmail time.Duration `yaml:"updateEmail"`
+	// UpdateSecondFactor is the delay to enable or disable two-factor authentication, or to register a passkey.
+	UpdateSecondFactor time.Duration `yaml:"updateSecondFactor"`
+	// RevokeSession is the delay to end a session from another device.
+	RevokeSession time.Duration `yaml:"revokeSession"`
+}
+
+var StepUp *StepUpConfig
+
+func init() {
+	cfg := new(StepUpConfig)
+
+	if err := loadEnv(EnvLoader{DefaultENV: stepUpFile}, cfg); err != nil {
+		log.Fatalf("error loading step-up configuration: %v\n", err)
+	}
+
+	StepUp = cfg
+}
+
+// GetStepUpThresholds returns the delay, after authentication, during which each sensitive operation can be
+// performed without the password.
+func GetStepUpThresholds() services.StepUpThresholds {
+	return services.StepUpThresholds{
+		services.SensitiveOperationUpdateEmail:        StepUp.UpdateEmail,
+		services.SensitiveOperationUpdateSecondFactor: StepUp.UpdateSecondFactor,
+		services.SensitiveOperationRevokeSession:      StepUp.RevokeSession,
+	}
+}
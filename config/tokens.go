@@ -10,8 +10,14 @@ import (
 var tokensFile []byte
 
 type TokensConfig struct {
-	TTL        time.Duration `yaml:"ttl"`
-	RenewDelta time.Duration `yaml:"renewDelta"`
+	TTL              time.Duration `yaml:"ttl"`
+	RenewDelta       time.Duration `yaml:"renewDelta"`
+	RefreshTTL       time.Duration `yaml:"refreshTTL"`
+	LastSeenThrottle time.Duration `yaml:"lastSeenThrottle"`
+	Issuer           string        `yaml:"issuer"`
+	Audience         string        `yaml:"audience"`
+	// AcceptLegacy keeps accepting tokens issued in the format used before JWT compliance, until they expire.
+	AcceptLegacy bool `yaml:"acceptLegacy"`
 }
 
 var Tokens *TokensConfig
@@ -0,0 +1,31 @@
+package config
+
+import (
+	_ "embed"
+	"log"
+	"time"
+)
+
+//go:embed validation-codes.yml
+var validationCodesFile []byte
+
+type ValidationCodesConfig struct {
+	// EmailValidationTTL is the time a user has to validate the email they registered with.
+	EmailValidationTTL time.Duration `yaml:"emailValidationTTL"`
+	// EmailUpdateTTL is the time a user has to validate a new email address.
+	EmailUpdateTTL time.Duration `yaml:"emailUpdateTTL"`
+	// PasswordResetTTL is the time a user has to set a new password, after requesting a reset.
+	PasswordResetTTL time.Duration `yaml:"passwordResetTTL"`
+}
+
+var ValidationCodes *ValidationCodesConfig
+
+func init() {
+	cfg := new(ValidationCodesConfig)
+
+	if err := loadEnv(EnvLoader{DefaultENV: validationCodesFile}, cfg); err != nil {
+		log.Fatalf("error loading validation codes configuration: %v\n", err)
+	}
+
+	ValidationCodes = cfg
+}
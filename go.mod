@@ -15,6 +15,7 @@ require (
 	github.com/samber/lo v1.39.0
 	github.com/sendgrid/sendgrid-go v3.14.0+incompatible
 	github.com/stretchr/testify v1.8.4
+	github.com/ugorji/go/codec v1.2.12
 	github.com/uptrace/bun v1.1.17
 	golang.org/x/crypto v0.19.0
 	google.golang.org/api v0.165.0
@@ -59,7 +60,6 @@ require (
 	github.com/stretchr/objx v0.5.1 // indirect
 	github.com/tmthrgd/go-hex v0.0.0-20190904060850-447a3041c3bc // indirect
 	github.com/twitchyliquid64/golang-asm v0.15.1 // indirect
-	github.com/ugorji/go/codec v1.2.12 // indirect
 	github.com/uptrace/bun/dialect/pgdialect v1.1.17 // indirect
 	github.com/uptrace/bun/driver/pgdriver v1.1.17 // indirect
 	github.com/vmihailenco/msgpack/v5 v5.4.1 // indirect
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS refresh_tokens_family;
+DROP INDEX IF EXISTS refresh_tokens_user;
+
+--bun:split
+
+DROP TABLE IF EXISTS refresh_tokens;
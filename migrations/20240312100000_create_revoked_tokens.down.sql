@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS revoked_user_tokens_expires_at;
+
+--bun:split
+
+DROP TABLE IF EXISTS revoked_user_tokens;
+
+--bun:split
+
+DROP INDEX IF EXISTS revoked_tokens_expires_at;
+
+--bun:split
+
+DROP TABLE IF EXISTS revoked_tokens;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ,
+
+    user_id uuid NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS revoked_tokens_expires_at ON revoked_tokens (expires_at);
+
+--bun:split
+
+/* Revokes every token issued to a user before a given date. The id is the user id. */
+CREATE TABLE IF NOT EXISTS revoked_user_tokens (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ,
+
+    revoked_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS revoked_user_tokens_expires_at ON revoked_user_tokens (expires_at);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS sessions_user;
+
+--bun:split
+
+DROP TABLE IF EXISTS sessions;
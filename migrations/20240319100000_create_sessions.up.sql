@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS sessions (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ,
+
+    family_id uuid NOT NULL UNIQUE,
+    user_id uuid NOT NULL,
+    user_agent VARCHAR(512),
+    ip VARCHAR(64),
+    last_seen_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS sessions_user ON sessions (user_id);
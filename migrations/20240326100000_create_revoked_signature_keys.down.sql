@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS sessions_key_id;
+
+--bun:split
+
+ALTER TABLE sessions DROP COLUMN IF EXISTS token_expires_at;
+
+--bun:split
+
+ALTER TABLE sessions DROP COLUMN IF EXISTS key_id;
+
+--bun:split
+
+DROP TABLE IF EXISTS revoked_signature_keys;
@@ -0,0 +1,20 @@
+/* Signature keys that must no longer be trusted. The id is the key ID, as published in the JWKS. */
+CREATE TABLE IF NOT EXISTS revoked_signature_keys (
+    id VARCHAR(64) PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+
+    name VARCHAR(128) NOT NULL
+);
+
+--bun:split
+
+/* ID of the key that signed the last token issued for the session, and the expiration date of this token. */
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS key_id VARCHAR(64);
+
+--bun:split
+
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS token_expires_at TIMESTAMPTZ;
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS sessions_key_id ON sessions (key_id);
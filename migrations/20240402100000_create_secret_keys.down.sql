@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS secret_keys_created_at;
+
+--bun:split
+
+DROP TABLE IF EXISTS secret_keys;
@@ -0,0 +1,14 @@
+/* Signature keys, for deployments that store them in Postgres. Keys are encrypted with a master key. */
+CREATE TABLE IF NOT EXISTS secret_keys (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ,
+
+    name VARCHAR(128) NOT NULL UNIQUE,
+    key_encrypted BYTEA NOT NULL,
+    activates_at TIMESTAMPTZ NOT NULL
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS secret_keys_created_at ON secret_keys (created_at);
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS login_failures_created_at;
+
+--bun:split
+
+DROP INDEX IF EXISTS login_failures_ip;
+
+--bun:split
+
+DROP INDEX IF EXISTS login_failures_account;
+
+--bun:split
+
+DROP TABLE IF EXISTS login_failures;
@@ -0,0 +1,24 @@
+/* Failed login attempts, used to throttle brute force attacks. The account is the normalized email that was tried,
+   whether it belongs to a user or not. */
+CREATE TABLE IF NOT EXISTS login_failures (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+
+    account VARCHAR(128) NOT NULL,
+    ip VARCHAR(64) NOT NULL,
+    /* Set once the account has been unlocked, either by a successful login or by an admin. Cleared failures still
+       count for the client IP. */
+    cleared_at TIMESTAMPTZ
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS login_failures_account ON login_failures (account, created_at);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS login_failures_ip ON login_failures (ip, created_at);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS login_failures_created_at ON login_failures (created_at);
@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS mfa_challenges;
+
+--bun:split
+
+DROP INDEX IF EXISTS recovery_codes_user_id;
+
+--bun:split
+
+DROP TABLE IF EXISTS recovery_codes;
+
+--bun:split
+
+DROP TABLE IF EXISTS totp_secrets;
@@ -0,0 +1,44 @@
+/* TOTP secrets, used for two-factor authentication. The id is the id of the user. Two-factor authentication is only
+   enabled once the secret is confirmed. */
+CREATE TABLE IF NOT EXISTS totp_secrets (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ,
+
+    secret VARCHAR(64) NOT NULL,
+    confirmed_at TIMESTAMPTZ,
+    /* Time step of the last accepted code, so a code cannot be used twice. */
+    last_step BIGINT NOT NULL DEFAULT 0
+);
+
+--bun:split
+
+/* Single use codes, that replace a TOTP code when the user has lost their device. Codes are hashed. */
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+
+    user_id uuid NOT NULL,
+    code_hashed VARCHAR(256) NOT NULL,
+    used_at TIMESTAMPTZ
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS recovery_codes_user_id ON recovery_codes (user_id);
+
+--bun:split
+
+/* Challenges issued by the login to users with two-factor authentication, and exchanged for a session once the second
+   factor is verified. Tokens are hashed. */
+CREATE TABLE IF NOT EXISTS mfa_challenges (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ,
+
+    user_id uuid NOT NULL,
+    token_hashed VARCHAR(256) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    failures INTEGER NOT NULL DEFAULT 0
+);
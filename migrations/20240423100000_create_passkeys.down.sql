@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS webauthn_challenges;
+
+--bun:split
+
+DROP INDEX IF EXISTS passkeys_user_id;
+
+--bun:split
+
+DROP TABLE IF EXISTS passkeys;
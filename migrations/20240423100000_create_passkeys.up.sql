@@ -0,0 +1,34 @@
+/* WebAuthn credentials, used to log in without a password, or as a second factor. */
+CREATE TABLE IF NOT EXISTS passkeys (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ,
+
+    user_id uuid NOT NULL,
+    credential_id BYTEA NOT NULL UNIQUE,
+    /* COSE encoded public key, as returned by the authenticator. */
+    public_key BYTEA NOT NULL,
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    transports VARCHAR(16)[] NOT NULL DEFAULT '{}',
+    last_used_at TIMESTAMPTZ
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS passkeys_user_id ON passkeys (user_id);
+
+--bun:split
+
+/* Challenges of the WebAuthn ceremonies. Each challenge can only be used once. The user id is empty for passwordless
+   logins, where the user is only known once the credential is read. */
+CREATE TABLE IF NOT EXISTS webauthn_challenges (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ,
+
+    user_id uuid,
+    ceremony VARCHAR(16) NOT NULL,
+    challenge BYTEA NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ
+);
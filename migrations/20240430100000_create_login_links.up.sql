@@ -0,0 +1,11 @@
+/* Single use links, sent by email, that open a session without a password. Codes are hashed. */
+CREATE TABLE IF NOT EXISTS login_links (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ,
+
+    user_id uuid NOT NULL,
+    code_hashed VARCHAR(256) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ
+);
@@ -0,0 +1,3 @@
+ALTER TABLE credentials DROP COLUMN IF EXISTS email_validation_issued_at;
+ALTER TABLE credentials DROP COLUMN IF EXISTS new_email_validation_issued_at;
+ALTER TABLE credentials DROP COLUMN IF EXISTS password_validation_issued_at;
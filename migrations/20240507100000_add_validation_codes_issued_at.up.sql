@@ -0,0 +1,16 @@
+/*
+    Validation codes expire after a delay that depends on their purpose, which is given by the column they are stored
+    in. Codes issued before this migration are dated from the last update of their row.
+*/
+ALTER TABLE credentials ADD COLUMN IF NOT EXISTS email_validation_issued_at TIMESTAMPTZ;
+ALTER TABLE credentials ADD COLUMN IF NOT EXISTS new_email_validation_issued_at TIMESTAMPTZ;
+ALTER TABLE credentials ADD COLUMN IF NOT EXISTS password_validation_issued_at TIMESTAMPTZ;
+
+--bun:split
+
+UPDATE credentials SET email_validation_issued_at = COALESCE(updated_at, created_at)
+    WHERE email_validation_code IS NOT NULL AND email_validation_code <> '';
+UPDATE credentials SET new_email_validation_issued_at = COALESCE(updated_at, created_at)
+    WHERE new_email_validation_code IS NOT NULL AND new_email_validation_code <> '';
+UPDATE credentials SET password_validation_issued_at = COALESCE(updated_at, created_at)
+    WHERE password_validation_code IS NOT NULL AND password_validation_code <> '';
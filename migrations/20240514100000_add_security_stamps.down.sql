@@ -0,0 +1,2 @@
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS security_stamp;
+ALTER TABLE credentials DROP COLUMN IF EXISTS security_stamp;
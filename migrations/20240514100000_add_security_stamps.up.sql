@@ -0,0 +1,7 @@
+/*
+    The security stamp changes every time the credentials of a user are updated, and is embedded in the tokens issued
+    to them. A token whose stamp no longer matches is rejected. Users who never rotated their stamp have none, which
+    keeps the tokens issued before this migration valid.
+*/
+ALTER TABLE credentials ADD COLUMN IF NOT EXISTS security_stamp uuid;
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS security_stamp uuid;
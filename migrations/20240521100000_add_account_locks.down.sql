@@ -0,0 +1,2 @@
+ALTER TABLE credentials DROP COLUMN IF EXISTS locked_at;
+ALTER TABLE credentials DROP COLUMN IF EXISTS new_email_report_code;
@@ -0,0 +1,6 @@
+/*
+    When a new email is requested, a report code is sent to the current address, so its owner can cancel a change they
+    did not ask for. Reporting a change locks the account until the password is reset.
+*/
+ALTER TABLE credentials ADD COLUMN IF NOT EXISTS new_email_report_code VARCHAR(256);
+ALTER TABLE credentials ADD COLUMN IF NOT EXISTS locked_at TIMESTAMPTZ;
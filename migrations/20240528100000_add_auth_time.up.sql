@@ -0,0 +1,6 @@
+/*
+    The authentication time is the date the user opened the session of a refresh token family, with their
+    credentials. It is carried over to every token of the family, so sensitive operations can require a recent
+    authentication. Families opened before this migration have none.
+*/
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS auth_time TIMESTAMPTZ;
@@ -0,0 +1,31 @@
+CREATE OR REPLACE VIEW users_view AS
+    SELECT
+        credentials.id AS id,
+        LEAST(credentials.created_at, identities.created_at, profiles.created_at) AS created_at,
+        GREATEST(credentials.updated_at, identities.updated_at, profiles.updated_at) AS updated_at,
+        json_build_object(
+            'email', json_build_object(
+                'user', credentials.email_user,
+                'domain', credentials.email_domain
+            )
+        ) AS credentials,
+        json_build_object(
+            'firstName', identities.first_name,
+            'lastName', identities.last_name,
+            'sex', identities.sex,
+            'birthday', identities.birthday
+        ) AS identity,
+        json_build_object(
+            'username', profiles.username,
+            'slug', profiles.slug
+        ) AS profile
+    FROM credentials
+        INNER JOIN identities ON credentials.id = identities.id
+        INNER JOIN profiles ON credentials.id = profiles.id;
+
+--bun:split
+
+DROP INDEX IF EXISTS credentials_deleted_at;
+
+ALTER TABLE credentials DROP COLUMN IF EXISTS deletion_code;
+ALTER TABLE credentials DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,36 @@
+/*
+    Deleted accounts are kept for a grace period, during which their owner can cancel the deletion. They are hidden
+    from the users view, and purged once the grace period is over.
+*/
+ALTER TABLE credentials ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE credentials ADD COLUMN IF NOT EXISTS deletion_code VARCHAR(256);
+
+CREATE INDEX IF NOT EXISTS credentials_deleted_at ON credentials (deleted_at) WHERE deleted_at IS NOT NULL;
+
+--bun:split
+
+CREATE OR REPLACE VIEW users_view AS
+    SELECT
+        credentials.id AS id,
+        LEAST(credentials.created_at, identities.created_at, profiles.created_at) AS created_at,
+        GREATEST(credentials.updated_at, identities.updated_at, profiles.updated_at) AS updated_at,
+        json_build_object(
+            'email', json_build_object(
+                'user', credentials.email_user,
+                'domain', credentials.email_domain
+            )
+        ) AS credentials,
+        json_build_object(
+            'firstName', identities.first_name,
+            'lastName', identities.last_name,
+            'sex', identities.sex,
+            'birthday', identities.birthday
+        ) AS identity,
+        json_build_object(
+            'username', profiles.username,
+            'slug', profiles.slug
+        ) AS profile
+    FROM credentials
+        INNER JOIN identities ON credentials.id = identities.id
+        INNER JOIN profiles ON credentials.id = profiles.id
+    WHERE credentials.deleted_at IS NULL;
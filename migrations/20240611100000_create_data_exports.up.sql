@@ -0,0 +1,16 @@
+/*
+    Archives of the personal data of a user, built in the background when they are too large to be returned directly.
+    They are downloaded with a link sent by email, whose code is hashed, and removed once expired.
+*/
+CREATE TABLE IF NOT EXISTS data_exports (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ,
+
+    user_id uuid NOT NULL,
+    code_hashed VARCHAR(256) NOT NULL,
+    archive BYTEA NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS data_exports_expires_at ON data_exports (expires_at);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS suspension_events_user_id;
+DROP TABLE IF EXISTS suspension_events;
+
+--bun:split
+
+DROP INDEX IF EXISTS credentials_suspended_at;
+
+ALTER TABLE credentials DROP COLUMN IF EXISTS suspended_by;
+ALTER TABLE credentials DROP COLUMN IF EXISTS suspension_reason;
+ALTER TABLE credentials DROP COLUMN IF EXISTS suspended_until;
+ALTER TABLE credentials DROP COLUMN IF EXISTS suspended_at;
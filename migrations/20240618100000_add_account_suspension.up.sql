@@ -0,0 +1,26 @@
+/*
+    Moderators can suspend abusive accounts, with a reason and an optional expiration date. Suspended accounts cannot
+    log in, and their tokens are rejected. Every suspension and unsuspension is recorded in the suspension events,
+    which are never updated.
+*/
+ALTER TABLE credentials ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMPTZ;
+ALTER TABLE credentials ADD COLUMN IF NOT EXISTS suspended_until TIMESTAMPTZ;
+ALTER TABLE credentials ADD COLUMN IF NOT EXISTS suspension_reason TEXT;
+ALTER TABLE credentials ADD COLUMN IF NOT EXISTS suspended_by VARCHAR(256);
+
+CREATE INDEX IF NOT EXISTS credentials_suspended_at ON credentials (suspended_at) WHERE suspended_at IS NOT NULL;
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS suspension_events (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+
+    user_id uuid NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    actor VARCHAR(256) NOT NULL,
+    reason TEXT,
+    suspended_until TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS suspension_events_user_id ON suspension_events (user_id, created_at);
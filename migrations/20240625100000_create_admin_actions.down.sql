@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS admin_actions_user_id;
+DROP TABLE IF EXISTS admin_actions;
@@ -0,0 +1,14 @@
+/*
+    Actions performed on users through the admin API of the internal server. Each action records the identity of
+    the internal caller that performed it. Rows are never updated.
+*/
+CREATE TABLE IF NOT EXISTS admin_actions (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+
+    user_id uuid NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    actor VARCHAR(256) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS admin_actions_user_id ON admin_actions (user_id, created_at);
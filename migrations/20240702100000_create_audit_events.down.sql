@@ -0,0 +1,51 @@
+CREATE TABLE IF NOT EXISTS suspension_events (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+
+    user_id uuid NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    actor VARCHAR(256) NOT NULL,
+    reason TEXT,
+    suspended_until TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS suspension_events_user_id ON suspension_events (user_id, created_at);
+
+CREATE TABLE IF NOT EXISTS admin_actions (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+
+    user_id uuid NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    actor VARCHAR(256) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS admin_actions_user_id ON admin_actions (user_id, created_at);
+
+--bun:split
+
+INSERT INTO suspension_events (id, created_at, user_id, action, actor, reason, suspended_until)
+SELECT
+    id,
+    created_at,
+    user_id,
+    CASE kind WHEN 'admin.suspend_user' THEN 'suspend' ELSE 'unsuspend' END,
+    actor,
+    details->>'reason',
+    (details->>'until')::TIMESTAMPTZ
+FROM audit_events
+WHERE kind IN ('admin.suspend_user', 'admin.unsuspend_user');
+
+INSERT INTO admin_actions (id, created_at, user_id, action, actor)
+SELECT id, created_at, user_id, substring(kind FROM 7), actor
+FROM audit_events
+WHERE kind IN (
+    'admin.lookup_user', 'admin.validate_email', 'admin.reset_password', 'admin.cancel_new_email',
+    'admin.update_profile'
+);
+
+--bun:split
+
+DROP INDEX IF EXISTS audit_events_user_id;
+DROP INDEX IF EXISTS audit_events_created_at;
+DROP TABLE IF EXISTS audit_events;
@@ -0,0 +1,51 @@
+/*
+    Append-only log of security relevant changes: logins, password and email changes, key rotations and admin
+    actions. Events are written in the same transaction as the change they describe, when both live in this database.
+    The actor is the internal caller that made the change, and is empty when the targeted user made it themselves.
+*/
+CREATE TABLE IF NOT EXISTS audit_events (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+
+    kind VARCHAR(64) NOT NULL,
+    actor VARCHAR(256),
+    user_id uuid,
+    ip VARCHAR(64),
+    user_agent VARCHAR(512),
+    details JSONB
+);
+
+CREATE INDEX IF NOT EXISTS audit_events_created_at ON audit_events (created_at);
+CREATE INDEX IF NOT EXISTS audit_events_user_id ON audit_events (user_id, created_at);
+
+--bun:split
+
+/*
+    Suspension events and admin actions were recorded in their own tables. They are moved to the audit log, which
+    now records them.
+*/
+INSERT INTO audit_events (id, created_at, kind, actor, user_id, details)
+SELECT
+    id,
+    created_at,
+    CASE action WHEN 'suspend' THEN 'admin.suspend_user' ELSE 'admin.unsuspend_user' END,
+    actor,
+    user_id,
+    -- Dates in details use the RFC 3339 format, in UTC.
+    NULLIF(
+        jsonb_strip_nulls(jsonb_build_object(
+            'reason', reason,
+            'until', to_char(suspended_until AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')
+        )),
+        '{}'::jsonb
+    )
+FROM suspension_events;
+
+INSERT INTO audit_events (id, created_at, kind, actor, user_id)
+SELECT id, created_at, 'admin.' || action, actor, user_id FROM admin_actions;
+
+DROP INDEX IF EXISTS suspension_events_user_id;
+DROP TABLE IF EXISTS suspension_events;
+
+DROP INDEX IF EXISTS admin_actions_user_id;
+DROP TABLE IF EXISTS admin_actions;
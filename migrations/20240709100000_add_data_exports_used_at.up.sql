@@ -0,0 +1,4 @@
+/*
+    Download links of data exports can only be used once.
+*/
+ALTER TABLE data_exports ADD COLUMN IF NOT EXISTS used_at TIMESTAMPTZ;
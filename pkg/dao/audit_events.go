@@ -0,0 +1,141 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+// AuditEventKind is the type of change recorded by an audit event.
+type AuditEventKind string
+
+const (
+	// AuditEventLoginSucceeded is recorded whenever a session is opened, whatever the login method.
+	AuditEventLoginSucceeded AuditEventKind = "login.succeeded"
+	// AuditEventLoginFailed is recorded when a password, a second factor or a step-up check fails. The user is not set
+	// if the email is unknown.
+	AuditEventLoginFailed AuditEventKind = "login.failed"
+
+	AuditEventPasswordResetRequested AuditEventKind = "password.reset_requested"
+	AuditEventPasswordChanged        AuditEventKind = "password.changed"
+
+	AuditEventEmailValidated       AuditEventKind = "email.validated"
+	AuditEventEmailChangeRequested AuditEventKind = "email.change_requested"
+	AuditEventEmailChanged         AuditEventKind = "email.changed"
+
+	AuditEventKeysRotated AuditEventKind = "keys.rotated"
+	AuditEventKeyRevoked  AuditEventKind = "keys.revoked"
+
+	AuditEventAdminLookupUser     AuditEventKind = "admin.lookup_user"
+	AuditEventAdminValidateEmail  AuditEventKind = "admin.validate_email"
+	AuditEventAdminResetPassword  AuditEventKind = "admin.reset_password"
+	AuditEventAdminCancelNewEmail AuditEventKind = "admin.cancel_new_email"
+	AuditEventAdminUpdateProfile  AuditEventKind = "admin.update_profile"
+	AuditEventAdminSuspendUser    AuditEventKind = "admin.suspend_user"
+	AuditEventAdminUnsuspendUser  AuditEventKind = "admin.unsuspend_user"
+)
+
+// AuditActorSystem is the actor of the changes made by the service itself, such as scheduled key rotations.
+const AuditActorSystem = "system"
+
+// AuditRecorder writes audit events. Repositories that implement it record the event with the connection they use,
+// so an event recorded within their transaction is only saved along with the change it describes.
+type AuditRecorder interface {
+	// RecordAuditEvent saves a new audit event. Events are never updated.
+	RecordAuditEvent(ctx context.Context, data *AuditEventModelCore, id uuid.UUID, now time.Time) (*AuditEventModel, error)
+}
+
+type AuditEventsRepository interface {
+	AuditRecorder
+	// List returns the audit events that match the filter, most recent first, along with their total count.
+	List(ctx context.Context, filter AuditEventsFilter, limit, offset int) ([]*AuditEventModel, int, error)
+}
+
+// AuditEventsFilter restricts the events returned by AuditEventsRepository.List. Empty fields are ignored.
+type AuditEventsFilter struct {
+	UserID uuid.UUID
+	Kinds  []AuditEventKind
+	Actor  string
+	// Since and Until bound the creation date of the events. Since is inclusive, Until is exclusive.
+	Since *time.Time
+	Until *time.Time
+}
+
+// AuditEventModel records a security relevant change. Events are append-only.
+type AuditEventModel struct {
+	bun.BaseModel `bun:"table:audit_events"`
+
+	ID        uuid.UUID `bun:"id,pk,type:uuid"`
+	CreatedAt time.Time `bun:"created_at"`
+	AuditEventModelCore
+}
+
+type AuditEventModelCore struct {
+	Kind AuditEventKind `bun:"kind"`
+	// Actor is the identity of the internal caller that made the change. It is empty when the change was made by the
+	// targeted user themselves.
+	Actor string `bun:"actor,nullzero"`
+	// UserID is the ID of the targeted user. It is empty for changes that do not target a user.
+	UserID    uuid.UUID `bun:"user_id,nullzero"`
+	IP        string    `bun:"ip,nullzero"`
+	UserAgent string    `bun:"user_agent,nullzero"`
+	// Details holds additional information, that depends on the kind of event.
+	Details map[string]string `bun:"details,type:jsonb,nullzero"`
+}
+
+func NewAuditEventsRepository(db bun.IDB) AuditEventsRepository {
+	return &auditEventsRepositoryImpl{db: db}
+}
+
+type auditEventsRepositoryImpl struct {
+	db bun.IDB
+}
+
+// recordAuditEvent is shared by every AuditRecorder implementation.
+func recordAuditEvent(ctx context.Context, db bun.IDB, data *AuditEventModelCore, id uuid.UUID, now time.Time) (*AuditEventModel, error) {
+	model := &AuditEventModel{ID: id, CreatedAt: now, AuditEventModelCore: *data}
+
+	if _, err := db.NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *auditEventsRepositoryImpl) RecordAuditEvent(ctx context.Context, data *AuditEventModelCore, id uuid.UUID, now time.Time) (*AuditEventModel, error) {
+	return recordAuditEvent(ctx, repository.db, data, id, now)
+}
+
+func (repository *auditEventsRepositoryImpl) List(ctx context.Context, filter AuditEventsFilter, limit, offset int) ([]*AuditEventModel, int, error) {
+	results := make([]*AuditEventModel, 0)
+
+	query := repository.db.NewSelect().Model(&results)
+	if filter.UserID != uuid.Nil {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if len(filter.Kinds) > 0 {
+		query = query.Where("kind IN (?)", bun.In(filter.Kinds))
+	}
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Since != nil {
+		query = query.Where("created_at >= ?", *filter.Since)
+	}
+	if filter.Until != nil {
+		query = query.Where("created_at < ?", *filter.Until)
+	}
+
+	count, err := query.
+		Order("created_at DESC", "id DESC").
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, bunovel.HandlePGError(err)
+	}
+
+	return results, count, nil
+}
@@ -0,0 +1,208 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/migrations"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+var auditEventsFixtures = []*dao.AuditEventModel{
+	{
+		ID:        goframework.NumberUUID(1),
+		CreatedAt: baseTime,
+		AuditEventModelCore: dao.AuditEventModelCore{
+			Kind:      dao.AuditEventLoginFailed,
+			UserID:    goframework.NumberUUID(10),
+			IP:        "127.0.0.1",
+			UserAgent: "Mozilla/5.0",
+			Details:   map[string]string{"email": "user@domain.com"},
+		},
+	},
+	{
+		ID:        goframework.NumberUUID(2),
+		CreatedAt: baseTime.Add(time.Minute),
+		AuditEventModelCore: dao.AuditEventModelCore{
+			Kind:      dao.AuditEventLoginSucceeded,
+			UserID:    goframework.NumberUUID(10),
+			IP:        "127.0.0.1",
+			UserAgent: "Mozilla/5.0",
+		},
+	},
+	{
+		ID:        goframework.NumberUUID(3),
+		CreatedAt: updateTime,
+		AuditEventModelCore: dao.AuditEventModelCore{
+			Kind:   dao.AuditEventAdminLookupUser,
+			Actor:  "support",
+			UserID: goframework.NumberUUID(10),
+		},
+	},
+	{
+		ID:        goframework.NumberUUID(4),
+		CreatedAt: updateTime,
+		AuditEventModelCore: dao.AuditEventModelCore{
+			Kind:    dao.AuditEventKeysRotated,
+			Actor:   dao.AuditActorSystem,
+			Details: map[string]string{"key": "key-1"},
+		},
+	},
+	{
+		ID:        goframework.NumberUUID(5),
+		CreatedAt: updateTime.Add(time.Minute),
+		AuditEventModelCore: dao.AuditEventModelCore{
+			Kind:   dao.AuditEventLoginSucceeded,
+			UserID: goframework.NumberUUID(20),
+		},
+	},
+}
+
+func TestAuditEventsRepository_RecordAuditEvent(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, auditEventsFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewAuditEventsRepository(tx)
+
+		data := &dao.AuditEventModelCore{
+			Kind:      dao.AuditEventPasswordChanged,
+			UserID:    goframework.NumberUUID(10),
+			IP:        "127.0.0.1",
+			UserAgent: "Mozilla/5.0",
+			Details:   map[string]string{"method": "reset"},
+		}
+
+		res, err := repository.RecordAuditEvent(ctx, data, goframework.NumberUUID(100), updateTime)
+		require.NoError(t, err)
+		require.Equal(t, &dao.AuditEventModel{
+			ID:                  goframework.NumberUUID(100),
+			CreatedAt:           updateTime,
+			AuditEventModelCore: *data,
+		}, res)
+
+		// Events recorded by other repositories land in the same log.
+		_, err = dao.NewCredentialsRepository(tx).RecordAuditEvent(ctx, &dao.AuditEventModelCore{
+			Kind:   dao.AuditEventEmailValidated,
+			UserID: goframework.NumberUUID(10),
+		}, goframework.NumberUUID(101), updateTime)
+		require.NoError(t, err)
+
+		events, total, err := repository.List(ctx, dao.AuditEventsFilter{
+			Kinds: []dao.AuditEventKind{dao.AuditEventPasswordChanged, dao.AuditEventEmailValidated},
+		}, 10, 0)
+		require.NoError(t, err)
+		require.Equal(t, 2, total)
+		require.Len(t, events, 2)
+
+		// Events cannot be overwritten.
+		_, err = repository.RecordAuditEvent(ctx, data, goframework.NumberUUID(100), updateTime)
+		require.Error(t, err)
+	})
+	require.NoError(t, err)
+}
+
+func TestAuditEventsRepository_List(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	data := []struct {
+		name string
+
+		filter dao.AuditEventsFilter
+		limit  int
+		offset int
+
+		expect      []uuid.UUID
+		expectTotal int
+	}{
+		{
+			name:        "Success/NoFilter",
+			limit:       10,
+			expect:      []uuid.UUID{goframework.NumberUUID(5), goframework.NumberUUID(4), goframework.NumberUUID(3), goframework.NumberUUID(2), goframework.NumberUUID(1)},
+			expectTotal: 5,
+		},
+		{
+			name:        "Success/Paginated",
+			limit:       2,
+			offset:      1,
+			expect:      []uuid.UUID{goframework.NumberUUID(4), goframework.NumberUUID(3)},
+			expectTotal: 5,
+		},
+		{
+			name:        "Success/ByUser",
+			filter:      dao.AuditEventsFilter{UserID: goframework.NumberUUID(10)},
+			limit:       10,
+			expect:      []uuid.UUID{goframework.NumberUUID(3), goframework.NumberUUID(2), goframework.NumberUUID(1)},
+			expectTotal: 3,
+		},
+		{
+			name: "Success/ByKinds",
+			filter: dao.AuditEventsFilter{
+				Kinds: []dao.AuditEventKind{dao.AuditEventLoginSucceeded, dao.AuditEventLoginFailed},
+			},
+			limit:       10,
+			expect:      []uuid.UUID{goframework.NumberUUID(5), goframework.NumberUUID(2), goframework.NumberUUID(1)},
+			expectTotal: 3,
+		},
+		{
+			name:        "Success/ByActor",
+			filter:      dao.AuditEventsFilter{Actor: "support"},
+			limit:       10,
+			expect:      []uuid.UUID{goframework.NumberUUID(3)},
+			expectTotal: 1,
+		},
+		{
+			name: "Success/ByDate",
+			filter: dao.AuditEventsFilter{
+				Since: lo.ToPtr(baseTime.Add(time.Minute)),
+				Until: lo.ToPtr(updateTime.Add(time.Minute)),
+			},
+			limit:       10,
+			expect:      []uuid.UUID{goframework.NumberUUID(4), goframework.NumberUUID(3), goframework.NumberUUID(2)},
+			expectTotal: 3,
+		},
+		{
+			name: "Success/Combined",
+			filter: dao.AuditEventsFilter{
+				UserID: goframework.NumberUUID(10),
+				Kinds:  []dao.AuditEventKind{dao.AuditEventLoginSucceeded},
+			},
+			limit:       10,
+			expect:      []uuid.UUID{goframework.NumberUUID(2)},
+			expectTotal: 1,
+		},
+		{
+			name:        "Success/NoResults",
+			filter:      dao.AuditEventsFilter{UserID: goframework.NumberUUID(30)},
+			limit:       10,
+			expect:      []uuid.UUID{},
+			expectTotal: 0,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, auditEventsFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewAuditEventsRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(t *testing.T) {
+				res, total, err := repository.List(ctx, d.filter, d.limit, d.offset)
+				require.NoError(t, err)
+				require.Equal(t, d.expectTotal, total)
+				require.Equal(t, d.expect, lo.Map(res, func(item *dao.AuditEventModel, _ int) uuid.UUID {
+					return item.ID
+				}))
+			})
+		}
+	})
+	require.NoError(t, err)
+}
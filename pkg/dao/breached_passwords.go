@@ -0,0 +1,58 @@
+package dao
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	goerrors "errors"
+	"io/fs"
+	"strings"
+)
+
+// BreachedPasswordsPrefixLength is the number of hexadecimal characters of a SHA-1 hash used to name range files.
+const BreachedPasswordsPrefixLength = 5
+
+type BreachedPasswordsRepository interface {
+	// IsBreached returns true if the password with the given SHA-1 hash appears in a known data breach.
+	IsBreached(ctx context.Context, hash [sha1.Size]byte) (bool, error)
+}
+
+// NewBreachedPasswordsRepository reads breached passwords from local range files, in the k-anonymity format of the
+// Have I Been Pwned API. Each file is named after the first 5 hexadecimal characters of the hashes it holds, and
+// lists the remaining characters of each hash, followed by a colon and the number of times it was seen.
+//
+// A missing range file means no hash with this prefix is known, so a partial list can be used.
+func NewBreachedPasswordsRepository(fsys fs.FS) BreachedPasswordsRepository {
+	return &breachedPasswordsRepositoryImpl{fsys: fsys}
+}
+
+type breachedPasswordsRepositoryImpl struct {
+	fsys fs.FS
+}
+
+func (repository *breachedPasswordsRepositoryImpl) IsBreached(_ context.Context, hash [sha1.Size]byte) (bool, error) {
+	encoded := strings.ToUpper(hex.EncodeToString(hash[:]))
+	prefix, suffix := encoded[:BreachedPasswordsPrefixLength], []byte(encoded[BreachedPasswordsPrefixLength:])
+
+	file, err := repository.fsys.Open(prefix)
+	if err != nil {
+		if goerrors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := bytes.Cut(scanner.Bytes(), []byte(":"))
+		if bytes.EqualFold(bytes.TrimSpace(line), suffix) {
+			return true, nil
+		}
+	}
+
+	return false, scanner.Err()
+}
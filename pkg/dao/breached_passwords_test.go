@@ -0,0 +1,79 @@
+package dao_test
+
+import (
+	"context"
+	"crypto/sha1"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"testing/fstest"
+)
+
+func TestBreachedPasswordsRepository_IsBreached(t *testing.T) {
+	fsys := fstest.MapFS{
+		// Range of "password", whose SHA-1 hash is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8.
+		"5BAA6": &fstest.MapFile{
+			Data: []byte("003D68EB55068C33ACE09247EE4C639306B:3\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\r\n"),
+		},
+		// Range of "hello", whose SHA-1 hash is AAF4C61DDCC5E8A2DABEDE0F3B482CD9AEA9434D, with lowercase hashes.
+		"AAF4C": &fstest.MapFile{
+			Data: []byte("61ddcc5e8a2dabede0f3b482cd9aea9434d:253581\n"),
+		},
+		// Range of "password-not-listed", whose SHA-1 hash is 767A456A8C74E9BF5A714283A17FD2E0BC3C2F52.
+		"767A4": &fstest.MapFile{
+			Data: []byte("56A8C74E9BF5A714283A17FD2E0BC3C2F51:2\n"),
+		},
+		// A directory cannot be read as a range file.
+		"21BD1/invalid": &fstest.MapFile{},
+	}
+
+	data := []struct {
+		name string
+
+		password string
+
+		expect    bool
+		expectErr bool
+	}{
+		{
+			name:     "Breached",
+			password: "password",
+			expect:   true,
+		},
+		{
+			name:     "Breached/LowerCaseRange",
+			password: "hello",
+			expect:   true,
+		},
+		{
+			name:     "NotBreached/SameRange",
+			password: "password-not-listed",
+			expect:   false,
+		},
+		{
+			name:     "NotBreached/MissingRange",
+			password: "correct horse battery staple",
+			expect:   false,
+		},
+		{
+			name:      "Error/UnreadableRange",
+			password:  "P@ssw0rd",
+			expectErr: true,
+		},
+	}
+
+	repository := dao.NewBreachedPasswordsRepository(fsys)
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			res, err := repository.IsBreached(context.Background(), sha1.Sum([]byte(d.password)))
+			if d.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, d.expect, res)
+		})
+	}
+}
@@ -21,15 +21,19 @@ type CredentialsRepository interface {
 	EmailExists(ctx context.Context, email Email) (bool, error)
 
 	// UpdateEmail updates the email of a user. The new email value is set as CredentialsModelCore.NewEmail.
-	// The validation code should not be set on the Email.Validation field, as it will be filtered. The code value
-	// MUST be hashed.
+	// The validation code should not be set on the Email.Validation field, as it will be filtered. The report code
+	// is saved as CredentialsModelCore.NewEmailReportCode. Both code values MUST be hashed.
 	// To make the new email the primary email of the user, you must call ValidateNewEmail.
-	UpdateEmail(ctx context.Context, email Email, code string, id uuid.UUID, now time.Time) (*CredentialsModel, error)
+	UpdateEmail(ctx context.Context, email Email, code, reportCode string, id uuid.UUID, now time.Time) (*CredentialsModel, error)
 	// ValidateEmail nullifies the Email.Validation value of CredentialsModelCore.Email, for the targeted user.
-	ValidateEmail(ctx context.Context, id uuid.UUID, now time.Time) (*CredentialsModel, error)
+	// The code is the hashed validation code that was verified: if it was consumed or replaced in the meantime, this
+	// method fails with bunovel.ErrNotFound, so a code can only be used once.
+	ValidateEmail(ctx context.Context, id uuid.UUID, code string, now time.Time) (*CredentialsModel, error)
 	// ValidateNewEmail sets the email in argument as the primary email (CredentialsModelCore.Email) for the targeted user.
 	// The CredentialsModelCore.NewEmail value is nullified in the process, and Email.Validation is filtered.
-	ValidateNewEmail(ctx context.Context, id uuid.UUID, now time.Time) (*CredentialsModel, error)
+	// Like ValidateEmail, the hashed code must still be pending. The security stamp is replaced with the given value,
+	// as the user now authenticates with a different email.
+	ValidateNewEmail(ctx context.Context, id uuid.UUID, code string, securityStamp uuid.UUID, now time.Time) (*CredentialsModel, error)
 
 	// UpdateEmailValidation sets a new Email.Validation code for the targeted user CredentialsModelCore.Email.
 	// The code value MUST be hashed.
@@ -43,12 +47,56 @@ type CredentialsRepository interface {
 	CancelNewEmail(ctx context.Context, id uuid.UUID, now time.Time) (*CredentialsModel, error)
 
 	// UpdatePassword updates the password of the targeted user. The password value MUST be hashed in order to be
-	// saved properly.
-	UpdatePassword(ctx context.Context, newPassword string, id uuid.UUID, now time.Time) (*CredentialsModel, error)
+	// saved properly. The security stamp is set to the given value: passing the current stamp keeps the tokens
+	// of the user valid.
+	//
+	// When the password is reset, code is the hashed reset code that was verified. The update only happens if this
+	// code is still pending, so it can only be used once: otherwise, this method fails with bunovel.ErrNotFound.
+	// Pass an empty code when the password is updated otherwise.
+	UpdatePassword(ctx context.Context, newPassword string, code string, securityStamp uuid.UUID, id uuid.UUID, now time.Time) (*CredentialsModel, error)
+	// UpdatePasswordHash replaces the hash of the current password of the targeted user, for example to upgrade its
+	// parameters. Both values MUST be hashed. Nothing else is updated, and the update only happens if the stored hash
+	// is still oldHash: if the password changed in the meantime, this method fails with bunovel.ErrNotFound.
+	UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string, now time.Time) (*CredentialsModel, error)
 	// ResetPassword sets Password.Validation field. The code value MUST be hashed. This does not nullify the
 	// Password.Hashed field, so authentication can still work while password is being reset.
 	ResetPassword(ctx context.Context, code string, email Email, now time.Time) (*CredentialsModel, error)
 
+	// Lock sets CredentialsModelCore.LockedAt for the targeted user, and replaces their security stamp, so every
+	// token issued to them is revoked.
+	Lock(ctx context.Context, securityStamp uuid.UUID, id uuid.UUID, now time.Time) (*CredentialsModel, error)
+	// Unlock nullifies CredentialsModelCore.LockedAt for the targeted user. It does not fail if the account was not
+	// locked.
+	Unlock(ctx context.Context, id uuid.UUID, now time.Time) (*CredentialsModel, error)
+
+	// RotateSecurityStamp replaces the security stamp of the targeted user, which invalidates every token issued to
+	// them so far.
+	RotateSecurityStamp(ctx context.Context, securityStamp uuid.UUID, id uuid.UUID, now time.Time) (*CredentialsModel, error)
+
+	// Delete sets CredentialsModelCore.DeletedAt for the targeted user, along with the code used to cancel the
+	// deletion. The code value MUST be hashed. The security stamp is replaced, so every token issued to the user is
+	// revoked.
+	Delete(ctx context.Context, code string, securityStamp uuid.UUID, id uuid.UUID, now time.Time) (*CredentialsModel, error)
+	// CancelDeletion nullifies CredentialsModelCore.DeletedAt and CredentialsModelCore.DeletionCode for the targeted
+	// user. The code is the hashed deletion code that was verified: if the deletion was canceled, or the account
+	// purged in the meantime, this method fails with bunovel.ErrNotFound.
+	CancelDeletion(ctx context.Context, id uuid.UUID, code string, now time.Time) (*CredentialsModel, error)
+	// ListDeleted returns the ids of the users deleted before the given time.
+	ListDeleted(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, error)
+
+	// Suspend sets the suspension state of the targeted user, and records it as a suspension event. A nil until date
+	// suspends the user until they are unsuspended. Suspending a user again replaces the previous suspension.
+	Suspend(ctx context.Context, reason, actor string, until *time.Time, id uuid.UUID, now time.Time) (*CredentialsModel, error)
+	// Unsuspend clears the suspension state of the targeted user, and records it as a suspension event. It fails
+	// with bunovel.ErrNotFound if the user was never suspended.
+	Unsuspend(ctx context.Context, actor string, id uuid.UUID, now time.Time) (*CredentialsModel, error)
+	// ListSuspended returns the users whose suspension is active at the given time, along with their total count.
+	// The most recent suspensions come first.
+	ListSuspended(ctx context.Context, now time.Time, limit, offset int) ([]*CredentialsModel, int, error)
+
+	// AuditRecorder records audit events. Within RunInTx, events are saved with the changes they describe.
+	AuditRecorder
+
 	RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository CredentialsRepository) error) error
 }
 
@@ -66,8 +114,37 @@ type CredentialsModelCore struct {
 	// Email.Validation code set.
 	// Once this email is validated, the Email field is updated, and this one is emptied.
 	NewEmail Email `bun:"embed:new_email_"`
+	// NewEmailReportCode is the hashed code sent to the primary Email when a NewEmail is requested. It lets the
+	// owner of the primary address report a change they did not ask for. It is emptied with NewEmail.
+	NewEmailReportCode string `bun:"new_email_report_code"`
 	// Password used to authenticate the user.
 	Password Password `bun:"embed:password_"`
+	// SecurityStamp is embedded in every token issued to the user. It changes when the credentials are updated, so
+	// the tokens issued before are rejected. It is empty until the first change.
+	SecurityStamp uuid.UUID `bun:"security_stamp,nullzero"`
+	// LockedAt is set when the account is locked. A locked account cannot open new sessions until its password is
+	// reset.
+	LockedAt *time.Time `bun:"locked_at"`
+	// DeletedAt is set when the user deletes their account. A deleted account is hidden from other users, and cannot
+	// open new sessions. It is purged once the grace period is over, unless the deletion is canceled.
+	DeletedAt *time.Time `bun:"deleted_at"`
+	// DeletionCode is the hashed code sent to the user when they delete their account, so they can cancel the
+	// deletion. It is emptied with DeletedAt.
+	DeletionCode string `bun:"deletion_code"`
+	// SuspendedAt is set when a moderator suspends the account. A suspended account cannot open new sessions, its
+	// tokens are rejected, and it is hidden from searches.
+	SuspendedAt *time.Time `bun:"suspended_at"`
+	// SuspendedUntil is the date the suspension ends. It is nil for suspensions without expiration.
+	SuspendedUntil *time.Time `bun:"suspended_until"`
+	// SuspensionReason explains the suspension to other moderators.
+	SuspensionReason string `bun:"suspension_reason"`
+	// SuspendedBy is the identity of the internal caller that suspended the account.
+	SuspendedBy string `bun:"suspended_by"`
+}
+
+// Suspended returns true if the account is suspended at the given time.
+func (credentials *CredentialsModelCore) Suspended(now time.Time) bool {
+	return credentials.SuspendedAt != nil && (credentials.SuspendedUntil == nil || credentials.SuspendedUntil.After(now))
 }
 
 func NewCredentialsRepository(db bun.IDB) CredentialsRepository {
@@ -103,19 +180,23 @@ func (repository *credentialsRepositoryImpl) EmailExists(ctx context.Context, em
 	return ok, bunovel.HandlePGError(err)
 }
 
-func (repository *credentialsRepositoryImpl) UpdateEmail(ctx context.Context, email Email, code string, id uuid.UUID, now time.Time) (*CredentialsModel, error) {
+func (repository *credentialsRepositoryImpl) UpdateEmail(ctx context.Context, email Email, code, reportCode string, id uuid.UUID, now time.Time) (*CredentialsModel, error) {
 	model := &CredentialsModel{
 		Metadata: bunovel.NewMetadata(id, time.Time{}, &now),
 		// Set new email with the given validation code. The main email remains unchanged until this email is
 		// validated.
 		CredentialsModelCore: CredentialsModelCore{
-			NewEmail: Email{User: email.User, Domain: email.Domain, Validation: code},
+			NewEmail:           Email{User: email.User, Domain: email.Domain, Validation: code, ValidationIssuedAt: &now},
+			NewEmailReportCode: reportCode,
 		},
 	}
 
 	res, err := repository.db.NewUpdate().Model(model).
 		WherePK().
-		Column("new_email_user", "new_email_domain", "new_email_validation_code", "updated_at").
+		Column(
+			"new_email_user", "new_email_domain", "new_email_validation_code", "new_email_validation_issued_at",
+			"new_email_report_code", "updated_at",
+		).
 		Returning("*").
 		Exec(ctx)
 
@@ -130,13 +211,14 @@ func (repository *credentialsRepositoryImpl) UpdateEmail(ctx context.Context, em
 	return model, nil
 }
 
-func (repository *credentialsRepositoryImpl) ValidateEmail(ctx context.Context, id uuid.UUID, now time.Time) (*CredentialsModel, error) {
+func (repository *credentialsRepositoryImpl) ValidateEmail(ctx context.Context, id uuid.UUID, code string, now time.Time) (*CredentialsModel, error) {
 	model := &CredentialsModel{Metadata: bunovel.NewMetadata(id, time.Time{}, &now)}
 	res, err := repository.db.NewUpdate().Model(model).
 		WherePK().
-		// User must have a pending email.
+		// User must have a pending email, validated by the given code.
 		Where("email_validation_code != ''").
-		Column("email_validation_code", "updated_at").
+		Where("email_validation_code = ?", code).
+		Column("email_validation_code", "email_validation_issued_at", "updated_at").
 		Returning("*").
 		Exec(ctx)
 
@@ -151,21 +233,26 @@ func (repository *credentialsRepositoryImpl) ValidateEmail(ctx context.Context,
 	return model, nil
 }
 
-func (repository *credentialsRepositoryImpl) ValidateNewEmail(ctx context.Context, id uuid.UUID, now time.Time) (*CredentialsModel, error) {
+func (repository *credentialsRepositoryImpl) ValidateNewEmail(ctx context.Context, id uuid.UUID, code string, securityStamp uuid.UUID, now time.Time) (*CredentialsModel, error) {
 	model := &CredentialsModel{Metadata: bunovel.NewMetadata(id, time.Time{}, nil)}
 
 	res, err := repository.db.NewUpdate().Model(model).
 		WherePK().
-		// User must have a pending email update.
+		// User must have a pending email update, validated by the given code.
 		Where("new_email_validation_code != ''").
+		Where("new_email_validation_code = ?", code).
 		// Use the pending update ONLY to update the main email.
 		SetColumn("email_user", "new_email_user").
 		SetColumn("email_domain", "new_email_domain").
 		SetColumn("email_validation_code", "''").
+		SetColumn("email_validation_issued_at", "NULL").
 		// Empty the new_email columns, and update timestamps.
 		SetColumn("new_email_user", "''").
 		SetColumn("new_email_domain", "''").
 		SetColumn("new_email_validation_code", "''").
+		SetColumn("new_email_validation_issued_at", "NULL").
+		SetColumn("new_email_report_code", "''").
+		SetColumn("security_stamp", "?", securityStamp).
 		SetColumn("updated_at", "?", now).
 		Returning("*").
 		Exec(ctx)
@@ -181,19 +268,51 @@ func (repository *credentialsRepositoryImpl) ValidateNewEmail(ctx context.Contex
 	return model, nil
 }
 
-func (repository *credentialsRepositoryImpl) UpdatePassword(ctx context.Context, newPassword string, id uuid.UUID, now time.Time) (*CredentialsModel, error) {
+func (repository *credentialsRepositoryImpl) UpdatePassword(ctx context.Context, newPassword string, code string, securityStamp uuid.UUID, id uuid.UUID, now time.Time) (*CredentialsModel, error) {
 	model := &CredentialsModel{
 		Metadata: bunovel.NewMetadata(id, time.Time{}, &now),
 		CredentialsModelCore: CredentialsModelCore{
-			Password: Password{Hashed: newPassword},
+			Password:      Password{Hashed: newPassword},
+			SecurityStamp: securityStamp,
 		},
 	}
 
-	res, err := repository.db.NewUpdate().Model(model).
-		WherePK().
+	query := repository.db.NewUpdate().Model(model).WherePK()
+
+	if code != "" {
+		// User must have a pending reset, validated by the given code.
+		query = query.
+			Where("password_validation_code != ''").
+			Where("password_validation_code = ?", code)
+	}
+
+	res, err := query.
 		// "password_validation_code" is important to invalidate any pending reset, since a new known password is
 		// now available.
-		Column("password_hashed", "password_validation_code", "updated_at").
+		Column("password_hashed", "password_validation_code", "password_validation_issued_at", "security_stamp", "updated_at").
+		Returning("*").
+		Exec(ctx)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	if err = bunovel.ForceRowsUpdate(res); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (repository *credentialsRepositoryImpl) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string, now time.Time) (*CredentialsModel, error) {
+	model := &CredentialsModel{Metadata: bunovel.NewMetadata(id, time.Time{}, nil)}
+
+	res, err := repository.db.NewUpdate().Model(model).
+		WherePK().
+		// Don't overwrite a password that was changed since the hash was read.
+		Where("password_hashed = ?", oldHash).
+		SetColumn("password_hashed", "?", newHash).
+		SetColumn("updated_at", "?", now).
 		Returning("*").
 		Exec(ctx)
 
@@ -212,13 +331,13 @@ func (repository *credentialsRepositoryImpl) ResetPassword(ctx context.Context,
 	model := &CredentialsModel{
 		Metadata: bunovel.NewMetadata(uuid.Nil, time.Time{}, &now),
 		CredentialsModelCore: CredentialsModelCore{
-			Password: Password{Validation: code},
+			Password: Password{Validation: code, ValidationIssuedAt: &now},
 		},
 	}
 
 	res, err := repository.db.NewUpdate().Model(model).
 		Where(WhereEmail("email", email)).
-		Column("password_validation_code", "updated_at").
+		Column("password_validation_code", "password_validation_issued_at", "updated_at").
 		Returning("*").
 		Exec(ctx)
 
@@ -237,7 +356,7 @@ func (repository *credentialsRepositoryImpl) UpdateEmailValidation(ctx context.C
 	model := &CredentialsModel{
 		Metadata: bunovel.NewMetadata(id, time.Time{}, &now),
 		CredentialsModelCore: CredentialsModelCore{
-			Email: Email{Validation: code},
+			Email: Email{Validation: code, ValidationIssuedAt: &now},
 		},
 	}
 
@@ -245,7 +364,7 @@ func (repository *credentialsRepositoryImpl) UpdateEmailValidation(ctx context.C
 		WherePK().
 		// User must have a pending validation update.
 		Where("email_validation_code != ''").
-		Column("email_validation_code", "updated_at").
+		Column("email_validation_code", "email_validation_issued_at", "updated_at").
 		Returning("*").
 		Exec(ctx)
 
@@ -264,7 +383,7 @@ func (repository *credentialsRepositoryImpl) UpdateNewEmailValidation(ctx contex
 	model := &CredentialsModel{
 		Metadata: bunovel.NewMetadata(id, time.Time{}, &now),
 		CredentialsModelCore: CredentialsModelCore{
-			NewEmail: Email{Validation: code},
+			NewEmail: Email{Validation: code, ValidationIssuedAt: &now},
 		},
 	}
 
@@ -272,7 +391,7 @@ func (repository *credentialsRepositoryImpl) UpdateNewEmailValidation(ctx contex
 		WherePK().
 		// User must have a pending email update.
 		Where("new_email_validation_code != ''").
-		Column("new_email_validation_code", "updated_at").
+		Column("new_email_validation_code", "new_email_validation_issued_at", "updated_at").
 		Returning("*").
 		Exec(ctx)
 
@@ -291,7 +410,10 @@ func (repository *credentialsRepositoryImpl) CancelNewEmail(ctx context.Context,
 	model := &CredentialsModel{Metadata: bunovel.NewMetadata(id, time.Time{}, &now)}
 	res, err := repository.db.NewUpdate().Model(model).
 		WherePK().
-		Column("new_email_user", "new_email_domain", "new_email_validation_code", "updated_at").
+		Column(
+			"new_email_user", "new_email_domain", "new_email_validation_code", "new_email_validation_issued_at",
+			"new_email_report_code", "updated_at",
+		).
 		Returning("*").
 		Exec(ctx)
 
@@ -306,6 +428,225 @@ func (repository *credentialsRepositoryImpl) CancelNewEmail(ctx context.Context,
 	return model, nil
 }
 
+func (repository *credentialsRepositoryImpl) Lock(ctx context.Context, securityStamp uuid.UUID, id uuid.UUID, now time.Time) (*CredentialsModel, error) {
+	model := &CredentialsModel{
+		Metadata:             bunovel.NewMetadata(id, time.Time{}, &now),
+		CredentialsModelCore: CredentialsModelCore{SecurityStamp: securityStamp, LockedAt: &now},
+	}
+
+	res, err := repository.db.NewUpdate().Model(model).
+		WherePK().
+		Column("security_stamp", "locked_at", "updated_at").
+		Returning("*").
+		Exec(ctx)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	if err = bunovel.ForceRowsUpdate(res); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (repository *credentialsRepositoryImpl) Unlock(ctx context.Context, id uuid.UUID, now time.Time) (*CredentialsModel, error) {
+	model := &CredentialsModel{Metadata: bunovel.NewMetadata(id, time.Time{}, &now)}
+
+	res, err := repository.db.NewUpdate().Model(model).
+		WherePK().
+		Column("locked_at", "updated_at").
+		Returning("*").
+		Exec(ctx)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	if err = bunovel.ForceRowsUpdate(res); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (repository *credentialsRepositoryImpl) RotateSecurityStamp(ctx context.Context, securityStamp uuid.UUID, id uuid.UUID, now time.Time) (*CredentialsModel, error) {
+	model := &CredentialsModel{
+		Metadata:             bunovel.NewMetadata(id, time.Time{}, &now),
+		CredentialsModelCore: CredentialsModelCore{SecurityStamp: securityStamp},
+	}
+
+	res, err := repository.db.NewUpdate().Model(model).
+		WherePK().
+		Column("security_stamp", "updated_at").
+		Returning("*").
+		Exec(ctx)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	if err = bunovel.ForceRowsUpdate(res); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (repository *credentialsRepositoryImpl) Delete(ctx context.Context, code string, securityStamp uuid.UUID, id uuid.UUID, now time.Time) (*CredentialsModel, error) {
+	model := &CredentialsModel{
+		Metadata:             bunovel.NewMetadata(id, time.Time{}, &now),
+		CredentialsModelCore: CredentialsModelCore{SecurityStamp: securityStamp, DeletedAt: &now, DeletionCode: code},
+	}
+
+	res, err := repository.db.NewUpdate().Model(model).
+		WherePK().
+		Column("security_stamp", "deleted_at", "deletion_code", "updated_at").
+		Returning("*").
+		Exec(ctx)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	if err = bunovel.ForceRowsUpdate(res); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (repository *credentialsRepositoryImpl) CancelDeletion(ctx context.Context, id uuid.UUID, code string, now time.Time) (*CredentialsModel, error) {
+	model := &CredentialsModel{Metadata: bunovel.NewMetadata(id, time.Time{}, &now)}
+
+	res, err := repository.db.NewUpdate().Model(model).
+		WherePK().
+		// User must still be deleted, with the given code.
+		Where("deleted_at IS NOT NULL").
+		Where("deletion_code = ?", code).
+		Column("deleted_at", "deletion_code", "updated_at").
+		Returning("*").
+		Exec(ctx)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	if err = bunovel.ForceRowsUpdate(res); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (repository *credentialsRepositoryImpl) ListDeleted(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+
+	err := repository.db.NewSelect().Model(new(CredentialsModel)).
+		Column("id").
+		Where("deleted_at <= ?", deletedBefore).
+		Order("deleted_at ASC").
+		Scan(ctx, &ids)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return ids, nil
+}
+
+// updateSuspension writes the suspension state of a user, and the matching audit event, in a single transaction, so
+// the history cannot miss a change.
+func (repository *credentialsRepositoryImpl) updateSuspension(ctx context.Context, model *CredentialsModel, event *AuditEventModelCore, now time.Time, conditions ...string) error {
+	return repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		query := tx.NewUpdate().Model(model).WherePK()
+		for _, condition := range conditions {
+			query = query.Where(condition)
+		}
+
+		res, err := query.
+			Column("suspended_at", "suspended_until", "suspension_reason", "suspended_by", "updated_at").
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err = bunovel.ForceRowsUpdate(res); err != nil {
+			return err
+		}
+
+		_, err = recordAuditEvent(ctx, tx, event, uuid.New(), now)
+		return err
+	})
+}
+
+func (repository *credentialsRepositoryImpl) Suspend(ctx context.Context, reason, actor string, until *time.Time, id uuid.UUID, now time.Time) (*CredentialsModel, error) {
+	model := &CredentialsModel{
+		Metadata: bunovel.NewMetadata(id, time.Time{}, &now),
+		CredentialsModelCore: CredentialsModelCore{
+			SuspendedAt:      &now,
+			SuspendedUntil:   until,
+			SuspensionReason: reason,
+			SuspendedBy:      actor,
+		},
+	}
+
+	event := &AuditEventModelCore{
+		Kind:    AuditEventAdminSuspendUser,
+		Actor:   actor,
+		UserID:  id,
+		Details: map[string]string{"reason": reason},
+	}
+	if until != nil {
+		event.Details["until"] = until.UTC().Format(time.RFC3339)
+	}
+
+	if err := repository.updateSuspension(ctx, model, event, now); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *credentialsRepositoryImpl) Unsuspend(ctx context.Context, actor string, id uuid.UUID, now time.Time) (*CredentialsModel, error) {
+	model := &CredentialsModel{Metadata: bunovel.NewMetadata(id, time.Time{}, &now)}
+
+	event := &AuditEventModelCore{
+		Kind:   AuditEventAdminUnsuspendUser,
+		Actor:  actor,
+		UserID: id,
+	}
+
+	// Only suspended users can be unsuspended, so the history does not record changes that did nothing.
+	if err := repository.updateSuspension(ctx, model, event, now, "suspended_at IS NOT NULL"); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *credentialsRepositoryImpl) ListSuspended(ctx context.Context, now time.Time, limit, offset int) ([]*CredentialsModel, int, error) {
+	var results []*CredentialsModel
+
+	count, err := repository.db.NewSelect().Model(&results).
+		Where("suspended_at IS NOT NULL").
+		Where("suspended_until IS NULL OR suspended_until > ?", now).
+		Order("suspended_at DESC").
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, bunovel.HandlePGError(err)
+	}
+
+	return results, count, nil
+}
+
+func (repository *credentialsRepositoryImpl) RecordAuditEvent(ctx context.Context, data *AuditEventModelCore, id uuid.UUID, now time.Time) (*AuditEventModel, error) {
+	return recordAuditEvent(ctx, repository.db, data, id, now)
+}
+
 func (repository *credentialsRepositoryImpl) RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository CredentialsRepository) error) error {
 	return repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
 		return callback(ctx, NewCredentialsRepository(tx))
@@ -7,6 +7,7 @@ import (
 	"github.com/a-novel/bunovel"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	"github.com/uptrace/bun"
 	"io/fs"
@@ -194,98 +195,110 @@ func TestCredentialsRepository_UpdateEmail(t *testing.T) {
 	data := []struct {
 		name string
 
-		email dao.Email
-		code  string
-		id    uuid.UUID
-		now   time.Time
+		email      dao.Email
+		code       string
+		reportCode string
+		id         uuid.UUID
+		now        time.Time
 
 		expect    *dao.CredentialsModel
 		expectErr error
 	}{
 		{
-			name:  "Success",
-			email: MustParseEmailWithValidation("new-user1@domain.com", "this-code-should-be-ignored"),
-			code:  "validation-code",
-			id:    goframework.NumberUUID(1000),
-			now:   updateTime,
+			name:       "Success",
+			email:      MustParseEmailWithValidation("new-user1@domain.com", "this-code-should-be-ignored"),
+			code:       "validation-code",
+			reportCode: "report-code",
+			id:         goframework.NumberUUID(1000),
+			now:        updateTime,
 			expect: &dao.CredentialsModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
 				CredentialsModelCore: dao.CredentialsModelCore{
-					Email:    MustParseEmail("user1@domain.com"),
-					NewEmail: MustParseEmailWithValidation("new-user1@domain.com", "validation-code"),
-					Password: dao.Password{Hashed: "password-hashed"},
+					Email:              MustParseEmail("user1@domain.com"),
+					NewEmail:           MustParseEmailWithValidationAt("new-user1@domain.com", "validation-code", updateTime),
+					NewEmailReportCode: "report-code",
+					Password:           dao.Password{Hashed: "password-hashed"},
 				},
 			},
 		},
 		{
-			name:  "Success/WithValidationOnMainEmail",
-			email: MustParseEmailWithValidation("new-user2@domain.com", "this-code-should-be-ignored"),
-			code:  "validation-code",
-			id:    goframework.NumberUUID(1001),
-			now:   updateTime,
+			name:       "Success/WithValidationOnMainEmail",
+			email:      MustParseEmailWithValidation("new-user2@domain.com", "this-code-should-be-ignored"),
+			code:       "validation-code",
+			reportCode: "report-code",
+			id:         goframework.NumberUUID(1001),
+			now:        updateTime,
 			expect: &dao.CredentialsModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &updateTime),
 				CredentialsModelCore: dao.CredentialsModelCore{
-					Email:    MustParseEmailWithValidation("user2@domain.com", "initial-validation-code"),
-					NewEmail: MustParseEmailWithValidation("new-user2@domain.com", "validation-code"),
-					Password: dao.Password{Hashed: "password-hashed"},
+					Email:              MustParseEmailWithValidation("user2@domain.com", "initial-validation-code"),
+					NewEmail:           MustParseEmailWithValidationAt("new-user2@domain.com", "validation-code", updateTime),
+					NewEmailReportCode: "report-code",
+					Password:           dao.Password{Hashed: "password-hashed"},
 				},
 			},
 		},
 		{
-			name:  "Success/WithPreviousNewEmail",
-			email: MustParseEmailWithValidation("new-user3@domain.com", "this-code-should-be-ignored"),
-			code:  "validation-code",
-			id:    goframework.NumberUUID(1002),
-			now:   updateTime,
+			name:       "Success/WithPreviousNewEmail",
+			email:      MustParseEmailWithValidation("new-user3@domain.com", "this-code-should-be-ignored"),
+			code:       "validation-code",
+			reportCode: "report-code",
+			id:         goframework.NumberUUID(1002),
+			now:        updateTime,
 			expect: &dao.CredentialsModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1002), baseTime, &updateTime),
 				CredentialsModelCore: dao.CredentialsModelCore{
-					Email:    MustParseEmail("user3@domain.com"),
-					NewEmail: MustParseEmailWithValidation("new-user3@domain.com", "validation-code"),
-					Password: dao.Password{Hashed: "password-hashed"},
+					Email:              MustParseEmail("user3@domain.com"),
+					NewEmail:           MustParseEmailWithValidationAt("new-user3@domain.com", "validation-code", updateTime),
+					NewEmailReportCode: "report-code",
+					Password:           dao.Password{Hashed: "password-hashed"},
 				},
 			},
 		},
 		{
-			name:  "Success/WhenAnotherAccountHasTheSameEmailPendingValidation",
-			email: MustParseEmailWithValidation("new-other-user3@domain.com", "this-code-should-be-ignored"),
-			code:  "validation-code",
-			id:    goframework.NumberUUID(1000),
-			now:   updateTime,
+			name:       "Success/WhenAnotherAccountHasTheSameEmailPendingValidation",
+			email:      MustParseEmailWithValidation("new-other-user3@domain.com", "this-code-should-be-ignored"),
+			code:       "validation-code",
+			reportCode: "report-code",
+			id:         goframework.NumberUUID(1000),
+			now:        updateTime,
 			expect: &dao.CredentialsModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
 				CredentialsModelCore: dao.CredentialsModelCore{
-					Email:    MustParseEmail("user1@domain.com"),
-					NewEmail: MustParseEmailWithValidation("new-other-user3@domain.com", "validation-code"),
-					Password: dao.Password{Hashed: "password-hashed"},
+					Email:              MustParseEmail("user1@domain.com"),
+					NewEmail:           MustParseEmailWithValidationAt("new-other-user3@domain.com", "validation-code", updateTime),
+					NewEmailReportCode: "report-code",
+					Password:           dao.Password{Hashed: "password-hashed"},
 				},
 			},
 		},
 		// This is allowed, validating the email will however fail. It should be blocked in the service, by checking
 		// if the email is already taken.
 		{
-			name:  "Success/TakenByAnotherAccount",
-			email: MustParseEmail("user2@domain.com"),
-			code:  "validation-code",
-			id:    goframework.NumberUUID(1000),
-			now:   updateTime,
+			name:       "Success/TakenByAnotherAccount",
+			email:      MustParseEmail("user2@domain.com"),
+			code:       "validation-code",
+			reportCode: "report-code",
+			id:         goframework.NumberUUID(1000),
+			now:        updateTime,
 			expect: &dao.CredentialsModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
 				CredentialsModelCore: dao.CredentialsModelCore{
-					Email:    MustParseEmail("user1@domain.com"),
-					NewEmail: MustParseEmailWithValidation("user2@domain.com", "validation-code"),
-					Password: dao.Password{Hashed: "password-hashed"},
+					Email:              MustParseEmail("user1@domain.com"),
+					NewEmail:           MustParseEmailWithValidationAt("user2@domain.com", "validation-code", updateTime),
+					NewEmailReportCode: "report-code",
+					Password:           dao.Password{Hashed: "password-hashed"},
 				},
 			},
 		},
 		{
-			name:      "Error/NotFound",
-			email:     MustParseEmailWithValidation("new-user1@domain.com", "this-code-should-be-ignored"),
-			code:      "validation-code",
-			id:        goframework.NumberUUID(100),
-			now:       updateTime,
-			expectErr: bunovel.ErrNotFound,
+			name:       "Error/NotFound",
+			email:      MustParseEmailWithValidation("new-user1@domain.com", "this-code-should-be-ignored"),
+			code:       "validation-code",
+			reportCode: "report-code",
+			id:         goframework.NumberUUID(100),
+			now:        updateTime,
+			expectErr:  bunovel.ErrNotFound,
 		},
 		{
 			name:      "Error/WithoutValidationCode",
@@ -299,20 +312,22 @@ func TestCredentialsRepository_UpdateEmail(t *testing.T) {
 			email: dao.Email{
 				Domain: "domain.com",
 			},
-			code:      "validation-code",
-			id:        goframework.NumberUUID(1000),
-			now:       updateTime,
-			expectErr: bunovel.ErrConstraintViolation,
+			code:       "validation-code",
+			reportCode: "report-code",
+			id:         goframework.NumberUUID(1000),
+			now:        updateTime,
+			expectErr:  bunovel.ErrConstraintViolation,
 		},
 		{
 			name: "Error/WithoutDomain",
 			email: dao.Email{
 				User: "new-user1",
 			},
-			code:      "validation-code",
-			id:        goframework.NumberUUID(1000),
-			now:       updateTime,
-			expectErr: bunovel.ErrConstraintViolation,
+			code:       "validation-code",
+			reportCode: "report-code",
+			id:         goframework.NumberUUID(1000),
+			now:        updateTime,
+			expectErr:  bunovel.ErrConstraintViolation,
 		},
 	}
 
@@ -323,7 +338,7 @@ func TestCredentialsRepository_UpdateEmail(t *testing.T) {
 				require.NoError(st, err)
 				defer stx.Rollback()
 
-				res, err := dao.NewCredentialsRepository(stx).UpdateEmail(ctx, d.email, d.code, d.id, d.now)
+				res, err := dao.NewCredentialsRepository(stx).UpdateEmail(ctx, d.email, d.code, d.reportCode, d.id, d.now)
 				require.ErrorIs(t, err, d.expectErr)
 				require.Equal(t, d.expect, res)
 			})
@@ -365,8 +380,9 @@ func TestCredentialsRepository_ValidateEmail(t *testing.T) {
 	data := []struct {
 		name string
 
-		id  uuid.UUID
-		now time.Time
+		id   uuid.UUID
+		code string
+		now  time.Time
 
 		expect    *dao.CredentialsModel
 		expectErr error
@@ -374,6 +390,7 @@ func TestCredentialsRepository_ValidateEmail(t *testing.T) {
 		{
 			name: "Success",
 			id:   goframework.NumberUUID(1000),
+			code: "initial-validation-code",
 			now:  updateTime,
 			expect: &dao.CredentialsModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
@@ -386,6 +403,7 @@ func TestCredentialsRepository_ValidateEmail(t *testing.T) {
 		{
 			name: "Success/WithEmailPendingValidation",
 			id:   goframework.NumberUUID(1001),
+			code: "initial-validation-code",
 			now:  updateTime,
 			expect: &dao.CredentialsModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &updateTime),
@@ -399,12 +417,21 @@ func TestCredentialsRepository_ValidateEmail(t *testing.T) {
 		{
 			name:      "Error/NoPendingValidation",
 			id:        goframework.NumberUUID(1002),
+			code:      "initial-validation-code",
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
+		},
+		{
+			name:      "Error/WrongCode",
+			id:        goframework.NumberUUID(1000),
+			code:      "other-validation-code",
 			now:       updateTime,
 			expectErr: bunovel.ErrNotFound,
 		},
 		{
 			name:      "Error/NotFound",
 			id:        goframework.NumberUUID(1),
+			code:      "initial-validation-code",
 			now:       updateTime,
 			expectErr: bunovel.ErrNotFound,
 		},
@@ -417,7 +444,7 @@ func TestCredentialsRepository_ValidateEmail(t *testing.T) {
 				require.NoError(st, err)
 				defer stx.Rollback()
 
-				res, err := dao.NewCredentialsRepository(stx).ValidateEmail(ctx, d.id, d.now)
+				res, err := dao.NewCredentialsRepository(stx).ValidateEmail(ctx, d.id, d.code, d.now)
 				require.ErrorIs(t, err, d.expectErr)
 				require.Equal(t, d.expect, res)
 			})
@@ -468,51 +495,69 @@ func TestCredentialsRepository_ValidateNewEmail(t *testing.T) {
 	data := []struct {
 		name string
 
-		id  uuid.UUID
-		now time.Time
+		id            uuid.UUID
+		code          string
+		securityStamp uuid.UUID
+		now           time.Time
 
 		expect    *dao.CredentialsModel
 		expectErr error
 	}{
 		{
-			name: "Success",
-			id:   goframework.NumberUUID(1000),
-			now:  updateTime,
+			name:          "Success",
+			id:            goframework.NumberUUID(1000),
+			code:          "validation-code",
+			securityStamp: goframework.NumberUUID(10),
+			now:           updateTime,
 			expect: &dao.CredentialsModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
 				CredentialsModelCore: dao.CredentialsModelCore{
-					Email:    MustParseEmail("new-user1@domain.com"),
-					Password: dao.Password{Hashed: "password-hashed"},
+					Email:         MustParseEmail("new-user1@domain.com"),
+					Password:      dao.Password{Hashed: "password-hashed"},
+					SecurityStamp: goframework.NumberUUID(10),
 				},
 			},
 		},
 		{
-			name: "Success/WithMainEmailPendingValidation",
-			id:   goframework.NumberUUID(1001),
-			now:  updateTime,
+			name:          "Success/WithMainEmailPendingValidation",
+			id:            goframework.NumberUUID(1001),
+			code:          "validation-code",
+			securityStamp: goframework.NumberUUID(10),
+			now:           updateTime,
 			expect: &dao.CredentialsModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &updateTime),
 				CredentialsModelCore: dao.CredentialsModelCore{
-					Email:    MustParseEmail("new-user2@domain.com"),
-					Password: dao.Password{Hashed: "password-hashed"},
+					Email:         MustParseEmail("new-user2@domain.com"),
+					Password:      dao.Password{Hashed: "password-hashed"},
+					SecurityStamp: goframework.NumberUUID(10),
 				},
 			},
 		},
 		{
 			name:      "Error/NoPendingValidation",
 			id:        goframework.NumberUUID(1002),
+			code:      "validation-code",
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
+		},
+		{
+			name:      "Error/WrongCode",
+			id:        goframework.NumberUUID(1000),
+			code:      "other-validation-code",
 			now:       updateTime,
 			expectErr: bunovel.ErrNotFound,
 		},
 		{
 			name:      "Error/NotFound",
 			id:        goframework.NumberUUID(1),
+			code:      "validation-code",
 			now:       updateTime,
 			expectErr: bunovel.ErrNotFound,
 		},
 		{
 			name:      "Error/AlreadyTaken",
 			id:        goframework.NumberUUID(1003),
+			code:      "validation-code",
 			now:       updateTime,
 			expectErr: bunovel.ErrUniqConstraintViolation,
 		},
@@ -525,7 +570,7 @@ func TestCredentialsRepository_ValidateNewEmail(t *testing.T) {
 				require.NoError(st, err)
 				defer stx.Rollback()
 
-				res, err := dao.NewCredentialsRepository(stx).ValidateNewEmail(ctx, d.id, d.now)
+				res, err := dao.NewCredentialsRepository(stx).ValidateNewEmail(ctx, d.id, d.code, d.securityStamp, d.now)
 				require.ErrorIs(t, err, d.expectErr)
 				require.Equal(t, d.expect, res)
 			})
@@ -554,50 +599,190 @@ func TestCredentialsRepository_UpdatePassword(t *testing.T) {
 				Password: dao.Password{Hashed: "password-hashed", Validation: "validation-code"},
 			},
 		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1002), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:         MustParseEmail("user3@domain.com"),
+				Password:      dao.Password{Hashed: "password-hashed"},
+				SecurityStamp: goframework.NumberUUID(10),
+			},
+		},
 	}
 
 	data := []struct {
 		name string
 
-		newPassword string
-		id          uuid.UUID
-		now         time.Time
+		newPassword   string
+		code          string
+		securityStamp uuid.UUID
+		id            uuid.UUID
+		now           time.Time
 
 		expect    *dao.CredentialsModel
 		expectErr error
 	}{
 		{
-			name:        "Success",
-			newPassword: "new-password-hashed",
-			id:          goframework.NumberUUID(1000),
-			now:         updateTime,
+			name:          "Success",
+			newPassword:   "new-password-hashed",
+			securityStamp: goframework.NumberUUID(11),
+			id:            goframework.NumberUUID(1000),
+			now:           updateTime,
 			expect: &dao.CredentialsModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
 				CredentialsModelCore: dao.CredentialsModelCore{
-					Email:    MustParseEmail("user1@domain.com"),
-					Password: dao.Password{Hashed: "new-password-hashed"},
+					Email:         MustParseEmail("user1@domain.com"),
+					Password:      dao.Password{Hashed: "new-password-hashed"},
+					SecurityStamp: goframework.NumberUUID(11),
 				},
 			},
 		},
 		{
-			name:        "Success/WithPendingReset",
-			newPassword: "new-password-hashed",
-			id:          goframework.NumberUUID(1001),
-			now:         updateTime,
+			name:          "Success/WithPendingReset",
+			newPassword:   "new-password-hashed",
+			securityStamp: goframework.NumberUUID(11),
+			id:            goframework.NumberUUID(1001),
+			now:           updateTime,
 			expect: &dao.CredentialsModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &updateTime),
 				CredentialsModelCore: dao.CredentialsModelCore{
-					Email:    MustParseEmail("user2@domain.com"),
-					Password: dao.Password{Hashed: "new-password-hashed"},
+					Email:         MustParseEmail("user2@domain.com"),
+					Password:      dao.Password{Hashed: "new-password-hashed"},
+					SecurityStamp: goframework.NumberUUID(11),
 				},
 			},
 		},
 		{
-			name:        "Error/NotFound",
-			newPassword: "new-password-hashed",
-			id:          goframework.NumberUUID(100),
-			now:         updateTime,
-			expectErr:   bunovel.ErrNotFound,
+			name:          "Success/WithResetCode",
+			newPassword:   "new-password-hashed",
+			code:          "validation-code",
+			securityStamp: goframework.NumberUUID(11),
+			id:            goframework.NumberUUID(1001),
+			now:           updateTime,
+			expect: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &updateTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:         MustParseEmail("user2@domain.com"),
+					Password:      dao.Password{Hashed: "new-password-hashed"},
+					SecurityStamp: goframework.NumberUUID(11),
+				},
+			},
+		},
+		{
+			name:          "Success/KeepSecurityStamp",
+			newPassword:   "new-password-hashed",
+			securityStamp: goframework.NumberUUID(10),
+			id:            goframework.NumberUUID(1002),
+			now:           updateTime,
+			expect: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1002), baseTime, &updateTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:         MustParseEmail("user3@domain.com"),
+					Password:      dao.Password{Hashed: "new-password-hashed"},
+					SecurityStamp: goframework.NumberUUID(10),
+				},
+			},
+		},
+		{
+			name:          "Error/WrongResetCode",
+			newPassword:   "new-password-hashed",
+			code:          "fake-code",
+			securityStamp: goframework.NumberUUID(11),
+			id:            goframework.NumberUUID(1001),
+			now:           updateTime,
+			expectErr:     bunovel.ErrNotFound,
+		},
+		{
+			name:          "Error/NoPendingReset",
+			newPassword:   "new-password-hashed",
+			code:          "validation-code",
+			securityStamp: goframework.NumberUUID(11),
+			id:            goframework.NumberUUID(1000),
+			now:           updateTime,
+			expectErr:     bunovel.ErrNotFound,
+		},
+		{
+			name:          "Error/NotFound",
+			newPassword:   "new-password-hashed",
+			securityStamp: goframework.NumberUUID(11),
+			id:            goframework.NumberUUID(100),
+			now:           updateTime,
+			expectErr:     bunovel.ErrNotFound,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				res, err := dao.NewCredentialsRepository(stx).UpdatePassword(ctx, d.newPassword, d.code, d.securityStamp, d.id, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestCredentialsRepository_UpdatePasswordHash(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.CredentialsModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:         MustParseEmail("user1@domain.com"),
+				Password:      dao.Password{Hashed: "password-hashed", Validation: "validation-code", ValidationIssuedAt: &baseTime},
+				SecurityStamp: goframework.NumberUUID(10),
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		id      uuid.UUID
+		oldHash string
+		newHash string
+		now     time.Time
+
+		expect    *dao.CredentialsModel
+		expectErr error
+	}{
+		{
+			name:    "Success",
+			id:      goframework.NumberUUID(1000),
+			oldHash: "password-hashed",
+			newHash: "new-password-hashed",
+			now:     updateTime,
+			expect: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:         MustParseEmail("user1@domain.com"),
+					Password:      dao.Password{Hashed: "new-password-hashed", Validation: "validation-code", ValidationIssuedAt: &baseTime},
+					SecurityStamp: goframework.NumberUUID(10),
+				},
+			},
+		},
+		{
+			name:      "Error/PasswordChanged",
+			id:        goframework.NumberUUID(1000),
+			oldHash:   "other-password-hashed",
+			newHash:   "new-password-hashed",
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
+		},
+		{
+			name:      "Error/NotFound",
+			id:        goframework.NumberUUID(100),
+			oldHash:   "password-hashed",
+			newHash:   "new-password-hashed",
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
 		},
 	}
 
@@ -608,7 +793,7 @@ func TestCredentialsRepository_UpdatePassword(t *testing.T) {
 				require.NoError(st, err)
 				defer stx.Rollback()
 
-				res, err := dao.NewCredentialsRepository(stx).UpdatePassword(ctx, d.newPassword, d.id, d.now)
+				res, err := dao.NewCredentialsRepository(stx).UpdatePasswordHash(ctx, d.id, d.oldHash, d.newHash, d.now)
 				require.ErrorIs(t, err, d.expectErr)
 				require.Equal(t, d.expect, res)
 			})
@@ -659,7 +844,7 @@ func TestCredentialsRepository_ResetPassword(t *testing.T) {
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
 				CredentialsModelCore: dao.CredentialsModelCore{
 					Email:    MustParseEmail("user1@domain.com"),
-					Password: dao.Password{Hashed: "password-hashed", Validation: "validation-code"},
+					Password: dao.Password{Hashed: "password-hashed", Validation: "validation-code", ValidationIssuedAt: &updateTime},
 				},
 			},
 		},
@@ -673,7 +858,7 @@ func TestCredentialsRepository_ResetPassword(t *testing.T) {
 				CredentialsModelCore: dao.CredentialsModelCore{
 					Email:    MustParseEmail("user2@domain.com"),
 					NewEmail: MustParseEmailWithValidation("new-user2@domain.com", "validation-code"),
-					Password: dao.Password{Hashed: "password-hashed", Validation: "validation-code"},
+					Password: dao.Password{Hashed: "password-hashed", Validation: "validation-code", ValidationIssuedAt: &updateTime},
 				},
 			},
 		},
@@ -758,7 +943,7 @@ func TestCredentialsRepository_UpdateEmailValidation(t *testing.T) {
 			expect: &dao.CredentialsModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
 				CredentialsModelCore: dao.CredentialsModelCore{
-					Email:    MustParseEmailWithValidation("user1@domain.com", "validation-code"),
+					Email:    MustParseEmailWithValidationAt("user1@domain.com", "validation-code", updateTime),
 					Password: dao.Password{Hashed: "password-hashed"},
 				},
 			},
@@ -771,7 +956,7 @@ func TestCredentialsRepository_UpdateEmailValidation(t *testing.T) {
 			expect: &dao.CredentialsModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &updateTime),
 				CredentialsModelCore: dao.CredentialsModelCore{
-					Email:    MustParseEmailWithValidation("user2@domain.com", "validation-code"),
+					Email:    MustParseEmailWithValidationAt("user2@domain.com", "validation-code", updateTime),
 					NewEmail: MustParseEmailWithValidation("new-user2@domain.com", "validation-code"),
 					Password: dao.Password{Hashed: "password-hashed"},
 				},
@@ -865,7 +1050,7 @@ func TestCredentialsRepository_UpdateNewEmailValidation(t *testing.T) {
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
 				CredentialsModelCore: dao.CredentialsModelCore{
 					Email:    MustParseEmail("user1@domain.com"),
-					NewEmail: MustParseEmailWithValidation("new-user1@domain.com", "validation-code"),
+					NewEmail: MustParseEmailWithValidationAt("new-user1@domain.com", "validation-code", updateTime),
 					Password: dao.Password{Hashed: "password-hashed"},
 				},
 			},
@@ -879,7 +1064,7 @@ func TestCredentialsRepository_UpdateNewEmailValidation(t *testing.T) {
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &updateTime),
 				CredentialsModelCore: dao.CredentialsModelCore{
 					Email:    MustParseEmailWithValidation("user2@domain.com", "initial-validation-code"),
-					NewEmail: MustParseEmailWithValidation("new-user2@domain.com", "validation-code"),
+					NewEmail: MustParseEmailWithValidationAt("new-user2@domain.com", "validation-code", updateTime),
 					Password: dao.Password{Hashed: "password-hashed"},
 				},
 			},
@@ -1015,3 +1200,744 @@ func TestCredentialsRepository_CancelNewEmail(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestCredentialsRepository_RotateSecurityStamp(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.CredentialsModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:    MustParseEmail("user1@domain.com"),
+				Password: dao.Password{Hashed: "password-hashed"},
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:         MustParseEmail("user2@domain.com"),
+				Password:      dao.Password{Hashed: "password-hashed"},
+				SecurityStamp: goframework.NumberUUID(10),
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		securityStamp uuid.UUID
+		id            uuid.UUID
+		now           time.Time
+
+		expect    *dao.CredentialsModel
+		expectErr error
+	}{
+		{
+			name:          "Success",
+			securityStamp: goframework.NumberUUID(11),
+			id:            goframework.NumberUUID(1000),
+			now:           updateTime,
+			expect: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:         MustParseEmail("user1@domain.com"),
+					Password:      dao.Password{Hashed: "password-hashed"},
+					SecurityStamp: goframework.NumberUUID(11),
+				},
+			},
+		},
+		{
+			name:          "Success/ReplaceSecurityStamp",
+			securityStamp: goframework.NumberUUID(11),
+			id:            goframework.NumberUUID(1001),
+			now:           updateTime,
+			expect: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &updateTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:         MustParseEmail("user2@domain.com"),
+					Password:      dao.Password{Hashed: "password-hashed"},
+					SecurityStamp: goframework.NumberUUID(11),
+				},
+			},
+		},
+		{
+			name:          "Error/NotFound",
+			securityStamp: goframework.NumberUUID(11),
+			id:            goframework.NumberUUID(100),
+			now:           updateTime,
+			expectErr:     bunovel.ErrNotFound,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				res, err := dao.NewCredentialsRepository(stx).RotateSecurityStamp(ctx, d.securityStamp, d.id, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestCredentialsRepository_Lock(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.CredentialsModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:         MustParseEmail("user1@domain.com"),
+				Password:      dao.Password{Hashed: "password-hashed"},
+				SecurityStamp: goframework.NumberUUID(10),
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		securityStamp uuid.UUID
+		id            uuid.UUID
+		now           time.Time
+
+		expect    *dao.CredentialsModel
+		expectErr error
+	}{
+		{
+			name:          "Success",
+			securityStamp: goframework.NumberUUID(11),
+			id:            goframework.NumberUUID(1000),
+			now:           updateTime,
+			expect: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:         MustParseEmail("user1@domain.com"),
+					Password:      dao.Password{Hashed: "password-hashed"},
+					SecurityStamp: goframework.NumberUUID(11),
+					LockedAt:      &updateTime,
+				},
+			},
+		},
+		{
+			name:          "Error/NotFound",
+			securityStamp: goframework.NumberUUID(11),
+			id:            goframework.NumberUUID(100),
+			now:           updateTime,
+			expectErr:     bunovel.ErrNotFound,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				res, err := dao.NewCredentialsRepository(stx).Lock(ctx, d.securityStamp, d.id, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestCredentialsRepository_Unlock(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.CredentialsModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:    MustParseEmail("user1@domain.com"),
+				Password: dao.Password{Hashed: "password-hashed"},
+				LockedAt: &baseTime,
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:    MustParseEmail("user2@domain.com"),
+				Password: dao.Password{Hashed: "password-hashed"},
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		id  uuid.UUID
+		now time.Time
+
+		expect    *dao.CredentialsModel
+		expectErr error
+	}{
+		{
+			name: "Success",
+			id:   goframework.NumberUUID(1000),
+			now:  updateTime,
+			expect: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    MustParseEmail("user1@domain.com"),
+					Password: dao.Password{Hashed: "password-hashed"},
+				},
+			},
+		},
+		{
+			name: "Success/NotLocked",
+			id:   goframework.NumberUUID(1001),
+			now:  updateTime,
+			expect: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &updateTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    MustParseEmail("user2@domain.com"),
+					Password: dao.Password{Hashed: "password-hashed"},
+				},
+			},
+		},
+		{
+			name:      "Error/NotFound",
+			id:        goframework.NumberUUID(100),
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				res, err := dao.NewCredentialsRepository(stx).Unlock(ctx, d.id, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestCredentialsRepository_Delete(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.CredentialsModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:         MustParseEmail("user1@domain.com"),
+				Password:      dao.Password{Hashed: "password-hashed"},
+				SecurityStamp: goframework.NumberUUID(10),
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		code          string
+		securityStamp uuid.UUID
+		id            uuid.UUID
+		now           time.Time
+
+		expect    *dao.CredentialsModel
+		expectErr error
+	}{
+		{
+			name:          "Success",
+			code:          "deletion-code",
+			securityStamp: goframework.NumberUUID(11),
+			id:            goframework.NumberUUID(1000),
+			now:           updateTime,
+			expect: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:         MustParseEmail("user1@domain.com"),
+					Password:      dao.Password{Hashed: "password-hashed"},
+					SecurityStamp: goframework.NumberUUID(11),
+					DeletedAt:     &updateTime,
+					DeletionCode:  "deletion-code",
+				},
+			},
+		},
+		{
+			name:          "Error/NotFound",
+			code:          "deletion-code",
+			securityStamp: goframework.NumberUUID(11),
+			id:            goframework.NumberUUID(100),
+			now:           updateTime,
+			expectErr:     bunovel.ErrNotFound,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				res, err := dao.NewCredentialsRepository(stx).Delete(ctx, d.code, d.securityStamp, d.id, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestCredentialsRepository_CancelDeletion(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.CredentialsModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:        MustParseEmail("user1@domain.com"),
+				Password:     dao.Password{Hashed: "password-hashed"},
+				DeletedAt:    &baseTime,
+				DeletionCode: "deletion-code",
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:    MustParseEmail("user2@domain.com"),
+				Password: dao.Password{Hashed: "password-hashed"},
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		id   uuid.UUID
+		code string
+		now  time.Time
+
+		expect    *dao.CredentialsModel
+		expectErr error
+	}{
+		{
+			name: "Success",
+			id:   goframework.NumberUUID(1000),
+			code: "deletion-code",
+			now:  updateTime,
+			expect: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    MustParseEmail("user1@domain.com"),
+					Password: dao.Password{Hashed: "password-hashed"},
+				},
+			},
+		},
+		{
+			name:      "Error/WrongCode",
+			id:        goframework.NumberUUID(1000),
+			code:      "fake-code",
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
+		},
+		{
+			name:      "Error/NotDeleted",
+			id:        goframework.NumberUUID(1001),
+			code:      "",
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
+		},
+		{
+			name:      "Error/NotFound",
+			id:        goframework.NumberUUID(100),
+			code:      "deletion-code",
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				res, err := dao.NewCredentialsRepository(stx).CancelDeletion(ctx, d.id, d.code, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestCredentialsRepository_ListDeleted(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	deletedLater := baseTime.Add(time.Hour)
+
+	fixtures := []*dao.CredentialsModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:     MustParseEmail("user1@domain.com"),
+				Password:  dao.Password{Hashed: "password-hashed"},
+				DeletedAt: &deletedLater,
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:     MustParseEmail("user2@domain.com"),
+				Password:  dao.Password{Hashed: "password-hashed"},
+				DeletedAt: &baseTime,
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1002), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:    MustParseEmail("user3@domain.com"),
+				Password: dao.Password{Hashed: "password-hashed"},
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		deletedBefore time.Time
+
+		expect    []uuid.UUID
+		expectErr error
+	}{
+		{
+			name:          "Success",
+			deletedBefore: deletedLater,
+			expect:        []uuid.UUID{goframework.NumberUUID(1001), goframework.NumberUUID(1000)},
+		},
+		{
+			name:          "Success/GracePeriodNotOver",
+			deletedBefore: baseTime,
+			expect:        []uuid.UUID{goframework.NumberUUID(1001)},
+		},
+		{
+			name:          "Success/NoResults",
+			deletedBefore: baseTime.Add(-time.Hour),
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := dao.NewCredentialsRepository(tx).ListDeleted(ctx, d.deletedBefore)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestCredentialsRepository_Suspend(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	until := updateTime.Add(24 * time.Hour)
+
+	fixtures := []*dao.CredentialsModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:    MustParseEmail("user1@domain.com"),
+				Password: dao.Password{Hashed: "password-hashed"},
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		id    uuid.UUID
+		until *time.Time
+		now   time.Time
+
+		expect    *dao.CredentialsModel
+		expectErr error
+	}{
+		{
+			name:  "Success",
+			id:    goframework.NumberUUID(1000),
+			until: &until,
+			now:   updateTime,
+			expect: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:            MustParseEmail("user1@domain.com"),
+					Password:         dao.Password{Hashed: "password-hashed"},
+					SuspendedAt:      &updateTime,
+					SuspendedUntil:   &until,
+					SuspensionReason: "spam",
+					SuspendedBy:      "moderator",
+				},
+			},
+		},
+		{
+			name: "Success/NoExpiration",
+			id:   goframework.NumberUUID(1000),
+			now:  updateTime,
+			expect: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:            MustParseEmail("user1@domain.com"),
+					Password:         dao.Password{Hashed: "password-hashed"},
+					SuspendedAt:      &updateTime,
+					SuspensionReason: "spam",
+					SuspendedBy:      "moderator",
+				},
+			},
+		},
+		{
+			name:      "Error/NotFound",
+			id:        goframework.NumberUUID(100),
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				res, err := dao.NewCredentialsRepository(stx).Suspend(ctx, "spam", "moderator", d.until, d.id, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+
+				var events []*dao.AuditEventModel
+				require.NoError(t, stx.NewSelect().Model(&events).Where("user_id = ?", d.id).Scan(ctx))
+
+				if d.expectErr != nil {
+					require.Empty(t, events)
+					return
+				}
+
+				details := map[string]string{"reason": "spam"}
+				if d.until != nil {
+					details["until"] = d.until.UTC().Format(time.RFC3339)
+				}
+
+				require.Len(t, events, 1)
+				require.Equal(t, dao.AuditEventModelCore{
+					Kind:    dao.AuditEventAdminSuspendUser,
+					Actor:   "moderator",
+					UserID:  d.id,
+					Details: details,
+				}, events[0].AuditEventModelCore)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestCredentialsRepository_Unsuspend(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.CredentialsModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:            MustParseEmail("user1@domain.com"),
+				Password:         dao.Password{Hashed: "password-hashed"},
+				SuspendedAt:      &baseTime,
+				SuspensionReason: "spam",
+				SuspendedBy:      "moderator",
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:    MustParseEmail("user2@domain.com"),
+				Password: dao.Password{Hashed: "password-hashed"},
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		id  uuid.UUID
+		now time.Time
+
+		expect    *dao.CredentialsModel
+		expectErr error
+	}{
+		{
+			name: "Success",
+			id:   goframework.NumberUUID(1000),
+			now:  updateTime,
+			expect: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    MustParseEmail("user1@domain.com"),
+					Password: dao.Password{Hashed: "password-hashed"},
+				},
+			},
+		},
+		{
+			name:      "Error/NotSuspended",
+			id:        goframework.NumberUUID(1001),
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
+		},
+		{
+			name:      "Error/NotFound",
+			id:        goframework.NumberUUID(100),
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				res, err := dao.NewCredentialsRepository(stx).Unsuspend(ctx, "moderator", d.id, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+
+				var events []*dao.AuditEventModel
+				require.NoError(t, stx.NewSelect().Model(&events).Where("user_id = ?", d.id).Scan(ctx))
+
+				if d.expectErr != nil {
+					require.Empty(t, events)
+					return
+				}
+
+				require.Len(t, events, 1)
+				require.Equal(t, dao.AuditEventModelCore{
+					Kind:   dao.AuditEventAdminUnsuspendUser,
+					Actor:  "moderator",
+					UserID: d.id,
+				}, events[0].AuditEventModelCore)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestCredentialsRepository_ListSuspended(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	suspendedLater := baseTime.Add(time.Hour)
+	expiresLater := updateTime.Add(time.Hour)
+
+	fixtures := []*dao.CredentialsModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:            MustParseEmail("user1@domain.com"),
+				Password:         dao.Password{Hashed: "password-hashed"},
+				SuspendedAt:      &baseTime,
+				SuspensionReason: "spam",
+				SuspendedBy:      "moderator",
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:            MustParseEmail("user2@domain.com"),
+				Password:         dao.Password{Hashed: "password-hashed"},
+				SuspendedAt:      &suspendedLater,
+				SuspendedUntil:   &expiresLater,
+				SuspensionReason: "harassment",
+				SuspendedBy:      "moderator",
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1002), baseTime, &baseTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:    MustParseEmail("user3@domain.com"),
+				Password: dao.Password{Hashed: "password-hashed"},
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		now    time.Time
+		limit  int
+		offset int
+
+		expect      []uuid.UUID
+		expectTotal int
+		expectErr   error
+	}{
+		{
+			name:        "Success",
+			now:         updateTime,
+			limit:       10,
+			expect:      []uuid.UUID{goframework.NumberUUID(1001), goframework.NumberUUID(1000)},
+			expectTotal: 2,
+		},
+		{
+			name:        "Success/Paginated",
+			now:         updateTime,
+			limit:       1,
+			offset:      1,
+			expect:      []uuid.UUID{goframework.NumberUUID(1000)},
+			expectTotal: 2,
+		},
+		{
+			name:        "Success/ExpiredSuspension",
+			now:         expiresLater,
+			limit:       10,
+			expect:      []uuid.UUID{goframework.NumberUUID(1000)},
+			expectTotal: 1,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, total, err := dao.NewCredentialsRepository(tx).ListSuspended(ctx, d.now, d.limit, d.offset)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, lo.Map(res, func(item *dao.CredentialsModel, _ int) uuid.UUID {
+					return item.ID
+				}))
+				require.Equal(t, d.expectTotal, total)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
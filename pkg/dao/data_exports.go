@@ -0,0 +1,98 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+type DataExportsRepository interface {
+	// Create stores a new data export. The code MUST be hashed.
+	Create(ctx context.Context, data *DataExportModelCore, id uuid.UUID, now time.Time) (*DataExportModel, error)
+	// Get reads a data export, based on its id.
+	Get(ctx context.Context, id uuid.UUID) (*DataExportModel, error)
+	// Use marks a data export as downloaded. Because a download link can only be used once, this method fails with
+	// bunovel.ErrNotFound if the export was already downloaded, even if the operations happen concurrently.
+	Use(ctx context.Context, id uuid.UUID, now time.Time) (*DataExportModel, error)
+	// Prune removes the data exports that expired before the given date.
+	Prune(ctx context.Context, now time.Time) error
+}
+
+type DataExportModel struct {
+	bun.BaseModel `bun:"table:data_exports"`
+	bunovel.Metadata
+	DataExportModelCore
+}
+
+type DataExportModelCore struct {
+	// UserID is the ID of the user whose data is exported.
+	UserID uuid.UUID `bun:"user_id"`
+	// CodeHashed is the hashed value of the code sent in the download link. The raw value is only known by the
+	// recipient.
+	CodeHashed string `bun:"code_hashed"`
+	// Archive is the zip file that holds the data of the user.
+	Archive []byte `bun:"archive"`
+	// ExpiresAt is the date after which the archive can no longer be downloaded.
+	ExpiresAt time.Time `bun:"expires_at"`
+	// UsedAt is set once the archive has been downloaded.
+	UsedAt *time.Time `bun:"used_at"`
+}
+
+func NewDataExportsRepository(db bun.IDB) DataExportsRepository {
+	return &dataExportsRepositoryImpl{db: db}
+}
+
+type dataExportsRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *dataExportsRepositoryImpl) Create(ctx context.Context, data *DataExportModelCore, id uuid.UUID, now time.Time) (*DataExportModel, error) {
+	model := &DataExportModel{Metadata: bunovel.NewMetadata(id, now, nil), DataExportModelCore: *data}
+
+	if _, err := repository.db.NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *dataExportsRepositoryImpl) Get(ctx context.Context, id uuid.UUID) (*DataExportModel, error) {
+	model := &DataExportModel{Metadata: bunovel.NewMetadata(id, time.Time{}, nil)}
+
+	if err := repository.db.NewSelect().Model(model).WherePK().Scan(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *dataExportsRepositoryImpl) Use(ctx context.Context, id uuid.UUID, now time.Time) (*DataExportModel, error) {
+	model := &DataExportModel{
+		Metadata:            bunovel.NewMetadata(id, time.Time{}, &now),
+		DataExportModelCore: DataExportModelCore{UsedAt: &now},
+	}
+
+	res, err := repository.db.NewUpdate().Model(model).
+		WherePK().
+		// The check happens in the same statement as the update, so two concurrent calls cannot both succeed.
+		Where("used_at IS NULL").
+		Column("used_at", "updated_at").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	if err = bunovel.ForceRowsUpdate(res); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (repository *dataExportsRepositoryImpl) Prune(ctx context.Context, now time.Time) error {
+	_, err := repository.db.NewDelete().Model((*DataExportModel)(nil)).Where("expires_at < ?", now).Exec(ctx)
+	return bunovel.HandlePGError(err)
+}
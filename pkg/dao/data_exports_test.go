@@ -0,0 +1,109 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/migrations"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+var dataExportsFixtures = []*dao.DataExportModel{
+	{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		DataExportModelCore: dao.DataExportModelCore{
+			UserID:     goframework.NumberUUID(10),
+			CodeHashed: "code-1",
+			Archive:    []byte("archive-1"),
+			ExpiresAt:  baseTime.Add(time.Hour),
+		},
+	},
+	{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+		DataExportModelCore: dao.DataExportModelCore{
+			UserID:     goframework.NumberUUID(10),
+			CodeHashed: "code-2",
+			Archive:    []byte("archive-2"),
+			ExpiresAt:  updateTime.Add(time.Hour),
+		},
+	},
+}
+
+func TestDataExportsRepository_Create(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, dataExportsFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewDataExportsRepository(tx)
+
+		data := &dao.DataExportModelCore{
+			UserID:     goframework.NumberUUID(10),
+			CodeHashed: "code-3",
+			Archive:    []byte("archive-3"),
+			ExpiresAt:  updateTime.Add(time.Hour),
+		}
+
+		res, err := repository.Create(ctx, data, goframework.NumberUUID(3), updateTime)
+		require.NoError(t, err)
+		require.Equal(t, &dao.DataExportModel{
+			Metadata:            bunovel.NewMetadata(goframework.NumberUUID(3), updateTime, nil),
+			DataExportModelCore: *data,
+		}, res)
+
+		got, err := repository.Get(ctx, goframework.NumberUUID(3))
+		require.NoError(t, err)
+		require.Equal(t, res, got)
+
+		_, err = repository.Get(ctx, goframework.NumberUUID(4))
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+	})
+	require.NoError(t, err)
+}
+
+func TestDataExportsRepository_Use(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, dataExportsFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewDataExportsRepository(tx)
+
+		res, err := repository.Use(ctx, goframework.NumberUUID(1), updateTime)
+		require.NoError(t, err)
+		require.Equal(t, &updateTime, res.UsedAt)
+		require.Equal(t, &updateTime, res.UpdatedAt)
+
+		// An export can only be downloaded once.
+		_, err = repository.Use(ctx, goframework.NumberUUID(1), updateTime)
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+
+		_, err = repository.Use(ctx, goframework.NumberUUID(4), updateTime)
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+	})
+	require.NoError(t, err)
+}
+
+func TestDataExportsRepository_Prune(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, dataExportsFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewDataExportsRepository(tx)
+
+		require.NoError(t, repository.Prune(ctx, updateTime))
+
+		_, err := repository.Get(ctx, goframework.NumberUUID(1))
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+
+		_, err = repository.Get(ctx, goframework.NumberUUID(2))
+		require.NoError(t, err)
+	})
+	require.NoError(t, err)
+}
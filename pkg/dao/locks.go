@@ -0,0 +1,33 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/uptrace/bun"
+)
+
+type LocksRepository interface {
+	// RunLocked runs the callback while holding the named lock. Callers that use the same name run one after the
+	// other, even on different instances. The lock is released once the callback returns.
+	RunLocked(ctx context.Context, name string, callback func(ctx context.Context) error) error
+}
+
+// NewLocksRepository uses Postgres advisory locks, which do not lock any row. They are held by a transaction that
+// stays open while the callback runs, so they are released even if the instance dies.
+func NewLocksRepository(db bun.IDB) LocksRepository {
+	return &locksRepositoryImpl{db: db}
+}
+
+type locksRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *locksRepositoryImpl) RunLocked(ctx context.Context, name string, callback func(ctx context.Context) error) error {
+	return repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext(?))", name); err != nil {
+			return bunovel.HandlePGError(err)
+		}
+
+		return callback(ctx)
+	})
+}
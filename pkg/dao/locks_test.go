@@ -0,0 +1,62 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/migrations"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	"github.com/stretchr/testify/require"
+	"io/fs"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLocksRepository_RunLocked(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	repository := dao.NewLocksRepository(db)
+
+	var (
+		mu      sync.Mutex
+		running int
+		maxRun  int
+		wg      sync.WaitGroup
+	)
+
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := repository.RunLocked(context.Background(), "test-lock", func(ctx context.Context) error {
+				mu.Lock()
+				running++
+				maxRun = max(maxRun, running)
+				mu.Unlock()
+
+				time.Sleep(50 * time.Millisecond)
+
+				mu.Lock()
+				running--
+				mu.Unlock()
+
+				return nil
+			})
+			require.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	// Callers holding the same lock never run at the same time.
+	require.Equal(t, 1, maxRun)
+
+	// Errors from the callback are returned.
+	err := repository.RunLocked(context.Background(), "test-lock", func(ctx context.Context) error {
+		return fooErr
+	})
+	require.ErrorIs(t, err, fooErr)
+}
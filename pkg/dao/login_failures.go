@@ -0,0 +1,128 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+type LoginFailuresRepository interface {
+	// Record saves a failed login attempt.
+	Record(ctx context.Context, data *LoginFailureModelCore, id uuid.UUID, now time.Time) (*LoginFailureModel, error)
+	// GetAccountFailures summarizes the failures on an account since the given date. Cleared failures are ignored.
+	GetAccountFailures(ctx context.Context, account string, since time.Time) (*LoginFailuresSummaryModel, error)
+	// GetIPFailures summarizes the failures from a client IP since the given date, on every account. Cleared failures
+	// are counted, so a client cannot reset its counter by logging into an account it owns.
+	GetIPFailures(ctx context.Context, ip string, since time.Time) (*LoginFailuresSummaryModel, error)
+	// ListAccountFailures returns the failures recorded on an account that have not been pruned yet, including cleared
+	// failures, most recent first.
+	ListAccountFailures(ctx context.Context, account string) ([]*LoginFailureModel, error)
+	// ClearAccount marks the failures on an account as cleared, which unlocks it.
+	ClearAccount(ctx context.Context, account string, now time.Time) error
+	// Prune removes the failures that happened before the given date.
+	Prune(ctx context.Context, before time.Time) error
+}
+
+type LoginFailureModel struct {
+	bun.BaseModel `bun:"table:login_failures"`
+
+	ID        uuid.UUID `bun:"id,pk,type:uuid"`
+	CreatedAt time.Time `bun:"created_at"`
+	LoginFailureModelCore
+	// ClearedAt is set once the account has been unlocked.
+	ClearedAt *time.Time `bun:"cleared_at"`
+}
+
+type LoginFailureModelCore struct {
+	// Account is the normalized email used for the attempt. It may not belong to any user.
+	Account string `bun:"account"`
+	// IP of the client that made the attempt.
+	IP string `bun:"ip"`
+}
+
+// LoginFailuresSummaryModel describes a set of failed login attempts.
+type LoginFailuresSummaryModel struct {
+	// Count is the number of failures.
+	Count int `bun:"count"`
+	// FirstAt is the date of the oldest failure. It is zero if there is no failure.
+	FirstAt time.Time `bun:"first_at"`
+	// LastAt is the date of the most recent failure. It is zero if there is no failure.
+	LastAt time.Time `bun:"last_at"`
+}
+
+func NewLoginFailuresRepository(db bun.IDB) LoginFailuresRepository {
+	return &loginFailuresRepositoryImpl{db: db}
+}
+
+type loginFailuresRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *loginFailuresRepositoryImpl) Record(ctx context.Context, data *LoginFailureModelCore, id uuid.UUID, now time.Time) (*LoginFailureModel, error) {
+	model := &LoginFailureModel{ID: id, CreatedAt: now, LoginFailureModelCore: *data}
+
+	if _, err := repository.db.NewInsert().Model(model).Exec(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *loginFailuresRepositoryImpl) summarize(ctx context.Context, query *bun.SelectQuery) (*LoginFailuresSummaryModel, error) {
+	model := new(LoginFailuresSummaryModel)
+
+	err := query.
+		ColumnExpr("count(*) AS count").
+		ColumnExpr("min(created_at) AS first_at").
+		ColumnExpr("max(created_at) AS last_at").
+		Scan(ctx, model)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *loginFailuresRepositoryImpl) GetAccountFailures(ctx context.Context, account string, since time.Time) (*LoginFailuresSummaryModel, error) {
+	return repository.summarize(ctx, repository.db.NewSelect().Model((*LoginFailureModel)(nil)).
+		Where("account = ?", account).
+		Where("created_at > ?", since).
+		Where("cleared_at IS NULL"))
+}
+
+func (repository *loginFailuresRepositoryImpl) GetIPFailures(ctx context.Context, ip string, since time.Time) (*LoginFailuresSummaryModel, error) {
+	return repository.summarize(ctx, repository.db.NewSelect().Model((*LoginFailureModel)(nil)).
+		Where("ip = ?", ip).
+		Where("created_at > ?", since))
+}
+
+func (repository *loginFailuresRepositoryImpl) ListAccountFailures(ctx context.Context, account string) ([]*LoginFailureModel, error) {
+	var results []*LoginFailureModel
+
+	err := repository.db.NewSelect().Model(&results).
+		Where("account = ?", account).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return results, nil
+}
+
+func (repository *loginFailuresRepositoryImpl) ClearAccount(ctx context.Context, account string, now time.Time) error {
+	_, err := repository.db.NewUpdate().Model((*LoginFailureModel)(nil)).
+		Set("cleared_at = ?", now).
+		Where("account = ?", account).
+		Where("cleared_at IS NULL").
+		Exec(ctx)
+
+	return bunovel.HandlePGError(err)
+}
+
+func (repository *loginFailuresRepositoryImpl) Prune(ctx context.Context, before time.Time) error {
+	_, err := repository.db.NewDelete().Model((*LoginFailureModel)(nil)).Where("created_at < ?", before).Exec(ctx)
+	return bunovel.HandlePGError(err)
+}
@@ -0,0 +1,117 @@
+package dao
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"sync"
+	"time"
+)
+
+// NewMemoryLoginFailuresRepository keeps the failures in the memory of the process. Each instance throttles attempts
+// on its own, so this repository is only suited to deployments with a single instance.
+//
+// Failures older than retention are dropped on every new record, instead of relying on Prune.
+func NewMemoryLoginFailuresRepository(retention time.Duration) LoginFailuresRepository {
+	return &memoryLoginFailuresRepositoryImpl{retention: retention}
+}
+
+type memoryLoginFailuresRepositoryImpl struct {
+	retention time.Duration
+	failures  []*LoginFailureModel
+	mu        sync.RWMutex
+}
+
+func (repository *memoryLoginFailuresRepositoryImpl) Record(_ context.Context, data *LoginFailureModelCore, id uuid.UUID, now time.Time) (*LoginFailureModel, error) {
+	model := &LoginFailureModel{ID: id, CreatedAt: now, LoginFailureModelCore: *data}
+
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	repository.prune(now.Add(-repository.retention))
+	repository.failures = append(repository.failures, model)
+
+	// Return a copy, so the caller cannot alter the stored value.
+	output := *model
+	return &output, nil
+}
+
+func (repository *memoryLoginFailuresRepositoryImpl) summarize(filter func(item *LoginFailureModel) bool) *LoginFailuresSummaryModel {
+	repository.mu.RLock()
+	defer repository.mu.RUnlock()
+
+	output := new(LoginFailuresSummaryModel)
+	for _, item := range repository.failures {
+		if !filter(item) {
+			continue
+		}
+
+		if output.Count == 0 || item.CreatedAt.Before(output.FirstAt) {
+			output.FirstAt = item.CreatedAt
+		}
+		if output.Count == 0 || item.CreatedAt.After(output.LastAt) {
+			output.LastAt = item.CreatedAt
+		}
+
+		output.Count++
+	}
+
+	return output
+}
+
+func (repository *memoryLoginFailuresRepositoryImpl) GetAccountFailures(_ context.Context, account string, since time.Time) (*LoginFailuresSummaryModel, error) {
+	return repository.summarize(func(item *LoginFailureModel) bool {
+		return item.Account == account && item.CreatedAt.After(since) && item.ClearedAt == nil
+	}), nil
+}
+
+func (repository *memoryLoginFailuresRepositoryImpl) GetIPFailures(_ context.Context, ip string, since time.Time) (*LoginFailuresSummaryModel, error) {
+	return repository.summarize(func(item *LoginFailureModel) bool {
+		return item.IP == ip && item.CreatedAt.After(since)
+	}), nil
+}
+
+func (repository *memoryLoginFailuresRepositoryImpl) ListAccountFailures(_ context.Context, account string) ([]*LoginFailureModel, error) {
+	repository.mu.RLock()
+	defer repository.mu.RUnlock()
+
+	var output []*LoginFailureModel
+	// Failures are stored in the order they were recorded.
+	for i := len(repository.failures) - 1; i >= 0; i-- {
+		if item := repository.failures[i]; item.Account == account {
+			// Return copies, so the caller cannot alter the stored values.
+			copied := *item
+			output = append(output, &copied)
+		}
+	}
+
+	return output, nil
+}
+
+func (repository *memoryLoginFailuresRepositoryImpl) ClearAccount(_ context.Context, account string, now time.Time) error {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	for _, item := range repository.failures {
+		if item.Account == account && item.ClearedAt == nil {
+			item.ClearedAt = lo.ToPtr(now)
+		}
+	}
+
+	return nil
+}
+
+func (repository *memoryLoginFailuresRepositoryImpl) Prune(_ context.Context, before time.Time) error {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	repository.prune(before)
+	return nil
+}
+
+// prune must be called with the write lock held.
+func (repository *memoryLoginFailuresRepositoryImpl) prune(before time.Time) {
+	repository.failures = lo.Filter(repository.failures, func(item *LoginFailureModel, _ int) bool {
+		return !item.CreatedAt.Before(before)
+	})
+}
@@ -0,0 +1,104 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestMemoryLoginFailuresRepository(t *testing.T) {
+	ctx := context.Background()
+	repository := dao.NewMemoryLoginFailuresRepository(time.Hour)
+
+	for _, fixture := range loginFailuresFixtures {
+		_, err := repository.Record(ctx, &fixture.LoginFailureModelCore, fixture.ID, fixture.CreatedAt)
+		require.NoError(t, err)
+	}
+	require.NoError(t, repository.ClearAccount(ctx, "other@domain.com", baseTime.Add(3*time.Minute)))
+
+	res, err := repository.Record(
+		ctx, &dao.LoginFailureModelCore{Account: "other@domain.com", IP: "127.0.0.1"}, goframework.NumberUUID(5), baseTime.Add(4*time.Minute),
+	)
+	require.NoError(t, err)
+	require.Equal(t, &dao.LoginFailureModel{
+		ID:                    goframework.NumberUUID(5),
+		CreatedAt:             baseTime.Add(4 * time.Minute),
+		LoginFailureModelCore: dao.LoginFailureModelCore{Account: "other@domain.com", IP: "127.0.0.1"},
+	}, res)
+
+	summary, err := repository.GetAccountFailures(ctx, "user@domain.com", baseTime.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, &dao.LoginFailuresSummaryModel{Count: 2, FirstAt: baseTime, LastAt: baseTime.Add(time.Minute)}, summary)
+
+	summary, err = repository.GetAccountFailures(ctx, "user@domain.com", baseTime)
+	require.NoError(t, err)
+	require.Equal(t, 1, summary.Count)
+
+	// Cleared failures are ignored for the account, but not for the client IP.
+	summary, err = repository.GetAccountFailures(ctx, "other@domain.com", baseTime.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, &dao.LoginFailuresSummaryModel{
+		Count:   1,
+		FirstAt: baseTime.Add(4 * time.Minute),
+		LastAt:  baseTime.Add(4 * time.Minute),
+	}, summary)
+
+	summary, err = repository.GetIPFailures(ctx, "127.0.0.1", baseTime.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, &dao.LoginFailuresSummaryModel{
+		Count:   4,
+		FirstAt: baseTime,
+		LastAt:  baseTime.Add(4 * time.Minute),
+	}, summary)
+
+	summary, err = repository.GetIPFailures(ctx, "127.0.0.3", baseTime.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, &dao.LoginFailuresSummaryModel{}, summary)
+
+	// Cleared failures are listed, most recent first.
+	failures, err := repository.ListAccountFailures(ctx, "other@domain.com")
+	require.NoError(t, err)
+	require.Equal(t, []*dao.LoginFailureModel{
+		{
+			ID:                    goframework.NumberUUID(5),
+			CreatedAt:             baseTime.Add(4 * time.Minute),
+			LoginFailureModelCore: dao.LoginFailureModelCore{Account: "other@domain.com", IP: "127.0.0.1"},
+		},
+		{
+			ID:                    goframework.NumberUUID(4),
+			CreatedAt:             baseTime.Add(3 * time.Minute),
+			LoginFailureModelCore: dao.LoginFailureModelCore{Account: "other@domain.com", IP: "127.0.0.1"},
+			ClearedAt:             lo.ToPtr(baseTime.Add(3 * time.Minute)),
+		},
+		{
+			ID:                    goframework.NumberUUID(3),
+			CreatedAt:             baseTime.Add(2 * time.Minute),
+			LoginFailureModelCore: dao.LoginFailureModelCore{Account: "other@domain.com", IP: "127.0.0.1"},
+			ClearedAt:             lo.ToPtr(baseTime.Add(3 * time.Minute)),
+		},
+	}, failures)
+
+	failures, err = repository.ListAccountFailures(ctx, "unknown@domain.com")
+	require.NoError(t, err)
+	require.Empty(t, failures)
+
+	require.NoError(t, repository.Prune(ctx, baseTime.Add(90*time.Second)))
+
+	summary, err = repository.GetIPFailures(ctx, "127.0.0.1", baseTime.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 3, summary.Count)
+
+	// Failures older than the retention are dropped on record.
+	_, err = repository.Record(
+		ctx, &dao.LoginFailureModelCore{Account: "user@domain.com", IP: "127.0.0.1"}, goframework.NumberUUID(6), baseTime.Add(time.Hour+150*time.Second),
+	)
+	require.NoError(t, err)
+
+	summary, err = repository.GetIPFailures(ctx, "127.0.0.1", baseTime.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 3, summary.Count)
+}
@@ -0,0 +1,277 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/migrations"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+var loginFailuresFixtures = []*dao.LoginFailureModel{
+	{
+		ID:                    goframework.NumberUUID(1),
+		CreatedAt:             baseTime,
+		LoginFailureModelCore: dao.LoginFailureModelCore{Account: "user@domain.com", IP: "127.0.0.1"},
+	},
+	{
+		ID:                    goframework.NumberUUID(2),
+		CreatedAt:             baseTime.Add(time.Minute),
+		LoginFailureModelCore: dao.LoginFailureModelCore{Account: "user@domain.com", IP: "127.0.0.2"},
+	},
+	{
+		ID:                    goframework.NumberUUID(3),
+		CreatedAt:             baseTime.Add(2 * time.Minute),
+		LoginFailureModelCore: dao.LoginFailureModelCore{Account: "other@domain.com", IP: "127.0.0.1"},
+	},
+	{
+		ID:                    goframework.NumberUUID(4),
+		CreatedAt:             baseTime.Add(3 * time.Minute),
+		LoginFailureModelCore: dao.LoginFailureModelCore{Account: "other@domain.com", IP: "127.0.0.1"},
+		ClearedAt:             lo.ToPtr(baseTime.Add(4 * time.Minute)),
+	},
+}
+
+func TestLoginFailuresRepository_Record(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, loginFailuresFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewLoginFailuresRepository(tx)
+
+		res, err := repository.Record(
+			ctx, &dao.LoginFailureModelCore{Account: "user@domain.com", IP: "127.0.0.3"}, goframework.NumberUUID(10), updateTime,
+		)
+		require.NoError(t, err)
+		require.Equal(t, &dao.LoginFailureModel{
+			ID:                    goframework.NumberUUID(10),
+			CreatedAt:             updateTime,
+			LoginFailureModelCore: dao.LoginFailureModelCore{Account: "user@domain.com", IP: "127.0.0.3"},
+		}, res)
+
+		summary, err := repository.GetAccountFailures(ctx, "user@domain.com", baseTime.Add(-time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, &dao.LoginFailuresSummaryModel{Count: 3, FirstAt: baseTime, LastAt: updateTime}, summary)
+	})
+	require.NoError(t, err)
+}
+
+func TestLoginFailuresRepository_GetAccountFailures(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	data := []struct {
+		name string
+
+		account string
+		since   time.Time
+
+		expect *dao.LoginFailuresSummaryModel
+	}{
+		{
+			name:    "Success",
+			account: "user@domain.com",
+			since:   baseTime.Add(-time.Hour),
+			expect: &dao.LoginFailuresSummaryModel{
+				Count:   2,
+				FirstAt: baseTime,
+				LastAt:  baseTime.Add(time.Minute),
+			},
+		},
+		{
+			name:    "Success/Since",
+			account: "user@domain.com",
+			since:   baseTime,
+			expect: &dao.LoginFailuresSummaryModel{
+				Count:   1,
+				FirstAt: baseTime.Add(time.Minute),
+				LastAt:  baseTime.Add(time.Minute),
+			},
+		},
+		{
+			name:    "Success/IgnoreCleared",
+			account: "other@domain.com",
+			since:   baseTime.Add(-time.Hour),
+			expect: &dao.LoginFailuresSummaryModel{
+				Count:   1,
+				FirstAt: baseTime.Add(2 * time.Minute),
+				LastAt:  baseTime.Add(2 * time.Minute),
+			},
+		},
+		{
+			name:    "Success/NoFailures",
+			account: "unknown@domain.com",
+			since:   baseTime.Add(-time.Hour),
+			expect:  &dao.LoginFailuresSummaryModel{},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, loginFailuresFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewLoginFailuresRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.GetAccountFailures(ctx, d.account, d.since)
+				require.NoError(st, err)
+				require.Equal(st, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestLoginFailuresRepository_GetIPFailures(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	data := []struct {
+		name string
+
+		ip    string
+		since time.Time
+
+		expect *dao.LoginFailuresSummaryModel
+	}{
+		{
+			name:  "Success/IncludeCleared",
+			ip:    "127.0.0.1",
+			since: baseTime.Add(-time.Hour),
+			expect: &dao.LoginFailuresSummaryModel{
+				Count:   3,
+				FirstAt: baseTime,
+				LastAt:  baseTime.Add(3 * time.Minute),
+			},
+		},
+		{
+			name:  "Success/Since",
+			ip:    "127.0.0.1",
+			since: baseTime,
+			expect: &dao.LoginFailuresSummaryModel{
+				Count:   2,
+				FirstAt: baseTime.Add(2 * time.Minute),
+				LastAt:  baseTime.Add(3 * time.Minute),
+			},
+		},
+		{
+			name:   "Success/NoFailures",
+			ip:     "127.0.0.3",
+			since:  baseTime.Add(-time.Hour),
+			expect: &dao.LoginFailuresSummaryModel{},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, loginFailuresFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewLoginFailuresRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.GetIPFailures(ctx, d.ip, d.since)
+				require.NoError(st, err)
+				require.Equal(st, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestLoginFailuresRepository_ListAccountFailures(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	data := []struct {
+		name string
+
+		account string
+
+		expect []*dao.LoginFailureModel
+	}{
+		{
+			name:    "Success",
+			account: "user@domain.com",
+			expect:  []*dao.LoginFailureModel{loginFailuresFixtures[1], loginFailuresFixtures[0]},
+		},
+		{
+			name:    "Success/WithCleared",
+			account: "other@domain.com",
+			expect:  []*dao.LoginFailureModel{loginFailuresFixtures[3], loginFailuresFixtures[2]},
+		},
+		{
+			name:    "Success/NoFailures",
+			account: "unknown@domain.com",
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, loginFailuresFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewLoginFailuresRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.ListAccountFailures(ctx, d.account)
+				require.NoError(st, err)
+				require.Equal(st, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestLoginFailuresRepository_ClearAccount(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, loginFailuresFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewLoginFailuresRepository(tx)
+
+		require.NoError(t, repository.ClearAccount(ctx, "user@domain.com", updateTime))
+
+		summary, err := repository.GetAccountFailures(ctx, "user@domain.com", baseTime.Add(-time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, &dao.LoginFailuresSummaryModel{}, summary)
+
+		// Clearing an account does not clear the failures of the client IP.
+		summary, err = repository.GetIPFailures(ctx, "127.0.0.2", baseTime.Add(-time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, 1, summary.Count)
+
+		// Other accounts are not affected.
+		summary, err = repository.GetAccountFailures(ctx, "other@domain.com", baseTime.Add(-time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, 1, summary.Count)
+
+		// Clearing an account without failures has no effect.
+		require.NoError(t, repository.ClearAccount(ctx, "unknown@domain.com", updateTime))
+	})
+	require.NoError(t, err)
+}
+
+func TestLoginFailuresRepository_Prune(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, loginFailuresFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewLoginFailuresRepository(tx)
+
+		require.NoError(t, repository.Prune(ctx, baseTime.Add(90*time.Second)))
+
+		summary, err := repository.GetAccountFailures(ctx, "user@domain.com", baseTime.Add(-time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, &dao.LoginFailuresSummaryModel{}, summary)
+
+		summary, err = repository.GetIPFailures(ctx, "127.0.0.1", baseTime.Add(-time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, 2, summary.Count)
+	})
+	require.NoError(t, err)
+}
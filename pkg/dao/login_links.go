@@ -0,0 +1,88 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+type LoginLinksRepository interface {
+	// Create stores a new login link. The code MUST be hashed.
+	Create(ctx context.Context, data *LoginLinkModelCore, id uuid.UUID, now time.Time) (*LoginLinkModel, error)
+	// Get reads a login link, based on its id.
+	Get(ctx context.Context, id uuid.UUID) (*LoginLinkModel, error)
+	// Use marks a login link as used. Because a link can only be used once, this method fails with
+	// bunovel.ErrNotFound if the link was already used, even if the operations happen concurrently.
+	Use(ctx context.Context, id uuid.UUID, now time.Time) (*LoginLinkModel, error)
+}
+
+type LoginLinkModel struct {
+	bun.BaseModel `bun:"table:login_links"`
+	bunovel.Metadata
+	LoginLinkModelCore
+}
+
+type LoginLinkModelCore struct {
+	// UserID is the ID of the user the link was sent to.
+	UserID uuid.UUID `bun:"user_id"`
+	// CodeHashed is the hashed value of the code sent in the link. The raw value is only known by the recipient.
+	CodeHashed string `bun:"code_hashed"`
+	// ExpiresAt is the date after which the link can no longer be used.
+	ExpiresAt time.Time `bun:"expires_at"`
+	// UsedAt is set once the link has been exchanged for a session.
+	UsedAt *time.Time `bun:"used_at"`
+}
+
+func NewLoginLinksRepository(db bun.IDB) LoginLinksRepository {
+	return &loginLinksRepositoryImpl{db: db}
+}
+
+type loginLinksRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *loginLinksRepositoryImpl) Create(ctx context.Context, data *LoginLinkModelCore, id uuid.UUID, now time.Time) (*LoginLinkModel, error) {
+	model := &LoginLinkModel{Metadata: bunovel.NewMetadata(id, now, nil), LoginLinkModelCore: *data}
+
+	if _, err := repository.db.NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *loginLinksRepositoryImpl) Get(ctx context.Context, id uuid.UUID) (*LoginLinkModel, error) {
+	model := &LoginLinkModel{Metadata: bunovel.NewMetadata(id, time.Time{}, nil)}
+
+	if err := repository.db.NewSelect().Model(model).WherePK().Scan(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *loginLinksRepositoryImpl) Use(ctx context.Context, id uuid.UUID, now time.Time) (*LoginLinkModel, error) {
+	model := &LoginLinkModel{
+		Metadata:           bunovel.NewMetadata(id, time.Time{}, &now),
+		LoginLinkModelCore: LoginLinkModelCore{UsedAt: &now},
+	}
+
+	res, err := repository.db.NewUpdate().Model(model).
+		WherePK().
+		// The check happens in the same statement as the update, so two concurrent calls cannot both succeed.
+		Where("used_at IS NULL").
+		Column("used_at", "updated_at").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	if err = bunovel.ForceRowsUpdate(res); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
@@ -0,0 +1,89 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/migrations"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+var loginLinksFixtures = []*dao.LoginLinkModel{
+	{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		LoginLinkModelCore: dao.LoginLinkModelCore{
+			UserID:     goframework.NumberUUID(10),
+			CodeHashed: "code-1",
+			ExpiresAt:  baseTime.Add(15 * time.Minute),
+		},
+	},
+	{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, &baseTime),
+		LoginLinkModelCore: dao.LoginLinkModelCore{
+			UserID:     goframework.NumberUUID(10),
+			CodeHashed: "code-2",
+			ExpiresAt:  baseTime.Add(15 * time.Minute),
+			UsedAt:     &baseTime,
+		},
+	},
+}
+
+func TestLoginLinksRepository_Create(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, loginLinksFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewLoginLinksRepository(tx)
+
+		data := &dao.LoginLinkModelCore{
+			UserID:     goframework.NumberUUID(10),
+			CodeHashed: "code-3",
+			ExpiresAt:  updateTime.Add(15 * time.Minute),
+		}
+
+		res, err := repository.Create(ctx, data, goframework.NumberUUID(3), updateTime)
+		require.NoError(t, err)
+		require.Equal(t, &dao.LoginLinkModel{
+			Metadata:           bunovel.NewMetadata(goframework.NumberUUID(3), updateTime, nil),
+			LoginLinkModelCore: *data,
+		}, res)
+
+		got, err := repository.Get(ctx, goframework.NumberUUID(3))
+		require.NoError(t, err)
+		require.Equal(t, res, got)
+
+		_, err = repository.Get(ctx, goframework.NumberUUID(4))
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+	})
+	require.NoError(t, err)
+}
+
+func TestLoginLinksRepository_Use(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, loginLinksFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewLoginLinksRepository(tx)
+
+		res, err := repository.Use(ctx, goframework.NumberUUID(1), updateTime)
+		require.NoError(t, err)
+		require.Equal(t, &updateTime, res.UsedAt)
+
+		_, err = repository.Use(ctx, goframework.NumberUUID(1), updateTime)
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+
+		_, err = repository.Use(ctx, goframework.NumberUUID(2), updateTime)
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+
+		_, err = repository.Use(ctx, goframework.NumberUUID(3), updateTime)
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+	})
+	require.NoError(t, err)
+}
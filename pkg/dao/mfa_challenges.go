@@ -0,0 +1,108 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+type MFAChallengesRepository interface {
+	// Create stores a new challenge. The token value MUST be hashed.
+	Create(ctx context.Context, data *MFAChallengeModelCore, id uuid.UUID, now time.Time) (*MFAChallengeModel, error)
+	// Get reads a challenge, based on its id.
+	Get(ctx context.Context, id uuid.UUID) (*MFAChallengeModel, error)
+	// Use marks a challenge as used. Because a challenge can only be used once, this method fails with
+	// bunovel.ErrNotFound if the challenge was already used, even if the operations happen concurrently.
+	Use(ctx context.Context, id uuid.UUID, now time.Time) (*MFAChallengeModel, error)
+	// Fail increments the number of failed verifications of a challenge, and returns the updated challenge.
+	Fail(ctx context.Context, id uuid.UUID, now time.Time) (*MFAChallengeModel, error)
+}
+
+type MFAChallengeModel struct {
+	bun.BaseModel `bun:"table:mfa_challenges"`
+	bunovel.Metadata
+	MFAChallengeModelCore
+}
+
+type MFAChallengeModelCore struct {
+	// UserID is the ID of the user who passed the first factor.
+	UserID uuid.UUID `bun:"user_id"`
+	// TokenHashed is the hashed value of the secret part of the challenge. The raw value is only known by the client.
+	TokenHashed string `bun:"token_hashed"`
+	// ExpiresAt is the date after which the challenge can no longer be used.
+	ExpiresAt time.Time `bun:"expires_at"`
+	// UsedAt is set once the challenge has been exchanged for a session.
+	UsedAt *time.Time `bun:"used_at"`
+	// Failures is the number of wrong codes sent with the challenge.
+	Failures int `bun:"failures"`
+}
+
+func NewMFAChallengesRepository(db bun.IDB) MFAChallengesRepository {
+	return &mfaChallengesRepositoryImpl{db: db}
+}
+
+type mfaChallengesRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *mfaChallengesRepositoryImpl) Create(ctx context.Context, data *MFAChallengeModelCore, id uuid.UUID, now time.Time) (*MFAChallengeModel, error) {
+	model := &MFAChallengeModel{Metadata: bunovel.NewMetadata(id, now, nil), MFAChallengeModelCore: *data}
+
+	if _, err := repository.db.NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *mfaChallengesRepositoryImpl) Get(ctx context.Context, id uuid.UUID) (*MFAChallengeModel, error) {
+	model := &MFAChallengeModel{Metadata: bunovel.NewMetadata(id, time.Time{}, nil)}
+
+	if err := repository.db.NewSelect().Model(model).WherePK().Scan(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *mfaChallengesRepositoryImpl) Use(ctx context.Context, id uuid.UUID, now time.Time) (*MFAChallengeModel, error) {
+	model := &MFAChallengeModel{
+		Metadata:              bunovel.NewMetadata(id, time.Time{}, &now),
+		MFAChallengeModelCore: MFAChallengeModelCore{UsedAt: &now},
+	}
+
+	res, err := repository.db.NewUpdate().Model(model).
+		WherePK().
+		// The check happens in the same statement as the update, so two concurrent calls cannot both succeed.
+		Where("used_at IS NULL").
+		Column("used_at", "updated_at").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	if err = bunovel.ForceRowsUpdate(res); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (repository *mfaChallengesRepositoryImpl) Fail(ctx context.Context, id uuid.UUID, now time.Time) (*MFAChallengeModel, error) {
+	model := &MFAChallengeModel{Metadata: bunovel.NewMetadata(id, time.Time{}, &now)}
+
+	_, err := repository.db.NewUpdate().Model(model).
+		WherePK().
+		Set("failures = failures + 1").
+		Set("updated_at = ?", now).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
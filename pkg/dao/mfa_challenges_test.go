@@ -0,0 +1,108 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/migrations"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+var mfaChallengesFixtures = []*dao.MFAChallengeModel{
+	{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		MFAChallengeModelCore: dao.MFAChallengeModelCore{
+			UserID:      goframework.NumberUUID(10),
+			TokenHashed: "token-1",
+			ExpiresAt:   baseTime.Add(5 * time.Minute),
+		},
+	},
+	{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, &baseTime),
+		MFAChallengeModelCore: dao.MFAChallengeModelCore{
+			UserID:      goframework.NumberUUID(10),
+			TokenHashed: "token-2",
+			ExpiresAt:   baseTime.Add(5 * time.Minute),
+			UsedAt:      &baseTime,
+		},
+	},
+}
+
+func TestMFAChallengesRepository_Create(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, mfaChallengesFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewMFAChallengesRepository(tx)
+
+		data := &dao.MFAChallengeModelCore{
+			UserID:      goframework.NumberUUID(10),
+			TokenHashed: "token-3",
+			ExpiresAt:   updateTime.Add(5 * time.Minute),
+		}
+
+		res, err := repository.Create(ctx, data, goframework.NumberUUID(3), updateTime)
+		require.NoError(t, err)
+		require.Equal(t, &dao.MFAChallengeModel{
+			Metadata:              bunovel.NewMetadata(goframework.NumberUUID(3), updateTime, nil),
+			MFAChallengeModelCore: *data,
+		}, res)
+
+		got, err := repository.Get(ctx, goframework.NumberUUID(3))
+		require.NoError(t, err)
+		require.Equal(t, res, got)
+
+		_, err = repository.Get(ctx, goframework.NumberUUID(4))
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+	})
+	require.NoError(t, err)
+}
+
+func TestMFAChallengesRepository_Use(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, mfaChallengesFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewMFAChallengesRepository(tx)
+
+		res, err := repository.Use(ctx, goframework.NumberUUID(1), updateTime)
+		require.NoError(t, err)
+		require.Equal(t, &updateTime, res.UsedAt)
+
+		_, err = repository.Use(ctx, goframework.NumberUUID(1), updateTime)
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+
+		_, err = repository.Use(ctx, goframework.NumberUUID(2), updateTime)
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+	})
+	require.NoError(t, err)
+}
+
+func TestMFAChallengesRepository_Fail(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, mfaChallengesFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewMFAChallengesRepository(tx)
+
+		res, err := repository.Fail(ctx, goframework.NumberUUID(1), updateTime)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Failures)
+
+		res, err = repository.Fail(ctx, goframework.NumberUUID(1), updateTime)
+		require.NoError(t, err)
+		require.Equal(t, 2, res.Failures)
+
+		_, err = repository.Fail(ctx, goframework.NumberUUID(3), updateTime)
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,160 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+	time "time"
+
+	dao "github.com/a-novel/auth-service/pkg/dao"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditEventsRepository is an autogenerated mock type for the AuditEventsRepository type
+type AuditEventsRepository struct {
+	mock.Mock
+}
+
+type AuditEventsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditEventsRepository) EXPECT() *AuditEventsRepository_Expecter {
+	return &AuditEventsRepository_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function with given fields: ctx, filter, limit, offset
+func (_m *AuditEventsRepository) List(ctx context.Context, filter dao.AuditEventsFilter, limit int, offset int) ([]*dao.AuditEventModel, int, error) {
+	ret := _m.Called(ctx, filter, limit, offset)
+
+	var r0 []*dao.AuditEventModel
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, dao.AuditEventsFilter, int, int) ([]*dao.AuditEventModel, int, error)); ok {
+		return rf(ctx, filter, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dao.AuditEventsFilter, int, int) []*dao.AuditEventModel); ok {
+		r0 = rf(ctx, filter, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.AuditEventModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dao.AuditEventsFilter, int, int) int); ok {
+		r1 = rf(ctx, filter, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, dao.AuditEventsFilter, int, int) error); ok {
+		r2 = rf(ctx, filter, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AuditEventsRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type AuditEventsRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter dao.AuditEventsFilter
+//   - limit int
+//   - offset int
+func (_e *AuditEventsRepository_Expecter) List(ctx interface{}, filter interface{}, limit interface{}, offset interface{}) *AuditEventsRepository_List_Call {
+	return &AuditEventsRepository_List_Call{Call: _e.mock.On("List", ctx, filter, limit, offset)}
+}
+
+func (_c *AuditEventsRepository_List_Call) Run(run func(ctx context.Context, filter dao.AuditEventsFilter, limit int, offset int)) *AuditEventsRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dao.AuditEventsFilter), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *AuditEventsRepository_List_Call) Return(_a0 []*dao.AuditEventModel, _a1 int, _a2 error) *AuditEventsRepository_List_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *AuditEventsRepository_List_Call) RunAndReturn(run func(context.Context, dao.AuditEventsFilter, int, int) ([]*dao.AuditEventModel, int, error)) *AuditEventsRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordAuditEvent provides a mock function with given fields: ctx, data, id, now
+func (_m *AuditEventsRepository) RecordAuditEvent(ctx context.Context, data *dao.AuditEventModelCore, id uuid.UUID, now time.Time) (*dao.AuditEventModel, error) {
+	ret := _m.Called(ctx, data, id, now)
+
+	var r0 *dao.AuditEventModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.AuditEventModelCore, uuid.UUID, time.Time) (*dao.AuditEventModel, error)); ok {
+		return rf(ctx, data, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.AuditEventModelCore, uuid.UUID, time.Time) *dao.AuditEventModel); ok {
+		r0 = rf(ctx, data, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.AuditEventModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.AuditEventModelCore, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, data, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditEventsRepository_RecordAuditEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordAuditEvent'
+type AuditEventsRepository_RecordAuditEvent_Call struct {
+	*mock.Call
+}
+
+// RecordAuditEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *dao.AuditEventModelCore
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *AuditEventsRepository_Expecter) RecordAuditEvent(ctx interface{}, data interface{}, id interface{}, now interface{}) *AuditEventsRepository_RecordAuditEvent_Call {
+	return &AuditEventsRepository_RecordAuditEvent_Call{Call: _e.mock.On("RecordAuditEvent", ctx, data, id, now)}
+}
+
+func (_c *AuditEventsRepository_RecordAuditEvent_Call) Run(run func(ctx context.Context, data *dao.AuditEventModelCore, id uuid.UUID, now time.Time)) *AuditEventsRepository_RecordAuditEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.AuditEventModelCore), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AuditEventsRepository_RecordAuditEvent_Call) Return(_a0 *dao.AuditEventModel, _a1 error) *AuditEventsRepository_RecordAuditEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditEventsRepository_RecordAuditEvent_Call) RunAndReturn(run func(context.Context, *dao.AuditEventModelCore, uuid.UUID, time.Time) (*dao.AuditEventModel, error)) *AuditEventsRepository_RecordAuditEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuditEventsRepository creates a new instance of AuditEventsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditEventsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditEventsRepository {
+	mock := &AuditEventsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+	time "time"
+
+	dao "github.com/a-novel/auth-service/pkg/dao"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditRecorder is an autogenerated mock type for the AuditRecorder type
+type AuditRecorder struct {
+	mock.Mock
+}
+
+type AuditRecorder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditRecorder) EXPECT() *AuditRecorder_Expecter {
+	return &AuditRecorder_Expecter{mock: &_m.Mock}
+}
+
+// RecordAuditEvent provides a mock function with given fields: ctx, data, id, now
+func (_m *AuditRecorder) RecordAuditEvent(ctx context.Context, data *dao.AuditEventModelCore, id uuid.UUID, now time.Time) (*dao.AuditEventModel, error) {
+	ret := _m.Called(ctx, data, id, now)
+
+	var r0 *dao.AuditEventModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.AuditEventModelCore, uuid.UUID, time.Time) (*dao.AuditEventModel, error)); ok {
+		return rf(ctx, data, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.AuditEventModelCore, uuid.UUID, time.Time) *dao.AuditEventModel); ok {
+		r0 = rf(ctx, data, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.AuditEventModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.AuditEventModelCore, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, data, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditRecorder_RecordAuditEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordAuditEvent'
+type AuditRecorder_RecordAuditEvent_Call struct {
+	*mock.Call
+}
+
+// RecordAuditEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *dao.AuditEventModelCore
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *AuditRecorder_Expecter) RecordAuditEvent(ctx interface{}, data interface{}, id interface{}, now interface{}) *AuditRecorder_RecordAuditEvent_Call {
+	return &AuditRecorder_RecordAuditEvent_Call{Call: _e.mock.On("RecordAuditEvent", ctx, data, id, now)}
+}
+
+func (_c *AuditRecorder_RecordAuditEvent_Call) Run(run func(ctx context.Context, data *dao.AuditEventModelCore, id uuid.UUID, now time.Time)) *AuditRecorder_RecordAuditEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.AuditEventModelCore), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AuditRecorder_RecordAuditEvent_Call) Return(_a0 *dao.AuditEventModel, _a1 error) *AuditRecorder_RecordAuditEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditRecorder_RecordAuditEvent_Call) RunAndReturn(run func(context.Context, *dao.AuditEventModelCore, uuid.UUID, time.Time) (*dao.AuditEventModel, error)) *AuditRecorder_RecordAuditEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuditRecorder creates a new instance of AuditRecorder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditRecorder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditRecorder {
+	mock := &AuditRecorder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,89 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BreachedPasswordsRepository is an autogenerated mock type for the BreachedPasswordsRepository type
+type BreachedPasswordsRepository struct {
+	mock.Mock
+}
+
+type BreachedPasswordsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BreachedPasswordsRepository) EXPECT() *BreachedPasswordsRepository_Expecter {
+	return &BreachedPasswordsRepository_Expecter{mock: &_m.Mock}
+}
+
+// IsBreached provides a mock function with given fields: ctx, hash
+func (_m *BreachedPasswordsRepository) IsBreached(ctx context.Context, hash [20]byte) (bool, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, [20]byte) (bool, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, [20]byte) bool); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, [20]byte) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BreachedPasswordsRepository_IsBreached_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsBreached'
+type BreachedPasswordsRepository_IsBreached_Call struct {
+	*mock.Call
+}
+
+// IsBreached is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash [20]byte
+func (_e *BreachedPasswordsRepository_Expecter) IsBreached(ctx interface{}, hash interface{}) *BreachedPasswordsRepository_IsBreached_Call {
+	return &BreachedPasswordsRepository_IsBreached_Call{Call: _e.mock.On("IsBreached", ctx, hash)}
+}
+
+func (_c *BreachedPasswordsRepository_IsBreached_Call) Run(run func(ctx context.Context, hash [20]byte)) *BreachedPasswordsRepository_IsBreached_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([20]byte))
+	})
+	return _c
+}
+
+func (_c *BreachedPasswordsRepository_IsBreached_Call) Return(_a0 bool, _a1 error) *BreachedPasswordsRepository_IsBreached_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BreachedPasswordsRepository_IsBreached_Call) RunAndReturn(run func(context.Context, [20]byte) (bool, error)) *BreachedPasswordsRepository_IsBreached_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBreachedPasswordsRepository creates a new instance of BreachedPasswordsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBreachedPasswordsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BreachedPasswordsRepository {
+	mock := &BreachedPasswordsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,13 +4,11 @@ package daomocks
 
 import (
 	context "context"
-
-	dao "github.com/a-novel/auth-service/pkg/dao"
-	mock "github.com/stretchr/testify/mock"
-
 	time "time"
 
+	dao "github.com/a-novel/auth-service/pkg/dao"
 	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // CredentialsRepository is an autogenerated mock type for the CredentialsRepository type
@@ -26,6 +24,63 @@ func (_m *CredentialsRepository) EXPECT() *CredentialsRepository_Expecter {
 	return &CredentialsRepository_Expecter{mock: &_m.Mock}
 }
 
+// CancelDeletion provides a mock function with given fields: ctx, id, code, now
+func (_m *CredentialsRepository) CancelDeletion(ctx context.Context, id uuid.UUID, code string, now time.Time) (*dao.CredentialsModel, error) {
+	ret := _m.Called(ctx, id, code, now)
+
+	var r0 *dao.CredentialsModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) (*dao.CredentialsModel, error)); ok {
+		return rf(ctx, id, code, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) *dao.CredentialsModel); ok {
+		r0 = rf(ctx, id, code, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.CredentialsModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, time.Time) error); ok {
+		r1 = rf(ctx, id, code, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CredentialsRepository_CancelDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelDeletion'
+type CredentialsRepository_CancelDeletion_Call struct {
+	*mock.Call
+}
+
+// CancelDeletion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - code string
+//   - now time.Time
+func (_e *CredentialsRepository_Expecter) CancelDeletion(ctx interface{}, id interface{}, code interface{}, now interface{}) *CredentialsRepository_CancelDeletion_Call {
+	return &CredentialsRepository_CancelDeletion_Call{Call: _e.mock.On("CancelDeletion", ctx, id, code, now)}
+}
+
+func (_c *CredentialsRepository_CancelDeletion_Call) Run(run func(ctx context.Context, id uuid.UUID, code string, now time.Time)) *CredentialsRepository_CancelDeletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CredentialsRepository_CancelDeletion_Call) Return(_a0 *dao.CredentialsModel, _a1 error) *CredentialsRepository_CancelDeletion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CredentialsRepository_CancelDeletion_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, time.Time) (*dao.CredentialsModel, error)) *CredentialsRepository_CancelDeletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CancelNewEmail provides a mock function with given fields: ctx, id, now
 func (_m *CredentialsRepository) CancelNewEmail(ctx context.Context, id uuid.UUID, now time.Time) (*dao.CredentialsModel, error) {
 	ret := _m.Called(ctx, id, now)
@@ -82,6 +137,64 @@ func (_c *CredentialsRepository_CancelNewEmail_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// Delete provides a mock function with given fields: ctx, code, securityStamp, id, now
+func (_m *CredentialsRepository) Delete(ctx context.Context, code string, securityStamp uuid.UUID, id uuid.UUID, now time.Time) (*dao.CredentialsModel, error) {
+	ret := _m.Called(ctx, code, securityStamp, id, now)
+
+	var r0 *dao.CredentialsModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, uuid.UUID, time.Time) (*dao.CredentialsModel, error)); ok {
+		return rf(ctx, code, securityStamp, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, uuid.UUID, time.Time) *dao.CredentialsModel); ok {
+		r0 = rf(ctx, code, securityStamp, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.CredentialsModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, code, securityStamp, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CredentialsRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type CredentialsRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code string
+//   - securityStamp uuid.UUID
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *CredentialsRepository_Expecter) Delete(ctx interface{}, code interface{}, securityStamp interface{}, id interface{}, now interface{}) *CredentialsRepository_Delete_Call {
+	return &CredentialsRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, code, securityStamp, id, now)}
+}
+
+func (_c *CredentialsRepository_Delete_Call) Run(run func(ctx context.Context, code string, securityStamp uuid.UUID, id uuid.UUID, now time.Time)) *CredentialsRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(uuid.UUID), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CredentialsRepository_Delete_Call) Return(_a0 *dao.CredentialsModel, _a1 error) *CredentialsRepository_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CredentialsRepository_Delete_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, uuid.UUID, time.Time) (*dao.CredentialsModel, error)) *CredentialsRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // EmailExists provides a mock function with given fields: ctx, email
 func (_m *CredentialsRepository) EmailExists(ctx context.Context, email dao.Email) (bool, error) {
 	ret := _m.Called(ctx, email)
@@ -245,6 +358,239 @@ func (_c *CredentialsRepository_GetCredentialsByEmail_Call) RunAndReturn(run fun
 	return _c
 }
 
+// ListDeleted provides a mock function with given fields: ctx, deletedBefore
+func (_m *CredentialsRepository) ListDeleted(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, deletedBefore)
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]uuid.UUID, error)); ok {
+		return rf(ctx, deletedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []uuid.UUID); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CredentialsRepository_ListDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeleted'
+type CredentialsRepository_ListDeleted_Call struct {
+	*mock.Call
+}
+
+// ListDeleted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deletedBefore time.Time
+func (_e *CredentialsRepository_Expecter) ListDeleted(ctx interface{}, deletedBefore interface{}) *CredentialsRepository_ListDeleted_Call {
+	return &CredentialsRepository_ListDeleted_Call{Call: _e.mock.On("ListDeleted", ctx, deletedBefore)}
+}
+
+func (_c *CredentialsRepository_ListDeleted_Call) Run(run func(ctx context.Context, deletedBefore time.Time)) *CredentialsRepository_ListDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CredentialsRepository_ListDeleted_Call) Return(_a0 []uuid.UUID, _a1 error) *CredentialsRepository_ListDeleted_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CredentialsRepository_ListDeleted_Call) RunAndReturn(run func(context.Context, time.Time) ([]uuid.UUID, error)) *CredentialsRepository_ListDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSuspended provides a mock function with given fields: ctx, now, limit, offset
+func (_m *CredentialsRepository) ListSuspended(ctx context.Context, now time.Time, limit int, offset int) ([]*dao.CredentialsModel, int, error) {
+	ret := _m.Called(ctx, now, limit, offset)
+
+	var r0 []*dao.CredentialsModel
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, int) ([]*dao.CredentialsModel, int, error)); ok {
+		return rf(ctx, now, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, int) []*dao.CredentialsModel); ok {
+		r0 = rf(ctx, now, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.CredentialsModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int, int) int); ok {
+		r1 = rf(ctx, now, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time, int, int) error); ok {
+		r2 = rf(ctx, now, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CredentialsRepository_ListSuspended_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSuspended'
+type CredentialsRepository_ListSuspended_Call struct {
+	*mock.Call
+}
+
+// ListSuspended is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit int
+//   - offset int
+func (_e *CredentialsRepository_Expecter) ListSuspended(ctx interface{}, now interface{}, limit interface{}, offset interface{}) *CredentialsRepository_ListSuspended_Call {
+	return &CredentialsRepository_ListSuspended_Call{Call: _e.mock.On("ListSuspended", ctx, now, limit, offset)}
+}
+
+func (_c *CredentialsRepository_ListSuspended_Call) Run(run func(ctx context.Context, now time.Time, limit int, offset int)) *CredentialsRepository_ListSuspended_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *CredentialsRepository_ListSuspended_Call) Return(_a0 []*dao.CredentialsModel, _a1 int, _a2 error) *CredentialsRepository_ListSuspended_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *CredentialsRepository_ListSuspended_Call) RunAndReturn(run func(context.Context, time.Time, int, int) ([]*dao.CredentialsModel, int, error)) *CredentialsRepository_ListSuspended_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lock provides a mock function with given fields: ctx, securityStamp, id, now
+func (_m *CredentialsRepository) Lock(ctx context.Context, securityStamp uuid.UUID, id uuid.UUID, now time.Time) (*dao.CredentialsModel, error) {
+	ret := _m.Called(ctx, securityStamp, id, now)
+
+	var r0 *dao.CredentialsModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) (*dao.CredentialsModel, error)); ok {
+		return rf(ctx, securityStamp, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) *dao.CredentialsModel); ok {
+		r0 = rf(ctx, securityStamp, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.CredentialsModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, securityStamp, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CredentialsRepository_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type CredentialsRepository_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - securityStamp uuid.UUID
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *CredentialsRepository_Expecter) Lock(ctx interface{}, securityStamp interface{}, id interface{}, now interface{}) *CredentialsRepository_Lock_Call {
+	return &CredentialsRepository_Lock_Call{Call: _e.mock.On("Lock", ctx, securityStamp, id, now)}
+}
+
+func (_c *CredentialsRepository_Lock_Call) Run(run func(ctx context.Context, securityStamp uuid.UUID, id uuid.UUID, now time.Time)) *CredentialsRepository_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CredentialsRepository_Lock_Call) Return(_a0 *dao.CredentialsModel, _a1 error) *CredentialsRepository_Lock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CredentialsRepository_Lock_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, time.Time) (*dao.CredentialsModel, error)) *CredentialsRepository_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordAuditEvent provides a mock function with given fields: ctx, data, id, now
+func (_m *CredentialsRepository) RecordAuditEvent(ctx context.Context, data *dao.AuditEventModelCore, id uuid.UUID, now time.Time) (*dao.AuditEventModel, error) {
+	ret := _m.Called(ctx, data, id, now)
+
+	var r0 *dao.AuditEventModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.AuditEventModelCore, uuid.UUID, time.Time) (*dao.AuditEventModel, error)); ok {
+		return rf(ctx, data, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.AuditEventModelCore, uuid.UUID, time.Time) *dao.AuditEventModel); ok {
+		r0 = rf(ctx, data, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.AuditEventModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.AuditEventModelCore, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, data, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CredentialsRepository_RecordAuditEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordAuditEvent'
+type CredentialsRepository_RecordAuditEvent_Call struct {
+	*mock.Call
+}
+
+// RecordAuditEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *dao.AuditEventModelCore
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *CredentialsRepository_Expecter) RecordAuditEvent(ctx interface{}, data interface{}, id interface{}, now interface{}) *CredentialsRepository_RecordAuditEvent_Call {
+	return &CredentialsRepository_RecordAuditEvent_Call{Call: _e.mock.On("RecordAuditEvent", ctx, data, id, now)}
+}
+
+func (_c *CredentialsRepository_RecordAuditEvent_Call) Run(run func(ctx context.Context, data *dao.AuditEventModelCore, id uuid.UUID, now time.Time)) *CredentialsRepository_RecordAuditEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.AuditEventModelCore), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CredentialsRepository_RecordAuditEvent_Call) Return(_a0 *dao.AuditEventModel, _a1 error) *CredentialsRepository_RecordAuditEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CredentialsRepository_RecordAuditEvent_Call) RunAndReturn(run func(context.Context, *dao.AuditEventModelCore, uuid.UUID, time.Time) (*dao.AuditEventModel, error)) *CredentialsRepository_RecordAuditEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ResetPassword provides a mock function with given fields: ctx, code, email, now
 func (_m *CredentialsRepository) ResetPassword(ctx context.Context, code string, email dao.Email, now time.Time) (*dao.CredentialsModel, error) {
 	ret := _m.Called(ctx, code, email, now)
@@ -302,6 +648,63 @@ func (_c *CredentialsRepository_ResetPassword_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// RotateSecurityStamp provides a mock function with given fields: ctx, securityStamp, id, now
+func (_m *CredentialsRepository) RotateSecurityStamp(ctx context.Context, securityStamp uuid.UUID, id uuid.UUID, now time.Time) (*dao.CredentialsModel, error) {
+	ret := _m.Called(ctx, securityStamp, id, now)
+
+	var r0 *dao.CredentialsModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) (*dao.CredentialsModel, error)); ok {
+		return rf(ctx, securityStamp, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) *dao.CredentialsModel); ok {
+		r0 = rf(ctx, securityStamp, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.CredentialsModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, securityStamp, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CredentialsRepository_RotateSecurityStamp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateSecurityStamp'
+type CredentialsRepository_RotateSecurityStamp_Call struct {
+	*mock.Call
+}
+
+// RotateSecurityStamp is a helper method to define mock.On call
+//   - ctx context.Context
+//   - securityStamp uuid.UUID
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *CredentialsRepository_Expecter) RotateSecurityStamp(ctx interface{}, securityStamp interface{}, id interface{}, now interface{}) *CredentialsRepository_RotateSecurityStamp_Call {
+	return &CredentialsRepository_RotateSecurityStamp_Call{Call: _e.mock.On("RotateSecurityStamp", ctx, securityStamp, id, now)}
+}
+
+func (_c *CredentialsRepository_RotateSecurityStamp_Call) Run(run func(ctx context.Context, securityStamp uuid.UUID, id uuid.UUID, now time.Time)) *CredentialsRepository_RotateSecurityStamp_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CredentialsRepository_RotateSecurityStamp_Call) Return(_a0 *dao.CredentialsModel, _a1 error) *CredentialsRepository_RotateSecurityStamp_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CredentialsRepository_RotateSecurityStamp_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, time.Time) (*dao.CredentialsModel, error)) *CredentialsRepository_RotateSecurityStamp_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RunInTx provides a mock function with given fields: ctx, callback
 func (_m *CredentialsRepository) RunInTx(ctx context.Context, callback func(context.Context, dao.CredentialsRepository) error) error {
 	ret := _m.Called(ctx, callback)
@@ -345,25 +748,197 @@ func (_c *CredentialsRepository_RunInTx_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
-// UpdateEmail provides a mock function with given fields: ctx, email, code, id, now
-func (_m *CredentialsRepository) UpdateEmail(ctx context.Context, email dao.Email, code string, id uuid.UUID, now time.Time) (*dao.CredentialsModel, error) {
-	ret := _m.Called(ctx, email, code, id, now)
+// Suspend provides a mock function with given fields: ctx, reason, actor, until, id, now
+func (_m *CredentialsRepository) Suspend(ctx context.Context, reason string, actor string, until *time.Time, id uuid.UUID, now time.Time) (*dao.CredentialsModel, error) {
+	ret := _m.Called(ctx, reason, actor, until, id, now)
 
 	var r0 *dao.CredentialsModel
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, dao.Email, string, uuid.UUID, time.Time) (*dao.CredentialsModel, error)); ok {
-		return rf(ctx, email, code, id, now)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *time.Time, uuid.UUID, time.Time) (*dao.CredentialsModel, error)); ok {
+		return rf(ctx, reason, actor, until, id, now)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, dao.Email, string, uuid.UUID, time.Time) *dao.CredentialsModel); ok {
-		r0 = rf(ctx, email, code, id, now)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *time.Time, uuid.UUID, time.Time) *dao.CredentialsModel); ok {
+		r0 = rf(ctx, reason, actor, until, id, now)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dao.CredentialsModel)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, dao.Email, string, uuid.UUID, time.Time) error); ok {
-		r1 = rf(ctx, email, code, id, now)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *time.Time, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, reason, actor, until, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CredentialsRepository_Suspend_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Suspend'
+type CredentialsRepository_Suspend_Call struct {
+	*mock.Call
+}
+
+// Suspend is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reason string
+//   - actor string
+//   - until *time.Time
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *CredentialsRepository_Expecter) Suspend(ctx interface{}, reason interface{}, actor interface{}, until interface{}, id interface{}, now interface{}) *CredentialsRepository_Suspend_Call {
+	return &CredentialsRepository_Suspend_Call{Call: _e.mock.On("Suspend", ctx, reason, actor, until, id, now)}
+}
+
+func (_c *CredentialsRepository_Suspend_Call) Run(run func(ctx context.Context, reason string, actor string, until *time.Time, id uuid.UUID, now time.Time)) *CredentialsRepository_Suspend_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*time.Time), args[4].(uuid.UUID), args[5].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CredentialsRepository_Suspend_Call) Return(_a0 *dao.CredentialsModel, _a1 error) *CredentialsRepository_Suspend_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CredentialsRepository_Suspend_Call) RunAndReturn(run func(context.Context, string, string, *time.Time, uuid.UUID, time.Time) (*dao.CredentialsModel, error)) *CredentialsRepository_Suspend_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unlock provides a mock function with given fields: ctx, id, now
+func (_m *CredentialsRepository) Unlock(ctx context.Context, id uuid.UUID, now time.Time) (*dao.CredentialsModel, error) {
+	ret := _m.Called(ctx, id, now)
+
+	var r0 *dao.CredentialsModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (*dao.CredentialsModel, error)); ok {
+		return rf(ctx, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *dao.CredentialsModel); ok {
+		r0 = rf(ctx, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.CredentialsModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CredentialsRepository_Unlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unlock'
+type CredentialsRepository_Unlock_Call struct {
+	*mock.Call
+}
+
+// Unlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *CredentialsRepository_Expecter) Unlock(ctx interface{}, id interface{}, now interface{}) *CredentialsRepository_Unlock_Call {
+	return &CredentialsRepository_Unlock_Call{Call: _e.mock.On("Unlock", ctx, id, now)}
+}
+
+func (_c *CredentialsRepository_Unlock_Call) Run(run func(ctx context.Context, id uuid.UUID, now time.Time)) *CredentialsRepository_Unlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CredentialsRepository_Unlock_Call) Return(_a0 *dao.CredentialsModel, _a1 error) *CredentialsRepository_Unlock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CredentialsRepository_Unlock_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) (*dao.CredentialsModel, error)) *CredentialsRepository_Unlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unsuspend provides a mock function with given fields: ctx, actor, id, now
+func (_m *CredentialsRepository) Unsuspend(ctx context.Context, actor string, id uuid.UUID, now time.Time) (*dao.CredentialsModel, error) {
+	ret := _m.Called(ctx, actor, id, now)
+
+	var r0 *dao.CredentialsModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, time.Time) (*dao.CredentialsModel, error)); ok {
+		return rf(ctx, actor, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, time.Time) *dao.CredentialsModel); ok {
+		r0 = rf(ctx, actor, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.CredentialsModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, actor, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CredentialsRepository_Unsuspend_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unsuspend'
+type CredentialsRepository_Unsuspend_Call struct {
+	*mock.Call
+}
+
+// Unsuspend is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor string
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *CredentialsRepository_Expecter) Unsuspend(ctx interface{}, actor interface{}, id interface{}, now interface{}) *CredentialsRepository_Unsuspend_Call {
+	return &CredentialsRepository_Unsuspend_Call{Call: _e.mock.On("Unsuspend", ctx, actor, id, now)}
+}
+
+func (_c *CredentialsRepository_Unsuspend_Call) Run(run func(ctx context.Context, actor string, id uuid.UUID, now time.Time)) *CredentialsRepository_Unsuspend_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CredentialsRepository_Unsuspend_Call) Return(_a0 *dao.CredentialsModel, _a1 error) *CredentialsRepository_Unsuspend_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CredentialsRepository_Unsuspend_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, time.Time) (*dao.CredentialsModel, error)) *CredentialsRepository_Unsuspend_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEmail provides a mock function with given fields: ctx, email, code, reportCode, id, now
+func (_m *CredentialsRepository) UpdateEmail(ctx context.Context, email dao.Email, code string, reportCode string, id uuid.UUID, now time.Time) (*dao.CredentialsModel, error) {
+	ret := _m.Called(ctx, email, code, reportCode, id, now)
+
+	var r0 *dao.CredentialsModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dao.Email, string, string, uuid.UUID, time.Time) (*dao.CredentialsModel, error)); ok {
+		return rf(ctx, email, code, reportCode, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dao.Email, string, string, uuid.UUID, time.Time) *dao.CredentialsModel); ok {
+		r0 = rf(ctx, email, code, reportCode, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.CredentialsModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dao.Email, string, string, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, email, code, reportCode, id, now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -380,15 +955,16 @@ type CredentialsRepository_UpdateEmail_Call struct {
 //   - ctx context.Context
 //   - email dao.Email
 //   - code string
+//   - reportCode string
 //   - id uuid.UUID
 //   - now time.Time
-func (_e *CredentialsRepository_Expecter) UpdateEmail(ctx interface{}, email interface{}, code interface{}, id interface{}, now interface{}) *CredentialsRepository_UpdateEmail_Call {
-	return &CredentialsRepository_UpdateEmail_Call{Call: _e.mock.On("UpdateEmail", ctx, email, code, id, now)}
+func (_e *CredentialsRepository_Expecter) UpdateEmail(ctx interface{}, email interface{}, code interface{}, reportCode interface{}, id interface{}, now interface{}) *CredentialsRepository_UpdateEmail_Call {
+	return &CredentialsRepository_UpdateEmail_Call{Call: _e.mock.On("UpdateEmail", ctx, email, code, reportCode, id, now)}
 }
 
-func (_c *CredentialsRepository_UpdateEmail_Call) Run(run func(ctx context.Context, email dao.Email, code string, id uuid.UUID, now time.Time)) *CredentialsRepository_UpdateEmail_Call {
+func (_c *CredentialsRepository_UpdateEmail_Call) Run(run func(ctx context.Context, email dao.Email, code string, reportCode string, id uuid.UUID, now time.Time)) *CredentialsRepository_UpdateEmail_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(dao.Email), args[2].(string), args[3].(uuid.UUID), args[4].(time.Time))
+		run(args[0].(context.Context), args[1].(dao.Email), args[2].(string), args[3].(string), args[4].(uuid.UUID), args[5].(time.Time))
 	})
 	return _c
 }
@@ -398,7 +974,7 @@ func (_c *CredentialsRepository_UpdateEmail_Call) Return(_a0 *dao.CredentialsMod
 	return _c
 }
 
-func (_c *CredentialsRepository_UpdateEmail_Call) RunAndReturn(run func(context.Context, dao.Email, string, uuid.UUID, time.Time) (*dao.CredentialsModel, error)) *CredentialsRepository_UpdateEmail_Call {
+func (_c *CredentialsRepository_UpdateEmail_Call) RunAndReturn(run func(context.Context, dao.Email, string, string, uuid.UUID, time.Time) (*dao.CredentialsModel, error)) *CredentialsRepository_UpdateEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -517,25 +1093,25 @@ func (_c *CredentialsRepository_UpdateNewEmailValidation_Call) RunAndReturn(run
 	return _c
 }
 
-// UpdatePassword provides a mock function with given fields: ctx, newPassword, id, now
-func (_m *CredentialsRepository) UpdatePassword(ctx context.Context, newPassword string, id uuid.UUID, now time.Time) (*dao.CredentialsModel, error) {
-	ret := _m.Called(ctx, newPassword, id, now)
+// UpdatePassword provides a mock function with given fields: ctx, newPassword, code, securityStamp, id, now
+func (_m *CredentialsRepository) UpdatePassword(ctx context.Context, newPassword string, code string, securityStamp uuid.UUID, id uuid.UUID, now time.Time) (*dao.CredentialsModel, error) {
+	ret := _m.Called(ctx, newPassword, code, securityStamp, id, now)
 
 	var r0 *dao.CredentialsModel
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, time.Time) (*dao.CredentialsModel, error)); ok {
-		return rf(ctx, newPassword, id, now)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uuid.UUID, uuid.UUID, time.Time) (*dao.CredentialsModel, error)); ok {
+		return rf(ctx, newPassword, code, securityStamp, id, now)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, time.Time) *dao.CredentialsModel); ok {
-		r0 = rf(ctx, newPassword, id, now)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uuid.UUID, uuid.UUID, time.Time) *dao.CredentialsModel); ok {
+		r0 = rf(ctx, newPassword, code, securityStamp, id, now)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dao.CredentialsModel)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, time.Time) error); ok {
-		r1 = rf(ctx, newPassword, id, now)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, uuid.UUID, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, newPassword, code, securityStamp, id, now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -551,15 +1127,17 @@ type CredentialsRepository_UpdatePassword_Call struct {
 // UpdatePassword is a helper method to define mock.On call
 //   - ctx context.Context
 //   - newPassword string
+//   - code string
+//   - securityStamp uuid.UUID
 //   - id uuid.UUID
 //   - now time.Time
-func (_e *CredentialsRepository_Expecter) UpdatePassword(ctx interface{}, newPassword interface{}, id interface{}, now interface{}) *CredentialsRepository_UpdatePassword_Call {
-	return &CredentialsRepository_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", ctx, newPassword, id, now)}
+func (_e *CredentialsRepository_Expecter) UpdatePassword(ctx interface{}, newPassword interface{}, code interface{}, securityStamp interface{}, id interface{}, now interface{}) *CredentialsRepository_UpdatePassword_Call {
+	return &CredentialsRepository_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", ctx, newPassword, code, securityStamp, id, now)}
 }
 
-func (_c *CredentialsRepository_UpdatePassword_Call) Run(run func(ctx context.Context, newPassword string, id uuid.UUID, now time.Time)) *CredentialsRepository_UpdatePassword_Call {
+func (_c *CredentialsRepository_UpdatePassword_Call) Run(run func(ctx context.Context, newPassword string, code string, securityStamp uuid.UUID, id uuid.UUID, now time.Time)) *CredentialsRepository_UpdatePassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(time.Time))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(uuid.UUID), args[4].(uuid.UUID), args[5].(time.Time))
 	})
 	return _c
 }
@@ -569,30 +1147,88 @@ func (_c *CredentialsRepository_UpdatePassword_Call) Return(_a0 *dao.Credentials
 	return _c
 }
 
-func (_c *CredentialsRepository_UpdatePassword_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, time.Time) (*dao.CredentialsModel, error)) *CredentialsRepository_UpdatePassword_Call {
+func (_c *CredentialsRepository_UpdatePassword_Call) RunAndReturn(run func(context.Context, string, string, uuid.UUID, uuid.UUID, time.Time) (*dao.CredentialsModel, error)) *CredentialsRepository_UpdatePassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ValidateEmail provides a mock function with given fields: ctx, id, now
-func (_m *CredentialsRepository) ValidateEmail(ctx context.Context, id uuid.UUID, now time.Time) (*dao.CredentialsModel, error) {
-	ret := _m.Called(ctx, id, now)
+// UpdatePasswordHash provides a mock function with given fields: ctx, id, oldHash, newHash, now
+func (_m *CredentialsRepository) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash string, newHash string, now time.Time) (*dao.CredentialsModel, error) {
+	ret := _m.Called(ctx, id, oldHash, newHash, now)
 
 	var r0 *dao.CredentialsModel
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (*dao.CredentialsModel, error)); ok {
-		return rf(ctx, id, now)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, time.Time) (*dao.CredentialsModel, error)); ok {
+		return rf(ctx, id, oldHash, newHash, now)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *dao.CredentialsModel); ok {
-		r0 = rf(ctx, id, now)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, time.Time) *dao.CredentialsModel); ok {
+		r0 = rf(ctx, id, oldHash, newHash, now)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dao.CredentialsModel)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
-		r1 = rf(ctx, id, now)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, string, time.Time) error); ok {
+		r1 = rf(ctx, id, oldHash, newHash, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CredentialsRepository_UpdatePasswordHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePasswordHash'
+type CredentialsRepository_UpdatePasswordHash_Call struct {
+	*mock.Call
+}
+
+// UpdatePasswordHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - oldHash string
+//   - newHash string
+//   - now time.Time
+func (_e *CredentialsRepository_Expecter) UpdatePasswordHash(ctx interface{}, id interface{}, oldHash interface{}, newHash interface{}, now interface{}) *CredentialsRepository_UpdatePasswordHash_Call {
+	return &CredentialsRepository_UpdatePasswordHash_Call{Call: _e.mock.On("UpdatePasswordHash", ctx, id, oldHash, newHash, now)}
+}
+
+func (_c *CredentialsRepository_UpdatePasswordHash_Call) Run(run func(ctx context.Context, id uuid.UUID, oldHash string, newHash string, now time.Time)) *CredentialsRepository_UpdatePasswordHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(string), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CredentialsRepository_UpdatePasswordHash_Call) Return(_a0 *dao.CredentialsModel, _a1 error) *CredentialsRepository_UpdatePasswordHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CredentialsRepository_UpdatePasswordHash_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, string, time.Time) (*dao.CredentialsModel, error)) *CredentialsRepository_UpdatePasswordHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateEmail provides a mock function with given fields: ctx, id, code, now
+func (_m *CredentialsRepository) ValidateEmail(ctx context.Context, id uuid.UUID, code string, now time.Time) (*dao.CredentialsModel, error) {
+	ret := _m.Called(ctx, id, code, now)
+
+	var r0 *dao.CredentialsModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) (*dao.CredentialsModel, error)); ok {
+		return rf(ctx, id, code, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) *dao.CredentialsModel); ok {
+		r0 = rf(ctx, id, code, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.CredentialsModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, time.Time) error); ok {
+		r1 = rf(ctx, id, code, now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -608,14 +1244,15 @@ type CredentialsRepository_ValidateEmail_Call struct {
 // ValidateEmail is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id uuid.UUID
+//   - code string
 //   - now time.Time
-func (_e *CredentialsRepository_Expecter) ValidateEmail(ctx interface{}, id interface{}, now interface{}) *CredentialsRepository_ValidateEmail_Call {
-	return &CredentialsRepository_ValidateEmail_Call{Call: _e.mock.On("ValidateEmail", ctx, id, now)}
+func (_e *CredentialsRepository_Expecter) ValidateEmail(ctx interface{}, id interface{}, code interface{}, now interface{}) *CredentialsRepository_ValidateEmail_Call {
+	return &CredentialsRepository_ValidateEmail_Call{Call: _e.mock.On("ValidateEmail", ctx, id, code, now)}
 }
 
-func (_c *CredentialsRepository_ValidateEmail_Call) Run(run func(ctx context.Context, id uuid.UUID, now time.Time)) *CredentialsRepository_ValidateEmail_Call {
+func (_c *CredentialsRepository_ValidateEmail_Call) Run(run func(ctx context.Context, id uuid.UUID, code string, now time.Time)) *CredentialsRepository_ValidateEmail_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(time.Time))
 	})
 	return _c
 }
@@ -625,30 +1262,30 @@ func (_c *CredentialsRepository_ValidateEmail_Call) Return(_a0 *dao.CredentialsM
 	return _c
 }
 
-func (_c *CredentialsRepository_ValidateEmail_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) (*dao.CredentialsModel, error)) *CredentialsRepository_ValidateEmail_Call {
+func (_c *CredentialsRepository_ValidateEmail_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, time.Time) (*dao.CredentialsModel, error)) *CredentialsRepository_ValidateEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ValidateNewEmail provides a mock function with given fields: ctx, id, now
-func (_m *CredentialsRepository) ValidateNewEmail(ctx context.Context, id uuid.UUID, now time.Time) (*dao.CredentialsModel, error) {
-	ret := _m.Called(ctx, id, now)
+// ValidateNewEmail provides a mock function with given fields: ctx, id, code, securityStamp, now
+func (_m *CredentialsRepository) ValidateNewEmail(ctx context.Context, id uuid.UUID, code string, securityStamp uuid.UUID, now time.Time) (*dao.CredentialsModel, error) {
+	ret := _m.Called(ctx, id, code, securityStamp, now)
 
 	var r0 *dao.CredentialsModel
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (*dao.CredentialsModel, error)); ok {
-		return rf(ctx, id, now)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, uuid.UUID, time.Time) (*dao.CredentialsModel, error)); ok {
+		return rf(ctx, id, code, securityStamp, now)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *dao.CredentialsModel); ok {
-		r0 = rf(ctx, id, now)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, uuid.UUID, time.Time) *dao.CredentialsModel); ok {
+		r0 = rf(ctx, id, code, securityStamp, now)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dao.CredentialsModel)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
-		r1 = rf(ctx, id, now)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, id, code, securityStamp, now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -664,14 +1301,16 @@ type CredentialsRepository_ValidateNewEmail_Call struct {
 // ValidateNewEmail is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id uuid.UUID
+//   - code string
+//   - securityStamp uuid.UUID
 //   - now time.Time
-func (_e *CredentialsRepository_Expecter) ValidateNewEmail(ctx interface{}, id interface{}, now interface{}) *CredentialsRepository_ValidateNewEmail_Call {
-	return &CredentialsRepository_ValidateNewEmail_Call{Call: _e.mock.On("ValidateNewEmail", ctx, id, now)}
+func (_e *CredentialsRepository_Expecter) ValidateNewEmail(ctx interface{}, id interface{}, code interface{}, securityStamp interface{}, now interface{}) *CredentialsRepository_ValidateNewEmail_Call {
+	return &CredentialsRepository_ValidateNewEmail_Call{Call: _e.mock.On("ValidateNewEmail", ctx, id, code, securityStamp, now)}
 }
 
-func (_c *CredentialsRepository_ValidateNewEmail_Call) Run(run func(ctx context.Context, id uuid.UUID, now time.Time)) *CredentialsRepository_ValidateNewEmail_Call {
+func (_c *CredentialsRepository_ValidateNewEmail_Call) Run(run func(ctx context.Context, id uuid.UUID, code string, securityStamp uuid.UUID, now time.Time)) *CredentialsRepository_ValidateNewEmail_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(uuid.UUID), args[4].(time.Time))
 	})
 	return _c
 }
@@ -681,7 +1320,7 @@ func (_c *CredentialsRepository_ValidateNewEmail_Call) Return(_a0 *dao.Credentia
 	return _c
 }
 
-func (_c *CredentialsRepository_ValidateNewEmail_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) (*dao.CredentialsModel, error)) *CredentialsRepository_ValidateNewEmail_Call {
+func (_c *CredentialsRepository_ValidateNewEmail_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, uuid.UUID, time.Time) (*dao.CredentialsModel, error)) *CredentialsRepository_ValidateNewEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
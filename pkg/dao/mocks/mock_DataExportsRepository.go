@@ -0,0 +1,250 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+	time "time"
+
+	dao "github.com/a-novel/auth-service/pkg/dao"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DataExportsRepository is an autogenerated mock type for the DataExportsRepository type
+type DataExportsRepository struct {
+	mock.Mock
+}
+
+type DataExportsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DataExportsRepository) EXPECT() *DataExportsRepository_Expecter {
+	return &DataExportsRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, data, id, now
+func (_m *DataExportsRepository) Create(ctx context.Context, data *dao.DataExportModelCore, id uuid.UUID, now time.Time) (*dao.DataExportModel, error) {
+	ret := _m.Called(ctx, data, id, now)
+
+	var r0 *dao.DataExportModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.DataExportModelCore, uuid.UUID, time.Time) (*dao.DataExportModel, error)); ok {
+		return rf(ctx, data, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.DataExportModelCore, uuid.UUID, time.Time) *dao.DataExportModel); ok {
+		r0 = rf(ctx, data, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.DataExportModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.DataExportModelCore, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, data, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataExportsRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type DataExportsRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *dao.DataExportModelCore
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *DataExportsRepository_Expecter) Create(ctx interface{}, data interface{}, id interface{}, now interface{}) *DataExportsRepository_Create_Call {
+	return &DataExportsRepository_Create_Call{Call: _e.mock.On("Create", ctx, data, id, now)}
+}
+
+func (_c *DataExportsRepository_Create_Call) Run(run func(ctx context.Context, data *dao.DataExportModelCore, id uuid.UUID, now time.Time)) *DataExportsRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.DataExportModelCore), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *DataExportsRepository_Create_Call) Return(_a0 *dao.DataExportModel, _a1 error) *DataExportsRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataExportsRepository_Create_Call) RunAndReturn(run func(context.Context, *dao.DataExportModelCore, uuid.UUID, time.Time) (*dao.DataExportModel, error)) *DataExportsRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *DataExportsRepository) Get(ctx context.Context, id uuid.UUID) (*dao.DataExportModel, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *dao.DataExportModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*dao.DataExportModel, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *dao.DataExportModel); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.DataExportModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataExportsRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type DataExportsRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *DataExportsRepository_Expecter) Get(ctx interface{}, id interface{}) *DataExportsRepository_Get_Call {
+	return &DataExportsRepository_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *DataExportsRepository_Get_Call) Run(run func(ctx context.Context, id uuid.UUID)) *DataExportsRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataExportsRepository_Get_Call) Return(_a0 *dao.DataExportModel, _a1 error) *DataExportsRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataExportsRepository_Get_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*dao.DataExportModel, error)) *DataExportsRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Prune provides a mock function with given fields: ctx, now
+func (_m *DataExportsRepository) Prune(ctx context.Context, now time.Time) error {
+	ret := _m.Called(ctx, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataExportsRepository_Prune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Prune'
+type DataExportsRepository_Prune_Call struct {
+	*mock.Call
+}
+
+// Prune is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *DataExportsRepository_Expecter) Prune(ctx interface{}, now interface{}) *DataExportsRepository_Prune_Call {
+	return &DataExportsRepository_Prune_Call{Call: _e.mock.On("Prune", ctx, now)}
+}
+
+func (_c *DataExportsRepository_Prune_Call) Run(run func(ctx context.Context, now time.Time)) *DataExportsRepository_Prune_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *DataExportsRepository_Prune_Call) Return(_a0 error) *DataExportsRepository_Prune_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataExportsRepository_Prune_Call) RunAndReturn(run func(context.Context, time.Time) error) *DataExportsRepository_Prune_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Use provides a mock function with given fields: ctx, id, now
+func (_m *DataExportsRepository) Use(ctx context.Context, id uuid.UUID, now time.Time) (*dao.DataExportModel, error) {
+	ret := _m.Called(ctx, id, now)
+
+	var r0 *dao.DataExportModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (*dao.DataExportModel, error)); ok {
+		return rf(ctx, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *dao.DataExportModel); ok {
+		r0 = rf(ctx, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.DataExportModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataExportsRepository_Use_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Use'
+type DataExportsRepository_Use_Call struct {
+	*mock.Call
+}
+
+// Use is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *DataExportsRepository_Expecter) Use(ctx interface{}, id interface{}, now interface{}) *DataExportsRepository_Use_Call {
+	return &DataExportsRepository_Use_Call{Call: _e.mock.On("Use", ctx, id, now)}
+}
+
+func (_c *DataExportsRepository_Use_Call) Run(run func(ctx context.Context, id uuid.UUID, now time.Time)) *DataExportsRepository_Use_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *DataExportsRepository_Use_Call) Return(_a0 *dao.DataExportModel, _a1 error) *DataExportsRepository_Use_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataExportsRepository_Use_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) (*dao.DataExportModel, error)) *DataExportsRepository_Use_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDataExportsRepository creates a new instance of DataExportsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDataExportsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DataExportsRepository {
+	mock := &DataExportsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,80 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LocksRepository is an autogenerated mock type for the LocksRepository type
+type LocksRepository struct {
+	mock.Mock
+}
+
+type LocksRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LocksRepository) EXPECT() *LocksRepository_Expecter {
+	return &LocksRepository_Expecter{mock: &_m.Mock}
+}
+
+// RunLocked provides a mock function with given fields: ctx, name, callback
+func (_m *LocksRepository) RunLocked(ctx context.Context, name string, callback func(context.Context) error) error {
+	ret := _m.Called(ctx, name, callback)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(context.Context) error) error); ok {
+		r0 = rf(ctx, name, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LocksRepository_RunLocked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunLocked'
+type LocksRepository_RunLocked_Call struct {
+	*mock.Call
+}
+
+// RunLocked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - callback func(context.Context) error
+func (_e *LocksRepository_Expecter) RunLocked(ctx interface{}, name interface{}, callback interface{}) *LocksRepository_RunLocked_Call {
+	return &LocksRepository_RunLocked_Call{Call: _e.mock.On("RunLocked", ctx, name, callback)}
+}
+
+func (_c *LocksRepository_RunLocked_Call) Run(run func(ctx context.Context, name string, callback func(context.Context) error)) *LocksRepository_RunLocked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(func(context.Context) error))
+	})
+	return _c
+}
+
+func (_c *LocksRepository_RunLocked_Call) Return(_a0 error) *LocksRepository_RunLocked_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LocksRepository_RunLocked_Call) RunAndReturn(run func(context.Context, string, func(context.Context) error) error) *LocksRepository_RunLocked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLocksRepository creates a new instance of LocksRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLocksRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LocksRepository {
+	mock := &LocksRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
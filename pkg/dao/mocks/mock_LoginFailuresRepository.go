@@ -0,0 +1,350 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+	time "time"
+
+	dao "github.com/a-novel/auth-service/pkg/dao"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LoginFailuresRepository is an autogenerated mock type for the LoginFailuresRepository type
+type LoginFailuresRepository struct {
+	mock.Mock
+}
+
+type LoginFailuresRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LoginFailuresRepository) EXPECT() *LoginFailuresRepository_Expecter {
+	return &LoginFailuresRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClearAccount provides a mock function with given fields: ctx, account, now
+func (_m *LoginFailuresRepository) ClearAccount(ctx context.Context, account string, now time.Time) error {
+	ret := _m.Called(ctx, account, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, account, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoginFailuresRepository_ClearAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearAccount'
+type LoginFailuresRepository_ClearAccount_Call struct {
+	*mock.Call
+}
+
+// ClearAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account string
+//   - now time.Time
+func (_e *LoginFailuresRepository_Expecter) ClearAccount(ctx interface{}, account interface{}, now interface{}) *LoginFailuresRepository_ClearAccount_Call {
+	return &LoginFailuresRepository_ClearAccount_Call{Call: _e.mock.On("ClearAccount", ctx, account, now)}
+}
+
+func (_c *LoginFailuresRepository_ClearAccount_Call) Run(run func(ctx context.Context, account string, now time.Time)) *LoginFailuresRepository_ClearAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LoginFailuresRepository_ClearAccount_Call) Return(_a0 error) *LoginFailuresRepository_ClearAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoginFailuresRepository_ClearAccount_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *LoginFailuresRepository_ClearAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccountFailures provides a mock function with given fields: ctx, account, since
+func (_m *LoginFailuresRepository) GetAccountFailures(ctx context.Context, account string, since time.Time) (*dao.LoginFailuresSummaryModel, error) {
+	ret := _m.Called(ctx, account, since)
+
+	var r0 *dao.LoginFailuresSummaryModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*dao.LoginFailuresSummaryModel, error)); ok {
+		return rf(ctx, account, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *dao.LoginFailuresSummaryModel); ok {
+		r0 = rf(ctx, account, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.LoginFailuresSummaryModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, account, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginFailuresRepository_GetAccountFailures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountFailures'
+type LoginFailuresRepository_GetAccountFailures_Call struct {
+	*mock.Call
+}
+
+// GetAccountFailures is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account string
+//   - since time.Time
+func (_e *LoginFailuresRepository_Expecter) GetAccountFailures(ctx interface{}, account interface{}, since interface{}) *LoginFailuresRepository_GetAccountFailures_Call {
+	return &LoginFailuresRepository_GetAccountFailures_Call{Call: _e.mock.On("GetAccountFailures", ctx, account, since)}
+}
+
+func (_c *LoginFailuresRepository_GetAccountFailures_Call) Run(run func(ctx context.Context, account string, since time.Time)) *LoginFailuresRepository_GetAccountFailures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LoginFailuresRepository_GetAccountFailures_Call) Return(_a0 *dao.LoginFailuresSummaryModel, _a1 error) *LoginFailuresRepository_GetAccountFailures_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoginFailuresRepository_GetAccountFailures_Call) RunAndReturn(run func(context.Context, string, time.Time) (*dao.LoginFailuresSummaryModel, error)) *LoginFailuresRepository_GetAccountFailures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIPFailures provides a mock function with given fields: ctx, ip, since
+func (_m *LoginFailuresRepository) GetIPFailures(ctx context.Context, ip string, since time.Time) (*dao.LoginFailuresSummaryModel, error) {
+	ret := _m.Called(ctx, ip, since)
+
+	var r0 *dao.LoginFailuresSummaryModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*dao.LoginFailuresSummaryModel, error)); ok {
+		return rf(ctx, ip, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *dao.LoginFailuresSummaryModel); ok {
+		r0 = rf(ctx, ip, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.LoginFailuresSummaryModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, ip, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginFailuresRepository_GetIPFailures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIPFailures'
+type LoginFailuresRepository_GetIPFailures_Call struct {
+	*mock.Call
+}
+
+// GetIPFailures is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ip string
+//   - since time.Time
+func (_e *LoginFailuresRepository_Expecter) GetIPFailures(ctx interface{}, ip interface{}, since interface{}) *LoginFailuresRepository_GetIPFailures_Call {
+	return &LoginFailuresRepository_GetIPFailures_Call{Call: _e.mock.On("GetIPFailures", ctx, ip, since)}
+}
+
+func (_c *LoginFailuresRepository_GetIPFailures_Call) Run(run func(ctx context.Context, ip string, since time.Time)) *LoginFailuresRepository_GetIPFailures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LoginFailuresRepository_GetIPFailures_Call) Return(_a0 *dao.LoginFailuresSummaryModel, _a1 error) *LoginFailuresRepository_GetIPFailures_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoginFailuresRepository_GetIPFailures_Call) RunAndReturn(run func(context.Context, string, time.Time) (*dao.LoginFailuresSummaryModel, error)) *LoginFailuresRepository_GetIPFailures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAccountFailures provides a mock function with given fields: ctx, account
+func (_m *LoginFailuresRepository) ListAccountFailures(ctx context.Context, account string) ([]*dao.LoginFailureModel, error) {
+	ret := _m.Called(ctx, account)
+
+	var r0 []*dao.LoginFailureModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*dao.LoginFailureModel, error)); ok {
+		return rf(ctx, account)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*dao.LoginFailureModel); ok {
+		r0 = rf(ctx, account)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.LoginFailureModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginFailuresRepository_ListAccountFailures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccountFailures'
+type LoginFailuresRepository_ListAccountFailures_Call struct {
+	*mock.Call
+}
+
+// ListAccountFailures is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account string
+func (_e *LoginFailuresRepository_Expecter) ListAccountFailures(ctx interface{}, account interface{}) *LoginFailuresRepository_ListAccountFailures_Call {
+	return &LoginFailuresRepository_ListAccountFailures_Call{Call: _e.mock.On("ListAccountFailures", ctx, account)}
+}
+
+func (_c *LoginFailuresRepository_ListAccountFailures_Call) Run(run func(ctx context.Context, account string)) *LoginFailuresRepository_ListAccountFailures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *LoginFailuresRepository_ListAccountFailures_Call) Return(_a0 []*dao.LoginFailureModel, _a1 error) *LoginFailuresRepository_ListAccountFailures_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoginFailuresRepository_ListAccountFailures_Call) RunAndReturn(run func(context.Context, string) ([]*dao.LoginFailureModel, error)) *LoginFailuresRepository_ListAccountFailures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Prune provides a mock function with given fields: ctx, before
+func (_m *LoginFailuresRepository) Prune(ctx context.Context, before time.Time) error {
+	ret := _m.Called(ctx, before)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoginFailuresRepository_Prune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Prune'
+type LoginFailuresRepository_Prune_Call struct {
+	*mock.Call
+}
+
+// Prune is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *LoginFailuresRepository_Expecter) Prune(ctx interface{}, before interface{}) *LoginFailuresRepository_Prune_Call {
+	return &LoginFailuresRepository_Prune_Call{Call: _e.mock.On("Prune", ctx, before)}
+}
+
+func (_c *LoginFailuresRepository_Prune_Call) Run(run func(ctx context.Context, before time.Time)) *LoginFailuresRepository_Prune_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LoginFailuresRepository_Prune_Call) Return(_a0 error) *LoginFailuresRepository_Prune_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoginFailuresRepository_Prune_Call) RunAndReturn(run func(context.Context, time.Time) error) *LoginFailuresRepository_Prune_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function with given fields: ctx, data, id, now
+func (_m *LoginFailuresRepository) Record(ctx context.Context, data *dao.LoginFailureModelCore, id uuid.UUID, now time.Time) (*dao.LoginFailureModel, error) {
+	ret := _m.Called(ctx, data, id, now)
+
+	var r0 *dao.LoginFailureModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.LoginFailureModelCore, uuid.UUID, time.Time) (*dao.LoginFailureModel, error)); ok {
+		return rf(ctx, data, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.LoginFailureModelCore, uuid.UUID, time.Time) *dao.LoginFailureModel); ok {
+		r0 = rf(ctx, data, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.LoginFailureModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.LoginFailureModelCore, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, data, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginFailuresRepository_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type LoginFailuresRepository_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *dao.LoginFailureModelCore
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *LoginFailuresRepository_Expecter) Record(ctx interface{}, data interface{}, id interface{}, now interface{}) *LoginFailuresRepository_Record_Call {
+	return &LoginFailuresRepository_Record_Call{Call: _e.mock.On("Record", ctx, data, id, now)}
+}
+
+func (_c *LoginFailuresRepository_Record_Call) Run(run func(ctx context.Context, data *dao.LoginFailureModelCore, id uuid.UUID, now time.Time)) *LoginFailuresRepository_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.LoginFailureModelCore), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LoginFailuresRepository_Record_Call) Return(_a0 *dao.LoginFailureModel, _a1 error) *LoginFailuresRepository_Record_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoginFailuresRepository_Record_Call) RunAndReturn(run func(context.Context, *dao.LoginFailureModelCore, uuid.UUID, time.Time) (*dao.LoginFailureModel, error)) *LoginFailuresRepository_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLoginFailuresRepository creates a new instance of LoginFailuresRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoginFailuresRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoginFailuresRepository {
+	mock := &LoginFailuresRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
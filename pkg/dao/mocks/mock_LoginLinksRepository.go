@@ -0,0 +1,207 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+	time "time"
+
+	dao "github.com/a-novel/auth-service/pkg/dao"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LoginLinksRepository is an autogenerated mock type for the LoginLinksRepository type
+type LoginLinksRepository struct {
+	mock.Mock
+}
+
+type LoginLinksRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LoginLinksRepository) EXPECT() *LoginLinksRepository_Expecter {
+	return &LoginLinksRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, data, id, now
+func (_m *LoginLinksRepository) Create(ctx context.Context, data *dao.LoginLinkModelCore, id uuid.UUID, now time.Time) (*dao.LoginLinkModel, error) {
+	ret := _m.Called(ctx, data, id, now)
+
+	var r0 *dao.LoginLinkModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.LoginLinkModelCore, uuid.UUID, time.Time) (*dao.LoginLinkModel, error)); ok {
+		return rf(ctx, data, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.LoginLinkModelCore, uuid.UUID, time.Time) *dao.LoginLinkModel); ok {
+		r0 = rf(ctx, data, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.LoginLinkModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.LoginLinkModelCore, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, data, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginLinksRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type LoginLinksRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *dao.LoginLinkModelCore
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *LoginLinksRepository_Expecter) Create(ctx interface{}, data interface{}, id interface{}, now interface{}) *LoginLinksRepository_Create_Call {
+	return &LoginLinksRepository_Create_Call{Call: _e.mock.On("Create", ctx, data, id, now)}
+}
+
+func (_c *LoginLinksRepository_Create_Call) Run(run func(ctx context.Context, data *dao.LoginLinkModelCore, id uuid.UUID, now time.Time)) *LoginLinksRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.LoginLinkModelCore), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LoginLinksRepository_Create_Call) Return(_a0 *dao.LoginLinkModel, _a1 error) *LoginLinksRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoginLinksRepository_Create_Call) RunAndReturn(run func(context.Context, *dao.LoginLinkModelCore, uuid.UUID, time.Time) (*dao.LoginLinkModel, error)) *LoginLinksRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *LoginLinksRepository) Get(ctx context.Context, id uuid.UUID) (*dao.LoginLinkModel, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *dao.LoginLinkModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*dao.LoginLinkModel, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *dao.LoginLinkModel); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.LoginLinkModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginLinksRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type LoginLinksRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *LoginLinksRepository_Expecter) Get(ctx interface{}, id interface{}) *LoginLinksRepository_Get_Call {
+	return &LoginLinksRepository_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *LoginLinksRepository_Get_Call) Run(run func(ctx context.Context, id uuid.UUID)) *LoginLinksRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *LoginLinksRepository_Get_Call) Return(_a0 *dao.LoginLinkModel, _a1 error) *LoginLinksRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoginLinksRepository_Get_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*dao.LoginLinkModel, error)) *LoginLinksRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Use provides a mock function with given fields: ctx, id, now
+func (_m *LoginLinksRepository) Use(ctx context.Context, id uuid.UUID, now time.Time) (*dao.LoginLinkModel, error) {
+	ret := _m.Called(ctx, id, now)
+
+	var r0 *dao.LoginLinkModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (*dao.LoginLinkModel, error)); ok {
+		return rf(ctx, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *dao.LoginLinkModel); ok {
+		r0 = rf(ctx, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.LoginLinkModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginLinksRepository_Use_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Use'
+type LoginLinksRepository_Use_Call struct {
+	*mock.Call
+}
+
+// Use is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *LoginLinksRepository_Expecter) Use(ctx interface{}, id interface{}, now interface{}) *LoginLinksRepository_Use_Call {
+	return &LoginLinksRepository_Use_Call{Call: _e.mock.On("Use", ctx, id, now)}
+}
+
+func (_c *LoginLinksRepository_Use_Call) Run(run func(ctx context.Context, id uuid.UUID, now time.Time)) *LoginLinksRepository_Use_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LoginLinksRepository_Use_Call) Return(_a0 *dao.LoginLinkModel, _a1 error) *LoginLinksRepository_Use_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoginLinksRepository_Use_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) (*dao.LoginLinkModel, error)) *LoginLinksRepository_Use_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLoginLinksRepository creates a new instance of LoginLinksRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoginLinksRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoginLinksRepository {
+	mock := &LoginLinksRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,263 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+	time "time"
+
+	dao "github.com/a-novel/auth-service/pkg/dao"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MFAChallengesRepository is an autogenerated mock type for the MFAChallengesRepository type
+type MFAChallengesRepository struct {
+	mock.Mock
+}
+
+type MFAChallengesRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MFAChallengesRepository) EXPECT() *MFAChallengesRepository_Expecter {
+	return &MFAChallengesRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, data, id, now
+func (_m *MFAChallengesRepository) Create(ctx context.Context, data *dao.MFAChallengeModelCore, id uuid.UUID, now time.Time) (*dao.MFAChallengeModel, error) {
+	ret := _m.Called(ctx, data, id, now)
+
+	var r0 *dao.MFAChallengeModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.MFAChallengeModelCore, uuid.UUID, time.Time) (*dao.MFAChallengeModel, error)); ok {
+		return rf(ctx, data, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.MFAChallengeModelCore, uuid.UUID, time.Time) *dao.MFAChallengeModel); ok {
+		r0 = rf(ctx, data, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.MFAChallengeModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.MFAChallengeModelCore, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, data, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MFAChallengesRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MFAChallengesRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *dao.MFAChallengeModelCore
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *MFAChallengesRepository_Expecter) Create(ctx interface{}, data interface{}, id interface{}, now interface{}) *MFAChallengesRepository_Create_Call {
+	return &MFAChallengesRepository_Create_Call{Call: _e.mock.On("Create", ctx, data, id, now)}
+}
+
+func (_c *MFAChallengesRepository_Create_Call) Run(run func(ctx context.Context, data *dao.MFAChallengeModelCore, id uuid.UUID, now time.Time)) *MFAChallengesRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.MFAChallengeModelCore), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MFAChallengesRepository_Create_Call) Return(_a0 *dao.MFAChallengeModel, _a1 error) *MFAChallengesRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MFAChallengesRepository_Create_Call) RunAndReturn(run func(context.Context, *dao.MFAChallengeModelCore, uuid.UUID, time.Time) (*dao.MFAChallengeModel, error)) *MFAChallengesRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Fail provides a mock function with given fields: ctx, id, now
+func (_m *MFAChallengesRepository) Fail(ctx context.Context, id uuid.UUID, now time.Time) (*dao.MFAChallengeModel, error) {
+	ret := _m.Called(ctx, id, now)
+
+	var r0 *dao.MFAChallengeModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (*dao.MFAChallengeModel, error)); ok {
+		return rf(ctx, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *dao.MFAChallengeModel); ok {
+		r0 = rf(ctx, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.MFAChallengeModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MFAChallengesRepository_Fail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Fail'
+type MFAChallengesRepository_Fail_Call struct {
+	*mock.Call
+}
+
+// Fail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *MFAChallengesRepository_Expecter) Fail(ctx interface{}, id interface{}, now interface{}) *MFAChallengesRepository_Fail_Call {
+	return &MFAChallengesRepository_Fail_Call{Call: _e.mock.On("Fail", ctx, id, now)}
+}
+
+func (_c *MFAChallengesRepository_Fail_Call) Run(run func(ctx context.Context, id uuid.UUID, now time.Time)) *MFAChallengesRepository_Fail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MFAChallengesRepository_Fail_Call) Return(_a0 *dao.MFAChallengeModel, _a1 error) *MFAChallengesRepository_Fail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MFAChallengesRepository_Fail_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) (*dao.MFAChallengeModel, error)) *MFAChallengesRepository_Fail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *MFAChallengesRepository) Get(ctx context.Context, id uuid.UUID) (*dao.MFAChallengeModel, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *dao.MFAChallengeModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*dao.MFAChallengeModel, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *dao.MFAChallengeModel); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.MFAChallengeModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MFAChallengesRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MFAChallengesRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MFAChallengesRepository_Expecter) Get(ctx interface{}, id interface{}) *MFAChallengesRepository_Get_Call {
+	return &MFAChallengesRepository_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *MFAChallengesRepository_Get_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MFAChallengesRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MFAChallengesRepository_Get_Call) Return(_a0 *dao.MFAChallengeModel, _a1 error) *MFAChallengesRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MFAChallengesRepository_Get_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*dao.MFAChallengeModel, error)) *MFAChallengesRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Use provides a mock function with given fields: ctx, id, now
+func (_m *MFAChallengesRepository) Use(ctx context.Context, id uuid.UUID, now time.Time) (*dao.MFAChallengeModel, error) {
+	ret := _m.Called(ctx, id, now)
+
+	var r0 *dao.MFAChallengeModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (*dao.MFAChallengeModel, error)); ok {
+		return rf(ctx, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *dao.MFAChallengeModel); ok {
+		r0 = rf(ctx, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.MFAChallengeModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MFAChallengesRepository_Use_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Use'
+type MFAChallengesRepository_Use_Call struct {
+	*mock.Call
+}
+
+// Use is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *MFAChallengesRepository_Expecter) Use(ctx interface{}, id interface{}, now interface{}) *MFAChallengesRepository_Use_Call {
+	return &MFAChallengesRepository_Use_Call{Call: _e.mock.On("Use", ctx, id, now)}
+}
+
+func (_c *MFAChallengesRepository_Use_Call) Run(run func(ctx context.Context, id uuid.UUID, now time.Time)) *MFAChallengesRepository_Use_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MFAChallengesRepository_Use_Call) Return(_a0 *dao.MFAChallengeModel, _a1 error) *MFAChallengesRepository_Use_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MFAChallengesRepository_Use_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) (*dao.MFAChallengeModel, error)) *MFAChallengesRepository_Use_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMFAChallengesRepository creates a new instance of MFAChallengesRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMFAChallengesRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MFAChallengesRepository {
+	mock := &MFAChallengesRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,263 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+	time "time"
+
+	dao "github.com/a-novel/auth-service/pkg/dao"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PasskeysRepository is an autogenerated mock type for the PasskeysRepository type
+type PasskeysRepository struct {
+	mock.Mock
+}
+
+type PasskeysRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PasskeysRepository) EXPECT() *PasskeysRepository_Expecter {
+	return &PasskeysRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, data, id, now
+func (_m *PasskeysRepository) Create(ctx context.Context, data *dao.PasskeyModelCore, id uuid.UUID, now time.Time) (*dao.PasskeyModel, error) {
+	ret := _m.Called(ctx, data, id, now)
+
+	var r0 *dao.PasskeyModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.PasskeyModelCore, uuid.UUID, time.Time) (*dao.PasskeyModel, error)); ok {
+		return rf(ctx, data, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.PasskeyModelCore, uuid.UUID, time.Time) *dao.PasskeyModel); ok {
+		r0 = rf(ctx, data, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.PasskeyModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.PasskeyModelCore, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, data, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PasskeysRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type PasskeysRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *dao.PasskeyModelCore
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *PasskeysRepository_Expecter) Create(ctx interface{}, data interface{}, id interface{}, now interface{}) *PasskeysRepository_Create_Call {
+	return &PasskeysRepository_Create_Call{Call: _e.mock.On("Create", ctx, data, id, now)}
+}
+
+func (_c *PasskeysRepository_Create_Call) Run(run func(ctx context.Context, data *dao.PasskeyModelCore, id uuid.UUID, now time.Time)) *PasskeysRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.PasskeyModelCore), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PasskeysRepository_Create_Call) Return(_a0 *dao.PasskeyModel, _a1 error) *PasskeysRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PasskeysRepository_Create_Call) RunAndReturn(run func(context.Context, *dao.PasskeyModelCore, uuid.UUID, time.Time) (*dao.PasskeyModel, error)) *PasskeysRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByCredentialID provides a mock function with given fields: ctx, credentialID
+func (_m *PasskeysRepository) GetByCredentialID(ctx context.Context, credentialID []byte) (*dao.PasskeyModel, error) {
+	ret := _m.Called(ctx, credentialID)
+
+	var r0 *dao.PasskeyModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) (*dao.PasskeyModel, error)); ok {
+		return rf(ctx, credentialID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) *dao.PasskeyModel); ok {
+		r0 = rf(ctx, credentialID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.PasskeyModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, credentialID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PasskeysRepository_GetByCredentialID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByCredentialID'
+type PasskeysRepository_GetByCredentialID_Call struct {
+	*mock.Call
+}
+
+// GetByCredentialID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - credentialID []byte
+func (_e *PasskeysRepository_Expecter) GetByCredentialID(ctx interface{}, credentialID interface{}) *PasskeysRepository_GetByCredentialID_Call {
+	return &PasskeysRepository_GetByCredentialID_Call{Call: _e.mock.On("GetByCredentialID", ctx, credentialID)}
+}
+
+func (_c *PasskeysRepository_GetByCredentialID_Call) Run(run func(ctx context.Context, credentialID []byte)) *PasskeysRepository_GetByCredentialID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *PasskeysRepository_GetByCredentialID_Call) Return(_a0 *dao.PasskeyModel, _a1 error) *PasskeysRepository_GetByCredentialID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PasskeysRepository_GetByCredentialID_Call) RunAndReturn(run func(context.Context, []byte) (*dao.PasskeyModel, error)) *PasskeysRepository_GetByCredentialID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserPasskeys provides a mock function with given fields: ctx, userID
+func (_m *PasskeysRepository) ListUserPasskeys(ctx context.Context, userID uuid.UUID) ([]*dao.PasskeyModel, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*dao.PasskeyModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*dao.PasskeyModel, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*dao.PasskeyModel); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.PasskeyModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PasskeysRepository_ListUserPasskeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserPasskeys'
+type PasskeysRepository_ListUserPasskeys_Call struct {
+	*mock.Call
+}
+
+// ListUserPasskeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *PasskeysRepository_Expecter) ListUserPasskeys(ctx interface{}, userID interface{}) *PasskeysRepository_ListUserPasskeys_Call {
+	return &PasskeysRepository_ListUserPasskeys_Call{Call: _e.mock.On("ListUserPasskeys", ctx, userID)}
+}
+
+func (_c *PasskeysRepository_ListUserPasskeys_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *PasskeysRepository_ListUserPasskeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PasskeysRepository_ListUserPasskeys_Call) Return(_a0 []*dao.PasskeyModel, _a1 error) *PasskeysRepository_ListUserPasskeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PasskeysRepository_ListUserPasskeys_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*dao.PasskeyModel, error)) *PasskeysRepository_ListUserPasskeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Use provides a mock function with given fields: ctx, id, signCount, now
+func (_m *PasskeysRepository) Use(ctx context.Context, id uuid.UUID, signCount uint32, now time.Time) (*dao.PasskeyModel, error) {
+	ret := _m.Called(ctx, id, signCount, now)
+
+	var r0 *dao.PasskeyModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uint32, time.Time) (*dao.PasskeyModel, error)); ok {
+		return rf(ctx, id, signCount, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uint32, time.Time) *dao.PasskeyModel); ok {
+		r0 = rf(ctx, id, signCount, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.PasskeyModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uint32, time.Time) error); ok {
+		r1 = rf(ctx, id, signCount, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PasskeysRepository_Use_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Use'
+type PasskeysRepository_Use_Call struct {
+	*mock.Call
+}
+
+// Use is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - signCount uint32
+//   - now time.Time
+func (_e *PasskeysRepository_Expecter) Use(ctx interface{}, id interface{}, signCount interface{}, now interface{}) *PasskeysRepository_Use_Call {
+	return &PasskeysRepository_Use_Call{Call: _e.mock.On("Use", ctx, id, signCount, now)}
+}
+
+func (_c *PasskeysRepository_Use_Call) Run(run func(ctx context.Context, id uuid.UUID, signCount uint32, now time.Time)) *PasskeysRepository_Use_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uint32), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PasskeysRepository_Use_Call) Return(_a0 *dao.PasskeyModel, _a1 error) *PasskeysRepository_Use_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PasskeysRepository_Use_Call) RunAndReturn(run func(context.Context, uuid.UUID, uint32, time.Time) (*dao.PasskeyModel, error)) *PasskeysRepository_Use_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPasskeysRepository creates a new instance of PasskeysRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasskeysRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasskeysRepository {
+	mock := &PasskeysRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
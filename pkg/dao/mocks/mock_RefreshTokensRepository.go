@@ -0,0 +1,392 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+	time "time"
+
+	dao "github.com/a-novel/auth-service/pkg/dao"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RefreshTokensRepository is an autogenerated mock type for the RefreshTokensRepository type
+type RefreshTokensRepository struct {
+	mock.Mock
+}
+
+type RefreshTokensRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RefreshTokensRepository) EXPECT() *RefreshTokensRepository_Expecter {
+	return &RefreshTokensRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, data, id, now
+func (_m *RefreshTokensRepository) Create(ctx context.Context, data *dao.RefreshTokenModelCore, id uuid.UUID, now time.Time) (*dao.RefreshTokenModel, error) {
+	ret := _m.Called(ctx, data, id, now)
+
+	var r0 *dao.RefreshTokenModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.RefreshTokenModelCore, uuid.UUID, time.Time) (*dao.RefreshTokenModel, error)); ok {
+		return rf(ctx, data, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.RefreshTokenModelCore, uuid.UUID, time.Time) *dao.RefreshTokenModel); ok {
+		r0 = rf(ctx, data, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.RefreshTokenModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.RefreshTokenModelCore, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, data, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshTokensRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type RefreshTokensRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *dao.RefreshTokenModelCore
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *RefreshTokensRepository_Expecter) Create(ctx interface{}, data interface{}, id interface{}, now interface{}) *RefreshTokensRepository_Create_Call {
+	return &RefreshTokensRepository_Create_Call{Call: _e.mock.On("Create", ctx, data, id, now)}
+}
+
+func (_c *RefreshTokensRepository_Create_Call) Run(run func(ctx context.Context, data *dao.RefreshTokenModelCore, id uuid.UUID, now time.Time)) *RefreshTokensRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.RefreshTokenModelCore), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RefreshTokensRepository_Create_Call) Return(_a0 *dao.RefreshTokenModel, _a1 error) *RefreshTokensRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RefreshTokensRepository_Create_Call) RunAndReturn(run func(context.Context, *dao.RefreshTokenModelCore, uuid.UUID, time.Time) (*dao.RefreshTokenModel, error)) *RefreshTokensRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FamilyActive provides a mock function with given fields: ctx, familyID, now
+func (_m *RefreshTokensRepository) FamilyActive(ctx context.Context, familyID uuid.UUID, now time.Time) (bool, error) {
+	ret := _m.Called(ctx, familyID, now)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (bool, error)); ok {
+		return rf(ctx, familyID, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) bool); ok {
+		r0 = rf(ctx, familyID, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, familyID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshTokensRepository_FamilyActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FamilyActive'
+type RefreshTokensRepository_FamilyActive_Call struct {
+	*mock.Call
+}
+
+// FamilyActive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - familyID uuid.UUID
+//   - now time.Time
+func (_e *RefreshTokensRepository_Expecter) FamilyActive(ctx interface{}, familyID interface{}, now interface{}) *RefreshTokensRepository_FamilyActive_Call {
+	return &RefreshTokensRepository_FamilyActive_Call{Call: _e.mock.On("FamilyActive", ctx, familyID, now)}
+}
+
+func (_c *RefreshTokensRepository_FamilyActive_Call) Run(run func(ctx context.Context, familyID uuid.UUID, now time.Time)) *RefreshTokensRepository_FamilyActive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RefreshTokensRepository_FamilyActive_Call) Return(_a0 bool, _a1 error) *RefreshTokensRepository_FamilyActive_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RefreshTokensRepository_FamilyActive_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) (bool, error)) *RefreshTokensRepository_FamilyActive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRefreshToken provides a mock function with given fields: ctx, id
+func (_m *RefreshTokensRepository) GetRefreshToken(ctx context.Context, id uuid.UUID) (*dao.RefreshTokenModel, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *dao.RefreshTokenModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*dao.RefreshTokenModel, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *dao.RefreshTokenModel); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.RefreshTokenModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshTokensRepository_GetRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRefreshToken'
+type RefreshTokensRepository_GetRefreshToken_Call struct {
+	*mock.Call
+}
+
+// GetRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *RefreshTokensRepository_Expecter) GetRefreshToken(ctx interface{}, id interface{}) *RefreshTokensRepository_GetRefreshToken_Call {
+	return &RefreshTokensRepository_GetRefreshToken_Call{Call: _e.mock.On("GetRefreshToken", ctx, id)}
+}
+
+func (_c *RefreshTokensRepository_GetRefreshToken_Call) Run(run func(ctx context.Context, id uuid.UUID)) *RefreshTokensRepository_GetRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *RefreshTokensRepository_GetRefreshToken_Call) Return(_a0 *dao.RefreshTokenModel, _a1 error) *RefreshTokensRepository_GetRefreshToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RefreshTokensRepository_GetRefreshToken_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*dao.RefreshTokenModel, error)) *RefreshTokensRepository_GetRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeFamily provides a mock function with given fields: ctx, familyID, now
+func (_m *RefreshTokensRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID, now time.Time) error {
+	ret := _m.Called(ctx, familyID, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r0 = rf(ctx, familyID, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshTokensRepository_RevokeFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeFamily'
+type RefreshTokensRepository_RevokeFamily_Call struct {
+	*mock.Call
+}
+
+// RevokeFamily is a helper method to define mock.On call
+//   - ctx context.Context
+//   - familyID uuid.UUID
+//   - now time.Time
+func (_e *RefreshTokensRepository_Expecter) RevokeFamily(ctx interface{}, familyID interface{}, now interface{}) *RefreshTokensRepository_RevokeFamily_Call {
+	return &RefreshTokensRepository_RevokeFamily_Call{Call: _e.mock.On("RevokeFamily", ctx, familyID, now)}
+}
+
+func (_c *RefreshTokensRepository_RevokeFamily_Call) Run(run func(ctx context.Context, familyID uuid.UUID, now time.Time)) *RefreshTokensRepository_RevokeFamily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RefreshTokensRepository_RevokeFamily_Call) Return(_a0 error) *RefreshTokensRepository_RevokeFamily_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RefreshTokensRepository_RevokeFamily_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) error) *RefreshTokensRepository_RevokeFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeUser provides a mock function with given fields: ctx, userID, now
+func (_m *RefreshTokensRepository) RevokeUser(ctx context.Context, userID uuid.UUID, now time.Time) error {
+	ret := _m.Called(ctx, userID, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r0 = rf(ctx, userID, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshTokensRepository_RevokeUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeUser'
+type RefreshTokensRepository_RevokeUser_Call struct {
+	*mock.Call
+}
+
+// RevokeUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - now time.Time
+func (_e *RefreshTokensRepository_Expecter) RevokeUser(ctx interface{}, userID interface{}, now interface{}) *RefreshTokensRepository_RevokeUser_Call {
+	return &RefreshTokensRepository_RevokeUser_Call{Call: _e.mock.On("RevokeUser", ctx, userID, now)}
+}
+
+func (_c *RefreshTokensRepository_RevokeUser_Call) Run(run func(ctx context.Context, userID uuid.UUID, now time.Time)) *RefreshTokensRepository_RevokeUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RefreshTokensRepository_RevokeUser_Call) Return(_a0 error) *RefreshTokensRepository_RevokeUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RefreshTokensRepository_RevokeUser_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) error) *RefreshTokensRepository_RevokeUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunInTx provides a mock function with given fields: ctx, callback
+func (_m *RefreshTokensRepository) RunInTx(ctx context.Context, callback func(context.Context, dao.RefreshTokensRepository) error) error {
+	ret := _m.Called(ctx, callback)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context, dao.RefreshTokensRepository) error) error); ok {
+		r0 = rf(ctx, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshTokensRepository_RunInTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunInTx'
+type RefreshTokensRepository_RunInTx_Call struct {
+	*mock.Call
+}
+
+// RunInTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - callback func(context.Context , dao.RefreshTokensRepository) error
+func (_e *RefreshTokensRepository_Expecter) RunInTx(ctx interface{}, callback interface{}) *RefreshTokensRepository_RunInTx_Call {
+	return &RefreshTokensRepository_RunInTx_Call{Call: _e.mock.On("RunInTx", ctx, callback)}
+}
+
+func (_c *RefreshTokensRepository_RunInTx_Call) Run(run func(ctx context.Context, callback func(context.Context, dao.RefreshTokensRepository) error)) *RefreshTokensRepository_RunInTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context, dao.RefreshTokensRepository) error))
+	})
+	return _c
+}
+
+func (_c *RefreshTokensRepository_RunInTx_Call) Return(_a0 error) *RefreshTokensRepository_RunInTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RefreshTokensRepository_RunInTx_Call) RunAndReturn(run func(context.Context, func(context.Context, dao.RefreshTokensRepository) error) error) *RefreshTokensRepository_RunInTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Use provides a mock function with given fields: ctx, id, now
+func (_m *RefreshTokensRepository) Use(ctx context.Context, id uuid.UUID, now time.Time) (*dao.RefreshTokenModel, error) {
+	ret := _m.Called(ctx, id, now)
+
+	var r0 *dao.RefreshTokenModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (*dao.RefreshTokenModel, error)); ok {
+		return rf(ctx, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *dao.RefreshTokenModel); ok {
+		r0 = rf(ctx, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.RefreshTokenModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshTokensRepository_Use_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Use'
+type RefreshTokensRepository_Use_Call struct {
+	*mock.Call
+}
+
+// Use is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *RefreshTokensRepository_Expecter) Use(ctx interface{}, id interface{}, now interface{}) *RefreshTokensRepository_Use_Call {
+	return &RefreshTokensRepository_Use_Call{Call: _e.mock.On("Use", ctx, id, now)}
+}
+
+func (_c *RefreshTokensRepository_Use_Call) Run(run func(ctx context.Context, id uuid.UUID, now time.Time)) *RefreshTokensRepository_Use_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RefreshTokensRepository_Use_Call) Return(_a0 *dao.RefreshTokenModel, _a1 error) *RefreshTokensRepository_Use_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RefreshTokensRepository_Use_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) (*dao.RefreshTokenModel, error)) *RefreshTokensRepository_Use_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRefreshTokensRepository creates a new instance of RefreshTokensRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRefreshTokensRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RefreshTokensRepository {
+	mock := &RefreshTokensRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,192 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+	time "time"
+
+	dao "github.com/a-novel/auth-service/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RevokedSignatureKeysRepository is an autogenerated mock type for the RevokedSignatureKeysRepository type
+type RevokedSignatureKeysRepository struct {
+	mock.Mock
+}
+
+type RevokedSignatureKeysRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RevokedSignatureKeysRepository) EXPECT() *RevokedSignatureKeysRepository_Expecter {
+	return &RevokedSignatureKeysRepository_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *RevokedSignatureKeysRepository) List(ctx context.Context) ([]*dao.RevokedSignatureKeyModel, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*dao.RevokedSignatureKeyModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*dao.RevokedSignatureKeyModel, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*dao.RevokedSignatureKeyModel); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.RevokedSignatureKeyModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokedSignatureKeysRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type RevokedSignatureKeysRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *RevokedSignatureKeysRepository_Expecter) List(ctx interface{}) *RevokedSignatureKeysRepository_List_Call {
+	return &RevokedSignatureKeysRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *RevokedSignatureKeysRepository_List_Call) Run(run func(ctx context.Context)) *RevokedSignatureKeysRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *RevokedSignatureKeysRepository_List_Call) Return(_a0 []*dao.RevokedSignatureKeyModel, _a1 error) *RevokedSignatureKeysRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RevokedSignatureKeysRepository_List_Call) RunAndReturn(run func(context.Context) ([]*dao.RevokedSignatureKeyModel, error)) *RevokedSignatureKeysRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function with given fields: ctx, keyID, name, now
+func (_m *RevokedSignatureKeysRepository) Revoke(ctx context.Context, keyID string, name string, now time.Time) (*dao.RevokedSignatureKeyModel, error) {
+	ret := _m.Called(ctx, keyID, name, now)
+
+	var r0 *dao.RevokedSignatureKeyModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (*dao.RevokedSignatureKeyModel, error)); ok {
+		return rf(ctx, keyID, name, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) *dao.RevokedSignatureKeyModel); ok {
+		r0 = rf(ctx, keyID, name, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.RevokedSignatureKeyModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, keyID, name, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokedSignatureKeysRepository_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type RevokedSignatureKeysRepository_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keyID string
+//   - name string
+//   - now time.Time
+func (_e *RevokedSignatureKeysRepository_Expecter) Revoke(ctx interface{}, keyID interface{}, name interface{}, now interface{}) *RevokedSignatureKeysRepository_Revoke_Call {
+	return &RevokedSignatureKeysRepository_Revoke_Call{Call: _e.mock.On("Revoke", ctx, keyID, name, now)}
+}
+
+func (_c *RevokedSignatureKeysRepository_Revoke_Call) Run(run func(ctx context.Context, keyID string, name string, now time.Time)) *RevokedSignatureKeysRepository_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RevokedSignatureKeysRepository_Revoke_Call) Return(_a0 *dao.RevokedSignatureKeyModel, _a1 error) *RevokedSignatureKeysRepository_Revoke_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RevokedSignatureKeysRepository_Revoke_Call) RunAndReturn(run func(context.Context, string, string, time.Time) (*dao.RevokedSignatureKeyModel, error)) *RevokedSignatureKeysRepository_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunInTx provides a mock function with given fields: ctx, callback
+func (_m *RevokedSignatureKeysRepository) RunInTx(ctx context.Context, callback func(context.Context, dao.RevokedSignatureKeysRepository) error) error {
+	ret := _m.Called(ctx, callback)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context, dao.RevokedSignatureKeysRepository) error) error); ok {
+		r0 = rf(ctx, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokedSignatureKeysRepository_RunInTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunInTx'
+type RevokedSignatureKeysRepository_RunInTx_Call struct {
+	*mock.Call
+}
+
+// RunInTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - callback func(context.Context , dao.RevokedSignatureKeysRepository) error
+func (_e *RevokedSignatureKeysRepository_Expecter) RunInTx(ctx interface{}, callback interface{}) *RevokedSignatureKeysRepository_RunInTx_Call {
+	return &RevokedSignatureKeysRepository_RunInTx_Call{Call: _e.mock.On("RunInTx", ctx, callback)}
+}
+
+func (_c *RevokedSignatureKeysRepository_RunInTx_Call) Run(run func(ctx context.Context, callback func(context.Context, dao.RevokedSignatureKeysRepository) error)) *RevokedSignatureKeysRepository_RunInTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context, dao.RevokedSignatureKeysRepository) error))
+	})
+	return _c
+}
+
+func (_c *RevokedSignatureKeysRepository_RunInTx_Call) Return(_a0 error) *RevokedSignatureKeysRepository_RunInTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RevokedSignatureKeysRepository_RunInTx_Call) RunAndReturn(run func(context.Context, func(context.Context, dao.RevokedSignatureKeysRepository) error) error) *RevokedSignatureKeysRepository_RunInTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRevokedSignatureKeysRepository creates a new instance of RevokedSignatureKeysRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRevokedSignatureKeysRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RevokedSignatureKeysRepository {
+	mock := &RevokedSignatureKeysRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,294 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+	time "time"
+
+	dao "github.com/a-novel/auth-service/pkg/dao"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RevokedTokensRepository is an autogenerated mock type for the RevokedTokensRepository type
+type RevokedTokensRepository struct {
+	mock.Mock
+}
+
+type RevokedTokensRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RevokedTokensRepository) EXPECT() *RevokedTokensRepository_Expecter {
+	return &RevokedTokensRepository_Expecter{mock: &_m.Mock}
+}
+
+// IsRevoked provides a mock function with given fields: ctx, id, userID, issuedAt
+func (_m *RevokedTokensRepository) IsRevoked(ctx context.Context, id uuid.UUID, userID uuid.UUID, issuedAt time.Time) (bool, error) {
+	ret := _m.Called(ctx, id, userID, issuedAt)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) (bool, error)); ok {
+		return rf(ctx, id, userID, issuedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) bool); ok {
+		r0 = rf(ctx, id, userID, issuedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, id, userID, issuedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokedTokensRepository_IsRevoked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsRevoked'
+type RevokedTokensRepository_IsRevoked_Call struct {
+	*mock.Call
+}
+
+// IsRevoked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - userID uuid.UUID
+//   - issuedAt time.Time
+func (_e *RevokedTokensRepository_Expecter) IsRevoked(ctx interface{}, id interface{}, userID interface{}, issuedAt interface{}) *RevokedTokensRepository_IsRevoked_Call {
+	return &RevokedTokensRepository_IsRevoked_Call{Call: _e.mock.On("IsRevoked", ctx, id, userID, issuedAt)}
+}
+
+func (_c *RevokedTokensRepository_IsRevoked_Call) Run(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID, issuedAt time.Time)) *RevokedTokensRepository_IsRevoked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RevokedTokensRepository_IsRevoked_Call) Return(_a0 bool, _a1 error) *RevokedTokensRepository_IsRevoked_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RevokedTokensRepository_IsRevoked_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, time.Time) (bool, error)) *RevokedTokensRepository_IsRevoked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Prune provides a mock function with given fields: ctx, now
+func (_m *RevokedTokensRepository) Prune(ctx context.Context, now time.Time) error {
+	ret := _m.Called(ctx, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokedTokensRepository_Prune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Prune'
+type RevokedTokensRepository_Prune_Call struct {
+	*mock.Call
+}
+
+// Prune is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *RevokedTokensRepository_Expecter) Prune(ctx interface{}, now interface{}) *RevokedTokensRepository_Prune_Call {
+	return &RevokedTokensRepository_Prune_Call{Call: _e.mock.On("Prune", ctx, now)}
+}
+
+func (_c *RevokedTokensRepository_Prune_Call) Run(run func(ctx context.Context, now time.Time)) *RevokedTokensRepository_Prune_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RevokedTokensRepository_Prune_Call) Return(_a0 error) *RevokedTokensRepository_Prune_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RevokedTokensRepository_Prune_Call) RunAndReturn(run func(context.Context, time.Time) error) *RevokedTokensRepository_Prune_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function with given fields: ctx, data, id, now
+func (_m *RevokedTokensRepository) Revoke(ctx context.Context, data *dao.RevokedTokenModelCore, id uuid.UUID, now time.Time) (*dao.RevokedTokenModel, error) {
+	ret := _m.Called(ctx, data, id, now)
+
+	var r0 *dao.RevokedTokenModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.RevokedTokenModelCore, uuid.UUID, time.Time) (*dao.RevokedTokenModel, error)); ok {
+		return rf(ctx, data, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.RevokedTokenModelCore, uuid.UUID, time.Time) *dao.RevokedTokenModel); ok {
+		r0 = rf(ctx, data, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.RevokedTokenModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.RevokedTokenModelCore, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, data, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokedTokensRepository_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type RevokedTokensRepository_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *dao.RevokedTokenModelCore
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *RevokedTokensRepository_Expecter) Revoke(ctx interface{}, data interface{}, id interface{}, now interface{}) *RevokedTokensRepository_Revoke_Call {
+	return &RevokedTokensRepository_Revoke_Call{Call: _e.mock.On("Revoke", ctx, data, id, now)}
+}
+
+func (_c *RevokedTokensRepository_Revoke_Call) Run(run func(ctx context.Context, data *dao.RevokedTokenModelCore, id uuid.UUID, now time.Time)) *RevokedTokensRepository_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.RevokedTokenModelCore), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RevokedTokensRepository_Revoke_Call) Return(_a0 *dao.RevokedTokenModel, _a1 error) *RevokedTokensRepository_Revoke_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RevokedTokensRepository_Revoke_Call) RunAndReturn(run func(context.Context, *dao.RevokedTokenModelCore, uuid.UUID, time.Time) (*dao.RevokedTokenModel, error)) *RevokedTokensRepository_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeUser provides a mock function with given fields: ctx, userID, expiresAt, now
+func (_m *RevokedTokensRepository) RevokeUser(ctx context.Context, userID uuid.UUID, expiresAt time.Time, now time.Time) (*dao.RevokedUserTokensModel, error) {
+	ret := _m.Called(ctx, userID, expiresAt, now)
+
+	var r0 *dao.RevokedUserTokensModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) (*dao.RevokedUserTokensModel, error)); ok {
+		return rf(ctx, userID, expiresAt, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) *dao.RevokedUserTokensModel); ok {
+		r0 = rf(ctx, userID, expiresAt, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.RevokedUserTokensModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, expiresAt, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokedTokensRepository_RevokeUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeUser'
+type RevokedTokensRepository_RevokeUser_Call struct {
+	*mock.Call
+}
+
+// RevokeUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - expiresAt time.Time
+//   - now time.Time
+func (_e *RevokedTokensRepository_Expecter) RevokeUser(ctx interface{}, userID interface{}, expiresAt interface{}, now interface{}) *RevokedTokensRepository_RevokeUser_Call {
+	return &RevokedTokensRepository_RevokeUser_Call{Call: _e.mock.On("RevokeUser", ctx, userID, expiresAt, now)}
+}
+
+func (_c *RevokedTokensRepository_RevokeUser_Call) Run(run func(ctx context.Context, userID uuid.UUID, expiresAt time.Time, now time.Time)) *RevokedTokensRepository_RevokeUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RevokedTokensRepository_RevokeUser_Call) Return(_a0 *dao.RevokedUserTokensModel, _a1 error) *RevokedTokensRepository_RevokeUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RevokedTokensRepository_RevokeUser_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time, time.Time) (*dao.RevokedUserTokensModel, error)) *RevokedTokensRepository_RevokeUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunInTx provides a mock function with given fields: ctx, callback
+func (_m *RevokedTokensRepository) RunInTx(ctx context.Context, callback func(context.Context, dao.RevokedTokensRepository) error) error {
+	ret := _m.Called(ctx, callback)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context, dao.RevokedTokensRepository) error) error); ok {
+		r0 = rf(ctx, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokedTokensRepository_RunInTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunInTx'
+type RevokedTokensRepository_RunInTx_Call struct {
+	*mock.Call
+}
+
+// RunInTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - callback func(context.Context , dao.RevokedTokensRepository) error
+func (_e *RevokedTokensRepository_Expecter) RunInTx(ctx interface{}, callback interface{}) *RevokedTokensRepository_RunInTx_Call {
+	return &RevokedTokensRepository_RunInTx_Call{Call: _e.mock.On("RunInTx", ctx, callback)}
+}
+
+func (_c *RevokedTokensRepository_RunInTx_Call) Run(run func(ctx context.Context, callback func(context.Context, dao.RevokedTokensRepository) error)) *RevokedTokensRepository_RunInTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context, dao.RevokedTokensRepository) error))
+	})
+	return _c
+}
+
+func (_c *RevokedTokensRepository_RunInTx_Call) Return(_a0 error) *RevokedTokensRepository_RunInTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RevokedTokensRepository_RunInTx_Call) RunAndReturn(run func(context.Context, func(context.Context, dao.RevokedTokensRepository) error) error) *RevokedTokensRepository_RunInTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRevokedTokensRepository creates a new instance of RevokedTokensRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRevokedTokensRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RevokedTokensRepository {
+	mock := &RevokedTokensRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
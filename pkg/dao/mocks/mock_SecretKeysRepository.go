@@ -5,9 +5,9 @@ package daomocks
 import (
 	context "context"
 	ed25519 "crypto/ed25519"
+	time "time"
 
 	dao "github.com/a-novel/auth-service/pkg/dao"
-
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -176,25 +176,110 @@ func (_c *SecretKeysRepository_Read_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
-// Write provides a mock function with given fields: ctx, key, name
-func (_m *SecretKeysRepository) Write(ctx context.Context, key ed25519.PrivateKey, name string) (*dao.SecretKeyModel, error) {
-	ret := _m.Called(ctx, key, name)
+// Refresh provides a mock function with given fields: ctx
+func (_m *SecretKeysRepository) Refresh(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SecretKeysRepository_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type SecretKeysRepository_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *SecretKeysRepository_Expecter) Refresh(ctx interface{}) *SecretKeysRepository_Refresh_Call {
+	return &SecretKeysRepository_Refresh_Call{Call: _e.mock.On("Refresh", ctx)}
+}
+
+func (_c *SecretKeysRepository_Refresh_Call) Run(run func(ctx context.Context)) *SecretKeysRepository_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *SecretKeysRepository_Refresh_Call) Return(_a0 error) *SecretKeysRepository_Refresh_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SecretKeysRepository_Refresh_Call) RunAndReturn(run func(context.Context) error) *SecretKeysRepository_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunInTx provides a mock function with given fields: ctx, callback
+func (_m *SecretKeysRepository) RunInTx(ctx context.Context, callback func(context.Context, dao.SecretKeysRepository) error) error {
+	ret := _m.Called(ctx, callback)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context, dao.SecretKeysRepository) error) error); ok {
+		r0 = rf(ctx, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SecretKeysRepository_RunInTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunInTx'
+type SecretKeysRepository_RunInTx_Call struct {
+	*mock.Call
+}
+
+// RunInTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - callback func(context.Context , dao.SecretKeysRepository) error
+func (_e *SecretKeysRepository_Expecter) RunInTx(ctx interface{}, callback interface{}) *SecretKeysRepository_RunInTx_Call {
+	return &SecretKeysRepository_RunInTx_Call{Call: _e.mock.On("RunInTx", ctx, callback)}
+}
+
+func (_c *SecretKeysRepository_RunInTx_Call) Run(run func(ctx context.Context, callback func(context.Context, dao.SecretKeysRepository) error)) *SecretKeysRepository_RunInTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context, dao.SecretKeysRepository) error))
+	})
+	return _c
+}
+
+func (_c *SecretKeysRepository_RunInTx_Call) Return(_a0 error) *SecretKeysRepository_RunInTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SecretKeysRepository_RunInTx_Call) RunAndReturn(run func(context.Context, func(context.Context, dao.SecretKeysRepository) error) error) *SecretKeysRepository_RunInTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Write provides a mock function with given fields: ctx, key, name, now, activatesAt
+func (_m *SecretKeysRepository) Write(ctx context.Context, key ed25519.PrivateKey, name string, now time.Time, activatesAt time.Time) (*dao.SecretKeyModel, error) {
+	ret := _m.Called(ctx, key, name, now, activatesAt)
 
 	var r0 *dao.SecretKeyModel
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, ed25519.PrivateKey, string) (*dao.SecretKeyModel, error)); ok {
-		return rf(ctx, key, name)
+	if rf, ok := ret.Get(0).(func(context.Context, ed25519.PrivateKey, string, time.Time, time.Time) (*dao.SecretKeyModel, error)); ok {
+		return rf(ctx, key, name, now, activatesAt)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ed25519.PrivateKey, string) *dao.SecretKeyModel); ok {
-		r0 = rf(ctx, key, name)
+	if rf, ok := ret.Get(0).(func(context.Context, ed25519.PrivateKey, string, time.Time, time.Time) *dao.SecretKeyModel); ok {
+		r0 = rf(ctx, key, name, now, activatesAt)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dao.SecretKeyModel)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, ed25519.PrivateKey, string) error); ok {
-		r1 = rf(ctx, key, name)
+	if rf, ok := ret.Get(1).(func(context.Context, ed25519.PrivateKey, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, key, name, now, activatesAt)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -211,13 +296,15 @@ type SecretKeysRepository_Write_Call struct {
 //   - ctx context.Context
 //   - key ed25519.PrivateKey
 //   - name string
-func (_e *SecretKeysRepository_Expecter) Write(ctx interface{}, key interface{}, name interface{}) *SecretKeysRepository_Write_Call {
-	return &SecretKeysRepository_Write_Call{Call: _e.mock.On("Write", ctx, key, name)}
+//   - now time.Time
+//   - activatesAt time.Time
+func (_e *SecretKeysRepository_Expecter) Write(ctx interface{}, key interface{}, name interface{}, now interface{}, activatesAt interface{}) *SecretKeysRepository_Write_Call {
+	return &SecretKeysRepository_Write_Call{Call: _e.mock.On("Write", ctx, key, name, now, activatesAt)}
 }
 
-func (_c *SecretKeysRepository_Write_Call) Run(run func(ctx context.Context, key ed25519.PrivateKey, name string)) *SecretKeysRepository_Write_Call {
+func (_c *SecretKeysRepository_Write_Call) Run(run func(ctx context.Context, key ed25519.PrivateKey, name string, now time.Time, activatesAt time.Time)) *SecretKeysRepository_Write_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(ed25519.PrivateKey), args[2].(string))
+		run(args[0].(context.Context), args[1].(ed25519.PrivateKey), args[2].(string), args[3].(time.Time), args[4].(time.Time))
 	})
 	return _c
 }
@@ -227,7 +314,7 @@ func (_c *SecretKeysRepository_Write_Call) Return(_a0 *dao.SecretKeyModel, _a1 e
 	return _c
 }
 
-func (_c *SecretKeysRepository_Write_Call) RunAndReturn(run func(context.Context, ed25519.PrivateKey, string) (*dao.SecretKeyModel, error)) *SecretKeysRepository_Write_Call {
+func (_c *SecretKeysRepository_Write_Call) RunAndReturn(run func(context.Context, ed25519.PrivateKey, string, time.Time, time.Time) (*dao.SecretKeyModel, error)) *SecretKeysRepository_Write_Call {
 	_c.Call.Return(run)
 	return _c
 }
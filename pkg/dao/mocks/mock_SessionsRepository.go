@@ -0,0 +1,561 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+	time "time"
+
+	dao "github.com/a-novel/auth-service/pkg/dao"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionsRepository is an autogenerated mock type for the SessionsRepository type
+type SessionsRepository struct {
+	mock.Mock
+}
+
+type SessionsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SessionsRepository) EXPECT() *SessionsRepository_Expecter {
+	return &SessionsRepository_Expecter{mock: &_m.Mock}
+}
+
+// CountLiveSessionsByKey provides a mock function with given fields: ctx, keyID, now
+func (_m *SessionsRepository) CountLiveSessionsByKey(ctx context.Context, keyID string, now time.Time) (int, error) {
+	ret := _m.Called(ctx, keyID, now)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (int, error)); ok {
+		return rf(ctx, keyID, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) int); ok {
+		r0 = rf(ctx, keyID, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, keyID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionsRepository_CountLiveSessionsByKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountLiveSessionsByKey'
+type SessionsRepository_CountLiveSessionsByKey_Call struct {
+	*mock.Call
+}
+
+// CountLiveSessionsByKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keyID string
+//   - now time.Time
+func (_e *SessionsRepository_Expecter) CountLiveSessionsByKey(ctx interface{}, keyID interface{}, now interface{}) *SessionsRepository_CountLiveSessionsByKey_Call {
+	return &SessionsRepository_CountLiveSessionsByKey_Call{Call: _e.mock.On("CountLiveSessionsByKey", ctx, keyID, now)}
+}
+
+func (_c *SessionsRepository_CountLiveSessionsByKey_Call) Run(run func(ctx context.Context, keyID string, now time.Time)) *SessionsRepository_CountLiveSessionsByKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *SessionsRepository_CountLiveSessionsByKey_Call) Return(_a0 int, _a1 error) *SessionsRepository_CountLiveSessionsByKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionsRepository_CountLiveSessionsByKey_Call) RunAndReturn(run func(context.Context, string, time.Time) (int, error)) *SessionsRepository_CountLiveSessionsByKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, data, id, now
+func (_m *SessionsRepository) Create(ctx context.Context, data *dao.SessionModelCore, id uuid.UUID, now time.Time) (*dao.SessionModel, error) {
+	ret := _m.Called(ctx, data, id, now)
+
+	var r0 *dao.SessionModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.SessionModelCore, uuid.UUID, time.Time) (*dao.SessionModel, error)); ok {
+		return rf(ctx, data, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.SessionModelCore, uuid.UUID, time.Time) *dao.SessionModel); ok {
+		r0 = rf(ctx, data, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.SessionModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.SessionModelCore, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, data, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionsRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type SessionsRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *dao.SessionModelCore
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *SessionsRepository_Expecter) Create(ctx interface{}, data interface{}, id interface{}, now interface{}) *SessionsRepository_Create_Call {
+	return &SessionsRepository_Create_Call{Call: _e.mock.On("Create", ctx, data, id, now)}
+}
+
+func (_c *SessionsRepository_Create_Call) Run(run func(ctx context.Context, data *dao.SessionModelCore, id uuid.UUID, now time.Time)) *SessionsRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.SessionModelCore), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *SessionsRepository_Create_Call) Return(_a0 *dao.SessionModel, _a1 error) *SessionsRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionsRepository_Create_Call) RunAndReturn(run func(context.Context, *dao.SessionModelCore, uuid.UUID, time.Time) (*dao.SessionModel, error)) *SessionsRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSessionByFamily provides a mock function with given fields: ctx, familyID
+func (_m *SessionsRepository) GetSessionByFamily(ctx context.Context, familyID uuid.UUID) (*dao.SessionModel, error) {
+	ret := _m.Called(ctx, familyID)
+
+	var r0 *dao.SessionModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*dao.SessionModel, error)); ok {
+		return rf(ctx, familyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *dao.SessionModel); ok {
+		r0 = rf(ctx, familyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.SessionModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, familyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionsRepository_GetSessionByFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSessionByFamily'
+type SessionsRepository_GetSessionByFamily_Call struct {
+	*mock.Call
+}
+
+// GetSessionByFamily is a helper method to define mock.On call
+//   - ctx context.Context
+//   - familyID uuid.UUID
+func (_e *SessionsRepository_Expecter) GetSessionByFamily(ctx interface{}, familyID interface{}) *SessionsRepository_GetSessionByFamily_Call {
+	return &SessionsRepository_GetSessionByFamily_Call{Call: _e.mock.On("GetSessionByFamily", ctx, familyID)}
+}
+
+func (_c *SessionsRepository_GetSessionByFamily_Call) Run(run func(ctx context.Context, familyID uuid.UUID)) *SessionsRepository_GetSessionByFamily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *SessionsRepository_GetSessionByFamily_Call) Return(_a0 *dao.SessionModel, _a1 error) *SessionsRepository_GetSessionByFamily_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionsRepository_GetSessionByFamily_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*dao.SessionModel, error)) *SessionsRepository_GetSessionByFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserAgents provides a mock function with given fields: ctx, userID
+func (_m *SessionsRepository) ListUserAgents(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionsRepository_ListUserAgents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserAgents'
+type SessionsRepository_ListUserAgents_Call struct {
+	*mock.Call
+}
+
+// ListUserAgents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *SessionsRepository_Expecter) ListUserAgents(ctx interface{}, userID interface{}) *SessionsRepository_ListUserAgents_Call {
+	return &SessionsRepository_ListUserAgents_Call{Call: _e.mock.On("ListUserAgents", ctx, userID)}
+}
+
+func (_c *SessionsRepository_ListUserAgents_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *SessionsRepository_ListUserAgents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *SessionsRepository_ListUserAgents_Call) Return(_a0 []string, _a1 error) *SessionsRepository_ListUserAgents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionsRepository_ListUserAgents_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]string, error)) *SessionsRepository_ListUserAgents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserSessions provides a mock function with given fields: ctx, userID
+func (_m *SessionsRepository) ListUserSessions(ctx context.Context, userID uuid.UUID) ([]*dao.SessionModel, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*dao.SessionModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*dao.SessionModel, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*dao.SessionModel); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.SessionModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionsRepository_ListUserSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserSessions'
+type SessionsRepository_ListUserSessions_Call struct {
+	*mock.Call
+}
+
+// ListUserSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *SessionsRepository_Expecter) ListUserSessions(ctx interface{}, userID interface{}) *SessionsRepository_ListUserSessions_Call {
+	return &SessionsRepository_ListUserSessions_Call{Call: _e.mock.On("ListUserSessions", ctx, userID)}
+}
+
+func (_c *SessionsRepository_ListUserSessions_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *SessionsRepository_ListUserSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *SessionsRepository_ListUserSessions_Call) Return(_a0 []*dao.SessionModel, _a1 error) *SessionsRepository_ListUserSessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionsRepository_ListUserSessions_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*dao.SessionModel, error)) *SessionsRepository_ListUserSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserSessionsHistory provides a mock function with given fields: ctx, userID
+func (_m *SessionsRepository) ListUserSessionsHistory(ctx context.Context, userID uuid.UUID) ([]*dao.SessionModel, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*dao.SessionModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*dao.SessionModel, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*dao.SessionModel); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.SessionModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionsRepository_ListUserSessionsHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserSessionsHistory'
+type SessionsRepository_ListUserSessionsHistory_Call struct {
+	*mock.Call
+}
+
+// ListUserSessionsHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *SessionsRepository_Expecter) ListUserSessionsHistory(ctx interface{}, userID interface{}) *SessionsRepository_ListUserSessionsHistory_Call {
+	return &SessionsRepository_ListUserSessionsHistory_Call{Call: _e.mock.On("ListUserSessionsHistory", ctx, userID)}
+}
+
+func (_c *SessionsRepository_ListUserSessionsHistory_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *SessionsRepository_ListUserSessionsHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *SessionsRepository_ListUserSessionsHistory_Call) Return(_a0 []*dao.SessionModel, _a1 error) *SessionsRepository_ListUserSessionsHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionsRepository_ListUserSessionsHistory_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*dao.SessionModel, error)) *SessionsRepository_ListUserSessionsHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function with given fields: ctx, id, userID, now
+func (_m *SessionsRepository) Revoke(ctx context.Context, id uuid.UUID, userID uuid.UUID, now time.Time) (*dao.SessionModel, error) {
+	ret := _m.Called(ctx, id, userID, now)
+
+	var r0 *dao.SessionModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) (*dao.SessionModel, error)); ok {
+		return rf(ctx, id, userID, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) *dao.SessionModel); ok {
+		r0 = rf(ctx, id, userID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.SessionModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, id, userID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionsRepository_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type SessionsRepository_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - userID uuid.UUID
+//   - now time.Time
+func (_e *SessionsRepository_Expecter) Revoke(ctx interface{}, id interface{}, userID interface{}, now interface{}) *SessionsRepository_Revoke_Call {
+	return &SessionsRepository_Revoke_Call{Call: _e.mock.On("Revoke", ctx, id, userID, now)}
+}
+
+func (_c *SessionsRepository_Revoke_Call) Run(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID, now time.Time)) *SessionsRepository_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *SessionsRepository_Revoke_Call) Return(_a0 *dao.SessionModel, _a1 error) *SessionsRepository_Revoke_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionsRepository_Revoke_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, time.Time) (*dao.SessionModel, error)) *SessionsRepository_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunInTx provides a mock function with given fields: ctx, callback
+func (_m *SessionsRepository) RunInTx(ctx context.Context, callback func(context.Context, dao.SessionsRepository) error) error {
+	ret := _m.Called(ctx, callback)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context, dao.SessionsRepository) error) error); ok {
+		r0 = rf(ctx, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionsRepository_RunInTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunInTx'
+type SessionsRepository_RunInTx_Call struct {
+	*mock.Call
+}
+
+// RunInTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - callback func(context.Context , dao.SessionsRepository) error
+func (_e *SessionsRepository_Expecter) RunInTx(ctx interface{}, callback interface{}) *SessionsRepository_RunInTx_Call {
+	return &SessionsRepository_RunInTx_Call{Call: _e.mock.On("RunInTx", ctx, callback)}
+}
+
+func (_c *SessionsRepository_RunInTx_Call) Run(run func(ctx context.Context, callback func(context.Context, dao.SessionsRepository) error)) *SessionsRepository_RunInTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context, dao.SessionsRepository) error))
+	})
+	return _c
+}
+
+func (_c *SessionsRepository_RunInTx_Call) Return(_a0 error) *SessionsRepository_RunInTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SessionsRepository_RunInTx_Call) RunAndReturn(run func(context.Context, func(context.Context, dao.SessionsRepository) error) error) *SessionsRepository_RunInTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLastSeen provides a mock function with given fields: ctx, familyID, threshold, now
+func (_m *SessionsRepository) UpdateLastSeen(ctx context.Context, familyID uuid.UUID, threshold time.Time, now time.Time) error {
+	ret := _m.Called(ctx, familyID, threshold, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) error); ok {
+		r0 = rf(ctx, familyID, threshold, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionsRepository_UpdateLastSeen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLastSeen'
+type SessionsRepository_UpdateLastSeen_Call struct {
+	*mock.Call
+}
+
+// UpdateLastSeen is a helper method to define mock.On call
+//   - ctx context.Context
+//   - familyID uuid.UUID
+//   - threshold time.Time
+//   - now time.Time
+func (_e *SessionsRepository_Expecter) UpdateLastSeen(ctx interface{}, familyID interface{}, threshold interface{}, now interface{}) *SessionsRepository_UpdateLastSeen_Call {
+	return &SessionsRepository_UpdateLastSeen_Call{Call: _e.mock.On("UpdateLastSeen", ctx, familyID, threshold, now)}
+}
+
+func (_c *SessionsRepository_UpdateLastSeen_Call) Run(run func(ctx context.Context, familyID uuid.UUID, threshold time.Time, now time.Time)) *SessionsRepository_UpdateLastSeen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *SessionsRepository_UpdateLastSeen_Call) Return(_a0 error) *SessionsRepository_UpdateLastSeen_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SessionsRepository_UpdateLastSeen_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time, time.Time) error) *SessionsRepository_UpdateLastSeen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateToken provides a mock function with given fields: ctx, familyID, keyID, expiresAt, now
+func (_m *SessionsRepository) UpdateToken(ctx context.Context, familyID uuid.UUID, keyID string, expiresAt time.Time, now time.Time) error {
+	ret := _m.Called(ctx, familyID, keyID, expiresAt, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time, time.Time) error); ok {
+		r0 = rf(ctx, familyID, keyID, expiresAt, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionsRepository_UpdateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateToken'
+type SessionsRepository_UpdateToken_Call struct {
+	*mock.Call
+}
+
+// UpdateToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - familyID uuid.UUID
+//   - keyID string
+//   - expiresAt time.Time
+//   - now time.Time
+func (_e *SessionsRepository_Expecter) UpdateToken(ctx interface{}, familyID interface{}, keyID interface{}, expiresAt interface{}, now interface{}) *SessionsRepository_UpdateToken_Call {
+	return &SessionsRepository_UpdateToken_Call{Call: _e.mock.On("UpdateToken", ctx, familyID, keyID, expiresAt, now)}
+}
+
+func (_c *SessionsRepository_UpdateToken_Call) Run(run func(ctx context.Context, familyID uuid.UUID, keyID string, expiresAt time.Time, now time.Time)) *SessionsRepository_UpdateToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(time.Time), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *SessionsRepository_UpdateToken_Call) Return(_a0 error) *SessionsRepository_UpdateToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SessionsRepository_UpdateToken_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, time.Time, time.Time) error) *SessionsRepository_UpdateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSessionsRepository creates a new instance of SessionsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSessionsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SessionsRepository {
+	mock := &SessionsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,439 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+	time "time"
+
+	dao "github.com/a-novel/auth-service/pkg/dao"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TOTPRepository is an autogenerated mock type for the TOTPRepository type
+type TOTPRepository struct {
+	mock.Mock
+}
+
+type TOTPRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TOTPRepository) EXPECT() *TOTPRepository_Expecter {
+	return &TOTPRepository_Expecter{mock: &_m.Mock}
+}
+
+// Confirm provides a mock function with given fields: ctx, userID, step, now
+func (_m *TOTPRepository) Confirm(ctx context.Context, userID uuid.UUID, step int64, now time.Time) (*dao.TOTPModel, error) {
+	ret := _m.Called(ctx, userID, step, now)
+
+	var r0 *dao.TOTPModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, time.Time) (*dao.TOTPModel, error)); ok {
+		return rf(ctx, userID, step, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, time.Time) *dao.TOTPModel); ok {
+		r0 = rf(ctx, userID, step, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.TOTPModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int64, time.Time) error); ok {
+		r1 = rf(ctx, userID, step, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TOTPRepository_Confirm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Confirm'
+type TOTPRepository_Confirm_Call struct {
+	*mock.Call
+}
+
+// Confirm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - step int64
+//   - now time.Time
+func (_e *TOTPRepository_Expecter) Confirm(ctx interface{}, userID interface{}, step interface{}, now interface{}) *TOTPRepository_Confirm_Call {
+	return &TOTPRepository_Confirm_Call{Call: _e.mock.On("Confirm", ctx, userID, step, now)}
+}
+
+func (_c *TOTPRepository_Confirm_Call) Run(run func(ctx context.Context, userID uuid.UUID, step int64, now time.Time)) *TOTPRepository_Confirm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *TOTPRepository_Confirm_Call) Return(_a0 *dao.TOTPModel, _a1 error) *TOTPRepository_Confirm_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TOTPRepository_Confirm_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64, time.Time) (*dao.TOTPModel, error)) *TOTPRepository_Confirm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, userID
+func (_m *TOTPRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TOTPRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type TOTPRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *TOTPRepository_Expecter) Delete(ctx interface{}, userID interface{}) *TOTPRepository_Delete_Call {
+	return &TOTPRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, userID)}
+}
+
+func (_c *TOTPRepository_Delete_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *TOTPRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *TOTPRepository_Delete_Call) Return(_a0 error) *TOTPRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TOTPRepository_Delete_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *TOTPRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Enroll provides a mock function with given fields: ctx, secret, recoveryCodes, userID, now
+func (_m *TOTPRepository) Enroll(ctx context.Context, secret string, recoveryCodes []string, userID uuid.UUID, now time.Time) (*dao.TOTPModel, error) {
+	ret := _m.Called(ctx, secret, recoveryCodes, userID, now)
+
+	var r0 *dao.TOTPModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, uuid.UUID, time.Time) (*dao.TOTPModel, error)); ok {
+		return rf(ctx, secret, recoveryCodes, userID, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, uuid.UUID, time.Time) *dao.TOTPModel); ok {
+		r0 = rf(ctx, secret, recoveryCodes, userID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.TOTPModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, secret, recoveryCodes, userID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TOTPRepository_Enroll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enroll'
+type TOTPRepository_Enroll_Call struct {
+	*mock.Call
+}
+
+// Enroll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secret string
+//   - recoveryCodes []string
+//   - userID uuid.UUID
+//   - now time.Time
+func (_e *TOTPRepository_Expecter) Enroll(ctx interface{}, secret interface{}, recoveryCodes interface{}, userID interface{}, now interface{}) *TOTPRepository_Enroll_Call {
+	return &TOTPRepository_Enroll_Call{Call: _e.mock.On("Enroll", ctx, secret, recoveryCodes, userID, now)}
+}
+
+func (_c *TOTPRepository_Enroll_Call) Run(run func(ctx context.Context, secret string, recoveryCodes []string, userID uuid.UUID, now time.Time)) *TOTPRepository_Enroll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].(uuid.UUID), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *TOTPRepository_Enroll_Call) Return(_a0 *dao.TOTPModel, _a1 error) *TOTPRepository_Enroll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TOTPRepository_Enroll_Call) RunAndReturn(run func(context.Context, string, []string, uuid.UUID, time.Time) (*dao.TOTPModel, error)) *TOTPRepository_Enroll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, userID
+func (_m *TOTPRepository) Get(ctx context.Context, userID uuid.UUID) (*dao.TOTPModel, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *dao.TOTPModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*dao.TOTPModel, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *dao.TOTPModel); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.TOTPModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TOTPRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type TOTPRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *TOTPRepository_Expecter) Get(ctx interface{}, userID interface{}) *TOTPRepository_Get_Call {
+	return &TOTPRepository_Get_Call{Call: _e.mock.On("Get", ctx, userID)}
+}
+
+func (_c *TOTPRepository_Get_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *TOTPRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *TOTPRepository_Get_Call) Return(_a0 *dao.TOTPModel, _a1 error) *TOTPRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TOTPRepository_Get_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*dao.TOTPModel, error)) *TOTPRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRecoveryCodes provides a mock function with given fields: ctx, userID
+func (_m *TOTPRepository) ListRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]*dao.RecoveryCodeModel, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*dao.RecoveryCodeModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*dao.RecoveryCodeModel, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*dao.RecoveryCodeModel); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.RecoveryCodeModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TOTPRepository_ListRecoveryCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRecoveryCodes'
+type TOTPRepository_ListRecoveryCodes_Call struct {
+	*mock.Call
+}
+
+// ListRecoveryCodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *TOTPRepository_Expecter) ListRecoveryCodes(ctx interface{}, userID interface{}) *TOTPRepository_ListRecoveryCodes_Call {
+	return &TOTPRepository_ListRecoveryCodes_Call{Call: _e.mock.On("ListRecoveryCodes", ctx, userID)}
+}
+
+func (_c *TOTPRepository_ListRecoveryCodes_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *TOTPRepository_ListRecoveryCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *TOTPRepository_ListRecoveryCodes_Call) Return(_a0 []*dao.RecoveryCodeModel, _a1 error) *TOTPRepository_ListRecoveryCodes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TOTPRepository_ListRecoveryCodes_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*dao.RecoveryCodeModel, error)) *TOTPRepository_ListRecoveryCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunInTx provides a mock function with given fields: ctx, callback
+func (_m *TOTPRepository) RunInTx(ctx context.Context, callback func(context.Context, dao.TOTPRepository) error) error {
+	ret := _m.Called(ctx, callback)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context, dao.TOTPRepository) error) error); ok {
+		r0 = rf(ctx, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TOTPRepository_RunInTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunInTx'
+type TOTPRepository_RunInTx_Call struct {
+	*mock.Call
+}
+
+// RunInTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - callback func(context.Context , dao.TOTPRepository) error
+func (_e *TOTPRepository_Expecter) RunInTx(ctx interface{}, callback interface{}) *TOTPRepository_RunInTx_Call {
+	return &TOTPRepository_RunInTx_Call{Call: _e.mock.On("RunInTx", ctx, callback)}
+}
+
+func (_c *TOTPRepository_RunInTx_Call) Run(run func(ctx context.Context, callback func(context.Context, dao.TOTPRepository) error)) *TOTPRepository_RunInTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context, dao.TOTPRepository) error))
+	})
+	return _c
+}
+
+func (_c *TOTPRepository_RunInTx_Call) Return(_a0 error) *TOTPRepository_RunInTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TOTPRepository_RunInTx_Call) RunAndReturn(run func(context.Context, func(context.Context, dao.TOTPRepository) error) error) *TOTPRepository_RunInTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseRecoveryCode provides a mock function with given fields: ctx, id, now
+func (_m *TOTPRepository) UseRecoveryCode(ctx context.Context, id uuid.UUID, now time.Time) error {
+	ret := _m.Called(ctx, id, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r0 = rf(ctx, id, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TOTPRepository_UseRecoveryCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseRecoveryCode'
+type TOTPRepository_UseRecoveryCode_Call struct {
+	*mock.Call
+}
+
+// UseRecoveryCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *TOTPRepository_Expecter) UseRecoveryCode(ctx interface{}, id interface{}, now interface{}) *TOTPRepository_UseRecoveryCode_Call {
+	return &TOTPRepository_UseRecoveryCode_Call{Call: _e.mock.On("UseRecoveryCode", ctx, id, now)}
+}
+
+func (_c *TOTPRepository_UseRecoveryCode_Call) Run(run func(ctx context.Context, id uuid.UUID, now time.Time)) *TOTPRepository_UseRecoveryCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *TOTPRepository_UseRecoveryCode_Call) Return(_a0 error) *TOTPRepository_UseRecoveryCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TOTPRepository_UseRecoveryCode_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) error) *TOTPRepository_UseRecoveryCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseStep provides a mock function with given fields: ctx, userID, step, now
+func (_m *TOTPRepository) UseStep(ctx context.Context, userID uuid.UUID, step int64, now time.Time) error {
+	ret := _m.Called(ctx, userID, step, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, time.Time) error); ok {
+		r0 = rf(ctx, userID, step, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TOTPRepository_UseStep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseStep'
+type TOTPRepository_UseStep_Call struct {
+	*mock.Call
+}
+
+// UseStep is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - step int64
+//   - now time.Time
+func (_e *TOTPRepository_Expecter) UseStep(ctx interface{}, userID interface{}, step interface{}, now interface{}) *TOTPRepository_UseStep_Call {
+	return &TOTPRepository_UseStep_Call{Call: _e.mock.On("UseStep", ctx, userID, step, now)}
+}
+
+func (_c *TOTPRepository_UseStep_Call) Run(run func(ctx context.Context, userID uuid.UUID, step int64, now time.Time)) *TOTPRepository_UseStep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *TOTPRepository_UseStep_Call) Return(_a0 error) *TOTPRepository_UseStep_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TOTPRepository_UseStep_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64, time.Time) error) *TOTPRepository_UseStep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTOTPRepository creates a new instance of TOTPRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTOTPRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TOTPRepository {
+	mock := &TOTPRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,13 +4,11 @@ package daomocks
 
 import (
 	context "context"
-
-	dao "github.com/a-novel/auth-service/pkg/dao"
-	mock "github.com/stretchr/testify/mock"
-
 	time "time"
 
+	dao "github.com/a-novel/auth-service/pkg/dao"
 	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // UserRepository is an autogenerated mock type for the UserRepository type
@@ -138,32 +136,87 @@ func (_c *UserRepository_List_Call) RunAndReturn(run func(context.Context, []uui
 	return _c
 }
 
-// Search provides a mock function with given fields: ctx, query, limit, offset
-func (_m *UserRepository) Search(ctx context.Context, query string, limit int, offset int) ([]*dao.UserModel, int, error) {
-	ret := _m.Called(ctx, query, limit, offset)
+// Purge provides a mock function with given fields: ctx, ids
+func (_m *UserRepository) Purge(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]uuid.UUID, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []uuid.UUID); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type UserRepository_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+func (_e *UserRepository_Expecter) Purge(ctx interface{}, ids interface{}) *UserRepository_Purge_Call {
+	return &UserRepository_Purge_Call{Call: _e.mock.On("Purge", ctx, ids)}
+}
+
+func (_c *UserRepository_Purge_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *UserRepository_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *UserRepository_Purge_Call) Return(_a0 []uuid.UUID, _a1 error) *UserRepository_Purge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_Purge_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]uuid.UUID, error)) *UserRepository_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: ctx, query, limit, offset, now
+func (_m *UserRepository) Search(ctx context.Context, query string, limit int, offset int, now time.Time) ([]*dao.UserModel, int, error) {
+	ret := _m.Called(ctx, query, limit, offset, now)
 
 	var r0 []*dao.UserModel
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*dao.UserModel, int, error)); ok {
-		return rf(ctx, query, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int, time.Time) ([]*dao.UserModel, int, error)); ok {
+		return rf(ctx, query, limit, offset, now)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*dao.UserModel); ok {
-		r0 = rf(ctx, query, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int, time.Time) []*dao.UserModel); ok {
+		r0 = rf(ctx, query, limit, offset, now)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*dao.UserModel)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int); ok {
-		r1 = rf(ctx, query, limit, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int, time.Time) int); ok {
+		r1 = rf(ctx, query, limit, offset, now)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
-		r2 = rf(ctx, query, limit, offset)
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int, time.Time) error); ok {
+		r2 = rf(ctx, query, limit, offset, now)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -181,13 +234,14 @@ type UserRepository_Search_Call struct {
 //   - query string
 //   - limit int
 //   - offset int
-func (_e *UserRepository_Expecter) Search(ctx interface{}, query interface{}, limit interface{}, offset interface{}) *UserRepository_Search_Call {
-	return &UserRepository_Search_Call{Call: _e.mock.On("Search", ctx, query, limit, offset)}
+//   - now time.Time
+func (_e *UserRepository_Expecter) Search(ctx interface{}, query interface{}, limit interface{}, offset interface{}, now interface{}) *UserRepository_Search_Call {
+	return &UserRepository_Search_Call{Call: _e.mock.On("Search", ctx, query, limit, offset, now)}
 }
 
-func (_c *UserRepository_Search_Call) Run(run func(ctx context.Context, query string, limit int, offset int)) *UserRepository_Search_Call {
+func (_c *UserRepository_Search_Call) Run(run func(ctx context.Context, query string, limit int, offset int, now time.Time)) *UserRepository_Search_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int), args[4].(time.Time))
 	})
 	return _c
 }
@@ -197,7 +251,7 @@ func (_c *UserRepository_Search_Call) Return(_a0 []*dao.UserModel, _a1 int, _a2
 	return _c
 }
 
-func (_c *UserRepository_Search_Call) RunAndReturn(run func(context.Context, string, int, int) ([]*dao.UserModel, int, error)) *UserRepository_Search_Call {
+func (_c *UserRepository_Search_Call) RunAndReturn(run func(context.Context, string, int, int, time.Time) ([]*dao.UserModel, int, error)) *UserRepository_Search_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,152 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+	time "time"
+
+	dao "github.com/a-novel/auth-service/pkg/dao"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebAuthnChallengesRepository is an autogenerated mock type for the WebAuthnChallengesRepository type
+type WebAuthnChallengesRepository struct {
+	mock.Mock
+}
+
+type WebAuthnChallengesRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebAuthnChallengesRepository) EXPECT() *WebAuthnChallengesRepository_Expecter {
+	return &WebAuthnChallengesRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, data, id, now
+func (_m *WebAuthnChallengesRepository) Create(ctx context.Context, data *dao.WebAuthnChallengeModelCore, id uuid.UUID, now time.Time) (*dao.WebAuthnChallengeModel, error) {
+	ret := _m.Called(ctx, data, id, now)
+
+	var r0 *dao.WebAuthnChallengeModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.WebAuthnChallengeModelCore, uuid.UUID, time.Time) (*dao.WebAuthnChallengeModel, error)); ok {
+		return rf(ctx, data, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.WebAuthnChallengeModelCore, uuid.UUID, time.Time) *dao.WebAuthnChallengeModel); ok {
+		r0 = rf(ctx, data, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.WebAuthnChallengeModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.WebAuthnChallengeModelCore, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, data, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebAuthnChallengesRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type WebAuthnChallengesRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *dao.WebAuthnChallengeModelCore
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *WebAuthnChallengesRepository_Expecter) Create(ctx interface{}, data interface{}, id interface{}, now interface{}) *WebAuthnChallengesRepository_Create_Call {
+	return &WebAuthnChallengesRepository_Create_Call{Call: _e.mock.On("Create", ctx, data, id, now)}
+}
+
+func (_c *WebAuthnChallengesRepository_Create_Call) Run(run func(ctx context.Context, data *dao.WebAuthnChallengeModelCore, id uuid.UUID, now time.Time)) *WebAuthnChallengesRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.WebAuthnChallengeModelCore), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *WebAuthnChallengesRepository_Create_Call) Return(_a0 *dao.WebAuthnChallengeModel, _a1 error) *WebAuthnChallengesRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebAuthnChallengesRepository_Create_Call) RunAndReturn(run func(context.Context, *dao.WebAuthnChallengeModelCore, uuid.UUID, time.Time) (*dao.WebAuthnChallengeModel, error)) *WebAuthnChallengesRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Use provides a mock function with given fields: ctx, id, now
+func (_m *WebAuthnChallengesRepository) Use(ctx context.Context, id uuid.UUID, now time.Time) (*dao.WebAuthnChallengeModel, error) {
+	ret := _m.Called(ctx, id, now)
+
+	var r0 *dao.WebAuthnChallengeModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (*dao.WebAuthnChallengeModel, error)); ok {
+		return rf(ctx, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *dao.WebAuthnChallengeModel); ok {
+		r0 = rf(ctx, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.WebAuthnChallengeModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebAuthnChallengesRepository_Use_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Use'
+type WebAuthnChallengesRepository_Use_Call struct {
+	*mock.Call
+}
+
+// Use is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *WebAuthnChallengesRepository_Expecter) Use(ctx interface{}, id interface{}, now interface{}) *WebAuthnChallengesRepository_Use_Call {
+	return &WebAuthnChallengesRepository_Use_Call{Call: _e.mock.On("Use", ctx, id, now)}
+}
+
+func (_c *WebAuthnChallengesRepository_Use_Call) Run(run func(ctx context.Context, id uuid.UUID, now time.Time)) *WebAuthnChallengesRepository_Use_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *WebAuthnChallengesRepository_Use_Call) Return(_a0 *dao.WebAuthnChallengeModel, _a1 error) *WebAuthnChallengesRepository_Use_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebAuthnChallengesRepository_Use_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) (*dao.WebAuthnChallengeModel, error)) *WebAuthnChallengesRepository_Use_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebAuthnChallengesRepository creates a new instance of WebAuthnChallengesRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebAuthnChallengesRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebAuthnChallengesRepository {
+	mock := &WebAuthnChallengesRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,107 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+type PasskeysRepository interface {
+	// Create stores a new passkey. It fails with bunovel.ErrUniqConstraintViolation if the credential is already
+	// registered.
+	Create(ctx context.Context, data *PasskeyModelCore, id uuid.UUID, now time.Time) (*PasskeyModel, error)
+	// GetByCredentialID reads a passkey, based on the credential ID chosen by the authenticator.
+	GetByCredentialID(ctx context.Context, credentialID []byte) (*PasskeyModel, error)
+	// ListUserPasskeys returns the passkeys of a user, oldest first.
+	ListUserPasskeys(ctx context.Context, userID uuid.UUID) ([]*PasskeyModel, error)
+	// Use records a successful assertion with the passkey, along with the new signature counter of the authenticator.
+	Use(ctx context.Context, id uuid.UUID, signCount uint32, now time.Time) (*PasskeyModel, error)
+}
+
+type PasskeyModel struct {
+	bun.BaseModel `bun:"table:passkeys"`
+	bunovel.Metadata
+	PasskeyModelCore
+}
+
+type PasskeyModelCore struct {
+	// UserID is the ID of the user who owns the passkey.
+	UserID uuid.UUID `bun:"user_id"`
+	// CredentialID is the identifier of the credential, chosen by the authenticator.
+	CredentialID []byte `bun:"credential_id"`
+	// PublicKey is the COSE encoded public key of the credential, used to verify assertions.
+	PublicKey []byte `bun:"public_key"`
+	// SignCount is the signature counter of the authenticator. Authenticators that do not implement it always
+	// return 0.
+	SignCount uint32 `bun:"sign_count"`
+	// Transports are hints about how the client can reach the authenticator (usb, nfc, ble, internal, hybrid...).
+	Transports []string `bun:"transports,array"`
+	// LastUsedAt is the date of the last successful assertion.
+	LastUsedAt *time.Time `bun:"last_used_at"`
+}
+
+func NewPasskeysRepository(db bun.IDB) PasskeysRepository {
+	return &passkeysRepositoryImpl{db: db}
+}
+
+type passkeysRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *passkeysRepositoryImpl) Create(ctx context.Context, data *PasskeyModelCore, id uuid.UUID, now time.Time) (*PasskeyModel, error) {
+	model := &PasskeyModel{Metadata: bunovel.NewMetadata(id, now, nil), PasskeyModelCore: *data}
+
+	if _, err := repository.db.NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *passkeysRepositoryImpl) GetByCredentialID(ctx context.Context, credentialID []byte) (*PasskeyModel, error) {
+	model := new(PasskeyModel)
+
+	if err := repository.db.NewSelect().Model(model).Where("credential_id = ?", credentialID).Scan(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *passkeysRepositoryImpl) ListUserPasskeys(ctx context.Context, userID uuid.UUID) ([]*PasskeyModel, error) {
+	var results []*PasskeyModel
+
+	err := repository.db.NewSelect().Model(&results).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return results, nil
+}
+
+func (repository *passkeysRepositoryImpl) Use(ctx context.Context, id uuid.UUID, signCount uint32, now time.Time) (*PasskeyModel, error) {
+	model := &PasskeyModel{
+		Metadata:         bunovel.NewMetadata(id, time.Time{}, &now),
+		PasskeyModelCore: PasskeyModelCore{SignCount: signCount, LastUsedAt: &now},
+	}
+
+	res, err := repository.db.NewUpdate().Model(model).
+		WherePK().
+		Column("sign_count", "last_used_at", "updated_at").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	if err = bunovel.ForceRowsUpdate(res); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
@@ -0,0 +1,144 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/migrations"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+var passkeysFixtures = []*dao.PasskeyModel{
+	{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		PasskeyModelCore: dao.PasskeyModelCore{
+			UserID:       goframework.NumberUUID(10),
+			CredentialID: []byte("credential-1"),
+			PublicKey:    []byte("public-key-1"),
+			Transports:   []string{"internal", "hybrid"},
+		},
+	},
+	{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime.Add(time.Minute), nil),
+		PasskeyModelCore: dao.PasskeyModelCore{
+			UserID:       goframework.NumberUUID(10),
+			CredentialID: []byte("credential-2"),
+			PublicKey:    []byte("public-key-2"),
+			SignCount:    12,
+			Transports:   []string{"usb"},
+		},
+	},
+	{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, nil),
+		PasskeyModelCore: dao.PasskeyModelCore{
+			UserID:       goframework.NumberUUID(20),
+			CredentialID: []byte("credential-3"),
+			PublicKey:    []byte("public-key-3"),
+			Transports:   []string{},
+		},
+	},
+}
+
+func TestPasskeysRepository_Create(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, passkeysFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewPasskeysRepository(tx)
+
+		data := &dao.PasskeyModelCore{
+			UserID:       goframework.NumberUUID(20),
+			CredentialID: []byte("credential-4"),
+			PublicKey:    []byte("public-key-4"),
+			Transports:   []string{"nfc"},
+		}
+
+		res, err := repository.Create(ctx, data, goframework.NumberUUID(4), updateTime)
+		require.NoError(t, err)
+		require.Equal(t, &dao.PasskeyModel{
+			Metadata:         bunovel.NewMetadata(goframework.NumberUUID(4), updateTime, nil),
+			PasskeyModelCore: *data,
+		}, res)
+
+		// The credential is already registered.
+		_, err = repository.Create(ctx, &dao.PasskeyModelCore{
+			UserID:       goframework.NumberUUID(20),
+			CredentialID: []byte("credential-1"),
+			PublicKey:    []byte("public-key-5"),
+			Transports:   []string{},
+		}, goframework.NumberUUID(5), updateTime)
+		require.ErrorIs(t, err, bunovel.ErrUniqConstraintViolation)
+	})
+	require.NoError(t, err)
+}
+
+func TestPasskeysRepository_GetByCredentialID(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, passkeysFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewPasskeysRepository(tx)
+
+		res, err := repository.GetByCredentialID(ctx, []byte("credential-2"))
+		require.NoError(t, err)
+		require.Equal(t, passkeysFixtures[1], res)
+
+		_, err = repository.GetByCredentialID(ctx, []byte("credential-4"))
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+	})
+	require.NoError(t, err)
+}
+
+func TestPasskeysRepository_ListUserPasskeys(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, passkeysFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewPasskeysRepository(tx)
+
+		res, err := repository.ListUserPasskeys(ctx, goframework.NumberUUID(10))
+		require.NoError(t, err)
+		require.Equal(t, []*dao.PasskeyModel{passkeysFixtures[0], passkeysFixtures[1]}, res)
+
+		res, err = repository.ListUserPasskeys(ctx, goframework.NumberUUID(30))
+		require.NoError(t, err)
+		require.Empty(t, res)
+	})
+	require.NoError(t, err)
+}
+
+func TestPasskeysRepository_Use(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, passkeysFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewPasskeysRepository(tx)
+
+		res, err := repository.Use(ctx, goframework.NumberUUID(2), 13, updateTime)
+		require.NoError(t, err)
+		require.Equal(t, &dao.PasskeyModel{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime.Add(time.Minute), &updateTime),
+			PasskeyModelCore: dao.PasskeyModelCore{
+				UserID:       goframework.NumberUUID(10),
+				CredentialID: []byte("credential-2"),
+				PublicKey:    []byte("public-key-2"),
+				SignCount:    13,
+				Transports:   []string{"usb"},
+				LastUsedAt:   &updateTime,
+			},
+		}, res)
+
+		_, err = repository.Use(ctx, goframework.NumberUUID(4), 1, updateTime)
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+	})
+	require.NoError(t, err)
+}
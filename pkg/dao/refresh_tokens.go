@@ -0,0 +1,156 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+type RefreshTokensRepository interface {
+	// Create stores a new refresh token. The token value MUST be hashed.
+	Create(ctx context.Context, data *RefreshTokenModelCore, id uuid.UUID, now time.Time) (*RefreshTokenModel, error)
+	// GetRefreshToken reads a refresh token, based on its id.
+	GetRefreshToken(ctx context.Context, id uuid.UUID) (*RefreshTokenModel, error)
+	// Use marks a refresh token as used. Because a refresh token can only be used once, this method fails with
+	// bunovel.ErrNotFound if the token was already used or revoked, even if the operations happen concurrently.
+	Use(ctx context.Context, id uuid.UUID, now time.Time) (*RefreshTokenModel, error)
+	// RevokeFamily revokes every token from the given family, that has not already been revoked.
+	RevokeFamily(ctx context.Context, familyID uuid.UUID, now time.Time) error
+	// RevokeUser revokes every token owned by the given user, that has not already been revoked.
+	RevokeUser(ctx context.Context, userID uuid.UUID, now time.Time) error
+	// FamilyActive returns true if the family still has a token that can be used to refresh the session.
+	FamilyActive(ctx context.Context, familyID uuid.UUID, now time.Time) (bool, error)
+
+	RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository RefreshTokensRepository) error) error
+}
+
+type RefreshTokenModel struct {
+	bun.BaseModel `bun:"table:refresh_tokens"`
+	bunovel.Metadata
+	RefreshTokenModelCore
+}
+
+type RefreshTokenModelCore struct {
+	// FamilyID is shared by all the refresh tokens that derive from the same login. Rotating a refresh token keeps
+	// the family, so a whole session can be revoked at once.
+	FamilyID uuid.UUID `bun:"family_id"`
+	// UserID is the ID of the user who owns the token.
+	UserID uuid.UUID `bun:"user_id"`
+	// TokenHashed is the hashed value of the secret part of the token. The raw value is only known by the client.
+	TokenHashed string `bun:"token_hashed"`
+	// ExpiresAt is the date after which the token can no longer be used.
+	ExpiresAt time.Time `bun:"expires_at"`
+	// UsedAt is set once the token has been exchanged for a new one.
+	UsedAt *time.Time `bun:"used_at"`
+	// RevokedAt is set when the token family has been revoked.
+	RevokedAt *time.Time `bun:"revoked_at"`
+	// SecurityStamp is the security stamp of the user when the token was issued. The token can no longer be used
+	// once the stamp of the user changes.
+	SecurityStamp uuid.UUID `bun:"security_stamp,nullzero"`
+	// AuthTime is the date the user authenticated to open the session. It is carried over to every token of the
+	// family.
+	AuthTime time.Time `bun:"auth_time,nullzero"`
+}
+
+func NewRefreshTokensRepository(db bun.IDB) RefreshTokensRepository {
+	return &refreshTokensRepositoryImpl{db: db}
+}
+
+type refreshTokensRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *refreshTokensRepositoryImpl) Create(ctx context.Context, data *RefreshTokenModelCore, id uuid.UUID, now time.Time) (*RefreshTokenModel, error) {
+	model := &RefreshTokenModel{Metadata: bunovel.NewMetadata(id, now, nil), RefreshTokenModelCore: *data}
+
+	if _, err := repository.db.NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *refreshTokensRepositoryImpl) GetRefreshToken(ctx context.Context, id uuid.UUID) (*RefreshTokenModel, error) {
+	model := &RefreshTokenModel{Metadata: bunovel.NewMetadata(id, time.Time{}, nil)}
+
+	if err := repository.db.NewSelect().Model(model).WherePK().Scan(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *refreshTokensRepositoryImpl) Use(ctx context.Context, id uuid.UUID, now time.Time) (*RefreshTokenModel, error) {
+	model := &RefreshTokenModel{
+		Metadata:              bunovel.NewMetadata(id, time.Time{}, &now),
+		RefreshTokenModelCore: RefreshTokenModelCore{UsedAt: &now},
+	}
+
+	res, err := repository.db.NewUpdate().Model(model).
+		WherePK().
+		// The check happens in the same statement as the update, so two concurrent calls cannot both succeed.
+		Where("used_at IS NULL").
+		Where("revoked_at IS NULL").
+		Column("used_at", "updated_at").
+		Returning("*").
+		Exec(ctx)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	if err = bunovel.ForceRowsUpdate(res); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (repository *refreshTokensRepositoryImpl) RevokeFamily(ctx context.Context, familyID uuid.UUID, now time.Time) error {
+	model := &RefreshTokenModel{
+		Metadata:              bunovel.NewMetadata(uuid.Nil, time.Time{}, &now),
+		RefreshTokenModelCore: RefreshTokenModelCore{RevokedAt: &now},
+	}
+
+	_, err := repository.db.NewUpdate().Model(model).
+		Where("family_id = ?", familyID).
+		Where("revoked_at IS NULL").
+		Column("revoked_at", "updated_at").
+		Exec(ctx)
+
+	return bunovel.HandlePGError(err)
+}
+
+func (repository *refreshTokensRepositoryImpl) RevokeUser(ctx context.Context, userID uuid.UUID, now time.Time) error {
+	model := &RefreshTokenModel{
+		Metadata:              bunovel.NewMetadata(uuid.Nil, time.Time{}, &now),
+		RefreshTokenModelCore: RefreshTokenModelCore{RevokedAt: &now},
+	}
+
+	_, err := repository.db.NewUpdate().Model(model).
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Column("revoked_at", "updated_at").
+		Exec(ctx)
+
+	return bunovel.HandlePGError(err)
+}
+
+func (repository *refreshTokensRepositoryImpl) FamilyActive(ctx context.Context, familyID uuid.UUID, now time.Time) (bool, error) {
+	ok, err := repository.db.NewSelect().Model(new(RefreshTokenModel)).
+		Where("family_id = ?", familyID).
+		Where("used_at IS NULL").
+		Where("revoked_at IS NULL").
+		Where("expires_at > ?", now).
+		Exists(ctx)
+
+	return ok, bunovel.HandlePGError(err)
+}
+
+func (repository *refreshTokensRepositoryImpl) RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository RefreshTokensRepository) error) error {
+	return repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return callback(ctx, NewRefreshTokensRepository(tx))
+	})
+}
@@ -0,0 +1,541 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/migrations"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+func TestRefreshTokensRepository_Create(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	data := []struct {
+		name string
+
+		data *dao.RefreshTokenModelCore
+		id   uuid.UUID
+		now  time.Time
+
+		expect    *dao.RefreshTokenModel
+		expectErr error
+	}{
+		{
+			name: "Success",
+			data: &dao.RefreshTokenModelCore{
+				FamilyID:    goframework.NumberUUID(100),
+				UserID:      goframework.NumberUUID(1),
+				TokenHashed: "token-hashed",
+				ExpiresAt:   baseTime.Add(time.Hour),
+			},
+			id:  goframework.NumberUUID(1000),
+			now: baseTime,
+			expect: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:    goframework.NumberUUID(100),
+					UserID:      goframework.NumberUUID(1),
+					TokenHashed: "token-hashed",
+					ExpiresAt:   baseTime.Add(time.Hour),
+				},
+			},
+		},
+		{
+			name: "Success/WithSecurityStamp",
+			data: &dao.RefreshTokenModelCore{
+				FamilyID:      goframework.NumberUUID(100),
+				UserID:        goframework.NumberUUID(1),
+				TokenHashed:   "token-hashed",
+				ExpiresAt:     baseTime.Add(time.Hour),
+				SecurityStamp: goframework.NumberUUID(10),
+			},
+			id:  goframework.NumberUUID(1000),
+			now: baseTime,
+			expect: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:      goframework.NumberUUID(100),
+					UserID:        goframework.NumberUUID(1),
+					TokenHashed:   "token-hashed",
+					ExpiresAt:     baseTime.Add(time.Hour),
+					SecurityStamp: goframework.NumberUUID(10),
+				},
+			},
+		},
+		{
+			name: "Success/WithAuthTime",
+			data: &dao.RefreshTokenModelCore{
+				FamilyID:    goframework.NumberUUID(100),
+				UserID:      goframework.NumberUUID(1),
+				TokenHashed: "token-hashed",
+				ExpiresAt:   baseTime.Add(time.Hour),
+				AuthTime:    updateTime,
+			},
+			id:  goframework.NumberUUID(1000),
+			now: baseTime,
+			expect: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:    goframework.NumberUUID(100),
+					UserID:      goframework.NumberUUID(1),
+					TokenHashed: "token-hashed",
+					ExpiresAt:   baseTime.Add(time.Hour),
+					AuthTime:    updateTime,
+				},
+			},
+		},
+		{
+			name: "Error/NoToken",
+			data: &dao.RefreshTokenModelCore{
+				FamilyID:  goframework.NumberUUID(100),
+				UserID:    goframework.NumberUUID(1),
+				ExpiresAt: baseTime.Add(time.Hour),
+			},
+			id:        goframework.NumberUUID(1000),
+			now:       baseTime,
+			expectErr: bunovel.ErrConstraintViolation,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, nil, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				res, err := dao.NewRefreshTokensRepository(stx).Create(ctx, d.data, d.id, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestRefreshTokensRepository_GetRefreshToken(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.RefreshTokenModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			RefreshTokenModelCore: dao.RefreshTokenModelCore{
+				FamilyID:    goframework.NumberUUID(100),
+				UserID:      goframework.NumberUUID(1),
+				TokenHashed: "token-hashed",
+				ExpiresAt:   baseTime.Add(time.Hour),
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		id uuid.UUID
+
+		expect    *dao.RefreshTokenModel
+		expectErr error
+	}{
+		{
+			name:   "Success",
+			id:     goframework.NumberUUID(1000),
+			expect: fixtures[0],
+		},
+		{
+			name:      "Error/NotFound",
+			id:        goframework.NumberUUID(1),
+			expectErr: bunovel.ErrNotFound,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewRefreshTokensRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.GetRefreshToken(ctx, d.id)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestRefreshTokensRepository_Use(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.RefreshTokenModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			RefreshTokenModelCore: dao.RefreshTokenModelCore{
+				FamilyID:    goframework.NumberUUID(100),
+				UserID:      goframework.NumberUUID(1),
+				TokenHashed: "token-hashed",
+				ExpiresAt:   baseTime.Add(time.Hour),
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &baseTime),
+			RefreshTokenModelCore: dao.RefreshTokenModelCore{
+				FamilyID:    goframework.NumberUUID(100),
+				UserID:      goframework.NumberUUID(1),
+				TokenHashed: "token-hashed",
+				ExpiresAt:   baseTime.Add(time.Hour),
+				UsedAt:      &baseTime,
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1002), baseTime, &baseTime),
+			RefreshTokenModelCore: dao.RefreshTokenModelCore{
+				FamilyID:    goframework.NumberUUID(101),
+				UserID:      goframework.NumberUUID(1),
+				TokenHashed: "token-hashed",
+				ExpiresAt:   baseTime.Add(time.Hour),
+				RevokedAt:   &baseTime,
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		id  uuid.UUID
+		now time.Time
+
+		expect    *dao.RefreshTokenModel
+		expectErr error
+	}{
+		{
+			name: "Success",
+			id:   goframework.NumberUUID(1000),
+			now:  updateTime,
+			expect: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:    goframework.NumberUUID(100),
+					UserID:      goframework.NumberUUID(1),
+					TokenHashed: "token-hashed",
+					ExpiresAt:   baseTime.Add(time.Hour),
+					UsedAt:      &updateTime,
+				},
+			},
+		},
+		{
+			name:      "Error/AlreadyUsed",
+			id:        goframework.NumberUUID(1001),
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
+		},
+		{
+			name:      "Error/Revoked",
+			id:        goframework.NumberUUID(1002),
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
+		},
+		{
+			name:      "Error/NotFound",
+			id:        goframework.NumberUUID(1),
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				res, err := dao.NewRefreshTokensRepository(stx).Use(ctx, d.id, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestRefreshTokensRepository_RevokeFamily(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.RefreshTokenModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			RefreshTokenModelCore: dao.RefreshTokenModelCore{
+				FamilyID:    goframework.NumberUUID(100),
+				UserID:      goframework.NumberUUID(1),
+				TokenHashed: "token-hashed",
+				ExpiresAt:   baseTime.Add(time.Hour),
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &baseTime),
+			RefreshTokenModelCore: dao.RefreshTokenModelCore{
+				FamilyID:    goframework.NumberUUID(100),
+				UserID:      goframework.NumberUUID(1),
+				TokenHashed: "token-hashed",
+				ExpiresAt:   baseTime.Add(time.Hour),
+				RevokedAt:   &baseTime,
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1002), baseTime, nil),
+			RefreshTokenModelCore: dao.RefreshTokenModelCore{
+				FamilyID:    goframework.NumberUUID(101),
+				UserID:      goframework.NumberUUID(1),
+				TokenHashed: "token-hashed",
+				ExpiresAt:   baseTime.Add(time.Hour),
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		familyID uuid.UUID
+		now      time.Time
+
+		expect []*dao.RefreshTokenModel
+	}{
+		{
+			name:     "Success",
+			familyID: goframework.NumberUUID(100),
+			now:      updateTime,
+			expect: []*dao.RefreshTokenModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+					RefreshTokenModelCore: dao.RefreshTokenModelCore{
+						FamilyID:    goframework.NumberUUID(100),
+						UserID:      goframework.NumberUUID(1),
+						TokenHashed: "token-hashed",
+						ExpiresAt:   baseTime.Add(time.Hour),
+						RevokedAt:   &updateTime,
+					},
+				},
+				// Already revoked tokens keep their original revocation date.
+				fixtures[1],
+				fixtures[2],
+			},
+		},
+		{
+			name:     "Success/NoTokens",
+			familyID: goframework.NumberUUID(102),
+			now:      updateTime,
+			expect:   fixtures,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				repository := dao.NewRefreshTokensRepository(stx)
+				require.NoError(t, repository.RevokeFamily(ctx, d.familyID, d.now))
+
+				for _, expect := range d.expect {
+					res, err := repository.GetRefreshToken(ctx, expect.ID)
+					require.NoError(t, err)
+					require.Equal(t, expect, res)
+				}
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestRefreshTokensRepository_RevokeUser(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.RefreshTokenModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			RefreshTokenModelCore: dao.RefreshTokenModelCore{
+				FamilyID:    goframework.NumberUUID(100),
+				UserID:      goframework.NumberUUID(1),
+				TokenHashed: "token-hashed",
+				ExpiresAt:   baseTime.Add(time.Hour),
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &baseTime),
+			RefreshTokenModelCore: dao.RefreshTokenModelCore{
+				FamilyID:    goframework.NumberUUID(100),
+				UserID:      goframework.NumberUUID(1),
+				TokenHashed: "token-hashed",
+				ExpiresAt:   baseTime.Add(time.Hour),
+				RevokedAt:   &baseTime,
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1002), baseTime, nil),
+			RefreshTokenModelCore: dao.RefreshTokenModelCore{
+				FamilyID:    goframework.NumberUUID(101),
+				UserID:      goframework.NumberUUID(2),
+				TokenHashed: "token-hashed",
+				ExpiresAt:   baseTime.Add(time.Hour),
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		userID uuid.UUID
+		now    time.Time
+
+		expect []*dao.RefreshTokenModel
+	}{
+		{
+			name:   "Success",
+			userID: goframework.NumberUUID(1),
+			now:    updateTime,
+			expect: []*dao.RefreshTokenModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+					RefreshTokenModelCore: dao.RefreshTokenModelCore{
+						FamilyID:    goframework.NumberUUID(100),
+						UserID:      goframework.NumberUUID(1),
+						TokenHashed: "token-hashed",
+						ExpiresAt:   baseTime.Add(time.Hour),
+						RevokedAt:   &updateTime,
+					},
+				},
+				// Already revoked tokens keep their original revocation date.
+				fixtures[1],
+				fixtures[2],
+			},
+		},
+		{
+			name:   "Success/NoTokens",
+			userID: goframework.NumberUUID(3),
+			now:    updateTime,
+			expect: fixtures,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				repository := dao.NewRefreshTokensRepository(stx)
+				require.NoError(t, repository.RevokeUser(ctx, d.userID, d.now))
+
+				for _, expect := range d.expect {
+					res, err := repository.GetRefreshToken(ctx, expect.ID)
+					require.NoError(t, err)
+					require.Equal(t, expect, res)
+				}
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestRefreshTokensRepository_FamilyActive(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.RefreshTokenModel{
+		// Active family.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &baseTime),
+			RefreshTokenModelCore: dao.RefreshTokenModelCore{
+				FamilyID:    goframework.NumberUUID(100),
+				UserID:      goframework.NumberUUID(1),
+				TokenHashed: "token-hashed",
+				ExpiresAt:   baseTime.Add(time.Hour),
+				UsedAt:      &baseTime,
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, nil),
+			RefreshTokenModelCore: dao.RefreshTokenModelCore{
+				FamilyID:    goframework.NumberUUID(100),
+				UserID:      goframework.NumberUUID(1),
+				TokenHashed: "token-hashed",
+				ExpiresAt:   baseTime.Add(2 * time.Hour),
+			},
+		},
+		// Revoked family.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1002), baseTime, &baseTime),
+			RefreshTokenModelCore: dao.RefreshTokenModelCore{
+				FamilyID:    goframework.NumberUUID(101),
+				UserID:      goframework.NumberUUID(1),
+				TokenHashed: "token-hashed",
+				ExpiresAt:   baseTime.Add(time.Hour),
+				RevokedAt:   &baseTime,
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		familyID uuid.UUID
+		now      time.Time
+
+		expect bool
+	}{
+		{
+			name:     "Success",
+			familyID: goframework.NumberUUID(100),
+			now:      baseTime,
+			expect:   true,
+		},
+		{
+			name:     "Success/Expired",
+			familyID: goframework.NumberUUID(100),
+			now:      baseTime.Add(3 * time.Hour),
+		},
+		{
+			name:     "Success/Revoked",
+			familyID: goframework.NumberUUID(101),
+			now:      baseTime,
+		},
+		{
+			name:     "Success/NotFound",
+			familyID: goframework.NumberUUID(102),
+			now:      baseTime,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewRefreshTokensRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.FamilyActive(ctx, d.familyID, d.now)
+				require.NoError(t, err)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,163 @@
+package dao
+
+import (
+	"context"
+	"crypto/ed25519"
+	"github.com/a-novel/bunovel"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+type RevokedSignatureKeysRepository interface {
+	// Revoke marks a signature key as revoked, using the ID published in the JWKS. Revoking a key twice has no
+	// effect, and returns the original revocation.
+	Revoke(ctx context.Context, keyID, name string, now time.Time) (*RevokedSignatureKeyModel, error)
+	// List returns every revoked key.
+	List(ctx context.Context) ([]*RevokedSignatureKeyModel, error)
+
+	RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository RevokedSignatureKeysRepository) error) error
+}
+
+type RevokedSignatureKeyModel struct {
+	bun.BaseModel `bun:"table:revoked_signature_keys"`
+
+	// KeyID is the ID of the revoked key, as returned by SecretKeyModel.KeyID.
+	KeyID string `bun:"id,pk"`
+	// CreatedAt is the date of the revocation.
+	CreatedAt time.Time `bun:"created_at"`
+	// Name of the record that stored the key, for information purposes.
+	Name string `bun:"name"`
+}
+
+func NewRevokedSignatureKeysRepository(db bun.IDB) RevokedSignatureKeysRepository {
+	return &revokedSignatureKeysRepositoryImpl{db: db}
+}
+
+type revokedSignatureKeysRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *revokedSignatureKeysRepositoryImpl) Revoke(ctx context.Context, keyID, name string, now time.Time) (*RevokedSignatureKeyModel, error) {
+	model := &RevokedSignatureKeyModel{KeyID: keyID, CreatedAt: now, Name: name}
+
+	_, err := repository.db.NewInsert().Model(model).
+		On("CONFLICT (id) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	// The key may have been revoked before, in which case the insert did nothing.
+	if err = repository.db.NewSelect().Model(model).WherePK().Scan(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *revokedSignatureKeysRepositoryImpl) List(ctx context.Context) ([]*RevokedSignatureKeyModel, error) {
+	var results []*RevokedSignatureKeyModel
+
+	if err := repository.db.NewSelect().Model(&results).Order("created_at DESC").Scan(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return results, nil
+}
+
+func (repository *revokedSignatureKeysRepositoryImpl) RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository RevokedSignatureKeysRepository) error) error {
+	return repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return callback(ctx, NewRevokedSignatureKeysRepository(tx))
+	})
+}
+
+// NewRevocationFilteredSecretKeysRepository hides the keys revoked in revokedSignatureKeysDAO from the source
+// repository, so they are neither used to sign, nor to verify tokens.
+//
+// Revocations are read on every call, rather than kept in memory like the keys. A leaked key must stop being trusted
+// by every instance right away, without waiting for their next reload.
+func NewRevocationFilteredSecretKeysRepository(
+	source SecretKeysRepository,
+	revokedSignatureKeysDAO RevokedSignatureKeysRepository,
+) SecretKeysRepository {
+	return &revocationFilteredSecretKeysRepositoryImpl{
+		source:                  source,
+		revokedSignatureKeysDAO: revokedSignatureKeysDAO,
+	}
+}
+
+type revocationFilteredSecretKeysRepositoryImpl struct {
+	source                  SecretKeysRepository
+	revokedSignatureKeysDAO RevokedSignatureKeysRepository
+}
+
+func (repository *revocationFilteredSecretKeysRepositoryImpl) revokedKeyIDs(ctx context.Context) (map[string]bool, error) {
+	revoked, err := repository.revokedSignatureKeysDAO.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make(map[string]bool, len(revoked))
+	for _, item := range revoked {
+		output[item.KeyID] = true
+	}
+
+	return output, nil
+}
+
+func (repository *revocationFilteredSecretKeysRepositoryImpl) Write(ctx context.Context, key ed25519.PrivateKey, name string, now time.Time, activatesAt time.Time) (*SecretKeyModel, error) {
+	return repository.source.Write(ctx, key, name, now, activatesAt)
+}
+
+func (repository *revocationFilteredSecretKeysRepositoryImpl) Read(ctx context.Context, name string) (*SecretKeyModel, error) {
+	key, err := repository.source.Read(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := repository.revokedKeyIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if revoked[key.KeyID()] {
+		return nil, bunovel.ErrNotFound
+	}
+
+	return key, nil
+}
+
+func (repository *revocationFilteredSecretKeysRepositoryImpl) List(ctx context.Context) ([]*SecretKeyModel, error) {
+	keys, err := repository.source.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := repository.revokedKeyIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(revoked) == 0 {
+		return keys, nil
+	}
+
+	return lo.Filter(keys, func(item *SecretKeyModel, _ int) bool {
+		return !revoked[item.KeyID()]
+	}), nil
+}
+
+func (repository *revocationFilteredSecretKeysRepositoryImpl) Delete(ctx context.Context, name string) error {
+	return repository.source.Delete(ctx, name)
+}
+
+func (repository *revocationFilteredSecretKeysRepositoryImpl) Refresh(ctx context.Context) error {
+	return repository.source.Refresh(ctx)
+}
+
+func (repository *revocationFilteredSecretKeysRepositoryImpl) RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository SecretKeysRepository) error) error {
+	return repository.source.RunInTx(ctx, func(ctx context.Context, txRepository SecretKeysRepository) error {
+		return callback(ctx, NewRevocationFilteredSecretKeysRepository(txRepository, repository.revokedSignatureKeysDAO))
+	})
+}
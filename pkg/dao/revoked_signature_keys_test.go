@@ -0,0 +1,146 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/migrations"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+func TestRevokedSignatureKeysRepository_Revoke(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.RevokedSignatureKeyModel{
+		{KeyID: "key-1", CreatedAt: baseTime, Name: "test-1"},
+	}
+
+	data := []struct {
+		name string
+
+		keyID   string
+		keyName string
+		now     time.Time
+
+		expect    *dao.RevokedSignatureKeyModel
+		expectErr error
+	}{
+		{
+			name:    "Success",
+			keyID:   "key-2",
+			keyName: "test-2",
+			now:     updateTime,
+			expect:  &dao.RevokedSignatureKeyModel{KeyID: "key-2", CreatedAt: updateTime, Name: "test-2"},
+		},
+		{
+			name:    "Success/AlreadyRevoked",
+			keyID:   "key-1",
+			keyName: "test-1",
+			now:     updateTime,
+			expect:  &dao.RevokedSignatureKeyModel{KeyID: "key-1", CreatedAt: baseTime, Name: "test-1"},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				res, err := dao.NewRevokedSignatureKeysRepository(stx).Revoke(ctx, d.keyID, d.keyName, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestRevokedSignatureKeysRepository_List(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.RevokedSignatureKeyModel{
+		{KeyID: "key-1", CreatedAt: baseTime, Name: "test-1"},
+		{KeyID: "key-2", CreatedAt: updateTime, Name: "test-2"},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		res, err := dao.NewRevokedSignatureKeysRepository(tx).List(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []*dao.RevokedSignatureKeyModel{
+			{KeyID: "key-2", CreatedAt: updateTime, Name: "test-2"},
+			{KeyID: "key-1", CreatedAt: baseTime, Name: "test-1"},
+		}, res)
+	})
+	require.NoError(t, err)
+}
+
+func TestRevocationFilteredSecretKeysRepository(t *testing.T) {
+	revokedKeyID := (&dao.SecretKeyModel{Name: "test-2"}).KeyID()
+
+	err := goframework.RunFileTransactionalTest(t, SecretKeysFixtures, func(ctx context.Context, basePath string) {
+		source := dao.NewFileSystemSecretKeysRepository(basePath, "foo")
+
+		revokedSignatureKeysDAO := daomocks.NewRevokedSignatureKeysRepository(t)
+		revokedSignatureKeysDAO.On("List", ctx).Return([]*dao.RevokedSignatureKeyModel{
+			{KeyID: revokedKeyID, CreatedAt: baseTime, Name: "test-2"},
+		}, nil)
+
+		repository := dao.NewRevocationFilteredSecretKeysRepository(source, revokedSignatureKeysDAO)
+
+		res, err := repository.List(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"test-3", "test-1"}, secretKeyNames(res))
+
+		_, err = repository.Read(ctx, "test-2")
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+
+		key, err := repository.Read(ctx, "test-1")
+		require.NoError(t, err)
+		require.True(t, MockedSecretKeys[0].Equal(key.Key))
+
+		// Revoked keys are still stored.
+		_, err = source.Read(ctx, "test-2")
+		require.NoError(t, err)
+
+		// Revoked keys are also hidden within transactions.
+		err = repository.RunInTx(ctx, func(ctx context.Context, txRepository dao.SecretKeysRepository) error {
+			res, err := txRepository.List(ctx)
+			require.NoError(t, err)
+			require.Equal(t, []string{"test-3", "test-1"}, secretKeyNames(res))
+
+			return nil
+		})
+		require.NoError(t, err)
+	})
+	require.NoError(t, err)
+}
+
+func TestRevocationFilteredSecretKeysRepository_ListRevokedFailure(t *testing.T) {
+	err := goframework.RunFileTransactionalTest(t, SecretKeysFixtures, func(ctx context.Context, basePath string) {
+		source := dao.NewFileSystemSecretKeysRepository(basePath, "foo")
+
+		revokedSignatureKeysDAO := daomocks.NewRevokedSignatureKeysRepository(t)
+		revokedSignatureKeysDAO.On("List", ctx).Return(nil, fooErr)
+
+		repository := dao.NewRevocationFilteredSecretKeysRepository(source, revokedSignatureKeysDAO)
+
+		_, err := repository.List(ctx)
+		require.ErrorIs(t, err, fooErr)
+
+		_, err = repository.Read(ctx, "test-1")
+		require.ErrorIs(t, err, fooErr)
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,128 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+type RevokedTokensRepository interface {
+	// Revoke marks a single token as revoked. The id is the ID of the token, from its header. Revoking a token twice
+	// has no effect.
+	Revoke(ctx context.Context, data *RevokedTokenModelCore, id uuid.UUID, now time.Time) (*RevokedTokenModel, error)
+	// RevokeUser revokes every token issued to the given user before now. The revocation is kept until expiresAt,
+	// which must be at least one token TTL in the future.
+	RevokeUser(ctx context.Context, userID uuid.UUID, expiresAt time.Time, now time.Time) (*RevokedUserTokensModel, error)
+	// IsRevoked returns true if the token was revoked, either directly or through a revocation of its owner tokens.
+	IsRevoked(ctx context.Context, id uuid.UUID, userID uuid.UUID, issuedAt time.Time) (bool, error)
+	// Prune removes the revocations that are past their expiration date. Tokens they refer to are expired, so they
+	// are no longer needed.
+	Prune(ctx context.Context, now time.Time) error
+
+	RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository RevokedTokensRepository) error) error
+}
+
+type RevokedTokenModel struct {
+	bun.BaseModel `bun:"table:revoked_tokens"`
+	bunovel.Metadata
+	RevokedTokenModelCore
+}
+
+type RevokedTokenModelCore struct {
+	// UserID is the ID of the user who owns the token.
+	UserID uuid.UUID `bun:"user_id"`
+	// ExpiresAt is the date after which every token with this ID has expired, including the renewed ones. The
+	// revocation can be removed once it is passed.
+	ExpiresAt time.Time `bun:"expires_at"`
+}
+
+type RevokedUserTokensModel struct {
+	bun.BaseModel `bun:"table:revoked_user_tokens"`
+	bunovel.Metadata
+	RevokedUserTokensModelCore
+}
+
+type RevokedUserTokensModelCore struct {
+	// RevokedAt is the date of the revocation. Every token issued before this date is revoked.
+	RevokedAt time.Time `bun:"revoked_at"`
+	// ExpiresAt is the date after which every revoked token has expired.
+	ExpiresAt time.Time `bun:"expires_at"`
+}
+
+func NewRevokedTokensRepository(db bun.IDB) RevokedTokensRepository {
+	return &revokedTokensRepositoryImpl{db: db}
+}
+
+type revokedTokensRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *revokedTokensRepositoryImpl) Revoke(ctx context.Context, data *RevokedTokenModelCore, id uuid.UUID, now time.Time) (*RevokedTokenModel, error) {
+	model := &RevokedTokenModel{Metadata: bunovel.NewMetadata(id, now, nil), RevokedTokenModelCore: *data}
+
+	if _, err := repository.db.NewInsert().Model(model).On("CONFLICT (id) DO NOTHING").Exec(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *revokedTokensRepositoryImpl) RevokeUser(ctx context.Context, userID uuid.UUID, expiresAt time.Time, now time.Time) (*RevokedUserTokensModel, error) {
+	model := &RevokedUserTokensModel{
+		Metadata: bunovel.NewMetadata(userID, now, nil),
+		RevokedUserTokensModelCore: RevokedUserTokensModelCore{
+			RevokedAt: now,
+			ExpiresAt: expiresAt,
+		},
+	}
+
+	_, err := repository.db.NewInsert().Model(model).
+		// A user can be revoked multiple times. Only the most recent revocation matters.
+		On("CONFLICT (id) DO UPDATE").
+		Set("updated_at = EXCLUDED.created_at").
+		Set("revoked_at = EXCLUDED.revoked_at").
+		Set("expires_at = EXCLUDED.expires_at").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *revokedTokensRepositoryImpl) IsRevoked(ctx context.Context, id uuid.UUID, userID uuid.UUID, issuedAt time.Time) (bool, error) {
+	ok, err := repository.db.NewSelect().Model(new(RevokedTokenModel)).Where("id = ?", id).Exists(ctx)
+	if err != nil || ok {
+		return ok, bunovel.HandlePGError(err)
+	}
+
+	// Token dates have a precision of one second, so a token issued in the same second as the revocation is
+	// considered revoked.
+	ok, err = repository.db.NewSelect().Model(new(RevokedUserTokensModel)).
+		Where("id = ?", userID).
+		Where("revoked_at > ?", issuedAt).
+		Exists(ctx)
+
+	return ok, bunovel.HandlePGError(err)
+}
+
+func (repository *revokedTokensRepositoryImpl) Prune(ctx context.Context, now time.Time) error {
+	if _, err := repository.db.NewDelete().Model(new(RevokedTokenModel)).Where("expires_at < ?", now).Exec(ctx); err != nil {
+		return bunovel.HandlePGError(err)
+	}
+
+	if _, err := repository.db.NewDelete().Model(new(RevokedUserTokensModel)).Where("expires_at < ?", now).Exec(ctx); err != nil {
+		return bunovel.HandlePGError(err)
+	}
+
+	return nil
+}
+
+func (repository *revokedTokensRepositoryImpl) RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository RevokedTokensRepository) error) error {
+	return repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return callback(ctx, NewRevokedTokensRepository(tx))
+	})
+}
@@ -0,0 +1,289 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/migrations"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+func TestRevokedTokensRepository_Revoke(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.RevokedTokenModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			RevokedTokenModelCore: dao.RevokedTokenModelCore{
+				UserID:    goframework.NumberUUID(1),
+				ExpiresAt: baseTime.Add(time.Hour),
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		data *dao.RevokedTokenModelCore
+		id   uuid.UUID
+		now  time.Time
+
+		expect    *dao.RevokedTokenModel
+		expectErr error
+	}{
+		{
+			name: "Success",
+			data: &dao.RevokedTokenModelCore{
+				UserID:    goframework.NumberUUID(1),
+				ExpiresAt: updateTime.Add(time.Hour),
+			},
+			id:  goframework.NumberUUID(1001),
+			now: updateTime,
+			expect: &dao.RevokedTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), updateTime, nil),
+				RevokedTokenModelCore: dao.RevokedTokenModelCore{
+					UserID:    goframework.NumberUUID(1),
+					ExpiresAt: updateTime.Add(time.Hour),
+				},
+			},
+		},
+		{
+			name: "Success/AlreadyRevoked",
+			data: &dao.RevokedTokenModelCore{
+				UserID:    goframework.NumberUUID(1),
+				ExpiresAt: baseTime.Add(time.Hour),
+			},
+			id:  goframework.NumberUUID(1000),
+			now: updateTime,
+			expect: &dao.RevokedTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), updateTime, nil),
+				RevokedTokenModelCore: dao.RevokedTokenModelCore{
+					UserID:    goframework.NumberUUID(1),
+					ExpiresAt: baseTime.Add(time.Hour),
+				},
+			},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				repository := dao.NewRevokedTokensRepository(stx)
+
+				res, err := repository.Revoke(ctx, d.data, d.id, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+
+				if d.expectErr == nil {
+					ok, err := repository.IsRevoked(ctx, d.id, d.data.UserID, d.now)
+					require.NoError(t, err)
+					require.True(t, ok)
+				}
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestRevokedTokensRepository_RevokeUser(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.RevokedUserTokensModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+			RevokedUserTokensModelCore: dao.RevokedUserTokensModelCore{
+				RevokedAt: baseTime,
+				ExpiresAt: baseTime.Add(time.Hour),
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		userID    uuid.UUID
+		expiresAt time.Time
+		now       time.Time
+
+		expect    *dao.RevokedUserTokensModel
+		expectErr error
+	}{
+		{
+			name:      "Success",
+			userID:    goframework.NumberUUID(1),
+			expiresAt: updateTime.Add(time.Hour),
+			now:       updateTime,
+			expect: &dao.RevokedUserTokensModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), updateTime, nil),
+				RevokedUserTokensModelCore: dao.RevokedUserTokensModelCore{
+					RevokedAt: updateTime,
+					ExpiresAt: updateTime.Add(time.Hour),
+				},
+			},
+		},
+		{
+			name:      "Success/AlreadyRevoked",
+			userID:    goframework.NumberUUID(2),
+			expiresAt: updateTime.Add(time.Hour),
+			now:       updateTime,
+			expect: &dao.RevokedUserTokensModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, &updateTime),
+				RevokedUserTokensModelCore: dao.RevokedUserTokensModelCore{
+					RevokedAt: updateTime,
+					ExpiresAt: updateTime.Add(time.Hour),
+				},
+			},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				res, err := dao.NewRevokedTokensRepository(stx).RevokeUser(ctx, d.userID, d.expiresAt, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestRevokedTokensRepository_IsRevoked(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []interface{}{
+		&dao.RevokedTokenModel{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			RevokedTokenModelCore: dao.RevokedTokenModelCore{
+				UserID:    goframework.NumberUUID(1),
+				ExpiresAt: baseTime.Add(time.Hour),
+			},
+		},
+		&dao.RevokedUserTokensModel{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+			RevokedUserTokensModelCore: dao.RevokedUserTokensModelCore{
+				RevokedAt: baseTime,
+				ExpiresAt: baseTime.Add(time.Hour),
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		id       uuid.UUID
+		userID   uuid.UUID
+		issuedAt time.Time
+
+		expect bool
+	}{
+		{
+			name:     "Success/TokenRevoked",
+			id:       goframework.NumberUUID(1000),
+			userID:   goframework.NumberUUID(1),
+			issuedAt: baseTime,
+			expect:   true,
+		},
+		{
+			name:     "Success/UserRevoked",
+			id:       goframework.NumberUUID(1001),
+			userID:   goframework.NumberUUID(2),
+			issuedAt: baseTime.Add(-time.Minute),
+			expect:   true,
+		},
+		{
+			name:     "Success/IssuedAfterUserRevocation",
+			id:       goframework.NumberUUID(1001),
+			userID:   goframework.NumberUUID(2),
+			issuedAt: baseTime.Add(time.Minute),
+		},
+		{
+			name:     "Success/NotRevoked",
+			id:       goframework.NumberUUID(1001),
+			userID:   goframework.NumberUUID(1),
+			issuedAt: baseTime,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewRevokedTokensRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.IsRevoked(ctx, d.id, d.userID, d.issuedAt)
+				require.NoError(t, err)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestRevokedTokensRepository_Prune(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []interface{}{
+		&dao.RevokedTokenModel{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			RevokedTokenModelCore: dao.RevokedTokenModelCore{
+				UserID:    goframework.NumberUUID(1),
+				ExpiresAt: baseTime.Add(time.Hour),
+			},
+		},
+		&dao.RevokedTokenModel{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, nil),
+			RevokedTokenModelCore: dao.RevokedTokenModelCore{
+				UserID:    goframework.NumberUUID(1),
+				ExpiresAt: baseTime.Add(3 * time.Hour),
+			},
+		},
+		&dao.RevokedUserTokensModel{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+			RevokedUserTokensModelCore: dao.RevokedUserTokensModelCore{
+				RevokedAt: baseTime,
+				ExpiresAt: baseTime.Add(time.Hour),
+			},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewRevokedTokensRepository(tx)
+
+		require.NoError(t, repository.Prune(ctx, baseTime.Add(2*time.Hour)))
+
+		ok, err := repository.IsRevoked(ctx, goframework.NumberUUID(1000), goframework.NumberUUID(1), baseTime)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		ok, err = repository.IsRevoked(ctx, goframework.NumberUUID(1001), goframework.NumberUUID(1), baseTime)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = repository.IsRevoked(ctx, goframework.NumberUUID(1002), goframework.NumberUUID(2), baseTime.Add(-time.Minute))
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+	require.NoError(t, err)
+}
@@ -4,6 +4,8 @@ import (
 	"cloud.google.com/go/storage"
 	"context"
 	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
 	goerrors "errors"
 	"fmt"
 	"github.com/a-novel/bunovel"
@@ -17,24 +19,48 @@ import (
 )
 
 type SecretKeysRepository interface {
-	// Write creates a new entry.
-	Write(ctx context.Context, key ed25519.PrivateKey, name string) (*SecretKeyModel, error)
+	// Write creates a new entry. The key is published (accepted to verify signatures) right away, but only becomes
+	// active (used to sign) at activatesAt.
+	Write(ctx context.Context, key ed25519.PrivateKey, name string, now time.Time, activatesAt time.Time) (*SecretKeyModel, error)
 	// Read a key from the specified name.
 	Read(ctx context.Context, name string) (*SecretKeyModel, error)
-	// List all entries.
+	// List all entries, most recently published first.
 	List(ctx context.Context) ([]*SecretKeyModel, error)
 	// Delete the specified entry.
 	Delete(ctx context.Context, name string) error
+	// Refresh reloads the entries kept in memory. It does nothing on repositories that read the storage on every
+	// call.
+	Refresh(ctx context.Context) error
+
+	// RunInTx runs the callback in a transaction, on repositories that support them. Other repositories run the
+	// callback directly.
+	RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository SecretKeysRepository) error) error
 }
 
 type SecretKeyModel struct {
 	// Key returns the decoded key for the current entry.
 	Key ed25519.PrivateKey
-	// Date returns the date when the key was created.
+	// Date returns the date when the key was published.
 	Date time.Time
+	// ActivatesAt is the date from which the key is used to sign new tokens. Until then, the key is only used to
+	// verify signatures, which gives every instance the time to load it.
+	ActivatesAt time.Time
 	// Name of the record (file) that stores the entry.
 	Name string
 }
+
+// IsActive returns true if the key can be used to sign new tokens.
+func (model *SecretKeyModel) IsActive(now time.Time) bool {
+	return !model.ActivatesAt.After(now)
+}
+
+// KeyID returns a stable identifier for the key, derived from its Name. This identifier is published alongside the
+// public key, so services that verify tokens can pick the right key without knowing how they are stored.
+func (model *SecretKeyModel) KeyID() string {
+	sum := sha256.Sum256([]byte(model.Name))
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
+
 type fileSystemRepositoryImpl struct {
 	basePath string
 	prefix   string
@@ -56,7 +82,7 @@ func (repository *fileSystemRepositoryImpl) removePrefix(name string) string {
 	return strings.TrimPrefix(name, repository.prefix+"-")
 }
 
-func (repository *fileSystemRepositoryImpl) Write(_ context.Context, key ed25519.PrivateKey, name string) (*SecretKeyModel, error) {
+func (repository *fileSystemRepositoryImpl) Write(_ context.Context, key ed25519.PrivateKey, name string, now time.Time, activatesAt time.Time) (*SecretKeyModel, error) {
 	fileWriter, err := os.Create(repository.getPath(name))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create file %q: %w", name, err)
@@ -64,7 +90,7 @@ func (repository *fileSystemRepositoryImpl) Write(_ context.Context, key ed25519
 
 	defer fileWriter.Close()
 
-	if err = writeKeyToOutput(fileWriter, key); err != nil {
+	if err = writeKeyToOutput(fileWriter, key, now, activatesAt); err != nil {
 		return nil, fmt.Errorf("failed to write key to file %q: %w", name, err)
 	}
 
@@ -74,9 +100,10 @@ func (repository *fileSystemRepositoryImpl) Write(_ context.Context, key ed25519
 	}
 
 	return &SecretKeyModel{
-		Key:  key,
-		Date: stat.ModTime(),
-		Name: repository.removePrefix(stat.Name()),
+		Key:         key,
+		Date:        now,
+		ActivatesAt: activatesAt,
+		Name:        repository.removePrefix(stat.Name()),
 	}, nil
 }
 
@@ -95,21 +122,19 @@ func (repository *fileSystemRepositoryImpl) Read(_ context.Context, name string)
 		return nil, fmt.Errorf("failed to read content of file %q: %w", repository.getPath(name), err)
 	}
 
-	key, err := unmarshalPrivateKey(fileData)
+	stat, err := fileReader.Stat()
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode file %q: %w", repository.getPath(name), err)
+		return nil, fmt.Errorf("failed to read stats of file %q: %w", name, err)
 	}
 
-	stat, err := fileReader.Stat()
+	model, err := unmarshalPrivateKey(fileData, stat.ModTime())
 	if err != nil {
-		return nil, fmt.Errorf("failed to read stats of file %q: %w", name, err)
+		return nil, fmt.Errorf("failed to decode file %q: %w", repository.getPath(name), err)
 	}
 
-	return &SecretKeyModel{
-		Key:  key,
-		Date: stat.ModTime(),
-		Name: repository.removePrefix(stat.Name()),
-	}, nil
+	model.Name = repository.removePrefix(stat.Name())
+
+	return model, nil
 }
 
 func (repository *fileSystemRepositoryImpl) List(ctx context.Context) ([]*SecretKeyModel, error) {
@@ -155,6 +180,14 @@ func (repository *fileSystemRepositoryImpl) Delete(_ context.Context, name strin
 	return nil
 }
 
+func (repository *fileSystemRepositoryImpl) Refresh(_ context.Context) error {
+	return nil
+}
+
+func (repository *fileSystemRepositoryImpl) RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository SecretKeysRepository) error) error {
+	return callback(ctx, repository)
+}
+
 type googleDatastoreRepositoryImpl struct {
 	bucket *storage.BucketHandle
 }
@@ -163,18 +196,19 @@ func NewGoogleDatastoreSecretKeysRepository(bucket *storage.BucketHandle) Secret
 	return &googleDatastoreRepositoryImpl{bucket: bucket}
 }
 
-func (repository *googleDatastoreRepositoryImpl) Write(ctx context.Context, key ed25519.PrivateKey, name string) (*SecretKeyModel, error) {
+func (repository *googleDatastoreRepositoryImpl) Write(ctx context.Context, key ed25519.PrivateKey, name string, now time.Time, activatesAt time.Time) (*SecretKeyModel, error) {
 	fileWriter := repository.bucket.Object(name).NewWriter(ctx)
 	defer fileWriter.Close()
 
-	if err := writeKeyToOutput(fileWriter, key); err != nil {
+	if err := writeKeyToOutput(fileWriter, key, now, activatesAt); err != nil {
 		return nil, fmt.Errorf("failed to write key to file %q: %w", name, err)
 	}
 
 	return &SecretKeyModel{
-		Key:  key,
-		Date: fileWriter.Updated,
-		Name: fileWriter.Name,
+		Key:         key,
+		Date:        now,
+		ActivatesAt: activatesAt,
+		Name:        fileWriter.Name,
 	}, nil
 }
 
@@ -194,15 +228,14 @@ func (repository *googleDatastoreRepositoryImpl) Read(ctx context.Context, name
 		return nil, fmt.Errorf("failed to read file %q: %w", name, err)
 	}
 
-	key, err := unmarshalPrivateKey(data)
+	model, err := unmarshalPrivateKey(data, fileReader.Attrs.LastModified)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode file %q: %w", name, err)
 	}
-	return &SecretKeyModel{
-		Key:  key,
-		Date: fileReader.Attrs.LastModified,
-		Name: name,
-	}, nil
+
+	model.Name = name
+
+	return model, nil
 }
 
 func (repository *googleDatastoreRepositoryImpl) List(ctx context.Context) ([]*SecretKeyModel, error) {
@@ -244,3 +277,11 @@ func (repository *googleDatastoreRepositoryImpl) Delete(ctx context.Context, nam
 
 	return nil
 }
+
+func (repository *googleDatastoreRepositoryImpl) Refresh(_ context.Context) error {
+	return nil
+}
+
+func (repository *googleDatastoreRepositoryImpl) RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository SecretKeysRepository) error) error {
+	return callback(ctx, repository)
+}
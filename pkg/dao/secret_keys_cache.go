@@ -0,0 +1,188 @@
+package dao
+
+import (
+	"context"
+	"crypto/ed25519"
+	"sync"
+	"time"
+)
+
+// NewCachedSecretKeysRepository keeps the entries of the source repository in memory, so they are not downloaded
+// and decoded on every request.
+//
+// Entries are reloaded in the background every updateInterval, until ctx is done. If a reload fails, the previous
+// entries are served, and the error is passed to onError (if set). Refresh forces a reload, but at most once every
+// refreshCooldown, so a client sending unknown key IDs cannot hammer the source.
+func NewCachedSecretKeysRepository(
+	ctx context.Context,
+	source SecretKeysRepository,
+	updateInterval time.Duration,
+	refreshCooldown time.Duration,
+	onError func(err error),
+) SecretKeysRepository {
+	repository := &cachedSecretKeysRepositoryImpl{
+		source:          source,
+		refreshCooldown: refreshCooldown,
+		onError:         onError,
+	}
+
+	if updateInterval > 0 {
+		go repository.watch(ctx, updateInterval)
+	}
+
+	return repository
+}
+
+type cachedSecretKeysRepositoryImpl struct {
+	source          SecretKeysRepository
+	refreshCooldown time.Duration
+	onError         func(err error)
+
+	// reloadMu prevents concurrent reloads from hitting the source at the same time.
+	reloadMu sync.Mutex
+
+	mu       sync.RWMutex
+	keys     []*SecretKeyModel
+	loaded   bool
+	loadedAt time.Time
+}
+
+func (repository *cachedSecretKeysRepositoryImpl) watch(ctx context.Context, updateInterval time.Duration) {
+	ticker := time.NewTicker(updateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := repository.reload(ctx, 0); err != nil {
+				repository.reportError(err)
+			}
+		}
+	}
+}
+
+func (repository *cachedSecretKeysRepositoryImpl) reportError(err error) {
+	if repository.onError != nil {
+		repository.onError(err)
+	}
+}
+
+// reload replaces the entries in memory with the ones from the source. On failure, the current entries are kept.
+// Entries loaded less than minAge ago are considered fresh enough, and are not reloaded.
+func (repository *cachedSecretKeysRepositoryImpl) reload(ctx context.Context, minAge time.Duration) error {
+	repository.reloadMu.Lock()
+	defer repository.reloadMu.Unlock()
+
+	repository.mu.RLock()
+	fresh := repository.loaded && time.Since(repository.loadedAt) < minAge
+	repository.mu.RUnlock()
+
+	if fresh {
+		return nil
+	}
+
+	keys, err := repository.source.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	repository.keys = keys
+	repository.loaded = true
+	repository.loadedAt = time.Now()
+
+	return nil
+}
+
+// snapshot returns a copy of the entries in memory, so callers cannot alter the cache.
+func (repository *cachedSecretKeysRepositoryImpl) snapshot() ([]*SecretKeyModel, bool) {
+	repository.mu.RLock()
+	defer repository.mu.RUnlock()
+
+	if !repository.loaded {
+		return nil, false
+	}
+
+	if repository.keys == nil {
+		return nil, true
+	}
+
+	keys := make([]*SecretKeyModel, len(repository.keys))
+	copy(keys, repository.keys)
+
+	return keys, true
+}
+
+func (repository *cachedSecretKeysRepositoryImpl) Write(ctx context.Context, key ed25519.PrivateKey, name string, now time.Time, activatesAt time.Time) (*SecretKeyModel, error) {
+	model, err := repository.source.Write(ctx, key, name, now, activatesAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// The write succeeded, so a stale cache must not fail the call. The background reload will catch up.
+	if err = repository.reload(ctx, 0); err != nil {
+		repository.reportError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *cachedSecretKeysRepositoryImpl) Read(ctx context.Context, name string) (*SecretKeyModel, error) {
+	keys, _ := repository.snapshot()
+
+	for _, key := range keys {
+		if key.Name == name {
+			return key, nil
+		}
+	}
+
+	return repository.source.Read(ctx, name)
+}
+
+func (repository *cachedSecretKeysRepositoryImpl) List(ctx context.Context) ([]*SecretKeyModel, error) {
+	if keys, ok := repository.snapshot(); ok {
+		return keys, nil
+	}
+
+	// Nothing to serve yet, so the first load must succeed.
+	if err := repository.reload(ctx, repository.refreshCooldown); err != nil {
+		return nil, err
+	}
+
+	keys, _ := repository.snapshot()
+	return keys, nil
+}
+
+func (repository *cachedSecretKeysRepositoryImpl) Delete(ctx context.Context, name string) error {
+	if err := repository.source.Delete(ctx, name); err != nil {
+		return err
+	}
+
+	if err := repository.reload(ctx, 0); err != nil {
+		repository.reportError(err)
+	}
+
+	return nil
+}
+
+func (repository *cachedSecretKeysRepositoryImpl) Refresh(ctx context.Context) error {
+	return repository.reload(ctx, repository.refreshCooldown)
+}
+
+// RunInTx runs the callback on the source repository, so it reads the stored entries rather than the ones in memory.
+// The entries are reloaded once the transaction ends.
+func (repository *cachedSecretKeysRepositoryImpl) RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository SecretKeysRepository) error) error {
+	if err := repository.source.RunInTx(ctx, callback); err != nil {
+		return err
+	}
+
+	if err := repository.reload(ctx, 0); err != nil {
+		repository.reportError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func secretKeyNames(keys []*dao.SecretKeyModel) []string {
+	return lo.Map(keys, func(item *dao.SecretKeyModel, _ int) string {
+		return item.Name
+	})
+}
+
+func TestCachedSecretKeysRepository_List(t *testing.T) {
+	err := goframework.RunFileTransactionalTest(t, SecretKeysFixtures, func(ctx context.Context, basePath string) {
+		source := dao.NewFileSystemSecretKeysRepository(basePath, "foo")
+		repository := dao.NewCachedSecretKeysRepository(ctx, source, 0, 0, nil)
+
+		res, err := repository.List(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"test-2", "test-3", "test-1"}, secretKeyNames(res))
+
+		// Changes made directly on the source are not visible until the next reload.
+		_, err = source.Write(ctx, MockedSecretKeys[4], "test-4", time.Now(), time.Now())
+		require.NoError(t, err)
+
+		res, err = repository.List(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"test-2", "test-3", "test-1"}, secretKeyNames(res))
+
+		require.NoError(t, repository.Refresh(ctx))
+
+		res, err = repository.List(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"test-4", "test-2", "test-3", "test-1"}, secretKeyNames(res))
+
+		// Altering the returned slice must not alter the cache.
+		res[0] = nil
+
+		res, err = repository.List(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"test-4", "test-2", "test-3", "test-1"}, secretKeyNames(res))
+	})
+	require.NoError(t, err)
+}
+
+func TestCachedSecretKeysRepository_ListFirstLoadFailure(t *testing.T) {
+	source := dao.NewFileSystemSecretKeysRepository(t.TempDir()+"/missing", "foo")
+	repository := dao.NewCachedSecretKeysRepository(context.Background(), source, 0, 0, nil)
+
+	res, err := repository.List(context.Background())
+	require.Error(t, err)
+	require.Nil(t, res)
+}
+
+func TestCachedSecretKeysRepository_RefreshCooldown(t *testing.T) {
+	err := goframework.RunFileTransactionalTest(t, SecretKeysFixtures, func(ctx context.Context, basePath string) {
+		source := dao.NewFileSystemSecretKeysRepository(basePath, "foo")
+		repository := dao.NewCachedSecretKeysRepository(ctx, source, 0, time.Hour, nil)
+
+		_, err := repository.List(ctx)
+		require.NoError(t, err)
+
+		_, err = source.Write(ctx, MockedSecretKeys[4], "test-4", time.Now(), time.Now())
+		require.NoError(t, err)
+
+		// The keys were loaded less than an hour ago, so the forced reload is ignored.
+		require.NoError(t, repository.Refresh(ctx))
+
+		res, err := repository.List(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"test-2", "test-3", "test-1"}, secretKeyNames(res))
+	})
+	require.NoError(t, err)
+}
+
+func TestCachedSecretKeysRepository_BackgroundRefresh(t *testing.T) {
+	err := goframework.RunFileTransactionalTest(t, SecretKeysFixtures, func(ctx context.Context, basePath string) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		source := dao.NewFileSystemSecretKeysRepository(basePath, "foo")
+		repository := dao.NewCachedSecretKeysRepository(ctx, source, 10*time.Millisecond, time.Hour, nil)
+
+		_, err := repository.List(ctx)
+		require.NoError(t, err)
+
+		_, err = source.Write(ctx, MockedSecretKeys[4], "test-4", time.Now(), time.Now())
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			res, err := repository.List(ctx)
+			return err == nil && len(res) == 4 && res[0].Name == "test-4"
+		}, time.Second, 10*time.Millisecond)
+	})
+	require.NoError(t, err)
+}
+
+func TestCachedSecretKeysRepository_ServeStaleOnFailure(t *testing.T) {
+	err := goframework.RunFileTransactionalTest(t, SecretKeysFixtures, func(ctx context.Context, basePath string) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var reportedErrors atomic.Int32
+
+		source := dao.NewFileSystemSecretKeysRepository(basePath, "foo")
+		repository := dao.NewCachedSecretKeysRepository(ctx, source, 10*time.Millisecond, 0, func(err error) {
+			reportedErrors.Add(1)
+		})
+
+		_, err := repository.List(ctx)
+		require.NoError(t, err)
+
+		// Make the source unavailable.
+		require.NoError(t, os.RemoveAll(basePath))
+
+		require.Eventually(t, func() bool {
+			return reportedErrors.Load() > 0
+		}, time.Second, 10*time.Millisecond)
+
+		require.Error(t, repository.Refresh(ctx))
+
+		res, err := repository.List(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"test-2", "test-3", "test-1"}, secretKeyNames(res))
+	})
+	require.NoError(t, err)
+}
+
+func TestCachedSecretKeysRepository_WriteDelete(t *testing.T) {
+	err := goframework.RunFileTransactionalTest(t, SecretKeysFixtures, func(ctx context.Context, basePath string) {
+		source := dao.NewFileSystemSecretKeysRepository(basePath, "foo")
+		repository := dao.NewCachedSecretKeysRepository(ctx, source, 0, time.Hour, nil)
+
+		_, err := repository.List(ctx)
+		require.NoError(t, err)
+
+		// Writes and deletions made through the cache are visible right away, regardless of the cooldown.
+		_, err = repository.Write(ctx, MockedSecretKeys[4], "test-4", time.Now(), time.Now())
+		require.NoError(t, err)
+
+		res, err := repository.List(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"test-4", "test-2", "test-3", "test-1"}, secretKeyNames(res))
+
+		require.NoError(t, repository.Delete(ctx, "test-2"))
+
+		res, err = repository.List(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"test-4", "test-3", "test-1"}, secretKeyNames(res))
+
+		require.ErrorIs(t, repository.Delete(ctx, "test-2"), bunovel.ErrNotFound)
+	})
+	require.NoError(t, err)
+}
+
+func TestCachedSecretKeysRepository_Read(t *testing.T) {
+	err := goframework.RunFileTransactionalTest(t, SecretKeysFixtures, func(ctx context.Context, basePath string) {
+		source := dao.NewFileSystemSecretKeysRepository(basePath, "foo")
+		repository := dao.NewCachedSecretKeysRepository(ctx, source, 0, time.Hour, nil)
+
+		_, err := repository.List(ctx)
+		require.NoError(t, err)
+
+		res, err := repository.Read(ctx, "test-2")
+		require.NoError(t, err)
+		require.True(t, MockedSecretKeys[1].Equal(res.Key))
+
+		// Entries missing from the cache are read from the source.
+		_, err = source.Write(ctx, MockedSecretKeys[4], "test-4", time.Now(), time.Now())
+		require.NoError(t, err)
+
+		res, err = repository.Read(ctx, "test-4")
+		require.NoError(t, err)
+		require.True(t, MockedSecretKeys[4].Equal(res.Key))
+
+		_, err = repository.Read(ctx, "test-5")
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+	})
+	require.NoError(t, err)
+}
+
+// Run with -race to detect unsafe accesses. The source is not written during the test, because the file system
+// repository does not support concurrent writes.
+func TestCachedSecretKeysRepository_Concurrency(t *testing.T) {
+	err := goframework.RunFileTransactionalTest(t, SecretKeysFixtures, func(ctx context.Context, basePath string) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		source := dao.NewFileSystemSecretKeysRepository(basePath, "foo")
+		repository := dao.NewCachedSecretKeysRepository(ctx, source, time.Millisecond, 0, nil)
+
+		wg := new(sync.WaitGroup)
+
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				for j := 0; j < 20; j++ {
+					switch (i + j) % 3 {
+					case 0:
+						res, err := repository.List(ctx)
+						require.NoError(t, err)
+						require.NotEmpty(t, res)
+					case 1:
+						require.NoError(t, repository.Refresh(ctx))
+					case 2:
+						_, err := repository.Read(ctx, "test-1")
+						require.NoError(t, err)
+					}
+				}
+			}(i)
+		}
+
+		wg.Wait()
+
+		res, err := repository.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, res, 3)
+	})
+	require.NoError(t, err)
+}
+
+func TestCachedSecretKeysRepository_RunInTx(t *testing.T) {
+	err := goframework.RunFileTransactionalTest(t, SecretKeysFixtures, func(ctx context.Context, basePath string) {
+		source := dao.NewFileSystemSecretKeysRepository(basePath, "foo")
+		repository := dao.NewCachedSecretKeysRepository(ctx, source, 0, time.Hour, nil)
+
+		_, err := repository.List(ctx)
+		require.NoError(t, err)
+
+		err = repository.RunInTx(ctx, func(ctx context.Context, txRepository dao.SecretKeysRepository) error {
+			_, err := txRepository.Write(ctx, MockedSecretKeys[4], "test-4", time.Now(), time.Now())
+			return err
+		})
+		require.NoError(t, err)
+
+		// The cache is reloaded once the transaction ends, regardless of the cooldown.
+		res, err := repository.List(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"test-4", "test-2", "test-3", "test-1"}, secretKeyNames(res))
+
+		err = repository.RunInTx(ctx, func(ctx context.Context, txRepository dao.SecretKeysRepository) error {
+			return fooErr
+		})
+		require.ErrorIs(t, err, fooErr)
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,192 @@
+package dao
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	goerrors "errors"
+	"fmt"
+	"github.com/a-novel/bunovel"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+var (
+	ErrInvalidMasterKey     = goerrors.New("the master key must be 32 bytes long")
+	ErrDecryptSignatureKey  = goerrors.New("failed to decrypt signature key")
+	ErrInvalidEncryptedData = goerrors.New("encrypted signature key is too short")
+)
+
+// EncryptedSecretKeyModel is the representation of a signature key in Postgres. The key is encrypted with the master
+// key of the repository.
+type EncryptedSecretKeyModel struct {
+	bun.BaseModel `bun:"table:secret_keys"`
+	bunovel.Metadata
+
+	// Name of the key, unique among all keys.
+	Name string `bun:"name"`
+	// KeyEncrypted is the PKCS #8 form of the key, sealed with AES-GCM. The nonce is stored before the ciphertext.
+	KeyEncrypted []byte `bun:"key_encrypted"`
+	// ActivatesAt is the date from which the key is used to sign new tokens.
+	ActivatesAt time.Time `bun:"activates_at"`
+}
+
+// NewPostgresSecretKeysRepository stores the keys in Postgres, encrypted with masterKey (AES-256). Keys are sorted
+// by their creation date, as recorded in the database.
+//
+// RunInTx locks the table for writes, so concurrent rotations happen one after the other.
+func NewPostgresSecretKeysRepository(db bun.IDB, masterKey []byte) (SecretKeysRepository, error) {
+	if len(masterKey) != 32 {
+		return nil, ErrInvalidMasterKey
+	}
+
+	block, err := aes.NewCipher(masterKey)
+	if err != nil {
+		return nil, goerrors.Join(ErrInvalidMasterKey, err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, goerrors.Join(ErrInvalidMasterKey, err)
+	}
+
+	return &postgresSecretKeysRepositoryImpl{db: db, aead: aead}, nil
+}
+
+type postgresSecretKeysRepositoryImpl struct {
+	db   bun.IDB
+	aead cipher.AEAD
+}
+
+// encrypt seals the key. The name is used as additional data, so an encrypted key cannot be moved to another record.
+func (repository *postgresSecretKeysRepositoryImpl) encrypt(key ed25519.PrivateKey, name string) ([]byte, error) {
+	marshalledKey, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, goerrors.Join(ErrMarshalSignatureKey, err)
+	}
+
+	nonce := make([]byte, repository.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return repository.aead.Seal(nonce, nonce, marshalledKey, []byte(name)), nil
+}
+
+func (repository *postgresSecretKeysRepositoryImpl) decrypt(model *EncryptedSecretKeyModel) (*SecretKeyModel, error) {
+	nonceSize := repository.aead.NonceSize()
+	if len(model.KeyEncrypted) < nonceSize {
+		return nil, ErrInvalidEncryptedData
+	}
+
+	marshalledKey, err := repository.aead.Open(
+		nil, model.KeyEncrypted[:nonceSize], model.KeyEncrypted[nonceSize:], []byte(model.Name),
+	)
+	if err != nil {
+		return nil, goerrors.Join(ErrDecryptSignatureKey, err)
+	}
+
+	keyData, err := x509.ParsePKCS8PrivateKey(marshalledKey)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := keyData.(ed25519.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidSignatureKeyFileContent
+	}
+
+	return &SecretKeyModel{
+		Key:         key,
+		Date:        model.CreatedAt,
+		ActivatesAt: model.ActivatesAt,
+		Name:        model.Name,
+	}, nil
+}
+
+func (repository *postgresSecretKeysRepositoryImpl) Write(ctx context.Context, key ed25519.PrivateKey, name string, now time.Time, activatesAt time.Time) (*SecretKeyModel, error) {
+	encrypted, err := repository.encrypt(key, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt key %q: %w", name, err)
+	}
+
+	model := &EncryptedSecretKeyModel{
+		Metadata:     bunovel.NewMetadata(uuid.New(), now, nil),
+		Name:         name,
+		KeyEncrypted: encrypted,
+		ActivatesAt:  activatesAt,
+	}
+
+	if _, err := repository.db.NewInsert().Model(model).Exec(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return &SecretKeyModel{
+		Key:         key,
+		Date:        now,
+		ActivatesAt: activatesAt,
+		Name:        name,
+	}, nil
+}
+
+func (repository *postgresSecretKeysRepositoryImpl) Read(ctx context.Context, name string) (*SecretKeyModel, error) {
+	model := new(EncryptedSecretKeyModel)
+
+	if err := repository.db.NewSelect().Model(model).Where("name = ?", name).Scan(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	key, err := repository.decrypt(model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key %q: %w", name, err)
+	}
+
+	return key, nil
+}
+
+func (repository *postgresSecretKeysRepositoryImpl) List(ctx context.Context) ([]*SecretKeyModel, error) {
+	var models []*EncryptedSecretKeyModel
+
+	if err := repository.db.NewSelect().Model(&models).Order("created_at DESC").Scan(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	records := make([]*SecretKeyModel, len(models))
+	for i, model := range models {
+		key, err := repository.decrypt(model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode key %q: %w", model.Name, err)
+		}
+
+		records[i] = key
+	}
+
+	return records, nil
+}
+
+func (repository *postgresSecretKeysRepositoryImpl) Delete(ctx context.Context, name string) error {
+	res, err := repository.db.NewDelete().Model(new(EncryptedSecretKeyModel)).Where("name = ?", name).Exec(ctx)
+	if err != nil {
+		return bunovel.HandlePGError(err)
+	}
+
+	return bunovel.ForceRowsUpdate(res)
+}
+
+func (repository *postgresSecretKeysRepositoryImpl) Refresh(_ context.Context) error {
+	return nil
+}
+
+func (repository *postgresSecretKeysRepositoryImpl) RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository SecretKeysRepository) error) error {
+	return repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Reads are still allowed, but other writers wait for the transaction to end.
+		if _, err := tx.ExecContext(ctx, "LOCK TABLE secret_keys IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+			return bunovel.HandlePGError(err)
+		}
+
+		return callback(ctx, &postgresSecretKeysRepositoryImpl{db: tx, aead: repository.aead})
+	})
+}
@@ -0,0 +1,160 @@
+package dao_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/a-novel/auth-service/migrations"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+var (
+	masterKey      = bytes.Repeat([]byte{1}, 32)
+	otherMasterKey = bytes.Repeat([]byte{2}, 32)
+)
+
+func TestNewPostgresSecretKeysRepository(t *testing.T) {
+	_, err := dao.NewPostgresSecretKeysRepository(nil, masterKey)
+	require.NoError(t, err)
+
+	_, err = dao.NewPostgresSecretKeysRepository(nil, masterKey[:16])
+	require.ErrorIs(t, err, dao.ErrInvalidMasterKey)
+
+	_, err = dao.NewPostgresSecretKeysRepository(nil, nil)
+	require.ErrorIs(t, err, dao.ErrInvalidMasterKey)
+}
+
+func TestPostgresSecretKeysRepository(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, []*dao.EncryptedSecretKeyModel{}, func(ctx context.Context, tx bun.Tx) {
+		repository, err := dao.NewPostgresSecretKeysRepository(tx, masterKey)
+		require.NoError(t, err)
+
+		_, err = repository.Write(ctx, MockedSecretKeys[0], "test-1", baseTime, baseTime)
+		require.NoError(t, err)
+		_, err = repository.Write(ctx, MockedSecretKeys[1], "test-2", baseTime.Add(time.Hour), baseTime.Add(2*time.Hour))
+		require.NoError(t, err)
+		_, err = repository.Write(ctx, MockedSecretKeys[2], "test-3", baseTime.Add(30*time.Minute), baseTime.Add(30*time.Minute))
+		require.NoError(t, err)
+
+		// Keys are not stored in clear.
+		var stored []*dao.EncryptedSecretKeyModel
+		require.NoError(t, tx.NewSelect().Model(&stored).Scan(ctx))
+		require.Len(t, stored, 3)
+		for _, item := range stored {
+			require.NotContains(t, string(item.KeyEncrypted), string(MockedSecretKeys[0].Seed()))
+		}
+
+		res, err := repository.Read(ctx, "test-2")
+		require.NoError(t, err)
+		require.Equal(t, &dao.SecretKeyModel{
+			Key:         MockedSecretKeys[1],
+			Date:        baseTime.Add(time.Hour),
+			ActivatesAt: baseTime.Add(2 * time.Hour),
+			Name:        "test-2",
+		}, res)
+
+		_, err = repository.Read(ctx, "test-4")
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+
+		list, err := repository.List(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"test-2", "test-3", "test-1"}, secretKeyNames(list))
+
+		require.NoError(t, repository.Delete(ctx, "test-3"))
+		require.ErrorIs(t, repository.Delete(ctx, "test-3"), bunovel.ErrNotFound)
+
+		list, err = repository.List(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"test-2", "test-1"}, secretKeyNames(list))
+	})
+	require.NoError(t, err)
+}
+
+func TestPostgresSecretKeysRepository_Encryption(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, []*dao.EncryptedSecretKeyModel{}, func(ctx context.Context, tx bun.Tx) {
+		repository, err := dao.NewPostgresSecretKeysRepository(tx, masterKey)
+		require.NoError(t, err)
+
+		otherRepository, err := dao.NewPostgresSecretKeysRepository(tx, otherMasterKey)
+		require.NoError(t, err)
+
+		_, err = repository.Write(ctx, MockedSecretKeys[0], "test-1", baseTime, baseTime)
+		require.NoError(t, err)
+		_, err = repository.Write(ctx, MockedSecretKeys[1], "test-2", baseTime, baseTime)
+		require.NoError(t, err)
+
+		// Keys cannot be read without the right master key.
+		_, err = otherRepository.Read(ctx, "test-1")
+		require.ErrorIs(t, err, dao.ErrDecryptSignatureKey)
+
+		// Keys cannot be moved to another record.
+		_, err = tx.NewUpdate().
+			Table("secret_keys").
+			Set("key_encrypted = (SELECT key_encrypted FROM secret_keys WHERE name = ?)", "test-1").
+			Where("name = ?", "test-2").
+			Exec(ctx)
+		require.NoError(t, err)
+
+		_, err = repository.Read(ctx, "test-2")
+		require.ErrorIs(t, err, dao.ErrDecryptSignatureKey)
+	})
+	require.NoError(t, err)
+}
+
+func TestPostgresSecretKeysRepository_RunInTx(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, []*dao.EncryptedSecretKeyModel{}, func(ctx context.Context, tx bun.Tx) {
+		repository, err := dao.NewPostgresSecretKeysRepository(tx, masterKey)
+		require.NoError(t, err)
+
+		_, err = repository.Write(ctx, MockedSecretKeys[0], "test-1", baseTime, baseTime)
+		require.NoError(t, err)
+
+		// Changes are rolled back on failure.
+		err = repository.RunInTx(ctx, func(ctx context.Context, txRepository dao.SecretKeysRepository) error {
+			if _, err := txRepository.Write(ctx, MockedSecretKeys[1], "test-2", updateTime, updateTime); err != nil {
+				return err
+			}
+			if err := txRepository.Delete(ctx, "test-1"); err != nil {
+				return err
+			}
+
+			return fooErr
+		})
+		require.ErrorIs(t, err, fooErr)
+
+		list, err := repository.List(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"test-1"}, secretKeyNames(list))
+
+		err = repository.RunInTx(ctx, func(ctx context.Context, txRepository dao.SecretKeysRepository) error {
+			if _, err := txRepository.Write(ctx, MockedSecretKeys[1], "test-2", updateTime, updateTime); err != nil {
+				return err
+			}
+
+			return txRepository.Delete(ctx, "test-1")
+		})
+		require.NoError(t, err)
+
+		list, err = repository.List(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"test-2"}, secretKeyNames(list))
+	})
+	require.NoError(t, err)
+}
@@ -73,28 +73,38 @@ func TestFileRepository_Write(t *testing.T) {
 	data := []struct {
 		name string
 
-		key     ed25519.PrivateKey
-		keyName string
+		key         ed25519.PrivateKey
+		keyName     string
+		now         time.Time
+		activatesAt time.Time
 
 		expect    *dao.SecretKeyModel
 		expectErr error
 	}{
 		{
-			name:    "Success",
-			key:     MockedSecretKeys[4],
-			keyName: "test-4",
+			name:        "Success",
+			key:         MockedSecretKeys[4],
+			keyName:     "test-4",
+			now:         baseTime,
+			activatesAt: updateTime,
 			expect: &dao.SecretKeyModel{
-				Key:  MockedSecretKeys[4],
-				Name: "test-4",
+				Key:         MockedSecretKeys[4],
+				Date:        baseTime,
+				ActivatesAt: updateTime,
+				Name:        "test-4",
 			},
 		},
 		{
-			name:    "Success/Exists",
-			key:     MockedSecretKeys[4],
-			keyName: "test-2",
+			name:        "Success/Exists",
+			key:         MockedSecretKeys[4],
+			keyName:     "test-2",
+			now:         baseTime,
+			activatesAt: updateTime,
 			expect: &dao.SecretKeyModel{
-				Key:  MockedSecretKeys[4],
-				Name: "test-2",
+				Key:         MockedSecretKeys[4],
+				Date:        baseTime,
+				ActivatesAt: updateTime,
+				Name:        "test-2",
 			},
 		},
 	}
@@ -104,12 +114,21 @@ func TestFileRepository_Write(t *testing.T) {
 
 		for _, d := range data {
 			t.Run(d.name, func(t *testing.T) {
-				res, err := repository.Write(ctx, d.key, d.keyName)
+				res, err := repository.Write(ctx, d.key, d.keyName, d.now, d.activatesAt)
 				require.ErrorIs(t, err, d.expectErr)
 
 				if d.expect != nil {
 					require.Equal(t, d.expect.Name, res.Name)
 					require.True(t, d.expect.Key.Equal(res.Key))
+					require.Equal(t, d.expect.Date, res.Date)
+					require.Equal(t, d.expect.ActivatesAt, res.ActivatesAt)
+
+					// Dates are stored with the key, rather than relying on the file metadata.
+					stored, err := repository.Read(ctx, d.keyName)
+					require.NoError(t, err)
+					require.True(t, d.expect.Key.Equal(stored.Key))
+					require.True(t, d.expect.Date.Equal(stored.Date))
+					require.True(t, d.expect.ActivatesAt.Equal(stored.ActivatesAt))
 				} else {
 					require.Nil(t, res)
 				}
@@ -132,8 +151,10 @@ func TestFileRepository_Read(t *testing.T) {
 			name:    "Success",
 			keyName: "test-2",
 			expect: &dao.SecretKeyModel{
-				Key:  MockedSecretKeys[1],
-				Name: "test-2",
+				Key:         MockedSecretKeys[1],
+				Date:        baseTime.Add(time.Hour),
+				ActivatesAt: baseTime.Add(time.Hour),
+				Name:        "test-2",
 			},
 		},
 		{
@@ -154,6 +175,9 @@ func TestFileRepository_Read(t *testing.T) {
 				if d.expect != nil {
 					require.Equal(t, d.expect.Name, res.Name)
 					require.True(t, d.expect.Key.Equal(res.Key))
+					// Keys written without dates fall back to the modification date of the file.
+					require.True(t, d.expect.Date.Equal(res.Date))
+					require.True(t, d.expect.ActivatesAt.Equal(res.ActivatesAt))
 				} else {
 					require.Nil(t, res)
 				}
@@ -250,3 +274,69 @@ func TestFileRepository_Delete(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestSecretKeyModel_KeyID(t *testing.T) {
+	data := []struct {
+		name string
+
+		model *dao.SecretKeyModel
+		other *dao.SecretKeyModel
+
+		expectEqual bool
+	}{
+		{
+			name:        "Success/SameName",
+			model:       &dao.SecretKeyModel{Name: "test-1", Key: MockedSecretKeys[0]},
+			other:       &dao.SecretKeyModel{Name: "test-1", Key: MockedSecretKeys[1], Date: baseTime},
+			expectEqual: true,
+		},
+		{
+			name:  "Success/DifferentName",
+			model: &dao.SecretKeyModel{Name: "test-1", Key: MockedSecretKeys[0]},
+			other: &dao.SecretKeyModel{Name: "test-2", Key: MockedSecretKeys[0]},
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			require.NotEmpty(t, d.model.KeyID())
+			require.Equal(t, d.model.KeyID(), d.model.KeyID())
+			require.Equal(t, d.expectEqual, d.model.KeyID() == d.other.KeyID())
+		})
+	}
+}
+
+func TestSecretKeyModel_IsActive(t *testing.T) {
+	data := []struct {
+		name string
+
+		model *dao.SecretKeyModel
+		now   time.Time
+
+		expect bool
+	}{
+		{
+			name:   "Active",
+			model:  &dao.SecretKeyModel{Date: baseTime, ActivatesAt: baseTime.Add(time.Hour)},
+			now:    baseTime.Add(2 * time.Hour),
+			expect: true,
+		},
+		{
+			name:   "Active/ActivationDate",
+			model:  &dao.SecretKeyModel{Date: baseTime, ActivatesAt: baseTime.Add(time.Hour)},
+			now:    baseTime.Add(time.Hour),
+			expect: true,
+		},
+		{
+			name:  "Published",
+			model: &dao.SecretKeyModel{Date: baseTime, ActivatesAt: baseTime.Add(time.Hour)},
+			now:   baseTime.Add(30 * time.Minute),
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			require.Equal(t, d.expect, d.model.IsActive(d.now))
+		})
+	}
+}
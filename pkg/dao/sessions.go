@@ -0,0 +1,208 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+type SessionsRepository interface {
+	// Create stores a new session, for a new refresh token family.
+	Create(ctx context.Context, data *SessionModelCore, id uuid.UUID, now time.Time) (*SessionModel, error)
+	// GetSessionByFamily reads the session attached to the given refresh token family.
+	GetSessionByFamily(ctx context.Context, familyID uuid.UUID) (*SessionModel, error)
+	// ListUserSessions returns the sessions of a user that have not been revoked, most recently seen first.
+	ListUserSessions(ctx context.Context, userID uuid.UUID) ([]*SessionModel, error)
+	// ListUserSessionsHistory returns every session of a user, including revoked sessions, most recently opened
+	// first.
+	ListUserSessionsHistory(ctx context.Context, userID uuid.UUID) ([]*SessionModel, error)
+	// ListUserAgents returns every distinct user agent a user opened a session from, including revoked sessions.
+	ListUserAgents(ctx context.Context, userID uuid.UUID) ([]string, error)
+	// Revoke revokes a session. Because sessions are revoked by their owner, the user ID must match, otherwise
+	// bunovel.ErrNotFound is returned. It is also returned if the session was already revoked.
+	Revoke(ctx context.Context, id, userID uuid.UUID, now time.Time) (*SessionModel, error)
+	// UpdateLastSeen sets the last activity date of the session attached to the given refresh token family. The
+	// update only happens if the session was last seen before the given threshold, to limit writes.
+	UpdateLastSeen(ctx context.Context, familyID uuid.UUID, threshold time.Time, now time.Time) error
+	// UpdateToken records the signature key, and the expiration date, of the last token issued for the given refresh
+	// token family.
+	UpdateToken(ctx context.Context, familyID uuid.UUID, keyID string, expiresAt time.Time, now time.Time) error
+	// CountLiveSessionsByKey returns the number of sessions that have not been revoked, and hold a token signed with
+	// the given key that has not expired yet.
+	CountLiveSessionsByKey(ctx context.Context, keyID string, now time.Time) (int, error)
+
+	RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository SessionsRepository) error) error
+}
+
+type SessionModel struct {
+	bun.BaseModel `bun:"table:sessions"`
+	bunovel.Metadata
+	SessionModelCore
+}
+
+type SessionModelCore struct {
+	// FamilyID is the refresh token family of the session. Each login creates a new family.
+	FamilyID uuid.UUID `bun:"family_id"`
+	// UserID is the ID of the user who owns the session.
+	UserID uuid.UUID `bun:"user_id"`
+	// UserAgent of the client that opened the session.
+	UserAgent string `bun:"user_agent"`
+	// IP of the client that opened the session.
+	IP string `bun:"ip"`
+	// LastSeenAt is the date of the last known activity on the session.
+	LastSeenAt time.Time `bun:"last_seen_at"`
+	// RevokedAt is set when the user ends the session from another device.
+	RevokedAt *time.Time `bun:"revoked_at"`
+	// KeyID is the ID of the signature key used for the last token issued for the session.
+	KeyID string `bun:"key_id"`
+	// TokenExpiresAt is the expiration date of the last token issued for the session.
+	TokenExpiresAt *time.Time `bun:"token_expires_at"`
+}
+
+func NewSessionsRepository(db bun.IDB) SessionsRepository {
+	return &sessionsRepositoryImpl{db: db}
+}
+
+type sessionsRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *sessionsRepositoryImpl) Create(ctx context.Context, data *SessionModelCore, id uuid.UUID, now time.Time) (*SessionModel, error) {
+	model := &SessionModel{Metadata: bunovel.NewMetadata(id, now, nil), SessionModelCore: *data}
+
+	if _, err := repository.db.NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *sessionsRepositoryImpl) GetSessionByFamily(ctx context.Context, familyID uuid.UUID) (*SessionModel, error) {
+	model := new(SessionModel)
+
+	if err := repository.db.NewSelect().Model(model).Where("family_id = ?", familyID).Scan(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *sessionsRepositoryImpl) ListUserSessions(ctx context.Context, userID uuid.UUID) ([]*SessionModel, error) {
+	var results []*SessionModel
+
+	err := repository.db.NewSelect().Model(&results).
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Order("last_seen_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return results, nil
+}
+
+func (repository *sessionsRepositoryImpl) ListUserSessionsHistory(ctx context.Context, userID uuid.UUID) ([]*SessionModel, error) {
+	var results []*SessionModel
+
+	err := repository.db.NewSelect().Model(&results).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return results, nil
+}
+
+func (repository *sessionsRepositoryImpl) ListUserAgents(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	var results []string
+
+	err := repository.db.NewSelect().Model(new(SessionModel)).
+		Distinct().
+		Column("user_agent").
+		Where("user_id = ?", userID).
+		Order("user_agent").
+		Scan(ctx, &results)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return results, nil
+}
+
+func (repository *sessionsRepositoryImpl) Revoke(ctx context.Context, id, userID uuid.UUID, now time.Time) (*SessionModel, error) {
+	model := &SessionModel{
+		Metadata:         bunovel.NewMetadata(id, time.Time{}, &now),
+		SessionModelCore: SessionModelCore{RevokedAt: &now},
+	}
+
+	res, err := repository.db.NewUpdate().Model(model).
+		WherePK().
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Column("revoked_at", "updated_at").
+		Returning("*").
+		Exec(ctx)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	if err = bunovel.ForceRowsUpdate(res); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (repository *sessionsRepositoryImpl) UpdateLastSeen(ctx context.Context, familyID uuid.UUID, threshold time.Time, now time.Time) error {
+	model := &SessionModel{
+		Metadata:         bunovel.NewMetadata(uuid.Nil, time.Time{}, &now),
+		SessionModelCore: SessionModelCore{LastSeenAt: now},
+	}
+
+	_, err := repository.db.NewUpdate().Model(model).
+		Where("family_id = ?", familyID).
+		Where("last_seen_at < ?", threshold).
+		Column("last_seen_at", "updated_at").
+		Exec(ctx)
+
+	return bunovel.HandlePGError(err)
+}
+
+func (repository *sessionsRepositoryImpl) UpdateToken(ctx context.Context, familyID uuid.UUID, keyID string, expiresAt time.Time, now time.Time) error {
+	model := &SessionModel{
+		Metadata:         bunovel.NewMetadata(uuid.Nil, time.Time{}, &now),
+		SessionModelCore: SessionModelCore{KeyID: keyID, TokenExpiresAt: &expiresAt},
+	}
+
+	_, err := repository.db.NewUpdate().Model(model).
+		Where("family_id = ?", familyID).
+		Column("key_id", "token_expires_at", "updated_at").
+		Exec(ctx)
+
+	return bunovel.HandlePGError(err)
+}
+
+func (repository *sessionsRepositoryImpl) CountLiveSessionsByKey(ctx context.Context, keyID string, now time.Time) (int, error) {
+	count, err := repository.db.NewSelect().Model(new(SessionModel)).
+		Where("key_id = ?", keyID).
+		Where("token_expires_at > ?", now).
+		Where("revoked_at IS NULL").
+		Count(ctx)
+	if err != nil {
+		return 0, bunovel.HandlePGError(err)
+	}
+
+	return count, nil
+}
+
+func (repository *sessionsRepositoryImpl) RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository SessionsRepository) error) error {
+	return repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return callback(ctx, NewSessionsRepository(tx))
+	})
+}
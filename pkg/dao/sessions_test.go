@@ -0,0 +1,706 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/migrations"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+func TestSessionsRepository_Create(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.SessionModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(100),
+				UserID:     goframework.NumberUUID(1),
+				LastSeenAt: baseTime,
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		data *dao.SessionModelCore
+		id   uuid.UUID
+		now  time.Time
+
+		expect    *dao.SessionModel
+		expectErr error
+	}{
+		{
+			name: "Success",
+			data: &dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(101),
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "Mozilla/5.0",
+				IP:         "127.0.0.1",
+				LastSeenAt: updateTime,
+			},
+			id:  goframework.NumberUUID(1001),
+			now: updateTime,
+			expect: &dao.SessionModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), updateTime, nil),
+				SessionModelCore: dao.SessionModelCore{
+					FamilyID:   goframework.NumberUUID(101),
+					UserID:     goframework.NumberUUID(1),
+					UserAgent:  "Mozilla/5.0",
+					IP:         "127.0.0.1",
+					LastSeenAt: updateTime,
+				},
+			},
+		},
+		{
+			name: "Error/FamilyTaken",
+			data: &dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(100),
+				UserID:     goframework.NumberUUID(1),
+				LastSeenAt: updateTime,
+			},
+			id:        goframework.NumberUUID(1001),
+			now:       updateTime,
+			expectErr: bunovel.ErrUniqConstraintViolation,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				res, err := dao.NewSessionsRepository(stx).Create(ctx, d.data, d.id, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestSessionsRepository_GetSessionByFamily(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.SessionModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(100),
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "Mozilla/5.0",
+				IP:         "127.0.0.1",
+				LastSeenAt: baseTime,
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		familyID uuid.UUID
+
+		expect    *dao.SessionModel
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			familyID: goframework.NumberUUID(100),
+			expect:   fixtures[0],
+		},
+		{
+			name:      "Error/NotFound",
+			familyID:  goframework.NumberUUID(101),
+			expectErr: bunovel.ErrNotFound,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewSessionsRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.GetSessionByFamily(ctx, d.familyID)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestSessionsRepository_ListUserSessions(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.SessionModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(100),
+				UserID:     goframework.NumberUUID(1),
+				LastSeenAt: baseTime,
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(101),
+				UserID:     goframework.NumberUUID(1),
+				LastSeenAt: updateTime,
+			},
+		},
+		// Revoked.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1002), baseTime, &updateTime),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(102),
+				UserID:     goframework.NumberUUID(1),
+				LastSeenAt: updateTime,
+				RevokedAt:  &updateTime,
+			},
+		},
+		// Other user.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1003), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(103),
+				UserID:     goframework.NumberUUID(2),
+				LastSeenAt: baseTime,
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		userID uuid.UUID
+
+		expect    []*dao.SessionModel
+		expectErr error
+	}{
+		{
+			name:   "Success",
+			userID: goframework.NumberUUID(1),
+			expect: []*dao.SessionModel{fixtures[1], fixtures[0]},
+		},
+		{
+			name:   "Success/NoSessions",
+			userID: goframework.NumberUUID(3),
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewSessionsRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.ListUserSessions(ctx, d.userID)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestSessionsRepository_ListUserSessionsHistory(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.SessionModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(100),
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "user-agent-1",
+				IP:         "127.0.0.1",
+				LastSeenAt: updateTime,
+			},
+		},
+		// Revoked.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), updateTime, &updateTime),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(101),
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "user-agent-2",
+				IP:         "127.0.0.2",
+				LastSeenAt: updateTime,
+				RevokedAt:  &updateTime,
+			},
+		},
+		// Other user.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1002), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(102),
+				UserID:     goframework.NumberUUID(2),
+				UserAgent:  "user-agent-3",
+				LastSeenAt: baseTime,
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		userID uuid.UUID
+
+		expect    []*dao.SessionModel
+		expectErr error
+	}{
+		{
+			name:   "Success",
+			userID: goframework.NumberUUID(1),
+			expect: []*dao.SessionModel{fixtures[1], fixtures[0]},
+		},
+		{
+			name:   "Success/NoSessions",
+			userID: goframework.NumberUUID(3),
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewSessionsRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.ListUserSessionsHistory(ctx, d.userID)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestSessionsRepository_ListUserAgents(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.SessionModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(100),
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "user-agent-2",
+				LastSeenAt: baseTime,
+			},
+		},
+		// Same user agent.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(101),
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "user-agent-2",
+				LastSeenAt: updateTime,
+			},
+		},
+		// Revoked.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1002), baseTime, &updateTime),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(102),
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "user-agent-1",
+				LastSeenAt: updateTime,
+				RevokedAt:  &updateTime,
+			},
+		},
+		// Other user.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1003), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(103),
+				UserID:     goframework.NumberUUID(2),
+				UserAgent:  "user-agent-3",
+				LastSeenAt: baseTime,
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		userID uuid.UUID
+
+		expect    []string
+		expectErr error
+	}{
+		{
+			name:   "Success",
+			userID: goframework.NumberUUID(1),
+			expect: []string{"user-agent-1", "user-agent-2"},
+		},
+		{
+			name:   "Success/NoSessions",
+			userID: goframework.NumberUUID(3),
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewSessionsRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.ListUserAgents(ctx, d.userID)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestSessionsRepository_Revoke(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.SessionModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(100),
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "Mozilla/5.0",
+				IP:         "127.0.0.1",
+				LastSeenAt: baseTime,
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &baseTime),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(101),
+				UserID:     goframework.NumberUUID(1),
+				LastSeenAt: baseTime,
+				RevokedAt:  &baseTime,
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		id     uuid.UUID
+		userID uuid.UUID
+		now    time.Time
+
+		expect    *dao.SessionModel
+		expectErr error
+	}{
+		{
+			name:   "Success",
+			id:     goframework.NumberUUID(1000),
+			userID: goframework.NumberUUID(1),
+			now:    updateTime,
+			expect: &dao.SessionModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+				SessionModelCore: dao.SessionModelCore{
+					FamilyID:   goframework.NumberUUID(100),
+					UserID:     goframework.NumberUUID(1),
+					UserAgent:  "Mozilla/5.0",
+					IP:         "127.0.0.1",
+					LastSeenAt: baseTime,
+					RevokedAt:  &updateTime,
+				},
+			},
+		},
+		{
+			name:      "Error/OtherUser",
+			id:        goframework.NumberUUID(1000),
+			userID:    goframework.NumberUUID(2),
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
+		},
+		{
+			name:      "Error/AlreadyRevoked",
+			id:        goframework.NumberUUID(1001),
+			userID:    goframework.NumberUUID(1),
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
+		},
+		{
+			name:      "Error/NotFound",
+			id:        goframework.NumberUUID(1002),
+			userID:    goframework.NumberUUID(1),
+			now:       updateTime,
+			expectErr: bunovel.ErrNotFound,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				res, err := dao.NewSessionsRepository(stx).Revoke(ctx, d.id, d.userID, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestSessionsRepository_UpdateLastSeen(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.SessionModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(100),
+				UserID:     goframework.NumberUUID(1),
+				LastSeenAt: baseTime,
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		familyID  uuid.UUID
+		threshold time.Time
+		now       time.Time
+
+		expect *dao.SessionModel
+	}{
+		{
+			name:      "Success",
+			familyID:  goframework.NumberUUID(100),
+			threshold: updateTime.Add(-time.Minute),
+			now:       updateTime,
+			expect: &dao.SessionModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+				SessionModelCore: dao.SessionModelCore{
+					FamilyID:   goframework.NumberUUID(100),
+					UserID:     goframework.NumberUUID(1),
+					LastSeenAt: updateTime,
+				},
+			},
+		},
+		{
+			name:      "Success/Throttled",
+			familyID:  goframework.NumberUUID(100),
+			threshold: baseTime.Add(-time.Minute),
+			now:       baseTime.Add(time.Second),
+			expect:    fixtures[0],
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				repository := dao.NewSessionsRepository(stx)
+				require.NoError(t, repository.UpdateLastSeen(ctx, d.familyID, d.threshold, d.now))
+
+				res, err := repository.GetSessionByFamily(ctx, d.familyID)
+				require.NoError(t, err)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestSessionsRepository_UpdateToken(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.SessionModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(100),
+				UserID:     goframework.NumberUUID(1),
+				LastSeenAt: baseTime,
+			},
+		},
+	}
+
+	expiresAt := updateTime.Add(time.Hour)
+
+	data := []struct {
+		name string
+
+		familyID  uuid.UUID
+		keyID     string
+		expiresAt time.Time
+		now       time.Time
+
+		expect *dao.SessionModel
+	}{
+		{
+			name:      "Success",
+			familyID:  goframework.NumberUUID(100),
+			keyID:     "key-1",
+			expiresAt: expiresAt,
+			now:       updateTime,
+			expect: &dao.SessionModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+				SessionModelCore: dao.SessionModelCore{
+					FamilyID:       goframework.NumberUUID(100),
+					UserID:         goframework.NumberUUID(1),
+					LastSeenAt:     baseTime,
+					KeyID:          "key-1",
+					TokenExpiresAt: &expiresAt,
+				},
+			},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				repository := dao.NewSessionsRepository(stx)
+				require.NoError(t, repository.UpdateToken(ctx, d.familyID, d.keyID, d.expiresAt, d.now))
+
+				res, err := repository.GetSessionByFamily(ctx, d.familyID)
+				require.NoError(t, err)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestSessionsRepository_CountLiveSessionsByKey(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	expiresAt := baseTime.Add(time.Hour)
+
+	fixtures := []*dao.SessionModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:       goframework.NumberUUID(100),
+				UserID:         goframework.NumberUUID(1),
+				LastSeenAt:     baseTime,
+				KeyID:          "key-1",
+				TokenExpiresAt: &expiresAt,
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:       goframework.NumberUUID(101),
+				UserID:         goframework.NumberUUID(2),
+				LastSeenAt:     baseTime,
+				KeyID:          "key-1",
+				TokenExpiresAt: &expiresAt,
+			},
+		},
+		// Revoked.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1002), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:       goframework.NumberUUID(102),
+				UserID:         goframework.NumberUUID(1),
+				LastSeenAt:     baseTime,
+				RevokedAt:      &baseTime,
+				KeyID:          "key-1",
+				TokenExpiresAt: &expiresAt,
+			},
+		},
+		// Signed with another key.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1003), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:       goframework.NumberUUID(103),
+				UserID:         goframework.NumberUUID(1),
+				LastSeenAt:     baseTime,
+				KeyID:          "key-2",
+				TokenExpiresAt: &expiresAt,
+			},
+		},
+		// No token recorded.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1004), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				FamilyID:   goframework.NumberUUID(104),
+				UserID:     goframework.NumberUUID(1),
+				LastSeenAt: baseTime,
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		keyID string
+		now   time.Time
+
+		expect int
+	}{
+		{
+			name:   "Success",
+			keyID:  "key-1",
+			now:    baseTime,
+			expect: 2,
+		},
+		{
+			name:   "Success/TokensExpired",
+			keyID:  "key-1",
+			now:    expiresAt,
+			expect: 0,
+		},
+		{
+			name:   "Success/UnknownKey",
+			keyID:  "key-3",
+			now:    baseTime,
+			expect: 0,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				res, err := dao.NewSessionsRepository(stx).CountLiveSessionsByKey(ctx, d.keyID, d.now)
+				require.NoError(t, err)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
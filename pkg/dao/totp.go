@@ -0,0 +1,215 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+type TOTPRepository interface {
+	// Enroll stores a new TOTP secret for the user, along with its recovery codes. Any previous secret and recovery
+	// codes are replaced, and the new secret must be confirmed again. The recovery codes MUST be hashed.
+	Enroll(ctx context.Context, secret string, recoveryCodes []string, userID uuid.UUID, now time.Time) (*TOTPModel, error)
+	// Get reads the TOTP secret of a user.
+	Get(ctx context.Context, userID uuid.UUID) (*TOTPModel, error)
+	// Confirm enables two-factor authentication, once the user has proven they can generate codes for the secret.
+	// The step of the code used for confirmation is recorded, so it cannot be used again.
+	Confirm(ctx context.Context, userID uuid.UUID, step int64, now time.Time) (*TOTPModel, error)
+	// UseStep records the time step of an accepted code. It fails with bunovel.ErrNotFound if a code from the same
+	// or a later step was already accepted, even if the operations happen concurrently.
+	UseStep(ctx context.Context, userID uuid.UUID, step int64, now time.Time) error
+	// Delete removes the TOTP secret and the recovery codes of a user, which disables two-factor authentication.
+	Delete(ctx context.Context, userID uuid.UUID) error
+
+	// ListRecoveryCodes returns the recovery codes of a user that have not been used yet.
+	ListRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]*RecoveryCodeModel, error)
+	// UseRecoveryCode marks a recovery code as used. It fails with bunovel.ErrNotFound if the code was already used,
+	// even if the operations happen concurrently.
+	UseRecoveryCode(ctx context.Context, id uuid.UUID, now time.Time) error
+
+	RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository TOTPRepository) error) error
+}
+
+type TOTPModel struct {
+	bun.BaseModel `bun:"table:totp_secrets"`
+	bunovel.Metadata
+	TOTPModelCore
+}
+
+type TOTPModelCore struct {
+	// Secret is the base32 encoded secret shared with the authenticator of the user.
+	Secret string `bun:"secret"`
+	// ConfirmedAt is set once the user has proven they can generate codes. Two-factor authentication is only enabled
+	// after this date.
+	ConfirmedAt *time.Time `bun:"confirmed_at"`
+	// LastStep is the time step of the last accepted code.
+	LastStep int64 `bun:"last_step"`
+}
+
+type RecoveryCodeModel struct {
+	bun.BaseModel `bun:"table:recovery_codes"`
+
+	ID        uuid.UUID `bun:"id,pk,type:uuid"`
+	CreatedAt time.Time `bun:"created_at"`
+	// UserID is the ID of the user who owns the code.
+	UserID uuid.UUID `bun:"user_id"`
+	// CodeHashed is the hashed value of the code. The raw value is only shown to the user once.
+	CodeHashed string `bun:"code_hashed"`
+	// UsedAt is set once the code has been used.
+	UsedAt *time.Time `bun:"used_at"`
+}
+
+func NewTOTPRepository(db bun.IDB) TOTPRepository {
+	return &totpRepositoryImpl{db: db}
+}
+
+type totpRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *totpRepositoryImpl) Enroll(ctx context.Context, secret string, recoveryCodes []string, userID uuid.UUID, now time.Time) (*TOTPModel, error) {
+	model := &TOTPModel{
+		Metadata:      bunovel.NewMetadata(userID, now, nil),
+		TOTPModelCore: TOTPModelCore{Secret: secret},
+	}
+
+	// Replace the secret and the codes together, so the user never ends up with codes from another secret.
+	err := repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().Model(model).
+			On("CONFLICT (id) DO UPDATE").
+			Set("updated_at = EXCLUDED.created_at").
+			Set("secret = EXCLUDED.secret").
+			Set("confirmed_at = NULL").
+			Set("last_step = 0").
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.NewDelete().Model((*RecoveryCodeModel)(nil)).Where("user_id = ?", userID).Exec(ctx); err != nil {
+			return err
+		}
+
+		if len(recoveryCodes) == 0 {
+			return nil
+		}
+
+		codes := lo.Map(recoveryCodes, func(item string, _ int) *RecoveryCodeModel {
+			return &RecoveryCodeModel{ID: uuid.New(), CreatedAt: now, UserID: userID, CodeHashed: item}
+		})
+		_, err = tx.NewInsert().Model(&codes).Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *totpRepositoryImpl) Get(ctx context.Context, userID uuid.UUID) (*TOTPModel, error) {
+	model := &TOTPModel{Metadata: bunovel.NewMetadata(userID, time.Time{}, nil)}
+
+	if err := repository.db.NewSelect().Model(model).WherePK().Scan(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *totpRepositoryImpl) Confirm(ctx context.Context, userID uuid.UUID, step int64, now time.Time) (*TOTPModel, error) {
+	model := &TOTPModel{
+		Metadata:      bunovel.NewMetadata(userID, time.Time{}, &now),
+		TOTPModelCore: TOTPModelCore{ConfirmedAt: &now, LastStep: step},
+	}
+
+	res, err := repository.db.NewUpdate().Model(model).
+		WherePK().
+		Where("confirmed_at IS NULL").
+		Column("confirmed_at", "last_step", "updated_at").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	if err = bunovel.ForceRowsUpdate(res); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (repository *totpRepositoryImpl) UseStep(ctx context.Context, userID uuid.UUID, step int64, now time.Time) error {
+	model := &TOTPModel{
+		Metadata:      bunovel.NewMetadata(userID, time.Time{}, &now),
+		TOTPModelCore: TOTPModelCore{LastStep: step},
+	}
+
+	res, err := repository.db.NewUpdate().Model(model).
+		WherePK().
+		// The check happens in the same statement as the update, so a code cannot be accepted twice.
+		Where("last_step < ?", step).
+		Column("last_step", "updated_at").
+		Exec(ctx)
+	if err != nil {
+		return bunovel.HandlePGError(err)
+	}
+
+	return bunovel.ForceRowsUpdate(res)
+}
+
+func (repository *totpRepositoryImpl) Delete(ctx context.Context, userID uuid.UUID) error {
+	err := repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().Model((*RecoveryCodeModel)(nil)).Where("user_id = ?", userID).Exec(ctx); err != nil {
+			return err
+		}
+
+		res, err := tx.NewDelete().Model((*TOTPModel)(nil)).Where("id = ?", userID).Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		return bunovel.ForceRowsUpdate(res)
+	})
+
+	return bunovel.HandlePGError(err)
+}
+
+func (repository *totpRepositoryImpl) ListRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]*RecoveryCodeModel, error) {
+	var results []*RecoveryCodeModel
+
+	err := repository.db.NewSelect().Model(&results).
+		Where("user_id = ?", userID).
+		Where("used_at IS NULL").
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return results, nil
+}
+
+func (repository *totpRepositoryImpl) UseRecoveryCode(ctx context.Context, id uuid.UUID, now time.Time) error {
+	res, err := repository.db.NewUpdate().Model((*RecoveryCodeModel)(nil)).
+		Set("used_at = ?", now).
+		Where("id = ?", id).
+		Where("used_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return bunovel.HandlePGError(err)
+	}
+
+	return bunovel.ForceRowsUpdate(res)
+}
+
+func (repository *totpRepositoryImpl) RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository TOTPRepository) error) error {
+	return repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return callback(ctx, NewTOTPRepository(tx))
+	})
+}
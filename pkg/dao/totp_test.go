@@ -0,0 +1,201 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/migrations"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+var totpFixtures = []interface{}{
+	&dao.TOTPModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		TOTPModelCore: dao.TOTPModelCore{
+			Secret:      "JBSWY3DPEHPK3PXP",
+			ConfirmedAt: &baseTime,
+			LastStep:    100,
+		},
+	},
+	&dao.TOTPModel{
+		Metadata:      bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+		TOTPModelCore: dao.TOTPModelCore{Secret: "KRSXG5CTMVRXEZLU"},
+	},
+	&dao.RecoveryCodeModel{
+		ID:         goframework.NumberUUID(100),
+		CreatedAt:  baseTime,
+		UserID:     goframework.NumberUUID(1),
+		CodeHashed: "code-1",
+	},
+	&dao.RecoveryCodeModel{
+		ID:         goframework.NumberUUID(101),
+		CreatedAt:  baseTime.Add(time.Second),
+		UserID:     goframework.NumberUUID(1),
+		CodeHashed: "code-2",
+		UsedAt:     &baseTime,
+	},
+	&dao.RecoveryCodeModel{
+		ID:         goframework.NumberUUID(102),
+		CreatedAt:  baseTime.Add(2 * time.Second),
+		UserID:     goframework.NumberUUID(1),
+		CodeHashed: "code-3",
+	},
+}
+
+func TestTOTPRepository_Enroll(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, totpFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewTOTPRepository(tx)
+
+		// New enrollment.
+		res, err := repository.Enroll(ctx, "GEZDGNBVGY3TQOJQ", []string{"code-a"}, goframework.NumberUUID(3), updateTime)
+		require.NoError(t, err)
+		require.Equal(t, &dao.TOTPModel{
+			Metadata:      bunovel.NewMetadata(goframework.NumberUUID(3), updateTime, nil),
+			TOTPModelCore: dao.TOTPModelCore{Secret: "GEZDGNBVGY3TQOJQ"},
+		}, res)
+
+		codes, err := repository.ListRecoveryCodes(ctx, goframework.NumberUUID(3))
+		require.NoError(t, err)
+		require.Len(t, codes, 1)
+		require.Equal(t, "code-a", codes[0].CodeHashed)
+
+		// Enrolling again replaces the secret, and the recovery codes.
+		res, err = repository.Enroll(ctx, "GEZDGNBVGY3TQOJQ", []string{"code-b", "code-c"}, goframework.NumberUUID(1), updateTime)
+		require.NoError(t, err)
+		require.Equal(t, &dao.TOTPModel{
+			Metadata:      bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &updateTime),
+			TOTPModelCore: dao.TOTPModelCore{Secret: "GEZDGNBVGY3TQOJQ"},
+		}, res)
+
+		codes, err = repository.ListRecoveryCodes(ctx, goframework.NumberUUID(1))
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"code-b", "code-c"}, lo.Map(codes, func(item *dao.RecoveryCodeModel, _ int) string {
+			return item.CodeHashed
+		}))
+	})
+	require.NoError(t, err)
+}
+
+func TestTOTPRepository_Get(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, totpFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewTOTPRepository(tx)
+
+		res, err := repository.Get(ctx, goframework.NumberUUID(1))
+		require.NoError(t, err)
+		require.Equal(t, totpFixtures[0], res)
+
+		_, err = repository.Get(ctx, goframework.NumberUUID(3))
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+	})
+	require.NoError(t, err)
+}
+
+func TestTOTPRepository_Confirm(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, totpFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewTOTPRepository(tx)
+
+		res, err := repository.Confirm(ctx, goframework.NumberUUID(2), 200, updateTime)
+		require.NoError(t, err)
+		require.Equal(t, &dao.TOTPModel{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, &updateTime),
+			TOTPModelCore: dao.TOTPModelCore{
+				Secret:      "KRSXG5CTMVRXEZLU",
+				ConfirmedAt: &updateTime,
+				LastStep:    200,
+			},
+		}, res)
+
+		// Already confirmed.
+		_, err = repository.Confirm(ctx, goframework.NumberUUID(1), 200, updateTime)
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+
+		_, err = repository.Confirm(ctx, goframework.NumberUUID(3), 200, updateTime)
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+	})
+	require.NoError(t, err)
+}
+
+func TestTOTPRepository_UseStep(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, totpFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewTOTPRepository(tx)
+
+		require.NoError(t, repository.UseStep(ctx, goframework.NumberUUID(1), 101, updateTime))
+		require.ErrorIs(t, repository.UseStep(ctx, goframework.NumberUUID(1), 101, updateTime), bunovel.ErrNotFound)
+		require.ErrorIs(t, repository.UseStep(ctx, goframework.NumberUUID(1), 99, updateTime), bunovel.ErrNotFound)
+		require.ErrorIs(t, repository.UseStep(ctx, goframework.NumberUUID(3), 101, updateTime), bunovel.ErrNotFound)
+
+		res, err := repository.Get(ctx, goframework.NumberUUID(1))
+		require.NoError(t, err)
+		require.Equal(t, int64(101), res.LastStep)
+	})
+	require.NoError(t, err)
+}
+
+func TestTOTPRepository_Delete(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, totpFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewTOTPRepository(tx)
+
+		require.NoError(t, repository.Delete(ctx, goframework.NumberUUID(1)))
+		require.ErrorIs(t, repository.Delete(ctx, goframework.NumberUUID(1)), bunovel.ErrNotFound)
+
+		_, err := repository.Get(ctx, goframework.NumberUUID(1))
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+
+		codes, err := repository.ListRecoveryCodes(ctx, goframework.NumberUUID(1))
+		require.NoError(t, err)
+		require.Empty(t, codes)
+	})
+	require.NoError(t, err)
+}
+
+func TestTOTPRepository_RecoveryCodes(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, totpFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewTOTPRepository(tx)
+
+		codes, err := repository.ListRecoveryCodes(ctx, goframework.NumberUUID(1))
+		require.NoError(t, err)
+		require.Equal(t, []*dao.RecoveryCodeModel{
+			totpFixtures[2].(*dao.RecoveryCodeModel),
+			totpFixtures[4].(*dao.RecoveryCodeModel),
+		}, codes)
+
+		require.NoError(t, repository.UseRecoveryCode(ctx, goframework.NumberUUID(100), updateTime))
+		require.ErrorIs(t, repository.UseRecoveryCode(ctx, goframework.NumberUUID(100), updateTime), bunovel.ErrNotFound)
+		require.ErrorIs(t, repository.UseRecoveryCode(ctx, goframework.NumberUUID(101), updateTime), bunovel.ErrNotFound)
+
+		codes, err = repository.ListRecoveryCodes(ctx, goframework.NumberUUID(1))
+		require.NoError(t, err)
+		require.Equal(t, []*dao.RecoveryCodeModel{totpFixtures[4].(*dao.RecoveryCodeModel)}, codes)
+	})
+	require.NoError(t, err)
+}
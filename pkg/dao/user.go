@@ -12,10 +12,29 @@ type UserRepository interface {
 	// Create creates a new user. The credentials, identity and profile objects will share the same ID and create time.
 	// If any error occurs, no data is created.
 	Create(ctx context.Context, data *UserModelCore, id uuid.UUID, now time.Time) (*UserModel, error)
-	// Search performs a cross-table search query over the user repository.
-	Search(ctx context.Context, query string, limit, offset int) ([]*UserModel, int, error)
+	// Search performs a cross-table search query over the user repository. Users suspended at the given time are
+	// ignored.
+	Search(ctx context.Context, query string, limit, offset int, now time.Time) ([]*UserModel, int, error)
 	// List returns a list of users
 	List(ctx context.Context, ids []uuid.UUID) ([]*UserModel, error)
+	// Purge permanently removes the credentials, identity and profile of the given users, which releases their email
+	// and slug, along with every other data linked to them (see userDataModels). Their login failures are removed, and
+	// their audit events are kept without the client information. Users that are not deleted
+	// (see CredentialsModelCore.DeletedAt) are ignored. It returns the ids of the purged users.
+	Purge(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+}
+
+// userDataModels are the tables that reference a user with a user_id column. The migrations declare no foreign keys,
+// so Purge removes them explicitly: every new table of user data must be added here.
+var userDataModels = []interface{}{
+	(*SessionModel)(nil),
+	(*RefreshTokenModel)(nil),
+	(*RecoveryCodeModel)(nil),
+	(*MFAChallengeModel)(nil),
+	(*PasskeyModel)(nil),
+	(*WebAuthnChallengeModel)(nil),
+	(*LoginLinkModel)(nil),
+	(*DataExportModel)(nil),
 }
 
 type UserModel struct {
@@ -78,7 +97,7 @@ func (repository *userRepositoryImpl) Create(ctx context.Context, data *UserMode
 	return model, nil
 }
 
-func (repository *userRepositoryImpl) Search(ctx context.Context, query string, limit, offset int) ([]*UserModel, int, error) {
+func (repository *userRepositoryImpl) Search(ctx context.Context, query string, limit, offset int, now time.Time) ([]*UserModel, int, error) {
 	var results []*UserModel
 
 	count, err := repository.db.NewSelect().Model(&results).
@@ -90,6 +109,10 @@ LEFT JOIN LATERAL (
 	) AS score
 ) AS proximity ON TRUE`, query).
 		Where("proximity.score > 0.1").
+		Where(
+			"id NOT IN (SELECT id FROM credentials WHERE suspended_at IS NOT NULL AND (suspended_until IS NULL OR suspended_until > ?))",
+			now,
+		).
 		Order("proximity.score DESC", "created_at DESC").
 		Limit(limit).
 		Offset(offset).
@@ -112,3 +135,61 @@ func (repository *userRepositoryImpl) List(ctx context.Context, ids []uuid.UUID)
 
 	return results, nil
 }
+
+func (repository *userRepositoryImpl) Purge(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	var (
+		purged []uuid.UUID
+		// Login failures are recorded under the normalized email of the user.
+		accounts []string
+	)
+
+	// Remove all in a transaction, to avoid partially purged users if any part of the operation fails.
+	err := repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewDelete().Model((*CredentialsModel)(nil)).
+			Where("id IN (?)", bun.In(ids)).
+			Where("deleted_at IS NOT NULL").
+			Returning("id, lower(email_user || '@' || email_domain)").
+			Scan(ctx, &purged, &accounts)
+		if err != nil {
+			return err
+		}
+
+		if len(purged) == 0 {
+			return nil
+		}
+
+		// Tables that use the id of the user as their own id.
+		for _, model := range []interface{}{(*IdentityModel)(nil), (*ProfileModel)(nil), (*TOTPModel)(nil)} {
+			if _, err = tx.NewDelete().Model(model).Where("id IN (?)", bun.In(purged)).Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		for _, model := range userDataModels {
+			if _, err = tx.NewDelete().Model(model).Where("user_id IN (?)", bun.In(purged)).Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.NewDelete().Model((*LoginFailureModel)(nil)).Where("account IN (?)", bun.In(accounts)).Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		// The audit log is append-only, so the events are kept for the history, without anything that could identify
+		// the user. Failed logins on an unknown email have no user, but may still carry their email.
+		_, err = tx.NewUpdate().Model((*AuditEventModel)(nil)).
+			Set("ip = NULL").
+			Set("user_agent = NULL").
+			Set("details = NULL").
+			Where("user_id IN (?) OR details ->> 'email' IN (?)", bun.In(purged), bun.In(accounts)).
+			Exec(ctx)
+		return err
+	})
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return purged, nil
+}
@@ -450,6 +450,33 @@ func TestUserRepository_Search(t *testing.T) {
 				Slug: "i-dont-have-any-ideas-anymore-alt",
 			},
 		},
+
+		// User 6 (suspended, never returned)
+		&dao.CredentialsModel{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1005), baseTime.Add(5*time.Hour), &updateTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:            MustParseEmail("elon.bezos@suspended.com"),
+				Password:         dao.Password{Hashed: "password-hashed"},
+				SuspendedAt:      &baseTime,
+				SuspensionReason: "spam",
+				SuspendedBy:      "moderator",
+			},
+		},
+		&dao.IdentityModel{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1005), baseTime.Add(5*time.Hour), &updateTime),
+			IdentityModelCore: dao.IdentityModelCore{
+				FirstName: "Elon",
+				LastName:  "Bezos",
+				Birthday:  time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+				Sex:       models.SexMale,
+			},
+		},
+		&dao.ProfileModel{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1005), baseTime.Add(5*time.Hour), &updateTime),
+			ProfileModelCore: dao.ProfileModelCore{
+				Slug: "space-origin-suspended",
+			},
+		},
 	}
 
 	data := []struct {
@@ -942,7 +969,7 @@ func TestUserRepository_Search(t *testing.T) {
 	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
 		for _, d := range data {
 			t.Run(d.name, func(st *testing.T) {
-				res, count, err := dao.NewUserRepository(tx).Search(ctx, d.query, d.limit, d.offset)
+				res, count, err := dao.NewUserRepository(tx).Search(ctx, d.query, d.limit, d.offset, updateTime)
 				require.ErrorIs(t, err, d.expectErr)
 
 				require.Empty(t, cmp.Diff(d.expect, res, cmpopts.IgnoreUnexported(time.Time{})))
@@ -1079,6 +1106,31 @@ func TestUserRepository_List(t *testing.T) {
 				Slug: "i-dont-have-any-ideas-anymore-alt",
 			},
 		},
+
+		// User 6, deleted.
+		&dao.CredentialsModel{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1005), baseTime.Add(7*time.Hour), &updateTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:     MustParseEmail("johnny.silverhand@samurai.band"),
+				Password:  dao.Password{Hashed: "password-hashed"},
+				DeletedAt: &updateTime,
+			},
+		},
+		&dao.IdentityModel{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1005), baseTime.Add(7*time.Hour), &updateTime),
+			IdentityModelCore: dao.IdentityModelCore{
+				FirstName: "Johnny",
+				LastName:  "Silverhand",
+				Birthday:  time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+				Sex:       models.SexMale,
+			},
+		},
+		&dao.ProfileModel{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1005), baseTime.Add(7*time.Hour), &updateTime),
+			ProfileModelCore: dao.ProfileModelCore{
+				Slug: "samurai",
+			},
+		},
 	}
 
 	data := []struct {
@@ -1094,6 +1146,8 @@ func TestUserRepository_List(t *testing.T) {
 				goframework.NumberUUID(1000),
 				goframework.NumberUUID(1002),
 				goframework.NumberUUID(1004),
+				// Deleted.
+				goframework.NumberUUID(1005),
 				// Don't exist.
 				goframework.NumberUUID(15),
 			},
@@ -1180,3 +1234,185 @@ func TestUserRepository_List(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestUserRepository_Purge(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []interface{}{
+		// User 1, deleted.
+		&dao.CredentialsModel{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:     MustParseEmail("user1@domain.com"),
+				Password:  dao.Password{Hashed: "password-hashed"},
+				DeletedAt: &updateTime,
+			},
+		},
+		&dao.IdentityModel{
+			Metadata:          bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+			IdentityModelCore: dao.IdentityModelCore{FirstName: "name-1"},
+		},
+		&dao.ProfileModel{
+			Metadata:         bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, &updateTime),
+			ProfileModelCore: dao.ProfileModelCore{Slug: "slug-1"},
+		},
+		&dao.SessionModel{
+			Metadata:         bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{UserID: goframework.NumberUUID(1000), IP: "127.0.0.1", LastSeenAt: baseTime},
+		},
+		&dao.TOTPModel{
+			Metadata:      bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+			TOTPModelCore: dao.TOTPModelCore{Secret: "secret"},
+		},
+		&dao.LoginFailureModel{
+			ID:                    goframework.NumberUUID(1),
+			CreatedAt:             baseTime,
+			LoginFailureModelCore: dao.LoginFailureModelCore{Account: "user1@domain.com", IP: "127.0.0.1"},
+		},
+		&dao.AuditEventModel{
+			ID:        goframework.NumberUUID(1),
+			CreatedAt: baseTime,
+			AuditEventModelCore: dao.AuditEventModelCore{
+				Kind:      dao.AuditEventLoginSucceeded,
+				UserID:    goframework.NumberUUID(1000),
+				IP:        "127.0.0.1",
+				UserAgent: "Mozilla/5.0",
+			},
+		},
+		&dao.AuditEventModel{
+			ID:        goframework.NumberUUID(2),
+			CreatedAt: baseTime,
+			AuditEventModelCore: dao.AuditEventModelCore{
+				Kind:    dao.AuditEventLoginFailed,
+				IP:      "127.0.0.1",
+				Details: map[string]string{"email": "user1@domain.com"},
+			},
+		},
+
+		// User 2
+		&dao.CredentialsModel{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &updateTime),
+			CredentialsModelCore: dao.CredentialsModelCore{
+				Email:    MustParseEmail("user2@domain.com"),
+				Password: dao.Password{Hashed: "password-hashed"},
+			},
+		},
+		&dao.IdentityModel{
+			Metadata:          bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &updateTime),
+			IdentityModelCore: dao.IdentityModelCore{FirstName: "name-2"},
+		},
+		&dao.ProfileModel{
+			Metadata:         bunovel.NewMetadata(goframework.NumberUUID(1001), baseTime, &updateTime),
+			ProfileModelCore: dao.ProfileModelCore{Slug: "slug-2"},
+		},
+		&dao.SessionModel{
+			Metadata:         bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+			SessionModelCore: dao.SessionModelCore{UserID: goframework.NumberUUID(1001), IP: "127.0.0.1", LastSeenAt: baseTime},
+		},
+		&dao.LoginFailureModel{
+			ID:                    goframework.NumberUUID(2),
+			CreatedAt:             baseTime,
+			LoginFailureModelCore: dao.LoginFailureModelCore{Account: "user2@domain.com", IP: "127.0.0.1"},
+		},
+		&dao.AuditEventModel{
+			ID:        goframework.NumberUUID(3),
+			CreatedAt: baseTime,
+			AuditEventModelCore: dao.AuditEventModelCore{
+				Kind:      dao.AuditEventLoginSucceeded,
+				UserID:    goframework.NumberUUID(1001),
+				IP:        "127.0.0.1",
+				UserAgent: "Mozilla/5.0",
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		ids []uuid.UUID
+
+		expect        []uuid.UUID
+		expectRemains []uuid.UUID
+		expectErr     error
+	}{
+		{
+			name:          "Success",
+			ids:           []uuid.UUID{goframework.NumberUUID(1000)},
+			expect:        []uuid.UUID{goframework.NumberUUID(1000)},
+			expectRemains: []uuid.UUID{goframework.NumberUUID(1001)},
+		},
+		{
+			name:          "Success/IgnoreActiveUsers",
+			ids:           []uuid.UUID{goframework.NumberUUID(1000), goframework.NumberUUID(1001)},
+			expect:        []uuid.UUID{goframework.NumberUUID(1000)},
+			expectRemains: []uuid.UUID{goframework.NumberUUID(1001)},
+		},
+		{
+			name:          "Success/NoResults",
+			ids:           []uuid.UUID{goframework.NumberUUID(1001), goframework.NumberUUID(15)},
+			expectRemains: []uuid.UUID{goframework.NumberUUID(1000), goframework.NumberUUID(1001)},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				stx, err := tx.BeginTx(ctx, nil)
+				require.NoError(st, err)
+				defer stx.Rollback()
+
+				res, err := dao.NewUserRepository(stx).Purge(ctx, d.ids)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+
+				// The slug of purged users is released.
+				for _, id := range d.expect {
+					_, err := dao.NewProfileRepository(stx).GetProfile(ctx, id)
+					require.ErrorIs(t, err, bunovel.ErrNotFound)
+					_, err = dao.NewIdentityRepository(stx).GetIdentity(ctx, id)
+					require.ErrorIs(t, err, bunovel.ErrNotFound)
+					_, err = dao.NewTOTPRepository(stx).Get(ctx, id)
+					require.ErrorIs(t, err, bunovel.ErrNotFound)
+
+					sessions, err := dao.NewSessionsRepository(stx).ListUserSessionsHistory(ctx, id)
+					require.NoError(t, err)
+					require.Empty(t, sessions)
+
+					events, _, err := dao.NewAuditEventsRepository(stx).List(ctx, dao.AuditEventsFilter{UserID: id}, 10, 0)
+					require.NoError(t, err)
+					for _, event := range events {
+						require.Empty(t, event.IP)
+						require.Empty(t, event.UserAgent)
+						require.Empty(t, event.Details)
+					}
+				}
+
+				for _, id := range d.expectRemains {
+					_, err := dao.NewCredentialsRepository(stx).GetCredentials(ctx, id)
+					require.NoError(t, err)
+					_, err = dao.NewProfileRepository(stx).GetProfile(ctx, id)
+					require.NoError(t, err)
+
+					sessions, err := dao.NewSessionsRepository(stx).ListUserSessionsHistory(ctx, id)
+					require.NoError(t, err)
+					require.NotEmpty(t, sessions)
+				}
+
+				// Login failures are recorded under the email of the user, even when it is unknown.
+				failures, err := dao.NewLoginFailuresRepository(stx).ListAccountFailures(ctx, "user1@domain.com")
+				require.NoError(t, err)
+				require.Equal(t, len(d.expect) == 0, len(failures) > 0)
+
+				events, _, err := dao.NewAuditEventsRepository(stx).List(ctx, dao.AuditEventsFilter{
+					Kinds: []dao.AuditEventKind{dao.AuditEventLoginFailed},
+				}, 10, 0)
+				require.NoError(t, err)
+				require.Len(t, events, 1)
+				require.Equal(t, len(d.expect) == 0, events[0].Details != nil)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 )
 
 var (
@@ -15,6 +16,14 @@ var (
 	ErrMarshalSignatureKey            = goerrors.New("failed to marshal signature key")
 	ErrEncodeSignatureKey             = goerrors.New("failed to encode signature key")
 	ErrInvalidSignatureKeyFileContent = goerrors.New("file does not contain a valid ed25519 private key: no block found")
+	ErrInvalidSignatureKeyHeader      = goerrors.New("invalid signature key header")
+)
+
+const (
+	// signatureKeyPublishedAtHeader is the PEM header that stores the date a key was published.
+	signatureKeyPublishedAtHeader = "Published-At"
+	// signatureKeyActivatesAtHeader is the PEM header that stores the date a key starts to be used for signing.
+	signatureKeyActivatesAtHeader = "Activates-At"
 )
 
 // Email represents an email address as a structure, rather than a single string. This facilitates indexing:
@@ -25,6 +34,9 @@ type Email struct {
 	// When user manages to successfully prove its authenticity, the email is validated and this code is removed.
 	// Like a password, the raw key should never be stored or cached.
 	Validation string `bun:"validation_code"`
+	// ValidationIssuedAt is the time the Validation code was issued. Codes expire after a delay that depends on their
+	// purpose.
+	ValidationIssuedAt *time.Time `bun:"validation_issued_at"`
 	// User of the email. This is the unique name that comes before the provider.
 	User string `bun:"user"`
 	// Domain is the host of the mailing service provider, for example 'gmail.com'.
@@ -62,6 +74,8 @@ type Password struct {
 	// contains the hashed key only. The raw key is sent to the user through a secure channel (an email address),
 	// and once the user has managed to prove its identity, it can then create a new password.
 	Validation string `bun:"validation_code"`
+	// ValidationIssuedAt is the time the Validation code was issued. Codes expire after a delay.
+	ValidationIssuedAt *time.Time `bun:"validation_issued_at"`
 	// Hashed is the hashed password, used to validate user claims when trying to authenticate.
 	Hashed string `bun:"hashed"`
 }
@@ -73,20 +87,32 @@ func WhereEmail(source string, value Email) (string, string, string) {
 	return fmt.Sprintf("%[1]s_user = ? AND %[1]s_domain = ?", source), value.User, value.Domain
 }
 
-func writeKeyToOutput(out io.Writer, key ed25519.PrivateKey) error {
+func writeKeyToOutput(out io.Writer, key ed25519.PrivateKey, publishedAt, activatesAt time.Time) error {
 	marshalledKey, err := x509.MarshalPKCS8PrivateKey(key)
 	if err != nil {
 		return goerrors.Join(ErrMarshalSignatureKey, err)
 	}
 
-	if err := pem.Encode(out, &pem.Block{Type: "PRIVATE KEY", Bytes: marshalledKey}); err != nil {
+	block := &pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: marshalledKey,
+		Headers: map[string]string{
+			signatureKeyPublishedAtHeader: publishedAt.UTC().Format(time.RFC3339Nano),
+			signatureKeyActivatesAtHeader: activatesAt.UTC().Format(time.RFC3339Nano),
+		},
+	}
+
+	if err := pem.Encode(out, block); err != nil {
 		return goerrors.Join(ErrEncodeSignatureKey, err)
 	}
 
 	return nil
 }
 
-func unmarshalPrivateKey(data []byte) (ed25519.PrivateKey, error) {
+// unmarshalPrivateKey decodes a key written by writeKeyToOutput. Keys written before their dates were stored in
+// the PEM headers use the fallback date (usually the modification date of the file) instead, and are active as soon
+// as they are published.
+func unmarshalPrivateKey(data []byte, fallback time.Time) (*SecretKeyModel, error) {
 	block, _ := pem.Decode(data)
 	if block == nil {
 		return nil, ErrInvalidSignatureKeyFileContent
@@ -100,5 +126,20 @@ func unmarshalPrivateKey(data []byte) (ed25519.PrivateKey, error) {
 		return nil, ErrInvalidSignatureKeyFileContent
 	}
 
-	return key, nil
+	model := &SecretKeyModel{Key: key, Date: fallback, ActivatesAt: fallback}
+
+	if value, ok := block.Headers[signatureKeyPublishedAtHeader]; ok {
+		if model.Date, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			return nil, goerrors.Join(ErrInvalidSignatureKeyHeader, err)
+		}
+		model.ActivatesAt = model.Date
+	}
+
+	if value, ok := block.Headers[signatureKeyActivatesAtHeader]; ok {
+		if model.ActivatesAt, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			return nil, goerrors.Join(ErrInvalidSignatureKeyHeader, err)
+		}
+	}
+
+	return model, nil
 }
@@ -1,6 +1,7 @@
 package dao_test
 
 import (
+	"fmt"
 	"github.com/a-novel/auth-service/pkg/dao"
 	"time"
 )
@@ -8,6 +9,8 @@ import (
 var (
 	baseTime   = time.Date(2020, time.May, 4, 8, 0, 0, 0, time.UTC)
 	updateTime = time.Date(2020, time.May, 4, 9, 0, 0, 0, time.UTC)
+
+	fooErr = fmt.Errorf("foo")
 )
 
 func MustParseEmail(email string) dao.Email {
@@ -25,3 +28,10 @@ func MustParseEmailWithValidation(email, validationCode string) dao.Email {
 
 	return daoEmail
 }
+
+func MustParseEmailWithValidationAt(email, validationCode string, issuedAt time.Time) dao.Email {
+	daoEmail := MustParseEmailWithValidation(email, validationCode)
+	daoEmail.ValidationIssuedAt = &issuedAt
+
+	return daoEmail
+}
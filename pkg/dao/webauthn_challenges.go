@@ -0,0 +1,88 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+const (
+	// WebAuthnCeremonyRegistration is the ceremony that registers a new passkey.
+	WebAuthnCeremonyRegistration = "registration"
+	// WebAuthnCeremonyLogin is the ceremony that logs a user in, without password.
+	WebAuthnCeremonyLogin = "login"
+	// WebAuthnCeremonyMFA is the ceremony that verifies the second factor of a user who passed the password check.
+	WebAuthnCeremonyMFA = "mfa"
+)
+
+type WebAuthnChallengesRepository interface {
+	// Create stores a new challenge.
+	Create(ctx context.Context, data *WebAuthnChallengeModelCore, id uuid.UUID, now time.Time) (*WebAuthnChallengeModel, error)
+	// Use marks a challenge as used, and returns it. Because a challenge can only be used once, this method fails with
+	// bunovel.ErrNotFound if the challenge was already used, even if the operations happen concurrently.
+	Use(ctx context.Context, id uuid.UUID, now time.Time) (*WebAuthnChallengeModel, error)
+}
+
+type WebAuthnChallengeModel struct {
+	bun.BaseModel `bun:"table:webauthn_challenges"`
+	bunovel.Metadata
+	WebAuthnChallengeModelCore
+}
+
+type WebAuthnChallengeModelCore struct {
+	// UserID is the ID of the user the ceremony was started for. It is empty for passwordless logins.
+	UserID *uuid.UUID `bun:"user_id"`
+	// Ceremony is the type of ceremony the challenge was issued for. A challenge issued for a given ceremony cannot
+	// be used for another one.
+	Ceremony string `bun:"ceremony"`
+	// Challenge is the random value signed by the authenticator.
+	Challenge []byte `bun:"challenge"`
+	// ExpiresAt is the date after which the challenge can no longer be used.
+	ExpiresAt time.Time `bun:"expires_at"`
+	// UsedAt is set once the challenge has been used.
+	UsedAt *time.Time `bun:"used_at"`
+}
+
+func NewWebAuthnChallengesRepository(db bun.IDB) WebAuthnChallengesRepository {
+	return &webAuthnChallengesRepositoryImpl{db: db}
+}
+
+type webAuthnChallengesRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *webAuthnChallengesRepositoryImpl) Create(ctx context.Context, data *WebAuthnChallengeModelCore, id uuid.UUID, now time.Time) (*WebAuthnChallengeModel, error) {
+	model := &WebAuthnChallengeModel{Metadata: bunovel.NewMetadata(id, now, nil), WebAuthnChallengeModelCore: *data}
+
+	if _, err := repository.db.NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *webAuthnChallengesRepositoryImpl) Use(ctx context.Context, id uuid.UUID, now time.Time) (*WebAuthnChallengeModel, error) {
+	model := &WebAuthnChallengeModel{
+		Metadata:                   bunovel.NewMetadata(id, time.Time{}, &now),
+		WebAuthnChallengeModelCore: WebAuthnChallengeModelCore{UsedAt: &now},
+	}
+
+	res, err := repository.db.NewUpdate().Model(model).
+		WherePK().
+		// The check happens in the same statement as the update, so two concurrent calls cannot both succeed.
+		Where("used_at IS NULL").
+		Column("used_at", "updated_at").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	if err = bunovel.ForceRowsUpdate(res); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
@@ -0,0 +1,94 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/migrations"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+var webAuthnChallengesFixtures = []*dao.WebAuthnChallengeModel{
+	{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		WebAuthnChallengeModelCore: dao.WebAuthnChallengeModelCore{
+			UserID:    lo.ToPtr(goframework.NumberUUID(10)),
+			Ceremony:  dao.WebAuthnCeremonyRegistration,
+			Challenge: []byte("challenge-1"),
+			ExpiresAt: baseTime.Add(5 * time.Minute),
+		},
+	},
+	{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, &baseTime),
+		WebAuthnChallengeModelCore: dao.WebAuthnChallengeModelCore{
+			Ceremony:  dao.WebAuthnCeremonyLogin,
+			Challenge: []byte("challenge-2"),
+			ExpiresAt: baseTime.Add(5 * time.Minute),
+			UsedAt:    &baseTime,
+		},
+	},
+}
+
+func TestWebAuthnChallengesRepository_Create(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, webAuthnChallengesFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewWebAuthnChallengesRepository(tx)
+
+		data := &dao.WebAuthnChallengeModelCore{
+			Ceremony:  dao.WebAuthnCeremonyLogin,
+			Challenge: []byte("challenge-3"),
+			ExpiresAt: updateTime.Add(5 * time.Minute),
+		}
+
+		res, err := repository.Create(ctx, data, goframework.NumberUUID(3), updateTime)
+		require.NoError(t, err)
+		require.Equal(t, &dao.WebAuthnChallengeModel{
+			Metadata:                   bunovel.NewMetadata(goframework.NumberUUID(3), updateTime, nil),
+			WebAuthnChallengeModelCore: *data,
+		}, res)
+	})
+	require.NoError(t, err)
+}
+
+func TestWebAuthnChallengesRepository_Use(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	err := bunovel.RunTransactionalTest(db, webAuthnChallengesFixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewWebAuthnChallengesRepository(tx)
+
+		res, err := repository.Use(ctx, goframework.NumberUUID(1), updateTime)
+		require.NoError(t, err)
+		require.Equal(t, &dao.WebAuthnChallengeModel{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &updateTime),
+			WebAuthnChallengeModelCore: dao.WebAuthnChallengeModelCore{
+				UserID:    lo.ToPtr(goframework.NumberUUID(10)),
+				Ceremony:  dao.WebAuthnCeremonyRegistration,
+				Challenge: []byte("challenge-1"),
+				ExpiresAt: baseTime.Add(5 * time.Minute),
+				UsedAt:    &updateTime,
+			},
+		}, res)
+
+		// A challenge can only be used once.
+		_, err = repository.Use(ctx, goframework.NumberUUID(1), updateTime)
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+
+		_, err = repository.Use(ctx, goframework.NumberUUID(2), updateTime)
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+
+		_, err = repository.Use(ctx, goframework.NumberUUID(3), updateTime)
+		require.ErrorIs(t, err, bunovel.ErrNotFound)
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+type AdminCancelNewEmailHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewAdminCancelNewEmailHandler(service services.AdminCancelNewEmailService) AdminCancelNewEmailHandler {
+	return &adminCancelNewEmailHandlerImpl{
+		service: service,
+	}
+}
+
+type adminCancelNewEmailHandlerImpl struct {
+	service services.AdminCancelNewEmailService
+}
+
+func (h *adminCancelNewEmailHandlerImpl) Handle(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.AdminCancelNewEmail(c, id, c.GetString(InternalCallerKey), time.Now()); err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+			{bunovel.ErrNotFound, http.StatusNotFound},
+		}, false)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
@@ -0,0 +1,91 @@
+package handlers_test
+
+import (
+	"github.com/a-novel/auth-service/pkg/handlers"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminCancelNewEmailHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		id     string
+		caller string
+
+		shouldCallService bool
+		serviceErr        error
+
+		expectStatus int
+	}{
+		{
+			name:              "Success",
+			id:                goframework.NumberUUID(1).String(),
+			caller:            "support",
+			shouldCallService: true,
+			expectStatus:      http.StatusNoContent,
+		},
+		{
+			name:         "Error/BadID",
+			id:           "not-an-id",
+			caller:       "support",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:              "Error/InvalidEntity",
+			id:                goframework.NumberUUID(1).String(),
+			caller:            "support",
+			shouldCallService: true,
+			serviceErr:        goframework.ErrInvalidEntity,
+			expectStatus:      http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "Error/NotFound",
+			id:                goframework.NumberUUID(1).String(),
+			caller:            "support",
+			shouldCallService: true,
+			serviceErr:        bunovel.ErrNotFound,
+			expectStatus:      http.StatusNotFound,
+		},
+		{
+			name:              "Error/InternalError",
+			id:                goframework.NumberUUID(1).String(),
+			caller:            "support",
+			shouldCallService: true,
+			serviceErr:        fooErr,
+			expectStatus:      http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewAdminCancelNewEmailService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("DELETE", "/", nil)
+			c.Params = gin.Params{{Key: "id", Value: d.id}}
+			c.Set(handlers.InternalCallerKey, d.caller)
+
+			if d.shouldCallService {
+				service.
+					On("AdminCancelNewEmail", c, goframework.NumberUUID(1), d.caller, mock.Anything).
+					Return(d.serviceErr)
+			}
+
+			handler := handlers.NewAdminCancelNewEmailHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+
+			service.AssertExpectations(t)
+		})
+	}
+}
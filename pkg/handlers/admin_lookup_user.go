@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type AdminLookupUserHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewAdminLookupUserHandler(service services.AdminLookupUserService) AdminLookupUserHandler {
+	return &adminLookupUserHandlerImpl{
+		service: service,
+	}
+}
+
+type adminLookupUserHandlerImpl struct {
+	service services.AdminLookupUserService
+}
+
+func (h *adminLookupUserHandlerImpl) Handle(c *gin.Context) {
+	query := new(models.AdminLookupUserQuery)
+	if err := c.BindQuery(query); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := h.service.AdminLookupUser(c, *query, c.GetString(InternalCallerKey), time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidEntity, http.StatusBadRequest},
+			{bunovel.ErrNotFound, http.StatusNotFound},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
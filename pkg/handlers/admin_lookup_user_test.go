@@ -0,0 +1,147 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAdminLookupUserHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		url    string
+		caller string
+
+		shouldCallService     bool
+		shouldCallServiceWith models.AdminLookupUserQuery
+		serviceResp           *models.AdminUser
+		serviceErr            error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:              "Success",
+			url:               "/?email=user@domain.com",
+			caller:            "support",
+			shouldCallService: true,
+			shouldCallServiceWith: models.AdminLookupUserQuery{
+				Email: "user@domain.com",
+			},
+			serviceResp: &models.AdminUser{
+				ID:        goframework.NumberUUID(1),
+				CreatedAt: baseTime,
+				Credentials: models.AdminCredentials{
+					Email:                  "user@domain.com",
+					PendingEmailValidation: true,
+				},
+				Identity: models.Identity{
+					FirstName: "Elon",
+					LastName:  "Bezos",
+					Sex:       models.SexMale,
+					Birthday:  baseTime,
+				},
+				Profile: models.Profile{
+					Username: "username",
+					Slug:     "slug",
+				},
+			},
+			expect: map[string]interface{}{
+				"id":        goframework.NumberUUID(1).String(),
+				"createdAt": baseTime.Format(time.RFC3339),
+				"credentials": map[string]interface{}{
+					"email":                     "user@domain.com",
+					"validated":                 false,
+					"pendingEmailValidation":    true,
+					"pendingNewEmailValidation": false,
+					"pendingPasswordReset":      false,
+				},
+				"identity": map[string]interface{}{
+					"firstName": "Elon",
+					"lastName":  "Bezos",
+					"sex":       "male",
+					"birthday":  baseTime.Format(time.RFC3339),
+				},
+				"profile": map[string]interface{}{
+					"username": "username",
+					"slug":     "slug",
+				},
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:              "Error/InvalidEntity",
+			url:               "/?email=user@domain.com&slug=slug",
+			caller:            "support",
+			shouldCallService: true,
+			shouldCallServiceWith: models.AdminLookupUserQuery{
+				Email: "user@domain.com",
+				Slug:  "slug",
+			},
+			serviceErr:   goframework.ErrInvalidEntity,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:              "Error/NotFound",
+			url:               "/?slug=slug",
+			caller:            "support",
+			shouldCallService: true,
+			shouldCallServiceWith: models.AdminLookupUserQuery{
+				Slug: "slug",
+			},
+			serviceErr:   bunovel.ErrNotFound,
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:              "Error/InternalError",
+			url:               "/?id=" + goframework.NumberUUID(1).String(),
+			caller:            "support",
+			shouldCallService: true,
+			shouldCallServiceWith: models.AdminLookupUserQuery{
+				ID: goframework.NumberUUID(1).String(),
+			},
+			serviceErr:   fooErr,
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewAdminLookupUserService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", d.url, nil)
+			c.Set(handlers.InternalCallerKey, d.caller)
+
+			if d.shouldCallService {
+				service.
+					On("AdminLookupUser", c, d.shouldCallServiceWith, d.caller, mock.Anything).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewAdminLookupUserHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
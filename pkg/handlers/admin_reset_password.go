@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+type AdminResetPasswordHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewAdminResetPasswordHandler(service services.AdminResetPasswordService) AdminResetPasswordHandler {
+	return &adminResetPasswordHandlerImpl{
+		service: service,
+	}
+}
+
+type adminResetPasswordHandlerImpl struct {
+	service services.AdminResetPasswordService
+}
+
+func (h *adminResetPasswordHandlerImpl) Handle(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	deferred, err := h.service.AdminResetPassword(c, id, c.GetString(InternalCallerKey), time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+			{bunovel.ErrNotFound, http.StatusNotFound},
+		}, false)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusAccepted)
+
+	if deferred != nil {
+		if err := deferred(); err != nil {
+			_ = c.Error(err)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+type AdminUpdateProfileHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewAdminUpdateProfileHandler(service services.AdminUpdateProfileService) AdminUpdateProfileHandler {
+	return &adminUpdateProfileHandlerImpl{
+		service: service,
+	}
+}
+
+type adminUpdateProfileHandlerImpl struct {
+	service services.AdminUpdateProfileService
+}
+
+func (h *adminUpdateProfileHandlerImpl) Handle(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	form := new(models.UpdateProfileForm)
+	if err := c.BindJSON(form); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.AdminUpdateProfile(c, id, *form, c.GetString(InternalCallerKey), time.Now()); err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+			{bunovel.ErrNotFound, http.StatusNotFound},
+		}, false)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
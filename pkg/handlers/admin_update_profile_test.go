@@ -0,0 +1,137 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminUpdateProfileHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		id     string
+		body   interface{}
+		caller string
+
+		shouldCallService     bool
+		shouldCallServiceWith models.UpdateProfileForm
+		serviceErr            error
+
+		expectStatus int
+	}{
+		{
+			name: "Success",
+			id:   goframework.NumberUUID(1).String(),
+			body: map[string]interface{}{
+				"slug":     "slug",
+				"username": "username",
+			},
+			caller:            "support",
+			shouldCallService: true,
+			shouldCallServiceWith: models.UpdateProfileForm{
+				Slug:     "slug",
+				Username: "username",
+			},
+			expectStatus: http.StatusNoContent,
+		},
+		{
+			name: "Error/BadID",
+			id:   "not-an-id",
+			body: map[string]interface{}{
+				"slug": "slug",
+			},
+			caller:       "support",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Error/BadForm",
+			id:   goframework.NumberUUID(1).String(),
+			body: map[string]interface{}{
+				"slug": 123456,
+			},
+			caller:       "support",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Error/InvalidEntity",
+			id:   goframework.NumberUUID(1).String(),
+			body: map[string]interface{}{
+				"slug": "slug",
+			},
+			caller:            "support",
+			shouldCallService: true,
+			shouldCallServiceWith: models.UpdateProfileForm{
+				Slug: "slug",
+			},
+			serviceErr:   goframework.ErrInvalidEntity,
+			expectStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Error/NotFound",
+			id:   goframework.NumberUUID(1).String(),
+			body: map[string]interface{}{
+				"slug": "slug",
+			},
+			caller:            "support",
+			shouldCallService: true,
+			shouldCallServiceWith: models.UpdateProfileForm{
+				Slug: "slug",
+			},
+			serviceErr:   bunovel.ErrNotFound,
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name: "Error/InternalError",
+			id:   goframework.NumberUUID(1).String(),
+			body: map[string]interface{}{
+				"slug": "slug",
+			},
+			caller:            "support",
+			shouldCallService: true,
+			shouldCallServiceWith: models.UpdateProfileForm{
+				Slug: "slug",
+			},
+			serviceErr:   fooErr,
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewAdminUpdateProfileService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("PATCH", "/", bytes.NewReader(mrshBody))
+			c.Params = gin.Params{{Key: "id", Value: d.id}}
+			c.Set(handlers.InternalCallerKey, d.caller)
+
+			if d.shouldCallService {
+				service.
+					On("AdminUpdateProfile", c, goframework.NumberUUID(1), d.shouldCallServiceWith, d.caller, mock.Anything).
+					Return(d.serviceErr)
+			}
+
+			handler := handlers.NewAdminUpdateProfileHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+
+			service.AssertExpectations(t)
+		})
+	}
+}
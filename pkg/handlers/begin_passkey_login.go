@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type BeginPasskeyLoginHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewBeginPasskeyLoginHandler(service services.BeginPasskeyLoginService) BeginPasskeyLoginHandler {
+	return &beginPasskeyLoginHandlerImpl{
+		service: service,
+	}
+}
+
+type beginPasskeyLoginHandlerImpl struct {
+	service services.BeginPasskeyLoginService
+}
+
+func (h *beginPasskeyLoginHandlerImpl) Handle(c *gin.Context) {
+	options, err := h.service.BeginPasskeyLogin(c, time.Now())
+	if err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
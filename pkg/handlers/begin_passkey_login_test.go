@@ -0,0 +1,76 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBeginPasskeyLoginHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		serviceResp *models.PasskeyRequestOptions
+		serviceErr  error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name: "Success",
+			serviceResp: &models.PasskeyRequestOptions{
+				Challenge:        "challenge",
+				Timeout:          300000,
+				RPID:             "domain.com",
+				AllowCredentials: []models.PasskeyCredentialDescriptor{},
+				UserVerification: "required",
+			},
+			expect: map[string]interface{}{
+				"challenge":        "challenge",
+				"timeout":          float64(300000),
+				"rpId":             "domain.com",
+				"allowCredentials": []interface{}{},
+				"userVerification": "required",
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "Error/ErrFoo",
+			serviceErr:   fooErr,
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewBeginPasskeyLoginService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", nil)
+
+			service.
+				On("BeginPasskeyLogin", c, mock.Anything).
+				Return(d.serviceResp, d.serviceErr)
+
+			handler := handlers.NewBeginPasskeyLoginHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
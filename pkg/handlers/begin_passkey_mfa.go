@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type BeginPasskeyMFAHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewBeginPasskeyMFAHandler(service services.BeginPasskeyMFAService) BeginPasskeyMFAHandler {
+	return &beginPasskeyMFAHandlerImpl{service: service}
+}
+
+type beginPasskeyMFAHandlerImpl struct {
+	service services.BeginPasskeyMFAService
+}
+
+func (h *beginPasskeyMFAHandlerImpl) Handle(c *gin.Context) {
+	request := new(models.MFAChallengeForm)
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	options, err := h.service.BeginPasskeyMFA(c, request.Challenge, getClientInfo(c), time.Now())
+	if err != nil {
+		setRetryAfter(c, err)
+
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrTooManyAttempts, http.StatusTooManyRequests},
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
@@ -0,0 +1,152 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBeginPasskeyMFAHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		body interface{}
+
+		shouldCallService              bool
+		shouldCallServiceWithChallenge string
+
+		serviceResp *models.PasskeyRequestOptions
+		serviceErr  error
+
+		expect           interface{}
+		expectStatus     int
+		expectRetryAfter string
+	}{
+		{
+			name: "Success",
+			body: map[string]interface{}{
+				"challenge": "challenge",
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithChallenge: "challenge",
+			serviceResp: &models.PasskeyRequestOptions{
+				Challenge: "webauthn-challenge",
+				Timeout:   300000,
+				RPID:      "domain.com",
+				AllowCredentials: []models.PasskeyCredentialDescriptor{
+					{Type: "public-key", ID: "credential-id", Transports: []string{"internal"}},
+				},
+				UserVerification: "required",
+			},
+			expect: map[string]interface{}{
+				"challenge": "webauthn-challenge",
+				"timeout":   float64(300000),
+				"rpId":      "domain.com",
+				"allowCredentials": []interface{}{
+					map[string]interface{}{
+						"type":       "public-key",
+						"id":         "credential-id",
+						"transports": []interface{}{"internal"},
+					},
+				},
+				"userVerification": "required",
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name: "Error/BadForm",
+			body: map[string]interface{}{
+				"challenge": 123,
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Error/ErrInvalidCredentials",
+			body: map[string]interface{}{
+				"challenge": "challenge",
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithChallenge: "challenge",
+			serviceErr:                     goframework.ErrInvalidCredentials,
+			expectStatus:                   http.StatusForbidden,
+		},
+		{
+			name: "Error/TooManyAttempts",
+			body: map[string]interface{}{
+				"challenge": "challenge",
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithChallenge: "challenge",
+			serviceErr:                     &services.TooManyAttemptsError{RetryAfter: 1500 * time.Millisecond},
+			expectStatus:                   http.StatusTooManyRequests,
+			expectRetryAfter:               "2",
+		},
+		{
+			name: "Error/ErrInvalidEntity",
+			body: map[string]interface{}{
+				"challenge": "challenge",
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithChallenge: "challenge",
+			serviceErr:                     goframework.ErrInvalidEntity,
+			expectStatus:                   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Error/ErrFoo",
+			body: map[string]interface{}{
+				"challenge": "challenge",
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithChallenge: "challenge",
+			serviceErr:                     fooErr,
+			expectStatus:                   http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewBeginPasskeyMFAService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("User-Agent", "Mozilla/5.0")
+
+			if d.shouldCallService {
+				service.
+					On("BeginPasskeyMFA", c, d.shouldCallServiceWithChallenge, models.ClientInfo{
+						UserAgent: "Mozilla/5.0",
+						IP:        "192.0.2.1",
+					}, mock.Anything).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewBeginPasskeyMFAHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			require.Equal(t, d.expectRetryAfter, w.Header().Get("Retry-After"))
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
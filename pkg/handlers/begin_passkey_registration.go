@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type BeginPasskeyRegistrationHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewBeginPasskeyRegistrationHandler(service services.BeginPasskeyRegistrationService) BeginPasskeyRegistrationHandler {
+	return &beginPasskeyRegistrationHandlerImpl{
+		service: service,
+	}
+}
+
+type beginPasskeyRegistrationHandlerImpl struct {
+	service services.BeginPasskeyRegistrationService
+}
+
+func (h *beginPasskeyRegistrationHandlerImpl) Handle(c *gin.Context) {
+	request := new(models.ReauthenticationForm)
+	token := c.GetHeader("Authorization")
+
+	if err := bindOptionalJSON(c, request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	options, err := h.service.BeginPasskeyRegistration(c, token, request.Password, getClientInfo(c), time.Now())
+	if err != nil {
+		setRetryAfter(c, err)
+
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrTooManyAttempts, http.StatusTooManyRequests},
+			{services.ErrStepUpRequired, http.StatusUnauthorized},
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
@@ -0,0 +1,150 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBeginPasskeyRegistrationHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+		body          interface{}
+
+		shouldCallServiceWithPassword string
+
+		serviceResp *models.PasskeyCreationOptions
+		serviceErr  error
+
+		expect           interface{}
+		expectStatus     int
+		expectRetryAfter string
+	}{
+		{
+			name:          "Success",
+			authorization: "Bearer my-token",
+			serviceResp: &models.PasskeyCreationOptions{
+				Challenge: "challenge",
+				RP:        models.PasskeyRelyingParty{ID: "domain.com", Name: "Domain"},
+				User:      models.PasskeyUser{ID: "user-id", Name: "user@domain.com", DisplayName: "user@domain.com"},
+				PubKeyCredParams: []models.PasskeyCredentialParameters{
+					{Type: "public-key", Alg: -7},
+				},
+				Timeout:            300000,
+				ExcludeCredentials: []models.PasskeyCredentialDescriptor{},
+				AuthenticatorSelection: models.PasskeyAuthenticatorSelection{
+					ResidentKey:      "required",
+					UserVerification: "required",
+				},
+				Attestation: "none",
+			},
+			expect: map[string]interface{}{
+				"challenge": "challenge",
+				"rp":        map[string]interface{}{"id": "domain.com", "name": "Domain"},
+				"user": map[string]interface{}{
+					"id":          "user-id",
+					"name":        "user@domain.com",
+					"displayName": "user@domain.com",
+				},
+				"pubKeyCredParams": []interface{}{
+					map[string]interface{}{"type": "public-key", "alg": float64(-7)},
+				},
+				"timeout":            float64(300000),
+				"excludeCredentials": []interface{}{},
+				"authenticatorSelection": map[string]interface{}{
+					"residentKey":      "required",
+					"userVerification": "required",
+				},
+				"attestation": "none",
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:                          "Success/WithPassword",
+			authorization:                 "Bearer my-token",
+			body:                          map[string]interface{}{"password": "password"},
+			shouldCallServiceWithPassword: "password",
+			serviceResp:                   &models.PasskeyCreationOptions{},
+			expectStatus:                  http.StatusOK,
+		},
+		{
+			name:             "Error/ErrTooManyAttempts",
+			authorization:    "Bearer my-token",
+			serviceErr:       &services.TooManyAttemptsError{RetryAfter: 1500 * time.Millisecond},
+			expectStatus:     http.StatusTooManyRequests,
+			expectRetryAfter: "2",
+		},
+		{
+			name:          "Error/ErrStepUpRequired",
+			authorization: "Bearer my-token",
+			serviceErr:    goerrors.Join(goframework.ErrInvalidCredentials, services.ErrStepUpRequired),
+			expectStatus:  http.StatusUnauthorized,
+		},
+		{
+			name:          "Error/ErrInvalidCredentials",
+			authorization: "Bearer my-token",
+			serviceErr:    goframework.ErrInvalidCredentials,
+			expectStatus:  http.StatusForbidden,
+		},
+		{
+			name:          "Error/ErrFoo",
+			authorization: "Bearer my-token",
+			serviceErr:    fooErr,
+			expectStatus:  http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewBeginPasskeyRegistrationService(t)
+
+			var body io.Reader
+			if d.body != nil {
+				mrshBody, err := json.Marshal(d.body)
+				require.NoError(t, err)
+				body = bytes.NewReader(mrshBody)
+			}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", body)
+			c.Request.Header.Set("User-Agent", "Mozilla/5.0")
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			service.
+				On("BeginPasskeyRegistration", c, d.authorization, d.shouldCallServiceWithPassword, models.ClientInfo{
+					UserAgent: "Mozilla/5.0",
+					IP:        "192.0.2.1",
+				}, mock.Anything).
+				Return(d.serviceResp, d.serviceErr)
+
+			handler := handlers.NewBeginPasskeyRegistrationHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			require.Equal(t, d.expectRetryAfter, w.Header().Get("Retry-After"))
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
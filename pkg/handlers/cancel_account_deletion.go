@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type CancelAccountDeletionHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewCancelAccountDeletionHandler(service services.CancelAccountDeletionService) CancelAccountDeletionHandler {
+	return &cancelAccountDeletionHandlerImpl{
+		service: service,
+	}
+}
+
+type cancelAccountDeletionHandlerImpl struct {
+	service services.CancelAccountDeletionService
+}
+
+func (h *cancelAccountDeletionHandlerImpl) Handle(c *gin.Context) {
+	query := new(models.ValidateEmailQuery)
+	if err := c.BindQuery(query); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.CancelAccountDeletion(c, query.ID.Value(), query.Code, time.Now()); err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrValidationCodeExpired, http.StatusGone},
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{bunovel.ErrNotFound, http.StatusForbidden},
+		}, false)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
@@ -0,0 +1,93 @@
+package handlers_test
+
+import (
+	goerrors "errors"
+	"fmt"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCancelAccountDeletionHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		id   string
+		code string
+
+		shouldCallService bool
+		serviceErr        error
+
+		expectStatus int
+	}{
+		{
+			name:              "Success",
+			id:                goframework.NumberUUID(1).String(),
+			code:              "deletion-code",
+			shouldCallService: true,
+			expectStatus:      http.StatusNoContent,
+		},
+		{
+			name:              "Error/ErrValidationCodeExpired",
+			id:                goframework.NumberUUID(1).String(),
+			code:              "deletion-code",
+			shouldCallService: true,
+			serviceErr:        goerrors.Join(goframework.ErrInvalidCredentials, services.ErrValidationCodeExpired),
+			expectStatus:      http.StatusGone,
+		},
+		{
+			name:              "Error/ErrInvalidCredentials",
+			id:                goframework.NumberUUID(1).String(),
+			code:              "deletion-code",
+			shouldCallService: true,
+			serviceErr:        goerrors.Join(goframework.ErrInvalidCredentials, services.ErrInvalidValidationCode),
+			expectStatus:      http.StatusForbidden,
+		},
+		{
+			name:              "Error/ErrNotFound",
+			id:                goframework.NumberUUID(1).String(),
+			code:              "deletion-code",
+			shouldCallService: true,
+			serviceErr:        bunovel.ErrNotFound,
+			expectStatus:      http.StatusForbidden,
+		},
+		{
+			name:              "Error/InternalError",
+			id:                goframework.NumberUUID(1).String(),
+			code:              "deletion-code",
+			shouldCallService: true,
+			serviceErr:        fooErr,
+			expectStatus:      http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewCancelAccountDeletionService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", fmt.Sprintf("/?id=%s&code=%s", d.id, d.code), nil)
+
+			if d.shouldCallService {
+				service.On("CancelAccountDeletion", c, uuid.MustParse(d.id), d.code, mock.Anything).Return(d.serviceErr)
+			}
+
+			handler := handlers.NewCancelAccountDeletionHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type ConfirmTOTPHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewConfirmTOTPHandler(service services.ConfirmTOTPService) ConfirmTOTPHandler {
+	return &confirmTOTPHandlerImpl{
+		service: service,
+	}
+}
+
+type confirmTOTPHandlerImpl struct {
+	service services.ConfirmTOTPService
+}
+
+func (h *confirmTOTPHandlerImpl) Handle(c *gin.Context) {
+	request := new(models.SecondFactorForm)
+	token := c.GetHeader("Authorization")
+
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.ConfirmTOTP(c, token, request.Code, time.Now()); err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+			{services.ErrTOTPNotEnrolled, http.StatusNotFound},
+			{services.ErrTOTPAlreadyEnabled, http.StatusConflict},
+		}, false)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
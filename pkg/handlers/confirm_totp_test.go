@@ -0,0 +1,118 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConfirmTOTPHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+		body          interface{}
+
+		shouldCallService     bool
+		shouldCallServiceWith string
+		serviceErr            error
+
+		expectStatus int
+	}{
+		{
+			name:                  "Success",
+			authorization:         "Bearer my-token",
+			body:                  map[string]interface{}{"code": "123456"},
+			shouldCallService:     true,
+			shouldCallServiceWith: "123456",
+			expectStatus:          http.StatusNoContent,
+		},
+		{
+			name:          "Error/BadForm",
+			authorization: "Bearer my-token",
+			body:          map[string]interface{}{"code": 123456},
+			expectStatus:  http.StatusBadRequest,
+		},
+		{
+			name:                  "Error/ErrInvalidCredentials",
+			authorization:         "Bearer my-token",
+			body:                  map[string]interface{}{"code": "123456"},
+			shouldCallService:     true,
+			shouldCallServiceWith: "123456",
+			serviceErr:            goframework.ErrInvalidCredentials,
+			expectStatus:          http.StatusForbidden,
+		},
+		{
+			name:                  "Error/ErrInvalidEntity",
+			authorization:         "Bearer my-token",
+			body:                  map[string]interface{}{"code": "123456"},
+			shouldCallService:     true,
+			shouldCallServiceWith: "123456",
+			serviceErr:            goframework.ErrInvalidEntity,
+			expectStatus:          http.StatusUnprocessableEntity,
+		},
+		{
+			name:                  "Error/ErrTOTPNotEnrolled",
+			authorization:         "Bearer my-token",
+			body:                  map[string]interface{}{"code": "123456"},
+			shouldCallService:     true,
+			shouldCallServiceWith: "123456",
+			serviceErr:            services.ErrTOTPNotEnrolled,
+			expectStatus:          http.StatusNotFound,
+		},
+		{
+			name:                  "Error/ErrTOTPAlreadyEnabled",
+			authorization:         "Bearer my-token",
+			body:                  map[string]interface{}{"code": "123456"},
+			shouldCallService:     true,
+			shouldCallServiceWith: "123456",
+			serviceErr:            services.ErrTOTPAlreadyEnabled,
+			expectStatus:          http.StatusConflict,
+		},
+		{
+			name:                  "Error/ErrFoo",
+			authorization:         "Bearer my-token",
+			body:                  map[string]interface{}{"code": "123456"},
+			shouldCallService:     true,
+			shouldCallServiceWith: "123456",
+			serviceErr:            fooErr,
+			expectStatus:          http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewConfirmTOTPService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("PATCH", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			if d.shouldCallService {
+				service.
+					On("ConfirmTOTP", c, d.authorization, d.shouldCallServiceWith, mock.Anything).
+					Return(d.serviceErr)
+			}
+
+			handler := handlers.NewConfirmTOTPHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+
+			service.AssertExpectations(t)
+		})
+	}
+}
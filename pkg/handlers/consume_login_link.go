@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type ConsumeLoginLinkHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewConsumeLoginLinkHandler(service services.ConsumeLoginLinkService) ConsumeLoginLinkHandler {
+	return &consumeLoginLinkHandlerImpl{service: service}
+}
+
+type consumeLoginLinkHandlerImpl struct {
+	service services.ConsumeLoginLinkService
+}
+
+func (h *consumeLoginLinkHandlerImpl) Handle(c *gin.Context) {
+	request := new(models.ConsumeLoginLinkForm)
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.service.ConsumeLoginLink(c, request.ID, request.Code, getClientInfo(c), time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrAccountLocked, http.StatusLocked},
+			{services.ErrAccountDeleted, http.StatusGone},
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	// The user must verify a second factor before getting a session.
+	if token.MFAChallenge != "" {
+		c.JSON(http.StatusOK, gin.H{"mfaChallenge": token.MFAChallenge})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token.TokenRaw, "refreshToken": token.RefreshToken})
+}
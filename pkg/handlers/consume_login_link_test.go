@@ -0,0 +1,134 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConsumeLoginLinkHandler(t *testing.T) {
+	body := map[string]interface{}{
+		"id":   goframework.NumberUUID(1).String(),
+		"code": "code",
+	}
+
+	data := []struct {
+		name string
+
+		body interface{}
+
+		shouldCallService bool
+
+		serviceResp *models.UserTokenStatus
+		serviceErr  error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:              "Success",
+			body:              body,
+			shouldCallService: true,
+			serviceResp:       &models.UserTokenStatus{TokenRaw: "token", RefreshToken: "refresh-token"},
+			expect:            map[string]interface{}{"token": "token", "refreshToken": "refresh-token"},
+			expectStatus:      http.StatusOK,
+		},
+		{
+			name:              "Success/MFARequired",
+			body:              body,
+			shouldCallService: true,
+			serviceResp:       &models.UserTokenStatus{MFAChallenge: "mfa-challenge"},
+			expect:            map[string]interface{}{"mfaChallenge": "mfa-challenge"},
+			expectStatus:      http.StatusOK,
+		},
+		{
+			name: "Error/BadForm",
+			body: map[string]interface{}{
+				"id":   "not-an-id",
+				"code": "code",
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:              "Error/ErrAccountLocked",
+			body:              body,
+			shouldCallService: true,
+			serviceErr:        goerrors.Join(goframework.ErrInvalidCredentials, services.ErrAccountLocked),
+			expectStatus:      http.StatusLocked,
+		},
+		{
+			name:              "Error/ErrAccountDeleted",
+			body:              body,
+			shouldCallService: true,
+			serviceErr:        goerrors.Join(goframework.ErrInvalidCredentials, services.ErrAccountDeleted),
+			expectStatus:      http.StatusGone,
+		},
+		{
+			name:              "Error/ErrInvalidCredentials",
+			body:              body,
+			shouldCallService: true,
+			serviceErr:        goframework.ErrInvalidCredentials,
+			expectStatus:      http.StatusForbidden,
+		},
+		{
+			name:              "Error/ErrInvalidEntity",
+			body:              body,
+			shouldCallService: true,
+			serviceErr:        goframework.ErrInvalidEntity,
+			expectStatus:      http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "Error/ErrFoo",
+			body:              body,
+			shouldCallService: true,
+			serviceErr:        fooErr,
+			expectStatus:      http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewConsumeLoginLinkService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("User-Agent", "Mozilla/5.0")
+
+			if d.shouldCallService {
+				service.
+					On("ConsumeLoginLink", c, goframework.NumberUUID(1), "code", models.ClientInfo{
+						UserAgent: "Mozilla/5.0",
+						IP:        "192.0.2.1",
+					}, mock.Anything).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewConsumeLoginLinkHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
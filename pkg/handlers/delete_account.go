@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type DeleteAccountHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewDeleteAccountHandler(service services.DeleteAccountService) DeleteAccountHandler {
+	return &deleteAccountHandlerImpl{
+		service: service,
+	}
+}
+
+type deleteAccountHandlerImpl struct {
+	service services.DeleteAccountService
+}
+
+func (h *deleteAccountHandlerImpl) Handle(c *gin.Context) {
+	request := new(models.ReauthenticationForm)
+	token := c.GetHeader("Authorization")
+
+	if err := bindOptionalJSON(c, request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	deferred, err := h.service.DeleteAccount(c, token, request.Password, getClientInfo(c), time.Now())
+	if err != nil {
+		setRetryAfter(c, err)
+
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrTooManyAttempts, http.StatusTooManyRequests},
+			{services.ErrStepUpRequired, http.StatusUnauthorized},
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+		}, false)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusAccepted)
+
+	if deferred != nil {
+		if err := deferred(); err != nil {
+			_ = c.Error(err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeleteAccountHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+		body          interface{}
+
+		shouldCallService             bool
+		shouldCallServiceWithPassword string
+		serviceErr                    error
+
+		expectStatus     int
+		expectRetryAfter string
+	}{
+		{
+			name:                          "Success",
+			authorization:                 "Bearer my-token",
+			body:                          map[string]interface{}{"password": "password"},
+			shouldCallService:             true,
+			shouldCallServiceWithPassword: "password",
+			expectStatus:                  http.StatusAccepted,
+		},
+		{
+			name:          "Error/BadForm",
+			authorization: "Bearer my-token",
+			body:          map[string]interface{}{"password": 123},
+			expectStatus:  http.StatusBadRequest,
+		},
+		{
+			name:              "Error/ErrTooManyAttempts",
+			authorization:     "Bearer my-token",
+			shouldCallService: true,
+			serviceErr:        &services.TooManyAttemptsError{RetryAfter: 1500 * time.Millisecond},
+			expectStatus:      http.StatusTooManyRequests,
+			expectRetryAfter:  "2",
+		},
+		{
+			name:              "Error/ErrStepUpRequired",
+			authorization:     "Bearer my-token",
+			shouldCallService: true,
+			serviceErr:        goerrors.Join(goframework.ErrInvalidCredentials, services.ErrStepUpRequired),
+			expectStatus:      http.StatusUnauthorized,
+		},
+		{
+			name:                          "Error/ErrInvalidCredentials",
+			authorization:                 "Bearer my-token",
+			body:                          map[string]interface{}{"password": "password"},
+			shouldCallService:             true,
+			shouldCallServiceWithPassword: "password",
+			serviceErr:                    goerrors.Join(goframework.ErrInvalidCredentials, services.ErrWrongPassword),
+			expectStatus:                  http.StatusForbidden,
+		},
+		{
+			name:                          "Error/InternalError",
+			authorization:                 "Bearer my-token",
+			body:                          map[string]interface{}{"password": "password"},
+			shouldCallService:             true,
+			shouldCallServiceWithPassword: "password",
+			serviceErr:                    fooErr,
+			expectStatus:                  http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewDeleteAccountService(t)
+
+			var body io.Reader
+			if d.body != nil {
+				mrshBody, err := json.Marshal(d.body)
+				require.NoError(t, err)
+				body = bytes.NewReader(mrshBody)
+			}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("DELETE", "/", body)
+			c.Request.Header.Set("User-Agent", "Mozilla/5.0")
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			if d.shouldCallService {
+				var deferred func() error
+				if d.serviceErr == nil {
+					deferred = func() error { return nil }
+				}
+
+				service.
+					On("DeleteAccount", c, d.authorization, d.shouldCallServiceWithPassword, models.ClientInfo{
+						UserAgent: "Mozilla/5.0",
+						IP:        "192.0.2.1",
+					}, mock.Anything).
+					Return(deferred, d.serviceErr)
+			}
+
+			handler := handlers.NewDeleteAccountHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			require.Equal(t, d.expectRetryAfter, w.Header().Get("Retry-After"))
+
+			service.AssertExpectations(t)
+		})
+	}
+}
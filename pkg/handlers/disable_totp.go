@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type DisableTOTPHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewDisableTOTPHandler(service services.DisableTOTPService) DisableTOTPHandler {
+	return &disableTOTPHandlerImpl{
+		service: service,
+	}
+}
+
+type disableTOTPHandlerImpl struct {
+	service services.DisableTOTPService
+}
+
+func (h *disableTOTPHandlerImpl) Handle(c *gin.Context) {
+	request := new(models.DisableTOTPForm)
+	token := c.GetHeader("Authorization")
+
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.DisableTOTP(c, token, request.Code, request.Password, getClientInfo(c), time.Now()); err != nil {
+		setRetryAfter(c, err)
+
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrTooManyAttempts, http.StatusTooManyRequests},
+			{services.ErrStepUpRequired, http.StatusUnauthorized},
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+			{services.ErrTOTPNotEnrolled, http.StatusNotFound},
+		}, false)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
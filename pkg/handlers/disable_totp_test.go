@@ -0,0 +1,147 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDisableTOTPHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+		body          interface{}
+
+		shouldCallService             bool
+		shouldCallServiceWith         string
+		shouldCallServiceWithPassword string
+		serviceErr                    error
+
+		expectStatus     int
+		expectRetryAfter string
+	}{
+		{
+			name:                  "Success",
+			authorization:         "Bearer my-token",
+			body:                  map[string]interface{}{"code": "123456"},
+			shouldCallService:     true,
+			shouldCallServiceWith: "123456",
+			expectStatus:          http.StatusNoContent,
+		},
+		{
+			name:                          "Success/WithPassword",
+			authorization:                 "Bearer my-token",
+			body:                          map[string]interface{}{"code": "123456", "password": "password"},
+			shouldCallService:             true,
+			shouldCallServiceWith:         "123456",
+			shouldCallServiceWithPassword: "password",
+			expectStatus:                  http.StatusNoContent,
+		},
+		{
+			name:                  "Error/ErrTooManyAttempts",
+			authorization:         "Bearer my-token",
+			body:                  map[string]interface{}{"code": "123456"},
+			shouldCallService:     true,
+			shouldCallServiceWith: "123456",
+			serviceErr:            &services.TooManyAttemptsError{RetryAfter: 1500 * time.Millisecond},
+			expectStatus:          http.StatusTooManyRequests,
+			expectRetryAfter:      "2",
+		},
+		{
+			name:                  "Error/ErrStepUpRequired",
+			authorization:         "Bearer my-token",
+			body:                  map[string]interface{}{"code": "123456"},
+			shouldCallService:     true,
+			shouldCallServiceWith: "123456",
+			serviceErr:            goerrors.Join(goframework.ErrInvalidCredentials, services.ErrStepUpRequired),
+			expectStatus:          http.StatusUnauthorized,
+		},
+		{
+			name:          "Error/BadForm",
+			authorization: "Bearer my-token",
+			body:          map[string]interface{}{"code": 123456},
+			expectStatus:  http.StatusBadRequest,
+		},
+		{
+			name:                  "Error/ErrInvalidCredentials",
+			authorization:         "Bearer my-token",
+			body:                  map[string]interface{}{"code": "123456"},
+			shouldCallService:     true,
+			shouldCallServiceWith: "123456",
+			serviceErr:            goframework.ErrInvalidCredentials,
+			expectStatus:          http.StatusForbidden,
+		},
+		{
+			name:                  "Error/ErrInvalidEntity",
+			authorization:         "Bearer my-token",
+			body:                  map[string]interface{}{"code": "123456"},
+			shouldCallService:     true,
+			shouldCallServiceWith: "123456",
+			serviceErr:            goframework.ErrInvalidEntity,
+			expectStatus:          http.StatusUnprocessableEntity,
+		},
+		{
+			name:                  "Error/ErrTOTPNotEnrolled",
+			authorization:         "Bearer my-token",
+			body:                  map[string]interface{}{"code": "123456"},
+			shouldCallService:     true,
+			shouldCallServiceWith: "123456",
+			serviceErr:            services.ErrTOTPNotEnrolled,
+			expectStatus:          http.StatusNotFound,
+		},
+		{
+			name:                  "Error/ErrFoo",
+			authorization:         "Bearer my-token",
+			body:                  map[string]interface{}{"code": "123456"},
+			shouldCallService:     true,
+			shouldCallServiceWith: "123456",
+			serviceErr:            fooErr,
+			expectStatus:          http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewDisableTOTPService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("DELETE", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("User-Agent", "Mozilla/5.0")
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			if d.shouldCallService {
+				service.
+					On("DisableTOTP", c, d.authorization, d.shouldCallServiceWith, d.shouldCallServiceWithPassword, models.ClientInfo{
+						UserAgent: "Mozilla/5.0",
+						IP:        "192.0.2.1",
+					}, mock.Anything).
+					Return(d.serviceErr)
+			}
+
+			handler := handlers.NewDisableTOTPHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			require.Equal(t, d.expectRetryAfter, w.Header().Get("Retry-After"))
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type DownloadDataExportHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewDownloadDataExportHandler(service services.DownloadDataExportService) DownloadDataExportHandler {
+	return &downloadDataExportHandlerImpl{
+		service: service,
+	}
+}
+
+type downloadDataExportHandlerImpl struct {
+	service services.DownloadDataExportService
+}
+
+func (h *downloadDataExportHandlerImpl) Handle(c *gin.Context) {
+	query := new(models.ValidateEmailQuery)
+	if err := c.BindQuery(query); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	archive, err := h.service.DownloadDataExport(c, query.ID.Value(), query.Code, time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrValidationCodeExpired, http.StatusGone},
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusBadRequest},
+		}, false)
+		return
+	}
+
+	sendDataExport(c, archive)
+}
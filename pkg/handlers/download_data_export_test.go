@@ -0,0 +1,101 @@
+package handlers_test
+
+import (
+	goerrors "errors"
+	"fmt"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDownloadDataExportHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		id   string
+		code string
+
+		shouldCallService bool
+		serviceResp       []byte
+		serviceErr        error
+
+		expect       []byte
+		expectStatus int
+	}{
+		{
+			name:              "Success",
+			id:                goframework.NumberUUID(1).String(),
+			code:              "download-code",
+			shouldCallService: true,
+			serviceResp:       []byte("archive"),
+			expect:            []byte("archive"),
+			expectStatus:      http.StatusOK,
+		},
+		{
+			name:              "Error/ErrValidationCodeExpired",
+			id:                goframework.NumberUUID(1).String(),
+			code:              "download-code",
+			shouldCallService: true,
+			serviceErr:        goerrors.Join(goframework.ErrInvalidCredentials, services.ErrValidationCodeExpired),
+			expectStatus:      http.StatusGone,
+		},
+		{
+			name:              "Error/ErrInvalidCredentials",
+			id:                goframework.NumberUUID(1).String(),
+			code:              "download-code",
+			shouldCallService: true,
+			serviceErr:        goerrors.Join(goframework.ErrInvalidCredentials, services.ErrInvalidDataExport),
+			expectStatus:      http.StatusForbidden,
+		},
+		{
+			name:              "Error/ErrInvalidEntity",
+			id:                goframework.NumberUUID(1).String(),
+			shouldCallService: true,
+			serviceErr:        goerrors.Join(goframework.ErrInvalidEntity, services.ErrInvalidDataExport),
+			expectStatus:      http.StatusBadRequest,
+		},
+		{
+			name:              "Error/InternalError",
+			id:                goframework.NumberUUID(1).String(),
+			code:              "download-code",
+			shouldCallService: true,
+			serviceErr:        fooErr,
+			expectStatus:      http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewDownloadDataExportService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", fmt.Sprintf("/?id=%s&code=%s", d.id, d.code), nil)
+
+			if d.shouldCallService {
+				service.
+					On("DownloadDataExport", c, uuid.MustParse(d.id), d.code, mock.Anything).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewDownloadDataExportHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				require.Equal(t, d.expect, w.Body.Bytes())
+				require.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type EnrollTOTPHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewEnrollTOTPHandler(service services.EnrollTOTPService) EnrollTOTPHandler {
+	return &enrollTOTPHandlerImpl{
+		service: service,
+	}
+}
+
+type enrollTOTPHandlerImpl struct {
+	service services.EnrollTOTPService
+}
+
+func (h *enrollTOTPHandlerImpl) Handle(c *gin.Context) {
+	request := new(models.ReauthenticationForm)
+	token := c.GetHeader("Authorization")
+
+	if err := bindOptionalJSON(c, request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	enrollment, err := h.service.EnrollTOTP(c, token, request.Password, getClientInfo(c), time.Now())
+	if err != nil {
+		setRetryAfter(c, err)
+
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrTooManyAttempts, http.StatusTooManyRequests},
+			{services.ErrStepUpRequired, http.StatusUnauthorized},
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{services.ErrTOTPAlreadyEnabled, http.StatusConflict},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// dataExportFileName is the name under which personal data archives are downloaded.
+const dataExportFileName = "personal-data.zip"
+
+type ExportUserDataHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewExportUserDataHandler(service services.ExportUserDataService) ExportUserDataHandler {
+	return &exportUserDataHandlerImpl{service: service}
+}
+
+type exportUserDataHandlerImpl struct {
+	service services.ExportUserDataService
+}
+
+// sendDataExport writes a personal data archive as a file download.
+func sendDataExport(c *gin.Context, archive []byte) {
+	c.Header("Content-Disposition", `attachment; filename="`+dataExportFileName+`"`)
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/zip", archive)
+}
+
+func (h *exportUserDataHandlerImpl) Handle(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+
+	archive, deferred, err := h.service.ExportUserData(c, token, time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+		}, false)
+		return
+	}
+
+	if deferred == nil {
+		sendDataExport(c, archive)
+		return
+	}
+
+	// The archive is too large to be built during the request. It is sent by email instead.
+	c.AbortWithStatus(http.StatusAccepted)
+
+	if err := deferred(); err != nil {
+		_ = c.Error(err)
+	}
+}
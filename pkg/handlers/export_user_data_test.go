@@ -0,0 +1,92 @@
+package handlers_test
+
+import (
+	"github.com/a-novel/auth-service/pkg/handlers"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExportUserDataHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+
+		serviceResp     []byte
+		serviceDeferred func() error
+		serviceErr      error
+
+		expect       []byte
+		expectStatus int
+		expectErrors int
+	}{
+		{
+			name:          "Success",
+			authorization: "Bearer token",
+			serviceResp:   []byte("archive"),
+			expect:        []byte("archive"),
+			expectStatus:  http.StatusOK,
+		},
+		{
+			name:            "Success/Deferred",
+			authorization:   "Bearer token",
+			serviceDeferred: func() error { return nil },
+			expectStatus:    http.StatusAccepted,
+		},
+		{
+			name:            "Success/DeferredFailure",
+			authorization:   "Bearer token",
+			serviceDeferred: func() error { return fooErr },
+			expectStatus:    http.StatusAccepted,
+			expectErrors:    1,
+		},
+		{
+			name:          "Error/InvalidCredentials",
+			authorization: "Bearer token",
+			serviceErr:    goframework.ErrInvalidCredentials,
+			expectStatus:  http.StatusForbidden,
+			expectErrors:  1,
+		},
+		{
+			name:          "Error/InternalError",
+			authorization: "Bearer token",
+			serviceErr:    fooErr,
+			expectStatus:  http.StatusInternalServerError,
+			expectErrors:  1,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewExportUserDataService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/", nil)
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			service.
+				On("ExportUserData", c, d.authorization, mock.Anything).
+				Return(d.serviceResp, d.serviceDeferred, d.serviceErr)
+
+			handler := handlers.NewExportUserDataHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			require.Len(t, c.Errors, d.expectErrors)
+			if d.expect != nil {
+				require.Equal(t, d.expect, w.Body.Bytes())
+				require.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+				require.Equal(t, `attachment; filename="personal-data.zip"`, w.Header().Get("Content-Disposition"))
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFinishPasskeyLoginHandler(t *testing.T) {
+	body := map[string]interface{}{
+		"id":    "credential-id",
+		"rawId": "credential-id",
+		"type":  "public-key",
+		"response": map[string]interface{}{
+			"clientDataJSON":    "client-data",
+			"authenticatorData": "authenticator-data",
+			"signature":         "signature",
+			"userHandle":        "user-handle",
+		},
+	}
+
+	form := models.PasskeyAssertionForm{
+		ID:    "credential-id",
+		RawID: "credential-id",
+		Type:  "public-key",
+		Response: models.PasskeyAssertionResponse{
+			ClientDataJSON:    "client-data",
+			AuthenticatorData: "authenticator-data",
+			Signature:         "signature",
+			UserHandle:        "user-handle",
+		},
+	}
+
+	data := []struct {
+		name string
+
+		body interface{}
+
+		shouldCallService bool
+
+		serviceResp *models.UserTokenStatus
+		serviceErr  error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:              "Success",
+			body:              body,
+			shouldCallService: true,
+			serviceResp:       &models.UserTokenStatus{TokenRaw: "token", RefreshToken: "refresh-token"},
+			expect:            map[string]interface{}{"token": "token", "refreshToken": "refresh-token"},
+			expectStatus:      http.StatusOK,
+		},
+		{
+			name: "Error/BadForm",
+			body: map[string]interface{}{
+				"id": 123,
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:              "Error/ErrAccountLocked",
+			body:              body,
+			shouldCallService: true,
+			serviceErr:        goerrors.Join(goframework.ErrInvalidCredentials, services.ErrAccountLocked),
+			expectStatus:      http.StatusLocked,
+		},
+		{
+			name:              "Error/ErrAccountDeleted",
+			body:              body,
+			shouldCallService: true,
+			serviceErr:        goerrors.Join(goframework.ErrInvalidCredentials, services.ErrAccountDeleted),
+			expectStatus:      http.StatusGone,
+		},
+		{
+			name:              "Error/ErrInvalidCredentials",
+			body:              body,
+			shouldCallService: true,
+			serviceErr:        goframework.ErrInvalidCredentials,
+			expectStatus:      http.StatusForbidden,
+		},
+		{
+			name:              "Error/ErrInvalidEntity",
+			body:              body,
+			shouldCallService: true,
+			serviceErr:        goframework.ErrInvalidEntity,
+			expectStatus:      http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "Error/ErrFoo",
+			body:              body,
+			shouldCallService: true,
+			serviceErr:        fooErr,
+			expectStatus:      http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewFinishPasskeyLoginService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("User-Agent", "Mozilla/5.0")
+
+			if d.shouldCallService {
+				service.
+					On("FinishPasskeyLogin", c, form, models.ClientInfo{
+						UserAgent: "Mozilla/5.0",
+						IP:        "192.0.2.1",
+					}, mock.Anything).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewFinishPasskeyLoginHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type FinishPasskeyRegistrationHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewFinishPasskeyRegistrationHandler(service services.FinishPasskeyRegistrationService) FinishPasskeyRegistrationHandler {
+	return &finishPasskeyRegistrationHandlerImpl{
+		service: service,
+	}
+}
+
+type finishPasskeyRegistrationHandlerImpl struct {
+	service services.FinishPasskeyRegistrationService
+}
+
+func (h *finishPasskeyRegistrationHandlerImpl) Handle(c *gin.Context) {
+	request := new(models.PasskeyAttestationForm)
+	token := c.GetHeader("Authorization")
+
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.FinishPasskeyRegistration(c, token, *request, time.Now()); err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+			{services.ErrPasskeyRegistered, http.StatusConflict},
+		}, false)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
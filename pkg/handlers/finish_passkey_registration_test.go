@@ -0,0 +1,128 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFinishPasskeyRegistrationHandler(t *testing.T) {
+	body := map[string]interface{}{
+		"id":    "credential-id",
+		"rawId": "credential-id",
+		"type":  "public-key",
+		"response": map[string]interface{}{
+			"clientDataJSON":    "client-data",
+			"attestationObject": "attestation",
+			"transports":        []string{"internal"},
+		},
+	}
+
+	form := models.PasskeyAttestationForm{
+		ID:    "credential-id",
+		RawID: "credential-id",
+		Type:  "public-key",
+		Response: models.PasskeyAttestationResponse{
+			ClientDataJSON:    "client-data",
+			AttestationObject: "attestation",
+			Transports:        []string{"internal"},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		authorization string
+		body          interface{}
+
+		shouldCallService bool
+		serviceErr        error
+
+		expectStatus int
+	}{
+		{
+			name:              "Success",
+			authorization:     "Bearer my-token",
+			body:              body,
+			shouldCallService: true,
+			expectStatus:      http.StatusNoContent,
+		},
+		{
+			name:          "Error/BadForm",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"id": 123,
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:              "Error/ErrInvalidCredentials",
+			authorization:     "Bearer my-token",
+			body:              body,
+			shouldCallService: true,
+			serviceErr:        goframework.ErrInvalidCredentials,
+			expectStatus:      http.StatusForbidden,
+		},
+		{
+			name:              "Error/ErrInvalidEntity",
+			authorization:     "Bearer my-token",
+			body:              body,
+			shouldCallService: true,
+			serviceErr:        goframework.ErrInvalidEntity,
+			expectStatus:      http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "Error/ErrPasskeyRegistered",
+			authorization:     "Bearer my-token",
+			body:              body,
+			shouldCallService: true,
+			serviceErr:        services.ErrPasskeyRegistered,
+			expectStatus:      http.StatusConflict,
+		},
+		{
+			name:              "Error/ErrFoo",
+			authorization:     "Bearer my-token",
+			body:              body,
+			shouldCallService: true,
+			serviceErr:        fooErr,
+			expectStatus:      http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewFinishPasskeyRegistrationService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("PUT", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			if d.shouldCallService {
+				service.
+					On("FinishPasskeyRegistration", c, d.authorization, form, mock.Anything).
+					Return(d.serviceErr)
+			}
+
+			handler := handlers.NewFinishPasskeyRegistrationHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type GetJWKSHandler interface {
+	Handle(c *gin.Context)
+}
+
+// NewGetJWKSHandler publishes the signature keys of the service. The maxAge value tells clients how long they can
+// keep the key set in cache, before checking for new keys.
+func NewGetJWKSHandler(service services.GetJWKSService, maxAge time.Duration) GetJWKSHandler {
+	return &getJWKSHandlerImpl{
+		service: service,
+		maxAge:  maxAge,
+	}
+}
+
+type getJWKSHandlerImpl struct {
+	service services.GetJWKSService
+	maxAge  time.Duration
+}
+
+func (h *getJWKSHandlerImpl) Handle(c *gin.Context) {
+	keys, err := h.service.GetJWKS(c)
+	if err != nil {
+		c.Header("Cache-Control", "no-store")
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d, must-revalidate", int(h.maxAge.Seconds())))
+	c.JSON(http.StatusOK, keys)
+}
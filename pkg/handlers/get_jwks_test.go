@@ -0,0 +1,85 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetJWKSHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		maxAge time.Duration
+
+		serviceResp *models.JSONWebKeySet
+		serviceErr  error
+
+		expect             interface{}
+		expectStatus       int
+		expectCacheControl string
+	}{
+		{
+			name:   "Success",
+			maxAge: 15 * time.Minute,
+			serviceResp: &models.JSONWebKeySet{
+				Keys: []models.JSONWebKey{
+					{KTY: "OKP", CRV: "Ed25519", X: "public-key", KID: "key-id", Use: "sig", Alg: "EdDSA"},
+				},
+			},
+			expect: map[string]interface{}{
+				"keys": []interface{}{
+					map[string]interface{}{
+						"kty": "OKP",
+						"crv": "Ed25519",
+						"x":   "public-key",
+						"kid": "key-id",
+						"use": "sig",
+						"alg": "EdDSA",
+					},
+				},
+			},
+			expectStatus:       http.StatusOK,
+			expectCacheControl: "public, max-age=900, must-revalidate",
+		},
+		{
+			name:               "Error",
+			maxAge:             15 * time.Minute,
+			serviceErr:         fooErr,
+			expectStatus:       http.StatusInternalServerError,
+			expectCacheControl: "no-store",
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewGetJWKSService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/", nil)
+
+			service.On("GetJWKS", c).Return(d.serviceResp, d.serviceErr)
+
+			handler := handlers.NewGetJWKSHandler(service, d.maxAge)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			require.Equal(t, d.expectCacheControl, w.Header().Get("Cache-Control"))
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
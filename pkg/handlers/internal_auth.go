@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	goerrors "errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+	"net/http"
+)
+
+var (
+	ErrMissingCallerCredentials = goerrors.New("the caller did not provide any credentials")
+	ErrUnknownCaller            = goerrors.New("the caller credentials do not match any known identity")
+	ErrCallerNotAllowed         = goerrors.New("the caller is not allowed to call this route")
+)
+
+const (
+	// AnyInternalCaller allows every authenticated caller on a route.
+	AnyInternalCaller = "*"
+	// InternalCallerKey is the context key that holds the identity of an authenticated internal caller.
+	InternalCallerKey = "internalCaller"
+)
+
+// InternalAuthenticator identifies the services that call the internal API.
+type InternalAuthenticator interface {
+	// Authenticate returns the identity of the caller, or an error if the caller could not be identified.
+	Authenticate(c *gin.Context) (string, error)
+}
+
+// NewSharedSecretAuthenticator identifies callers by the secret they send in the given header. Secrets are mapped to
+// the identity of each caller. This method is meant for development, as secrets are easily leaked.
+func NewSharedSecretAuthenticator(header string, secrets map[string]string) InternalAuthenticator {
+	return &sharedSecretAuthenticatorImpl{header: header, secrets: secrets}
+}
+
+type sharedSecretAuthenticatorImpl struct {
+	header  string
+	secrets map[string]string
+}
+
+func (a *sharedSecretAuthenticatorImpl) Authenticate(c *gin.Context) (string, error) {
+	secret := c.GetHeader(a.header)
+	if secret == "" {
+		return "", ErrMissingCallerCredentials
+	}
+
+	for identity, expected := range a.secrets {
+		// Constant time comparison, so the secrets cannot be guessed from the response time.
+		if expected != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(expected)) == 1 {
+			return identity, nil
+		}
+	}
+
+	return "", ErrUnknownCaller
+}
+
+// NewMutualTLSAuthenticator identifies callers by the client certificate they presented. The certificate must have
+// been verified by the server against the client CA: unverified certificates are ignored. The identity is the
+// common name of the certificate, or its first DNS name if the common name is empty.
+func NewMutualTLSAuthenticator() InternalAuthenticator {
+	return &mutualTLSAuthenticatorImpl{}
+}
+
+type mutualTLSAuthenticatorImpl struct{}
+
+func (a *mutualTLSAuthenticatorImpl) Authenticate(c *gin.Context) (string, error) {
+	if c.Request.TLS == nil || len(c.Request.TLS.VerifiedChains) == 0 || len(c.Request.TLS.VerifiedChains[0]) == 0 {
+		return "", ErrMissingCallerCredentials
+	}
+
+	leaf := c.Request.TLS.VerifiedChains[0][0]
+	if leaf.Subject.CommonName != "" {
+		return leaf.Subject.CommonName, nil
+	}
+	if len(leaf.DNSNames) > 0 {
+		return leaf.DNSNames[0], nil
+	}
+
+	return "", ErrUnknownCaller
+}
+
+type InternalAuthHandler interface {
+	Handle(c *gin.Context)
+}
+
+// NewInternalAuthHandler creates a middleware that only lets the given identities through. Use AnyInternalCaller
+// to allow every authenticated caller. Rejected calls are logged.
+func NewInternalAuthHandler(authenticator InternalAuthenticator, allowed []string, logger zerolog.Logger) InternalAuthHandler {
+	return &internalAuthHandlerImpl{
+		authenticator: authenticator,
+		allowed:       allowed,
+		logger:        logger,
+	}
+}
+
+type internalAuthHandlerImpl struct {
+	authenticator InternalAuthenticator
+	allowed       []string
+	logger        zerolog.Logger
+}
+
+func (h *internalAuthHandlerImpl) reject(c *gin.Context, status int, identity string, err error) {
+	h.logger.Warn().
+		Err(err).
+		Str("method", c.Request.Method).
+		Str("route", c.FullPath()).
+		Str("ip", c.ClientIP()).
+		Str("caller", identity).
+		Int("status", status).
+		Msg("rejected internal call")
+
+	_ = c.AbortWithError(status, err)
+}
+
+func (h *internalAuthHandlerImpl) Handle(c *gin.Context) {
+	identity, err := h.authenticator.Authenticate(c)
+	if err != nil {
+		h.reject(c, http.StatusUnauthorized, "", err)
+		return
+	}
+
+	if !lo.Contains(h.allowed, AnyInternalCaller) && !lo.Contains(h.allowed, identity) {
+		h.reject(c, http.StatusForbidden, identity, ErrCallerNotAllowed)
+		return
+	}
+
+	c.Set(InternalCallerKey, identity)
+}
@@ -0,0 +1,205 @@
+package handlers_test
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSharedSecretAuthenticator(t *testing.T) {
+	data := []struct {
+		name string
+
+		secret string
+
+		expect    string
+		expectErr error
+	}{
+		{
+			name:   "Success",
+			secret: "secret-1",
+			expect: "caller-1",
+		},
+		{
+			name:      "Error/UnknownSecret",
+			secret:    "secret-3",
+			expectErr: handlers.ErrUnknownCaller,
+		},
+		{
+			name:      "Error/NoSecret",
+			expectErr: handlers.ErrMissingCallerCredentials,
+		},
+	}
+
+	authenticator := handlers.NewSharedSecretAuthenticator("X-Secret", map[string]string{
+		"caller-1": "secret-1",
+		"caller-2": "secret-2",
+		// Callers without a secret can never be authenticated.
+		"caller-3": "",
+	})
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", nil)
+
+			if d.secret != "" {
+				c.Request.Header.Set("X-Secret", d.secret)
+			}
+
+			res, err := authenticator.Authenticate(c)
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+		})
+	}
+}
+
+func TestMutualTLSAuthenticator(t *testing.T) {
+	data := []struct {
+		name string
+
+		tls *tls.ConnectionState
+
+		expect    string
+		expectErr error
+	}{
+		{
+			name: "Success",
+			tls: &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{
+					{Subject: pkix.Name{CommonName: "caller-1"}, DNSNames: []string{"caller-1.internal"}},
+					{Subject: pkix.Name{CommonName: "ca"}},
+				}},
+			},
+			expect: "caller-1",
+		},
+		{
+			name: "Success/DNSName",
+			tls: &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{
+					{DNSNames: []string{"caller-1.internal"}},
+				}},
+			},
+			expect: "caller-1.internal",
+		},
+		{
+			name: "Error/NoIdentity",
+			tls: &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{{}}},
+			},
+			expectErr: handlers.ErrUnknownCaller,
+		},
+		{
+			name: "Error/NotVerified",
+			tls: &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "caller-1"}}},
+			},
+			expectErr: handlers.ErrMissingCallerCredentials,
+		},
+		{
+			name:      "Error/NoTLS",
+			expectErr: handlers.ErrMissingCallerCredentials,
+		},
+	}
+
+	authenticator := handlers.NewMutualTLSAuthenticator()
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", nil)
+			c.Request.TLS = d.tls
+
+			res, err := authenticator.Authenticate(c)
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+		})
+	}
+}
+
+func TestInternalAuthHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		secret  string
+		allowed []string
+
+		expectStatus int
+		expectCaller string
+		expectLogged bool
+	}{
+		{
+			name:         "Success",
+			secret:       "secret-1",
+			allowed:      []string{"caller-1", "caller-2"},
+			expectStatus: http.StatusOK,
+			expectCaller: "caller-1",
+		},
+		{
+			name:         "Success/AnyCaller",
+			secret:       "secret-1",
+			allowed:      []string{handlers.AnyInternalCaller},
+			expectStatus: http.StatusOK,
+			expectCaller: "caller-1",
+		},
+		{
+			name:         "Error/NotAllowed",
+			secret:       "secret-1",
+			allowed:      []string{"caller-2"},
+			expectStatus: http.StatusForbidden,
+			expectLogged: true,
+		},
+		{
+			name:         "Error/NoRouteCallers",
+			secret:       "secret-1",
+			expectStatus: http.StatusForbidden,
+			expectLogged: true,
+		},
+		{
+			name:         "Error/Unauthenticated",
+			secret:       "secret-3",
+			allowed:      []string{handlers.AnyInternalCaller},
+			expectStatus: http.StatusUnauthorized,
+			expectLogged: true,
+		},
+	}
+
+	authenticator := handlers.NewSharedSecretAuthenticator("X-Secret", map[string]string{
+		"caller-1": "secret-1",
+		"caller-2": "secret-2",
+	})
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			logs := new(bytes.Buffer)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", nil)
+			c.Request.Header.Set("X-Secret", d.secret)
+
+			handler := handlers.NewInternalAuthHandler(authenticator, d.allowed, zerolog.New(logs))
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			require.Equal(t, d.expectStatus != http.StatusOK, c.IsAborted())
+			require.Equal(t, d.expectCaller, c.GetString(handlers.InternalCallerKey))
+
+			if d.expectLogged {
+				require.Contains(t, logs.String(), "rejected internal call")
+			} else {
+				require.Empty(t, logs.String())
+			}
+		})
+	}
+}
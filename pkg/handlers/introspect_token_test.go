@@ -41,7 +41,11 @@ func TestIntrospectTokenHandler(t *testing.T) {
 						EXP: baseTime.Add(time.Hour),
 						ID:  goframework.NumberUUID(10),
 					},
-					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+					Payload: models.UserTokenPayload{
+						ID:       goframework.NumberUUID(1),
+						FamilyID: goframework.NumberUUID(100),
+						AuthTime: baseTime,
+					},
 				},
 				TokenRaw: "Bearer my-token",
 			},
@@ -50,6 +54,8 @@ func TestIntrospectTokenHandler(t *testing.T) {
 				"expired":   false,
 				"notIssued": false,
 				"malformed": false,
+				"revoked":   false,
+				"suspended": false,
 				"token": map[string]interface{}{
 					"header": map[string]interface{}{
 						"iat": baseTime.Format(time.RFC3339),
@@ -57,7 +63,9 @@ func TestIntrospectTokenHandler(t *testing.T) {
 						"id":  goframework.NumberUUID(10).String(),
 					},
 					"payload": map[string]interface{}{
-						"id": goframework.NumberUUID(1).String(),
+						"id":       goframework.NumberUUID(1).String(),
+						"familyID": goframework.NumberUUID(100).String(),
+						"authTime": baseTime.Format(time.RFC3339),
 					},
 				},
 				"tokenRaw": "Bearer my-token",
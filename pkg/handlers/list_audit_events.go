@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ListAuditEventsHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewListAuditEventsHandler(service services.ListAuditEventsService) ListAuditEventsHandler {
+	return &listAuditEventsHandlerImpl{
+		service: service,
+	}
+}
+
+type listAuditEventsHandlerImpl struct {
+	service services.ListAuditEventsService
+}
+
+func (h *listAuditEventsHandlerImpl) Handle(c *gin.Context) {
+	query := new(models.ListAuditEventsQuery)
+	if err := c.BindQuery(query); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	events, total, err := h.service.ListAuditEvents(c, *query)
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidEntity, http.StatusBadRequest},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"res":   events,
+		"total": total,
+	})
+}
@@ -0,0 +1,123 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListAuditEventsHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		query string
+
+		shouldCallService     bool
+		shouldCallServiceWith models.ListAuditEventsQuery
+		serviceResp           []*models.AuditEvent
+		serviceTotal          int
+		serviceErr            error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name: "Success",
+			query: "?userID=" + goframework.NumberUUID(1).String() +
+				"&kind=login.failed&kind=admin.lookup_user&actor=support" +
+				"&since=2020-05-04T08:00:00Z&until=2020-05-04T09:00:00Z&limit=10&offset=20",
+			shouldCallService: true,
+			shouldCallServiceWith: models.ListAuditEventsQuery{
+				UserID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Kinds:  []string{"login.failed", "admin.lookup_user"},
+				Actor:  "support",
+				Since:  baseTime,
+				Until:  baseTime.Add(time.Hour),
+				Limit:  10,
+				Offset: 20,
+			},
+			serviceResp: []*models.AuditEvent{
+				{
+					ID:        goframework.NumberUUID(10),
+					Kind:      "admin.lookup_user",
+					Actor:     "support",
+					UserID:    lo.ToPtr(goframework.NumberUUID(1)),
+					CreatedAt: baseTime,
+				},
+			},
+			serviceTotal: 21,
+			expect: map[string]interface{}{
+				"total": float64(21),
+				"res": []interface{}{
+					map[string]interface{}{
+						"id":        goframework.NumberUUID(10).String(),
+						"kind":      "admin.lookup_user",
+						"actor":     "support",
+						"userID":    goframework.NumberUUID(1).String(),
+						"createdAt": baseTime.Format(time.RFC3339),
+					},
+				},
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:                  "Error/InvalidEntity",
+			query:                 "?limit=1000",
+			shouldCallService:     true,
+			shouldCallServiceWith: models.ListAuditEventsQuery{Limit: 1000},
+			serviceErr:            goframework.ErrInvalidEntity,
+			expectStatus:          http.StatusBadRequest,
+		},
+		{
+			name:         "Error/InvalidDate",
+			query:        "?limit=10&since=yesterday",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:                  "Error/InternalError",
+			query:                 "?limit=10",
+			shouldCallService:     true,
+			shouldCallServiceWith: models.ListAuditEventsQuery{Limit: 10},
+			serviceErr:            fooErr,
+			expectStatus:          http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewListAuditEventsService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/"+d.query, nil)
+
+			if d.shouldCallService {
+				service.
+					On("ListAuditEvents", c, d.shouldCallServiceWith).
+					Return(d.serviceResp, d.serviceTotal, d.serviceErr)
+			}
+
+			handler := handlers.NewListAuditEventsHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
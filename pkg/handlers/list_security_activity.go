@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type ListSecurityActivityHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewListSecurityActivityHandler(service services.ListSecurityActivityService) ListSecurityActivityHandler {
+	return &listSecurityActivityHandlerImpl{service: service}
+}
+
+type listSecurityActivityHandlerImpl struct {
+	service services.ListSecurityActivityService
+}
+
+func (h *listSecurityActivityHandlerImpl) Handle(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+
+	query := new(models.ListSecurityActivityQuery)
+	if err := c.BindQuery(query); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	activity, total, err := h.service.ListSecurityActivity(c, token, query.Limit, query.Offset, time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusBadRequest},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"res":   activity,
+		"total": total,
+	})
+}
@@ -0,0 +1,120 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListSecurityActivityHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+		limit         int
+		offset        int
+
+		serviceResp  []*models.SecurityActivity
+		serviceTotal int
+		serviceErr   error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:          "Success",
+			authorization: "Bearer token",
+			limit:         10,
+			offset:        20,
+			serviceResp: []*models.SecurityActivity{
+				{
+					Kind:      "login.succeeded",
+					IP:        "127.0.0.1",
+					UserAgent: "Mozilla/5.0",
+					CreatedAt: baseTime,
+				},
+				{
+					Kind:      "admin.reset_password",
+					CreatedAt: baseTime,
+					Admin:     true,
+				},
+			},
+			serviceTotal: 22,
+			expect: map[string]interface{}{
+				"total": float64(22),
+				"res": []interface{}{
+					map[string]interface{}{
+						"kind":      "login.succeeded",
+						"ip":        "127.0.0.1",
+						"userAgent": "Mozilla/5.0",
+						"createdAt": "2020-05-04T08:00:00Z",
+						"admin":     false,
+					},
+					map[string]interface{}{
+						"kind":      "admin.reset_password",
+						"createdAt": "2020-05-04T08:00:00Z",
+						"admin":     true,
+					},
+				},
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:          "Error/InvalidCredentials",
+			authorization: "Bearer token",
+			limit:         10,
+			serviceErr:    goframework.ErrInvalidCredentials,
+			expectStatus:  http.StatusForbidden,
+		},
+		{
+			name:          "Error/InvalidEntity",
+			authorization: "Bearer token",
+			limit:         1000,
+			serviceErr:    goframework.ErrInvalidEntity,
+			expectStatus:  http.StatusBadRequest,
+		},
+		{
+			name:          "Error/InternalError",
+			authorization: "Bearer token",
+			limit:         10,
+			serviceErr:    fooErr,
+			expectStatus:  http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewListSecurityActivityService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", fmt.Sprintf("/?limit=%d&offset=%d", d.limit, d.offset), nil)
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			service.
+				On("ListSecurityActivity", c, d.authorization, d.limit, d.offset, mock.Anything).
+				Return(d.serviceResp, d.serviceTotal, d.serviceErr)
+
+			handler := handlers.NewListSecurityActivityHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type ListSessionsHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewListSessionsHandler(service services.ListSessionsService) ListSessionsHandler {
+	return &listSessionsHandlerImpl{service: service}
+}
+
+type listSessionsHandlerImpl struct {
+	service services.ListSessionsService
+}
+
+func (h *listSessionsHandlerImpl) Handle(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+
+	sessions, err := h.service.ListSessions(c, token, time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+}
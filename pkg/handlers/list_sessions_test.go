@@ -0,0 +1,94 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListSessionsHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+
+		serviceResp []*models.Session
+		serviceErr  error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:          "Success",
+			authorization: "Bearer token",
+			serviceResp: []*models.Session{
+				{
+					ID:         goframework.NumberUUID(10),
+					UserAgent:  "Mozilla/5.0",
+					IP:         "127.0.0.1",
+					CreatedAt:  baseTime,
+					LastSeenAt: baseTime,
+					Current:    true,
+				},
+			},
+			expect: map[string]interface{}{
+				"sessions": []interface{}{
+					map[string]interface{}{
+						"id":         goframework.NumberUUID(10).String(),
+						"userAgent":  "Mozilla/5.0",
+						"ip":         "127.0.0.1",
+						"createdAt":  "2020-05-04T08:00:00Z",
+						"lastSeenAt": "2020-05-04T08:00:00Z",
+						"current":    true,
+					},
+				},
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:          "Error/InvalidCredentials",
+			authorization: "Bearer token",
+			serviceErr:    goframework.ErrInvalidCredentials,
+			expectStatus:  http.StatusForbidden,
+		},
+		{
+			name:          "Error/InternalError",
+			authorization: "Bearer token",
+			serviceErr:    fooErr,
+			expectStatus:  http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewListSessionsService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/", nil)
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			service.On("ListSessions", c, d.authorization, mock.Anything).Return(d.serviceResp, d.serviceErr)
+
+			handler := handlers.NewListSessionsHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
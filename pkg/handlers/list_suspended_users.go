@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type ListSuspendedUsersHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewListSuspendedUsersHandler(service services.ListSuspendedUsersService) ListSuspendedUsersHandler {
+	return &listSuspendedUsersHandlerImpl{
+		service: service,
+	}
+}
+
+type listSuspendedUsersHandlerImpl struct {
+	service services.ListSuspendedUsersService
+}
+
+func (h *listSuspendedUsersHandlerImpl) Handle(c *gin.Context) {
+	query := new(models.ListSuspendedUsersQuery)
+	if err := c.BindQuery(query); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	suspensions, total, err := h.service.ListSuspendedUsers(c, query.Limit, query.Offset, time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidEntity, http.StatusBadRequest},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"res":   suspensions,
+		"total": total,
+	})
+}
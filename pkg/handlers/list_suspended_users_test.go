@@ -0,0 +1,106 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListSuspendedUsersHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		limit  int
+		offset int
+
+		shouldCallService bool
+		serviceResp       []*models.Suspension
+		serviceTotal      int
+		serviceErr        error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:              "Success",
+			limit:             10,
+			offset:            20,
+			shouldCallService: true,
+			serviceResp: []*models.Suspension{
+				{
+					UserID:      goframework.NumberUUID(1),
+					Email:       "user@domain.com",
+					Reason:      "spam",
+					SuspendedBy: "moderation",
+					SuspendedAt: baseTime,
+				},
+			},
+			serviceTotal: 21,
+			expect: map[string]interface{}{
+				"total": float64(21),
+				"res": []interface{}{
+					map[string]interface{}{
+						"userID":      goframework.NumberUUID(1).String(),
+						"email":       "user@domain.com",
+						"reason":      "spam",
+						"suspendedBy": "moderation",
+						"suspendedAt": baseTime.Format(time.RFC3339),
+					},
+				},
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:              "Error/InvalidEntity",
+			limit:             1000,
+			shouldCallService: true,
+			serviceErr:        goframework.ErrInvalidEntity,
+			expectStatus:      http.StatusBadRequest,
+		},
+		{
+			name:              "Error/InternalError",
+			limit:             10,
+			shouldCallService: true,
+			serviceErr:        fooErr,
+			expectStatus:      http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewListSuspendedUsersService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", fmt.Sprintf("/?limit=%d&offset=%d", d.limit, d.offset), nil)
+
+			if d.shouldCallService {
+				service.
+					On("ListSuspendedUsers", c, d.limit, d.offset, mock.Anything).
+					Return(d.serviceResp, d.serviceTotal, d.serviceErr)
+			}
+
+			handler := handlers.NewListSuspendedUsersHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -3,7 +3,6 @@ package handlers
 import (
 	"github.com/a-novel/auth-service/pkg/models"
 	"github.com/a-novel/auth-service/pkg/services"
-	"github.com/a-novel/bunovel"
 	"github.com/a-novel/go-apis"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/gin-gonic/gin"
@@ -30,15 +29,25 @@ func (h *loginHandlerImpl) Handle(c *gin.Context) {
 		return
 	}
 
-	token, err := h.service.Login(c, request.Email, request.Password, time.Now())
+	token, err := h.service.Login(c, request.Email, request.Password, getClientInfo(c), time.Now())
 	if err != nil {
+		setRetryAfter(c, err)
+
 		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrAccountLocked, http.StatusLocked},
+			{services.ErrAccountDeleted, http.StatusGone},
+			{services.ErrTooManyAttempts, http.StatusTooManyRequests},
 			{goframework.ErrInvalidCredentials, http.StatusForbidden},
-			{bunovel.ErrNotFound, http.StatusNotFound},
 			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
 		}, false)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": token.TokenRaw})
+	// The user must verify a second factor before getting a session.
+	if token.MFAChallenge != "" {
+		c.JSON(http.StatusOK, gin.H{"mfaChallenge": token.MFAChallenge})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token.TokenRaw, "refreshToken": token.RefreshToken})
 }
@@ -3,10 +3,11 @@ package handlers_test
 import (
 	"bytes"
 	"encoding/json"
+	goerrors "errors"
 	"github.com/a-novel/auth-service/pkg/handlers"
 	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
 	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
-	"github.com/a-novel/bunovel"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/mock"
@@ -30,8 +31,9 @@ func TestLoginHandler(t *testing.T) {
 		serviceResp *models.UserTokenStatus
 		serviceErr  error
 
-		expect       interface{}
-		expectStatus int
+		expect           interface{}
+		expectStatus     int
+		expectRetryAfter string
 	}{
 		{
 			name: "Success",
@@ -52,11 +54,25 @@ func TestLoginHandler(t *testing.T) {
 					},
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
-				TokenRaw: "Bearer my-token",
+				TokenRaw:     "Bearer my-token",
+				RefreshToken: "refresh-token",
 			},
-			expect:       map[string]interface{}{"token": "Bearer my-token"},
+			expect:       map[string]interface{}{"token": "Bearer my-token", "refreshToken": "refresh-token"},
 			expectStatus: http.StatusOK,
 		},
+		{
+			name: "Success/MFAChallenge",
+			body: map[string]interface{}{
+				"email":    "email",
+				"password": "password",
+			},
+			shouldCallService:             true,
+			shouldCallServiceWithEmail:    "email",
+			shouldCallServiceWithPassword: "password",
+			serviceResp:                   &models.UserTokenStatus{MFAChallenge: "challenge"},
+			expect:                        map[string]interface{}{"mfaChallenge": "challenge"},
+			expectStatus:                  http.StatusOK,
+		},
 		{
 			name: "Error/BadForm",
 			body: map[string]interface{}{
@@ -65,6 +81,30 @@ func TestLoginHandler(t *testing.T) {
 			},
 			expectStatus: http.StatusBadRequest,
 		},
+		{
+			name: "Error/ErrAccountLocked",
+			body: map[string]interface{}{
+				"email":    "email",
+				"password": "password",
+			},
+			shouldCallService:             true,
+			shouldCallServiceWithEmail:    "email",
+			shouldCallServiceWithPassword: "password",
+			serviceErr:                    goerrors.Join(goframework.ErrInvalidCredentials, services.ErrAccountLocked),
+			expectStatus:                  http.StatusLocked,
+		},
+		{
+			name: "Error/ErrAccountDeleted",
+			body: map[string]interface{}{
+				"email":    "email",
+				"password": "password",
+			},
+			shouldCallService:             true,
+			shouldCallServiceWithEmail:    "email",
+			shouldCallServiceWithPassword: "password",
+			serviceErr:                    goerrors.Join(goframework.ErrInvalidCredentials, services.ErrAccountDeleted),
+			expectStatus:                  http.StatusGone,
+		},
 		{
 			name: "Error/Forbidden",
 			body: map[string]interface{}{
@@ -78,7 +118,7 @@ func TestLoginHandler(t *testing.T) {
 			expectStatus:                  http.StatusForbidden,
 		},
 		{
-			name: "Error/NotFound",
+			name: "Error/TooManyAttempts",
 			body: map[string]interface{}{
 				"email":    "email",
 				"password": "password",
@@ -86,8 +126,9 @@ func TestLoginHandler(t *testing.T) {
 			shouldCallService:             true,
 			shouldCallServiceWithEmail:    "email",
 			shouldCallServiceWithPassword: "password",
-			serviceErr:                    bunovel.ErrNotFound,
-			expectStatus:                  http.StatusNotFound,
+			serviceErr:                    &services.TooManyAttemptsError{RetryAfter: 1500 * time.Millisecond},
+			expectStatus:                  http.StatusTooManyRequests,
+			expectRetryAfter:              "2",
 		},
 		{
 			name: "Error/InvalidEntity",
@@ -113,10 +154,14 @@ func TestLoginHandler(t *testing.T) {
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("User-Agent", "Mozilla/5.0")
 
 			if d.shouldCallService {
 				service.
-					On("Login", c, d.shouldCallServiceWithEmail, d.shouldCallServiceWithPassword, mock.Anything).
+					On("Login", c, d.shouldCallServiceWithEmail, d.shouldCallServiceWithPassword, models.ClientInfo{
+						UserAgent: "Mozilla/5.0",
+						IP:        "192.0.2.1",
+					}, mock.Anything).
 					Return(d.serviceResp, d.serviceErr)
 			}
 
@@ -124,6 +169,7 @@ func TestLoginHandler(t *testing.T) {
 			handler.Handle(c)
 
 			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			require.Equal(t, d.expectRetryAfter, w.Header().Get("Retry-After"))
 			if d.expect != nil {
 				var body interface{}
 				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
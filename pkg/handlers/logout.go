@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type LogoutHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewLogoutHandler(service services.LogoutService) LogoutHandler {
+	return &logoutHandlerImpl{
+		service: service,
+	}
+}
+
+type logoutHandlerImpl struct {
+	service services.LogoutService
+}
+
+func (h *logoutHandlerImpl) Handle(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+
+	if err := h.service.Logout(c, token, time.Now()); err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+		}, false)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
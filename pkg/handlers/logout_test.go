@@ -0,0 +1,63 @@
+package handlers_test
+
+import (
+	"github.com/a-novel/auth-service/pkg/handlers"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLogoutHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+
+		serviceErr error
+
+		expectStatus int
+	}{
+		{
+			name:          "Success",
+			authorization: "Bearer token",
+			expectStatus:  http.StatusNoContent,
+		},
+		{
+			name:          "Error/InvalidCredentials",
+			authorization: "Bearer token",
+			serviceErr:    goframework.ErrInvalidCredentials,
+			expectStatus:  http.StatusForbidden,
+		},
+		{
+			name:          "Error/InternalError",
+			authorization: "Bearer token",
+			serviceErr:    fooErr,
+			expectStatus:  http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewLogoutService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("DELETE", "/", nil)
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			service.On("Logout", c, d.authorization, mock.Anything).Return(d.serviceErr)
+
+			handler := handlers.NewLogoutHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code)
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type PruneDataExportsHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewPruneDataExportsHandler(service services.PruneDataExportsService) PruneDataExportsHandler {
+	return &pruneDataExportsHandlerImpl{
+		service: service,
+	}
+}
+
+type pruneDataExportsHandlerImpl struct {
+	service services.PruneDataExportsService
+}
+
+func (h *pruneDataExportsHandlerImpl) Handle(c *gin.Context) {
+	if err := h.service.PruneDataExports(c, time.Now()); err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type PruneLoginFailuresHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewPruneLoginFailuresHandler(service services.PruneLoginFailuresService) PruneLoginFailuresHandler {
+	return &pruneLoginFailuresHandlerImpl{
+		service: service,
+	}
+}
+
+type pruneLoginFailuresHandlerImpl struct {
+	service services.PruneLoginFailuresService
+}
+
+func (h *pruneLoginFailuresHandlerImpl) Handle(c *gin.Context) {
+	if err := h.service.PruneLoginFailures(c, time.Now()); err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
@@ -0,0 +1,51 @@
+package handlers_test
+
+import (
+	"github.com/a-novel/auth-service/pkg/handlers"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPruneLoginFailuresHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		serviceErr error
+
+		expectStatus int
+	}{
+		{
+			name:         "Success",
+			expectStatus: http.StatusNoContent,
+		},
+		{
+			name:         "Error",
+			serviceErr:   fooErr,
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewPruneLoginFailuresService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", nil)
+
+			service.On("PruneLoginFailures", c, mock.Anything).Return(d.serviceErr)
+
+			handler := handlers.NewPruneLoginFailuresHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code)
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type PruneRevokedTokensHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewPruneRevokedTokensHandler(service services.PruneRevokedTokensService) PruneRevokedTokensHandler {
+	return &pruneRevokedTokensHandlerImpl{
+		service: service,
+	}
+}
+
+type pruneRevokedTokensHandlerImpl struct {
+	service services.PruneRevokedTokensService
+}
+
+func (h *pruneRevokedTokensHandlerImpl) Handle(c *gin.Context) {
+	if err := h.service.PruneRevokedTokens(c, time.Now()); err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
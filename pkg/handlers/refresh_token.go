@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type RefreshTokenHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewRefreshTokenHandler(service services.RefreshTokenService) RefreshTokenHandler {
+	return &refreshTokenHandlerImpl{service: service}
+}
+
+type refreshTokenHandlerImpl struct {
+	service services.RefreshTokenService
+}
+
+func (h *refreshTokenHandlerImpl) Handle(c *gin.Context) {
+	request := new(models.RefreshTokenForm)
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.service.RefreshToken(c, request.RefreshToken, time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrAccountLocked, http.StatusLocked},
+			{services.ErrAccountDeleted, http.StatusGone},
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token.TokenRaw, "refreshToken": token.RefreshToken})
+}
@@ -0,0 +1,148 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRefreshTokenHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		body interface{}
+
+		shouldCallService     bool
+		shouldCallServiceWith string
+
+		serviceResp *models.UserTokenStatus
+		serviceErr  error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name: "Success",
+			body: map[string]interface{}{
+				"refreshToken": "refresh-token",
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: "refresh-token",
+			serviceResp: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime,
+						EXP: baseTime.Add(time.Hour),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+				TokenRaw:     "Bearer my-token",
+				RefreshToken: "new-refresh-token",
+			},
+			expect:       map[string]interface{}{"token": "Bearer my-token", "refreshToken": "new-refresh-token"},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name: "Error/BadForm",
+			body: map[string]interface{}{
+				"refreshToken": 123456,
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Error/AccountLocked",
+			body: map[string]interface{}{
+				"refreshToken": "refresh-token",
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: "refresh-token",
+			serviceErr:            goerrors.Join(goframework.ErrInvalidCredentials, services.ErrAccountLocked),
+			expectStatus:          http.StatusLocked,
+		},
+		{
+			name: "Error/AccountDeleted",
+			body: map[string]interface{}{
+				"refreshToken": "refresh-token",
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: "refresh-token",
+			serviceErr:            goerrors.Join(goframework.ErrInvalidCredentials, services.ErrAccountDeleted),
+			expectStatus:          http.StatusGone,
+		},
+		{
+			name: "Error/Forbidden",
+			body: map[string]interface{}{
+				"refreshToken": "refresh-token",
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: "refresh-token",
+			serviceErr:            goframework.ErrInvalidCredentials,
+			expectStatus:          http.StatusForbidden,
+		},
+		{
+			name: "Error/InvalidEntity",
+			body: map[string]interface{}{
+				"refreshToken": "refresh-token",
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: "refresh-token",
+			serviceErr:            goframework.ErrInvalidEntity,
+			expectStatus:          http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Error/InternalError",
+			body: map[string]interface{}{
+				"refreshToken": "refresh-token",
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: "refresh-token",
+			serviceErr:            fooErr,
+			expectStatus:          http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewRefreshTokenService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+
+			if d.shouldCallService {
+				service.
+					On("RefreshToken", c, d.shouldCallServiceWith, mock.Anything).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewRefreshTokenHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
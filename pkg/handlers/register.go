@@ -29,8 +29,12 @@ func (h *registerHandlerImpl) Handle(c *gin.Context) {
 		return
 	}
 
-	token, deferred, err := h.service.Register(c, *form, time.Now())
+	token, deferred, err := h.service.Register(c, *form, getClientInfo(c), time.Now())
 	if err != nil {
+		if abortWithPasswordPolicyError(c, err) {
+			return
+		}
+
 		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
 			{services.ErrTaken, http.StatusConflict},
 			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
@@ -38,7 +42,7 @@ func (h *registerHandlerImpl) Handle(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"token": token.TokenRaw})
+	c.JSON(http.StatusCreated, gin.H{"token": token.TokenRaw, "refreshToken": token.RefreshToken})
 
 	if deferred != nil {
 		if err := deferred(); err != nil {
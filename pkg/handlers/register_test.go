@@ -3,6 +3,7 @@ package handlers_test
 import (
 	"bytes"
 	"encoding/json"
+	goerrors "errors"
 	"github.com/a-novel/auth-service/pkg/handlers"
 	"github.com/a-novel/auth-service/pkg/models"
 	"github.com/a-novel/auth-service/pkg/services"
@@ -64,9 +65,10 @@ func TestRegisterHandler(t *testing.T) {
 					},
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
-				TokenRaw: "Bearer my-token",
+				TokenRaw:     "Bearer my-token",
+				RefreshToken: "refresh-token",
 			},
-			expect:       map[string]interface{}{"token": "Bearer my-token"},
+			expect:       map[string]interface{}{"token": "Bearer my-token", "refreshToken": "refresh-token"},
 			expectStatus: http.StatusCreated,
 		},
 		{
@@ -135,6 +137,36 @@ func TestRegisterHandler(t *testing.T) {
 			serviceErr:   goframework.ErrInvalidEntity,
 			expectStatus: http.StatusUnprocessableEntity,
 		},
+		{
+			name: "Error/PasswordPolicy",
+			body: map[string]interface{}{
+				"email":     "email",
+				"password":  "password",
+				"slug":      "slug",
+				"firstName": "name",
+				"lastName":  "surname",
+				"sex":       "male",
+				"username":  "username",
+				"birthday":  baseTime.Format(time.RFC3339),
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.RegisterForm{
+				Email:     "email",
+				Password:  "password",
+				FirstName: "name",
+				LastName:  "surname",
+				Sex:       models.SexMale,
+				Birthday:  baseTime,
+				Slug:      "slug",
+				Username:  "username",
+			},
+			serviceErr: goerrors.Join(
+				goframework.ErrInvalidEntity,
+				&services.PasswordPolicyError{Rules: []string{services.PasswordRuleMinLength, services.PasswordRuleUserInfo}},
+			),
+			expect:       map[string]interface{}{"passwordRules": []interface{}{"minLength", "userInfo"}},
+			expectStatus: http.StatusUnprocessableEntity,
+		},
 	}
 
 	for _, d := range data {
@@ -147,10 +179,14 @@ func TestRegisterHandler(t *testing.T) {
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("User-Agent", "Mozilla/5.0")
 
 			if d.shouldCallService {
 				service.
-					On("Register", c, d.shouldCallServiceWith, mock.Anything).
+					On("Register", c, d.shouldCallServiceWith, models.ClientInfo{
+						UserAgent: "Mozilla/5.0",
+						IP:        "192.0.2.1",
+					}, mock.Anything).
 					Return(d.serviceResp, nil, d.serviceErr)
 			}
 
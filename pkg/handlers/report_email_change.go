@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type ReportEmailChangeHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewReportEmailChangeHandler(service services.ReportEmailChangeService) ReportEmailChangeHandler {
+	return &reportEmailChangeHandlerImpl{
+		service: service,
+	}
+}
+
+type reportEmailChangeHandlerImpl struct {
+	service services.ReportEmailChangeService
+}
+
+func (h *reportEmailChangeHandlerImpl) Handle(c *gin.Context) {
+	query := new(models.ValidateEmailQuery)
+	if err := c.BindQuery(query); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	deferred, err := h.service.ReportEmailChange(c, query.ID.Value(), query.Code, getClientInfo(c), time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{bunovel.ErrNotFound, http.StatusForbidden},
+		}, false)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusAccepted)
+
+	if deferred != nil {
+		if err := deferred(); err != nil {
+			_ = c.Error(err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package handlers_test
+
+import (
+	goerrors "errors"
+	"fmt"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReportEmailChangeHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		id   string
+		code string
+
+		shouldCallService bool
+		serviceErr        error
+
+		expectStatus int
+	}{
+		{
+			name:              "Success",
+			id:                goframework.NumberUUID(1).String(),
+			code:              "report-code",
+			shouldCallService: true,
+			expectStatus:      http.StatusAccepted,
+		},
+		{
+			name:              "Error/ErrInvalidCredentials",
+			id:                goframework.NumberUUID(1).String(),
+			code:              "report-code",
+			shouldCallService: true,
+			serviceErr:        goerrors.Join(goframework.ErrInvalidCredentials, services.ErrInvalidValidationCode),
+			expectStatus:      http.StatusForbidden,
+		},
+		{
+			name:              "Error/ErrNotFound",
+			id:                goframework.NumberUUID(1).String(),
+			code:              "report-code",
+			shouldCallService: true,
+			serviceErr:        bunovel.ErrNotFound,
+			expectStatus:      http.StatusForbidden,
+		},
+		{
+			name:              "Error/InternalError",
+			id:                goframework.NumberUUID(1).String(),
+			code:              "report-code",
+			shouldCallService: true,
+			serviceErr:        fooErr,
+			expectStatus:      http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewReportEmailChangeService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", fmt.Sprintf("/?id=%s&code=%s", d.id, d.code), nil)
+			c.Request.Header.Set("User-Agent", "Mozilla/5.0")
+
+			if d.shouldCallService {
+				var deferred func() error
+				if d.serviceErr == nil {
+					deferred = func() error { return nil }
+				}
+
+				service.On("ReportEmailChange", c, uuid.MustParse(d.id), d.code, models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "192.0.2.1"}, mock.Anything).Return(deferred, d.serviceErr)
+			}
+
+			handler := handlers.NewReportEmailChangeHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+
+			service.AssertExpectations(t)
+		})
+	}
+}
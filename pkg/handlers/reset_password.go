@@ -27,7 +27,7 @@ type resetPasswordHandlerImpl struct {
 func (h *resetPasswordHandlerImpl) Handle(c *gin.Context) {
 	email := c.Query("email")
 
-	deferred, err := h.service.ResetPassword(c, email, time.Now())
+	deferred, err := h.service.ResetPassword(c, email, getClientInfo(c), time.Now())
 	if err != nil {
 		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
 			{goframework.ErrInvalidEntity, http.StatusBadRequest},
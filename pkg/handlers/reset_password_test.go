@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
 	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
 	"github.com/a-novel/bunovel"
 	goframework "github.com/a-novel/go-framework"
@@ -49,8 +50,9 @@ func TestResetPasswordHandler(t *testing.T) {
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 			c.Request = httptest.NewRequest("GET", "/?email="+d.email, nil)
+			c.Request.Header.Set("User-Agent", "Mozilla/5.0")
 
-			service.On("ResetPassword", c, d.email, mock.Anything).Return(nil, d.serviceErr)
+			service.On("ResetPassword", c, d.email, models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "192.0.2.1"}, mock.Anything).Return(nil, d.serviceErr)
 
 			handler := handlers.NewResetPasswordHandler(service)
 			handler.Handle(c)
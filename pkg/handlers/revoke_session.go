@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+type RevokeSessionHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewRevokeSessionHandler(service services.RevokeSessionService) RevokeSessionHandler {
+	return &revokeSessionHandlerImpl{service: service}
+}
+
+type revokeSessionHandlerImpl struct {
+	service services.RevokeSessionService
+}
+
+func (h *revokeSessionHandlerImpl) Handle(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	request := new(models.ReauthenticationForm)
+	if err := bindOptionalJSON(c, request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.RevokeSession(c, token, id, request.Password, getClientInfo(c), time.Now()); err != nil {
+		setRetryAfter(c, err)
+
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrTooManyAttempts, http.StatusTooManyRequests},
+			{services.ErrStepUpRequired, http.StatusUnauthorized},
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{bunovel.ErrNotFound, http.StatusNotFound},
+		}, false)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
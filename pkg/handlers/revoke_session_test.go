@@ -0,0 +1,155 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRevokeSessionHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+		id            string
+		body          interface{}
+
+		shouldCallService             bool
+		shouldCallServiceWith         uuid.UUID
+		shouldCallServiceWithPassword string
+		serviceErr                    error
+
+		expectStatus     int
+		expectRetryAfter string
+	}{
+		{
+			name:                  "Success",
+			authorization:         "Bearer token",
+			id:                    goframework.NumberUUID(10).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(10),
+			expectStatus:          http.StatusNoContent,
+		},
+		{
+			name:                          "Success/WithPassword",
+			authorization:                 "Bearer token",
+			id:                            goframework.NumberUUID(10).String(),
+			body:                          map[string]interface{}{"password": "password"},
+			shouldCallService:             true,
+			shouldCallServiceWith:         goframework.NumberUUID(10),
+			shouldCallServiceWithPassword: "password",
+			expectStatus:                  http.StatusNoContent,
+		},
+		{
+			name:          "Error/BadForm",
+			authorization: "Bearer token",
+			id:            goframework.NumberUUID(10).String(),
+			body:          map[string]interface{}{"password": 123},
+			expectStatus:  http.StatusBadRequest,
+		},
+		{
+			name:                  "Error/TooManyAttempts",
+			authorization:         "Bearer token",
+			id:                    goframework.NumberUUID(10).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(10),
+			serviceErr:            &services.TooManyAttemptsError{RetryAfter: 1500 * time.Millisecond},
+			expectStatus:          http.StatusTooManyRequests,
+			expectRetryAfter:      "2",
+		},
+		{
+			name:                  "Error/StepUpRequired",
+			authorization:         "Bearer token",
+			id:                    goframework.NumberUUID(10).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(10),
+			serviceErr:            goerrors.Join(goframework.ErrInvalidCredentials, services.ErrStepUpRequired),
+			expectStatus:          http.StatusUnauthorized,
+		},
+		{
+			name:          "Error/InvalidID",
+			authorization: "Bearer token",
+			id:            "not-an-uuid",
+			expectStatus:  http.StatusBadRequest,
+		},
+		{
+			name:                  "Error/InvalidCredentials",
+			authorization:         "Bearer token",
+			id:                    goframework.NumberUUID(10).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(10),
+			serviceErr:            goframework.ErrInvalidCredentials,
+			expectStatus:          http.StatusForbidden,
+		},
+		{
+			name:                  "Error/NotFound",
+			authorization:         "Bearer token",
+			id:                    goframework.NumberUUID(10).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(10),
+			serviceErr:            bunovel.ErrNotFound,
+			expectStatus:          http.StatusNotFound,
+		},
+		{
+			name:                  "Error/InternalError",
+			authorization:         "Bearer token",
+			id:                    goframework.NumberUUID(10).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(10),
+			serviceErr:            fooErr,
+			expectStatus:          http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewRevokeSessionService(t)
+
+			var body io.Reader
+			if d.body != nil {
+				mrshBody, err := json.Marshal(d.body)
+				require.NoError(t, err)
+				body = bytes.NewReader(mrshBody)
+			}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("DELETE", "/", body)
+			c.Request.Header.Set("User-Agent", "Mozilla/5.0")
+			c.Request.Header.Set("Authorization", d.authorization)
+			c.Params = gin.Params{{Key: "id", Value: d.id}}
+
+			if d.shouldCallService {
+				service.
+					On("RevokeSession", c, d.authorization, d.shouldCallServiceWith, d.shouldCallServiceWithPassword, models.ClientInfo{
+						UserAgent: "Mozilla/5.0",
+						IP:        "192.0.2.1",
+					}, mock.Anything).
+					Return(d.serviceErr)
+			}
+
+			handler := handlers.NewRevokeSessionHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			require.Equal(t, d.expectRetryAfter, w.Header().Get("Retry-After"))
+
+			service.AssertExpectations(t)
+		})
+	}
+}
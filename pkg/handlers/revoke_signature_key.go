@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type RevokeSignatureKeyHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewRevokeSignatureKeyHandler(service services.RevokeSignatureKeyService) RevokeSignatureKeyHandler {
+	return &revokeSignatureKeyHandlerImpl{
+		service: service,
+	}
+}
+
+type revokeSignatureKeyHandlerImpl struct {
+	service services.RevokeSignatureKeyService
+}
+
+func (h *revokeSignatureKeyHandlerImpl) Handle(c *gin.Context) {
+	form := new(models.RevokeSignatureKeyForm)
+	if err := c.BindJSON(form); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.service.RevokeSignatureKey(c, form.Name, c.GetString(InternalCallerKey), time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+			{bunovel.ErrNotFound, http.StatusNotFound},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,125 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRevokeSignatureKeyHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		body interface{}
+
+		shouldCallService     bool
+		shouldCallServiceWith string
+		serviceResp           *models.RevokedSignatureKey
+		serviceErr            error
+
+		expectStatus int
+		expect       interface{}
+	}{
+		{
+			name: "Success",
+			body: map[string]interface{}{
+				"name": "key-0",
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: "key-0",
+			serviceResp: &models.RevokedSignatureKey{
+				Name:         "key-0",
+				KID:          "kid-0",
+				RevokedAt:    baseTime,
+				LiveSessions: 12,
+				Rotated:      true,
+			},
+			expectStatus: http.StatusOK,
+			expect: map[string]interface{}{
+				"name":         "key-0",
+				"kid":          "kid-0",
+				"revokedAt":    baseTime.Format("2006-01-02T15:04:05Z"),
+				"liveSessions": float64(12),
+				"rotated":      true,
+			},
+		},
+		{
+			name: "Error/BadForm",
+			body: map[string]interface{}{
+				"name": 123456,
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Error/InvalidEntity",
+			body: map[string]interface{}{
+				"name": "key-0",
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: "key-0",
+			serviceErr:            goframework.ErrInvalidEntity,
+			expectStatus:          http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Error/NotFound",
+			body: map[string]interface{}{
+				"name": "key-0",
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: "key-0",
+			serviceErr:            bunovel.ErrNotFound,
+			expectStatus:          http.StatusNotFound,
+		},
+		{
+			name: "Error/InternalError",
+			body: map[string]interface{}{
+				"name": "key-0",
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: "key-0",
+			serviceErr:            fooErr,
+			expectStatus:          http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewRevokeSignatureKeyService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Set(handlers.InternalCallerKey, "admin")
+
+			if d.shouldCallService {
+				service.On("RevokeSignatureKey", c, d.shouldCallServiceWith, "admin", mock.Anything).Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewRevokeSignatureKeyHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
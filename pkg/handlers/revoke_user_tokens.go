@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type RevokeUserTokensHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewRevokeUserTokensHandler(service services.RevokeUserTokensService) RevokeUserTokensHandler {
+	return &revokeUserTokensHandlerImpl{
+		service: service,
+	}
+}
+
+type revokeUserTokensHandlerImpl struct {
+	service services.RevokeUserTokensService
+}
+
+func (h *revokeUserTokensHandlerImpl) Handle(c *gin.Context) {
+	form := new(models.RevokeUserTokensForm)
+	if err := c.BindJSON(form); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.RevokeUserTokens(c, form.UserID, time.Now()); err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+			{bunovel.ErrNotFound, http.StatusNotFound},
+		}, false)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
@@ -0,0 +1,102 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRevokeUserTokensHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		body interface{}
+
+		shouldCallService     bool
+		shouldCallServiceWith uuid.UUID
+		serviceErr            error
+
+		expectStatus int
+	}{
+		{
+			name: "Success",
+			body: map[string]interface{}{
+				"userID": goframework.NumberUUID(1).String(),
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(1),
+			expectStatus:          http.StatusNoContent,
+		},
+		{
+			name: "Error/BadForm",
+			body: map[string]interface{}{
+				"userID": 123456,
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Error/InvalidEntity",
+			body: map[string]interface{}{
+				"userID": goframework.NumberUUID(1).String(),
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(1),
+			serviceErr:            goframework.ErrInvalidEntity,
+			expectStatus:          http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Error/NotFound",
+			body: map[string]interface{}{
+				"userID": goframework.NumberUUID(1).String(),
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(1),
+			serviceErr:            bunovel.ErrNotFound,
+			expectStatus:          http.StatusNotFound,
+		},
+		{
+			name: "Error/InternalError",
+			body: map[string]interface{}{
+				"userID": goframework.NumberUUID(1).String(),
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(1),
+			serviceErr:            fooErr,
+			expectStatus:          http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewRevokeUserTokensService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+
+			if d.shouldCallService {
+				service.On("RevokeUserTokens", c, d.shouldCallServiceWith, mock.Anything).Return(d.serviceErr)
+			}
+
+			handler := handlers.NewRevokeUserTokensHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/a-novel/auth-service/pkg/services"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"time"
 )
 
 type RotateSecretKeysHandler interface {
@@ -21,7 +22,7 @@ type rotateSecretKeysHandlerImpl struct {
 }
 
 func (h *rotateSecretKeysHandlerImpl) Handle(c *gin.Context) {
-	if err := h.service.RotateSecretKeys(c); err != nil {
+	if err := h.service.RotateSecretKeys(c, c.GetString(InternalCallerKey), time.Now()); err != nil {
 		_ = c.AbortWithError(http.StatusInternalServerError, err)
 		return
 	}
@@ -4,6 +4,7 @@ import (
 	"github.com/a-novel/auth-service/pkg/handlers"
 	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
@@ -36,8 +37,9 @@ func TestRotateSecretKeysHandler(t *testing.T) {
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 			c.Request = httptest.NewRequest("GET", "/", nil)
+			c.Set(handlers.InternalCallerKey, "admin")
 
-			service.On("RotateSecretKeys", c).Return(d.serviceErr)
+			service.On("RotateSecretKeys", c, "admin", mock.Anything).Return(d.serviceErr)
 
 			handler := handlers.NewRotateSecretKeysHandler(service)
 			handler.Handle(c)
@@ -7,6 +7,7 @@ import (
 	goframework "github.com/a-novel/go-framework"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"time"
 )
 
 type SearchHandler interface {
@@ -30,7 +31,7 @@ func (s *searchHandlerImpl) Handle(c *gin.Context) {
 		return
 	}
 
-	users, total, err := s.service.Search(c, query.Query, query.Limit, query.Offset)
+	users, total, err := s.service.Search(c, query.Query, query.Limit, query.Offset, time.Now())
 	if err != nil {
 		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
 			{goframework.ErrInvalidEntity, http.StatusBadRequest},
@@ -8,6 +8,7 @@ import (
 	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
@@ -99,7 +100,7 @@ func TestSearchHandler(t *testing.T) {
 			c.Request = httptest.NewRequest("GET", fmt.Sprintf("/?query=%s&limit=%d&offset=%d", d.query, d.limit, d.offset), nil)
 
 			if d.shouldCallService {
-				service.On("Search", c, d.query, d.limit, d.offset).Return(d.serviceResp, d.serviceTotal, d.serviceErr)
+				service.On("Search", c, d.query, d.limit, d.offset, mock.Anything).Return(d.serviceResp, d.serviceTotal, d.serviceErr)
 			}
 
 			handler := handlers.NewSearchHandler(service)
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type SendLoginLinkHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewSendLoginLinkHandler(service services.SendLoginLinkService) SendLoginLinkHandler {
+	return &sendLoginLinkHandlerImpl{
+		service: service,
+	}
+}
+
+type sendLoginLinkHandlerImpl struct {
+	service services.SendLoginLinkService
+}
+
+func (h *sendLoginLinkHandlerImpl) Handle(c *gin.Context) {
+	request := new(models.LoginLinkForm)
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	deferred, err := h.service.SendLoginLink(c, request.Email, time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusAccepted)
+
+	if deferred != nil {
+		if err := deferred(); err != nil {
+			_ = c.Error(err)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendLoginLinkHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		body interface{}
+
+		shouldCallService bool
+		// Unknown emails return no deferred function.
+		serviceDeferred bool
+		deferredErr     error
+		serviceErr      error
+
+		expectDeferredCalled bool
+		expectStatus         int
+	}{
+		{
+			name: "Success",
+			body: map[string]interface{}{
+				"email": "user@domain.com",
+			},
+			shouldCallService:    true,
+			serviceDeferred:      true,
+			expectDeferredCalled: true,
+			expectStatus:         http.StatusAccepted,
+		},
+		{
+			name: "Success/UnknownEmail",
+			body: map[string]interface{}{
+				"email": "user@domain.com",
+			},
+			shouldCallService: true,
+			expectStatus:      http.StatusAccepted,
+		},
+		{
+			name: "Success/DeferredFailure",
+			body: map[string]interface{}{
+				"email": "user@domain.com",
+			},
+			shouldCallService:    true,
+			serviceDeferred:      true,
+			deferredErr:          fooErr,
+			expectDeferredCalled: true,
+			expectStatus:         http.StatusAccepted,
+		},
+		{
+			name: "Error/BadForm",
+			body: map[string]interface{}{
+				"email": 123,
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Error/ErrInvalidEntity",
+			body: map[string]interface{}{
+				"email": "user@domain.com",
+			},
+			shouldCallService: true,
+			serviceErr:        goframework.ErrInvalidEntity,
+			expectStatus:      http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Error/ErrFoo",
+			body: map[string]interface{}{
+				"email": "user@domain.com",
+			},
+			shouldCallService: true,
+			serviceErr:        fooErr,
+			expectStatus:      http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewSendLoginLinkService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+
+			deferredCalled := false
+			var deferred func() error
+			if d.serviceDeferred {
+				deferred = func() error {
+					deferredCalled = true
+					return d.deferredErr
+				}
+			}
+
+			if d.shouldCallService {
+				service.
+					On("SendLoginLink", c, "user@domain.com", mock.Anything).
+					Return(deferred, d.serviceErr)
+			}
+
+			handler := handlers.NewSendLoginLinkHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			require.Equal(t, d.expectDeferredCalled, deferredCalled)
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type SuspendUserHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewSuspendUserHandler(service services.SuspendUserService) SuspendUserHandler {
+	return &suspendUserHandlerImpl{
+		service: service,
+	}
+}
+
+type suspendUserHandlerImpl struct {
+	service services.SuspendUserService
+}
+
+func (h *suspendUserHandlerImpl) Handle(c *gin.Context) {
+	form := new(models.SuspendUserForm)
+	if err := c.BindJSON(form); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	suspension, err := h.service.SuspendUser(c, *form, c.GetString(InternalCallerKey), time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+			{bunovel.ErrNotFound, http.StatusNotFound},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, suspension)
+}
@@ -0,0 +1,156 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSuspendUserHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		body   interface{}
+		caller string
+
+		shouldCallService     bool
+		shouldCallServiceWith models.SuspendUserForm
+		serviceResp           *models.Suspension
+		serviceErr            error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name: "Success",
+			body: map[string]interface{}{
+				"userID": goframework.NumberUUID(1).String(),
+				"reason": "spam",
+				"until":  baseTime.Format(time.RFC3339),
+			},
+			caller:            "moderation",
+			shouldCallService: true,
+			shouldCallServiceWith: models.SuspendUserForm{
+				UserID: goframework.NumberUUID(1),
+				Reason: "spam",
+				Until:  &baseTime,
+			},
+			serviceResp: &models.Suspension{
+				UserID:         goframework.NumberUUID(1),
+				Email:          "user@domain.com",
+				Reason:         "spam",
+				SuspendedBy:    "moderation",
+				SuspendedAt:    baseTime,
+				SuspendedUntil: lo.ToPtr(baseTime.Add(time.Hour)),
+			},
+			expect: map[string]interface{}{
+				"userID":         goframework.NumberUUID(1).String(),
+				"email":          "user@domain.com",
+				"reason":         "spam",
+				"suspendedBy":    "moderation",
+				"suspendedAt":    baseTime.Format(time.RFC3339),
+				"suspendedUntil": baseTime.Add(time.Hour).Format(time.RFC3339),
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name: "Error/BadForm",
+			body: map[string]interface{}{
+				"userID": 123456,
+			},
+			caller:       "moderation",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Error/InvalidEntity",
+			body: map[string]interface{}{
+				"userID": goframework.NumberUUID(1).String(),
+			},
+			caller:            "moderation",
+			shouldCallService: true,
+			shouldCallServiceWith: models.SuspendUserForm{
+				UserID: goframework.NumberUUID(1),
+			},
+			serviceErr:   goframework.ErrInvalidEntity,
+			expectStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Error/NotFound",
+			body: map[string]interface{}{
+				"userID": goframework.NumberUUID(1).String(),
+				"reason": "spam",
+			},
+			caller:            "moderation",
+			shouldCallService: true,
+			shouldCallServiceWith: models.SuspendUserForm{
+				UserID: goframework.NumberUUID(1),
+				Reason: "spam",
+			},
+			serviceErr:   bunovel.ErrNotFound,
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name: "Error/InternalError",
+			body: map[string]interface{}{
+				"userID": goframework.NumberUUID(1).String(),
+				"reason": "spam",
+			},
+			caller:            "moderation",
+			shouldCallService: true,
+			shouldCallServiceWith: models.SuspendUserForm{
+				UserID: goframework.NumberUUID(1),
+				Reason: "spam",
+			},
+			serviceErr:   fooErr,
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewSuspendUserService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Set(handlers.InternalCallerKey, d.caller)
+
+			if d.shouldCallService {
+				service.
+					On("SuspendUser", c, mock.MatchedBy(func(form models.SuspendUserForm) bool {
+						return form.UserID == d.shouldCallServiceWith.UserID &&
+							form.Reason == d.shouldCallServiceWith.Reason &&
+							lo.FromPtr(form.Until).Equal(lo.FromPtr(d.shouldCallServiceWith.Until))
+					}), d.caller, mock.Anything).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewSuspendUserHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type UnlockAccountHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewUnlockAccountHandler(service services.UnlockAccountService) UnlockAccountHandler {
+	return &unlockAccountHandlerImpl{
+		service: service,
+	}
+}
+
+type unlockAccountHandlerImpl struct {
+	service services.UnlockAccountService
+}
+
+func (h *unlockAccountHandlerImpl) Handle(c *gin.Context) {
+	form := new(models.UnlockAccountForm)
+	if err := c.BindJSON(form); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.UnlockAccount(c, form.Email, time.Now()); err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
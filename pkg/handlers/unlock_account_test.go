@@ -0,0 +1,90 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUnlockAccountHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		body interface{}
+
+		shouldCallService     bool
+		shouldCallServiceWith string
+		serviceErr            error
+
+		expectStatus int
+	}{
+		{
+			name: "Success",
+			body: map[string]interface{}{
+				"email": "user@domain.com",
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: "user@domain.com",
+			expectStatus:          http.StatusNoContent,
+		},
+		{
+			name: "Error/BadForm",
+			body: map[string]interface{}{
+				"email": 123456,
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Error/InvalidEntity",
+			body: map[string]interface{}{
+				"email": "user@domain.com",
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: "user@domain.com",
+			serviceErr:            goframework.ErrInvalidEntity,
+			expectStatus:          http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Error/InternalError",
+			body: map[string]interface{}{
+				"email": "user@domain.com",
+			},
+			shouldCallService:     true,
+			shouldCallServiceWith: "user@domain.com",
+			serviceErr:            fooErr,
+			expectStatus:          http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewUnlockAccountService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+
+			if d.shouldCallService {
+				service.On("UnlockAccount", c, d.shouldCallServiceWith, mock.Anything).Return(d.serviceErr)
+			}
+
+			handler := handlers.NewUnlockAccountHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+
+			service.AssertExpectations(t)
+		})
+	}
+}
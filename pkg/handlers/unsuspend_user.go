@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type UnsuspendUserHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewUnsuspendUserHandler(service services.UnsuspendUserService) UnsuspendUserHandler {
+	return &unsuspendUserHandlerImpl{
+		service: service,
+	}
+}
+
+type unsuspendUserHandlerImpl struct {
+	service services.UnsuspendUserService
+}
+
+func (h *unsuspendUserHandlerImpl) Handle(c *gin.Context) {
+	form := new(models.UnsuspendUserForm)
+	if err := c.BindJSON(form); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.UnsuspendUser(c, form.UserID, c.GetString(InternalCallerKey), time.Now()); err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+			{bunovel.ErrNotFound, http.StatusNotFound},
+		}, false)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
@@ -33,9 +33,13 @@ func (h *updateEmailHandlerImpl) Handle(c *gin.Context) {
 		return
 	}
 
-	deferred, err := h.service.UpdateEmail(c, token, request.NewEmail, time.Now())
+	deferred, err := h.service.UpdateEmail(c, token, request.NewEmail, request.Password, getClientInfo(c), time.Now())
 	if err != nil {
+		setRetryAfter(c, err)
+
 		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrTooManyAttempts, http.StatusTooManyRequests},
+			{services.ErrStepUpRequired, http.StatusUnauthorized},
 			{goframework.ErrInvalidCredentials, http.StatusForbidden},
 			{services.ErrTaken, http.StatusConflict},
 			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
@@ -3,7 +3,9 @@ package handlers_test
 import (
 	"bytes"
 	"encoding/json"
+	goerrors "errors"
 	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
 	"github.com/a-novel/auth-service/pkg/services"
 	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
 	goframework "github.com/a-novel/go-framework"
@@ -13,6 +15,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestUpdateEmailHandler(t *testing.T) {
@@ -23,12 +26,14 @@ func TestUpdateEmailHandler(t *testing.T) {
 
 		body interface{}
 
-		shouldCallService          bool
-		shouldCallServiceWithEmail string
+		shouldCallService             bool
+		shouldCallServiceWithEmail    string
+		shouldCallServiceWithPassword string
 
 		serviceErr error
 
-		expectStatus int
+		expectStatus     int
+		expectRetryAfter string
 	}{
 		{
 			name:          "Success",
@@ -40,6 +45,41 @@ func TestUpdateEmailHandler(t *testing.T) {
 			shouldCallServiceWithEmail: "new-email",
 			expectStatus:               http.StatusAccepted,
 		},
+		{
+			name:          "Success/WithPassword",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"newEmail": "new-email",
+				"password": "password",
+			},
+			shouldCallService:             true,
+			shouldCallServiceWithEmail:    "new-email",
+			shouldCallServiceWithPassword: "password",
+			expectStatus:                  http.StatusAccepted,
+		},
+		{
+			name:          "Error/ErrTooManyAttempts",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"newEmail": "new-email",
+			},
+			shouldCallService:          true,
+			shouldCallServiceWithEmail: "new-email",
+			serviceErr:                 &services.TooManyAttemptsError{RetryAfter: 1500 * time.Millisecond},
+			expectStatus:               http.StatusTooManyRequests,
+			expectRetryAfter:           "2",
+		},
+		{
+			name:          "Error/ErrStepUpRequired",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"newEmail": "new-email",
+			},
+			shouldCallService:          true,
+			shouldCallServiceWithEmail: "new-email",
+			serviceErr:                 goerrors.Join(goframework.ErrInvalidCredentials, services.ErrStepUpRequired),
+			expectStatus:               http.StatusUnauthorized,
+		},
 		{
 			name:          "Error/ErrInvalidCredentials",
 			authorization: "Bearer my-token",
@@ -85,11 +125,12 @@ func TestUpdateEmailHandler(t *testing.T) {
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("User-Agent", "Mozilla/5.0")
 			c.Request.Header.Set("Authorization", d.authorization)
 
 			if d.shouldCallService {
 				service.
-					On("UpdateEmail", c, d.authorization, d.shouldCallServiceWithEmail, mock.Anything).
+					On("UpdateEmail", c, d.authorization, d.shouldCallServiceWithEmail, d.shouldCallServiceWithPassword, models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "192.0.2.1"}, mock.Anything).
 					Return(nil, d.serviceErr)
 			}
 
@@ -97,6 +138,7 @@ func TestUpdateEmailHandler(t *testing.T) {
 			handler.Handle(c)
 
 			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			require.Equal(t, d.expectRetryAfter, w.Header().Get("Retry-After"))
 
 			service.AssertExpectations(t)
 		})
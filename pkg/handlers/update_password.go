@@ -32,8 +32,16 @@ func (h *updatePasswordHandlerImpl) Handle(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.UpdatePassword(c, *request, time.Now()); err != nil {
+	if err := h.service.UpdatePassword(c, *request, getClientInfo(c), time.Now()); err != nil {
+		if abortWithPasswordPolicyError(c, err) {
+			return
+		}
+
+		setRetryAfter(c, err)
+
 		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrTooManyAttempts, http.StatusTooManyRequests},
+			{services.ErrValidationCodeExpired, http.StatusGone},
 			{goframework.ErrInvalidCredentials, http.StatusForbidden},
 			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
 			{bunovel.ErrNotFound, http.StatusForbidden},
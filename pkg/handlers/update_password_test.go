@@ -3,8 +3,10 @@ package handlers_test
 import (
 	"bytes"
 	"encoding/json"
+	goerrors "errors"
 	"github.com/a-novel/auth-service/pkg/handlers"
 	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
 	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
 	"github.com/a-novel/bunovel"
 	goframework "github.com/a-novel/go-framework"
@@ -14,6 +16,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestUpdatePasswordHandler(t *testing.T) {
@@ -27,7 +30,9 @@ func TestUpdatePasswordHandler(t *testing.T) {
 
 		serviceErr error
 
-		expectStatus int
+		expect           interface{}
+		expectStatus     int
+		expectRetryAfter string
 	}{
 		{
 			name: "Success",
@@ -46,6 +51,41 @@ func TestUpdatePasswordHandler(t *testing.T) {
 			},
 			expectStatus: http.StatusCreated,
 		},
+		{
+			name: "Error/ErrTooManyAttempts",
+			body: map[string]interface{}{
+				"id":          goframework.NumberUUID(1).String(),
+				"oldPassword": "old-password",
+				"newPassword": "new-password",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				OldPassword: "old-password",
+				NewPassword: "new-password",
+			},
+			serviceErr:       &services.TooManyAttemptsError{RetryAfter: 1500 * time.Millisecond},
+			expectStatus:     http.StatusTooManyRequests,
+			expectRetryAfter: "2",
+		},
+		{
+			name: "Error/ErrValidationCodeExpired",
+			body: map[string]interface{}{
+				"id":          goframework.NumberUUID(1).String(),
+				"code":        "validation-code",
+				"oldPassword": "old-password",
+				"newPassword": "new-password",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				Code:        "validation-code",
+				OldPassword: "old-password",
+				NewPassword: "new-password",
+			},
+			serviceErr:   goerrors.Join(goframework.ErrInvalidCredentials, services.ErrValidationCodeExpired),
+			expectStatus: http.StatusGone,
+		},
 		{
 			name: "Error/ErrInvalidCredentials",
 			body: map[string]interface{}{
@@ -82,6 +122,28 @@ func TestUpdatePasswordHandler(t *testing.T) {
 			serviceErr:   goframework.ErrInvalidEntity,
 			expectStatus: http.StatusUnprocessableEntity,
 		},
+		{
+			name: "Error/PasswordPolicy",
+			body: map[string]interface{}{
+				"id":          goframework.NumberUUID(1).String(),
+				"code":        "validation-code",
+				"oldPassword": "old-password",
+				"newPassword": "new-password",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				Code:        "validation-code",
+				OldPassword: "old-password",
+				NewPassword: "new-password",
+			},
+			serviceErr: goerrors.Join(
+				goframework.ErrInvalidEntity,
+				&services.PasswordPolicyError{Rules: []string{services.PasswordRuleStrength, services.PasswordRuleBreached}},
+			),
+			expect:       map[string]interface{}{"passwordRules": []interface{}{"strength", "breached"}},
+			expectStatus: http.StatusUnprocessableEntity,
+		},
 		{
 			name: "Error/ErrNotFound",
 			body: map[string]interface{}{
@@ -112,10 +174,11 @@ func TestUpdatePasswordHandler(t *testing.T) {
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("User-Agent", "Mozilla/5.0")
 
 			if d.shouldCallService {
 				service.
-					On("UpdatePassword", c, d.shouldCallServiceWith, mock.Anything).
+					On("UpdatePassword", c, d.shouldCallServiceWith, models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "192.0.2.1"}, mock.Anything).
 					Return(d.serviceErr)
 			}
 
@@ -123,6 +186,12 @@ func TestUpdatePasswordHandler(t *testing.T) {
 			handler.Handle(c)
 
 			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			require.Equal(t, d.expectRetryAfter, w.Header().Get("Retry-After"))
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
 
 			service.AssertExpectations(t)
 		})
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+// getClientInfo reads the information about the device that sent the request.
+func getClientInfo(c *gin.Context) models.ClientInfo {
+	return models.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+}
+
+// bindOptionalJSON reads the JSON body of the request into obj. Requests without a body leave obj untouched.
+func bindOptionalJSON(c *gin.Context, obj interface{}) error {
+	if c.Request.ContentLength == 0 {
+		return nil
+	}
+
+	return c.BindJSON(obj)
+}
+
+// abortWithPasswordPolicyError responds with the password rules that failed, so they can be displayed to the user.
+// It returns false if the error does not come from the password policy.
+func abortWithPasswordPolicyError(c *gin.Context, err error) bool {
+	var passwordPolicyErr *services.PasswordPolicyError
+	if !goerrors.As(err, &passwordPolicyErr) {
+		return false
+	}
+
+	_ = c.Error(err)
+	c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"passwordRules": passwordPolicyErr.Rules})
+	return true
+}
+
+// setRetryAfter tells the client when to retry a throttled request. It does nothing if the error does not come from
+// the login throttle.
+func setRetryAfter(c *gin.Context, err error) {
+	var tooManyAttemptsErr *services.TooManyAttemptsError
+	if goerrors.As(err, &tooManyAttemptsErr) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(tooManyAttemptsErr.RetryAfter.Seconds()))))
+	}
+}
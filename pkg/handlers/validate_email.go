@@ -3,6 +3,7 @@ package handlers
 import (
 	"github.com/a-novel/auth-service/pkg/models"
 	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
 	"github.com/a-novel/go-apis"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/gin-gonic/gin"
@@ -31,10 +32,12 @@ func (h *validateEmailHandlerImpl) Handle(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.ValidateEmail(c, query.ID.Value(), query.Code, time.Now()); err != nil {
+	if err := h.service.ValidateEmail(c, query.ID.Value(), query.Code, getClientInfo(c), time.Now()); err != nil {
 		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrValidationCodeExpired, http.StatusGone},
 			{goframework.ErrInvalidCredentials, http.StatusForbidden},
 			{goframework.ErrInvalidEntity, http.StatusForbidden},
+			{bunovel.ErrNotFound, http.StatusForbidden},
 		}, false)
 		return
 	}
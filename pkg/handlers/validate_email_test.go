@@ -1,8 +1,11 @@
 package handlers_test
 
 import (
+	goerrors "errors"
 	"fmt"
 	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
 	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/gin-gonic/gin"
@@ -33,6 +36,14 @@ func TestValidateEmailHandler(t *testing.T) {
 			shouldCallService: true,
 			expectStatus:      http.StatusNoContent,
 		},
+		{
+			name:              "Error/ErrValidationCodeExpired",
+			id:                goframework.NumberUUID(1).String(),
+			code:              "validation-code",
+			shouldCallService: true,
+			serviceErr:        goerrors.Join(goframework.ErrInvalidCredentials, services.ErrValidationCodeExpired),
+			expectStatus:      http.StatusGone,
+		},
 		{
 			name:              "Error/ErrInvalidCredentials",
 			id:                goframework.NumberUUID(1).String(),
@@ -58,9 +69,10 @@ func TestValidateEmailHandler(t *testing.T) {
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 			c.Request = httptest.NewRequest("GET", fmt.Sprintf("/?id=%s&code=%s", d.id, d.code), nil)
+			c.Request.Header.Set("User-Agent", "Mozilla/5.0")
 
 			if d.shouldCallService {
-				service.On("ValidateEmail", c, uuid.MustParse(d.id), d.code, mock.Anything).Return(d.serviceErr)
+				service.On("ValidateEmail", c, uuid.MustParse(d.id), d.code, models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "192.0.2.1"}, mock.Anything).Return(d.serviceErr)
 			}
 
 			handler := handlers.NewValidateEmailHandler(service)
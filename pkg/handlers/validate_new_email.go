@@ -32,11 +32,13 @@ func (h *validateNewEmailHandlerImpl) Handle(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.ValidateNewEmail(c, query.ID.Value(), query.Code, time.Now()); err != nil {
+	if err := h.service.ValidateNewEmail(c, query.ID.Value(), query.Code, getClientInfo(c), time.Now()); err != nil {
 		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrValidationCodeExpired, http.StatusGone},
 			{goframework.ErrInvalidCredentials, http.StatusForbidden},
 			{goframework.ErrInvalidEntity, http.StatusForbidden},
 			{bunovel.ErrUniqConstraintViolation, http.StatusConflict},
+			{bunovel.ErrNotFound, http.StatusForbidden},
 		}, false)
 		return
 	}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type VerifyMFAHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewVerifyMFAHandler(service services.VerifyMFAService) VerifyMFAHandler {
+	return &verifyMFAHandlerImpl{service: service}
+}
+
+type verifyMFAHandlerImpl struct {
+	service services.VerifyMFAService
+}
+
+func (h *verifyMFAHandlerImpl) Handle(c *gin.Context) {
+	request := new(models.VerifyMFAForm)
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.service.VerifyMFA(c, request.Challenge, request.Code, getClientInfo(c), time.Now())
+	if err != nil {
+		setRetryAfter(c, err)
+
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrAccountLocked, http.StatusLocked},
+			{services.ErrAccountDeleted, http.StatusGone},
+			{services.ErrTooManyAttempts, http.StatusTooManyRequests},
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token.TokenRaw, "refreshToken": token.RefreshToken})
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type VerifyMFAPasskeyHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewVerifyMFAPasskeyHandler(service services.VerifyMFAPasskeyService) VerifyMFAPasskeyHandler {
+	return &verifyMFAPasskeyHandlerImpl{service: service}
+}
+
+type verifyMFAPasskeyHandlerImpl struct {
+	service services.VerifyMFAPasskeyService
+}
+
+func (h *verifyMFAPasskeyHandlerImpl) Handle(c *gin.Context) {
+	request := new(models.VerifyMFAPasskeyForm)
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.service.VerifyMFAPasskey(c, request.Challenge, request.Credential, getClientInfo(c), time.Now())
+	if err != nil {
+		setRetryAfter(c, err)
+
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrAccountLocked, http.StatusLocked},
+			{services.ErrAccountDeleted, http.StatusGone},
+			{services.ErrTooManyAttempts, http.StatusTooManyRequests},
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token.TokenRaw, "refreshToken": token.RefreshToken})
+}
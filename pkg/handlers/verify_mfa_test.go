@@ -0,0 +1,169 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/handlers"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestVerifyMFAHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		body interface{}
+
+		shouldCallService              bool
+		shouldCallServiceWithChallenge string
+		shouldCallServiceWithCode      string
+
+		serviceResp *models.UserTokenStatus
+		serviceErr  error
+
+		expect           interface{}
+		expectStatus     int
+		expectRetryAfter string
+	}{
+		{
+			name: "Success",
+			body: map[string]interface{}{
+				"challenge": "challenge",
+				"code":      "123456",
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithChallenge: "challenge",
+			shouldCallServiceWithCode:      "123456",
+			serviceResp:                    &models.UserTokenStatus{TokenRaw: "token", RefreshToken: "refresh-token"},
+			expect:                         map[string]interface{}{"token": "token", "refreshToken": "refresh-token"},
+			expectStatus:                   http.StatusOK,
+		},
+		{
+			name: "Error/BadForm",
+			body: map[string]interface{}{
+				"challenge": 123,
+				"code":      "123456",
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Error/ErrAccountLocked",
+			body: map[string]interface{}{
+				"challenge": "challenge",
+				"code":      "123456",
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithChallenge: "challenge",
+			shouldCallServiceWithCode:      "123456",
+			serviceErr:                     goerrors.Join(goframework.ErrInvalidCredentials, services.ErrAccountLocked),
+			expectStatus:                   http.StatusLocked,
+		},
+		{
+			name: "Error/ErrAccountDeleted",
+			body: map[string]interface{}{
+				"challenge": "challenge",
+				"code":      "123456",
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithChallenge: "challenge",
+			shouldCallServiceWithCode:      "123456",
+			serviceErr:                     goerrors.Join(goframework.ErrInvalidCredentials, services.ErrAccountDeleted),
+			expectStatus:                   http.StatusGone,
+		},
+		{
+			name: "Error/ErrInvalidCredentials",
+			body: map[string]interface{}{
+				"challenge": "challenge",
+				"code":      "123456",
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithChallenge: "challenge",
+			shouldCallServiceWithCode:      "123456",
+			serviceErr:                     goframework.ErrInvalidCredentials,
+			expectStatus:                   http.StatusForbidden,
+		},
+		{
+			name: "Error/TooManyAttempts",
+			body: map[string]interface{}{
+				"challenge": "challenge",
+				"code":      "123456",
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithChallenge: "challenge",
+			shouldCallServiceWithCode:      "123456",
+			serviceErr:                     &services.TooManyAttemptsError{RetryAfter: 1500 * time.Millisecond},
+			expectStatus:                   http.StatusTooManyRequests,
+			expectRetryAfter:               "2",
+		},
+		{
+			name: "Error/ErrInvalidEntity",
+			body: map[string]interface{}{
+				"challenge": "challenge",
+				"code":      "123456",
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithChallenge: "challenge",
+			shouldCallServiceWithCode:      "123456",
+			serviceErr:                     goframework.ErrInvalidEntity,
+			expectStatus:                   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Error/ErrFoo",
+			body: map[string]interface{}{
+				"challenge": "challenge",
+				"code":      "123456",
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithChallenge: "challenge",
+			shouldCallServiceWithCode:      "123456",
+			serviceErr:                     fooErr,
+			expectStatus:                   http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewVerifyMFAService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("User-Agent", "Mozilla/5.0")
+
+			if d.shouldCallService {
+				service.
+					On("VerifyMFA", c, d.shouldCallServiceWithChallenge, d.shouldCallServiceWithCode, models.ClientInfo{
+						UserAgent: "Mozilla/5.0",
+						IP:        "192.0.2.1",
+					}, mock.Anything).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewVerifyMFAHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			require.Equal(t, d.expectRetryAfter, w.Header().Get("Retry-After"))
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// AdminUser is the full state of a user, as seen by support staff through the admin API.
+type AdminUser struct {
+	ID          uuid.UUID        `json:"id"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   *time.Time       `json:"updatedAt,omitempty"`
+	Credentials AdminCredentials `json:"credentials"`
+	Identity    Identity         `json:"identity"`
+	Profile     Profile          `json:"profile"`
+}
+
+// AdminCredentials describes the credentials of a user. Codes are never exposed: only whether they are pending.
+type AdminCredentials struct {
+	Email    string `json:"email"`
+	NewEmail string `json:"newEmail,omitempty"`
+	// Validated is true once the main email of the user has been validated.
+	Validated bool `json:"validated"`
+	// PendingEmailValidation is true when a validation code was sent to the main email, and not used yet.
+	PendingEmailValidation bool `json:"pendingEmailValidation"`
+	// PendingNewEmailValidation is true when a validation code was sent to the new email, and not used yet.
+	PendingNewEmailValidation bool `json:"pendingNewEmailValidation"`
+	// PendingPasswordReset is true when a password reset code was sent, and not used yet.
+	PendingPasswordReset bool       `json:"pendingPasswordReset"`
+	LockedAt             *time.Time `json:"lockedAt,omitempty"`
+	DeletedAt            *time.Time `json:"deletedAt,omitempty"`
+	// Suspension is set when the user is suspended.
+	Suspension *Suspension `json:"suspension,omitempty"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// AuditEvent is a security relevant change, as recorded in the audit log.
+type AuditEvent struct {
+	ID   uuid.UUID `json:"id"`
+	Kind string    `json:"kind"`
+	// Actor is the identity of the internal caller that made the change. It is omitted when the change was made by
+	// the targeted user themselves.
+	Actor string `json:"actor,omitempty"`
+	// UserID is the ID of the targeted user. It is omitted for changes that do not target a user.
+	UserID    *uuid.UUID        `json:"userID,omitempty"`
+	IP        string            `json:"ip,omitempty"`
+	UserAgent string            `json:"userAgent,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+// SecurityActivity is an audit event, as shown to the user it targets.
+type SecurityActivity struct {
+	Kind      string    `json:"kind"`
+	IP        string    `json:"ip,omitempty"`
+	UserAgent string    `json:"userAgent,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Admin is true when the change was made by the support team, rather than by the user.
+	Admin bool `json:"admin"`
+}
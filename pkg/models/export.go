@@ -0,0 +1,84 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// DataExportVersion is the version of the personal data archive format. It MUST be increased whenever the content
+// of the archive changes in a way that is not backward compatible, so consumers can tell formats apart.
+const DataExportVersion = 1
+
+const (
+	DataExportManifestFile     = "manifest.json"
+	DataExportCredentialsFile  = "credentials.json"
+	DataExportIdentityFile     = "identity.json"
+	DataExportProfileFile      = "profile.json"
+	DataExportSessionsFile     = "sessions.json"
+	DataExportLoginHistoryFile = "login_history.json"
+	DataExportConsentsFile     = "consents.json"
+)
+
+// DataExportManifest describes the content of a personal data archive.
+type DataExportManifest struct {
+	Version     int       `json:"version"`
+	UserID      uuid.UUID `json:"userId"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Files lists the other files of the archive.
+	Files []string `json:"files"`
+}
+
+// DataExportCredentials holds the credentials of a user, without any hash or secret code.
+type DataExportCredentials struct {
+	Email          string     `json:"email"`
+	EmailValidated bool       `json:"emailValidated"`
+	NewEmail       string     `json:"newEmail,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      *time.Time `json:"updatedAt,omitempty"`
+	LockedAt       *time.Time `json:"lockedAt,omitempty"`
+	DeletedAt      *time.Time `json:"deletedAt,omitempty"`
+}
+
+type DataExportIdentity struct {
+	FirstName string     `json:"firstName"`
+	LastName  string     `json:"lastName"`
+	Sex       Sex        `json:"sex"`
+	Birthday  time.Time  `json:"birthday"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+type DataExportProfile struct {
+	Username  string     `json:"username"`
+	Slug      string     `json:"slug"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// DataExportSession is a session of the user, including the revoked ones.
+type DataExportSession struct {
+	ID         uuid.UUID  `json:"id"`
+	UserAgent  string     `json:"userAgent,omitempty"`
+	IP         string     `json:"ip,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastSeenAt time.Time  `json:"lastSeenAt"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// DataExportLoginAttempt is a login attempt on the account. Successful attempts are the sessions of the user.
+type DataExportLoginAttempt struct {
+	Date      time.Time `json:"date"`
+	IP        string    `json:"ip,omitempty"`
+	UserAgent string    `json:"userAgent,omitempty"`
+	Success   bool      `json:"success"`
+}
+
+// DataExportConsent is a consent given, or withdrawn, by the user.
+//
+// This service does not collect any consent yet, so the exported list is always empty. The file is still part of the
+// archive, so its format does not change once consents are recorded.
+type DataExportConsent struct {
+	Purpose     string     `json:"purpose"`
+	GivenAt     time.Time  `json:"givenAt"`
+	WithdrawnAt *time.Time `json:"withdrawnAt,omitempty"`
+}
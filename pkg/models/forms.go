@@ -10,6 +10,10 @@ type LoginForm struct {
 	Password string `json:"password" form:"password"`
 }
 
+type RefreshTokenForm struct {
+	RefreshToken string `json:"refreshToken" form:"refreshToken"`
+}
+
 type RegisterForm struct {
 	Email    string `json:"email" form:"email"`
 	Password string `json:"password" form:"password"`
@@ -25,6 +29,8 @@ type RegisterForm struct {
 
 type UpdateEmailForm struct {
 	NewEmail string `json:"newEmail" form:"newEmail"`
+	// Password is only required if the user did not authenticate recently.
+	Password string `json:"password" form:"password"`
 }
 
 type UpdateIdentityForm struct {
@@ -45,3 +51,96 @@ type UpdatePasswordForm struct {
 	OldPassword string    `json:"oldPassword" form:"oldPassword"`
 	NewPassword string    `json:"newPassword" form:"newPassword"`
 }
+
+type RevokeUserTokensForm struct {
+	UserID uuid.UUID `json:"userID" form:"userID"`
+}
+
+type SuspendUserForm struct {
+	UserID uuid.UUID `json:"userID" form:"userID"`
+	Reason string    `json:"reason" form:"reason"`
+	// Until is the date the suspension ends. Leave it empty to suspend the user until they are unsuspended.
+	Until *time.Time `json:"until" form:"until"`
+}
+
+type UnsuspendUserForm struct {
+	UserID uuid.UUID `json:"userID" form:"userID"`
+}
+
+type RevokeSignatureKeyForm struct {
+	Name string `json:"name" form:"name"`
+}
+
+type UnlockAccountForm struct {
+	Email string `json:"email" form:"email"`
+}
+
+type SecondFactorForm struct {
+	Code string `json:"code" form:"code"`
+}
+
+type DisableTOTPForm struct {
+	Code string `json:"code" form:"code"`
+	// Password is only required if the user did not authenticate recently.
+	Password string `json:"password" form:"password"`
+}
+
+// ReauthenticationForm carries the current password of the user, for sensitive operations. It can be omitted if the
+// user authenticated recently.
+type ReauthenticationForm struct {
+	Password string `json:"password" form:"password"`
+}
+
+type VerifyMFAForm struct {
+	Challenge string `json:"challenge" form:"challenge"`
+	Code      string `json:"code" form:"code"`
+}
+
+type LoginLinkForm struct {
+	Email string `json:"email" form:"email"`
+}
+
+type ConsumeLoginLinkForm struct {
+	ID   uuid.UUID `json:"id" form:"id"`
+	Code string    `json:"code" form:"code"`
+}
+
+type MFAChallengeForm struct {
+	Challenge string `json:"challenge" form:"challenge"`
+}
+
+// PasskeyAttestationForm is the JSON serialization of the credential returned by navigator.credentials.create().
+// Binary values are encoded in base64url, without padding.
+type PasskeyAttestationForm struct {
+	ID       string                     `json:"id" form:"id"`
+	RawID    string                     `json:"rawId" form:"rawId"`
+	Type     string                     `json:"type" form:"type"`
+	Response PasskeyAttestationResponse `json:"response" form:"response"`
+}
+
+type PasskeyAttestationResponse struct {
+	ClientDataJSON    string   `json:"clientDataJSON" form:"clientDataJSON"`
+	AttestationObject string   `json:"attestationObject" form:"attestationObject"`
+	Transports        []string `json:"transports" form:"transports"`
+}
+
+// PasskeyAssertionForm is the JSON serialization of the credential returned by navigator.credentials.get().
+// Binary values are encoded in base64url, without padding.
+type PasskeyAssertionForm struct {
+	ID       string                   `json:"id" form:"id"`
+	RawID    string                   `json:"rawId" form:"rawId"`
+	Type     string                   `json:"type" form:"type"`
+	Response PasskeyAssertionResponse `json:"response" form:"response"`
+}
+
+type PasskeyAssertionResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON" form:"clientDataJSON"`
+	AuthenticatorData string `json:"authenticatorData" form:"authenticatorData"`
+	Signature         string `json:"signature" form:"signature"`
+	UserHandle        string `json:"userHandle" form:"userHandle"`
+}
+
+type VerifyMFAPasskeyForm struct {
+	Challenge  string               `json:"challenge" form:"challenge"`
+	Credential PasskeyAssertionForm `json:"credential" form:"credential"`
+}
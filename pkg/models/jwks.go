@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// JSONWebKey is the public part of a signature key, in the format described by RFC 7517. Keys issued by this service
+// are Ed25519 keys, represented as Octet Key Pairs (RFC 8037).
+type JSONWebKey struct {
+	// KTY is the family of the key. Always "OKP" for Ed25519 keys.
+	KTY string `json:"kty"`
+	// CRV is the curve used by the key. Always "Ed25519".
+	CRV string `json:"crv"`
+	// X is the base64url encoded public key.
+	X string `json:"x"`
+	// KID uniquely identifies the key within the set. Tokens reference it in their header.
+	KID string `json:"kid"`
+	// Use is the intended use of the key. Always "sig".
+	Use string `json:"use"`
+	// Alg is the signature algorithm the key is used with. Always "EdDSA".
+	Alg string `json:"alg"`
+}
+
+// JSONWebKeySet lists every public key that may have been used to sign a valid token.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// RevokedSignatureKey describes a signature key that is no longer trusted.
+type RevokedSignatureKey struct {
+	// Name of the record that stores the key.
+	Name string `json:"name"`
+	// KID is the ID of the key, as it was published in the JSONWebKeySet.
+	KID string `json:"kid"`
+	// RevokedAt is the date of the revocation.
+	RevokedAt time.Time `json:"revokedAt"`
+	// LiveSessions is the number of sessions that still hold a valid token signed with the key. Those tokens are
+	// rejected, but the sessions can obtain new ones with their refresh token, unless they are revoked too.
+	LiveSessions int `json:"liveSessions"`
+	// Rotated is true if a new key had to be activated right away, because the revoked key was the last active one.
+	Rotated bool `json:"rotated"`
+}
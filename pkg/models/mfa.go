@@ -0,0 +1,12 @@
+package models
+
+// TOTPEnrollment is returned when a user enrolls in two-factor authentication. The secret and the recovery codes
+// are only shown once.
+type TOTPEnrollment struct {
+	// URI registers the secret in an authenticator. It is usually shown as a QR code.
+	URI string `json:"uri"`
+	// Secret is the base32 encoded secret, for authenticators that cannot read the URI.
+	Secret string `json:"secret"`
+	// RecoveryCodes can each be used once, in place of a TOTP code.
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
@@ -0,0 +1,55 @@
+package models
+
+// PasskeyCreationOptions are passed to navigator.credentials.create(), once decoded with
+// PublicKeyCredential.parseCreationOptionsFromJSON(). Binary values are encoded in base64url, without padding.
+type PasskeyCreationOptions struct {
+	Challenge              string                        `json:"challenge"`
+	RP                     PasskeyRelyingParty           `json:"rp"`
+	User                   PasskeyUser                   `json:"user"`
+	PubKeyCredParams       []PasskeyCredentialParameters `json:"pubKeyCredParams"`
+	Timeout                int64                         `json:"timeout"`
+	ExcludeCredentials     []PasskeyCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection PasskeyAuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                        `json:"attestation"`
+}
+
+// PasskeyRequestOptions are passed to navigator.credentials.get(), once decoded with
+// PublicKeyCredential.parseRequestOptionsFromJSON(). Binary values are encoded in base64url, without padding.
+type PasskeyRequestOptions struct {
+	Challenge string `json:"challenge"`
+	Timeout   int64  `json:"timeout"`
+	RPID      string `json:"rpId"`
+	// AllowCredentials is empty when the user is not known yet, so the authenticator proposes every passkey
+	// registered for the service.
+	AllowCredentials []PasskeyCredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                        `json:"userVerification"`
+}
+
+type PasskeyRelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type PasskeyUser struct {
+	// ID is the user handle, returned by the authenticator on login.
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type PasskeyCredentialParameters struct {
+	Type string `json:"type"`
+	// Alg is a COSE algorithm identifier.
+	Alg int `json:"alg"`
+}
+
+type PasskeyCredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+type PasskeyAuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
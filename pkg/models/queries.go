@@ -2,6 +2,7 @@ package models
 
 import (
 	"github.com/a-novel/go-apis"
+	"time"
 )
 
 type ListQuery struct {
@@ -14,6 +15,34 @@ type SearchQuery struct {
 	Offset int    `json:"offset" form:"offset"`
 }
 
+// AdminLookupUserQuery selects a user by exactly one of its ID, email or slug.
+type AdminLookupUserQuery struct {
+	ID    string `json:"id" form:"id"`
+	Email string `json:"email" form:"email"`
+	Slug  string `json:"slug" form:"slug"`
+}
+
+type ListSuspendedUsersQuery struct {
+	Limit  int `json:"limit" form:"limit"`
+	Offset int `json:"offset" form:"offset"`
+}
+
+type ListAuditEventsQuery struct {
+	UserID apis.StringUUID `json:"userID" form:"userID"`
+	Kinds  []string        `json:"kind" form:"kind"`
+	Actor  string          `json:"actor" form:"actor"`
+	// Since and Until bound the creation date of the events. Since is inclusive, Until is exclusive.
+	Since  time.Time `json:"since" form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Until  time.Time `json:"until" form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit  int       `json:"limit" form:"limit"`
+	Offset int       `json:"offset" form:"offset"`
+}
+
+type ListSecurityActivityQuery struct {
+	Limit  int `json:"limit" form:"limit"`
+	Offset int `json:"offset" form:"offset"`
+}
+
 type ValidateEmailQuery struct {
 	ID   apis.StringUUID `json:"id" form:"id"`
 	Code string          `json:"code" form:"code"`
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ClientInfo describes the device used to open a session.
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+// Session represents a device on which a user is logged in.
+type Session struct {
+	ID uuid.UUID `json:"id"`
+	// UserAgent of the client that opened the session.
+	UserAgent string `json:"userAgent,omitempty"`
+	// IP of the client that opened the session.
+	IP string `json:"ip,omitempty"`
+	// CreatedAt is the date of the login.
+	CreatedAt time.Time `json:"createdAt"`
+	// LastSeenAt is the date of the last known activity on the session.
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	// Current is true for the session used to make the request.
+	Current bool `json:"current"`
+}
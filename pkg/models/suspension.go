@@ -0,0 +1,19 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Suspension describes an active suspension of a user.
+type Suspension struct {
+	UserID uuid.UUID `json:"userID"`
+	Email  string    `json:"email"`
+	// Reason of the suspension, as given by the moderator.
+	Reason string `json:"reason"`
+	// SuspendedBy is the identity of the internal caller that suspended the user.
+	SuspendedBy string    `json:"suspendedBy"`
+	SuspendedAt time.Time `json:"suspendedAt"`
+	// SuspendedUntil is the date the suspension ends. It is omitted for suspensions without expiration.
+	SuspendedUntil *time.Time `json:"suspendedUntil,omitempty"`
+}
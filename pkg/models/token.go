@@ -15,10 +15,21 @@ type UserTokenStatus struct {
 	NotIssued bool `json:"notIssued"`
 	// Malformed is true if the token is not a valid JWT.
 	Malformed bool `json:"malformed"`
+	// Revoked is true if the token was revoked before its expiration date, for example after a logout.
+	Revoked bool `json:"revoked"`
+	// Suspended is true if the owner of the token is suspended. The token becomes valid again once the suspension
+	// ends, if it has not expired in the meantime.
+	Suspended bool `json:"suspended"`
 	// Token contains the decoded token, if decoding was successful.
 	Token *UserToken `json:"token,omitempty"`
 	// TokenRaw is the original token sent in the headers.
 	TokenRaw string `json:"tokenRaw,omitempty"`
+	// RefreshToken is an opaque, single-use token, that can be exchanged for a new access token. It is only set
+	// when a new session is created, or when the previous refresh token is used.
+	RefreshToken string `json:"refreshToken,omitempty"`
+	// MFAChallenge is set instead of the tokens when the credentials are valid, but the user must also provide a
+	// second factor. It is exchanged for the tokens once the second factor is verified.
+	MFAChallenge string `json:"mfaChallenge,omitempty"`
 }
 
 type UserTokenHeader struct {
@@ -28,11 +39,27 @@ type UserTokenHeader struct {
 	EXP time.Time `json:"exp"`
 	// ID is a unique identifier for this token, that guarantees a unique encoded string.
 	ID uuid.UUID `json:"id"`
+	// Issuer identifies the service that issued the token.
+	Issuer string `json:"iss,omitempty"`
+	// Audience identifies the services the token is intended for.
+	Audience string `json:"aud,omitempty"`
+	// KeyID identifies the key used to sign the token. It matches one of the keys published by the service.
+	KeyID string `json:"kid,omitempty"`
 }
 
 type UserTokenPayload struct {
 	// ID of the user who owns this token.
 	ID uuid.UUID `json:"id"`
+	// FamilyID identifies the refresh token family the token was issued from. Tokens issued in the legacy format
+	// have no family.
+	FamilyID uuid.UUID `json:"familyID"`
+	// SecurityStamp is the security stamp of the user when the token was issued. The token is revoked once the
+	// stamp of the user changes. It is only meant for the service, so it is not exposed by introspection.
+	SecurityStamp uuid.UUID `json:"-"`
+	// AuthTime is the date the user last proved their identity, with their credentials, for the session of the
+	// token. It is carried over when the token is renewed or refreshed. Tokens issued in the legacy format have no
+	// authentication time.
+	AuthTime time.Time `json:"authTime"`
 }
 
 // UserToken represents the token issued to a user, for authentication.
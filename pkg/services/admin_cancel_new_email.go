@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"time"
+)
+
+type AdminCancelNewEmailService interface {
+	// AdminCancelNewEmail cancels the pending email change of a user. The actor is the identity of the internal
+	// caller, recorded with the action.
+	AdminCancelNewEmail(ctx context.Context, userID uuid.UUID, actor string, now time.Time) error
+}
+
+func NewAdminCancelNewEmailService(credentialsDAO dao.CredentialsRepository) AdminCancelNewEmailService {
+	return &adminCancelNewEmailServiceImpl{
+		credentialsDAO: credentialsDAO,
+	}
+}
+
+type adminCancelNewEmailServiceImpl struct {
+	credentialsDAO dao.CredentialsRepository
+}
+
+func (s *adminCancelNewEmailServiceImpl) AdminCancelNewEmail(ctx context.Context, userID uuid.UUID, actor string, now time.Time) error {
+	if userID == uuid.Nil {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidUserID)
+	}
+
+	return s.credentialsDAO.RunInTx(ctx, func(ctx context.Context, txClient dao.CredentialsRepository) error {
+		if _, err := txClient.CancelNewEmail(ctx, userID, now); err != nil {
+			return goerrors.Join(ErrCancelNewEmail, err)
+		}
+
+		return recordAuditEvent(ctx, txClient, &dao.AuditEventModelCore{
+			Kind:   dao.AuditEventAdminCancelNewEmail,
+			Actor:  actor,
+			UserID: userID,
+		}, now)
+	})
+}
@@ -0,0 +1,111 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestAdminCancelNewEmail(t *testing.T) {
+	data := []struct {
+		name string
+
+		userID uuid.UUID
+		actor  string
+		now    time.Time
+
+		shouldCallCancel bool
+		cancelErr        error
+
+		shouldCallRecord bool
+		recordErr        error
+
+		expectErr error
+	}{
+		{
+			name:             "Success",
+			userID:           goframework.NumberUUID(1),
+			actor:            "support",
+			now:              baseTime,
+			shouldCallCancel: true,
+			shouldCallRecord: true,
+		},
+		{
+			name:             "Error/RecordFailure",
+			userID:           goframework.NumberUUID(1),
+			actor:            "support",
+			now:              baseTime,
+			shouldCallCancel: true,
+			shouldCallRecord: true,
+			recordErr:        fooErr,
+			expectErr:        fooErr,
+		},
+		{
+			name:             "Error/NotFound",
+			userID:           goframework.NumberUUID(1),
+			actor:            "support",
+			now:              baseTime,
+			shouldCallCancel: true,
+			cancelErr:        bunovel.ErrNotFound,
+			expectErr:        bunovel.ErrNotFound,
+		},
+		{
+			name:             "Error/CancelFailure",
+			userID:           goframework.NumberUUID(1),
+			actor:            "support",
+			now:              baseTime,
+			shouldCallCancel: true,
+			cancelErr:        fooErr,
+			expectErr:        fooErr,
+		},
+		{
+			name:      "Error/NoUserID",
+			actor:     "support",
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+
+			if d.shouldCallCancel {
+				txCall := credentialsDAO.On("RunInTx", context.Background(), mock.Anything)
+				txCall.Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context, dao.CredentialsRepository) error)
+					txCall.ReturnArguments = []interface{}{fn(context.Background(), credentialsDAO)}
+				})
+
+				credentialsDAO.
+					On("CancelNewEmail", context.Background(), d.userID, d.now).
+					Return(nil, d.cancelErr)
+			}
+
+			if d.shouldCallRecord {
+				credentialsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:   dao.AuditEventAdminCancelNewEmail,
+						Actor:  d.actor,
+						UserID: d.userID,
+					}, mock.Anything, d.now).
+					Return(nil, d.recordErr)
+			}
+
+			service := services.NewAdminCancelNewEmailService(credentialsDAO)
+			err := service.AdminCancelNewEmail(context.Background(), d.userID, d.actor, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			credentialsDAO.AssertExpectations(t)
+		})
+	}
+}
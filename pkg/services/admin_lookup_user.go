@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"time"
+)
+
+type AdminLookupUserService interface {
+	// AdminLookupUser returns the full state of a user, selected by exactly one of its ID, email or slug. The actor
+	// is the identity of the internal caller, recorded with the lookup.
+	AdminLookupUser(ctx context.Context, query models.AdminLookupUserQuery, actor string, now time.Time) (*models.AdminUser, error)
+}
+
+func NewAdminLookupUserService(
+	credentialsDAO dao.CredentialsRepository,
+	identityDAO dao.IdentityRepository,
+	profileDAO dao.ProfileRepository,
+	auditEventsDAO dao.AuditEventsRepository,
+) AdminLookupUserService {
+	return &adminLookupUserServiceImpl{
+		credentialsDAO: credentialsDAO,
+		identityDAO:    identityDAO,
+		profileDAO:     profileDAO,
+		auditEventsDAO: auditEventsDAO,
+	}
+}
+
+type adminLookupUserServiceImpl struct {
+	credentialsDAO dao.CredentialsRepository
+	identityDAO    dao.IdentityRepository
+	profileDAO     dao.ProfileRepository
+	auditEventsDAO dao.AuditEventsRepository
+}
+
+func (s *adminLookupUserServiceImpl) AdminLookupUser(
+	ctx context.Context, query models.AdminLookupUserQuery, actor string, now time.Time,
+) (*models.AdminUser, error) {
+	if lo.Count([]bool{query.ID != "", query.Email != "", query.Slug != ""}, true) != 1 {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidUserLookup)
+	}
+
+	var (
+		credentials *dao.CredentialsModel
+		profile     *dao.ProfileModel
+		err         error
+	)
+
+	switch {
+	case query.ID != "":
+		id, err := uuid.Parse(query.ID)
+		if err != nil {
+			return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidUserID, err)
+		}
+
+		if credentials, err = s.credentialsDAO.GetCredentials(ctx, id); err != nil {
+			return nil, goerrors.Join(ErrGetCredentials, err)
+		}
+	case query.Email != "":
+		email, err := dao.ParseEmail(query.Email)
+		if err != nil {
+			return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidEmail, err)
+		}
+
+		if credentials, err = s.credentialsDAO.GetCredentialsByEmail(ctx, email); err != nil {
+			return nil, goerrors.Join(ErrGetCredentials, err)
+		}
+	default:
+		if profile, err = s.profileDAO.GetProfileBySlug(ctx, query.Slug); err != nil {
+			return nil, goerrors.Join(ErrGetProfile, err)
+		}
+
+		if credentials, err = s.credentialsDAO.GetCredentials(ctx, profile.ID); err != nil {
+			return nil, goerrors.Join(ErrGetCredentials, err)
+		}
+	}
+
+	if profile == nil {
+		if profile, err = s.profileDAO.GetProfile(ctx, credentials.ID); err != nil {
+			return nil, goerrors.Join(ErrGetProfile, err)
+		}
+	}
+
+	identity, err := s.identityDAO.GetIdentity(ctx, credentials.ID)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetIdentity, err)
+	}
+
+	if err := recordAuditEvent(ctx, s.auditEventsDAO, &dao.AuditEventModelCore{
+		Kind:   dao.AuditEventAdminLookupUser,
+		Actor:  actor,
+		UserID: credentials.ID,
+	}, now); err != nil {
+		return nil, err
+	}
+
+	user := &models.AdminUser{
+		ID:        credentials.ID,
+		CreatedAt: credentials.CreatedAt,
+		UpdatedAt: credentials.UpdatedAt,
+		Credentials: models.AdminCredentials{
+			Email:                     credentials.Email.String(),
+			NewEmail:                  credentials.NewEmail.String(),
+			Validated:                 credentials.Email.Validation == "",
+			PendingEmailValidation:    credentials.Email.Validation != "",
+			PendingNewEmailValidation: credentials.NewEmail.Validation != "",
+			PendingPasswordReset:      credentials.Password.Validation != "",
+			LockedAt:                  credentials.LockedAt,
+			DeletedAt:                 credentials.DeletedAt,
+		},
+		Identity: models.Identity{
+			FirstName: identity.FirstName,
+			LastName:  identity.LastName,
+			Sex:       identity.Sex,
+			Birthday:  identity.Birthday,
+		},
+		Profile: models.Profile{
+			Username: profile.Username,
+			Slug:     profile.Slug,
+		},
+	}
+
+	if credentials.Suspended(now) {
+		user.Credentials.Suspension = suspensionFromCredentials(credentials)
+	}
+
+	return user, nil
+}
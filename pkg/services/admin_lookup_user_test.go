@@ -0,0 +1,324 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestAdminLookupUser(t *testing.T) {
+	credentials := &dao.CredentialsModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &updateTime),
+		CredentialsModelCore: dao.CredentialsModelCore{
+			Email:    dao.Email{User: "user", Domain: "domain.com"},
+			NewEmail: dao.Email{User: "new-user", Domain: "domain.com", Validation: privateValidationCode},
+			Password: dao.Password{Hashed: "password", Validation: privateValidationCode},
+		},
+	}
+
+	suspendedCredentials := &dao.CredentialsModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &updateTime),
+		CredentialsModelCore: dao.CredentialsModelCore{
+			Email:            dao.Email{User: "user", Domain: "domain.com", Validation: privateValidationCode},
+			Password:         dao.Password{Hashed: "password"},
+			SuspendedAt:      &baseTime,
+			SuspensionReason: "spam",
+			SuspendedBy:      "moderation",
+		},
+	}
+
+	identity := &dao.IdentityModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		IdentityModelCore: dao.IdentityModelCore{
+			FirstName: "Elon",
+			LastName:  "Bezos",
+			Birthday:  baseTime,
+			Sex:       models.SexMale,
+		},
+	}
+
+	profile := &dao.ProfileModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		ProfileModelCore: dao.ProfileModelCore{
+			Username: "username",
+			Slug:     "slug",
+		},
+	}
+
+	expect := &models.AdminUser{
+		ID:        goframework.NumberUUID(1),
+		CreatedAt: baseTime,
+		UpdatedAt: &updateTime,
+		Credentials: models.AdminCredentials{
+			Email:                     "user@domain.com",
+			NewEmail:                  "new-user@domain.com",
+			Validated:                 true,
+			PendingNewEmailValidation: true,
+			PendingPasswordReset:      true,
+		},
+		Identity: models.Identity{
+			FirstName: "Elon",
+			LastName:  "Bezos",
+			Birthday:  baseTime,
+			Sex:       models.SexMale,
+		},
+		Profile: models.Profile{
+			Username: "username",
+			Slug:     "slug",
+		},
+	}
+
+	data := []struct {
+		name string
+
+		query models.AdminLookupUserQuery
+		actor string
+		now   time.Time
+
+		shouldCallGetCredentials bool
+		getCredentialsData       *dao.CredentialsModel
+		getCredentialsErr        error
+
+		shouldCallGetCredentialsByEmail bool
+		getCredentialsByEmailErr        error
+
+		shouldCallGetProfileBySlug bool
+		getProfileBySlugErr        error
+
+		shouldCallGetProfile bool
+		getProfileErr        error
+
+		shouldCallGetIdentity bool
+		getIdentityErr        error
+
+		shouldCallRecord bool
+		recordErr        error
+
+		expect    *models.AdminUser
+		expectErr error
+	}{
+		{
+			name:                     "Success/ByID",
+			query:                    models.AdminLookupUserQuery{ID: goframework.NumberUUID(1).String()},
+			actor:                    "support",
+			now:                      updateTime,
+			shouldCallGetCredentials: true,
+			getCredentialsData:       credentials,
+			shouldCallGetProfile:     true,
+			shouldCallGetIdentity:    true,
+			shouldCallRecord:         true,
+			expect:                   expect,
+		},
+		{
+			name:                            "Success/ByEmail",
+			query:                           models.AdminLookupUserQuery{Email: "user@domain.com"},
+			actor:                           "support",
+			now:                             updateTime,
+			shouldCallGetCredentialsByEmail: true,
+			shouldCallGetProfile:            true,
+			shouldCallGetIdentity:           true,
+			shouldCallRecord:                true,
+			expect:                          expect,
+		},
+		{
+			name:                       "Success/BySlug",
+			query:                      models.AdminLookupUserQuery{Slug: "slug"},
+			actor:                      "support",
+			now:                        updateTime,
+			shouldCallGetProfileBySlug: true,
+			shouldCallGetCredentials:   true,
+			getCredentialsData:         credentials,
+			shouldCallGetIdentity:      true,
+			shouldCallRecord:           true,
+			expect:                     expect,
+		},
+		{
+			name:                     "Success/Suspended",
+			query:                    models.AdminLookupUserQuery{ID: goframework.NumberUUID(1).String()},
+			actor:                    "support",
+			now:                      updateTime,
+			shouldCallGetCredentials: true,
+			getCredentialsData:       suspendedCredentials,
+			shouldCallGetProfile:     true,
+			shouldCallGetIdentity:    true,
+			shouldCallRecord:         true,
+			expect: &models.AdminUser{
+				ID:        goframework.NumberUUID(1),
+				CreatedAt: baseTime,
+				UpdatedAt: &updateTime,
+				Credentials: models.AdminCredentials{
+					Email:                  "user@domain.com",
+					PendingEmailValidation: true,
+					Suspension: &models.Suspension{
+						UserID:      goframework.NumberUUID(1),
+						Email:       "user@domain.com",
+						Reason:      "spam",
+						SuspendedBy: "moderation",
+						SuspendedAt: baseTime,
+					},
+				},
+				Identity: expect.Identity,
+				Profile:  expect.Profile,
+			},
+		},
+		{
+			name:                     "Error/RecordFailure",
+			query:                    models.AdminLookupUserQuery{ID: goframework.NumberUUID(1).String()},
+			actor:                    "support",
+			now:                      updateTime,
+			shouldCallGetCredentials: true,
+			getCredentialsData:       credentials,
+			shouldCallGetProfile:     true,
+			shouldCallGetIdentity:    true,
+			shouldCallRecord:         true,
+			recordErr:                fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/GetIdentityFailure",
+			query:                    models.AdminLookupUserQuery{ID: goframework.NumberUUID(1).String()},
+			actor:                    "support",
+			now:                      updateTime,
+			shouldCallGetCredentials: true,
+			getCredentialsData:       credentials,
+			shouldCallGetProfile:     true,
+			shouldCallGetIdentity:    true,
+			getIdentityErr:           fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/GetProfileFailure",
+			query:                    models.AdminLookupUserQuery{ID: goframework.NumberUUID(1).String()},
+			actor:                    "support",
+			now:                      updateTime,
+			shouldCallGetCredentials: true,
+			getCredentialsData:       credentials,
+			shouldCallGetProfile:     true,
+			getProfileErr:            fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                       "Error/SlugNotFound",
+			query:                      models.AdminLookupUserQuery{Slug: "slug"},
+			actor:                      "support",
+			now:                        updateTime,
+			shouldCallGetProfileBySlug: true,
+			getProfileBySlugErr:        bunovel.ErrNotFound,
+			expectErr:                  bunovel.ErrNotFound,
+		},
+		{
+			name:                            "Error/EmailNotFound",
+			query:                           models.AdminLookupUserQuery{Email: "user@domain.com"},
+			actor:                           "support",
+			now:                             updateTime,
+			shouldCallGetCredentialsByEmail: true,
+			getCredentialsByEmailErr:        bunovel.ErrNotFound,
+			expectErr:                       bunovel.ErrNotFound,
+		},
+		{
+			name:                     "Error/IDNotFound",
+			query:                    models.AdminLookupUserQuery{ID: goframework.NumberUUID(1).String()},
+			actor:                    "support",
+			now:                      updateTime,
+			shouldCallGetCredentials: true,
+			getCredentialsErr:        bunovel.ErrNotFound,
+			expectErr:                bunovel.ErrNotFound,
+		},
+		{
+			name:      "Error/InvalidEmail",
+			query:     models.AdminLookupUserQuery{Email: "not-an-email"},
+			actor:     "support",
+			now:       updateTime,
+			expectErr: services.ErrInvalidEmail,
+		},
+		{
+			name:      "Error/InvalidID",
+			query:     models.AdminLookupUserQuery{ID: "not-an-id"},
+			actor:     "support",
+			now:       updateTime,
+			expectErr: services.ErrInvalidUserID,
+		},
+		{
+			name:      "Error/SeveralKeys",
+			query:     models.AdminLookupUserQuery{Email: "user@domain.com", Slug: "slug"},
+			actor:     "support",
+			now:       updateTime,
+			expectErr: services.ErrInvalidUserLookup,
+		},
+		{
+			name:      "Error/NoKey",
+			actor:     "support",
+			now:       updateTime,
+			expectErr: services.ErrInvalidUserLookup,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			identityDAO := daomocks.NewIdentityRepository(t)
+			profileDAO := daomocks.NewProfileRepository(t)
+			auditEventsDAO := daomocks.NewAuditEventsRepository(t)
+
+			if d.shouldCallGetCredentials {
+				credentialsDAO.
+					On("GetCredentials", context.Background(), goframework.NumberUUID(1)).
+					Return(d.getCredentialsData, d.getCredentialsErr)
+			}
+
+			if d.shouldCallGetCredentialsByEmail {
+				credentialsDAO.
+					On("GetCredentialsByEmail", context.Background(), dao.Email{User: "user", Domain: "domain.com"}).
+					Return(lo.Ternary(d.getCredentialsByEmailErr == nil, credentials, nil), d.getCredentialsByEmailErr)
+			}
+
+			if d.shouldCallGetProfileBySlug {
+				profileDAO.
+					On("GetProfileBySlug", context.Background(), d.query.Slug).
+					Return(lo.Ternary(d.getProfileBySlugErr == nil, profile, nil), d.getProfileBySlugErr)
+			}
+
+			if d.shouldCallGetProfile {
+				profileDAO.
+					On("GetProfile", context.Background(), goframework.NumberUUID(1)).
+					Return(lo.Ternary(d.getProfileErr == nil, profile, nil), d.getProfileErr)
+			}
+
+			if d.shouldCallGetIdentity {
+				identityDAO.
+					On("GetIdentity", context.Background(), goframework.NumberUUID(1)).
+					Return(lo.Ternary(d.getIdentityErr == nil, identity, nil), d.getIdentityErr)
+			}
+
+			if d.shouldCallRecord {
+				auditEventsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:   dao.AuditEventAdminLookupUser,
+						Actor:  d.actor,
+						UserID: goframework.NumberUUID(1),
+					}, mock.Anything, d.now).
+					Return(nil, d.recordErr)
+			}
+
+			service := services.NewAdminLookupUserService(credentialsDAO, identityDAO, profileDAO, auditEventsDAO)
+			res, err := service.AdminLookupUser(context.Background(), d.query, d.actor, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			credentialsDAO.AssertExpectations(t)
+			identityDAO.AssertExpectations(t)
+			profileDAO.AssertExpectations(t)
+			auditEventsDAO.AssertExpectations(t)
+		})
+	}
+}
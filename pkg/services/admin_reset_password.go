@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"time"
+)
+
+type AdminResetPasswordService interface {
+	// AdminResetPassword sends a password reset email to a user, as if they had requested it. The actor is the
+	// identity of the internal caller, recorded with the action. The returned function sends the email.
+	AdminResetPassword(ctx context.Context, userID uuid.UUID, actor string, now time.Time) (func() error, error)
+}
+
+func NewAdminResetPasswordService(
+	credentialsDAO dao.CredentialsRepository,
+	auditEventsDAO dao.AuditEventsRepository,
+	resetPasswordService ResetPasswordService,
+) AdminResetPasswordService {
+	return &adminResetPasswordServiceImpl{
+		credentialsDAO:       credentialsDAO,
+		auditEventsDAO:       auditEventsDAO,
+		ResetPasswordService: resetPasswordService,
+	}
+}
+
+type adminResetPasswordServiceImpl struct {
+	credentialsDAO dao.CredentialsRepository
+	auditEventsDAO dao.AuditEventsRepository
+	ResetPasswordService
+}
+
+func (s *adminResetPasswordServiceImpl) AdminResetPassword(ctx context.Context, userID uuid.UUID, actor string, now time.Time) (func() error, error) {
+	if userID == uuid.Nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidUserID)
+	}
+
+	credentials, err := s.credentialsDAO.GetCredentials(ctx, userID)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetCredentials, err)
+	}
+
+	deferred, err := s.ResetPassword(ctx, credentials.Email.String(), models.ClientInfo{}, now)
+	if err != nil {
+		return nil, goerrors.Join(ErrResetPassword, err)
+	}
+
+	if err := recordAuditEvent(ctx, s.auditEventsDAO, &dao.AuditEventModelCore{
+		Kind:   dao.AuditEventAdminResetPassword,
+		Actor:  actor,
+		UserID: userID,
+	}, now); err != nil {
+		return nil, err
+	}
+
+	return deferred, nil
+}
@@ -0,0 +1,150 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestAdminResetPassword(t *testing.T) {
+	data := []struct {
+		name string
+
+		userID uuid.UUID
+		actor  string
+		now    time.Time
+
+		shouldCallGetCredentials bool
+		getCredentialsData       *dao.CredentialsModel
+		getCredentialsErr        error
+
+		shouldCallResetPassword bool
+		resetPasswordErr        error
+
+		shouldCallRecord bool
+		recordErr        error
+
+		expectDeferred bool
+		expectErr      error
+	}{
+		{
+			name:                     "Success",
+			userID:                   goframework.NumberUUID(1),
+			actor:                    "support",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentialsData: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain.com"},
+				},
+			},
+			shouldCallResetPassword: true,
+			shouldCallRecord:        true,
+			expectDeferred:          true,
+		},
+		{
+			name:                     "Error/RecordFailure",
+			userID:                   goframework.NumberUUID(1),
+			actor:                    "support",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentialsData: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain.com"},
+				},
+			},
+			shouldCallResetPassword: true,
+			shouldCallRecord:        true,
+			recordErr:               fooErr,
+			expectErr:               fooErr,
+		},
+		{
+			name:                     "Error/ResetPasswordFailure",
+			userID:                   goframework.NumberUUID(1),
+			actor:                    "support",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentialsData: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain.com"},
+				},
+			},
+			shouldCallResetPassword: true,
+			resetPasswordErr:        fooErr,
+			expectErr:               fooErr,
+		},
+		{
+			name:                     "Error/NotFound",
+			userID:                   goframework.NumberUUID(1),
+			actor:                    "support",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentialsErr:        bunovel.ErrNotFound,
+			expectErr:                bunovel.ErrNotFound,
+		},
+		{
+			name:      "Error/NoUserID",
+			actor:     "support",
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			auditEventsDAO := daomocks.NewAuditEventsRepository(t)
+			resetPasswordService := servicesmocks.NewResetPasswordService(t)
+
+			if d.shouldCallGetCredentials {
+				credentialsDAO.
+					On("GetCredentials", context.Background(), d.userID).
+					Return(d.getCredentialsData, d.getCredentialsErr)
+			}
+
+			if d.shouldCallResetPassword {
+				var deferred func() error
+				if d.resetPasswordErr == nil {
+					deferred = func() error { return nil }
+				}
+
+				resetPasswordService.
+					On("ResetPassword", context.Background(), "user@domain.com", models.ClientInfo{}, d.now).
+					Return(deferred, d.resetPasswordErr)
+			}
+
+			if d.shouldCallRecord {
+				auditEventsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:   dao.AuditEventAdminResetPassword,
+						Actor:  d.actor,
+						UserID: d.userID,
+					}, mock.Anything, d.now).
+					Return(nil, d.recordErr)
+			}
+
+			service := services.NewAdminResetPasswordService(credentialsDAO, auditEventsDAO, resetPasswordService)
+			deferred, err := service.AdminResetPassword(context.Background(), d.userID, d.actor, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expectDeferred, deferred != nil)
+
+			credentialsDAO.AssertExpectations(t)
+			auditEventsDAO.AssertExpectations(t)
+			resetPasswordService.AssertExpectations(t)
+		})
+	}
+}
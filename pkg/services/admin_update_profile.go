@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"time"
+)
+
+type AdminUpdateProfileService interface {
+	// AdminUpdateProfile updates the profile of a user, with the same rules as UpdateProfileService. The actor is the
+	// identity of the internal caller, recorded with the action.
+	AdminUpdateProfile(ctx context.Context, userID uuid.UUID, form models.UpdateProfileForm, actor string, now time.Time) error
+}
+
+func NewAdminUpdateProfileService(
+	profileDAO dao.ProfileRepository,
+	auditEventsDAO dao.AuditEventsRepository,
+) AdminUpdateProfileService {
+	return &adminUpdateProfileServiceImpl{
+		profileDAO:     profileDAO,
+		auditEventsDAO: auditEventsDAO,
+	}
+}
+
+type adminUpdateProfileServiceImpl struct {
+	profileDAO     dao.ProfileRepository
+	auditEventsDAO dao.AuditEventsRepository
+}
+
+func (s *adminUpdateProfileServiceImpl) AdminUpdateProfile(
+	ctx context.Context, userID uuid.UUID, form models.UpdateProfileForm, actor string, now time.Time,
+) error {
+	if userID == uuid.Nil {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidUserID)
+	}
+
+	if err := checkProfileForm(ctx, s.profileDAO, form, userID); err != nil {
+		return err
+	}
+
+	if _, err := s.profileDAO.Update(ctx, &dao.ProfileModelCore{
+		Slug:     form.Slug,
+		Username: form.Username,
+	}, userID, now); err != nil {
+		return goerrors.Join(ErrUpdateProfile, err)
+	}
+
+	return recordAuditEvent(ctx, s.auditEventsDAO, &dao.AuditEventModelCore{
+		Kind:   dao.AuditEventAdminUpdateProfile,
+		Actor:  actor,
+		UserID: userID,
+	}, now)
+}
@@ -0,0 +1,171 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestAdminUpdateProfile(t *testing.T) {
+	data := []struct {
+		name string
+
+		userID uuid.UUID
+		form   models.UpdateProfileForm
+		actor  string
+		now    time.Time
+
+		shouldCallGetProfileBySlug bool
+		getProfileBySlugData       *dao.ProfileModel
+		getProfileBySlugErr        error
+
+		shouldCallUpdate bool
+		updateErr        error
+
+		shouldCallRecord bool
+		recordErr        error
+
+		expectErr error
+	}{
+		{
+			name:   "Success",
+			userID: goframework.NumberUUID(1),
+			form: models.UpdateProfileForm{
+				Slug:     "new-slug",
+				Username: "new username",
+			},
+			actor:                      "support",
+			now:                        baseTime,
+			shouldCallGetProfileBySlug: true,
+			getProfileBySlugErr:        bunovel.ErrNotFound,
+			shouldCallUpdate:           true,
+			shouldCallRecord:           true,
+		},
+		{
+			name:   "Success/SameSlug",
+			userID: goframework.NumberUUID(1),
+			form: models.UpdateProfileForm{
+				Slug: "slug",
+			},
+			actor:                      "support",
+			now:                        baseTime,
+			shouldCallGetProfileBySlug: true,
+			getProfileBySlugData: &dao.ProfileModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			},
+			shouldCallUpdate: true,
+			shouldCallRecord: true,
+		},
+		{
+			name:   "Error/RecordFailure",
+			userID: goframework.NumberUUID(1),
+			form: models.UpdateProfileForm{
+				Slug: "new-slug",
+			},
+			actor:                      "support",
+			now:                        baseTime,
+			shouldCallGetProfileBySlug: true,
+			getProfileBySlugErr:        bunovel.ErrNotFound,
+			shouldCallUpdate:           true,
+			shouldCallRecord:           true,
+			recordErr:                  fooErr,
+			expectErr:                  fooErr,
+		},
+		{
+			name:   "Error/UpdateFailure",
+			userID: goframework.NumberUUID(1),
+			form: models.UpdateProfileForm{
+				Slug: "new-slug",
+			},
+			actor:                      "support",
+			now:                        baseTime,
+			shouldCallGetProfileBySlug: true,
+			getProfileBySlugErr:        bunovel.ErrNotFound,
+			shouldCallUpdate:           true,
+			updateErr:                  fooErr,
+			expectErr:                  fooErr,
+		},
+		{
+			name:   "Error/SlugTaken",
+			userID: goframework.NumberUUID(1),
+			form: models.UpdateProfileForm{
+				Slug: "slug",
+			},
+			actor:                      "support",
+			now:                        baseTime,
+			shouldCallGetProfileBySlug: true,
+			getProfileBySlugData: &dao.ProfileModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+			},
+			expectErr: services.ErrTaken,
+		},
+		{
+			name:   "Error/InvalidSlug",
+			userID: goframework.NumberUUID(1),
+			form: models.UpdateProfileForm{
+				Slug: "Invalid Slug",
+			},
+			actor:     "support",
+			now:       baseTime,
+			expectErr: services.ErrInvalidSlug,
+		},
+		{
+			name: "Error/NoUserID",
+			form: models.UpdateProfileForm{
+				Slug: "new-slug",
+			},
+			actor:     "support",
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			profileDAO := daomocks.NewProfileRepository(t)
+			auditEventsDAO := daomocks.NewAuditEventsRepository(t)
+
+			if d.shouldCallGetProfileBySlug {
+				profileDAO.
+					On("GetProfileBySlug", context.Background(), d.form.Slug).
+					Return(d.getProfileBySlugData, d.getProfileBySlugErr)
+			}
+
+			if d.shouldCallUpdate {
+				profileDAO.
+					On("Update", context.Background(), &dao.ProfileModelCore{
+						Slug:     d.form.Slug,
+						Username: d.form.Username,
+					}, d.userID, d.now).
+					Return(nil, d.updateErr)
+			}
+
+			if d.shouldCallRecord {
+				auditEventsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:   dao.AuditEventAdminUpdateProfile,
+						Actor:  d.actor,
+						UserID: d.userID,
+					}, mock.Anything, d.now).
+					Return(nil, d.recordErr)
+			}
+
+			service := services.NewAdminUpdateProfileService(profileDAO, auditEventsDAO)
+			err := service.AdminUpdateProfile(context.Background(), d.userID, d.form, d.actor, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			profileDAO.AssertExpectations(t)
+			auditEventsDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	apiclients "github.com/a-novel/go-apis/clients"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"time"
+)
+
+type AdminValidateEmailService interface {
+	// AdminValidateEmail validates the main email of a user without their validation code, and grants them the
+	// permissions of a validated account. The actor is the identity of the internal caller, recorded with the action.
+	AdminValidateEmail(ctx context.Context, userID uuid.UUID, actor string, now time.Time) error
+}
+
+func NewAdminValidateEmailService(
+	credentialsDAO dao.CredentialsRepository,
+	permissionsClient apiclients.PermissionsClient,
+) AdminValidateEmailService {
+	return &adminValidateEmailServiceImpl{
+		credentialsDAO:    credentialsDAO,
+		permissionsClient: permissionsClient,
+	}
+}
+
+type adminValidateEmailServiceImpl struct {
+	credentialsDAO    dao.CredentialsRepository
+	permissionsClient apiclients.PermissionsClient
+}
+
+func (s *adminValidateEmailServiceImpl) AdminValidateEmail(ctx context.Context, userID uuid.UUID, actor string, now time.Time) error {
+	if userID == uuid.Nil {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidUserID)
+	}
+
+	credentials, err := s.credentialsDAO.GetCredentials(ctx, userID)
+	if err != nil {
+		return goerrors.Join(ErrGetCredentials, err)
+	}
+
+	// Email already validated.
+	if credentials.Email.Validation == "" {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrMissingPendingValidation)
+	}
+
+	// The pending code is consumed, exactly as if the user had followed their validation link.
+	return validateEmail(
+		ctx, s.credentialsDAO, s.permissionsClient, userID, credentials.Email.Validation,
+		&dao.AuditEventModelCore{
+			Kind:   dao.AuditEventAdminValidateEmail,
+			Actor:  actor,
+			UserID: userID,
+		},
+		now,
+	)
+}
@@ -0,0 +1,192 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestAdminValidateEmail(t *testing.T) {
+	data := []struct {
+		name string
+
+		userID uuid.UUID
+		actor  string
+		now    time.Time
+
+		shouldCallGetCredentials bool
+		getCredentialsData       *dao.CredentialsModel
+		getCredentialsErr        error
+
+		shouldCallUpdate bool
+		updateErr        error
+
+		shouldCallPermissionsClient bool
+		permissionsClientErr        error
+
+		shouldCallRecord bool
+		recordErr        error
+
+		expectErr error
+	}{
+		{
+			name:                     "Success",
+			userID:                   goframework.NumberUUID(1),
+			actor:                    "support",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentialsData: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain.com", Validation: privateValidationCode},
+				},
+			},
+			shouldCallUpdate:            true,
+			shouldCallPermissionsClient: true,
+			shouldCallRecord:            true,
+		},
+		{
+			name:                     "Error/RecordFailure",
+			userID:                   goframework.NumberUUID(1),
+			actor:                    "support",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentialsData: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain.com", Validation: privateValidationCode},
+				},
+			},
+			shouldCallUpdate:            true,
+			shouldCallPermissionsClient: true,
+			shouldCallRecord:            true,
+			recordErr:                   fooErr,
+			expectErr:                   fooErr,
+		},
+		{
+			name:                     "Error/PermissionsClientFailure",
+			userID:                   goframework.NumberUUID(1),
+			actor:                    "support",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentialsData: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain.com", Validation: privateValidationCode},
+				},
+			},
+			shouldCallUpdate:            true,
+			shouldCallPermissionsClient: true,
+			permissionsClientErr:        fooErr,
+			expectErr:                   fooErr,
+		},
+		{
+			name:                     "Error/UpdateFailure",
+			userID:                   goframework.NumberUUID(1),
+			actor:                    "support",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentialsData: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain.com", Validation: privateValidationCode},
+				},
+			},
+			shouldCallUpdate: true,
+			updateErr:        fooErr,
+			expectErr:        fooErr,
+		},
+		{
+			name:                     "Error/AlreadyValidated",
+			userID:                   goframework.NumberUUID(1),
+			actor:                    "support",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentialsData: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain.com"},
+				},
+			},
+			expectErr: services.ErrMissingPendingValidation,
+		},
+		{
+			name:                     "Error/NotFound",
+			userID:                   goframework.NumberUUID(1),
+			actor:                    "support",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentialsErr:        bunovel.ErrNotFound,
+			expectErr:                bunovel.ErrNotFound,
+		},
+		{
+			name:      "Error/NoUserID",
+			actor:     "support",
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			permissionsClient := apiclientsmocks.NewPermissionsClient(t)
+
+			if d.shouldCallGetCredentials {
+				credentialsDAO.
+					On("GetCredentials", context.Background(), d.userID).
+					Return(d.getCredentialsData, d.getCredentialsErr)
+			}
+
+			if d.shouldCallUpdate {
+				credentialsDAO.
+					On("ValidateEmail", context.Background(), d.userID, privateValidationCode, d.now).
+					Return(nil, d.updateErr)
+
+				// Execute the actual method, but call the mocks inside of it.
+				txCall := credentialsDAO.On("RunInTx", context.Background(), mock.Anything)
+				txCall.Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context, dao.CredentialsRepository) error)
+					txCall.ReturnArguments = []interface{}{fn(context.Background(), credentialsDAO)}
+				})
+			}
+
+			if d.shouldCallPermissionsClient {
+				permissionsClient.
+					On("SetUserPermissions", context.Background(), apiclients.SetUserPermissionsForm{
+						UserID:    d.userID,
+						SetFields: []string{apiclients.FieldValidatedAccount},
+					}).
+					Return(d.permissionsClientErr)
+			}
+
+			if d.shouldCallRecord {
+				credentialsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:   dao.AuditEventAdminValidateEmail,
+						Actor:  d.actor,
+						UserID: d.userID,
+					}, mock.Anything, d.now).
+					Return(nil, d.recordErr)
+			}
+
+			service := services.NewAdminValidateEmailService(credentialsDAO, permissionsClient)
+			err := service.AdminValidateEmail(context.Background(), d.userID, d.actor, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			credentialsDAO.AssertExpectations(t)
+			permissionsClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	"time"
+)
+
+type BeginPasskeyLoginService interface {
+	// BeginPasskeyLogin starts a passwordless login. The user is not known yet, so the authenticator proposes every
+	// passkey registered for the service. Its response is sent to FinishPasskeyLoginService.
+	BeginPasskeyLogin(ctx context.Context, now time.Time) (*models.PasskeyRequestOptions, error)
+}
+
+func NewBeginPasskeyLoginService(webAuthnChallengesDAO dao.WebAuthnChallengesRepository, rp WebAuthnRelyingParty) BeginPasskeyLoginService {
+	return &beginPasskeyLoginServiceImpl{
+		webAuthnChallengesDAO: webAuthnChallengesDAO,
+		rp:                    rp,
+	}
+}
+
+type beginPasskeyLoginServiceImpl struct {
+	webAuthnChallengesDAO dao.WebAuthnChallengesRepository
+	rp                    WebAuthnRelyingParty
+}
+
+func (s *beginPasskeyLoginServiceImpl) BeginPasskeyLogin(ctx context.Context, now time.Time) (*models.PasskeyRequestOptions, error) {
+	challenge, err := createWebAuthnChallenge(
+		ctx, s.webAuthnChallengesDAO, dao.WebAuthnCeremonyLogin, nil, s.rp.ChallengeTTL, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PasskeyRequestOptions{
+		Challenge:        challenge,
+		Timeout:          s.rp.ChallengeTTL.Milliseconds(),
+		RPID:             s.rp.ID,
+		AllowCredentials: []models.PasskeyCredentialDescriptor{},
+		UserVerification: "required",
+	}, nil
+}
@@ -0,0 +1,78 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestBeginPasskeyLogin(t *testing.T) {
+	data := []struct {
+		name string
+
+		now time.Time
+
+		createErr error
+
+		expect    *models.PasskeyRequestOptions
+		expectErr error
+	}{
+		{
+			name: "Success",
+			now:  baseTime,
+			expect: &models.PasskeyRequestOptions{
+				Timeout:          300000,
+				RPID:             "agoradesecrivains.fr",
+				AllowCredentials: []models.PasskeyCredentialDescriptor{},
+				UserVerification: "required",
+			},
+		},
+		{
+			name:      "Error/CreateFailure",
+			now:       baseTime,
+			createErr: fooErr,
+			expectErr: fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			webAuthnChallengesDAO := daomocks.NewWebAuthnChallengesRepository(t)
+
+			var created *dao.WebAuthnChallengeModelCore
+			webAuthnChallengesDAO.
+				On("Create", context.Background(), mock.Anything, mock.Anything, d.now).
+				Run(func(args mock.Arguments) {
+					created = args.Get(1).(*dao.WebAuthnChallengeModelCore)
+				}).
+				Return(nil, d.createErr)
+
+			service := services.NewBeginPasskeyLoginService(webAuthnChallengesDAO, webAuthnRP)
+			res, err := service.BeginPasskeyLogin(context.Background(), d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			if d.expect != nil {
+				require.NotNil(t, created)
+				// The user is unknown until the passkey is presented.
+				require.Equal(t, &dao.WebAuthnChallengeModelCore{
+					Ceremony:  dao.WebAuthnCeremonyLogin,
+					Challenge: created.Challenge,
+					ExpiresAt: d.now.Add(5 * time.Minute),
+				}, created)
+
+				d.expect.Challenge = encodeWebAuthn(created.Challenge)
+			}
+
+			require.Equal(t, d.expect, res)
+
+			webAuthnChallengesDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	"time"
+)
+
+type BeginPasskeyMFAService interface {
+	// BeginPasskeyMFA starts the verification of the second factor of a user, with one of their passkeys. The
+	// challenge is the one returned by the login. The response of the authenticator is sent to
+	// VerifyMFAPasskeyService.
+	//
+	// Once the login throttle of the account is reached, a TooManyAttemptsError is returned.
+	BeginPasskeyMFA(ctx context.Context, challenge string, client models.ClientInfo, now time.Time) (*models.PasskeyRequestOptions, error)
+}
+
+func NewBeginPasskeyMFAService(
+	mfaChallengesDAO dao.MFAChallengesRepository,
+	webAuthnChallengesDAO dao.WebAuthnChallengesRepository,
+	passkeysDAO dao.PasskeysRepository,
+	credentialsDAO dao.CredentialsRepository,
+	loginFailuresDAO dao.LoginFailuresRepository,
+	rp WebAuthnRelyingParty,
+	throttle LoginThrottle,
+) BeginPasskeyMFAService {
+	return &beginPasskeyMFAServiceImpl{
+		mfaChallengesDAO:      mfaChallengesDAO,
+		webAuthnChallengesDAO: webAuthnChallengesDAO,
+		passkeysDAO:           passkeysDAO,
+		credentialsDAO:        credentialsDAO,
+		loginFailuresDAO:      loginFailuresDAO,
+		rp:                    rp,
+		throttle:              throttle,
+	}
+}
+
+type beginPasskeyMFAServiceImpl struct {
+	mfaChallengesDAO      dao.MFAChallengesRepository
+	webAuthnChallengesDAO dao.WebAuthnChallengesRepository
+	passkeysDAO           dao.PasskeysRepository
+	credentialsDAO        dao.CredentialsRepository
+	loginFailuresDAO      dao.LoginFailuresRepository
+	rp                    WebAuthnRelyingParty
+	throttle              LoginThrottle
+}
+
+func (s *beginPasskeyMFAServiceImpl) BeginPasskeyMFA(ctx context.Context, challenge string, client models.ClientInfo, now time.Time) (*models.PasskeyRequestOptions, error) {
+	model, _, err := getMFAChallenge(
+		ctx, s.mfaChallengesDAO, s.credentialsDAO, s.loginFailuresDAO, s.throttle, challenge, client, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	passkeys, err := s.passkeysDAO.ListUserPasskeys(ctx, model.UserID)
+	if err != nil {
+		return nil, goerrors.Join(ErrListPasskeys, err)
+	}
+	if len(passkeys) == 0 {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrPasskeyRejected)
+	}
+
+	webAuthnChallenge, err := createWebAuthnChallenge(
+		ctx, s.webAuthnChallengesDAO, dao.WebAuthnCeremonyMFA, &model.UserID, s.rp.ChallengeTTL, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PasskeyRequestOptions{
+		Challenge:        webAuthnChallenge,
+		Timeout:          s.rp.ChallengeTTL.Milliseconds(),
+		RPID:             s.rp.ID,
+		AllowCredentials: passkeyDescriptors(passkeys),
+		UserVerification: "required",
+	}, nil
+}
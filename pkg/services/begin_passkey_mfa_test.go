@@ -0,0 +1,254 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestBeginPasskeyMFA(t *testing.T) {
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
+	challenge := goframework.NumberUUID(1).String() + "." + publicValidationCode
+
+	validChallenge := &dao.MFAChallengeModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		MFAChallengeModelCore: dao.MFAChallengeModelCore{
+			UserID:      goframework.NumberUUID(10),
+			TokenHashed: privateValidationCode,
+			ExpiresAt:   baseTime.Add(5 * time.Minute),
+		},
+	}
+
+	credentials := &dao.CredentialsModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, nil),
+		CredentialsModelCore: dao.CredentialsModelCore{
+			Email: dao.Email{User: "User", Domain: "domain.com"},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		challenge string
+		now       time.Time
+
+		shouldCallGetChallenge bool
+		getChallenge           *dao.MFAChallengeModel
+		getChallengeErr        error
+
+		shouldCallGetCredentials bool
+		getCredentials           *dao.CredentialsModel
+		getCredentialsErr        error
+
+		shouldCallCheckThrottle bool
+		getAccountFailures      *dao.LoginFailuresSummaryModel
+
+		shouldCallListPasskeys bool
+		listPasskeys           []*dao.PasskeyModel
+		listPasskeysErr        error
+
+		shouldCallCreate bool
+		createErr        error
+
+		expect    *models.PasskeyRequestOptions
+		expectErr error
+	}{
+		{
+			name:                     "Success",
+			challenge:                challenge,
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			getChallenge:             validChallenge,
+			shouldCallListPasskeys:   true,
+			listPasskeys:             []*dao.PasskeyModel{passkeyModel(0)},
+			shouldCallCreate:         true,
+			expect: &models.PasskeyRequestOptions{
+				Timeout: 300000,
+				RPID:    "agoradesecrivains.fr",
+				AllowCredentials: []models.PasskeyCredentialDescriptor{
+					{Type: "public-key", ID: passkeyCredentialID, Transports: []string{"internal", "hybrid"}},
+				},
+				UserVerification: "required",
+			},
+		},
+		{
+			name:                     "Error/CreateFailure",
+			challenge:                challenge,
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			getChallenge:             validChallenge,
+			shouldCallListPasskeys:   true,
+			listPasskeys:             []*dao.PasskeyModel{passkeyModel(0)},
+			shouldCallCreate:         true,
+			createErr:                fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/NoPasskey",
+			challenge:                challenge,
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			getChallenge:             validChallenge,
+			shouldCallListPasskeys:   true,
+			expectErr:                goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                     "Error/ListPasskeysFailure",
+			challenge:                challenge,
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			getChallenge:             validChallenge,
+			shouldCallListPasskeys:   true,
+			listPasskeysErr:          fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/TooManyAttempts",
+			challenge:                challenge,
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			getChallenge:             validChallenge,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			getAccountFailures:       &dao.LoginFailuresSummaryModel{Count: 3, FirstAt: baseTime, LastAt: baseTime},
+			expectErr:                services.ErrTooManyAttempts,
+		},
+		{
+			name:                     "Error/GetCredentialsFailure",
+			challenge:                challenge,
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			getChallenge:             validChallenge,
+			shouldCallGetCredentials: true,
+			getCredentialsErr:        fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                   "Error/ChallengeExpired",
+			challenge:              challenge,
+			now:                    baseTime.Add(10 * time.Minute),
+			shouldCallGetChallenge: true,
+			getChallenge:           validChallenge,
+			expectErr:              services.ErrInvalidMFAChallenge,
+		},
+		{
+			name:                   "Error/ChallengeNotFound",
+			challenge:              challenge,
+			now:                    baseTime,
+			shouldCallGetChallenge: true,
+			getChallengeErr:        bunovel.ErrNotFound,
+			expectErr:              goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                   "Error/GetChallengeFailure",
+			challenge:              challenge,
+			now:                    baseTime,
+			shouldCallGetChallenge: true,
+			getChallengeErr:        fooErr,
+			expectErr:              fooErr,
+		},
+		{
+			name:      "Error/InvalidChallenge",
+			challenge: "foo",
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			mfaChallengesDAO := daomocks.NewMFAChallengesRepository(t)
+			webAuthnChallengesDAO := daomocks.NewWebAuthnChallengesRepository(t)
+			passkeysDAO := daomocks.NewPasskeysRepository(t)
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			loginFailuresDAO := daomocks.NewLoginFailuresRepository(t)
+
+			if d.shouldCallGetChallenge {
+				mfaChallengesDAO.
+					On("Get", context.Background(), goframework.NumberUUID(1)).
+					Return(d.getChallenge, d.getChallengeErr)
+			}
+
+			if d.shouldCallGetCredentials {
+				credentialsDAO.
+					On("GetCredentials", context.Background(), goframework.NumberUUID(10)).
+					Return(d.getCredentials, d.getCredentialsErr)
+			}
+
+			if d.shouldCallCheckThrottle {
+				loginFailuresDAO.
+					On("GetIPFailures", context.Background(), client.IP, d.now.Add(-loginThrottle.IPWindow)).
+					Return(&dao.LoginFailuresSummaryModel{}, nil)
+				loginFailuresDAO.
+					On("GetAccountFailures", context.Background(), "user@domain.com", d.now.Add(-loginThrottle.AccountWindow)).
+					Return(lo.Ternary(d.getAccountFailures != nil, d.getAccountFailures, &dao.LoginFailuresSummaryModel{}), nil)
+			}
+
+			if d.shouldCallListPasskeys {
+				passkeysDAO.
+					On("ListUserPasskeys", context.Background(), goframework.NumberUUID(10)).
+					Return(d.listPasskeys, d.listPasskeysErr)
+			}
+
+			var created *dao.WebAuthnChallengeModelCore
+			if d.shouldCallCreate {
+				webAuthnChallengesDAO.
+					On("Create", context.Background(), mock.Anything, mock.Anything, d.now).
+					Run(func(args mock.Arguments) {
+						created = args.Get(1).(*dao.WebAuthnChallengeModelCore)
+					}).
+					Return(nil, d.createErr)
+			}
+
+			service := services.NewBeginPasskeyMFAService(
+				mfaChallengesDAO, webAuthnChallengesDAO, passkeysDAO, credentialsDAO, loginFailuresDAO, webAuthnRP, loginThrottle,
+			)
+			res, err := service.BeginPasskeyMFA(context.Background(), d.challenge, client, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			if d.expect != nil {
+				require.NotNil(t, created)
+				require.Equal(t, &dao.WebAuthnChallengeModelCore{
+					UserID:    lo.ToPtr(goframework.NumberUUID(10)),
+					Ceremony:  dao.WebAuthnCeremonyMFA,
+					Challenge: created.Challenge,
+					ExpiresAt: d.now.Add(5 * time.Minute),
+				}, created)
+
+				d.expect.Challenge = encodeWebAuthn(created.Challenge)
+			}
+
+			require.Equal(t, d.expect, res)
+
+			mfaChallengesDAO.AssertExpectations(t)
+			webAuthnChallengesDAO.AssertExpectations(t)
+			passkeysDAO.AssertExpectations(t)
+			credentialsDAO.AssertExpectations(t)
+			loginFailuresDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"time"
+)
+
+type BeginPasskeyRegistrationService interface {
+	// BeginPasskeyRegistration starts the registration of a new passkey for the user. The returned options are passed
+	// to the authenticator, whose response is sent to FinishPasskeyRegistrationService. The password is required if
+	// the user did not authenticate recently.
+	BeginPasskeyRegistration(ctx context.Context, tokenRaw, password string, client models.ClientInfo, now time.Time) (*models.PasskeyCreationOptions, error)
+}
+
+func NewBeginPasskeyRegistrationService(
+	webAuthnChallengesDAO dao.WebAuthnChallengesRepository,
+	passkeysDAO dao.PasskeysRepository,
+	credentialsDAO dao.CredentialsRepository,
+	introspectTokenService IntrospectTokenService,
+	checkStepUpService CheckStepUpService,
+	rp WebAuthnRelyingParty,
+) BeginPasskeyRegistrationService {
+	return &beginPasskeyRegistrationServiceImpl{
+		webAuthnChallengesDAO:  webAuthnChallengesDAO,
+		passkeysDAO:            passkeysDAO,
+		credentialsDAO:         credentialsDAO,
+		IntrospectTokenService: introspectTokenService,
+		CheckStepUpService:     checkStepUpService,
+		rp:                     rp,
+	}
+}
+
+type beginPasskeyRegistrationServiceImpl struct {
+	webAuthnChallengesDAO dao.WebAuthnChallengesRepository
+	passkeysDAO           dao.PasskeysRepository
+	credentialsDAO        dao.CredentialsRepository
+	IntrospectTokenService
+	CheckStepUpService
+	rp WebAuthnRelyingParty
+}
+
+func (s *beginPasskeyRegistrationServiceImpl) BeginPasskeyRegistration(ctx context.Context, tokenRaw, password string, client models.ClientInfo, now time.Time) (*models.PasskeyCreationOptions, error) {
+	token, err := s.IntrospectToken(ctx, tokenRaw, now, false)
+	if err != nil {
+		return nil, goerrors.Join(ErrIntrospectToken, err)
+	}
+	if !token.OK {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
+	}
+
+	if err := s.CheckStepUp(ctx, token.Token, SensitiveOperationUpdateSecondFactor, password, client, now); err != nil {
+		return nil, goerrors.Join(ErrCheckStepUp, err)
+	}
+
+	userID := token.Token.Payload.ID
+
+	credentials, err := s.credentialsDAO.GetCredentials(ctx, userID)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetCredentials, err)
+	}
+
+	passkeys, err := s.passkeysDAO.ListUserPasskeys(ctx, userID)
+	if err != nil {
+		return nil, goerrors.Join(ErrListPasskeys, err)
+	}
+
+	challenge, err := createWebAuthnChallenge(
+		ctx, s.webAuthnChallengesDAO, dao.WebAuthnCeremonyRegistration, &userID, s.rp.ChallengeTTL, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PasskeyCreationOptions{
+		Challenge: challenge,
+		RP:        models.PasskeyRelyingParty{ID: s.rp.ID, Name: s.rp.Name},
+		User: models.PasskeyUser{
+			ID:          webAuthnEncoding.EncodeToString(userID[:]),
+			Name:        credentials.Email.String(),
+			DisplayName: credentials.Email.String(),
+		},
+		PubKeyCredParams: lo.Map(WebAuthnSupportedAlgorithms, func(item int, _ int) models.PasskeyCredentialParameters {
+			return models.PasskeyCredentialParameters{Type: webAuthnPublicKey, Alg: item}
+		}),
+		Timeout: s.rp.ChallengeTTL.Milliseconds(),
+		// Prevent the authenticator from registering the same passkey twice.
+		ExcludeCredentials: passkeyDescriptors(passkeys),
+		AuthenticatorSelection: models.PasskeyAuthenticatorSelection{
+			ResidentKey:      "required",
+			UserVerification: "required",
+		},
+		Attestation: "none",
+	}, nil
+}
@@ -0,0 +1,257 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestBeginPasskeyRegistration(t *testing.T) {
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
+	validToken := &models.UserTokenStatus{
+		OK: true,
+		Token: &models.UserToken{
+			Payload: models.UserTokenPayload{ID: goframework.NumberUUID(10)},
+		},
+	}
+
+	credentials := &dao.CredentialsModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, nil),
+		CredentialsModelCore: dao.CredentialsModelCore{
+			Email: dao.Email{User: "user", Domain: "domain.com"},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		password string
+		now      time.Time
+
+		introspectToken    *models.UserTokenStatus
+		introspectTokenErr error
+
+		shouldCallCheckStepUp bool
+		checkStepUpErr        error
+
+		shouldCallGetCredentials bool
+		getCredentialsErr        error
+
+		shouldCallListPasskeys bool
+		listPasskeys           []*dao.PasskeyModel
+		listPasskeysErr        error
+
+		shouldCallCreate bool
+		createErr        error
+
+		expect    *models.PasskeyCreationOptions
+		expectErr error
+	}{
+		{
+			name:                     "Success",
+			tokenRaw:                 "string-token",
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGetCredentials: true,
+			shouldCallListPasskeys:   true,
+			listPasskeys:             []*dao.PasskeyModel{passkeyModel(0)},
+			shouldCallCreate:         true,
+			expect: &models.PasskeyCreationOptions{
+				RP: models.PasskeyRelyingParty{ID: "agoradesecrivains.fr", Name: "Agora des écrivains"},
+				User: models.PasskeyUser{
+					ID:          passkeyUserHandle,
+					Name:        "user@domain.com",
+					DisplayName: "user@domain.com",
+				},
+				PubKeyCredParams: []models.PasskeyCredentialParameters{
+					{Type: "public-key", Alg: -7},
+					{Type: "public-key", Alg: -8},
+					{Type: "public-key", Alg: -257},
+				},
+				Timeout: 300000,
+				ExcludeCredentials: []models.PasskeyCredentialDescriptor{
+					{Type: "public-key", ID: passkeyCredentialID, Transports: []string{"internal", "hybrid"}},
+				},
+				AuthenticatorSelection: models.PasskeyAuthenticatorSelection{
+					ResidentKey:      "required",
+					UserVerification: "required",
+				},
+				Attestation: "none",
+			},
+		},
+		{
+			name:                     "Success/FirstPasskey",
+			tokenRaw:                 "string-token",
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGetCredentials: true,
+			shouldCallListPasskeys:   true,
+			shouldCallCreate:         true,
+			expect: &models.PasskeyCreationOptions{
+				RP: models.PasskeyRelyingParty{ID: "agoradesecrivains.fr", Name: "Agora des écrivains"},
+				User: models.PasskeyUser{
+					ID:          passkeyUserHandle,
+					Name:        "user@domain.com",
+					DisplayName: "user@domain.com",
+				},
+				PubKeyCredParams: []models.PasskeyCredentialParameters{
+					{Type: "public-key", Alg: -7},
+					{Type: "public-key", Alg: -8},
+					{Type: "public-key", Alg: -257},
+				},
+				Timeout:            300000,
+				ExcludeCredentials: []models.PasskeyCredentialDescriptor{},
+				AuthenticatorSelection: models.PasskeyAuthenticatorSelection{
+					ResidentKey:      "required",
+					UserVerification: "required",
+				},
+				Attestation: "none",
+			},
+		},
+		{
+			name:                     "Error/CreateFailure",
+			tokenRaw:                 "string-token",
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGetCredentials: true,
+			shouldCallListPasskeys:   true,
+			shouldCallCreate:         true,
+			createErr:                fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/ListPasskeysFailure",
+			tokenRaw:                 "string-token",
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGetCredentials: true,
+			shouldCallListPasskeys:   true,
+			listPasskeysErr:          fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/GetCredentialsFailure",
+			tokenRaw:                 "string-token",
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGetCredentials: true,
+			getCredentialsErr:        fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                  "Error/CheckStepUpFailure",
+			tokenRaw:              "string-token",
+			now:                   baseTime,
+			introspectToken:       validToken,
+			shouldCallCheckStepUp: true,
+			checkStepUpErr:        fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:            "Error/InvalidToken",
+			tokenRaw:        "string-token",
+			now:             baseTime,
+			introspectToken: &models.UserTokenStatus{OK: false},
+			expectErr:       goframework.ErrInvalidCredentials,
+		},
+		{
+			name:               "Error/IntrospectTokenFailure",
+			tokenRaw:           "string-token",
+			now:                baseTime,
+			introspectTokenErr: fooErr,
+			expectErr:          fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			webAuthnChallengesDAO := daomocks.NewWebAuthnChallengesRepository(t)
+			passkeysDAO := daomocks.NewPasskeysRepository(t)
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			introspectTokenService := servicesmocks.NewIntrospectTokenService(t)
+			checkStepUpService := servicesmocks.NewCheckStepUpService(t)
+
+			introspectTokenService.
+				On("IntrospectToken", context.Background(), d.tokenRaw, d.now, false).
+				Return(d.introspectToken, d.introspectTokenErr)
+
+			if d.shouldCallCheckStepUp {
+				checkStepUpService.
+					On("CheckStepUp", context.Background(), d.introspectToken.Token, services.SensitiveOperationUpdateSecondFactor, d.password, client, d.now).
+					Return(d.checkStepUpErr)
+			}
+
+			if d.shouldCallGetCredentials {
+				credentialsDAO.
+					On("GetCredentials", context.Background(), goframework.NumberUUID(10)).
+					Return(credentials, d.getCredentialsErr)
+			}
+
+			if d.shouldCallListPasskeys {
+				passkeysDAO.
+					On("ListUserPasskeys", context.Background(), goframework.NumberUUID(10)).
+					Return(d.listPasskeys, d.listPasskeysErr)
+			}
+
+			var created *dao.WebAuthnChallengeModelCore
+			if d.shouldCallCreate {
+				webAuthnChallengesDAO.
+					On("Create", context.Background(), mock.Anything, mock.Anything, d.now).
+					Run(func(args mock.Arguments) {
+						created = args.Get(1).(*dao.WebAuthnChallengeModelCore)
+
+						// The challenge starts with its own ID.
+						id := args.Get(2).(uuid.UUID)
+						require.Equal(t, id[:], created.Challenge[:len(id)])
+					}).
+					Return(nil, d.createErr)
+			}
+
+			service := services.NewBeginPasskeyRegistrationService(
+				webAuthnChallengesDAO, passkeysDAO, credentialsDAO, introspectTokenService, checkStepUpService, webAuthnRP,
+			)
+			res, err := service.BeginPasskeyRegistration(context.Background(), d.tokenRaw, d.password, client, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			if d.expect != nil {
+				require.NotNil(t, created)
+				require.Equal(t, &dao.WebAuthnChallengeModelCore{
+					UserID:    lo.ToPtr(goframework.NumberUUID(10)),
+					Ceremony:  dao.WebAuthnCeremonyRegistration,
+					Challenge: created.Challenge,
+					ExpiresAt: d.now.Add(5 * time.Minute),
+				}, created)
+				require.Len(t, created.Challenge, 16+services.WebAuthnChallengeSize)
+
+				d.expect.Challenge = encodeWebAuthn(created.Challenge)
+			}
+
+			require.Equal(t, d.expect, res)
+
+			webAuthnChallengesDAO.AssertExpectations(t)
+			passkeysDAO.AssertExpectations(t)
+			credentialsDAO.AssertExpectations(t)
+			introspectTokenService.AssertExpectations(t)
+			checkStepUpService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"time"
+)
+
+type CancelAccountDeletionService interface {
+	// CancelAccountDeletion restores a deleted account, using the code sent to its owner on deletion. It fails once
+	// the grace period is over, even if the account was not purged yet.
+	CancelAccountDeletion(ctx context.Context, id uuid.UUID, code string, now time.Time) error
+}
+
+func NewCancelAccountDeletionService(credentialsDAO dao.CredentialsRepository, gracePeriod time.Duration) CancelAccountDeletionService {
+	return &cancelAccountDeletionServiceImpl{
+		credentialsDAO: credentialsDAO,
+		gracePeriod:    gracePeriod,
+	}
+}
+
+type cancelAccountDeletionServiceImpl struct {
+	credentialsDAO dao.CredentialsRepository
+	gracePeriod    time.Duration
+}
+
+func (s *cancelAccountDeletionServiceImpl) CancelAccountDeletion(ctx context.Context, id uuid.UUID, code string, now time.Time) error {
+	credentials, err := s.credentialsDAO.GetCredentials(ctx, id)
+	if err != nil {
+		return goerrors.Join(ErrGetCredentials, err)
+	}
+
+	// The account is not deleted, or the deletion was already canceled.
+	if credentials.DeletedAt == nil || credentials.DeletionCode == "" {
+		return goerrors.Join(goframework.ErrInvalidCredentials, ErrMissingPendingValidation)
+	}
+	ok, err := goframework.VerifyCode(code, credentials.DeletionCode)
+	if err != nil {
+		return goerrors.Join(ErrVerifyValidationCode, err)
+	}
+	if !ok {
+		return goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidValidationCode)
+	}
+	if validationCodeExpired(credentials.DeletedAt, s.gracePeriod, now) {
+		return goerrors.Join(goframework.ErrInvalidCredentials, ErrValidationCodeExpired)
+	}
+
+	if _, err := s.credentialsDAO.CancelDeletion(ctx, id, credentials.DeletionCode, now); err != nil {
+		// The deletion was canceled, or the account purged, by a concurrent request.
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return goerrors.Join(goframework.ErrInvalidCredentials, ErrMissingPendingValidation, err)
+		}
+
+		return goerrors.Join(ErrCancelAccountDeletion, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCancelAccountDeletion(t *testing.T) {
+	gracePeriod := 30 * 24 * time.Hour
+	deletedAt := baseTime.Add(-time.Hour)
+	expiredAt := baseTime.Add(-gracePeriod)
+
+	data := []struct {
+		name string
+
+		id   uuid.UUID
+		code string
+		now  time.Time
+
+		dao    *dao.CredentialsModel
+		daoErr error
+
+		shouldCallCancelDeletion bool
+		cancelDeletionErr        error
+
+		expectErr error
+	}{
+		{
+			name: "Success",
+			id:   goframework.NumberUUID(1),
+			code: publicValidationCode,
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					DeletedAt:    &deletedAt,
+					DeletionCode: privateValidationCode,
+				},
+			},
+			shouldCallCancelDeletion: true,
+		},
+		{
+			name: "Error/CancelDeletionFailure",
+			id:   goframework.NumberUUID(1),
+			code: publicValidationCode,
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					DeletedAt:    &deletedAt,
+					DeletionCode: privateValidationCode,
+				},
+			},
+			shouldCallCancelDeletion: true,
+			cancelDeletionErr:        fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name: "Error/CanceledConcurrently",
+			id:   goframework.NumberUUID(1),
+			code: publicValidationCode,
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					DeletedAt:    &deletedAt,
+					DeletionCode: privateValidationCode,
+				},
+			},
+			shouldCallCancelDeletion: true,
+			cancelDeletionErr:        bunovel.ErrNotFound,
+			expectErr:                services.ErrMissingPendingValidation,
+		},
+		{
+			name: "Error/GracePeriodOver",
+			id:   goframework.NumberUUID(1),
+			code: publicValidationCode,
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					DeletedAt:    &expiredAt,
+					DeletionCode: privateValidationCode,
+				},
+			},
+			expectErr: services.ErrValidationCodeExpired,
+		},
+		{
+			name: "Error/WrongCode",
+			id:   goframework.NumberUUID(1),
+			code: "fake-code",
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					DeletedAt:    &deletedAt,
+					DeletionCode: privateValidationCode,
+				},
+			},
+			expectErr: services.ErrInvalidValidationCode,
+		},
+		{
+			name:      "Error/NotDeleted",
+			id:        goframework.NumberUUID(1),
+			code:      publicValidationCode,
+			now:       baseTime,
+			dao:       &dao.CredentialsModel{},
+			expectErr: services.ErrMissingPendingValidation,
+		},
+		{
+			name:      "Error/DAOFailure",
+			id:        goframework.NumberUUID(1),
+			code:      publicValidationCode,
+			now:       baseTime,
+			daoErr:    fooErr,
+			expectErr: fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+
+			credentialsDAO.
+				On("GetCredentials", context.Background(), d.id).
+				Return(d.dao, d.daoErr)
+
+			if d.shouldCallCancelDeletion {
+				credentialsDAO.
+					On("CancelDeletion", context.Background(), d.id, privateValidationCode, d.now).
+					Return(nil, d.cancelDeletionErr)
+			}
+
+			service := services.NewCancelAccountDeletionService(credentialsDAO, gracePeriod)
+			err := service.CancelAccountDeletion(context.Background(), d.id, d.code, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			credentialsDAO.AssertExpectations(t)
+		})
+	}
+}
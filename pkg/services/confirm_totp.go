@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"time"
+)
+
+type ConfirmTOTPService interface {
+	// ConfirmTOTP enables two-factor authentication, once the user sends a code generated from the enrolled secret.
+	ConfirmTOTP(ctx context.Context, tokenRaw string, code string, now time.Time) error
+}
+
+func NewConfirmTOTPService(totpDAO dao.TOTPRepository, introspectTokenService IntrospectTokenService) ConfirmTOTPService {
+	return &confirmTOTPServiceImpl{
+		totpDAO:                totpDAO,
+		IntrospectTokenService: introspectTokenService,
+	}
+}
+
+type confirmTOTPServiceImpl struct {
+	totpDAO dao.TOTPRepository
+	IntrospectTokenService
+}
+
+func (s *confirmTOTPServiceImpl) ConfirmTOTP(ctx context.Context, tokenRaw string, code string, now time.Time) error {
+	token, err := s.IntrospectToken(ctx, tokenRaw, now, false)
+	if err != nil {
+		return goerrors.Join(ErrIntrospectToken, err)
+	}
+	if !token.OK {
+		return goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
+	}
+
+	code = normalizeSecondFactorCode(code)
+	if !isTOTPCode(code) {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSecondFactorCode)
+	}
+
+	totp, err := s.totpDAO.Get(ctx, token.Token.Payload.ID)
+	if err != nil {
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return goerrors.Join(ErrTOTPNotEnrolled, err)
+		}
+
+		return goerrors.Join(ErrGetTOTP, err)
+	}
+	if totp.ConfirmedAt != nil {
+		return ErrTOTPAlreadyEnabled
+	}
+
+	step, ok, err := matchTOTP(totp.Secret, code, now)
+	if err != nil {
+		return goerrors.Join(ErrCheckSecondFactor, err)
+	}
+	if !ok {
+		return goerrors.Join(goframework.ErrInvalidCredentials, ErrWrongSecondFactorCode)
+	}
+
+	if _, err := s.totpDAO.Confirm(ctx, token.Token.Payload.ID, step, now); err != nil {
+		// Another request confirmed the secret in the meantime.
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return goerrors.Join(ErrTOTPAlreadyEnabled, err)
+		}
+
+		return goerrors.Join(ErrConfirmTOTP, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestConfirmTOTP(t *testing.T) {
+	validToken := &models.UserTokenStatus{
+		OK: true,
+		Token: &models.UserToken{
+			Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+		},
+	}
+
+	pending := &dao.TOTPModel{
+		Metadata:      bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		TOTPModelCore: dao.TOTPModelCore{Secret: totpSecret},
+	}
+
+	confirmed := &dao.TOTPModel{
+		Metadata:      bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		TOTPModelCore: dao.TOTPModelCore{Secret: totpSecret, ConfirmedAt: &baseTime},
+	}
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		code     string
+		now      time.Time
+
+		introspectToken    *models.UserTokenStatus
+		introspectTokenErr error
+
+		shouldCallGet bool
+		get           *dao.TOTPModel
+		getErr        error
+
+		shouldCallConfirm bool
+		confirmStep       int64
+		confirmErr        error
+
+		expectErr error
+	}{
+		{
+			name:              "Success",
+			tokenRaw:          "string-token",
+			code:              mustTOTPCode(baseTime),
+			now:               baseTime,
+			introspectToken:   validToken,
+			shouldCallGet:     true,
+			get:               pending,
+			shouldCallConfirm: true,
+			confirmStep:       services.TOTPStep(baseTime),
+		},
+		{
+			name:              "Success/PreviousPeriod",
+			tokenRaw:          "string-token",
+			code:              mustTOTPCode(baseTime.Add(-services.TOTPPeriod)),
+			now:               baseTime,
+			introspectToken:   validToken,
+			shouldCallGet:     true,
+			get:               pending,
+			shouldCallConfirm: true,
+			confirmStep:       services.TOTPStep(baseTime) - 1,
+		},
+		{
+			name:              "Error/ConfirmFailure",
+			tokenRaw:          "string-token",
+			code:              mustTOTPCode(baseTime),
+			now:               baseTime,
+			introspectToken:   validToken,
+			shouldCallGet:     true,
+			get:               pending,
+			shouldCallConfirm: true,
+			confirmStep:       services.TOTPStep(baseTime),
+			confirmErr:        fooErr,
+			expectErr:         fooErr,
+		},
+		{
+			name:              "Error/ConfirmedConcurrently",
+			tokenRaw:          "string-token",
+			code:              mustTOTPCode(baseTime),
+			now:               baseTime,
+			introspectToken:   validToken,
+			shouldCallGet:     true,
+			get:               pending,
+			shouldCallConfirm: true,
+			confirmStep:       services.TOTPStep(baseTime),
+			confirmErr:        bunovel.ErrNotFound,
+			expectErr:         services.ErrTOTPAlreadyEnabled,
+		},
+		{
+			name:            "Error/WrongCode",
+			tokenRaw:        "string-token",
+			code:            mustTOTPCode(baseTime.Add(-2 * services.TOTPPeriod)),
+			now:             baseTime,
+			introspectToken: validToken,
+			shouldCallGet:   true,
+			get:             pending,
+			expectErr:       goframework.ErrInvalidCredentials,
+		},
+		{
+			name:            "Error/AlreadyEnabled",
+			tokenRaw:        "string-token",
+			code:            mustTOTPCode(baseTime),
+			now:             baseTime,
+			introspectToken: validToken,
+			shouldCallGet:   true,
+			get:             confirmed,
+			expectErr:       services.ErrTOTPAlreadyEnabled,
+		},
+		{
+			name:            "Error/NotEnrolled",
+			tokenRaw:        "string-token",
+			code:            mustTOTPCode(baseTime),
+			now:             baseTime,
+			introspectToken: validToken,
+			shouldCallGet:   true,
+			getErr:          bunovel.ErrNotFound,
+			expectErr:       services.ErrTOTPNotEnrolled,
+		},
+		{
+			name:            "Error/GetFailure",
+			tokenRaw:        "string-token",
+			code:            mustTOTPCode(baseTime),
+			now:             baseTime,
+			introspectToken: validToken,
+			shouldCallGet:   true,
+			getErr:          fooErr,
+			expectErr:       fooErr,
+		},
+		{
+			name:            "Error/RecoveryCode",
+			tokenRaw:        "string-token",
+			code:            recoveryCode,
+			now:             baseTime,
+			introspectToken: validToken,
+			expectErr:       goframework.ErrInvalidEntity,
+		},
+		{
+			name:            "Error/InvalidToken",
+			tokenRaw:        "string-token",
+			code:            mustTOTPCode(baseTime),
+			now:             baseTime,
+			introspectToken: &models.UserTokenStatus{OK: false},
+			expectErr:       goframework.ErrInvalidCredentials,
+		},
+		{
+			name:               "Error/IntrospectTokenFailure",
+			tokenRaw:           "string-token",
+			code:               mustTOTPCode(baseTime),
+			now:                baseTime,
+			introspectTokenErr: fooErr,
+			expectErr:          fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			totpDAO := daomocks.NewTOTPRepository(t)
+			introspectTokenService := servicesmocks.NewIntrospectTokenService(t)
+
+			introspectTokenService.
+				On("IntrospectToken", context.Background(), d.tokenRaw, d.now, false).
+				Return(d.introspectToken, d.introspectTokenErr)
+
+			if d.shouldCallGet {
+				totpDAO.
+					On("Get", context.Background(), goframework.NumberUUID(1)).
+					Return(d.get, d.getErr)
+			}
+
+			if d.shouldCallConfirm {
+				totpDAO.
+					On("Confirm", context.Background(), goframework.NumberUUID(1), d.confirmStep, d.now).
+					Return(nil, d.confirmErr)
+			}
+
+			service := services.NewConfirmTOTPService(totpDAO, introspectTokenService)
+			err := service.ConfirmTOTP(context.Background(), d.tokenRaw, d.code, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			totpDAO.AssertExpectations(t)
+			introspectTokenService.AssertExpectations(t)
+		})
+	}
+}
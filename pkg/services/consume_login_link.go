@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"time"
+)
+
+type ConsumeLoginLinkService interface {
+	// ConsumeLoginLink exchanges a link sent by SendLoginLinkService for a new session. A link can only be used once.
+	//
+	// Like with LoginService, users with a second factor get an MFA challenge instead of a session.
+	ConsumeLoginLink(ctx context.Context, id uuid.UUID, code string, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error)
+}
+
+func NewConsumeLoginLinkService(
+	loginLinksDAO dao.LoginLinksRepository,
+	totpDAO dao.TOTPRepository,
+	passkeysDAO dao.PasskeysRepository,
+	createSessionService CreateSessionService,
+	createMFAChallengeService CreateMFAChallengeService,
+) ConsumeLoginLinkService {
+	return &consumeLoginLinkServiceImpl{
+		loginLinksDAO:             loginLinksDAO,
+		totpDAO:                   totpDAO,
+		passkeysDAO:               passkeysDAO,
+		CreateSessionService:      createSessionService,
+		CreateMFAChallengeService: createMFAChallengeService,
+	}
+}
+
+type consumeLoginLinkServiceImpl struct {
+	loginLinksDAO dao.LoginLinksRepository
+	totpDAO       dao.TOTPRepository
+	passkeysDAO   dao.PasskeysRepository
+	CreateSessionService
+	CreateMFAChallengeService
+}
+
+func (s *consumeLoginLinkServiceImpl) ConsumeLoginLink(ctx context.Context, id uuid.UUID, code string, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error) {
+	if code == "" {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidLoginLink)
+	}
+
+	link, err := s.loginLinksDAO.Get(ctx, id)
+	if err != nil {
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidLoginLink, err)
+		}
+
+		return nil, goerrors.Join(ErrGetLoginLink, err)
+	}
+
+	ok, err := goframework.VerifyCode(code, link.CodeHashed)
+	if err != nil {
+		return nil, goerrors.Join(ErrVerifyValidationCode, err)
+	}
+	if !ok {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidLoginLink)
+	}
+
+	if link.UsedAt != nil || !link.ExpiresAt.After(now) {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidLoginLink)
+	}
+
+	if _, err := s.loginLinksDAO.Use(ctx, link.ID, now); err != nil {
+		// Another request used the link in the meantime.
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidLoginLink, err)
+		}
+
+		return nil, goerrors.Join(ErrUseLoginLink, err)
+	}
+
+	requireMFA, err := requireMFA(ctx, s.totpDAO, s.passkeysDAO, link.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if requireMFA {
+		challenge, err := s.CreateMFAChallenge(ctx, link.UserID, uuid.New(), now)
+		if err != nil {
+			return nil, goerrors.Join(ErrCreateMFAChallenge, err)
+		}
+
+		return &models.UserTokenStatus{MFAChallenge: challenge}, nil
+	}
+
+	status, err := s.CreateSession(ctx, link.UserID, client, now)
+	if err != nil {
+		return nil, goerrors.Join(ErrCreateSession, err)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,279 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestConsumeLoginLink(t *testing.T) {
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
+	validLink := &dao.LoginLinkModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		LoginLinkModelCore: dao.LoginLinkModelCore{
+			UserID:     goframework.NumberUUID(10),
+			CodeHashed: privateValidationCode,
+			ExpiresAt:  baseTime.Add(15 * time.Minute),
+		},
+	}
+
+	usedLink := &dao.LoginLinkModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
+		LoginLinkModelCore: dao.LoginLinkModelCore{
+			UserID:     goframework.NumberUUID(10),
+			CodeHashed: privateValidationCode,
+			ExpiresAt:  baseTime.Add(15 * time.Minute),
+			UsedAt:     &baseTime,
+		},
+	}
+
+	data := []struct {
+		name string
+
+		code string
+		now  time.Time
+
+		shouldCallGet bool
+		get           *dao.LoginLinkModel
+		getErr        error
+
+		shouldCallUse bool
+		useErr        error
+
+		shouldCallGetTOTP bool
+		getTOTP           *dao.TOTPModel
+		getTOTPErr        error
+
+		shouldCallListPasskeys bool
+		listPasskeys           []*dao.PasskeyModel
+		listPasskeysErr        error
+
+		shouldCallCreateMFAChallenge bool
+		createMFAChallengeErr        error
+
+		shouldCallCreateSession bool
+		createSession           *models.UserTokenStatus
+		createSessionErr        error
+
+		expect    *models.UserTokenStatus
+		expectErr error
+	}{
+		{
+			name:                    "Success",
+			code:                    publicValidationCode,
+			now:                     baseTime,
+			shouldCallGet:           true,
+			get:                     validLink,
+			shouldCallUse:           true,
+			shouldCallGetTOTP:       true,
+			getTOTPErr:              bunovel.ErrNotFound,
+			shouldCallListPasskeys:  true,
+			shouldCallCreateSession: true,
+			createSession:           &models.UserTokenStatus{OK: true, RefreshToken: "refresh-token"},
+			expect:                  &models.UserTokenStatus{OK: true, RefreshToken: "refresh-token"},
+		},
+		{
+			name:              "Success/MFARequired",
+			code:              publicValidationCode,
+			now:               baseTime,
+			shouldCallGet:     true,
+			get:               validLink,
+			shouldCallUse:     true,
+			shouldCallGetTOTP: true,
+			getTOTP: &dao.TOTPModel{
+				Metadata:      bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, nil),
+				TOTPModelCore: dao.TOTPModelCore{Secret: totpSecret, ConfirmedAt: &baseTime},
+			},
+			shouldCallCreateMFAChallenge: true,
+			expect:                       &models.UserTokenStatus{MFAChallenge: "mfa-challenge"},
+		},
+		{
+			name:                    "Error/CreateSessionFailure",
+			code:                    publicValidationCode,
+			now:                     baseTime,
+			shouldCallGet:           true,
+			get:                     validLink,
+			shouldCallUse:           true,
+			shouldCallGetTOTP:       true,
+			getTOTPErr:              bunovel.ErrNotFound,
+			shouldCallListPasskeys:  true,
+			shouldCallCreateSession: true,
+			createSessionErr:        fooErr,
+			expectErr:               fooErr,
+		},
+		{
+			name:                         "Error/CreateMFAChallengeFailure",
+			code:                         publicValidationCode,
+			now:                          baseTime,
+			shouldCallGet:                true,
+			get:                          validLink,
+			shouldCallUse:                true,
+			shouldCallGetTOTP:            true,
+			getTOTPErr:                   bunovel.ErrNotFound,
+			shouldCallListPasskeys:       true,
+			listPasskeys:                 []*dao.PasskeyModel{passkeyModel(0)},
+			shouldCallCreateMFAChallenge: true,
+			createMFAChallengeErr:        fooErr,
+			expectErr:                    fooErr,
+		},
+		{
+			name:                   "Error/ListPasskeysFailure",
+			code:                   publicValidationCode,
+			now:                    baseTime,
+			shouldCallGet:          true,
+			get:                    validLink,
+			shouldCallUse:          true,
+			shouldCallGetTOTP:      true,
+			getTOTPErr:             bunovel.ErrNotFound,
+			shouldCallListPasskeys: true,
+			listPasskeysErr:        fooErr,
+			expectErr:              fooErr,
+		},
+		{
+			name:              "Error/GetTOTPFailure",
+			code:              publicValidationCode,
+			now:               baseTime,
+			shouldCallGet:     true,
+			get:               validLink,
+			shouldCallUse:     true,
+			shouldCallGetTOTP: true,
+			getTOTPErr:        fooErr,
+			expectErr:         fooErr,
+		},
+		{
+			name:          "Error/UsedConcurrently",
+			code:          publicValidationCode,
+			now:           baseTime,
+			shouldCallGet: true,
+			get:           validLink,
+			shouldCallUse: true,
+			useErr:        bunovel.ErrNotFound,
+			expectErr:     goframework.ErrInvalidCredentials,
+		},
+		{
+			name:          "Error/UseFailure",
+			code:          publicValidationCode,
+			now:           baseTime,
+			shouldCallGet: true,
+			get:           validLink,
+			shouldCallUse: true,
+			useErr:        fooErr,
+			expectErr:     fooErr,
+		},
+		{
+			name:          "Error/Expired",
+			code:          publicValidationCode,
+			now:           baseTime.Add(15 * time.Minute),
+			shouldCallGet: true,
+			get:           validLink,
+			expectErr:     services.ErrInvalidLoginLink,
+		},
+		{
+			name:          "Error/AlreadyUsed",
+			code:          publicValidationCode,
+			now:           baseTime,
+			shouldCallGet: true,
+			get:           usedLink,
+			expectErr:     services.ErrInvalidLoginLink,
+		},
+		{
+			name:          "Error/WrongCode",
+			code:          "wrong-code",
+			now:           baseTime,
+			shouldCallGet: true,
+			get:           validLink,
+			expectErr:     goframework.ErrInvalidCredentials,
+		},
+		{
+			name:          "Error/NotFound",
+			code:          publicValidationCode,
+			now:           baseTime,
+			shouldCallGet: true,
+			getErr:        bunovel.ErrNotFound,
+			expectErr:     goframework.ErrInvalidCredentials,
+		},
+		{
+			name:          "Error/GetFailure",
+			code:          publicValidationCode,
+			now:           baseTime,
+			shouldCallGet: true,
+			getErr:        fooErr,
+			expectErr:     fooErr,
+		},
+		{
+			name:      "Error/MissingCode",
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			loginLinksDAO := daomocks.NewLoginLinksRepository(t)
+			totpDAO := daomocks.NewTOTPRepository(t)
+			passkeysDAO := daomocks.NewPasskeysRepository(t)
+			createSessionService := servicesmocks.NewCreateSessionService(t)
+			createMFAChallengeService := servicesmocks.NewCreateMFAChallengeService(t)
+
+			if d.shouldCallGet {
+				loginLinksDAO.
+					On("Get", context.Background(), goframework.NumberUUID(1)).
+					Return(d.get, d.getErr)
+			}
+
+			if d.shouldCallUse {
+				loginLinksDAO.
+					On("Use", context.Background(), goframework.NumberUUID(1), d.now).
+					Return(nil, d.useErr)
+			}
+
+			if d.shouldCallGetTOTP {
+				totpDAO.
+					On("Get", context.Background(), goframework.NumberUUID(10)).
+					Return(d.getTOTP, d.getTOTPErr)
+			}
+
+			if d.shouldCallListPasskeys {
+				passkeysDAO.
+					On("ListUserPasskeys", context.Background(), goframework.NumberUUID(10)).
+					Return(d.listPasskeys, d.listPasskeysErr)
+			}
+
+			if d.shouldCallCreateMFAChallenge {
+				createMFAChallengeService.
+					On("CreateMFAChallenge", context.Background(), goframework.NumberUUID(10), mock.Anything, d.now).
+					Return("mfa-challenge", d.createMFAChallengeErr)
+			}
+
+			if d.shouldCallCreateSession {
+				createSessionService.
+					On("CreateSession", context.Background(), goframework.NumberUUID(10), client, d.now).
+					Return(d.createSession, d.createSessionErr)
+			}
+
+			service := services.NewConsumeLoginLinkService(
+				loginLinksDAO, totpDAO, passkeysDAO, createSessionService, createMFAChallengeService,
+			)
+			res, err := service.ConsumeLoginLink(context.Background(), goframework.NumberUUID(1), d.code, client, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			loginLinksDAO.AssertExpectations(t)
+			totpDAO.AssertExpectations(t)
+			passkeysDAO.AssertExpectations(t)
+			createSessionService.AssertExpectations(t)
+			createMFAChallengeService.AssertExpectations(t)
+		})
+	}
+}
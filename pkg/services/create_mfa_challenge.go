@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/google/uuid"
+	"time"
+)
+
+type CreateMFAChallengeService interface {
+	// CreateMFAChallenge issues a challenge for a user who passed the first factor. The returned value is the only
+	// copy of the raw challenge: only its hashed version is stored.
+	CreateMFAChallenge(ctx context.Context, userID, id uuid.UUID, now time.Time) (string, error)
+}
+
+func NewCreateMFAChallengeService(
+	mfaChallengesDAO dao.MFAChallengesRepository,
+	generateCode func() (string, string, error),
+	challengeTTL time.Duration,
+) CreateMFAChallengeService {
+	return &createMFAChallengeServiceImpl{
+		mfaChallengesDAO: mfaChallengesDAO,
+		generateCode:     generateCode,
+		challengeTTL:     challengeTTL,
+	}
+}
+
+type createMFAChallengeServiceImpl struct {
+	mfaChallengesDAO dao.MFAChallengesRepository
+	generateCode     func() (string, string, error)
+	challengeTTL     time.Duration
+}
+
+func (s *createMFAChallengeServiceImpl) CreateMFAChallenge(ctx context.Context, userID, id uuid.UUID, now time.Time) (string, error) {
+	publicCode, privateCode, err := s.generateCode()
+	if err != nil {
+		return "", goerrors.Join(ErrGenerateValidationCode, err)
+	}
+
+	_, err = s.mfaChallengesDAO.Create(ctx, &dao.MFAChallengeModelCore{
+		UserID:      userID,
+		TokenHashed: privateCode,
+		ExpiresAt:   now.Add(s.challengeTTL),
+	}, id, now)
+	if err != nil {
+		return "", goerrors.Join(ErrCreateMFAChallenge, err)
+	}
+
+	// The ID is required to retrieve the hashed value, so the code can be verified.
+	return fmt.Sprintf("%s.%s", id, publicCode), nil
+}
@@ -0,0 +1,86 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/services"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCreateMFAChallenge(t *testing.T) {
+	data := []struct {
+		name string
+
+		challengeTTL time.Duration
+		now          time.Time
+
+		publicCode      string
+		privateCode     string
+		generateCodeErr error
+
+		shouldCallCreate bool
+		createErr        error
+
+		expect    string
+		expectErr error
+	}{
+		{
+			name:             "Success",
+			challengeTTL:     5 * time.Minute,
+			now:              baseTime,
+			publicCode:       "public-code",
+			privateCode:      "private-code",
+			shouldCallCreate: true,
+			expect:           "01010101-0101-0101-0101-010101010101.public-code",
+		},
+		{
+			name:             "Error/CreateFailure",
+			challengeTTL:     5 * time.Minute,
+			now:              baseTime,
+			publicCode:       "public-code",
+			privateCode:      "private-code",
+			shouldCallCreate: true,
+			createErr:        fooErr,
+			expectErr:        fooErr,
+		},
+		{
+			name:            "Error/GenerateCodeFailure",
+			challengeTTL:    5 * time.Minute,
+			now:             baseTime,
+			generateCodeErr: fooErr,
+			expectErr:       fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			mfaChallengesDAO := daomocks.NewMFAChallengesRepository(t)
+
+			generateCode := func() (string, string, error) {
+				return d.publicCode, d.privateCode, d.generateCodeErr
+			}
+
+			if d.shouldCallCreate {
+				mfaChallengesDAO.
+					On("Create", context.Background(), &dao.MFAChallengeModelCore{
+						UserID:      goframework.NumberUUID(10),
+						TokenHashed: d.privateCode,
+						ExpiresAt:   d.now.Add(d.challengeTTL),
+					}, goframework.NumberUUID(1), d.now).
+					Return(nil, d.createErr)
+			}
+
+			service := services.NewCreateMFAChallengeService(mfaChallengesDAO, generateCode, d.challengeTTL)
+			res, err := service.CreateMFAChallenge(context.Background(), goframework.NumberUUID(10), goframework.NumberUUID(1), d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			mfaChallengesDAO.AssertExpectations(t)
+		})
+	}
+}
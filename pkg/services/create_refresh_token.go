@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+type CreateRefreshTokenService interface {
+	// CreateRefreshToken issues a new refresh token for the user and family of the given payload. The returned value
+	// is the only copy of the raw token: only its hashed version is stored.
+	CreateRefreshToken(ctx context.Context, data models.UserTokenPayload, id uuid.UUID, now time.Time) (string, error)
+}
+
+func NewCreateRefreshTokenService(
+	refreshTokensDAO dao.RefreshTokensRepository,
+	generateCode func() (string, string, error),
+	refreshTTL time.Duration,
+) CreateRefreshTokenService {
+	return &createRefreshTokenServiceImpl{
+		refreshTokensDAO: refreshTokensDAO,
+		generateCode:     generateCode,
+		refreshTTL:       refreshTTL,
+	}
+}
+
+type createRefreshTokenServiceImpl struct {
+	refreshTokensDAO dao.RefreshTokensRepository
+	generateCode     func() (string, string, error)
+	refreshTTL       time.Duration
+}
+
+func (s *createRefreshTokenServiceImpl) CreateRefreshToken(ctx context.Context, data models.UserTokenPayload, id uuid.UUID, now time.Time) (string, error) {
+	publicCode, privateCode, err := s.generateCode()
+	if err != nil {
+		return "", goerrors.Join(ErrGenerateValidationCode, err)
+	}
+
+	_, err = s.refreshTokensDAO.Create(ctx, &dao.RefreshTokenModelCore{
+		FamilyID:      data.FamilyID,
+		UserID:        data.ID,
+		TokenHashed:   privateCode,
+		ExpiresAt:     now.Add(s.refreshTTL),
+		SecurityStamp: data.SecurityStamp,
+		AuthTime:      data.AuthTime,
+	}, id, now)
+	if err != nil {
+		return "", goerrors.Join(ErrCreateRefreshToken, err)
+	}
+
+	// The ID is required to retrieve the hashed value, so the code can be verified.
+	return fmt.Sprintf("%s.%s", id, publicCode), nil
+}
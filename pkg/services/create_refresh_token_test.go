@@ -0,0 +1,100 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCreateRefreshToken(t *testing.T) {
+	data := []struct {
+		name string
+
+		refreshTTL time.Duration
+		now        time.Time
+
+		publicCode      string
+		privateCode     string
+		generateCodeErr error
+
+		shouldCallCreate bool
+		createErr        error
+
+		expect    string
+		expectErr error
+	}{
+		{
+			name:             "Success",
+			refreshTTL:       time.Hour,
+			now:              baseTime,
+			publicCode:       "public-code",
+			privateCode:      "private-code",
+			shouldCallCreate: true,
+			expect:           "01010101-0101-0101-0101-010101010101.public-code",
+		},
+		{
+			name:             "Error/CreateFailure",
+			refreshTTL:       time.Hour,
+			now:              baseTime,
+			publicCode:       "public-code",
+			privateCode:      "private-code",
+			shouldCallCreate: true,
+			createErr:        fooErr,
+			expectErr:        fooErr,
+		},
+		{
+			name:            "Error/GenerateCodeFailure",
+			refreshTTL:      time.Hour,
+			now:             baseTime,
+			generateCodeErr: fooErr,
+			expectErr:       fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			refreshTokensDAO := daomocks.NewRefreshTokensRepository(t)
+
+			generateCode := func() (string, string, error) {
+				return d.publicCode, d.privateCode, d.generateCodeErr
+			}
+
+			if d.shouldCallCreate {
+				refreshTokensDAO.
+					On("Create", context.Background(), &dao.RefreshTokenModelCore{
+						FamilyID:      goframework.NumberUUID(100),
+						UserID:        goframework.NumberUUID(10),
+						TokenHashed:   d.privateCode,
+						ExpiresAt:     d.now.Add(d.refreshTTL),
+						SecurityStamp: goframework.NumberUUID(20),
+						AuthTime:      baseTime,
+					}, goframework.NumberUUID(1), d.now).
+					Return(nil, d.createErr)
+			}
+
+			service := services.NewCreateRefreshTokenService(refreshTokensDAO, generateCode, d.refreshTTL)
+			res, err := service.CreateRefreshToken(
+				context.Background(),
+				models.UserTokenPayload{
+					ID:            goframework.NumberUUID(10),
+					FamilyID:      goframework.NumberUUID(100),
+					SecurityStamp: goframework.NumberUUID(20),
+					AuthTime:      baseTime,
+				},
+				goframework.NumberUUID(1),
+				d.now,
+			)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			refreshTokensDAO.AssertExpectations(t)
+		})
+	}
+}
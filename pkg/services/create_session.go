@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"time"
+)
+
+type CreateSessionService interface {
+	// CreateSession opens a new session for the given user, on a new device. It returns an access token, along with
+	// the first refresh token of the session. Sessions cannot be opened on a locked account. The user is alerted
+	// when the session comes from a device they never used before.
+	CreateSession(ctx context.Context, userID uuid.UUID, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error)
+}
+
+func NewCreateSessionService(
+	credentialsDAO dao.CredentialsRepository,
+	sessionsDAO dao.SessionsRepository,
+	auditEventsDAO dao.AuditEventsRepository,
+	generateTokenService GenerateTokenService,
+	createRefreshTokenService CreateRefreshTokenService,
+	sendSecurityAlertService SendSecurityAlertService,
+) CreateSessionService {
+	return &createSessionServiceImpl{
+		credentialsDAO:            credentialsDAO,
+		sessionsDAO:               sessionsDAO,
+		auditEventsDAO:            auditEventsDAO,
+		GenerateTokenService:      generateTokenService,
+		CreateRefreshTokenService: createRefreshTokenService,
+		SendSecurityAlertService:  sendSecurityAlertService,
+	}
+}
+
+type createSessionServiceImpl struct {
+	credentialsDAO dao.CredentialsRepository
+	sessionsDAO    dao.SessionsRepository
+	auditEventsDAO dao.AuditEventsRepository
+	GenerateTokenService
+	CreateRefreshTokenService
+	SendSecurityAlertService
+}
+
+func (s *createSessionServiceImpl) CreateSession(ctx context.Context, userID uuid.UUID, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error) {
+	// The tokens of the session are bound to the current security stamp of the user.
+	credentials, err := s.credentialsDAO.GetCredentials(ctx, userID)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetCredentials, err)
+	}
+	if credentials.DeletedAt != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrAccountDeleted)
+	}
+	if credentials.LockedAt != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrAccountLocked)
+	}
+	if credentials.Suspended(now) {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrAccountSuspended)
+	}
+
+	userAgent := truncate(client.UserAgent, MaxUserAgentLength)
+	ip := truncate(client.IP, MaxIPLength)
+
+	// Known devices must be listed before the new session is saved. The first session of a user is not reported.
+	knownUserAgents, err := s.sessionsDAO.ListUserAgents(ctx, userID)
+	if err != nil {
+		return nil, goerrors.Join(ErrListUserAgents, err)
+	}
+	newDevice := len(knownUserAgents) > 0 && !lo.Contains(knownUserAgents, userAgent)
+
+	// Every session starts a new refresh token family.
+	familyID := uuid.New()
+
+	_, err = s.sessionsDAO.Create(ctx, &dao.SessionModelCore{
+		FamilyID:   familyID,
+		UserID:     userID,
+		UserAgent:  userAgent,
+		IP:         ip,
+		LastSeenAt: now,
+	}, uuid.New(), now)
+	if err != nil {
+		return nil, goerrors.Join(ErrCreateSession, err)
+	}
+
+	if err := recordAuditEvent(ctx, s.auditEventsDAO, newAuditEvent(dao.AuditEventLoginSucceeded, userID, client), now); err != nil {
+		return nil, err
+	}
+
+	// Opening a session is the moment the user proves their identity.
+	payload := models.UserTokenPayload{
+		ID:            userID,
+		FamilyID:      familyID,
+		SecurityStamp: credentials.SecurityStamp,
+		AuthTime:      now,
+	}
+
+	status, err := s.GenerateToken(ctx, payload, uuid.New(), now)
+	if err != nil {
+		return nil, goerrors.Join(ErrGenerateToken, err)
+	}
+
+	status.RefreshToken, err = s.CreateRefreshToken(ctx, payload, uuid.New(), now)
+	if err != nil {
+		return nil, goerrors.Join(ErrCreateRefreshToken, err)
+	}
+
+	if newDevice {
+		// The session is already open, so a failure to notify the user must not prevent the login.
+		_ = s.SendSecurityAlert(ctx, userID, SecurityAlertNewDevice, map[string]interface{}{
+			"user_agent": userAgent,
+			"ip":         ip,
+		})
+	}
+
+	return status, nil
+}
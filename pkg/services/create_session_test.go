@@ -0,0 +1,459 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateSession(t *testing.T) {
+	data := []struct {
+		name string
+
+		userID uuid.UUID
+		client models.ClientInfo
+		now    time.Time
+
+		locked            bool
+		deleted           bool
+		suspended         bool
+		getCredentialsErr error
+
+		shouldCallListUserAgents bool
+		listUserAgents           []string
+		listUserAgentsErr        error
+
+		shouldCallCreateSession bool
+		expectSessionCore       *dao.SessionModelCore
+		createSessionErr        error
+
+		shouldCallRecord bool
+		recordErr        error
+
+		shouldCallGenerateToken bool
+		generateTokenStatus     *models.UserTokenStatus
+		generateTokenErr        error
+
+		shouldCallCreateRefreshToken bool
+		createRefreshToken           string
+		createRefreshTokenErr        error
+
+		shouldCallSendSecurityAlert     bool
+		shouldCallSendSecurityAlertData map[string]interface{}
+		sendSecurityAlertErr            error
+
+		expect    *models.UserTokenStatus
+		expectErr error
+	}{
+		{
+			name:                     "Success",
+			userID:                   goframework.NumberUUID(1),
+			client:                   models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"},
+			now:                      baseTime,
+			shouldCallListUserAgents: true,
+			listUserAgents:           []string{"Mozilla/5.0"},
+			shouldCallCreateSession:  true,
+			expectSessionCore: &dao.SessionModelCore{
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "Mozilla/5.0",
+				IP:         "127.0.0.1",
+				LastSeenAt: baseTime,
+			},
+			shouldCallRecord:        true,
+			shouldCallGenerateToken: true,
+			generateTokenStatus: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+			shouldCallCreateRefreshToken: true,
+			createRefreshToken:           "refresh-token",
+			expect: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+				RefreshToken: "refresh-token",
+			},
+		},
+		{
+			name:   "Success/TruncateClientInfo",
+			userID: goframework.NumberUUID(1),
+			client: models.ClientInfo{
+				UserAgent: strings.Repeat("a", services.MaxUserAgentLength+10),
+				IP:        strings.Repeat("1", services.MaxIPLength+10),
+			},
+			now:                      baseTime,
+			shouldCallListUserAgents: true,
+			listUserAgents:           []string{strings.Repeat("a", services.MaxUserAgentLength)},
+			shouldCallCreateSession:  true,
+			expectSessionCore: &dao.SessionModelCore{
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  strings.Repeat("a", services.MaxUserAgentLength),
+				IP:         strings.Repeat("1", services.MaxIPLength),
+				LastSeenAt: baseTime,
+			},
+			shouldCallRecord:        true,
+			shouldCallGenerateToken: true,
+			generateTokenStatus: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+			shouldCallCreateRefreshToken: true,
+			createRefreshToken:           "refresh-token",
+			expect: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+				RefreshToken: "refresh-token",
+			},
+		},
+		{
+			name:                     "Success/NewDevice",
+			userID:                   goframework.NumberUUID(1),
+			client:                   models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"},
+			now:                      baseTime,
+			shouldCallListUserAgents: true,
+			listUserAgents:           []string{"Chrome/124.0"},
+			shouldCallCreateSession:  true,
+			expectSessionCore: &dao.SessionModelCore{
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "Mozilla/5.0",
+				IP:         "127.0.0.1",
+				LastSeenAt: baseTime,
+			},
+			shouldCallRecord:        true,
+			shouldCallGenerateToken: true,
+			generateTokenStatus: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+			shouldCallCreateRefreshToken: true,
+			createRefreshToken:           "refresh-token",
+			shouldCallSendSecurityAlert:  true,
+			shouldCallSendSecurityAlertData: map[string]interface{}{
+				"user_agent": "Mozilla/5.0",
+				"ip":         "127.0.0.1",
+			},
+			expect: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+				RefreshToken: "refresh-token",
+			},
+		},
+		{
+			name:                     "Success/SendSecurityAlertFailure",
+			userID:                   goframework.NumberUUID(1),
+			client:                   models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"},
+			now:                      baseTime,
+			shouldCallListUserAgents: true,
+			listUserAgents:           []string{"Chrome/124.0"},
+			shouldCallCreateSession:  true,
+			expectSessionCore: &dao.SessionModelCore{
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "Mozilla/5.0",
+				IP:         "127.0.0.1",
+				LastSeenAt: baseTime,
+			},
+			shouldCallRecord:        true,
+			shouldCallGenerateToken: true,
+			generateTokenStatus: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+			shouldCallCreateRefreshToken: true,
+			createRefreshToken:           "refresh-token",
+			shouldCallSendSecurityAlert:  true,
+			shouldCallSendSecurityAlertData: map[string]interface{}{
+				"user_agent": "Mozilla/5.0",
+				"ip":         "127.0.0.1",
+			},
+			sendSecurityAlertErr: fooErr,
+			expect: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+				RefreshToken: "refresh-token",
+			},
+		},
+		{
+			name:                     "Success/FirstSession",
+			userID:                   goframework.NumberUUID(1),
+			client:                   models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"},
+			now:                      baseTime,
+			shouldCallListUserAgents: true,
+			shouldCallCreateSession:  true,
+			expectSessionCore: &dao.SessionModelCore{
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "Mozilla/5.0",
+				IP:         "127.0.0.1",
+				LastSeenAt: baseTime,
+			},
+			shouldCallRecord:        true,
+			shouldCallGenerateToken: true,
+			generateTokenStatus: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+			shouldCallCreateRefreshToken: true,
+			createRefreshToken:           "refresh-token",
+			expect: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+				RefreshToken: "refresh-token",
+			},
+		},
+		{
+			name:                     "Error/CreateRefreshTokenFailure",
+			userID:                   goframework.NumberUUID(1),
+			client:                   models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"},
+			now:                      baseTime,
+			shouldCallListUserAgents: true,
+			listUserAgents:           []string{"Mozilla/5.0"},
+			shouldCallCreateSession:  true,
+			expectSessionCore: &dao.SessionModelCore{
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "Mozilla/5.0",
+				IP:         "127.0.0.1",
+				LastSeenAt: baseTime,
+			},
+			shouldCallRecord:        true,
+			shouldCallGenerateToken: true,
+			generateTokenStatus: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+			shouldCallCreateRefreshToken: true,
+			createRefreshTokenErr:        fooErr,
+			expectErr:                    fooErr,
+		},
+		{
+			name:                     "Error/GenerateTokenFailure",
+			userID:                   goframework.NumberUUID(1),
+			client:                   models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"},
+			now:                      baseTime,
+			shouldCallListUserAgents: true,
+			listUserAgents:           []string{"Mozilla/5.0"},
+			shouldCallCreateSession:  true,
+			expectSessionCore: &dao.SessionModelCore{
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "Mozilla/5.0",
+				IP:         "127.0.0.1",
+				LastSeenAt: baseTime,
+			},
+			shouldCallRecord:        true,
+			shouldCallGenerateToken: true,
+			generateTokenErr:        fooErr,
+			expectErr:               fooErr,
+		},
+		{
+			name:                     "Error/RecordAuditEventFailure",
+			userID:                   goframework.NumberUUID(1),
+			client:                   models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"},
+			now:                      baseTime,
+			shouldCallListUserAgents: true,
+			listUserAgents:           []string{"Mozilla/5.0"},
+			shouldCallCreateSession:  true,
+			expectSessionCore: &dao.SessionModelCore{
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "Mozilla/5.0",
+				IP:         "127.0.0.1",
+				LastSeenAt: baseTime,
+			},
+			shouldCallRecord: true,
+			recordErr:        fooErr,
+			expectErr:        fooErr,
+		},
+		{
+			name:                     "Error/CreateSessionFailure",
+			userID:                   goframework.NumberUUID(1),
+			client:                   models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"},
+			now:                      baseTime,
+			shouldCallListUserAgents: true,
+			listUserAgents:           []string{"Mozilla/5.0"},
+			shouldCallCreateSession:  true,
+			expectSessionCore: &dao.SessionModelCore{
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "Mozilla/5.0",
+				IP:         "127.0.0.1",
+				LastSeenAt: baseTime,
+			},
+			createSessionErr: fooErr,
+			expectErr:        fooErr,
+		},
+		{
+			name:                     "Error/ListUserAgentsFailure",
+			userID:                   goframework.NumberUUID(1),
+			client:                   models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"},
+			now:                      baseTime,
+			shouldCallListUserAgents: true,
+			listUserAgentsErr:        fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:      "Error/AccountLocked",
+			userID:    goframework.NumberUUID(1),
+			client:    models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"},
+			now:       baseTime,
+			locked:    true,
+			expectErr: services.ErrAccountLocked,
+		},
+		{
+			name:      "Error/AccountDeleted",
+			userID:    goframework.NumberUUID(1),
+			client:    models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"},
+			now:       baseTime,
+			deleted:   true,
+			expectErr: services.ErrAccountDeleted,
+		},
+		{
+			name:      "Error/AccountSuspended",
+			userID:    goframework.NumberUUID(1),
+			client:    models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"},
+			now:       baseTime,
+			suspended: true,
+			expectErr: services.ErrAccountSuspended,
+		},
+		{
+			name:              "Error/GetCredentialsFailure",
+			userID:            goframework.NumberUUID(1),
+			client:            models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"},
+			now:               baseTime,
+			getCredentialsErr: fooErr,
+			expectErr:         fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			sessionsDAO := daomocks.NewSessionsRepository(t)
+			auditEventsDAO := daomocks.NewAuditEventsRepository(t)
+			generateTokenService := servicesmocks.NewGenerateTokenService(t)
+			createRefreshTokenService := servicesmocks.NewCreateRefreshTokenService(t)
+
+			sendSecurityAlertService := servicesmocks.NewSendSecurityAlertService(t)
+
+			credentials := &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{SecurityStamp: goframework.NumberUUID(20)},
+			}
+			if d.locked {
+				credentials.LockedAt = &baseTime
+			}
+			if d.deleted {
+				credentials.DeletedAt = &baseTime
+			}
+			if d.suspended {
+				credentials.SuspendedAt = &baseTime
+			}
+
+			credentialsDAO.
+				On("GetCredentials", context.Background(), d.userID).
+				Return(credentials, d.getCredentialsErr)
+
+			if d.shouldCallListUserAgents {
+				sessionsDAO.
+					On("ListUserAgents", context.Background(), d.userID).
+					Return(d.listUserAgents, d.listUserAgentsErr)
+			}
+
+			// The family ID is generated by the service, so it is captured on the first call, then checked against
+			// the other dependencies.
+			var familyID uuid.UUID
+
+			if d.shouldCallCreateSession {
+				sessionsDAO.
+					On("Create", context.Background(), mock.MatchedBy(func(core *dao.SessionModelCore) bool {
+						familyID = core.FamilyID
+						expected := *d.expectSessionCore
+						expected.FamilyID = core.FamilyID
+						return core.FamilyID != uuid.Nil && *core == expected
+					}), mock.Anything, d.now).
+					Return(nil, d.createSessionErr)
+			}
+
+			if d.shouldCallRecord {
+				auditEventsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:      dao.AuditEventLoginSucceeded,
+						UserID:    d.userID,
+						IP:        d.expectSessionCore.IP,
+						UserAgent: d.expectSessionCore.UserAgent,
+					}, mock.Anything, d.now).
+					Return(nil, d.recordErr)
+			}
+
+			matchPayload := mock.MatchedBy(func(payload models.UserTokenPayload) bool {
+				return payload.ID == d.userID &&
+					payload.FamilyID == familyID &&
+					payload.SecurityStamp == goframework.NumberUUID(20) &&
+					payload.AuthTime.Equal(d.now)
+			})
+
+			if d.shouldCallGenerateToken {
+				generateTokenService.
+					On("GenerateToken", context.Background(), matchPayload, mock.Anything, d.now).
+					Return(d.generateTokenStatus, d.generateTokenErr)
+			}
+
+			if d.shouldCallCreateRefreshToken {
+				createRefreshTokenService.
+					On("CreateRefreshToken", context.Background(), matchPayload, mock.Anything, d.now).
+					Return(d.createRefreshToken, d.createRefreshTokenErr)
+			}
+
+			if d.shouldCallSendSecurityAlert {
+				sendSecurityAlertService.
+					On("SendSecurityAlert", context.Background(), d.userID, services.SecurityAlertNewDevice, d.shouldCallSendSecurityAlertData).
+					Return(d.sendSecurityAlertErr)
+			}
+
+			service := services.NewCreateSessionService(
+				credentialsDAO,
+				sessionsDAO,
+				auditEventsDAO,
+				generateTokenService,
+				createRefreshTokenService,
+				sendSecurityAlertService,
+			)
+			res, err := service.CreateSession(context.Background(), d.userID, d.client, d.now)
+
+			require.Equal(t, d.expect, res)
+			require.ErrorIs(t, err, d.expectErr)
+
+			credentialsDAO.AssertExpectations(t)
+			sessionsDAO.AssertExpectations(t)
+			auditEventsDAO.AssertExpectations(t)
+			generateTokenService.AssertExpectations(t)
+			createRefreshTokenService.AssertExpectations(t)
+			sendSecurityAlertService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	sendgridproxy "github.com/a-novel/sendgrid-proxy"
+	"github.com/google/uuid"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+	"time"
+)
+
+type DeleteAccountService interface {
+	// DeleteAccount deletes the account of the current user, and sends them a link to cancel the deletion. The
+	// account is only purged once the grace period is over. The password of the user is always required.
+	DeleteAccount(ctx context.Context, tokenRaw, password string, client models.ClientInfo, now time.Time) (func() error, error)
+}
+
+func NewDeleteAccountService(
+	credentialsDAO dao.CredentialsRepository,
+	identityDAO dao.IdentityRepository,
+	mailer sendgridproxy.Mailer,
+	generateValidationLink func() (string, string, error),
+	introspectTokenService IntrospectTokenService,
+	checkStepUpService CheckStepUpService,
+	cancelDeletionLink string,
+	accountDeletedTemplate string,
+) DeleteAccountService {
+	return &deleteAccountServiceImpl{
+		credentialsDAO:         credentialsDAO,
+		identityDAO:            identityDAO,
+		mailer:                 mailer,
+		generateValidationLink: generateValidationLink,
+		IntrospectTokenService: introspectTokenService,
+		CheckStepUpService:     checkStepUpService,
+		cancelDeletionLink:     cancelDeletionLink,
+		accountDeletedTemplate: accountDeletedTemplate,
+	}
+}
+
+type deleteAccountServiceImpl struct {
+	credentialsDAO         dao.CredentialsRepository
+	identityDAO            dao.IdentityRepository
+	mailer                 sendgridproxy.Mailer
+	generateValidationLink func() (string, string, error)
+	IntrospectTokenService
+	CheckStepUpService
+
+	cancelDeletionLink     string
+	accountDeletedTemplate string
+}
+
+func (s *deleteAccountServiceImpl) DeleteAccount(ctx context.Context, tokenRaw, password string, client models.ClientInfo, now time.Time) (func() error, error) {
+	token, err := s.IntrospectToken(ctx, tokenRaw, now, false)
+	if err != nil {
+		return nil, goerrors.Join(ErrIntrospectToken, err)
+	}
+	if !token.OK {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
+	}
+
+	if err := s.CheckStepUp(ctx, token.Token, SensitiveOperationDeleteAccount, password, client, now); err != nil {
+		return nil, goerrors.Join(ErrCheckStepUp, err)
+	}
+
+	publicCode, privateCode, err := s.generateValidationLink()
+	if err != nil {
+		return nil, goerrors.Join(ErrGenerateValidationCode, err)
+	}
+
+	// Replacing the security stamp logs the user out of every device.
+	credentials, err := s.credentialsDAO.Delete(ctx, privateCode, uuid.New(), token.Token.Payload.ID, now)
+	if err != nil {
+		return nil, goerrors.Join(ErrDeleteAccount, err)
+	}
+
+	identity, err := s.identityDAO.GetIdentity(ctx, token.Token.Payload.ID)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetIdentity, err)
+	}
+
+	deferred := func() error {
+		to := mail.NewEmail(identity.FirstName, credentials.Email.String())
+		templateData := map[string]interface{}{
+			"name":        identity.FirstName,
+			"cancel_link": fmt.Sprintf("%s?id=%s&code=%s", s.cancelDeletionLink, token.Token.Payload.ID, publicCode),
+		}
+
+		if err := s.mailer.Send(ctx, to, s.accountDeletedTemplate, templateData); err != nil {
+			return goerrors.Join(ErrSendDeletionEmail, err)
+		}
+
+		return nil
+	}
+
+	return deferred, nil
+}
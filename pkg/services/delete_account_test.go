@@ -0,0 +1,301 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	sendgridproxy "github.com/a-novel/sendgrid-proxy"
+	"github.com/google/uuid"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDeleteAccount(t *testing.T) {
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
+	data := []struct {
+		name string
+
+		cancelDeletionLink     string
+		accountDeletedTemplate string
+
+		tokenRaw string
+		password string
+		now      time.Time
+
+		introspectToken    *models.UserTokenStatus
+		introspectTokenErr error
+
+		shouldCallCheckStepUp bool
+		checkStepUpErr        error
+
+		publicValidationCode      string
+		privateValidationCode     string
+		generateValidationCodeErr error
+
+		shouldCallDelete bool
+		deleteDAO        *dao.CredentialsModel
+		deleteErr        error
+
+		shouldCallIdentityDAO bool
+		identityDAO           *dao.IdentityModel
+		identityDAOErr        error
+
+		shouldCallMailer          bool
+		shouldCallMailerWithEmail *mail.Email
+		shouldCallMailerWithData  map[string]interface{}
+		mailerErr                 error
+
+		expectErr         error
+		expectDeferred    bool
+		expectDeferredErr error
+	}{
+		{
+			name:                   "Success",
+			cancelDeletionLink:     "cancel-deletion-link",
+			accountDeletedTemplate: "account-deleted-template",
+			tokenRaw:               "string-token",
+			password:               "password",
+			now:                    baseTime,
+			introspectToken: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+			shouldCallCheckStepUp: true,
+			publicValidationCode:  "public-code",
+			privateValidationCode: "private-code",
+			shouldCallDelete:      true,
+			deleteDAO: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain.com"},
+				},
+			},
+			shouldCallIdentityDAO: true,
+			identityDAO: &dao.IdentityModel{
+				IdentityModelCore: dao.IdentityModelCore{FirstName: "name"},
+			},
+			shouldCallMailer:          true,
+			shouldCallMailerWithEmail: mail.NewEmail("name", "user@domain.com"),
+			shouldCallMailerWithData: map[string]interface{}{
+				"name":        "name",
+				"cancel_link": "cancel-deletion-link?id=01010101-0101-0101-0101-010101010101&code=public-code",
+			},
+			expectDeferred: true,
+		},
+		{
+			name:                   "Error/MailerFailure",
+			cancelDeletionLink:     "cancel-deletion-link",
+			accountDeletedTemplate: "account-deleted-template",
+			tokenRaw:               "string-token",
+			password:               "password",
+			now:                    baseTime,
+			introspectToken: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+			shouldCallCheckStepUp: true,
+			publicValidationCode:  "public-code",
+			privateValidationCode: "private-code",
+			shouldCallDelete:      true,
+			deleteDAO: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain.com"},
+				},
+			},
+			shouldCallIdentityDAO: true,
+			identityDAO: &dao.IdentityModel{
+				IdentityModelCore: dao.IdentityModelCore{FirstName: "name"},
+			},
+			shouldCallMailer:          true,
+			shouldCallMailerWithEmail: mail.NewEmail("name", "user@domain.com"),
+			shouldCallMailerWithData: map[string]interface{}{
+				"name":        "name",
+				"cancel_link": "cancel-deletion-link?id=01010101-0101-0101-0101-010101010101&code=public-code",
+			},
+			mailerErr:         fooErr,
+			expectDeferred:    true,
+			expectDeferredErr: services.ErrSendDeletionEmail,
+		},
+		{
+			name:                   "Error/IdentityDAOFailure",
+			cancelDeletionLink:     "cancel-deletion-link",
+			accountDeletedTemplate: "account-deleted-template",
+			tokenRaw:               "string-token",
+			password:               "password",
+			now:                    baseTime,
+			introspectToken: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+			shouldCallCheckStepUp: true,
+			publicValidationCode:  "public-code",
+			privateValidationCode: "private-code",
+			shouldCallDelete:      true,
+			deleteDAO: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain.com"},
+				},
+			},
+			shouldCallIdentityDAO: true,
+			identityDAOErr:        fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:                   "Error/DeleteFailure",
+			cancelDeletionLink:     "cancel-deletion-link",
+			accountDeletedTemplate: "account-deleted-template",
+			tokenRaw:               "string-token",
+			password:               "password",
+			now:                    baseTime,
+			introspectToken: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+			shouldCallCheckStepUp: true,
+			publicValidationCode:  "public-code",
+			privateValidationCode: "private-code",
+			shouldCallDelete:      true,
+			deleteErr:             fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:                   "Error/GenerateValidationCodeFailure",
+			cancelDeletionLink:     "cancel-deletion-link",
+			accountDeletedTemplate: "account-deleted-template",
+			tokenRaw:               "string-token",
+			password:               "password",
+			now:                    baseTime,
+			introspectToken: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+			shouldCallCheckStepUp:     true,
+			generateValidationCodeErr: fooErr,
+			expectErr:                 fooErr,
+		},
+		{
+			name:                   "Error/CheckStepUpFailure",
+			cancelDeletionLink:     "cancel-deletion-link",
+			accountDeletedTemplate: "account-deleted-template",
+			tokenRaw:               "string-token",
+			now:                    baseTime,
+			introspectToken: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+			shouldCallCheckStepUp: true,
+			checkStepUpErr:        services.ErrStepUpRequired,
+			expectErr:             services.ErrStepUpRequired,
+		},
+		{
+			name:                   "Error/InvalidToken",
+			cancelDeletionLink:     "cancel-deletion-link",
+			accountDeletedTemplate: "account-deleted-template",
+			tokenRaw:               "string-token",
+			password:               "password",
+			now:                    baseTime,
+			introspectToken:        &models.UserTokenStatus{},
+			expectErr:              goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                   "Error/IntrospectTokenFailure",
+			cancelDeletionLink:     "cancel-deletion-link",
+			accountDeletedTemplate: "account-deleted-template",
+			tokenRaw:               "string-token",
+			password:               "password",
+			now:                    baseTime,
+			introspectTokenErr:     fooErr,
+			expectErr:              fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			identityDAO := daomocks.NewIdentityRepository(t)
+			mailerService := sendgridproxy.NewMockMailer(t)
+			introspectTokenService := servicesmocks.NewIntrospectTokenService(t)
+			checkStepUpService := servicesmocks.NewCheckStepUpService(t)
+
+			generateLink := func() (string, string, error) {
+				return d.publicValidationCode, d.privateValidationCode, d.generateValidationCodeErr
+			}
+
+			introspectTokenService.
+				On("IntrospectToken", context.Background(), d.tokenRaw, d.now, false).
+				Return(d.introspectToken, d.introspectTokenErr)
+
+			if d.shouldCallCheckStepUp {
+				checkStepUpService.
+					On("CheckStepUp", context.Background(), d.introspectToken.Token, services.SensitiveOperationDeleteAccount, d.password, client, d.now).
+					Return(d.checkStepUpErr)
+			}
+
+			if d.shouldCallDelete {
+				credentialsDAO.
+					On("Delete", context.Background(), d.privateValidationCode, mock.MatchedBy(func(stamp uuid.UUID) bool {
+						return stamp != uuid.Nil
+					}), d.introspectToken.Token.Payload.ID, d.now).
+					Return(d.deleteDAO, d.deleteErr)
+			}
+
+			if d.shouldCallIdentityDAO {
+				identityDAO.
+					On("GetIdentity", context.Background(), d.introspectToken.Token.Payload.ID).
+					Return(d.identityDAO, d.identityDAOErr)
+			}
+
+			if d.shouldCallMailer {
+				mailerService.
+					On("Send", context.Background(), d.shouldCallMailerWithEmail, d.accountDeletedTemplate, d.shouldCallMailerWithData).
+					Return(d.mailerErr)
+			}
+
+			service := services.NewDeleteAccountService(
+				credentialsDAO,
+				identityDAO,
+				mailerService,
+				generateLink,
+				introspectTokenService,
+				checkStepUpService,
+				d.cancelDeletionLink,
+				d.accountDeletedTemplate,
+			)
+			deferred, err := service.DeleteAccount(context.Background(), d.tokenRaw, d.password, client, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			if d.expectDeferred {
+				require.NotNil(t, deferred)
+				require.ErrorIs(t, deferred(), d.expectDeferredErr)
+			} else {
+				require.Nil(t, deferred)
+			}
+
+			credentialsDAO.AssertExpectations(t)
+			identityDAO.AssertExpectations(t)
+			mailerService.AssertExpectations(t)
+			introspectTokenService.AssertExpectations(t)
+			checkStepUpService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"time"
+)
+
+type DisableTOTPService interface {
+	// DisableTOTP removes the TOTP secret and the recovery codes of the user. Once two-factor authentication is
+	// enabled, a valid TOTP or recovery code is required. A pending enrollment can be cancelled without code. The
+	// password is required if the user did not authenticate recently.
+	DisableTOTP(ctx context.Context, tokenRaw, code, password string, client models.ClientInfo, now time.Time) error
+}
+
+func NewDisableTOTPService(
+	totpDAO dao.TOTPRepository,
+	credentialsDAO dao.CredentialsRepository,
+	loginFailuresDAO dao.LoginFailuresRepository,
+	auditEventsDAO dao.AuditEventsRepository,
+	introspectTokenService IntrospectTokenService,
+	checkStepUpService CheckStepUpService,
+	throttle LoginThrottle,
+) DisableTOTPService {
+	return &disableTOTPServiceImpl{
+		totpDAO:                totpDAO,
+		credentialsDAO:         credentialsDAO,
+		loginFailuresDAO:       loginFailuresDAO,
+		auditEventsDAO:         auditEventsDAO,
+		IntrospectTokenService: introspectTokenService,
+		CheckStepUpService:     checkStepUpService,
+		throttle:               throttle,
+	}
+}
+
+type disableTOTPServiceImpl struct {
+	totpDAO          dao.TOTPRepository
+	credentialsDAO   dao.CredentialsRepository
+	loginFailuresDAO dao.LoginFailuresRepository
+	auditEventsDAO   dao.AuditEventsRepository
+	IntrospectTokenService
+	CheckStepUpService
+	throttle LoginThrottle
+}
+
+func (s *disableTOTPServiceImpl) DisableTOTP(ctx context.Context, tokenRaw, code, password string, client models.ClientInfo, now time.Time) error {
+	token, err := s.IntrospectToken(ctx, tokenRaw, now, false)
+	if err != nil {
+		return goerrors.Join(ErrIntrospectToken, err)
+	}
+	if !token.OK {
+		return goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
+	}
+
+	if err := s.CheckStepUp(ctx, token.Token, SensitiveOperationUpdateSecondFactor, password, client, now); err != nil {
+		return goerrors.Join(ErrCheckStepUp, err)
+	}
+
+	totp, err := s.totpDAO.Get(ctx, token.Token.Payload.ID)
+	if err != nil {
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return goerrors.Join(ErrTOTPNotEnrolled, err)
+		}
+
+		return goerrors.Join(ErrGetTOTP, err)
+	}
+
+	if totp.ConfirmedAt != nil {
+		credentials, err := s.credentialsDAO.GetCredentials(ctx, token.Token.Payload.ID)
+		if err != nil {
+			return goerrors.Join(ErrGetCredentials, err)
+		}
+
+		account := loginAccount(credentials.Email)
+		if err := checkLoginThrottle(ctx, s.loginFailuresDAO, s.throttle, account, client.IP, now); err != nil {
+			return err
+		}
+
+		// A stolen session must not be enough to remove the second factor.
+		if err := checkSecondFactor(ctx, s.totpDAO, totp, code, now); err != nil {
+			if goerrors.Is(err, goframework.ErrInvalidCredentials) {
+				failErr := recordLoginFailure(
+					ctx, s.loginFailuresDAO, s.auditEventsDAO, account, token.Token.Payload.ID, loginFactorTOTP,
+					client, now,
+				)
+				if failErr != nil {
+					return failErr
+				}
+			}
+
+			return err
+		}
+	}
+
+	if err := s.totpDAO.Delete(ctx, token.Token.Payload.ID); err != nil {
+		return goerrors.Join(ErrDeleteTOTP, err)
+	}
+
+	return nil
+}
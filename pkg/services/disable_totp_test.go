@@ -0,0 +1,496 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDisableTOTP(t *testing.T) {
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
+	validToken := &models.UserTokenStatus{
+		OK: true,
+		Token: &models.UserToken{
+			Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+		},
+	}
+
+	pending := &dao.TOTPModel{
+		Metadata:      bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		TOTPModelCore: dao.TOTPModelCore{Secret: totpSecret},
+	}
+
+	confirmed := &dao.TOTPModel{
+		Metadata:      bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		TOTPModelCore: dao.TOTPModelCore{Secret: totpSecret, ConfirmedAt: &baseTime},
+	}
+
+	recoveryCodes := []*dao.RecoveryCodeModel{
+		{ID: goframework.NumberUUID(10), CreatedAt: baseTime, UserID: goframework.NumberUUID(1), CodeHashed: privateValidationCode},
+		{ID: goframework.NumberUUID(11), CreatedAt: baseTime, UserID: goframework.NumberUUID(1), CodeHashed: recoveryCodeHashed},
+	}
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		password string
+		code     string
+		now      time.Time
+
+		introspectToken    *models.UserTokenStatus
+		introspectTokenErr error
+
+		shouldCallCheckStepUp bool
+		checkStepUpErr        error
+
+		shouldCallGet bool
+		get           *dao.TOTPModel
+		getErr        error
+
+		shouldCallGetCredentials bool
+		getCredentialsErr        error
+
+		shouldCallCheckThrottle bool
+		getAccountFailures      *dao.LoginFailuresSummaryModel
+		getAccountFailuresErr   error
+
+		shouldCallUseStep bool
+		useStepErr        error
+
+		shouldCallListRecoveryCodes bool
+		listRecoveryCodes           []*dao.RecoveryCodeModel
+		listRecoveryCodesErr        error
+
+		shouldCallUseRecoveryCode bool
+		useRecoveryCodeErr        error
+
+		shouldCallRecordFailure bool
+		recordFailureErr        error
+
+		shouldCallRecordAuditEvent bool
+		recordAuditEventErr        error
+
+		shouldCallDelete bool
+		deleteErr        error
+
+		expectErr error
+	}{
+		{
+			name:                     "Success/TOTPCode",
+			tokenRaw:                 "string-token",
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGet:            true,
+			get:                      confirmed,
+			shouldCallGetCredentials: true,
+			shouldCallCheckThrottle:  true,
+			shouldCallUseStep:        true,
+			shouldCallDelete:         true,
+		},
+		{
+			name:                        "Success/RecoveryCode",
+			tokenRaw:                    "string-token",
+			code:                        strings.ToUpper(recoveryCode),
+			now:                         baseTime,
+			introspectToken:             validToken,
+			shouldCallCheckStepUp:       true,
+			shouldCallGet:               true,
+			get:                         confirmed,
+			shouldCallGetCredentials:    true,
+			shouldCallCheckThrottle:     true,
+			shouldCallListRecoveryCodes: true,
+			listRecoveryCodes:           recoveryCodes,
+			shouldCallUseRecoveryCode:   true,
+			shouldCallDelete:            true,
+		},
+		{
+			name:                  "Success/PendingEnrollment",
+			tokenRaw:              "string-token",
+			now:                   baseTime,
+			introspectToken:       validToken,
+			shouldCallCheckStepUp: true,
+			shouldCallGet:         true,
+			get:                   pending,
+			shouldCallDelete:      true,
+		},
+		{
+			name:                  "Error/DeleteFailure",
+			tokenRaw:              "string-token",
+			now:                   baseTime,
+			introspectToken:       validToken,
+			shouldCallCheckStepUp: true,
+			shouldCallGet:         true,
+			get:                   pending,
+			shouldCallDelete:      true,
+			deleteErr:             fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:                        "Error/RecoveryCodeUsedConcurrently",
+			tokenRaw:                    "string-token",
+			code:                        recoveryCode,
+			now:                         baseTime,
+			introspectToken:             validToken,
+			shouldCallCheckStepUp:       true,
+			shouldCallGet:               true,
+			get:                         confirmed,
+			shouldCallGetCredentials:    true,
+			shouldCallCheckThrottle:     true,
+			shouldCallListRecoveryCodes: true,
+			listRecoveryCodes:           recoveryCodes,
+			shouldCallUseRecoveryCode:   true,
+			useRecoveryCodeErr:          bunovel.ErrNotFound,
+			shouldCallRecordFailure:     true,
+			shouldCallRecordAuditEvent:  true,
+			expectErr:                   goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                        "Error/UseRecoveryCodeFailure",
+			tokenRaw:                    "string-token",
+			code:                        recoveryCode,
+			now:                         baseTime,
+			introspectToken:             validToken,
+			shouldCallCheckStepUp:       true,
+			shouldCallGet:               true,
+			get:                         confirmed,
+			shouldCallGetCredentials:    true,
+			shouldCallCheckThrottle:     true,
+			shouldCallListRecoveryCodes: true,
+			listRecoveryCodes:           recoveryCodes,
+			shouldCallUseRecoveryCode:   true,
+			useRecoveryCodeErr:          fooErr,
+			expectErr:                   fooErr,
+		},
+		{
+			name:                        "Error/WrongRecoveryCode",
+			tokenRaw:                    "string-token",
+			code:                        "aaaaa-aaaaa",
+			now:                         baseTime,
+			introspectToken:             validToken,
+			shouldCallCheckStepUp:       true,
+			shouldCallGet:               true,
+			get:                         confirmed,
+			shouldCallGetCredentials:    true,
+			shouldCallCheckThrottle:     true,
+			shouldCallListRecoveryCodes: true,
+			listRecoveryCodes:           recoveryCodes,
+			shouldCallRecordFailure:     true,
+			shouldCallRecordAuditEvent:  true,
+			expectErr:                   goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                        "Error/ListRecoveryCodesFailure",
+			tokenRaw:                    "string-token",
+			code:                        recoveryCode,
+			now:                         baseTime,
+			introspectToken:             validToken,
+			shouldCallCheckStepUp:       true,
+			shouldCallGet:               true,
+			get:                         confirmed,
+			shouldCallGetCredentials:    true,
+			shouldCallCheckThrottle:     true,
+			shouldCallListRecoveryCodes: true,
+			listRecoveryCodesErr:        fooErr,
+			expectErr:                   fooErr,
+		},
+		{
+			name:                       "Error/ReplayedTOTPCode",
+			tokenRaw:                   "string-token",
+			code:                       mustTOTPCode(baseTime),
+			now:                        baseTime,
+			introspectToken:            validToken,
+			shouldCallCheckStepUp:      true,
+			shouldCallGet:              true,
+			get:                        confirmed,
+			shouldCallGetCredentials:   true,
+			shouldCallCheckThrottle:    true,
+			shouldCallUseStep:          true,
+			useStepErr:                 bunovel.ErrNotFound,
+			shouldCallRecordFailure:    true,
+			shouldCallRecordAuditEvent: true,
+			expectErr:                  goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                     "Error/UseStepFailure",
+			tokenRaw:                 "string-token",
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGet:            true,
+			get:                      confirmed,
+			shouldCallGetCredentials: true,
+			shouldCallCheckThrottle:  true,
+			shouldCallUseStep:        true,
+			useStepErr:               fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                       "Error/WrongTOTPCode",
+			tokenRaw:                   "string-token",
+			code:                       mustTOTPCode(baseTime.Add(-2 * services.TOTPPeriod)),
+			now:                        baseTime,
+			introspectToken:            validToken,
+			shouldCallCheckStepUp:      true,
+			shouldCallGet:              true,
+			get:                        confirmed,
+			shouldCallGetCredentials:   true,
+			shouldCallCheckThrottle:    true,
+			shouldCallRecordFailure:    true,
+			shouldCallRecordAuditEvent: true,
+			expectErr:                  goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                       "Error/RecordAuditEventFailure",
+			tokenRaw:                   "string-token",
+			code:                       mustTOTPCode(baseTime.Add(-2 * services.TOTPPeriod)),
+			now:                        baseTime,
+			introspectToken:            validToken,
+			shouldCallCheckStepUp:      true,
+			shouldCallGet:              true,
+			get:                        confirmed,
+			shouldCallGetCredentials:   true,
+			shouldCallCheckThrottle:    true,
+			shouldCallRecordFailure:    true,
+			shouldCallRecordAuditEvent: true,
+			recordAuditEventErr:        fooErr,
+			expectErr:                  fooErr,
+		},
+		{
+			name:                     "Error/RecordFailureFailure",
+			tokenRaw:                 "string-token",
+			code:                     mustTOTPCode(baseTime.Add(-2 * services.TOTPPeriod)),
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGet:            true,
+			get:                      confirmed,
+			shouldCallGetCredentials: true,
+			shouldCallCheckThrottle:  true,
+			shouldCallRecordFailure:  true,
+			recordFailureErr:         fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/TooManyAttempts",
+			tokenRaw:                 "string-token",
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGet:            true,
+			get:                      confirmed,
+			shouldCallGetCredentials: true,
+			shouldCallCheckThrottle:  true,
+			getAccountFailures:       &dao.LoginFailuresSummaryModel{Count: 3, FirstAt: baseTime, LastAt: baseTime},
+			expectErr:                services.ErrTooManyAttempts,
+		},
+		{
+			name:                     "Error/GetLoginFailuresFailure",
+			tokenRaw:                 "string-token",
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGet:            true,
+			get:                      confirmed,
+			shouldCallGetCredentials: true,
+			shouldCallCheckThrottle:  true,
+			getAccountFailuresErr:    fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/GetCredentialsFailure",
+			tokenRaw:                 "string-token",
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGet:            true,
+			get:                      confirmed,
+			shouldCallGetCredentials: true,
+			getCredentialsErr:        fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/MissingCode",
+			tokenRaw:                 "string-token",
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGet:            true,
+			get:                      confirmed,
+			shouldCallGetCredentials: true,
+			shouldCallCheckThrottle:  true,
+			expectErr:                goframework.ErrInvalidEntity,
+		},
+		{
+			name:                  "Error/NotEnrolled",
+			tokenRaw:              "string-token",
+			now:                   baseTime,
+			introspectToken:       validToken,
+			shouldCallCheckStepUp: true,
+			shouldCallGet:         true,
+			getErr:                bunovel.ErrNotFound,
+			expectErr:             services.ErrTOTPNotEnrolled,
+		},
+		{
+			name:                  "Error/GetFailure",
+			tokenRaw:              "string-token",
+			now:                   baseTime,
+			introspectToken:       validToken,
+			shouldCallCheckStepUp: true,
+			shouldCallGet:         true,
+			getErr:                fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:                  "Error/CheckStepUpFailure",
+			tokenRaw:              "string-token",
+			code:                  mustTOTPCode(baseTime),
+			now:                   baseTime,
+			introspectToken:       validToken,
+			shouldCallCheckStepUp: true,
+			checkStepUpErr:        fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:            "Error/InvalidToken",
+			tokenRaw:        "string-token",
+			now:             baseTime,
+			introspectToken: &models.UserTokenStatus{OK: false},
+			expectErr:       goframework.ErrInvalidCredentials,
+		},
+		{
+			name:               "Error/IntrospectTokenFailure",
+			tokenRaw:           "string-token",
+			now:                baseTime,
+			introspectTokenErr: fooErr,
+			expectErr:          fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			totpDAO := daomocks.NewTOTPRepository(t)
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			loginFailuresDAO := daomocks.NewLoginFailuresRepository(t)
+			auditEventsDAO := daomocks.NewAuditEventsRepository(t)
+			introspectTokenService := servicesmocks.NewIntrospectTokenService(t)
+			checkStepUpService := servicesmocks.NewCheckStepUpService(t)
+
+			introspectTokenService.
+				On("IntrospectToken", context.Background(), d.tokenRaw, d.now, false).
+				Return(d.introspectToken, d.introspectTokenErr)
+
+			if d.shouldCallCheckStepUp {
+				checkStepUpService.
+					On("CheckStepUp", context.Background(), d.introspectToken.Token, services.SensitiveOperationUpdateSecondFactor, d.password, client, d.now).
+					Return(d.checkStepUpErr)
+			}
+
+			if d.shouldCallGet {
+				totpDAO.
+					On("Get", context.Background(), goframework.NumberUUID(1)).
+					Return(d.get, d.getErr)
+			}
+
+			if d.shouldCallGetCredentials {
+				credentialsDAO.
+					On("GetCredentials", context.Background(), goframework.NumberUUID(1)).
+					Return(&dao.CredentialsModel{
+						CredentialsModelCore: dao.CredentialsModelCore{Email: dao.Email{User: "user", Domain: "domain.com"}},
+					}, d.getCredentialsErr)
+			}
+
+			if d.shouldCallCheckThrottle {
+				loginFailuresDAO.
+					On("GetIPFailures", context.Background(), client.IP, d.now.Add(-loginThrottle.IPWindow)).
+					Return(&dao.LoginFailuresSummaryModel{}, nil)
+				loginFailuresDAO.
+					On("GetAccountFailures", context.Background(), "user@domain.com", d.now.Add(-loginThrottle.AccountWindow)).
+					Return(lo.Ternary(d.getAccountFailures != nil, d.getAccountFailures, &dao.LoginFailuresSummaryModel{}), d.getAccountFailuresErr)
+			}
+
+			if d.shouldCallUseStep {
+				totpDAO.
+					On("UseStep", context.Background(), goframework.NumberUUID(1), services.TOTPStep(d.now), d.now).
+					Return(d.useStepErr)
+			}
+
+			if d.shouldCallListRecoveryCodes {
+				totpDAO.
+					On("ListRecoveryCodes", context.Background(), goframework.NumberUUID(1)).
+					Return(d.listRecoveryCodes, d.listRecoveryCodesErr)
+			}
+
+			if d.shouldCallUseRecoveryCode {
+				totpDAO.
+					On("UseRecoveryCode", context.Background(), goframework.NumberUUID(11), d.now).
+					Return(d.useRecoveryCodeErr)
+			}
+
+			if d.shouldCallRecordFailure {
+				loginFailuresDAO.
+					On("Record", context.Background(), &dao.LoginFailureModelCore{
+						Account: "user@domain.com",
+						IP:      client.IP,
+					}, mock.Anything, d.now).
+					Return(nil, d.recordFailureErr)
+			}
+
+			if d.shouldCallRecordAuditEvent {
+				auditEventsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:      dao.AuditEventLoginFailed,
+						UserID:    goframework.NumberUUID(1),
+						IP:        client.IP,
+						UserAgent: client.UserAgent,
+						Details:   map[string]string{"email": "user@domain.com", "factor": "totp"},
+					}, mock.Anything, d.now).
+					Return(nil, d.recordAuditEventErr)
+			}
+
+			if d.shouldCallDelete {
+				totpDAO.
+					On("Delete", context.Background(), goframework.NumberUUID(1)).
+					Return(d.deleteErr)
+			}
+
+			service := services.NewDisableTOTPService(
+				totpDAO,
+				credentialsDAO,
+				loginFailuresDAO,
+				auditEventsDAO,
+				introspectTokenService,
+				checkStepUpService,
+				loginThrottle,
+			)
+			err := service.DisableTOTP(context.Background(), d.tokenRaw, d.code, d.password, client, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			totpDAO.AssertExpectations(t)
+			credentialsDAO.AssertExpectations(t)
+			loginFailuresDAO.AssertExpectations(t)
+			auditEventsDAO.AssertExpectations(t)
+			introspectTokenService.AssertExpectations(t)
+			checkStepUpService.AssertExpectations(t)
+		})
+	}
+}
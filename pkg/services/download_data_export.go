@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"time"
+)
+
+type DownloadDataExportService interface {
+	// DownloadDataExport returns a personal data archive that was built in the background, using the code sent to its
+	// owner. The download link can only be used once.
+	DownloadDataExport(ctx context.Context, id uuid.UUID, code string, now time.Time) ([]byte, error)
+}
+
+func NewDownloadDataExportService(dataExportsDAO dao.DataExportsRepository) DownloadDataExportService {
+	return &downloadDataExportServiceImpl{
+		dataExportsDAO: dataExportsDAO,
+	}
+}
+
+type downloadDataExportServiceImpl struct {
+	dataExportsDAO dao.DataExportsRepository
+}
+
+func (s *downloadDataExportServiceImpl) DownloadDataExport(ctx context.Context, id uuid.UUID, code string, now time.Time) ([]byte, error) {
+	if code == "" {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidDataExport)
+	}
+
+	export, err := s.dataExportsDAO.Get(ctx, id)
+	if err != nil {
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidDataExport, err)
+		}
+
+		return nil, goerrors.Join(ErrGetDataExport, err)
+	}
+
+	ok, err := goframework.VerifyCode(code, export.CodeHashed)
+	if err != nil {
+		return nil, goerrors.Join(ErrVerifyValidationCode, err)
+	}
+	if !ok {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidDataExport)
+	}
+
+	if !export.ExpiresAt.After(now) {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrValidationCodeExpired)
+	}
+
+	if _, err := s.dataExportsDAO.Use(ctx, export.ID, now); err != nil {
+		// The archive was downloaded already, or by another request in the meantime.
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidDataExport, err)
+		}
+
+		return nil, goerrors.Join(ErrUseDataExport, err)
+	}
+
+	return export.Archive, nil
+}
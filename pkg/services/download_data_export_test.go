@@ -0,0 +1,135 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDownloadDataExport(t *testing.T) {
+	export := &dao.DataExportModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		DataExportModelCore: dao.DataExportModelCore{
+			UserID:     goframework.NumberUUID(10),
+			CodeHashed: privateValidationCode,
+			Archive:    []byte("archive"),
+			ExpiresAt:  baseTime.Add(time.Hour),
+		},
+	}
+
+	data := []struct {
+		name string
+
+		code string
+		now  time.Time
+
+		shouldCallGet bool
+		get           *dao.DataExportModel
+		getErr        error
+
+		shouldCallUse bool
+		useErr        error
+
+		expect    []byte
+		expectErr error
+	}{
+		{
+			name:          "Success",
+			code:          publicValidationCode,
+			now:           baseTime,
+			shouldCallGet: true,
+			get:           export,
+			shouldCallUse: true,
+			expect:        []byte("archive"),
+		},
+		{
+			name:          "Error/AlreadyDownloaded",
+			code:          publicValidationCode,
+			now:           baseTime,
+			shouldCallGet: true,
+			get:           export,
+			shouldCallUse: true,
+			useErr:        bunovel.ErrNotFound,
+			expectErr:     services.ErrInvalidDataExport,
+		},
+		{
+			name:          "Error/UseFailure",
+			code:          publicValidationCode,
+			now:           baseTime,
+			shouldCallGet: true,
+			get:           export,
+			shouldCallUse: true,
+			useErr:        fooErr,
+			expectErr:     fooErr,
+		},
+		{
+			name:          "Error/Expired",
+			code:          publicValidationCode,
+			now:           baseTime.Add(time.Hour),
+			shouldCallGet: true,
+			get:           export,
+			expectErr:     services.ErrValidationCodeExpired,
+		},
+		{
+			name:          "Error/WrongCode",
+			code:          "wrong-code",
+			now:           baseTime,
+			shouldCallGet: true,
+			get:           export,
+			expectErr:     goframework.ErrInvalidCredentials,
+		},
+		{
+			name:          "Error/NotFound",
+			code:          publicValidationCode,
+			now:           baseTime,
+			shouldCallGet: true,
+			getErr:        bunovel.ErrNotFound,
+			expectErr:     goframework.ErrInvalidCredentials,
+		},
+		{
+			name:          "Error/GetFailure",
+			code:          publicValidationCode,
+			now:           baseTime,
+			shouldCallGet: true,
+			getErr:        fooErr,
+			expectErr:     fooErr,
+		},
+		{
+			name:      "Error/NoCode",
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			dataExportsDAO := daomocks.NewDataExportsRepository(t)
+
+			if d.shouldCallGet {
+				dataExportsDAO.
+					On("Get", context.Background(), goframework.NumberUUID(1)).
+					Return(d.get, d.getErr)
+			}
+
+			if d.shouldCallUse {
+				dataExportsDAO.
+					On("Use", context.Background(), goframework.NumberUUID(1), d.now).
+					Return(nil, d.useErr)
+			}
+
+			service := services.NewDownloadDataExportService(dataExportsDAO)
+			res, err := service.DownloadDataExport(context.Background(), goframework.NumberUUID(1), d.code, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			dataExportsDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"time"
+)
+
+type EnrollTOTPService interface {
+	// EnrollTOTP generates a new TOTP secret and recovery codes for the user. Two-factor authentication is only
+	// enabled once the secret is confirmed with ConfirmTOTPService. Enrolling again before confirmation replaces the
+	// pending secret. The password is required if the user did not authenticate recently.
+	EnrollTOTP(ctx context.Context, tokenRaw, password string, client models.ClientInfo, now time.Time) (*models.TOTPEnrollment, error)
+}
+
+func NewEnrollTOTPService(
+	totpDAO dao.TOTPRepository,
+	credentialsDAO dao.CredentialsRepository,
+	generateSecret func() (string, error),
+	generateRecoveryCode func() (string, string, error),
+	introspectTokenService IntrospectTokenService,
+	checkStepUpService CheckStepUpService,
+	issuer string,
+) EnrollTOTPService {
+	return &enrollTOTPServiceImpl{
+		totpDAO:                totpDAO,
+		credentialsDAO:         credentialsDAO,
+		generateSecret:         generateSecret,
+		generateRecoveryCode:   generateRecoveryCode,
+		IntrospectTokenService: introspectTokenService,
+		CheckStepUpService:     checkStepUpService,
+		issuer:                 issuer,
+	}
+}
+
+type enrollTOTPServiceImpl struct {
+	totpDAO              dao.TOTPRepository
+	credentialsDAO       dao.CredentialsRepository
+	generateSecret       func() (string, error)
+	generateRecoveryCode func() (string, string, error)
+	IntrospectTokenService
+	CheckStepUpService
+	issuer string
+}
+
+func (s *enrollTOTPServiceImpl) EnrollTOTP(ctx context.Context, tokenRaw, password string, client models.ClientInfo, now time.Time) (*models.TOTPEnrollment, error) {
+	token, err := s.IntrospectToken(ctx, tokenRaw, now, false)
+	if err != nil {
+		return nil, goerrors.Join(ErrIntrospectToken, err)
+	}
+	if !token.OK {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
+	}
+
+	if err := s.CheckStepUp(ctx, token.Token, SensitiveOperationUpdateSecondFactor, password, client, now); err != nil {
+		return nil, goerrors.Join(ErrCheckStepUp, err)
+	}
+
+	current, err := s.totpDAO.Get(ctx, token.Token.Payload.ID)
+	if err != nil && !goerrors.Is(err, bunovel.ErrNotFound) {
+		return nil, goerrors.Join(ErrGetTOTP, err)
+	}
+	// Enrolling again would silently disable the current secret.
+	if current != nil && current.ConfirmedAt != nil {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	credentials, err := s.credentialsDAO.GetCredentials(ctx, token.Token.Payload.ID)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetCredentials, err)
+	}
+
+	secret, err := s.generateSecret()
+	if err != nil {
+		return nil, goerrors.Join(ErrGenerateTOTPSecret, err)
+	}
+
+	recoveryCodes := make([]string, RecoveryCodesCount)
+	recoveryCodesHashed := make([]string, RecoveryCodesCount)
+	for i := range recoveryCodes {
+		recoveryCodes[i], recoveryCodesHashed[i], err = s.generateRecoveryCode()
+		if err != nil {
+			return nil, goerrors.Join(ErrGenerateRecoveryCode, err)
+		}
+	}
+
+	if _, err := s.totpDAO.Enroll(ctx, secret, recoveryCodesHashed, token.Token.Payload.ID, now); err != nil {
+		return nil, goerrors.Join(ErrEnrollTOTP, err)
+	}
+
+	return &models.TOTPEnrollment{
+		URI:           TOTPURI(s.issuer, credentials.Email.String(), secret),
+		Secret:        secret,
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
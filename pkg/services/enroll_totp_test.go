@@ -0,0 +1,259 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestEnrollTOTP(t *testing.T) {
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
+	validToken := &models.UserTokenStatus{
+		OK: true,
+		Token: &models.UserToken{
+			Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+		},
+	}
+
+	credentials := &dao.CredentialsModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		CredentialsModelCore: dao.CredentialsModelCore{
+			Email: dao.Email{User: "user", Domain: "domain.com"},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		password string
+		now      time.Time
+
+		introspectToken    *models.UserTokenStatus
+		introspectTokenErr error
+
+		shouldCallCheckStepUp bool
+		checkStepUpErr        error
+
+		shouldCallGet bool
+		get           *dao.TOTPModel
+		getErr        error
+
+		shouldCallGetCredentials bool
+		getCredentialsErr        error
+
+		generateSecretErr       error
+		generateRecoveryCodeErr error
+
+		shouldCallEnroll bool
+		enrollErr        error
+
+		expect    *models.TOTPEnrollment
+		expectErr error
+	}{
+		{
+			name:                     "Success",
+			tokenRaw:                 "string-token",
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGet:            true,
+			getErr:                   bunovel.ErrNotFound,
+			shouldCallGetCredentials: true,
+			shouldCallEnroll:         true,
+			expect: &models.TOTPEnrollment{
+				URI:           services.TOTPURI("Agora", "user@domain.com", totpSecret),
+				Secret:        totpSecret,
+				RecoveryCodes: lo.Times(services.RecoveryCodesCount, func(_ int) string { return "aaaaa-bbbbb" }),
+			},
+		},
+		{
+			name:                  "Success/ReplacePendingEnrollment",
+			tokenRaw:              "string-token",
+			now:                   baseTime,
+			introspectToken:       validToken,
+			shouldCallCheckStepUp: true,
+			shouldCallGet:         true,
+			get: &dao.TOTPModel{
+				Metadata:      bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				TOTPModelCore: dao.TOTPModelCore{Secret: "old-secret"},
+			},
+			shouldCallGetCredentials: true,
+			shouldCallEnroll:         true,
+			expect: &models.TOTPEnrollment{
+				URI:           services.TOTPURI("Agora", "user@domain.com", totpSecret),
+				Secret:        totpSecret,
+				RecoveryCodes: lo.Times(services.RecoveryCodesCount, func(_ int) string { return "aaaaa-bbbbb" }),
+			},
+		},
+		{
+			name:                     "Error/EnrollFailure",
+			tokenRaw:                 "string-token",
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGet:            true,
+			getErr:                   bunovel.ErrNotFound,
+			shouldCallGetCredentials: true,
+			shouldCallEnroll:         true,
+			enrollErr:                fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/GenerateRecoveryCodeFailure",
+			tokenRaw:                 "string-token",
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGet:            true,
+			getErr:                   bunovel.ErrNotFound,
+			shouldCallGetCredentials: true,
+			generateRecoveryCodeErr:  fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/GenerateSecretFailure",
+			tokenRaw:                 "string-token",
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGet:            true,
+			getErr:                   bunovel.ErrNotFound,
+			shouldCallGetCredentials: true,
+			generateSecretErr:        fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/GetCredentialsFailure",
+			tokenRaw:                 "string-token",
+			now:                      baseTime,
+			introspectToken:          validToken,
+			shouldCallCheckStepUp:    true,
+			shouldCallGet:            true,
+			getErr:                   bunovel.ErrNotFound,
+			shouldCallGetCredentials: true,
+			getCredentialsErr:        fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                  "Error/AlreadyEnabled",
+			tokenRaw:              "string-token",
+			now:                   baseTime,
+			introspectToken:       validToken,
+			shouldCallCheckStepUp: true,
+			shouldCallGet:         true,
+			get: &dao.TOTPModel{
+				Metadata:      bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				TOTPModelCore: dao.TOTPModelCore{Secret: "old-secret", ConfirmedAt: &baseTime},
+			},
+			expectErr: services.ErrTOTPAlreadyEnabled,
+		},
+		{
+			name:                  "Error/GetFailure",
+			tokenRaw:              "string-token",
+			now:                   baseTime,
+			introspectToken:       validToken,
+			shouldCallCheckStepUp: true,
+			shouldCallGet:         true,
+			getErr:                fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:                  "Error/CheckStepUpFailure",
+			tokenRaw:              "string-token",
+			now:                   baseTime,
+			introspectToken:       validToken,
+			shouldCallCheckStepUp: true,
+			checkStepUpErr:        fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:            "Error/InvalidToken",
+			tokenRaw:        "string-token",
+			now:             baseTime,
+			introspectToken: &models.UserTokenStatus{OK: false},
+			expectErr:       goframework.ErrInvalidCredentials,
+		},
+		{
+			name:               "Error/IntrospectTokenFailure",
+			tokenRaw:           "string-token",
+			now:                baseTime,
+			introspectTokenErr: fooErr,
+			expectErr:          fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			totpDAO := daomocks.NewTOTPRepository(t)
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			introspectTokenService := servicesmocks.NewIntrospectTokenService(t)
+			checkStepUpService := servicesmocks.NewCheckStepUpService(t)
+
+			generateSecret := func() (string, error) {
+				return totpSecret, d.generateSecretErr
+			}
+			generateRecoveryCode := func() (string, string, error) {
+				return "aaaaa-bbbbb", "hashed-code", d.generateRecoveryCodeErr
+			}
+
+			introspectTokenService.
+				On("IntrospectToken", context.Background(), d.tokenRaw, d.now, false).
+				Return(d.introspectToken, d.introspectTokenErr)
+
+			if d.shouldCallCheckStepUp {
+				checkStepUpService.
+					On("CheckStepUp", context.Background(), d.introspectToken.Token, services.SensitiveOperationUpdateSecondFactor, d.password, client, d.now).
+					Return(d.checkStepUpErr)
+			}
+
+			if d.shouldCallGet {
+				totpDAO.
+					On("Get", context.Background(), goframework.NumberUUID(1)).
+					Return(d.get, d.getErr)
+			}
+
+			if d.shouldCallGetCredentials {
+				credentialsDAO.
+					On("GetCredentials", context.Background(), goframework.NumberUUID(1)).
+					Return(credentials, d.getCredentialsErr)
+			}
+
+			if d.shouldCallEnroll {
+				totpDAO.
+					On(
+						"Enroll",
+						context.Background(),
+						totpSecret,
+						lo.Times(services.RecoveryCodesCount, func(_ int) string { return "hashed-code" }),
+						goframework.NumberUUID(1),
+						d.now,
+					).
+					Return(nil, d.enrollErr)
+			}
+
+			service := services.NewEnrollTOTPService(
+				totpDAO, credentialsDAO, generateSecret, generateRecoveryCode, introspectTokenService, checkStepUpService, "Agora",
+			)
+			res, err := service.EnrollTOTP(context.Background(), d.tokenRaw, d.password, client, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			totpDAO.AssertExpectations(t)
+			credentialsDAO.AssertExpectations(t)
+			introspectTokenService.AssertExpectations(t)
+			checkStepUpService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	sendgridproxy "github.com/a-novel/sendgrid-proxy"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+	"sort"
+	"time"
+)
+
+type ExportUserDataService interface {
+	// ExportUserData returns a zip archive with the personal data of the user who owns the token.
+	//
+	// When the user has more records than the inline threshold, the archive is not returned. Instead, the returned
+	// function builds it, stores it, and sends a download link to the user.
+	ExportUserData(ctx context.Context, tokenRaw string, now time.Time) ([]byte, func() error, error)
+}
+
+func NewExportUserDataService(
+	credentialsDAO dao.CredentialsRepository,
+	identityDAO dao.IdentityRepository,
+	profileDAO dao.ProfileRepository,
+	sessionsDAO dao.SessionsRepository,
+	loginFailuresDAO dao.LoginFailuresRepository,
+	dataExportsDAO dao.DataExportsRepository,
+	mailer sendgridproxy.Mailer,
+	generateDownloadCode func() (string, string, error),
+	introspectTokenService IntrospectTokenService,
+	inlineThreshold int,
+	ttl time.Duration,
+	downloadLink string,
+	dataExportReadyTemplate string,
+) ExportUserDataService {
+	return &exportUserDataServiceImpl{
+		credentialsDAO:          credentialsDAO,
+		identityDAO:             identityDAO,
+		profileDAO:              profileDAO,
+		sessionsDAO:             sessionsDAO,
+		loginFailuresDAO:        loginFailuresDAO,
+		dataExportsDAO:          dataExportsDAO,
+		mailer:                  mailer,
+		generateDownloadCode:    generateDownloadCode,
+		IntrospectTokenService:  introspectTokenService,
+		inlineThreshold:         inlineThreshold,
+		ttl:                     ttl,
+		downloadLink:            downloadLink,
+		dataExportReadyTemplate: dataExportReadyTemplate,
+	}
+}
+
+type exportUserDataServiceImpl struct {
+	credentialsDAO       dao.CredentialsRepository
+	identityDAO          dao.IdentityRepository
+	profileDAO           dao.ProfileRepository
+	sessionsDAO          dao.SessionsRepository
+	loginFailuresDAO     dao.LoginFailuresRepository
+	dataExportsDAO       dao.DataExportsRepository
+	mailer               sendgridproxy.Mailer
+	generateDownloadCode func() (string, string, error)
+	IntrospectTokenService
+
+	inlineThreshold int
+	ttl             time.Duration
+
+	downloadLink            string
+	dataExportReadyTemplate string
+}
+
+type dataExportFile struct {
+	name    string
+	content interface{}
+}
+
+// buildDataExportArchive writes the given files as JSON in a zip archive, after a manifest that lists them.
+func buildDataExportArchive(userID uuid.UUID, files []dataExportFile, now time.Time) ([]byte, error) {
+	manifest := dataExportFile{
+		name: models.DataExportManifestFile,
+		content: &models.DataExportManifest{
+			Version:     models.DataExportVersion,
+			UserID:      userID,
+			GeneratedAt: now,
+			Files:       lo.Map(files, func(item dataExportFile, _ int) string { return item.name }),
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	archive := zip.NewWriter(buf)
+
+	for _, file := range append([]dataExportFile{manifest}, files...) {
+		writer, err := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (s *exportUserDataServiceImpl) ExportUserData(ctx context.Context, tokenRaw string, now time.Time) ([]byte, func() error, error) {
+	token, err := s.IntrospectToken(ctx, tokenRaw, now, false)
+	if err != nil {
+		return nil, nil, goerrors.Join(ErrIntrospectToken, err)
+	}
+	if !token.OK {
+		return nil, nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
+	}
+
+	userID := token.Token.Payload.ID
+
+	credentials, err := s.credentialsDAO.GetCredentials(ctx, userID)
+	if err != nil {
+		return nil, nil, goerrors.Join(ErrGetCredentials, err)
+	}
+
+	identity, err := s.identityDAO.GetIdentity(ctx, userID)
+	if err != nil {
+		return nil, nil, goerrors.Join(ErrGetIdentity, err)
+	}
+
+	profile, err := s.profileDAO.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, nil, goerrors.Join(ErrGetProfile, err)
+	}
+
+	sessions, err := s.sessionsDAO.ListUserSessionsHistory(ctx, userID)
+	if err != nil {
+		return nil, nil, goerrors.Join(ErrListSessions, err)
+	}
+
+	failures, err := s.loginFailuresDAO.ListAccountFailures(ctx, loginAccount(credentials.Email))
+	if err != nil {
+		return nil, nil, goerrors.Join(ErrGetLoginFailures, err)
+	}
+
+	// Every session was opened by a successful login.
+	loginHistory := append(
+		lo.Map(sessions, func(item *dao.SessionModel, _ int) *models.DataExportLoginAttempt {
+			return &models.DataExportLoginAttempt{Date: item.CreatedAt, IP: item.IP, UserAgent: item.UserAgent, Success: true}
+		}),
+		lo.Map(failures, func(item *dao.LoginFailureModel, _ int) *models.DataExportLoginAttempt {
+			return &models.DataExportLoginAttempt{Date: item.CreatedAt, IP: item.IP}
+		})...,
+	)
+	sort.SliceStable(loginHistory, func(i, j int) bool {
+		return loginHistory[i].Date.After(loginHistory[j].Date)
+	})
+
+	files := []dataExportFile{
+		{
+			name: models.DataExportCredentialsFile,
+			content: &models.DataExportCredentials{
+				Email:          credentials.Email.String(),
+				EmailValidated: credentials.Email.Validation == "",
+				NewEmail:       credentials.NewEmail.String(),
+				CreatedAt:      credentials.CreatedAt,
+				UpdatedAt:      credentials.UpdatedAt,
+				LockedAt:       credentials.LockedAt,
+				DeletedAt:      credentials.DeletedAt,
+			},
+		},
+		{
+			name: models.DataExportIdentityFile,
+			content: &models.DataExportIdentity{
+				FirstName: identity.FirstName,
+				LastName:  identity.LastName,
+				Sex:       identity.Sex,
+				Birthday:  identity.Birthday,
+				CreatedAt: identity.CreatedAt,
+				UpdatedAt: identity.UpdatedAt,
+			},
+		},
+		{
+			name: models.DataExportProfileFile,
+			content: &models.DataExportProfile{
+				Username:  profile.Username,
+				Slug:      profile.Slug,
+				CreatedAt: profile.CreatedAt,
+				UpdatedAt: profile.UpdatedAt,
+			},
+		},
+		{
+			name: models.DataExportSessionsFile,
+			content: lo.Map(sessions, func(item *dao.SessionModel, _ int) *models.DataExportSession {
+				return &models.DataExportSession{
+					ID:         item.ID,
+					UserAgent:  item.UserAgent,
+					IP:         item.IP,
+					CreatedAt:  item.CreatedAt,
+					LastSeenAt: item.LastSeenAt,
+					RevokedAt:  item.RevokedAt,
+				}
+			}),
+		},
+		{name: models.DataExportLoginHistoryFile, content: loginHistory},
+		// No consent is collected yet, see models.DataExportConsent.
+		{name: models.DataExportConsentsFile, content: []*models.DataExportConsent{}},
+	}
+
+	if len(sessions)+len(failures) <= s.inlineThreshold {
+		archive, err := buildDataExportArchive(userID, files, now)
+		if err != nil {
+			return nil, nil, goerrors.Join(ErrBuildDataExport, err)
+		}
+
+		return archive, nil, nil
+	}
+
+	deferred := func() error {
+		archive, err := buildDataExportArchive(userID, files, now)
+		if err != nil {
+			return goerrors.Join(ErrBuildDataExport, err)
+		}
+
+		publicCode, privateCode, err := s.generateDownloadCode()
+		if err != nil {
+			return goerrors.Join(ErrGenerateValidationCode, err)
+		}
+
+		export, err := s.dataExportsDAO.Create(ctx, &dao.DataExportModelCore{
+			UserID:     userID,
+			CodeHashed: privateCode,
+			Archive:    archive,
+			ExpiresAt:  now.Add(s.ttl),
+		}, uuid.New(), now)
+		if err != nil {
+			return goerrors.Join(ErrCreateDataExport, err)
+		}
+
+		to := mail.NewEmail(identity.FirstName, credentials.Email.String())
+		templateData := map[string]interface{}{
+			"name":          identity.FirstName,
+			"download_link": fmt.Sprintf("%s?id=%s&code=%s", s.downloadLink, export.ID, publicCode),
+		}
+
+		if err := s.mailer.Send(ctx, to, s.dataExportReadyTemplate, templateData); err != nil {
+			return goerrors.Join(ErrSendDataExportEmail, err)
+		}
+
+		return nil
+	}
+
+	return nil, deferred, nil
+}
@@ -0,0 +1,403 @@
+package services_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	sendgridproxy "github.com/a-novel/sendgrid-proxy"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+	"time"
+)
+
+// readDataExportArchive returns the raw content of each file in a data export archive.
+func readDataExportArchive(t *testing.T, archive []byte) map[string]json.RawMessage {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	files := make(map[string]json.RawMessage)
+	for _, file := range reader.File {
+		content, err := file.Open()
+		require.NoError(t, err)
+
+		raw, err := io.ReadAll(content)
+		require.NoError(t, err)
+		require.NoError(t, content.Close())
+
+		files[file.Name] = raw
+	}
+
+	return files
+}
+
+func TestExportUserData(t *testing.T) {
+	token := &models.UserTokenStatus{
+		OK: true,
+		Token: &models.UserToken{
+			Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+		},
+	}
+
+	credentials := &dao.CredentialsModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &updateTime),
+		CredentialsModelCore: dao.CredentialsModelCore{
+			Email:    dao.Email{User: "User", Domain: "domain.com"},
+			Password: dao.Password{Hashed: "password-hashed"},
+		},
+	}
+
+	identity := &dao.IdentityModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		IdentityModelCore: dao.IdentityModelCore{
+			FirstName: "name",
+			LastName:  "last-name",
+			Sex:       models.SexMale,
+			Birthday:  baseTime.AddDate(-20, 0, 0),
+		},
+	}
+
+	profile := &dao.ProfileModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		ProfileModelCore: dao.ProfileModelCore{
+			Username: "username",
+			Slug:     "slug",
+		},
+	}
+
+	sessions := []*dao.SessionModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(10), updateTime, nil),
+			SessionModelCore: dao.SessionModelCore{
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "Mozilla/5.0",
+				IP:         "127.0.0.1",
+				LastSeenAt: updateTime,
+			},
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(11), baseTime, &updateTime),
+			SessionModelCore: dao.SessionModelCore{
+				UserID:     goframework.NumberUUID(1),
+				UserAgent:  "curl/8.0",
+				IP:         "10.0.0.1",
+				LastSeenAt: baseTime,
+				RevokedAt:  &updateTime,
+			},
+		},
+	}
+
+	failures := []*dao.LoginFailureModel{
+		{
+			ID:        goframework.NumberUUID(20),
+			CreatedAt: baseTime.Add(time.Minute),
+			LoginFailureModelCore: dao.LoginFailureModelCore{
+				Account: "user@domain.com",
+				IP:      "10.0.0.2",
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		inlineThreshold int
+
+		introspectTokenResp *models.UserTokenStatus
+		introspectTokenErr  error
+
+		shouldCallGetCredentials bool
+		getCredentialsErr        error
+
+		shouldCallGetIdentity bool
+		getIdentityErr        error
+
+		shouldCallGetProfile bool
+		getProfileErr        error
+
+		shouldCallListSessions bool
+		listSessionsErr        error
+
+		shouldCallListFailures bool
+		listFailuresErr        error
+
+		generateDownloadCodeErr error
+
+		shouldCallCreate bool
+		createErr        error
+
+		shouldCallMailer bool
+		mailerErr        error
+
+		expectArchive     bool
+		expectErr         error
+		expectDeferred    bool
+		expectDeferredErr error
+	}{
+		{
+			name:                     "Success",
+			inlineThreshold:          10,
+			introspectTokenResp:      token,
+			shouldCallGetCredentials: true,
+			shouldCallGetIdentity:    true,
+			shouldCallGetProfile:     true,
+			shouldCallListSessions:   true,
+			shouldCallListFailures:   true,
+			expectArchive:            true,
+		},
+		{
+			name:                     "Success/Deferred",
+			inlineThreshold:          2,
+			introspectTokenResp:      token,
+			shouldCallGetCredentials: true,
+			shouldCallGetIdentity:    true,
+			shouldCallGetProfile:     true,
+			shouldCallListSessions:   true,
+			shouldCallListFailures:   true,
+			shouldCallCreate:         true,
+			shouldCallMailer:         true,
+			expectDeferred:           true,
+		},
+		{
+			name:                     "Error/Deferred/MailerFailure",
+			inlineThreshold:          2,
+			introspectTokenResp:      token,
+			shouldCallGetCredentials: true,
+			shouldCallGetIdentity:    true,
+			shouldCallGetProfile:     true,
+			shouldCallListSessions:   true,
+			shouldCallListFailures:   true,
+			shouldCallCreate:         true,
+			shouldCallMailer:         true,
+			mailerErr:                fooErr,
+			expectDeferred:           true,
+			expectDeferredErr:        fooErr,
+		},
+		{
+			name:                     "Error/Deferred/CreateFailure",
+			inlineThreshold:          2,
+			introspectTokenResp:      token,
+			shouldCallGetCredentials: true,
+			shouldCallGetIdentity:    true,
+			shouldCallGetProfile:     true,
+			shouldCallListSessions:   true,
+			shouldCallListFailures:   true,
+			shouldCallCreate:         true,
+			createErr:                fooErr,
+			expectDeferred:           true,
+			expectDeferredErr:        fooErr,
+		},
+		{
+			name:                     "Error/Deferred/GenerateDownloadCodeFailure",
+			inlineThreshold:          2,
+			introspectTokenResp:      token,
+			shouldCallGetCredentials: true,
+			shouldCallGetIdentity:    true,
+			shouldCallGetProfile:     true,
+			shouldCallListSessions:   true,
+			shouldCallListFailures:   true,
+			generateDownloadCodeErr:  fooErr,
+			expectDeferred:           true,
+			expectDeferredErr:        fooErr,
+		},
+		{
+			name:                     "Error/ListFailuresFailure",
+			inlineThreshold:          10,
+			introspectTokenResp:      token,
+			shouldCallGetCredentials: true,
+			shouldCallGetIdentity:    true,
+			shouldCallGetProfile:     true,
+			shouldCallListSessions:   true,
+			shouldCallListFailures:   true,
+			listFailuresErr:          fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/ListSessionsFailure",
+			inlineThreshold:          10,
+			introspectTokenResp:      token,
+			shouldCallGetCredentials: true,
+			shouldCallGetIdentity:    true,
+			shouldCallGetProfile:     true,
+			shouldCallListSessions:   true,
+			listSessionsErr:          fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/GetProfileFailure",
+			inlineThreshold:          10,
+			introspectTokenResp:      token,
+			shouldCallGetCredentials: true,
+			shouldCallGetIdentity:    true,
+			shouldCallGetProfile:     true,
+			getProfileErr:            fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/GetIdentityFailure",
+			inlineThreshold:          10,
+			introspectTokenResp:      token,
+			shouldCallGetCredentials: true,
+			shouldCallGetIdentity:    true,
+			getIdentityErr:           fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/GetCredentialsFailure",
+			inlineThreshold:          10,
+			introspectTokenResp:      token,
+			shouldCallGetCredentials: true,
+			getCredentialsErr:        fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:               "Error/IntrospectTokenFailure",
+			inlineThreshold:    10,
+			introspectTokenErr: fooErr,
+			expectErr:          fooErr,
+		},
+		{
+			name:                "Error/InvalidToken",
+			inlineThreshold:     10,
+			introspectTokenResp: &models.UserTokenStatus{OK: false},
+			expectErr:           goframework.ErrInvalidCredentials,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			identityDAO := daomocks.NewIdentityRepository(t)
+			profileDAO := daomocks.NewProfileRepository(t)
+			sessionsDAO := daomocks.NewSessionsRepository(t)
+			loginFailuresDAO := daomocks.NewLoginFailuresRepository(t)
+			dataExportsDAO := daomocks.NewDataExportsRepository(t)
+			mailerService := sendgridproxy.NewMockMailer(t)
+			introspectTokenService := servicesmocks.NewIntrospectTokenService(t)
+
+			generateDownloadCode := func() (string, string, error) {
+				return publicValidationCode, privateValidationCode, d.generateDownloadCodeErr
+			}
+
+			introspectTokenService.
+				On("IntrospectToken", context.Background(), "string-token", baseTime, false).
+				Return(d.introspectTokenResp, d.introspectTokenErr)
+
+			if d.shouldCallGetCredentials {
+				credentialsDAO.
+					On("GetCredentials", context.Background(), goframework.NumberUUID(1)).
+					Return(credentials, d.getCredentialsErr)
+			}
+
+			if d.shouldCallGetIdentity {
+				identityDAO.
+					On("GetIdentity", context.Background(), goframework.NumberUUID(1)).
+					Return(identity, d.getIdentityErr)
+			}
+
+			if d.shouldCallGetProfile {
+				profileDAO.
+					On("GetProfile", context.Background(), goframework.NumberUUID(1)).
+					Return(profile, d.getProfileErr)
+			}
+
+			if d.shouldCallListSessions {
+				sessionsDAO.
+					On("ListUserSessionsHistory", context.Background(), goframework.NumberUUID(1)).
+					Return(sessions, d.listSessionsErr)
+			}
+
+			if d.shouldCallListFailures {
+				loginFailuresDAO.
+					On("ListAccountFailures", context.Background(), "user@domain.com").
+					Return(failures, d.listFailuresErr)
+			}
+
+			if d.shouldCallCreate {
+				dataExportsDAO.
+					On("Create", context.Background(), mock.MatchedBy(func(core *dao.DataExportModelCore) bool {
+						return core.UserID == goframework.NumberUUID(1) &&
+							core.CodeHashed == privateValidationCode &&
+							core.ExpiresAt.Equal(baseTime.Add(24*time.Hour)) &&
+							len(core.Archive) > 0
+					}), mock.Anything, baseTime).
+					Return(&dao.DataExportModel{Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil)}, d.createErr)
+			}
+
+			if d.shouldCallMailer {
+				mailerService.
+					On("Send", context.Background(), mail.NewEmail("name", "User@domain.com"), "data-export-template", map[string]interface{}{
+						"name":          "name",
+						"download_link": "download-link?id=02020202-0202-0202-0202-020202020202&code=" + publicValidationCode,
+					}).
+					Return(d.mailerErr)
+			}
+
+			service := services.NewExportUserDataService(
+				credentialsDAO, identityDAO, profileDAO, sessionsDAO, loginFailuresDAO, dataExportsDAO, mailerService,
+				generateDownloadCode, introspectTokenService, d.inlineThreshold, 24*time.Hour,
+				"download-link", "data-export-template",
+			)
+			archive, deferred, err := service.ExportUserData(context.Background(), "string-token", baseTime)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			if d.expectDeferred {
+				require.NotNil(t, deferred)
+				require.ErrorIs(t, deferred(), d.expectDeferredErr)
+			} else {
+				require.Nil(t, deferred)
+			}
+
+			if d.expectArchive {
+				files := readDataExportArchive(t, archive)
+
+				require.JSONEq(t, `{
+					"version": 1,
+					"userId": "01010101-0101-0101-0101-010101010101",
+					"generatedAt": "`+baseTime.Format(time.RFC3339Nano)+`",
+					"files": ["credentials.json", "identity.json", "profile.json", "sessions.json", "login_history.json", "consents.json"]
+				}`, string(files[models.DataExportManifestFile]))
+
+				// Secrets are never exported.
+				require.NotContains(t, string(files[models.DataExportCredentialsFile]), "password-hashed")
+
+				var loginHistory []*models.DataExportLoginAttempt
+				require.NoError(t, json.Unmarshal(files[models.DataExportLoginHistoryFile], &loginHistory))
+				require.Equal(t, []*models.DataExportLoginAttempt{
+					{Date: updateTime, IP: "127.0.0.1", UserAgent: "Mozilla/5.0", Success: true},
+					{Date: baseTime.Add(time.Minute), IP: "10.0.0.2"},
+					{Date: baseTime, IP: "10.0.0.1", UserAgent: "curl/8.0", Success: true},
+				}, loginHistory)
+
+				require.JSONEq(t, `[]`, string(files[models.DataExportConsentsFile]))
+
+				var exportedSessions []*models.DataExportSession
+				require.NoError(t, json.Unmarshal(files[models.DataExportSessionsFile], &exportedSessions))
+				require.Len(t, exportedSessions, 2)
+				require.Equal(t, &updateTime, exportedSessions[1].RevokedAt)
+			} else {
+				require.Nil(t, archive)
+			}
+
+			credentialsDAO.AssertExpectations(t)
+			identityDAO.AssertExpectations(t)
+			profileDAO.AssertExpectations(t)
+			sessionsDAO.AssertExpectations(t)
+			loginFailuresDAO.AssertExpectations(t)
+			dataExportsDAO.AssertExpectations(t)
+			mailerService.AssertExpectations(t)
+			introspectTokenService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	"time"
+)
+
+type FinishPasskeyLoginService interface {
+	// FinishPasskeyLogin verifies the response of the authenticator to the options returned by
+	// BeginPasskeyLoginService, and creates a new session for the owner of the passkey.
+	//
+	// Passkeys verify the user on the device, so they count as two factors: no MFA challenge is issued.
+	FinishPasskeyLogin(ctx context.Context, form models.PasskeyAssertionForm, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error)
+}
+
+func NewFinishPasskeyLoginService(
+	webAuthnChallengesDAO dao.WebAuthnChallengesRepository,
+	passkeysDAO dao.PasskeysRepository,
+	createSessionService CreateSessionService,
+	rp WebAuthnRelyingParty,
+) FinishPasskeyLoginService {
+	return &finishPasskeyLoginServiceImpl{
+		webAuthnChallengesDAO: webAuthnChallengesDAO,
+		passkeysDAO:           passkeysDAO,
+		CreateSessionService:  createSessionService,
+		rp:                    rp,
+	}
+}
+
+type finishPasskeyLoginServiceImpl struct {
+	webAuthnChallengesDAO dao.WebAuthnChallengesRepository
+	passkeysDAO           dao.PasskeysRepository
+	CreateSessionService
+	rp WebAuthnRelyingParty
+}
+
+func (s *finishPasskeyLoginServiceImpl) FinishPasskeyLogin(ctx context.Context, form models.PasskeyAssertionForm, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error) {
+	passkey, err := verifyPasskeyAssertion(
+		ctx, s.webAuthnChallengesDAO, s.passkeysDAO, s.rp, form, dao.WebAuthnCeremonyLogin, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := s.CreateSession(ctx, passkey.UserID, client, now)
+	if err != nil {
+		return nil, goerrors.Join(ErrCreateSession, err)
+	}
+
+	return status, nil
+}
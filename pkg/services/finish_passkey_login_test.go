@@ -0,0 +1,324 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestFinishPasskeyLogin(t *testing.T) {
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
+	validChallenge := webAuthnChallengeModel(2, dao.WebAuthnCeremonyLogin, nil)
+
+	withForm := func(update func(form *models.PasskeyAssertionForm)) models.PasskeyAssertionForm {
+		form := passkeyLogin
+		update(&form)
+		return form
+	}
+
+	data := []struct {
+		name string
+
+		form models.PasskeyAssertionForm
+		rp   services.WebAuthnRelyingParty
+		now  time.Time
+
+		shouldCallUseChallenge bool
+		useChallengeID         int
+		useChallenge           *dao.WebAuthnChallengeModel
+		useChallengeErr        error
+
+		shouldCallGetPasskey bool
+		getPasskey           *dao.PasskeyModel
+		getPasskeyErr        error
+
+		shouldCallUsePasskey bool
+		usePasskeyErr        error
+
+		shouldCallCreateSession bool
+		createSession           *models.UserTokenStatus
+		createSessionErr        error
+
+		expect    *models.UserTokenStatus
+		expectErr error
+	}{
+		{
+			name:                    "Success",
+			form:                    passkeyLogin,
+			rp:                      webAuthnRP,
+			now:                     baseTime,
+			shouldCallUseChallenge:  true,
+			useChallengeID:          2,
+			useChallenge:            validChallenge,
+			shouldCallGetPasskey:    true,
+			getPasskey:              passkeyModel(0),
+			shouldCallUsePasskey:    true,
+			shouldCallCreateSession: true,
+			createSession:           &models.UserTokenStatus{OK: true, RefreshToken: "refresh-token"},
+			expect:                  &models.UserTokenStatus{OK: true, RefreshToken: "refresh-token"},
+		},
+		{
+			name: "Success/NoUserHandle",
+			form: withForm(func(form *models.PasskeyAssertionForm) {
+				form.Response.UserHandle = ""
+			}),
+			rp:                      webAuthnRP,
+			now:                     baseTime,
+			shouldCallUseChallenge:  true,
+			useChallengeID:          2,
+			useChallenge:            validChallenge,
+			shouldCallGetPasskey:    true,
+			getPasskey:              passkeyModel(0),
+			shouldCallUsePasskey:    true,
+			shouldCallCreateSession: true,
+			createSession:           &models.UserTokenStatus{OK: true, RefreshToken: "refresh-token"},
+			expect:                  &models.UserTokenStatus{OK: true, RefreshToken: "refresh-token"},
+		},
+		{
+			name:                    "Error/CreateSessionFailure",
+			form:                    passkeyLogin,
+			rp:                      webAuthnRP,
+			now:                     baseTime,
+			shouldCallUseChallenge:  true,
+			useChallengeID:          2,
+			useChallenge:            validChallenge,
+			shouldCallGetPasskey:    true,
+			getPasskey:              passkeyModel(0),
+			shouldCallUsePasskey:    true,
+			shouldCallCreateSession: true,
+			createSessionErr:        fooErr,
+			expectErr:               fooErr,
+		},
+		{
+			name:                   "Error/UsePasskeyFailure",
+			form:                   passkeyLogin,
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			shouldCallUseChallenge: true,
+			useChallengeID:         2,
+			useChallenge:           validChallenge,
+			shouldCallGetPasskey:   true,
+			getPasskey:             passkeyModel(0),
+			shouldCallUsePasskey:   true,
+			usePasskeyErr:          fooErr,
+			expectErr:              fooErr,
+		},
+		{
+			name:                   "Error/SignCountRegression",
+			form:                   passkeyLogin,
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			shouldCallUseChallenge: true,
+			useChallengeID:         2,
+			useChallenge:           validChallenge,
+			shouldCallGetPasskey:   true,
+			getPasskey:             passkeyModel(1),
+			expectErr:              services.ErrPasskeyRejected,
+		},
+		{
+			name: "Error/TamperedSignature",
+			form: withForm(func(form *models.PasskeyAssertionForm) {
+				form.Response.Signature = passkeyMFA.Response.Signature
+			}),
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			shouldCallUseChallenge: true,
+			useChallengeID:         2,
+			useChallenge:           validChallenge,
+			shouldCallGetPasskey:   true,
+			getPasskey:             passkeyModel(0),
+			expectErr:              services.ErrPasskeyRejected,
+		},
+		{
+			name: "Error/WrongRelyingParty",
+			form: passkeyLogin,
+			rp: services.WebAuthnRelyingParty{
+				ID:           "example.com",
+				Origins:      webAuthnRP.Origins,
+				ChallengeTTL: webAuthnRP.ChallengeTTL,
+			},
+			now:                    baseTime,
+			shouldCallUseChallenge: true,
+			useChallengeID:         2,
+			useChallenge:           validChallenge,
+			shouldCallGetPasskey:   true,
+			getPasskey:             passkeyModel(0),
+			expectErr:              services.ErrPasskeyRejected,
+		},
+		{
+			name: "Error/WrongUserHandle",
+			form: withForm(func(form *models.PasskeyAssertionForm) {
+				uid := goframework.NumberUUID(20)
+				form.Response.UserHandle = encodeWebAuthn(uid[:])
+			}),
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			shouldCallUseChallenge: true,
+			useChallengeID:         2,
+			useChallenge:           validChallenge,
+			shouldCallGetPasskey:   true,
+			getPasskey:             passkeyModel(0),
+			expectErr:              services.ErrPasskeyRejected,
+		},
+		{
+			name:                   "Error/UnknownPasskey",
+			form:                   passkeyLogin,
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			shouldCallUseChallenge: true,
+			useChallengeID:         2,
+			useChallenge:           validChallenge,
+			shouldCallGetPasskey:   true,
+			getPasskeyErr:          bunovel.ErrNotFound,
+			expectErr:              goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                   "Error/GetPasskeyFailure",
+			form:                   passkeyLogin,
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			shouldCallUseChallenge: true,
+			useChallengeID:         2,
+			useChallenge:           validChallenge,
+			shouldCallGetPasskey:   true,
+			getPasskeyErr:          fooErr,
+			expectErr:              fooErr,
+		},
+		{
+			name: "Error/WrongOrigin",
+			form: passkeyLogin,
+			rp: services.WebAuthnRelyingParty{
+				ID:           webAuthnRP.ID,
+				Origins:      []string{"https://example.com"},
+				ChallengeTTL: webAuthnRP.ChallengeTTL,
+			},
+			now:                    baseTime,
+			shouldCallUseChallenge: true,
+			useChallengeID:         2,
+			useChallenge:           validChallenge,
+			expectErr:              services.ErrPasskeyRejected,
+		},
+		{
+			name:                   "Error/WrongCeremony",
+			form:                   passkeyLogin,
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			shouldCallUseChallenge: true,
+			useChallengeID:         2,
+			useChallenge:           webAuthnChallengeModel(2, dao.WebAuthnCeremonyMFA, lo.ToPtr(goframework.NumberUUID(10))),
+			expectErr:              services.ErrInvalidWebAuthnChallenge,
+		},
+		{
+			name: "Error/RegistrationClientData",
+			form: withForm(func(form *models.PasskeyAssertionForm) {
+				form.Response.ClientDataJSON = passkeyRegistration.Response.ClientDataJSON
+			}),
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			shouldCallUseChallenge: true,
+			useChallengeID:         1,
+			useChallenge:           webAuthnChallengeModel(1, dao.WebAuthnCeremonyLogin, nil),
+			expectErr:              services.ErrPasskeyRejected,
+		},
+		{
+			name:                   "Error/ChallengeExpired",
+			form:                   passkeyLogin,
+			rp:                     webAuthnRP,
+			now:                    baseTime.Add(10 * time.Minute),
+			shouldCallUseChallenge: true,
+			useChallengeID:         2,
+			useChallenge:           validChallenge,
+			expectErr:              services.ErrInvalidWebAuthnChallenge,
+		},
+		{
+			name:                   "Error/ChallengeUsed",
+			form:                   passkeyLogin,
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			shouldCallUseChallenge: true,
+			useChallengeID:         2,
+			useChallengeErr:        bunovel.ErrNotFound,
+			expectErr:              goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                   "Error/UseChallengeFailure",
+			form:                   passkeyLogin,
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			shouldCallUseChallenge: true,
+			useChallengeID:         2,
+			useChallengeErr:        fooErr,
+			expectErr:              fooErr,
+		},
+		{
+			name: "Error/InvalidSignatureEncoding",
+			form: withForm(func(form *models.PasskeyAssertionForm) {
+				form.Response.Signature = "!!!"
+			}),
+			rp:        webAuthnRP,
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name: "Error/InvalidType",
+			form: withForm(func(form *models.PasskeyAssertionForm) {
+				form.Type = "password"
+			}),
+			rp:        webAuthnRP,
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			webAuthnChallengesDAO := daomocks.NewWebAuthnChallengesRepository(t)
+			passkeysDAO := daomocks.NewPasskeysRepository(t)
+			createSessionService := servicesmocks.NewCreateSessionService(t)
+
+			if d.shouldCallUseChallenge {
+				webAuthnChallengesDAO.
+					On("Use", context.Background(), goframework.NumberUUID(d.useChallengeID), d.now).
+					Return(d.useChallenge, d.useChallengeErr)
+			}
+
+			if d.shouldCallGetPasskey {
+				passkeysDAO.
+					On("GetByCredentialID", context.Background(), mustDecodeWebAuthn(passkeyCredentialID)).
+					Return(d.getPasskey, d.getPasskeyErr)
+			}
+
+			if d.shouldCallUsePasskey {
+				passkeysDAO.
+					On("Use", context.Background(), goframework.NumberUUID(100), uint32(1), d.now).
+					Return(passkeyModel(1), d.usePasskeyErr)
+			}
+
+			if d.shouldCallCreateSession {
+				createSessionService.
+					On("CreateSession", context.Background(), goframework.NumberUUID(10), client, d.now).
+					Return(d.createSession, d.createSessionErr)
+			}
+
+			service := services.NewFinishPasskeyLoginService(webAuthnChallengesDAO, passkeysDAO, createSessionService, d.rp)
+			res, err := service.FinishPasskeyLogin(context.Background(), d.form, client, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			webAuthnChallengesDAO.AssertExpectations(t)
+			passkeysDAO.AssertExpectations(t)
+			createSessionService.AssertExpectations(t)
+		})
+	}
+}
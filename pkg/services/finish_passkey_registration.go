@@ -0,0 +1,117 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"time"
+)
+
+// PasskeyTransports are the transports hints accepted from the client. Other values are ignored.
+var PasskeyTransports = []string{"ble", "hybrid", "internal", "nfc", "smart-card", "usb"}
+
+type FinishPasskeyRegistrationService interface {
+	// FinishPasskeyRegistration verifies the response of the authenticator to the options returned by
+	// BeginPasskeyRegistrationService, and stores the new passkey.
+	FinishPasskeyRegistration(ctx context.Context, tokenRaw string, form models.PasskeyAttestationForm, now time.Time) error
+}
+
+func NewFinishPasskeyRegistrationService(
+	webAuthnChallengesDAO dao.WebAuthnChallengesRepository,
+	passkeysDAO dao.PasskeysRepository,
+	introspectTokenService IntrospectTokenService,
+	rp WebAuthnRelyingParty,
+) FinishPasskeyRegistrationService {
+	return &finishPasskeyRegistrationServiceImpl{
+		webAuthnChallengesDAO:  webAuthnChallengesDAO,
+		passkeysDAO:            passkeysDAO,
+		IntrospectTokenService: introspectTokenService,
+		rp:                     rp,
+	}
+}
+
+type finishPasskeyRegistrationServiceImpl struct {
+	webAuthnChallengesDAO dao.WebAuthnChallengesRepository
+	passkeysDAO           dao.PasskeysRepository
+	IntrospectTokenService
+	rp WebAuthnRelyingParty
+}
+
+func (s *finishPasskeyRegistrationServiceImpl) FinishPasskeyRegistration(ctx context.Context, tokenRaw string, form models.PasskeyAttestationForm, now time.Time) error {
+	token, err := s.IntrospectToken(ctx, tokenRaw, now, false)
+	if err != nil {
+		return goerrors.Join(ErrIntrospectToken, err)
+	}
+	if !token.OK {
+		return goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
+	}
+
+	if form.Type != webAuthnPublicKey {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey)
+	}
+
+	credentialID, err := webAuthnEncoding.DecodeString(form.RawID)
+	if err != nil {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey, err)
+	}
+
+	clientDataJSON, err := webAuthnEncoding.DecodeString(form.Response.ClientDataJSON)
+	if err != nil {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey, err)
+	}
+
+	rawAttestation, err := webAuthnEncoding.DecodeString(form.Response.AttestationObject)
+	if err != nil {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey, err)
+	}
+
+	challenge, err := useWebAuthnChallenge(
+		ctx, s.webAuthnChallengesDAO, s.rp, clientDataJSON, webAuthnTypeCreate, dao.WebAuthnCeremonyRegistration, now,
+	)
+	if err != nil {
+		return err
+	}
+
+	// The challenge was issued for another user.
+	if challenge.UserID == nil || *challenge.UserID != token.Token.Payload.ID {
+		return goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidWebAuthnChallenge)
+	}
+
+	authData, err := parseAttestationObject(s.rp, rawAttestation)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(authData.CredentialID, credentialID) {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey)
+	}
+
+	if _, _, err := parseCOSEKey(authData.PublicKey); err != nil {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey, err)
+	}
+
+	_, err = s.passkeysDAO.Create(ctx, &dao.PasskeyModelCore{
+		UserID:       token.Token.Payload.ID,
+		CredentialID: authData.CredentialID,
+		PublicKey:    authData.PublicKey,
+		SignCount:    authData.SignCount,
+		Transports: lo.Uniq(lo.Filter(form.Response.Transports, func(item string, _ int) bool {
+			return lo.Contains(PasskeyTransports, item)
+		})),
+	}, uuid.New(), now)
+	if err != nil {
+		if goerrors.Is(err, bunovel.ErrUniqConstraintViolation) {
+			return goerrors.Join(ErrPasskeyRegistered, err)
+		}
+
+		return goerrors.Join(ErrCreatePasskey, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,311 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestFinishPasskeyRegistration(t *testing.T) {
+	validToken := &models.UserTokenStatus{
+		OK: true,
+		Token: &models.UserToken{
+			Payload: models.UserTokenPayload{ID: goframework.NumberUUID(10)},
+		},
+	}
+
+	validChallenge := webAuthnChallengeModel(1, dao.WebAuthnCeremonyRegistration, lo.ToPtr(goframework.NumberUUID(10)))
+
+	withForm := func(update func(form *models.PasskeyAttestationForm)) models.PasskeyAttestationForm {
+		form := passkeyRegistration
+		update(&form)
+		return form
+	}
+
+	withChallenge := func(update func(model *dao.WebAuthnChallengeModel)) *dao.WebAuthnChallengeModel {
+		model := *validChallenge
+		update(&model)
+		return &model
+	}
+
+	data := []struct {
+		name string
+
+		form models.PasskeyAttestationForm
+		rp   services.WebAuthnRelyingParty
+		now  time.Time
+
+		introspectToken    *models.UserTokenStatus
+		introspectTokenErr error
+
+		shouldCallUseChallenge bool
+		useChallenge           *dao.WebAuthnChallengeModel
+		useChallengeErr        error
+
+		shouldCallCreate bool
+		createErr        error
+
+		expectErr error
+	}{
+		{
+			name:                   "Success",
+			form:                   passkeyRegistration,
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			introspectToken:        validToken,
+			shouldCallUseChallenge: true,
+			useChallenge:           validChallenge,
+			shouldCallCreate:       true,
+		},
+		{
+			name: "Success/AnyOrigin",
+			form: passkeyRegistration,
+			rp: services.WebAuthnRelyingParty{
+				ID:           webAuthnRP.ID,
+				Origins:      []string{"*"},
+				ChallengeTTL: webAuthnRP.ChallengeTTL,
+			},
+			now:                    baseTime,
+			introspectToken:        validToken,
+			shouldCallUseChallenge: true,
+			useChallenge:           validChallenge,
+			shouldCallCreate:       true,
+		},
+		{
+			name:                   "Error/AlreadyRegistered",
+			form:                   passkeyRegistration,
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			introspectToken:        validToken,
+			shouldCallUseChallenge: true,
+			useChallenge:           validChallenge,
+			shouldCallCreate:       true,
+			createErr:              bunovel.ErrUniqConstraintViolation,
+			expectErr:              services.ErrPasskeyRegistered,
+		},
+		{
+			name:                   "Error/CreateFailure",
+			form:                   passkeyRegistration,
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			introspectToken:        validToken,
+			shouldCallUseChallenge: true,
+			useChallenge:           validChallenge,
+			shouldCallCreate:       true,
+			createErr:              fooErr,
+			expectErr:              fooErr,
+		},
+		{
+			name: "Error/CredentialIDMismatch",
+			form: withForm(func(form *models.PasskeyAttestationForm) {
+				form.RawID = "AAAA"
+			}),
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			introspectToken:        validToken,
+			shouldCallUseChallenge: true,
+			useChallenge:           validChallenge,
+			expectErr:              goframework.ErrInvalidEntity,
+		},
+		{
+			name: "Error/WrongRelyingParty",
+			form: passkeyRegistration,
+			rp: services.WebAuthnRelyingParty{
+				ID:           "example.com",
+				Origins:      webAuthnRP.Origins,
+				ChallengeTTL: webAuthnRP.ChallengeTTL,
+			},
+			now:                    baseTime,
+			introspectToken:        validToken,
+			shouldCallUseChallenge: true,
+			useChallenge:           validChallenge,
+			expectErr:              services.ErrPasskeyRejected,
+		},
+		{
+			name: "Error/ChallengeOfAnotherUser",
+			form: passkeyRegistration,
+			rp:   webAuthnRP,
+			now:  baseTime,
+			introspectToken: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(20)},
+				},
+			},
+			shouldCallUseChallenge: true,
+			useChallenge:           validChallenge,
+			expectErr:              services.ErrInvalidWebAuthnChallenge,
+		},
+		{
+			name: "Error/WrongOrigin",
+			form: passkeyRegistration,
+			rp: services.WebAuthnRelyingParty{
+				ID:           webAuthnRP.ID,
+				Origins:      []string{"https://example.com"},
+				ChallengeTTL: webAuthnRP.ChallengeTTL,
+			},
+			now:                    baseTime,
+			introspectToken:        validToken,
+			shouldCallUseChallenge: true,
+			useChallenge:           validChallenge,
+			expectErr:              services.ErrPasskeyRejected,
+		},
+		{
+			name: "Error/AssertionClientData",
+			form: withForm(func(form *models.PasskeyAttestationForm) {
+				form.Response.ClientDataJSON = passkeyLogin.Response.ClientDataJSON
+			}),
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			introspectToken:        validToken,
+			shouldCallUseChallenge: true,
+			useChallenge:           webAuthnChallengeModel(2, dao.WebAuthnCeremonyRegistration, lo.ToPtr(goframework.NumberUUID(10))),
+			expectErr:              services.ErrPasskeyRejected,
+		},
+		{
+			name:                   "Error/WrongCeremony",
+			form:                   passkeyRegistration,
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			introspectToken:        validToken,
+			shouldCallUseChallenge: true,
+			useChallenge: withChallenge(func(model *dao.WebAuthnChallengeModel) {
+				model.Ceremony = dao.WebAuthnCeremonyLogin
+			}),
+			expectErr: services.ErrInvalidWebAuthnChallenge,
+		},
+		{
+			name:                   "Error/ChallengeMismatch",
+			form:                   passkeyRegistration,
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			introspectToken:        validToken,
+			shouldCallUseChallenge: true,
+			useChallenge: withChallenge(func(model *dao.WebAuthnChallengeModel) {
+				model.Challenge = webAuthnChallenge(2)
+			}),
+			expectErr: services.ErrInvalidWebAuthnChallenge,
+		},
+		{
+			name:                   "Error/ChallengeExpired",
+			form:                   passkeyRegistration,
+			rp:                     webAuthnRP,
+			now:                    baseTime.Add(10 * time.Minute),
+			introspectToken:        validToken,
+			shouldCallUseChallenge: true,
+			useChallenge:           validChallenge,
+			expectErr:              services.ErrInvalidWebAuthnChallenge,
+		},
+		{
+			name:                   "Error/ChallengeUsed",
+			form:                   passkeyRegistration,
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			introspectToken:        validToken,
+			shouldCallUseChallenge: true,
+			useChallengeErr:        bunovel.ErrNotFound,
+			expectErr:              goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                   "Error/UseChallengeFailure",
+			form:                   passkeyRegistration,
+			rp:                     webAuthnRP,
+			now:                    baseTime,
+			introspectToken:        validToken,
+			shouldCallUseChallenge: true,
+			useChallengeErr:        fooErr,
+			expectErr:              fooErr,
+		},
+		{
+			name: "Error/InvalidAttestationEncoding",
+			form: withForm(func(form *models.PasskeyAttestationForm) {
+				form.Response.AttestationObject = "!!!"
+			}),
+			rp:              webAuthnRP,
+			now:             baseTime,
+			introspectToken: validToken,
+			expectErr:       goframework.ErrInvalidEntity,
+		},
+		{
+			name: "Error/InvalidType",
+			form: withForm(func(form *models.PasskeyAttestationForm) {
+				form.Type = "password"
+			}),
+			rp:              webAuthnRP,
+			now:             baseTime,
+			introspectToken: validToken,
+			expectErr:       goframework.ErrInvalidEntity,
+		},
+		{
+			name:            "Error/InvalidToken",
+			form:            passkeyRegistration,
+			rp:              webAuthnRP,
+			now:             baseTime,
+			introspectToken: &models.UserTokenStatus{OK: false},
+			expectErr:       goframework.ErrInvalidCredentials,
+		},
+		{
+			name:               "Error/IntrospectTokenFailure",
+			form:               passkeyRegistration,
+			rp:                 webAuthnRP,
+			now:                baseTime,
+			introspectTokenErr: fooErr,
+			expectErr:          fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			webAuthnChallengesDAO := daomocks.NewWebAuthnChallengesRepository(t)
+			passkeysDAO := daomocks.NewPasskeysRepository(t)
+			introspectTokenService := servicesmocks.NewIntrospectTokenService(t)
+
+			introspectTokenService.
+				On("IntrospectToken", context.Background(), "string-token", d.now, false).
+				Return(d.introspectToken, d.introspectTokenErr)
+
+			if d.shouldCallUseChallenge {
+				challengeID := goframework.NumberUUID(1)
+				if d.useChallenge != nil {
+					challengeID = d.useChallenge.ID
+				}
+
+				webAuthnChallengesDAO.
+					On("Use", context.Background(), challengeID, d.now).
+					Return(d.useChallenge, d.useChallengeErr)
+			}
+
+			if d.shouldCallCreate {
+				passkeysDAO.
+					On("Create", context.Background(), &dao.PasskeyModelCore{
+						UserID:       goframework.NumberUUID(10),
+						CredentialID: mustDecodeWebAuthn(passkeyCredentialID),
+						PublicKey:    mustDecodeWebAuthn(passkeyPublicKey),
+						Transports:   []string{"internal", "hybrid"},
+					}, mock.Anything, d.now).
+					Return(nil, d.createErr)
+			}
+
+			service := services.NewFinishPasskeyRegistrationService(
+				webAuthnChallengesDAO, passkeysDAO, introspectTokenService, d.rp,
+			)
+			err := service.FinishPasskeyRegistration(context.Background(), "string-token", d.form, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			webAuthnChallengesDAO.AssertExpectations(t)
+			passkeysDAO.AssertExpectations(t)
+			introspectTokenService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+)
+
+const (
+	JWKKeyTypeOKP     = "OKP"
+	JWKCurveEd25519   = "Ed25519"
+	JWKUseSignature   = "sig"
+	JWKAlgorithmEdDSA = "EdDSA"
+)
+
+type GetJWKSService interface {
+	// GetJWKS returns the public part of every signature key currently trusted by the service.
+	GetJWKS(ctx context.Context) (*models.JSONWebKeySet, error)
+}
+
+func NewGetJWKSService(secretKeysDAO dao.SecretKeysRepository) GetJWKSService {
+	return &getJWKSServiceImpl{
+		secretKeysDAO: secretKeysDAO,
+	}
+}
+
+type getJWKSServiceImpl struct {
+	secretKeysDAO dao.SecretKeysRepository
+}
+
+func (s *getJWKSServiceImpl) GetJWKS(ctx context.Context) (*models.JSONWebKeySet, error) {
+	keys, err := s.secretKeysDAO.List(ctx)
+	if err != nil {
+		return nil, goerrors.Join(ErrListSignatureKeys, err)
+	}
+
+	output := &models.JSONWebKeySet{Keys: make([]models.JSONWebKey, len(keys))}
+	for i, key := range keys {
+		output.Keys[i] = models.JSONWebKey{
+			KTY: JWKKeyTypeOKP,
+			CRV: JWKCurveEd25519,
+			// We know for sure the public type is correct, because we read it from the private key.
+			X:   base64.RawURLEncoding.EncodeToString(key.Key.Public().(ed25519.PublicKey)),
+			KID: key.KeyID(),
+			Use: JWKUseSignature,
+			Alg: JWKAlgorithmEdDSA,
+		}
+	}
+
+	return output, nil
+}
@@ -0,0 +1,147 @@
+package services_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetJWKS(t *testing.T) {
+	data := []struct {
+		name string
+
+		list    []*dao.SecretKeyModel
+		listErr error
+
+		expect    *models.JSONWebKeySet
+		expectErr error
+	}{
+		{
+			name: "Success",
+			list: []*dao.SecretKeyModel{
+				{
+					Name: "key-0",
+					Key:  MockedSecretKeys[0],
+				},
+				{
+					Name: "key-1",
+					Key:  MockedSecretKeys[1],
+				},
+			},
+			expect: &models.JSONWebKeySet{
+				Keys: []models.JSONWebKey{
+					{
+						KTY: services.JWKKeyTypeOKP,
+						CRV: services.JWKCurveEd25519,
+						X:   base64.RawURLEncoding.EncodeToString(MockedSecretKeys[0].Public().(ed25519.PublicKey)),
+						KID: (&dao.SecretKeyModel{Name: "key-0"}).KeyID(),
+						Use: services.JWKUseSignature,
+						Alg: services.JWKAlgorithmEdDSA,
+					},
+					{
+						KTY: services.JWKKeyTypeOKP,
+						CRV: services.JWKCurveEd25519,
+						X:   base64.RawURLEncoding.EncodeToString(MockedSecretKeys[1].Public().(ed25519.PublicKey)),
+						KID: (&dao.SecretKeyModel{Name: "key-1"}).KeyID(),
+						Use: services.JWKUseSignature,
+						Alg: services.JWKAlgorithmEdDSA,
+					},
+				},
+			},
+		},
+		{
+			name:   "Success/NoKeys",
+			expect: &models.JSONWebKeySet{Keys: []models.JSONWebKey{}},
+		},
+		{
+			name:      "Error/DAOFailure",
+			listErr:   fooErr,
+			expectErr: fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			secretKeysDAO := daomocks.NewSecretKeysRepository(t)
+
+			secretKeysDAO.On("List", context.Background()).Return(d.list, d.listErr)
+
+			service := services.NewGetJWKSService(secretKeysDAO)
+			keys, err := service.GetJWKS(context.Background())
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, keys)
+
+			secretKeysDAO.AssertExpectations(t)
+		})
+	}
+}
+
+// Make sure a token issued by the service can be verified by a third party, using only the published key set.
+func TestGetJWKS_VerifyGeneratedToken(t *testing.T) {
+	list := []*dao.SecretKeyModel{
+		{
+			Name: "key-0",
+			Key:  MockedSecretKeys[0],
+		},
+		{
+			Name: "key-1",
+			Key:  MockedSecretKeys[1],
+		},
+		{
+			Name: "key-2",
+			Key:  MockedSecretKeys[2],
+		},
+	}
+
+	secretKeysDAO := daomocks.NewSecretKeysRepository(t)
+	secretKeysDAO.On("List", context.Background()).Return(list, nil)
+
+	token, err := services.NewGenerateTokenService(secretKeysDAO, daomocks.NewSessionsRepository(t), time.Hour, "issuer", "audience").
+		GenerateToken(context.Background(), models.UserTokenPayload{ID: goframework.NumberUUID(1)}, goframework.NumberUUID(10), baseTime)
+	require.NoError(t, err)
+
+	keys, err := services.NewGetJWKSService(secretKeysDAO).GetJWKS(context.Background())
+	require.NoError(t, err)
+
+	parts := strings.Split(token.TokenRaw, ".")
+	require.Len(t, parts, 3)
+
+	rawHeader, err := base64.RawURLEncoding.DecodeString(parts[0])
+	require.NoError(t, err)
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	require.NoError(t, json.Unmarshal(rawHeader, &header))
+	require.Equal(t, services.JWKAlgorithmEdDSA, header.Alg)
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+
+	key, ok := lo.Find(keys.Keys, func(item models.JSONWebKey) bool {
+		return item.KID == header.Kid
+	})
+	require.True(t, ok)
+	require.Equal(t, services.JWKKeyTypeOKP, key.KTY)
+	require.Equal(t, services.JWKCurveEd25519, key.CRV)
+
+	publicKey, err := base64.RawURLEncoding.DecodeString(key.X)
+	require.NoError(t, err)
+	require.Len(t, publicKey, ed25519.PublicKeySize)
+	require.True(t, ed25519.Verify(publicKey, []byte(parts[0]+"."+parts[1]), signature))
+
+	secretKeysDAO.AssertExpectations(t)
+}
@@ -3,33 +3,46 @@ package services
 import (
 	"context"
 	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
 	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/bunovel"
+	"github.com/google/uuid"
 	"time"
 )
 
 type IntrospectTokenService interface {
-	// IntrospectToken parses, and verifies the provided token. If the autoRefresh flag is set to true, a new token
-	// will automatically be issued when close enough to the expiration date.
+	// IntrospectToken parses, and verifies the provided token. Tokens issued for a revoked session are rejected.
+	// If the autoRefresh flag is set to true, a new token will automatically be issued when close enough to the
+	// expiration date, as long as the refresh token family it was issued from is still active.
 	IntrospectToken(ctx context.Context, token string, now time.Time, autoRefresh bool) (*models.UserTokenStatus, error)
 }
 
 func NewIntrospectTokenService(
 	generateTokenService GenerateTokenService,
 	getTokenStatusService GetTokenStatusService,
+	refreshTokensDAO dao.RefreshTokensRepository,
+	sessionsDAO dao.SessionsRepository,
 	tokenRefreshThreshold time.Duration,
+	lastSeenThrottle time.Duration,
 ) IntrospectTokenService {
 	return &introspectTokenServiceImpl{
 		GenerateTokenService:  generateTokenService,
 		GetTokenStatusService: getTokenStatusService,
+		refreshTokensDAO:      refreshTokensDAO,
+		sessionsDAO:           sessionsDAO,
 		tokenRefreshThreshold: tokenRefreshThreshold,
+		lastSeenThrottle:      lastSeenThrottle,
 	}
 }
 
 type introspectTokenServiceImpl struct {
 	GenerateTokenService
 	GetTokenStatusService
+	refreshTokensDAO dao.RefreshTokensRepository
+	sessionsDAO      dao.SessionsRepository
 
 	tokenRefreshThreshold time.Duration
+	lastSeenThrottle      time.Duration
 }
 
 func (s *introspectTokenServiceImpl) IntrospectToken(ctx context.Context, token string, now time.Time, autoRefresh bool) (*models.UserTokenStatus, error) {
@@ -42,11 +55,42 @@ func (s *introspectTokenServiceImpl) IntrospectToken(ctx context.Context, token
 		return status, nil
 	}
 
+	// Tokens issued before sessions were introduced have no session attached, and remain valid.
+	if status.Token.Payload.FamilyID != uuid.Nil {
+		session, err := s.sessionsDAO.GetSessionByFamily(ctx, status.Token.Payload.FamilyID)
+		if err != nil && !goerrors.Is(err, bunovel.ErrNotFound) {
+			return nil, goerrors.Join(ErrGetSession, err)
+		}
+		if session != nil && session.RevokedAt != nil {
+			status.OK = false
+			status.Revoked = true
+			return status, nil
+		}
+	}
+
 	if autoRefresh && status.Token.Header.EXP.Sub(now) <= s.tokenRefreshThreshold {
+		// Tokens without a family cannot be renewed. Otherwise, a stolen token could be renewed forever.
+		if status.Token.Payload.FamilyID == uuid.Nil {
+			return status, nil
+		}
+
+		active, err := s.refreshTokensDAO.FamilyActive(ctx, status.Token.Payload.FamilyID, now)
+		if err != nil {
+			return nil, goerrors.Join(ErrCheckTokenFamily, err)
+		}
+		if !active {
+			return status, nil
+		}
+
 		status, err = s.GenerateToken(ctx, status.Token.Payload, status.Token.Header.ID, now)
 		if err != nil {
 			return nil, goerrors.Join(ErrGenerateToken, err)
 		}
+
+		err = s.sessionsDAO.UpdateLastSeen(ctx, status.Token.Payload.FamilyID, now.Add(-s.lastSeenThrottle), now)
+		if err != nil {
+			return nil, goerrors.Join(ErrUpdateSessionLastSeen, err)
+		}
 	}
 
 	return status, err
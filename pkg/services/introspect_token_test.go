@@ -2,9 +2,12 @@ package services_test
 
 import (
 	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
 	"github.com/a-novel/auth-service/pkg/models"
 	"github.com/a-novel/auth-service/pkg/services"
 	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -16,6 +19,7 @@ func TestIntrospectToken(t *testing.T) {
 		name string
 
 		tokenRefreshThreshold time.Duration
+		lastSeenThrottle      time.Duration
 
 		token       string
 		now         time.Time
@@ -24,10 +28,21 @@ func TestIntrospectToken(t *testing.T) {
 		tokenStatus    *models.UserTokenStatus
 		tokenStatusErr error
 
+		shouldCallGetSession bool
+		getSession           *dao.SessionModel
+		getSessionErr        error
+
+		shouldCallFamilyActive bool
+		familyActive           bool
+		familyActiveErr        error
+
 		shouldCallGenerateToken bool
 		generateTokenStatus     *models.UserTokenStatus
 		generateTokenErr        error
 
+		shouldCallUpdateLastSeen bool
+		updateLastSeenErr        error
+
 		expect    *models.UserTokenStatus
 		expectErr error
 	}{
@@ -103,9 +118,16 @@ func TestIntrospectToken(t *testing.T) {
 						EXP: baseTime.Add(30 * time.Minute),
 						ID:  goframework.NumberUUID(10),
 					},
-					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
 				},
 			},
+			shouldCallGetSession: true,
+			getSession: &dao.SessionModel{
+				Metadata:         bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+				SessionModelCore: dao.SessionModelCore{FamilyID: goframework.NumberUUID(100)},
+			},
+			shouldCallFamilyActive:  true,
+			familyActive:            true,
 			shouldCallGenerateToken: true,
 			generateTokenStatus: &models.UserTokenStatus{
 				OK: true,
@@ -115,9 +137,10 @@ func TestIntrospectToken(t *testing.T) {
 						EXP: baseTime.Add(75 * time.Minute),
 						ID:  goframework.NumberUUID(10),
 					},
-					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
 				},
 			},
+			shouldCallUpdateLastSeen: true,
 			expect: &models.UserTokenStatus{
 				OK: true,
 				Token: &models.UserToken{
@@ -126,9 +149,248 @@ func TestIntrospectToken(t *testing.T) {
 						EXP: baseTime.Add(75 * time.Minute),
 						ID:  goframework.NumberUUID(10),
 					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+		},
+		{
+			name:                  "Success/Refresh/NoFamily",
+			tokenRefreshThreshold: 15 * time.Minute,
+			token:                 "string-token",
+			now:                   baseTime.Add(15 * time.Minute),
+			autoRefresh:           true,
+			tokenStatus: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime.Add(-time.Hour),
+						EXP: baseTime.Add(30 * time.Minute),
+						ID:  goframework.NumberUUID(10),
+					},
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
 			},
+			expect: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime.Add(-time.Hour),
+						EXP: baseTime.Add(30 * time.Minute),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+		},
+		{
+			name:                  "Success/Refresh/InactiveFamily",
+			tokenRefreshThreshold: 15 * time.Minute,
+			token:                 "string-token",
+			now:                   baseTime.Add(15 * time.Minute),
+			autoRefresh:           true,
+			tokenStatus: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime.Add(-time.Hour),
+						EXP: baseTime.Add(30 * time.Minute),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallGetSession: true,
+			getSession: &dao.SessionModel{
+				Metadata:         bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+				SessionModelCore: dao.SessionModelCore{FamilyID: goframework.NumberUUID(100)},
+			},
+			shouldCallFamilyActive: true,
+			familyActive:           false,
+			expect: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime.Add(-time.Hour),
+						EXP: baseTime.Add(30 * time.Minute),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+		},
+		{
+			name:                  "Success/Refresh/NoSession",
+			tokenRefreshThreshold: 15 * time.Minute,
+			lastSeenThrottle:      5 * time.Minute,
+			token:                 "string-token",
+			now:                   baseTime.Add(15 * time.Minute),
+			autoRefresh:           true,
+			tokenStatus: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime.Add(-time.Hour),
+						EXP: baseTime.Add(30 * time.Minute),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallGetSession:    true,
+			getSessionErr:           bunovel.ErrNotFound,
+			shouldCallFamilyActive:  true,
+			familyActive:            true,
+			shouldCallGenerateToken: true,
+			generateTokenStatus: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime.Add(15 * time.Hour),
+						EXP: baseTime.Add(75 * time.Minute),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallUpdateLastSeen: true,
+			expect: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime.Add(15 * time.Hour),
+						EXP: baseTime.Add(75 * time.Minute),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+		},
+		{
+			name:                  "Success/RevokedSession",
+			tokenRefreshThreshold: 15 * time.Minute,
+			lastSeenThrottle:      5 * time.Minute,
+			token:                 "string-token",
+			now:                   baseTime.Add(15 * time.Minute),
+			autoRefresh:           true,
+			tokenStatus: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime.Add(-time.Hour),
+						EXP: baseTime.Add(30 * time.Minute),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallGetSession: true,
+			getSession: &dao.SessionModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+				SessionModelCore: dao.SessionModelCore{
+					FamilyID:  goframework.NumberUUID(100),
+					RevokedAt: &baseTime,
+				},
+			},
+			expect: &models.UserTokenStatus{
+				Revoked: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime.Add(-time.Hour),
+						EXP: baseTime.Add(30 * time.Minute),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+		},
+		{
+			name:                  "Error/GetSessionFailure",
+			tokenRefreshThreshold: 15 * time.Minute,
+			lastSeenThrottle:      5 * time.Minute,
+			token:                 "string-token",
+			now:                   baseTime.Add(15 * time.Minute),
+			autoRefresh:           true,
+			tokenStatus: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime.Add(-time.Hour),
+						EXP: baseTime.Add(30 * time.Minute),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallGetSession: true,
+			getSessionErr:        fooErr,
+			expectErr:            fooErr,
+		},
+		{
+			name:                  "Error/UpdateLastSeenFailure",
+			tokenRefreshThreshold: 15 * time.Minute,
+			lastSeenThrottle:      5 * time.Minute,
+			token:                 "string-token",
+			now:                   baseTime.Add(15 * time.Minute),
+			autoRefresh:           true,
+			tokenStatus: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime.Add(-time.Hour),
+						EXP: baseTime.Add(30 * time.Minute),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallGetSession: true,
+			getSession: &dao.SessionModel{
+				Metadata:         bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+				SessionModelCore: dao.SessionModelCore{FamilyID: goframework.NumberUUID(100)},
+			},
+			shouldCallFamilyActive:  true,
+			familyActive:            true,
+			shouldCallGenerateToken: true,
+			generateTokenStatus: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime.Add(15 * time.Hour),
+						EXP: baseTime.Add(75 * time.Minute),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallUpdateLastSeen: true,
+			updateLastSeenErr:        fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                  "Error/FamilyActiveFailure",
+			tokenRefreshThreshold: 15 * time.Minute,
+			token:                 "string-token",
+			now:                   baseTime.Add(15 * time.Minute),
+			autoRefresh:           true,
+			tokenStatus: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime.Add(-time.Hour),
+						EXP: baseTime.Add(30 * time.Minute),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallGetSession: true,
+			getSession: &dao.SessionModel{
+				Metadata:         bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+				SessionModelCore: dao.SessionModelCore{FamilyID: goframework.NumberUUID(100)},
+			},
+			shouldCallFamilyActive: true,
+			familyActiveErr:        fooErr,
+			expectErr:              fooErr,
 		},
 		{
 			name:                  "Error/RefreshFailure",
@@ -144,9 +406,16 @@ func TestIntrospectToken(t *testing.T) {
 						EXP: baseTime.Add(30 * time.Minute),
 						ID:  goframework.NumberUUID(10),
 					},
-					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
 				},
 			},
+			shouldCallGetSession: true,
+			getSession: &dao.SessionModel{
+				Metadata:         bunovel.NewMetadata(goframework.NumberUUID(1000), baseTime, nil),
+				SessionModelCore: dao.SessionModelCore{FamilyID: goframework.NumberUUID(100)},
+			},
+			shouldCallFamilyActive:  true,
+			familyActive:            true,
 			shouldCallGenerateToken: true,
 			generateTokenErr:        fooErr,
 			expectErr:               fooErr,
@@ -175,18 +444,40 @@ func TestIntrospectToken(t *testing.T) {
 		t.Run(d.name, func(t *testing.T) {
 			getTokenStatusService := servicesmocks.NewGetTokenStatusService(t)
 			generateTokenService := servicesmocks.NewGenerateTokenService(t)
+			refreshTokensDAO := daomocks.NewRefreshTokensRepository(t)
+			sessionsDAO := daomocks.NewSessionsRepository(t)
 
 			getTokenStatusService.
 				On("GetTokenStatus", context.Background(), d.token, d.now).
 				Return(d.tokenStatus, d.tokenStatusErr)
 
+			if d.shouldCallGetSession {
+				sessionsDAO.
+					On("GetSessionByFamily", context.Background(), d.tokenStatus.Token.Payload.FamilyID).
+					Return(d.getSession, d.getSessionErr)
+			}
+
+			if d.shouldCallFamilyActive {
+				refreshTokensDAO.
+					On("FamilyActive", context.Background(), d.tokenStatus.Token.Payload.FamilyID, d.now).
+					Return(d.familyActive, d.familyActiveErr)
+			}
+
 			if d.shouldCallGenerateToken {
 				generateTokenService.
 					On("GenerateToken", context.Background(), d.tokenStatus.Token.Payload, d.tokenStatus.Token.Header.ID, d.now).
 					Return(d.generateTokenStatus, d.generateTokenErr)
 			}
 
-			service := services.NewIntrospectTokenService(generateTokenService, getTokenStatusService, d.tokenRefreshThreshold)
+			if d.shouldCallUpdateLastSeen {
+				sessionsDAO.
+					On("UpdateLastSeen", context.Background(), d.tokenStatus.Token.Payload.FamilyID, d.now.Add(-d.lastSeenThrottle), d.now).
+					Return(d.updateLastSeenErr)
+			}
+
+			service := services.NewIntrospectTokenService(
+				generateTokenService, getTokenStatusService, refreshTokensDAO, sessionsDAO, d.tokenRefreshThreshold, d.lastSeenThrottle,
+			)
 			status, err := service.IntrospectToken(context.Background(), d.token, d.now, d.autoRefresh)
 
 			require.ErrorIs(t, err, d.expectErr)
@@ -194,6 +485,8 @@ func TestIntrospectToken(t *testing.T) {
 
 			getTokenStatusService.AssertExpectations(t)
 			generateTokenService.AssertExpectations(t)
+			refreshTokensDAO.AssertExpectations(t)
+			sessionsDAO.AssertExpectations(t)
 		})
 	}
 }
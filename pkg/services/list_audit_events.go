@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type ListAuditEventsService interface {
+	// ListAuditEvents returns the audit events that match the query, most recent first, along with their total
+	// count. Empty filters are ignored.
+	ListAuditEvents(ctx context.Context, query models.ListAuditEventsQuery) ([]*models.AuditEvent, int, error)
+}
+
+func NewListAuditEventsService(auditEventsDAO dao.AuditEventsRepository) ListAuditEventsService {
+	return &listAuditEventsServiceImpl{
+		auditEventsDAO: auditEventsDAO,
+	}
+}
+
+type listAuditEventsServiceImpl struct {
+	auditEventsDAO dao.AuditEventsRepository
+}
+
+func (s *listAuditEventsServiceImpl) ListAuditEvents(
+	ctx context.Context, query models.ListAuditEventsQuery,
+) ([]*models.AuditEvent, int, error) {
+	if err := goframework.CheckMinMax(query.Limit, 1, MaxUserSearchLimit); err != nil {
+		return nil, 0, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSearchLimit, err)
+	}
+	if !query.Since.IsZero() && !query.Until.IsZero() && !query.Until.After(query.Since) {
+		return nil, 0, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidAuditPeriod)
+	}
+
+	filter := dao.AuditEventsFilter{
+		UserID: query.UserID.Value(),
+		Kinds: lo.Map(query.Kinds, func(item string, _ int) dao.AuditEventKind {
+			return dao.AuditEventKind(item)
+		}),
+		Actor: query.Actor,
+	}
+	if !query.Since.IsZero() {
+		filter.Since = &query.Since
+	}
+	if !query.Until.IsZero() {
+		filter.Until = &query.Until
+	}
+
+	events, total, err := s.auditEventsDAO.List(ctx, filter, query.Limit, query.Offset)
+	if err != nil {
+		return nil, 0, goerrors.Join(ErrListAuditEvents, err)
+	}
+
+	return lo.Map(events, func(item *dao.AuditEventModel, _ int) *models.AuditEvent {
+		output := &models.AuditEvent{
+			ID:        item.ID,
+			Kind:      string(item.Kind),
+			Actor:     item.Actor,
+			IP:        item.IP,
+			UserAgent: item.UserAgent,
+			Details:   item.Details,
+			CreatedAt: item.CreatedAt,
+		}
+		if item.UserID != uuid.Nil {
+			output.UserID = lo.ToPtr(item.UserID)
+		}
+
+		return output
+	}), total, nil
+}
@@ -0,0 +1,144 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestListAuditEvents(t *testing.T) {
+	data := []struct {
+		name string
+
+		query models.ListAuditEventsQuery
+
+		shouldCallList bool
+		expectFilter   dao.AuditEventsFilter
+		listData       []*dao.AuditEventModel
+		listTotal      int
+		listErr        error
+
+		expect      []*models.AuditEvent
+		expectTotal int
+		expectErr   error
+	}{
+		{
+			name: "Success",
+			query: models.ListAuditEventsQuery{
+				UserID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Kinds:  []string{"login.failed", "admin.lookup_user"},
+				Actor:  "support",
+				Since:  baseTime,
+				Until:  updateTime,
+				Limit:  10,
+				Offset: 5,
+			},
+			shouldCallList: true,
+			expectFilter: dao.AuditEventsFilter{
+				UserID: goframework.NumberUUID(1),
+				Kinds:  []dao.AuditEventKind{dao.AuditEventLoginFailed, dao.AuditEventAdminLookupUser},
+				Actor:  "support",
+				Since:  &baseTime,
+				Until:  &updateTime,
+			},
+			listData: []*dao.AuditEventModel{
+				{
+					ID:        goframework.NumberUUID(10),
+					CreatedAt: baseTime,
+					AuditEventModelCore: dao.AuditEventModelCore{
+						Kind:   dao.AuditEventAdminLookupUser,
+						Actor:  "support",
+						UserID: goframework.NumberUUID(1),
+					},
+				},
+				{
+					ID:        goframework.NumberUUID(20),
+					CreatedAt: baseTime,
+					AuditEventModelCore: dao.AuditEventModelCore{
+						Kind:      dao.AuditEventLoginFailed,
+						IP:        "127.0.0.1",
+						UserAgent: "Mozilla/5.0",
+						Details:   map[string]string{"email": "user@domain.com"},
+					},
+				},
+			},
+			listTotal: 12,
+			expect: []*models.AuditEvent{
+				{
+					ID:        goframework.NumberUUID(10),
+					Kind:      "admin.lookup_user",
+					Actor:     "support",
+					UserID:    lo.ToPtr(goframework.NumberUUID(1)),
+					CreatedAt: baseTime,
+				},
+				{
+					ID:        goframework.NumberUUID(20),
+					Kind:      "login.failed",
+					IP:        "127.0.0.1",
+					UserAgent: "Mozilla/5.0",
+					Details:   map[string]string{"email": "user@domain.com"},
+					CreatedAt: baseTime,
+				},
+			},
+			expectTotal: 12,
+		},
+		{
+			name:           "Success/NoFilter",
+			query:          models.ListAuditEventsQuery{Limit: 10},
+			shouldCallList: true,
+			expectFilter:   dao.AuditEventsFilter{Kinds: []dao.AuditEventKind{}},
+			listData:       []*dao.AuditEventModel{},
+			expect:         []*models.AuditEvent{},
+		},
+		{
+			name:           "Error/DAOFailure",
+			query:          models.ListAuditEventsQuery{Limit: 10},
+			shouldCallList: true,
+			expectFilter:   dao.AuditEventsFilter{Kinds: []dao.AuditEventKind{}},
+			listErr:        fooErr,
+			expectErr:      fooErr,
+		},
+		{
+			name:      "Error/InvalidPeriod",
+			query:     models.ListAuditEventsQuery{Since: updateTime, Until: baseTime, Limit: 10},
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name:      "Error/LimitTooHigh",
+			query:     models.ListAuditEventsQuery{Limit: services.MaxUserSearchLimit + 1},
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name:      "Error/NoLimit",
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			auditEventsDAO := daomocks.NewAuditEventsRepository(t)
+
+			if d.shouldCallList {
+				auditEventsDAO.
+					On("List", context.Background(), d.expectFilter, d.query.Limit, d.query.Offset).
+					Return(d.listData, d.listTotal, d.listErr)
+			}
+
+			service := services.NewListAuditEventsService(auditEventsDAO)
+			res, total, err := service.ListAuditEvents(context.Background(), d.query)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+			require.Equal(t, d.expectTotal, total)
+
+			auditEventsDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"time"
+)
+
+type ListSecurityActivityService interface {
+	// ListSecurityActivity returns the audit events that target the user who owns the token, most recent first,
+	// along with their total count. The identity of the support members involved is not revealed.
+	ListSecurityActivity(ctx context.Context, tokenRaw string, limit, offset int, now time.Time) ([]*models.SecurityActivity, int, error)
+}
+
+func NewListSecurityActivityService(
+	auditEventsDAO dao.AuditEventsRepository,
+	introspectTokenService IntrospectTokenService,
+) ListSecurityActivityService {
+	return &listSecurityActivityServiceImpl{
+		auditEventsDAO:         auditEventsDAO,
+		IntrospectTokenService: introspectTokenService,
+	}
+}
+
+type listSecurityActivityServiceImpl struct {
+	auditEventsDAO dao.AuditEventsRepository
+	IntrospectTokenService
+}
+
+func (s *listSecurityActivityServiceImpl) ListSecurityActivity(
+	ctx context.Context, tokenRaw string, limit, offset int, now time.Time,
+) ([]*models.SecurityActivity, int, error) {
+	token, err := s.IntrospectToken(ctx, tokenRaw, now, false)
+	if err != nil {
+		return nil, 0, goerrors.Join(ErrIntrospectToken, err)
+	}
+	if !token.OK {
+		return nil, 0, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
+	}
+
+	if err := goframework.CheckMinMax(limit, 1, MaxUserSearchLimit); err != nil {
+		return nil, 0, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSearchLimit, err)
+	}
+
+	events, total, err := s.auditEventsDAO.List(ctx, dao.AuditEventsFilter{UserID: token.Token.Payload.ID}, limit, offset)
+	if err != nil {
+		return nil, 0, goerrors.Join(ErrListAuditEvents, err)
+	}
+
+	return lo.Map(events, func(item *dao.AuditEventModel, _ int) *models.SecurityActivity {
+		return &models.SecurityActivity{
+			Kind:      string(item.Kind),
+			IP:        item.IP,
+			UserAgent: item.UserAgent,
+			CreatedAt: item.CreatedAt,
+			Admin:     item.Actor != "",
+		}
+	}), total, nil
+}
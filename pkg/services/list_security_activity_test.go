@@ -0,0 +1,169 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestListSecurityActivity(t *testing.T) {
+	validToken := &models.UserTokenStatus{
+		OK: true,
+		Token: &models.UserToken{
+			Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		limit    int
+		offset   int
+		now      time.Time
+
+		introspectTokenResp *models.UserTokenStatus
+		introspectTokenErr  error
+
+		shouldCallList bool
+		listData       []*dao.AuditEventModel
+		listTotal      int
+		listErr        error
+
+		expect      []*models.SecurityActivity
+		expectTotal int
+		expectErr   error
+	}{
+		{
+			name:                "Success",
+			tokenRaw:            "string-token",
+			limit:               10,
+			offset:              5,
+			now:                 updateTime,
+			introspectTokenResp: validToken,
+			shouldCallList:      true,
+			listData: []*dao.AuditEventModel{
+				{
+					ID:        goframework.NumberUUID(10),
+					CreatedAt: baseTime,
+					AuditEventModelCore: dao.AuditEventModelCore{
+						Kind:   dao.AuditEventAdminResetPassword,
+						Actor:  "support",
+						UserID: goframework.NumberUUID(1),
+					},
+				},
+				{
+					ID:        goframework.NumberUUID(20),
+					CreatedAt: baseTime,
+					AuditEventModelCore: dao.AuditEventModelCore{
+						Kind:      dao.AuditEventLoginFailed,
+						UserID:    goframework.NumberUUID(1),
+						IP:        "127.0.0.1",
+						UserAgent: "Mozilla/5.0",
+						Details:   map[string]string{"email": "user@domain.com"},
+					},
+				},
+			},
+			listTotal: 12,
+			expect: []*models.SecurityActivity{
+				{
+					Kind:      "admin.reset_password",
+					CreatedAt: baseTime,
+					Admin:     true,
+				},
+				{
+					Kind:      "login.failed",
+					IP:        "127.0.0.1",
+					UserAgent: "Mozilla/5.0",
+					CreatedAt: baseTime,
+				},
+			},
+			expectTotal: 12,
+		},
+		{
+			name:                "Success/NoActivity",
+			tokenRaw:            "string-token",
+			limit:               10,
+			now:                 updateTime,
+			introspectTokenResp: validToken,
+			shouldCallList:      true,
+			listData:            []*dao.AuditEventModel{},
+			expect:              []*models.SecurityActivity{},
+		},
+		{
+			name:                "Error/DAOFailure",
+			tokenRaw:            "string-token",
+			limit:               10,
+			now:                 updateTime,
+			introspectTokenResp: validToken,
+			shouldCallList:      true,
+			listErr:             fooErr,
+			expectErr:           fooErr,
+		},
+		{
+			name:                "Error/LimitTooHigh",
+			tokenRaw:            "string-token",
+			limit:               services.MaxUserSearchLimit + 1,
+			now:                 updateTime,
+			introspectTokenResp: validToken,
+			expectErr:           goframework.ErrInvalidEntity,
+		},
+		{
+			name:                "Error/NoLimit",
+			tokenRaw:            "string-token",
+			now:                 updateTime,
+			introspectTokenResp: validToken,
+			expectErr:           goframework.ErrInvalidEntity,
+		},
+		{
+			name:               "Error/IntrospectTokenFailure",
+			tokenRaw:           "string-token",
+			limit:              10,
+			now:                updateTime,
+			introspectTokenErr: fooErr,
+			expectErr:          fooErr,
+		},
+		{
+			name:                "Error/InvalidToken",
+			tokenRaw:            "string-token",
+			limit:               10,
+			now:                 updateTime,
+			introspectTokenResp: &models.UserTokenStatus{OK: false},
+			expectErr:           goframework.ErrInvalidCredentials,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			auditEventsDAO := daomocks.NewAuditEventsRepository(t)
+			introspectTokenService := servicesmocks.NewIntrospectTokenService(t)
+
+			introspectTokenService.
+				On("IntrospectToken", context.Background(), d.tokenRaw, d.now, false).
+				Return(d.introspectTokenResp, d.introspectTokenErr)
+
+			if d.shouldCallList {
+				auditEventsDAO.
+					On("List", context.Background(), dao.AuditEventsFilter{UserID: goframework.NumberUUID(1)}, d.limit, d.offset).
+					Return(d.listData, d.listTotal, d.listErr)
+			}
+
+			service := services.NewListSecurityActivityService(auditEventsDAO, introspectTokenService)
+			res, total, err := service.ListSecurityActivity(context.Background(), d.tokenRaw, d.limit, d.offset, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+			require.Equal(t, d.expectTotal, total)
+
+			auditEventsDAO.AssertExpectations(t)
+			introspectTokenService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"time"
+)
+
+type ListSessionsService interface {
+	// ListSessions returns the active sessions of the user who owns the token.
+	ListSessions(ctx context.Context, tokenRaw string, now time.Time) ([]*models.Session, error)
+}
+
+func NewListSessionsService(sessionsDAO dao.SessionsRepository, introspectTokenService IntrospectTokenService) ListSessionsService {
+	return &listSessionsServiceImpl{
+		sessionsDAO:            sessionsDAO,
+		IntrospectTokenService: introspectTokenService,
+	}
+}
+
+type listSessionsServiceImpl struct {
+	sessionsDAO dao.SessionsRepository
+	IntrospectTokenService
+}
+
+func (s *listSessionsServiceImpl) ListSessions(ctx context.Context, tokenRaw string, now time.Time) ([]*models.Session, error) {
+	token, err := s.IntrospectToken(ctx, tokenRaw, now, false)
+	if err != nil {
+		return nil, goerrors.Join(ErrIntrospectToken, err)
+	}
+	if !token.OK {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
+	}
+
+	sessions, err := s.sessionsDAO.ListUserSessions(ctx, token.Token.Payload.ID)
+	if err != nil {
+		return nil, goerrors.Join(ErrListSessions, err)
+	}
+
+	return lo.Map(sessions, func(item *dao.SessionModel, _ int) *models.Session {
+		return &models.Session{
+			ID:         item.ID,
+			UserAgent:  item.UserAgent,
+			IP:         item.IP,
+			CreatedAt:  item.CreatedAt,
+			LastSeenAt: item.LastSeenAt,
+			Current:    item.FamilyID == token.Token.Payload.FamilyID,
+		}
+	}), nil
+}
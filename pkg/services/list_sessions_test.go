@@ -0,0 +1,154 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestListSessions(t *testing.T) {
+	data := []struct {
+		name string
+
+		tokenRaw string
+		now      time.Time
+
+		introspectTokenResp *models.UserTokenStatus
+		introspectTokenErr  error
+
+		shouldCallListUserSessions bool
+		listUserSessions           []*dao.SessionModel
+		listUserSessionsErr        error
+
+		expect    []*models.Session
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			tokenRaw: "string-token",
+			now:      baseTime,
+			introspectTokenResp: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallListUserSessions: true,
+			listUserSessions: []*dao.SessionModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, &updateTime),
+					SessionModelCore: dao.SessionModelCore{
+						FamilyID:   goframework.NumberUUID(100),
+						UserID:     goframework.NumberUUID(1),
+						UserAgent:  "Mozilla/5.0",
+						IP:         "127.0.0.1",
+						LastSeenAt: updateTime,
+					},
+				},
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(20), baseTime, nil),
+					SessionModelCore: dao.SessionModelCore{
+						FamilyID:   goframework.NumberUUID(200),
+						UserID:     goframework.NumberUUID(1),
+						UserAgent:  "curl/8.0",
+						IP:         "10.0.0.1",
+						LastSeenAt: baseTime,
+					},
+				},
+			},
+			expect: []*models.Session{
+				{
+					ID:         goframework.NumberUUID(10),
+					UserAgent:  "Mozilla/5.0",
+					IP:         "127.0.0.1",
+					CreatedAt:  baseTime,
+					LastSeenAt: updateTime,
+					Current:    true,
+				},
+				{
+					ID:         goframework.NumberUUID(20),
+					UserAgent:  "curl/8.0",
+					IP:         "10.0.0.1",
+					CreatedAt:  baseTime,
+					LastSeenAt: baseTime,
+				},
+			},
+		},
+		{
+			name:     "Success/NoSessions",
+			tokenRaw: "string-token",
+			now:      baseTime,
+			introspectTokenResp: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+			shouldCallListUserSessions: true,
+			listUserSessions:           []*dao.SessionModel{},
+			expect:                     []*models.Session{},
+		},
+		{
+			name:     "Error/ListUserSessionsFailure",
+			tokenRaw: "string-token",
+			now:      baseTime,
+			introspectTokenResp: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallListUserSessions: true,
+			listUserSessionsErr:        fooErr,
+			expectErr:                  fooErr,
+		},
+		{
+			name:               "Error/IntrospectTokenFailure",
+			tokenRaw:           "string-token",
+			now:                baseTime,
+			introspectTokenErr: fooErr,
+			expectErr:          fooErr,
+		},
+		{
+			name:                "Error/InvalidToken",
+			tokenRaw:            "string-token",
+			now:                 baseTime,
+			introspectTokenResp: &models.UserTokenStatus{OK: false},
+			expectErr:           goframework.ErrInvalidCredentials,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			sessionsDAO := daomocks.NewSessionsRepository(t)
+			introspectTokenService := servicesmocks.NewIntrospectTokenService(t)
+
+			introspectTokenService.
+				On("IntrospectToken", context.Background(), d.tokenRaw, d.now, false).
+				Return(d.introspectTokenResp, d.introspectTokenErr)
+
+			if d.shouldCallListUserSessions {
+				sessionsDAO.
+					On("ListUserSessions", context.Background(), d.introspectTokenResp.Token.Payload.ID).
+					Return(d.listUserSessions, d.listUserSessionsErr)
+			}
+
+			service := services.NewListSessionsService(sessionsDAO, introspectTokenService)
+			res, err := service.ListSessions(context.Background(), d.tokenRaw, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			sessionsDAO.AssertExpectations(t)
+			introspectTokenService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"time"
+)
+
+type ListSuspendedUsersService interface {
+	// ListSuspendedUsers returns the users whose suspension is active, most recent first, along with their total count.
+	ListSuspendedUsers(ctx context.Context, limit, offset int, now time.Time) ([]*models.Suspension, int, error)
+}
+
+func NewListSuspendedUsersService(credentialsDAO dao.CredentialsRepository) ListSuspendedUsersService {
+	return &listSuspendedUsersServiceImpl{
+		credentialsDAO: credentialsDAO,
+	}
+}
+
+type listSuspendedUsersServiceImpl struct {
+	credentialsDAO dao.CredentialsRepository
+}
+
+func (s *listSuspendedUsersServiceImpl) ListSuspendedUsers(
+	ctx context.Context, limit, offset int, now time.Time,
+) ([]*models.Suspension, int, error) {
+	if err := goframework.CheckMinMax(limit, 1, MaxUserSearchLimit); err != nil {
+		return nil, 0, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSearchLimit, err)
+	}
+
+	credentials, total, err := s.credentialsDAO.ListSuspended(ctx, now, limit, offset)
+	if err != nil {
+		return nil, 0, goerrors.Join(ErrListSuspendedUsers, err)
+	}
+
+	return lo.Map(credentials, func(item *dao.CredentialsModel, _ int) *models.Suspension {
+		return suspensionFromCredentials(item)
+	}), total, nil
+}
+
+func suspensionFromCredentials(credentials *dao.CredentialsModel) *models.Suspension {
+	return &models.Suspension{
+		UserID:         credentials.ID,
+		Email:          credentials.Email.String(),
+		Reason:         credentials.SuspensionReason,
+		SuspendedBy:    credentials.SuspendedBy,
+		SuspendedAt:    lo.FromPtr(credentials.SuspendedAt),
+		SuspendedUntil: credentials.SuspendedUntil,
+	}
+}
@@ -0,0 +1,130 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestListSuspendedUsers(t *testing.T) {
+	data := []struct {
+		name string
+
+		limit  int
+		offset int
+		now    time.Time
+
+		shouldCallListSuspended bool
+		listSuspendedData       []*dao.CredentialsModel
+		listSuspendedTotal      int
+		listSuspendedErr        error
+
+		expect      []*models.Suspension
+		expectTotal int
+		expectErr   error
+	}{
+		{
+			name:                    "Success",
+			limit:                   10,
+			offset:                  5,
+			now:                     updateTime,
+			shouldCallListSuspended: true,
+			listSuspendedData: []*dao.CredentialsModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
+					CredentialsModelCore: dao.CredentialsModelCore{
+						Email:            dao.Email{User: "user", Domain: "domain.com"},
+						SuspendedAt:      &baseTime,
+						SuspendedUntil:   lo.ToPtr(baseTime.Add(24 * time.Hour)),
+						SuspensionReason: "spam",
+						SuspendedBy:      "moderation",
+					},
+				},
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, &baseTime),
+					CredentialsModelCore: dao.CredentialsModelCore{
+						Email:            dao.Email{User: "other", Domain: "domain.com"},
+						SuspendedAt:      &baseTime,
+						SuspensionReason: "abuse",
+						SuspendedBy:      "support",
+					},
+				},
+			},
+			listSuspendedTotal: 12,
+			expect: []*models.Suspension{
+				{
+					UserID:         goframework.NumberUUID(1),
+					Email:          "user@domain.com",
+					Reason:         "spam",
+					SuspendedBy:    "moderation",
+					SuspendedAt:    baseTime,
+					SuspendedUntil: lo.ToPtr(baseTime.Add(24 * time.Hour)),
+				},
+				{
+					UserID:      goframework.NumberUUID(2),
+					Email:       "other@domain.com",
+					Reason:      "abuse",
+					SuspendedBy: "support",
+					SuspendedAt: baseTime,
+				},
+			},
+			expectTotal: 12,
+		},
+		{
+			name:                    "Success/NoResults",
+			limit:                   10,
+			now:                     updateTime,
+			shouldCallListSuspended: true,
+			listSuspendedData:       []*dao.CredentialsModel{},
+			expect:                  []*models.Suspension{},
+		},
+		{
+			name:                    "Error/DAOFailure",
+			limit:                   10,
+			now:                     updateTime,
+			shouldCallListSuspended: true,
+			listSuspendedErr:        fooErr,
+			expectErr:               fooErr,
+		},
+		{
+			name:      "Error/LimitTooHigh",
+			limit:     services.MaxUserSearchLimit + 1,
+			now:       updateTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name:      "Error/NoLimit",
+			now:       updateTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+
+			if d.shouldCallListSuspended {
+				credentialsDAO.
+					On("ListSuspended", context.Background(), d.now, d.limit, d.offset).
+					Return(d.listSuspendedData, d.listSuspendedTotal, d.listSuspendedErr)
+			}
+
+			service := services.NewListSuspendedUsersService(credentialsDAO)
+			res, total, err := service.ListSuspendedUsers(context.Background(), d.limit, d.offset, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+			require.Equal(t, d.expectTotal, total)
+
+			credentialsDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -3,32 +3,224 @@ package services
 import (
 	"context"
 	goerrors "errors"
+	"fmt"
 	"github.com/a-novel/auth-service/pkg/dao"
 	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/bunovel"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"sync"
 	"time"
 )
 
+// TooManyAttemptsError is returned when a login is throttled. It matches ErrTooManyAttempts.
+type TooManyAttemptsError struct {
+	// RetryAfter is the time to wait before the next attempt.
+	RetryAfter time.Duration
+}
+
+func (err *TooManyAttemptsError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrTooManyAttempts.Error(), err.RetryAfter)
+}
+
+func (err *TooManyAttemptsError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+// LoginThrottle protects the login against brute force attacks.
+//
+// Each account can fail FreeFailures times, after which it is locked for BaseLockout. The lock doubles with every
+// new failure, up to MaxLockout. Failures older than AccountWindow are forgotten.
+//
+// Each client IP can fail IPMaxFailures times within IPWindow, on any account.
+type LoginThrottle struct {
+	FreeFailures  int
+	BaseLockout   time.Duration
+	MaxLockout    time.Duration
+	AccountWindow time.Duration
+	IPMaxFailures int
+	IPWindow      time.Duration
+}
+
+// Retention is the age after which failures no longer affect the throttle.
+func (throttle LoginThrottle) Retention() time.Duration {
+	return max(throttle.AccountWindow, throttle.IPWindow)
+}
+
+// accountRetryAfter returns how long the account remains locked, given its recent failures.
+func (throttle LoginThrottle) accountRetryAfter(failures *dao.LoginFailuresSummaryModel, now time.Time) time.Duration {
+	if failures.Count < throttle.FreeFailures || failures.Count == 0 {
+		return 0
+	}
+
+	lockout := throttle.MaxLockout
+	// Past 30 doublings, the lockout is greater than any sensible maximum, and may overflow.
+	if exponent := failures.Count - throttle.FreeFailures; exponent < 30 {
+		lockout = min(throttle.BaseLockout<<exponent, throttle.MaxLockout)
+	}
+
+	return failures.LastAt.Add(lockout).Sub(now)
+}
+
+// ipRetryAfter returns how long the client IP remains throttled, given its recent failures.
+func (throttle LoginThrottle) ipRetryAfter(failures *dao.LoginFailuresSummaryModel, now time.Time) time.Duration {
+	if failures.Count < throttle.IPMaxFailures {
+		return 0
+	}
+
+	// The window is freed, at the latest, when the oldest failure leaves it.
+	return failures.FirstAt.Add(throttle.IPWindow).Sub(now)
+}
+
+// loginAccount normalizes an email, to track the failures on it.
+func loginAccount(email dao.Email) string {
+	return strings.ToLower(email.String())
+}
+
 type LoginService interface {
 	// Login creates a new session for the given user, given the right credentials.
-	Login(ctx context.Context, email string, password string, now time.Time) (*models.UserTokenStatus, error)
+	//
+	// Unknown emails fail like wrong passwords. Failed attempts are throttled per account and per client IP: once
+	// a limit is reached, a TooManyAttemptsError is returned, without checking the credentials.
+	//
+	// Users with two-factor authentication, or with a passkey, get an MFA challenge instead of a session. It is
+	// exchanged for a session with VerifyMFAService or VerifyMFAPasskeyService, which share the throttle of the
+	// login: the failures of the account are only cleared once the second factor is verified.
+	//
+	// When the stored password hash uses an outdated algorithm or parameters, it is replaced by a fresh hash of the
+	// password. This upgrade is best effort, and never fails the login.
+	Login(ctx context.Context, email string, password string, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error)
 }
 
-func NewLoginService(credentialsDAO dao.CredentialsRepository, generateTokenService GenerateTokenService) LoginService {
+func NewLoginService(
+	credentialsDAO dao.CredentialsRepository,
+	loginFailuresDAO dao.LoginFailuresRepository,
+	totpDAO dao.TOTPRepository,
+	passkeysDAO dao.PasskeysRepository,
+	auditEventsDAO dao.AuditEventsRepository,
+	createSessionService CreateSessionService,
+	createMFAChallengeService CreateMFAChallengeService,
+	passwordHasher PasswordHasher,
+	throttle LoginThrottle,
+) LoginService {
 	return &loginServiceImpl{
-		credentialsDAO:       credentialsDAO,
-		GenerateTokenService: generateTokenService,
+		credentialsDAO:            credentialsDAO,
+		loginFailuresDAO:          loginFailuresDAO,
+		totpDAO:                   totpDAO,
+		passkeysDAO:               passkeysDAO,
+		auditEventsDAO:            auditEventsDAO,
+		CreateSessionService:      createSessionService,
+		CreateMFAChallengeService: createMFAChallengeService,
+		passwordHasher:            passwordHasher,
+		throttle:                  throttle,
+		// Compared against the password when the email is unknown, so the response takes as long as with a wrong
+		// password.
+		dummyPasswordHash: sync.OnceValue(func() string {
+			hash, _ := passwordHasher.Hash("dummy-password")
+			return hash
+		}),
 	}
 }
 
 type loginServiceImpl struct {
-	credentialsDAO dao.CredentialsRepository
-	GenerateTokenService
+	credentialsDAO   dao.CredentialsRepository
+	loginFailuresDAO dao.LoginFailuresRepository
+	totpDAO          dao.TOTPRepository
+	passkeysDAO      dao.PasskeysRepository
+	auditEventsDAO   dao.AuditEventsRepository
+	CreateSessionService
+	CreateMFAChallengeService
+	passwordHasher    PasswordHasher
+	throttle          LoginThrottle
+	dummyPasswordHash func() string
+}
+
+// Factors recorded with failed attempts, in the audit log.
+const (
+	loginFactorPassword = "password"
+	loginFactorTOTP     = "totp"
+	loginFactorPasskey  = "passkey"
+)
+
+// checkLoginThrottle returns a TooManyAttemptsError if the client IP, or the account, failed too many times recently.
+// It guards every check of a user secret: password, second factor or step-up.
+func checkLoginThrottle(
+	ctx context.Context,
+	loginFailuresDAO dao.LoginFailuresRepository,
+	throttle LoginThrottle,
+	account string,
+	ip string,
+	now time.Time,
+) error {
+	ipFailures, err := loginFailuresDAO.GetIPFailures(ctx, ip, now.Add(-throttle.IPWindow))
+	if err != nil {
+		return goerrors.Join(ErrGetLoginFailures, err)
+	}
+
+	if retryAfter := throttle.ipRetryAfter(ipFailures, now); retryAfter > 0 {
+		return &TooManyAttemptsError{RetryAfter: retryAfter}
+	}
+
+	accountFailures, err := loginFailuresDAO.GetAccountFailures(ctx, account, now.Add(-throttle.AccountWindow))
+	if err != nil {
+		return goerrors.Join(ErrGetLoginFailures, err)
+	}
+
+	if retryAfter := throttle.accountRetryAfter(accountFailures, now); retryAfter > 0 {
+		return &TooManyAttemptsError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// recordLoginFailure records a failed check of a user secret, both for throttling and in the audit log. Every factor
+// is recorded under the same account, so switching factors, or starting a new login, does not reset the count. The
+// user ID is nil when the email is unknown.
+func recordLoginFailure(
+	ctx context.Context,
+	loginFailuresDAO dao.LoginFailuresRepository,
+	auditEventsDAO dao.AuditEventsRepository,
+	account string,
+	userID uuid.UUID,
+	factor string,
+	client models.ClientInfo,
+	now time.Time,
+) error {
+	data := &dao.LoginFailureModelCore{Account: account, IP: truncate(client.IP, MaxIPLength)}
+	if _, err := loginFailuresDAO.Record(ctx, data, uuid.New(), now); err != nil {
+		return goerrors.Join(ErrRecordLoginFailure, err)
+	}
+
+	event := newAuditEvent(dao.AuditEventLoginFailed, userID, client)
+	event.Details = map[string]string{"email": account, "factor": factor}
+
+	return recordAuditEvent(ctx, auditEventsDAO, event, now)
+}
+
+// fail records a failed password check, and returns the matching error.
+func (s *loginServiceImpl) fail(ctx context.Context, account string, userID uuid.UUID, client models.ClientInfo, now time.Time) error {
+	err := recordLoginFailure(ctx, s.loginFailuresDAO, s.auditEventsDAO, account, userID, loginFactorPassword, client, now)
+	if err != nil {
+		return err
+	}
+
+	return goerrors.Join(goframework.ErrInvalidCredentials, ErrWrongPassword)
 }
 
-func (s *loginServiceImpl) Login(ctx context.Context, email string, password string, now time.Time) (*models.UserTokenStatus, error) {
+// rehash replaces an outdated password hash. The user already proved their password, so a failure is ignored: the
+// old hash remains valid, and will be upgraded on a later login. The password itself does not change, so pending
+// resets and the security stamp are left untouched.
+func (s *loginServiceImpl) rehash(ctx context.Context, user *dao.CredentialsModel, password string, now time.Time) {
+	passwordHashed, err := s.passwordHasher.Hash(password)
+	if err != nil {
+		return
+	}
+
+	_, _ = s.credentialsDAO.UpdatePasswordHash(ctx, user.ID, user.Password.Hashed, passwordHashed, now)
+}
+
+func (s *loginServiceImpl) Login(ctx context.Context, email string, password string, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error) {
 	if err := goframework.CheckMinMax(email, MinEmailLength, MaxEmailLength); err != nil {
 		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidEmail, err)
 	}
@@ -42,23 +234,63 @@ func (s *loginServiceImpl) Login(ctx context.Context, email string, password str
 		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidEmail, err)
 	}
 
+	account := loginAccount(daoEmail)
+
+	if err := checkLoginThrottle(ctx, s.loginFailuresDAO, s.throttle, account, client.IP, now); err != nil {
+		return nil, err
+	}
+
 	user, err := s.credentialsDAO.GetCredentialsByEmail(ctx, daoEmail)
 	if err != nil {
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			// Do the same work as with a wrong password, so the email cannot be guessed from the response time.
+			_, _, _ = s.passwordHasher.Verify(password, s.dummyPasswordHash())
+			return nil, s.fail(ctx, account, uuid.Nil, client, now)
+		}
+
 		return nil, goerrors.Join(ErrGetCredentialsByEmail, err)
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password.Hashed), []byte(password))
+	ok, outdated, err := s.passwordHasher.Verify(password, user.Password.Hashed)
 	if err != nil {
-		if err == bcrypt.ErrMismatchedHashAndPassword {
-			return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrWrongPassword)
+		return nil, goerrors.Join(ErrCheckPassword, err)
+	}
+	if !ok {
+		return nil, s.fail(ctx, account, user.ID, client, now)
+	}
+
+	// The suspension is only revealed to users who proved their identity.
+	if user.Suspended(now) {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrAccountSuspended)
+	}
+
+	if outdated {
+		s.rehash(ctx, user, password, now)
+	}
+
+	requireMFA, err := requireMFA(ctx, s.totpDAO, s.passkeysDAO, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if requireMFA {
+		challenge, err := s.CreateMFAChallenge(ctx, user.ID, uuid.New(), now)
+		if err != nil {
+			return nil, goerrors.Join(ErrCreateMFAChallenge, err)
 		}
 
-		return nil, goerrors.Join(ErrCheckPassword, err)
+		return &models.UserTokenStatus{MFAChallenge: challenge}, nil
+	}
+
+	// With a second factor, the failures are only cleared once it is verified, so the password alone does not reset
+	// the throttle of the second factor.
+	if err := s.loginFailuresDAO.ClearAccount(ctx, account, now); err != nil {
+		return nil, goerrors.Join(ErrClearLoginFailures, err)
 	}
 
-	status, err := s.GenerateToken(ctx, models.UserTokenPayload{ID: user.ID}, uuid.New(), now)
+	status, err := s.CreateSession(ctx, user.ID, client, now)
 	if err != nil {
-		return nil, goerrors.Join(ErrGenerateToken, err)
+		return nil, goerrors.Join(ErrCreateSession, err)
 	}
 
 	return status, nil
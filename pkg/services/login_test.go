@@ -9,6 +9,7 @@ import (
 	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
 	"github.com/a-novel/bunovel"
 	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"strings"
@@ -17,91 +18,549 @@ import (
 )
 
 func TestLogin(t *testing.T) {
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
+	throttle := services.LoginThrottle{
+		FreeFailures:  3,
+		BaseLockout:   time.Minute,
+		MaxLockout:    10 * time.Minute,
+		AccountWindow: 24 * time.Hour,
+		IPMaxFailures: 20,
+		IPWindow:      time.Hour,
+	}
+
+	credentials := &dao.CredentialsModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
+		CredentialsModelCore: dao.CredentialsModelCore{
+			Email:         dao.Email{User: "user", Domain: "domain.com"},
+			Password:      dao.Password{Hashed: passwordEncrypted},
+			SecurityStamp: goframework.NumberUUID(20),
+		},
+	}
+
+	suspendedCredentials := &dao.CredentialsModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
+		CredentialsModelCore: dao.CredentialsModelCore{
+			Email:         dao.Email{User: "user", Domain: "domain.com"},
+			Password:      dao.Password{Hashed: passwordEncrypted},
+			SecurityStamp: goframework.NumberUUID(20),
+			SuspendedAt:   &baseTime,
+		},
+	}
+
+	outdatedHashing := &services.PasswordHashing{
+		Algorithm:       services.PasswordAlgorithmArgon2id,
+		Argon2idMemory:  64,
+		Argon2idTime:    1,
+		Argon2idThreads: 1,
+	}
+
 	data := []struct {
 		name string
 
 		email    string
 		password string
 		now      time.Time
+		// hashing overrides the current hashing parameters. Otherwise, the stored password is up-to-date.
+		hashing *services.PasswordHashing
+
+		shouldCallGetIPFailures bool
+		getIPFailures           *dao.LoginFailuresSummaryModel
+		getIPFailuresErr        error
+
+		shouldCallGetAccountFailures bool
+		getAccountFailures           *dao.LoginFailuresSummaryModel
+		getAccountFailuresErr        error
 
 		shouldCallDAO bool
 		daoResponse   *dao.CredentialsModel
 		daoErr        error
 
-		shouldCallGenerateToken bool
-		generateTokenStatus     *models.UserTokenStatus
-		generateTokenErr        error
+		shouldCallRecordFailure bool
+		recordFailureErr        error
+
+		shouldCallRecordAuditEvent bool
+		recordAuditEventErr        error
+
+		shouldCallClearAccount bool
+		clearAccountErr        error
+
+		shouldCallUpdatePasswordHash bool
+		updatePasswordErr            error
 
-		expect    *models.UserTokenStatus
-		expectErr error
+		shouldCallGetTOTP bool
+		getTOTP           *dao.TOTPModel
+		getTOTPErr        error
+
+		shouldCallListPasskeys bool
+		listPasskeys           []*dao.PasskeyModel
+		listPasskeysErr        error
+
+		shouldCallCreateMFAChallenge bool
+		createMFAChallenge           string
+		createMFAChallengeErr        error
+
+		shouldCallCreateSession bool
+		createSession           *models.UserTokenStatus
+		createSessionErr        error
+
+		expect           *models.UserTokenStatus
+		expectErr        error
+		expectRetryAfter time.Duration
 	}{
 		{
-			name:          "Success",
-			email:         "user@domain.com",
-			password:      password,
-			now:           baseTime,
-			shouldCallDAO: true,
-			daoResponse: &dao.CredentialsModel{
-				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
-				CredentialsModelCore: dao.CredentialsModelCore{
-					Email:    dao.Email{User: "user", Domain: "domain.com"},
-					Password: dao.Password{Hashed: passwordEncrypted},
+			name:                         "Success",
+			email:                        "User@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
+			daoResponse:                  credentials,
+			shouldCallClearAccount:       true,
+			shouldCallGetTOTP:            true,
+			getTOTPErr:                   bunovel.ErrNotFound,
+			shouldCallListPasskeys:       true,
+			shouldCallCreateSession:      true,
+			createSession: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
+				RefreshToken: "refresh-token",
 			},
-			shouldCallGenerateToken: true,
-			generateTokenStatus: &models.UserTokenStatus{
+			expect: &models.UserTokenStatus{
 				OK: true,
 				Token: &models.UserToken{
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
+				RefreshToken: "refresh-token",
+			},
+		},
+		{
+			name:                         "Success/RehashOutdatedPassword",
+			email:                        "user@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			hashing:                      outdatedHashing,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
+			daoResponse:                  credentials,
+			shouldCallClearAccount:       true,
+			shouldCallUpdatePasswordHash: true,
+			shouldCallGetTOTP:            true,
+			getTOTPErr:                   bunovel.ErrNotFound,
+			shouldCallListPasskeys:       true,
+			shouldCallCreateSession:      true,
+			createSession: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+				RefreshToken: "refresh-token",
 			},
 			expect: &models.UserTokenStatus{
 				OK: true,
 				Token: &models.UserToken{
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
+				RefreshToken: "refresh-token",
 			},
 		},
 		{
-			name:          "Error/GenerateTokenFailure",
-			email:         "user@domain.com",
-			password:      password,
-			now:           baseTime,
-			shouldCallDAO: true,
-			daoResponse: &dao.CredentialsModel{
-				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
-				CredentialsModelCore: dao.CredentialsModelCore{
-					Email:    dao.Email{User: "user", Domain: "domain.com"},
-					Password: dao.Password{Hashed: passwordEncrypted},
+			name:                         "Success/RehashFailureIgnored",
+			email:                        "user@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			hashing:                      outdatedHashing,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
+			daoResponse:                  credentials,
+			shouldCallClearAccount:       true,
+			shouldCallUpdatePasswordHash: true,
+			updatePasswordErr:            fooErr,
+			shouldCallGetTOTP:            true,
+			getTOTPErr:                   bunovel.ErrNotFound,
+			shouldCallListPasskeys:       true,
+			shouldCallCreateSession:      true,
+			createSession: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
+				RefreshToken: "refresh-token",
+			},
+			expect: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+				RefreshToken: "refresh-token",
 			},
-			shouldCallGenerateToken: true,
-			generateTokenErr:        fooErr,
-			expectErr:               fooErr,
 		},
 		{
-			name:          "Error/WrongPassword",
-			email:         "user@domain.com",
-			password:      "fake-password",
-			now:           baseTime,
-			shouldCallDAO: true,
+			name:                         "Error/UnsupportedPasswordHash",
+			email:                        "user@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
 			daoResponse: &dao.CredentialsModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
 				CredentialsModelCore: dao.CredentialsModelCore{
 					Email:    dao.Email{User: "user", Domain: "domain.com"},
-					Password: dao.Password{Hashed: passwordEncrypted},
+					Password: dao.Password{Hashed: "$md5$not-supported"},
 				},
 			},
-			expectErr: goframework.ErrInvalidCredentials,
+			expectErr: services.ErrUnsupportedPasswordHash,
+		},
+		{
+			name:                    "Success/AccountLockExpired",
+			email:                   "user@domain.com",
+			password:                password,
+			now:                     baseTime,
+			shouldCallGetIPFailures: true,
+			getIPFailures: &dao.LoginFailuresSummaryModel{
+				Count:   4,
+				FirstAt: baseTime.Add(-10 * time.Minute),
+				LastAt:  baseTime.Add(-2 * time.Minute),
+			},
+			shouldCallGetAccountFailures: true,
+			// 4th failure: locked for 2 minutes.
+			getAccountFailures: &dao.LoginFailuresSummaryModel{
+				Count:   4,
+				FirstAt: baseTime.Add(-10 * time.Minute),
+				LastAt:  baseTime.Add(-2 * time.Minute),
+			},
+			shouldCallDAO:           true,
+			daoResponse:             credentials,
+			shouldCallClearAccount:  true,
+			shouldCallGetTOTP:       true,
+			getTOTPErr:              bunovel.ErrNotFound,
+			shouldCallListPasskeys:  true,
+			shouldCallCreateSession: true,
+			createSession:           &models.UserTokenStatus{OK: true, RefreshToken: "refresh-token"},
+			expect:                  &models.UserTokenStatus{OK: true, RefreshToken: "refresh-token"},
+		},
+		{
+			name:                    "Error/AccountLocked",
+			email:                   "user@domain.com",
+			password:                password,
+			now:                     baseTime,
+			shouldCallGetIPFailures: true,
+			getIPFailures: &dao.LoginFailuresSummaryModel{
+				Count:   4,
+				FirstAt: baseTime.Add(-10 * time.Minute),
+				LastAt:  baseTime.Add(-time.Minute),
+			},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures: &dao.LoginFailuresSummaryModel{
+				Count:   4,
+				FirstAt: baseTime.Add(-10 * time.Minute),
+				LastAt:  baseTime.Add(-30 * time.Second),
+			},
+			expectErr:        services.ErrTooManyAttempts,
+			expectRetryAfter: 90 * time.Second,
+		},
+		{
+			name:                         "Error/AccountLockedMax",
+			email:                        "user@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures: &dao.LoginFailuresSummaryModel{
+				Count:   100,
+				FirstAt: baseTime.Add(-10 * time.Hour),
+				LastAt:  baseTime,
+			},
+			expectErr:        services.ErrTooManyAttempts,
+			expectRetryAfter: 10 * time.Minute,
+		},
+		{
+			name:                    "Error/IPThrottled",
+			email:                   "user@domain.com",
+			password:                password,
+			now:                     baseTime,
+			shouldCallGetIPFailures: true,
+			getIPFailures: &dao.LoginFailuresSummaryModel{
+				Count:   20,
+				FirstAt: baseTime.Add(-45 * time.Minute),
+				LastAt:  baseTime.Add(-time.Minute),
+			},
+			expectErr:        services.ErrTooManyAttempts,
+			expectRetryAfter: 15 * time.Minute,
+		},
+		{
+			name:                         "Success/MFARequired",
+			email:                        "user@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
+			daoResponse:                  credentials,
+			shouldCallGetTOTP:            true,
+			getTOTP: &dao.TOTPModel{
+				Metadata:      bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				TOTPModelCore: dao.TOTPModelCore{Secret: "secret", ConfirmedAt: &baseTime},
+			},
+			shouldCallCreateMFAChallenge: true,
+			createMFAChallenge:           "challenge",
+			expect:                       &models.UserTokenStatus{MFAChallenge: "challenge"},
+		},
+		{
+			name:                         "Success/TOTPNotConfirmed",
+			email:                        "user@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
+			daoResponse:                  credentials,
+			shouldCallClearAccount:       true,
+			shouldCallGetTOTP:            true,
+			getTOTP: &dao.TOTPModel{
+				Metadata:      bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				TOTPModelCore: dao.TOTPModelCore{Secret: "secret"},
+			},
+			shouldCallListPasskeys:  true,
+			shouldCallCreateSession: true,
+			createSession:           &models.UserTokenStatus{OK: true, RefreshToken: "refresh-token"},
+			expect:                  &models.UserTokenStatus{OK: true, RefreshToken: "refresh-token"},
+		},
+		{
+			name:                         "Success/MFARequiredByPasskey",
+			email:                        "user@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
+			daoResponse:                  credentials,
+			shouldCallGetTOTP:            true,
+			getTOTPErr:                   bunovel.ErrNotFound,
+			shouldCallListPasskeys:       true,
+			listPasskeys: []*dao.PasskeyModel{
+				{Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil)},
+			},
+			shouldCallCreateMFAChallenge: true,
+			createMFAChallenge:           "challenge",
+			expect:                       &models.UserTokenStatus{MFAChallenge: "challenge"},
+		},
+		{
+			name:                         "Error/ListPasskeysFailure",
+			email:                        "user@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
+			daoResponse:                  credentials,
+			shouldCallGetTOTP:            true,
+			getTOTPErr:                   bunovel.ErrNotFound,
+			shouldCallListPasskeys:       true,
+			listPasskeysErr:              fooErr,
+			expectErr:                    fooErr,
+		},
+		{
+			name:                         "Error/CreateMFAChallengeFailure",
+			email:                        "user@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
+			daoResponse:                  credentials,
+			shouldCallGetTOTP:            true,
+			getTOTP: &dao.TOTPModel{
+				Metadata:      bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				TOTPModelCore: dao.TOTPModelCore{Secret: "secret", ConfirmedAt: &baseTime},
+			},
+			shouldCallCreateMFAChallenge: true,
+			createMFAChallengeErr:        fooErr,
+			expectErr:                    fooErr,
 		},
 		{
-			name:          "Error/CredentialsDAOFailure",
-			email:         "user@domain.com",
-			password:      password,
-			now:           baseTime,
-			shouldCallDAO: true,
-			daoErr:        fooErr,
-			expectErr:     fooErr,
+			name:                         "Error/GetTOTPFailure",
+			email:                        "user@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
+			daoResponse:                  credentials,
+			shouldCallGetTOTP:            true,
+			getTOTPErr:                   fooErr,
+			expectErr:                    fooErr,
+		},
+		{
+			name:                         "Error/CreateSessionFailure",
+			email:                        "user@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
+			daoResponse:                  credentials,
+			shouldCallClearAccount:       true,
+			shouldCallGetTOTP:            true,
+			getTOTPErr:                   bunovel.ErrNotFound,
+			shouldCallListPasskeys:       true,
+			shouldCallCreateSession:      true,
+			createSessionErr:             fooErr,
+			expectErr:                    fooErr,
+		},
+		{
+			name:                         "Error/ClearAccountFailure",
+			email:                        "user@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
+			daoResponse:                  credentials,
+			shouldCallGetTOTP:            true,
+			getTOTPErr:                   bunovel.ErrNotFound,
+			shouldCallListPasskeys:       true,
+			shouldCallClearAccount:       true,
+			clearAccountErr:              fooErr,
+			expectErr:                    fooErr,
+		},
+		{
+			name:                         "Error/AccountSuspended",
+			email:                        "user@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
+			daoResponse:                  suspendedCredentials,
+			expectErr:                    services.ErrAccountSuspended,
+		},
+		{
+			name:                         "Error/WrongPassword",
+			email:                        "user@domain.com",
+			password:                     "fake-password",
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{Count: 2, FirstAt: baseTime, LastAt: baseTime},
+			shouldCallDAO:                true,
+			daoResponse:                  credentials,
+			shouldCallRecordFailure:      true,
+			shouldCallRecordAuditEvent:   true,
+			expectErr:                    goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                         "Error/UnknownEmail",
+			email:                        "user@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
+			daoErr:                       bunovel.ErrNotFound,
+			shouldCallRecordFailure:      true,
+			shouldCallRecordAuditEvent:   true,
+			expectErr:                    goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                         "Error/RecordFailureFailure",
+			email:                        "user@domain.com",
+			password:                     "fake-password",
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
+			daoResponse:                  credentials,
+			shouldCallRecordFailure:      true,
+			recordFailureErr:             fooErr,
+			expectErr:                    fooErr,
+		},
+		{
+			name:                         "Error/RecordAuditEventFailure",
+			email:                        "user@domain.com",
+			password:                     "fake-password",
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
+			daoResponse:                  credentials,
+			shouldCallRecordFailure:      true,
+			shouldCallRecordAuditEvent:   true,
+			recordAuditEventErr:          fooErr,
+			expectErr:                    fooErr,
+		},
+		{
+			name:                         "Error/CredentialsDAOFailure",
+			email:                        "user@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailures:           &dao.LoginFailuresSummaryModel{},
+			shouldCallDAO:                true,
+			daoErr:                       fooErr,
+			expectErr:                    fooErr,
+		},
+		{
+			name:                         "Error/GetAccountFailuresFailure",
+			email:                        "user@domain.com",
+			password:                     password,
+			now:                          baseTime,
+			shouldCallGetIPFailures:      true,
+			getIPFailures:                &dao.LoginFailuresSummaryModel{},
+			shouldCallGetAccountFailures: true,
+			getAccountFailuresErr:        fooErr,
+			expectErr:                    fooErr,
+		},
+		{
+			name:                    "Error/GetIPFailuresFailure",
+			email:                   "user@domain.com",
+			password:                password,
+			now:                     baseTime,
+			shouldCallGetIPFailures: true,
+			getIPFailuresErr:        fooErr,
+			expectErr:               fooErr,
 		},
 		{
 			name:      "Error/InvalidEmail",
@@ -129,7 +588,24 @@ func TestLogin(t *testing.T) {
 	for _, d := range data {
 		t.Run(d.name, func(t *testing.T) {
 			credentialsDAO := daomocks.NewCredentialsRepository(t)
-			generateTokenService := servicesmocks.NewGenerateTokenService(t)
+			loginFailuresDAO := daomocks.NewLoginFailuresRepository(t)
+			totpDAO := daomocks.NewTOTPRepository(t)
+			passkeysDAO := daomocks.NewPasskeysRepository(t)
+			auditEventsDAO := daomocks.NewAuditEventsRepository(t)
+			createSessionService := servicesmocks.NewCreateSessionService(t)
+			createMFAChallengeService := servicesmocks.NewCreateMFAChallengeService(t)
+
+			if d.shouldCallGetIPFailures {
+				loginFailuresDAO.
+					On("GetIPFailures", context.Background(), client.IP, d.now.Add(-throttle.IPWindow)).
+					Return(d.getIPFailures, d.getIPFailuresErr)
+			}
+
+			if d.shouldCallGetAccountFailures {
+				loginFailuresDAO.
+					On("GetAccountFailures", context.Background(), "user@domain.com", d.now.Add(-throttle.AccountWindow)).
+					Return(d.getAccountFailures, d.getAccountFailuresErr)
+			}
 
 			if d.shouldCallDAO {
 				credentialsDAO.
@@ -137,20 +613,95 @@ func TestLogin(t *testing.T) {
 					Return(d.daoResponse, d.daoErr)
 			}
 
-			if d.shouldCallGenerateToken {
-				generateTokenService.
-					On("GenerateToken", context.Background(), models.UserTokenPayload{ID: d.daoResponse.ID}, mock.Anything, d.now).
-					Return(d.generateTokenStatus, d.generateTokenErr)
+			if d.shouldCallRecordFailure {
+				loginFailuresDAO.
+					On("Record", context.Background(), &dao.LoginFailureModelCore{
+						Account: "user@domain.com",
+						IP:      client.IP,
+					}, mock.Anything, d.now).
+					Return(nil, d.recordFailureErr)
+			}
+
+			if d.shouldCallRecordAuditEvent {
+				// Unknown emails are recorded without a user.
+				var userID uuid.UUID
+				if d.daoResponse != nil {
+					userID = d.daoResponse.ID
+				}
+
+				auditEventsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:      dao.AuditEventLoginFailed,
+						UserID:    userID,
+						IP:        client.IP,
+						UserAgent: client.UserAgent,
+						Details:   map[string]string{"email": "user@domain.com", "factor": "password"},
+					}, mock.Anything, d.now).
+					Return(nil, d.recordAuditEventErr)
+			}
+
+			if d.shouldCallClearAccount {
+				loginFailuresDAO.
+					On("ClearAccount", context.Background(), "user@domain.com", d.now).
+					Return(d.clearAccountErr)
 			}
 
-			service := services.NewLoginService(credentialsDAO, generateTokenService)
-			res, err := service.Login(context.Background(), d.email, d.password, d.now)
+			if d.shouldCallUpdatePasswordHash {
+				credentialsDAO.
+					On("UpdatePasswordHash", context.Background(), d.daoResponse.ID, d.daoResponse.Password.Hashed, mock.MatchedBy(func(hashed string) bool {
+						return strings.HasPrefix(hashed, "$argon2id$")
+					}), d.now).
+					Return(nil, d.updatePasswordErr)
+			}
+
+			if d.shouldCallGetTOTP {
+				totpDAO.
+					On("Get", context.Background(), d.daoResponse.ID).
+					Return(d.getTOTP, d.getTOTPErr)
+			}
+
+			if d.shouldCallListPasskeys {
+				passkeysDAO.
+					On("ListUserPasskeys", context.Background(), d.daoResponse.ID).
+					Return(d.listPasskeys, d.listPasskeysErr)
+			}
+
+			if d.shouldCallCreateMFAChallenge {
+				createMFAChallengeService.
+					On("CreateMFAChallenge", context.Background(), d.daoResponse.ID, mock.Anything, d.now).
+					Return(d.createMFAChallenge, d.createMFAChallengeErr)
+			}
+
+			if d.shouldCallCreateSession {
+				createSessionService.
+					On("CreateSession", context.Background(), d.daoResponse.ID, client, d.now).
+					Return(d.createSession, d.createSessionErr)
+			}
+
+			hasher := passwordHasher
+			if d.hashing != nil {
+				hasher = services.NewPasswordHasher(*d.hashing)
+			}
+
+			service := services.NewLoginService(credentialsDAO, loginFailuresDAO, totpDAO, passkeysDAO, auditEventsDAO, createSessionService, createMFAChallengeService, hasher, throttle)
+			res, err := service.Login(context.Background(), d.email, d.password, client, d.now)
 
 			require.Equal(t, d.expect, res)
 			require.ErrorIs(t, err, d.expectErr)
 
+			if d.expectRetryAfter > 0 {
+				var tooManyAttemptsErr *services.TooManyAttemptsError
+				require.ErrorAs(t, err, &tooManyAttemptsErr)
+				require.Equal(t, d.expectRetryAfter, tooManyAttemptsErr.RetryAfter)
+			}
+
 			credentialsDAO.AssertExpectations(t)
-			generateTokenService.AssertExpectations(t)
+			loginFailuresDAO.AssertExpectations(t)
+			totpDAO.AssertExpectations(t)
+			passkeysDAO.AssertExpectations(t)
+			auditEventsDAO.AssertExpectations(t)
+			createSessionService.AssertExpectations(t)
+			createMFAChallengeService.AssertExpectations(t)
 		})
 	}
 }
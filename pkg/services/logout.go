@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"time"
+)
+
+type LogoutService interface {
+	// Logout revokes the given token, as well as the refresh token family it was issued from, so the session cannot
+	// be extended.
+	Logout(ctx context.Context, tokenRaw string, now time.Time) error
+}
+
+func NewLogoutService(
+	revokedTokensDAO dao.RevokedTokensRepository,
+	refreshTokensDAO dao.RefreshTokensRepository,
+	introspectTokenService IntrospectTokenService,
+	tokenTTL time.Duration,
+) LogoutService {
+	return &logoutServiceImpl{
+		revokedTokensDAO:       revokedTokensDAO,
+		refreshTokensDAO:       refreshTokensDAO,
+		IntrospectTokenService: introspectTokenService,
+		tokenTTL:               tokenTTL,
+	}
+}
+
+type logoutServiceImpl struct {
+	revokedTokensDAO dao.RevokedTokensRepository
+	refreshTokensDAO dao.RefreshTokensRepository
+	IntrospectTokenService
+	tokenTTL time.Duration
+}
+
+func (s *logoutServiceImpl) Logout(ctx context.Context, tokenRaw string, now time.Time) error {
+	token, err := s.IntrospectToken(ctx, tokenRaw, now, false)
+	if err != nil {
+		return goerrors.Join(ErrIntrospectToken, err)
+	}
+	if !token.OK {
+		return goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
+	}
+
+	if token.Token.Payload.FamilyID != uuid.Nil {
+		if err := s.refreshTokensDAO.RevokeFamily(ctx, token.Token.Payload.FamilyID, now); err != nil {
+			return goerrors.Join(ErrRevokeTokenFamily, err)
+		}
+	}
+
+	// Renewed tokens keep the ID of the token they replace, and may expire later than the presented one. The family
+	// is revoked, so no token can be renewed after now: the revocation must last until the last renewal expires.
+	expiresAt := now.Add(s.tokenTTL)
+	if token.Token.Header.EXP.After(expiresAt) {
+		expiresAt = token.Token.Header.EXP
+	}
+
+	_, err = s.revokedTokensDAO.Revoke(ctx, &dao.RevokedTokenModelCore{
+		UserID:    token.Token.Payload.ID,
+		ExpiresAt: expiresAt,
+	}, token.Token.Header.ID, now)
+	if err != nil {
+		return goerrors.Join(ErrRevokeToken, err)
+	}
+
+	return nil
+}
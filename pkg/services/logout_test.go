@@ -0,0 +1,188 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestLogout(t *testing.T) {
+	data := []struct {
+		name string
+
+		tokenRaw string
+		now      time.Time
+
+		introspectTokenResp *models.UserTokenStatus
+		introspectTokenErr  error
+
+		shouldCallRevokeFamily bool
+		revokeFamilyErr        error
+
+		shouldCallRevoke          bool
+		expectRevocationExpiresAt time.Time
+		revokeErr                 error
+
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			tokenRaw: "string-token",
+			now:      baseTime,
+			introspectTokenResp: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime,
+						EXP: baseTime.Add(time.Hour),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+				TokenRaw: "string-token",
+			},
+			shouldCallRevokeFamily:    true,
+			shouldCallRevoke:          true,
+			expectRevocationExpiresAt: baseTime.Add(2 * time.Hour),
+		},
+		{
+			name:     "Success/NoFamily",
+			tokenRaw: "string-token",
+			now:      baseTime,
+			introspectTokenResp: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime,
+						EXP: baseTime.Add(time.Hour),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+				TokenRaw: "string-token",
+			},
+			shouldCallRevoke:          true,
+			expectRevocationExpiresAt: baseTime.Add(2 * time.Hour),
+		},
+		{
+			// The token was issued with a longer TTL than the current one.
+			name:     "Success/LongerExpiration",
+			tokenRaw: "string-token",
+			now:      baseTime,
+			introspectTokenResp: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime,
+						EXP: baseTime.Add(3 * time.Hour),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+				TokenRaw: "string-token",
+			},
+			shouldCallRevokeFamily:    true,
+			shouldCallRevoke:          true,
+			expectRevocationExpiresAt: baseTime.Add(3 * time.Hour),
+		},
+		{
+			name:     "Error/RevokeFailure",
+			tokenRaw: "string-token",
+			now:      baseTime,
+			introspectTokenResp: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime,
+						EXP: baseTime.Add(time.Hour),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+				TokenRaw: "string-token",
+			},
+			shouldCallRevokeFamily:    true,
+			shouldCallRevoke:          true,
+			expectRevocationExpiresAt: baseTime.Add(2 * time.Hour),
+			revokeErr:                 fooErr,
+			expectErr:                 fooErr,
+		},
+		{
+			name:     "Error/RevokeFamilyFailure",
+			tokenRaw: "string-token",
+			now:      baseTime,
+			introspectTokenResp: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Header: models.UserTokenHeader{
+						IAT: baseTime,
+						EXP: baseTime.Add(time.Hour),
+						ID:  goframework.NumberUUID(10),
+					},
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+				TokenRaw: "string-token",
+			},
+			shouldCallRevokeFamily: true,
+			revokeFamilyErr:        fooErr,
+			expectErr:              fooErr,
+		},
+		{
+			name:               "Error/IntrospectTokenFailure",
+			tokenRaw:           "string-token",
+			now:                baseTime,
+			introspectTokenErr: fooErr,
+			expectErr:          fooErr,
+		},
+		{
+			name:                "Error/InvalidToken",
+			tokenRaw:            "string-token",
+			now:                 baseTime,
+			introspectTokenResp: &models.UserTokenStatus{OK: false},
+			expectErr:           goframework.ErrInvalidCredentials,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			revokedTokensDAO := daomocks.NewRevokedTokensRepository(t)
+			refreshTokensDAO := daomocks.NewRefreshTokensRepository(t)
+			introspectTokenService := servicesmocks.NewIntrospectTokenService(t)
+
+			introspectTokenService.
+				On("IntrospectToken", context.Background(), d.tokenRaw, d.now, false).
+				Return(d.introspectTokenResp, d.introspectTokenErr)
+
+			if d.shouldCallRevokeFamily {
+				refreshTokensDAO.
+					On("RevokeFamily", context.Background(), d.introspectTokenResp.Token.Payload.FamilyID, d.now).
+					Return(d.revokeFamilyErr)
+			}
+
+			if d.shouldCallRevoke {
+				revokedTokensDAO.
+					On("Revoke", context.Background(), &dao.RevokedTokenModelCore{
+						UserID:    d.introspectTokenResp.Token.Payload.ID,
+						ExpiresAt: d.expectRevocationExpiresAt,
+					}, d.introspectTokenResp.Token.Header.ID, d.now).
+					Return(nil, d.revokeErr)
+			}
+
+			service := services.NewLogoutService(revokedTokensDAO, refreshTokensDAO, introspectTokenService, 2*time.Hour)
+			err := service.Logout(context.Background(), d.tokenRaw, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			revokedTokensDAO.AssertExpectations(t)
+			refreshTokensDAO.AssertExpectations(t)
+			introspectTokenService.AssertExpectations(t)
+		})
+	}
+}
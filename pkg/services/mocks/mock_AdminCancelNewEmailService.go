@@ -0,0 +1,85 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// AdminCancelNewEmailService is an autogenerated mock type for the AdminCancelNewEmailService type
+type AdminCancelNewEmailService struct {
+	mock.Mock
+}
+
+type AdminCancelNewEmailService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminCancelNewEmailService) EXPECT() *AdminCancelNewEmailService_Expecter {
+	return &AdminCancelNewEmailService_Expecter{mock: &_m.Mock}
+}
+
+// AdminCancelNewEmail provides a mock function with given fields: ctx, userID, actor, now
+func (_m *AdminCancelNewEmailService) AdminCancelNewEmail(ctx context.Context, userID uuid.UUID, actor string, now time.Time) error {
+	ret := _m.Called(ctx, userID, actor, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) error); ok {
+		r0 = rf(ctx, userID, actor, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminCancelNewEmailService_AdminCancelNewEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdminCancelNewEmail'
+type AdminCancelNewEmailService_AdminCancelNewEmail_Call struct {
+	*mock.Call
+}
+
+// AdminCancelNewEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - actor string
+//   - now time.Time
+func (_e *AdminCancelNewEmailService_Expecter) AdminCancelNewEmail(ctx interface{}, userID interface{}, actor interface{}, now interface{}) *AdminCancelNewEmailService_AdminCancelNewEmail_Call {
+	return &AdminCancelNewEmailService_AdminCancelNewEmail_Call{Call: _e.mock.On("AdminCancelNewEmail", ctx, userID, actor, now)}
+}
+
+func (_c *AdminCancelNewEmailService_AdminCancelNewEmail_Call) Run(run func(ctx context.Context, userID uuid.UUID, actor string, now time.Time)) *AdminCancelNewEmailService_AdminCancelNewEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AdminCancelNewEmailService_AdminCancelNewEmail_Call) Return(_a0 error) *AdminCancelNewEmailService_AdminCancelNewEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminCancelNewEmailService_AdminCancelNewEmail_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, time.Time) error) *AdminCancelNewEmailService_AdminCancelNewEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAdminCancelNewEmailService creates a new instance of AdminCancelNewEmailService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminCancelNewEmailService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminCancelNewEmailService {
+	mock := &AdminCancelNewEmailService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
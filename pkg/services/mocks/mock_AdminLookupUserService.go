@@ -0,0 +1,96 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// AdminLookupUserService is an autogenerated mock type for the AdminLookupUserService type
+type AdminLookupUserService struct {
+	mock.Mock
+}
+
+type AdminLookupUserService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminLookupUserService) EXPECT() *AdminLookupUserService_Expecter {
+	return &AdminLookupUserService_Expecter{mock: &_m.Mock}
+}
+
+// AdminLookupUser provides a mock function with given fields: ctx, query, actor, now
+func (_m *AdminLookupUserService) AdminLookupUser(ctx context.Context, query models.AdminLookupUserQuery, actor string, now time.Time) (*models.AdminUser, error) {
+	ret := _m.Called(ctx, query, actor, now)
+
+	var r0 *models.AdminUser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.AdminLookupUserQuery, string, time.Time) (*models.AdminUser, error)); ok {
+		return rf(ctx, query, actor, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.AdminLookupUserQuery, string, time.Time) *models.AdminUser); ok {
+		r0 = rf(ctx, query, actor, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AdminUser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.AdminLookupUserQuery, string, time.Time) error); ok {
+		r1 = rf(ctx, query, actor, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminLookupUserService_AdminLookupUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdminLookupUser'
+type AdminLookupUserService_AdminLookupUser_Call struct {
+	*mock.Call
+}
+
+// AdminLookupUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query models.AdminLookupUserQuery
+//   - actor string
+//   - now time.Time
+func (_e *AdminLookupUserService_Expecter) AdminLookupUser(ctx interface{}, query interface{}, actor interface{}, now interface{}) *AdminLookupUserService_AdminLookupUser_Call {
+	return &AdminLookupUserService_AdminLookupUser_Call{Call: _e.mock.On("AdminLookupUser", ctx, query, actor, now)}
+}
+
+func (_c *AdminLookupUserService_AdminLookupUser_Call) Run(run func(ctx context.Context, query models.AdminLookupUserQuery, actor string, now time.Time)) *AdminLookupUserService_AdminLookupUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.AdminLookupUserQuery), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AdminLookupUserService_AdminLookupUser_Call) Return(_a0 *models.AdminUser, _a1 error) *AdminLookupUserService_AdminLookupUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminLookupUserService_AdminLookupUser_Call) RunAndReturn(run func(context.Context, models.AdminLookupUserQuery, string, time.Time) (*models.AdminUser, error)) *AdminLookupUserService_AdminLookupUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAdminLookupUserService creates a new instance of AdminLookupUserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminLookupUserService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminLookupUserService {
+	mock := &AdminLookupUserService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
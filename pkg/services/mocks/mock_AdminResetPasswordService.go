@@ -0,0 +1,97 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// AdminResetPasswordService is an autogenerated mock type for the AdminResetPasswordService type
+type AdminResetPasswordService struct {
+	mock.Mock
+}
+
+type AdminResetPasswordService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminResetPasswordService) EXPECT() *AdminResetPasswordService_Expecter {
+	return &AdminResetPasswordService_Expecter{mock: &_m.Mock}
+}
+
+// AdminResetPassword provides a mock function with given fields: ctx, userID, actor, now
+func (_m *AdminResetPasswordService) AdminResetPassword(ctx context.Context, userID uuid.UUID, actor string, now time.Time) (func() error, error) {
+	ret := _m.Called(ctx, userID, actor, now)
+
+	var r0 func() error
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) (func() error, error)); ok {
+		return rf(ctx, userID, actor, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) func() error); ok {
+		r0 = rf(ctx, userID, actor, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func() error)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, time.Time) error); ok {
+		r1 = rf(ctx, userID, actor, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminResetPasswordService_AdminResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdminResetPassword'
+type AdminResetPasswordService_AdminResetPassword_Call struct {
+	*mock.Call
+}
+
+// AdminResetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - actor string
+//   - now time.Time
+func (_e *AdminResetPasswordService_Expecter) AdminResetPassword(ctx interface{}, userID interface{}, actor interface{}, now interface{}) *AdminResetPasswordService_AdminResetPassword_Call {
+	return &AdminResetPasswordService_AdminResetPassword_Call{Call: _e.mock.On("AdminResetPassword", ctx, userID, actor, now)}
+}
+
+func (_c *AdminResetPasswordService_AdminResetPassword_Call) Run(run func(ctx context.Context, userID uuid.UUID, actor string, now time.Time)) *AdminResetPasswordService_AdminResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AdminResetPasswordService_AdminResetPassword_Call) Return(_a0 func() error, _a1 error) *AdminResetPasswordService_AdminResetPassword_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminResetPasswordService_AdminResetPassword_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, time.Time) (func() error, error)) *AdminResetPasswordService_AdminResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAdminResetPasswordService creates a new instance of AdminResetPasswordService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminResetPasswordService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminResetPasswordService {
+	mock := &AdminResetPasswordService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
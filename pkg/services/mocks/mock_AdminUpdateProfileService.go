@@ -0,0 +1,87 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// AdminUpdateProfileService is an autogenerated mock type for the AdminUpdateProfileService type
+type AdminUpdateProfileService struct {
+	mock.Mock
+}
+
+type AdminUpdateProfileService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminUpdateProfileService) EXPECT() *AdminUpdateProfileService_Expecter {
+	return &AdminUpdateProfileService_Expecter{mock: &_m.Mock}
+}
+
+// AdminUpdateProfile provides a mock function with given fields: ctx, userID, form, actor, now
+func (_m *AdminUpdateProfileService) AdminUpdateProfile(ctx context.Context, userID uuid.UUID, form models.UpdateProfileForm, actor string, now time.Time) error {
+	ret := _m.Called(ctx, userID, form, actor, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.UpdateProfileForm, string, time.Time) error); ok {
+		r0 = rf(ctx, userID, form, actor, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminUpdateProfileService_AdminUpdateProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdminUpdateProfile'
+type AdminUpdateProfileService_AdminUpdateProfile_Call struct {
+	*mock.Call
+}
+
+// AdminUpdateProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - form models.UpdateProfileForm
+//   - actor string
+//   - now time.Time
+func (_e *AdminUpdateProfileService_Expecter) AdminUpdateProfile(ctx interface{}, userID interface{}, form interface{}, actor interface{}, now interface{}) *AdminUpdateProfileService_AdminUpdateProfile_Call {
+	return &AdminUpdateProfileService_AdminUpdateProfile_Call{Call: _e.mock.On("AdminUpdateProfile", ctx, userID, form, actor, now)}
+}
+
+func (_c *AdminUpdateProfileService_AdminUpdateProfile_Call) Run(run func(ctx context.Context, userID uuid.UUID, form models.UpdateProfileForm, actor string, now time.Time)) *AdminUpdateProfileService_AdminUpdateProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(models.UpdateProfileForm), args[3].(string), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AdminUpdateProfileService_AdminUpdateProfile_Call) Return(_a0 error) *AdminUpdateProfileService_AdminUpdateProfile_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminUpdateProfileService_AdminUpdateProfile_Call) RunAndReturn(run func(context.Context, uuid.UUID, models.UpdateProfileForm, string, time.Time) error) *AdminUpdateProfileService_AdminUpdateProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAdminUpdateProfileService creates a new instance of AdminUpdateProfileService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminUpdateProfileService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminUpdateProfileService {
+	mock := &AdminUpdateProfileService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
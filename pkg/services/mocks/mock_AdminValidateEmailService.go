@@ -0,0 +1,85 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// AdminValidateEmailService is an autogenerated mock type for the AdminValidateEmailService type
+type AdminValidateEmailService struct {
+	mock.Mock
+}
+
+type AdminValidateEmailService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminValidateEmailService) EXPECT() *AdminValidateEmailService_Expecter {
+	return &AdminValidateEmailService_Expecter{mock: &_m.Mock}
+}
+
+// AdminValidateEmail provides a mock function with given fields: ctx, userID, actor, now
+func (_m *AdminValidateEmailService) AdminValidateEmail(ctx context.Context, userID uuid.UUID, actor string, now time.Time) error {
+	ret := _m.Called(ctx, userID, actor, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) error); ok {
+		r0 = rf(ctx, userID, actor, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminValidateEmailService_AdminValidateEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdminValidateEmail'
+type AdminValidateEmailService_AdminValidateEmail_Call struct {
+	*mock.Call
+}
+
+// AdminValidateEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - actor string
+//   - now time.Time
+func (_e *AdminValidateEmailService_Expecter) AdminValidateEmail(ctx interface{}, userID interface{}, actor interface{}, now interface{}) *AdminValidateEmailService_AdminValidateEmail_Call {
+	return &AdminValidateEmailService_AdminValidateEmail_Call{Call: _e.mock.On("AdminValidateEmail", ctx, userID, actor, now)}
+}
+
+func (_c *AdminValidateEmailService_AdminValidateEmail_Call) Run(run func(ctx context.Context, userID uuid.UUID, actor string, now time.Time)) *AdminValidateEmailService_AdminValidateEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AdminValidateEmailService_AdminValidateEmail_Call) Return(_a0 error) *AdminValidateEmailService_AdminValidateEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminValidateEmailService_AdminValidateEmail_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, time.Time) error) *AdminValidateEmailService_AdminValidateEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAdminValidateEmailService creates a new instance of AdminValidateEmailService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminValidateEmailService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminValidateEmailService {
+	mock := &AdminValidateEmailService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// BeginPasskeyLoginService is an autogenerated mock type for the BeginPasskeyLoginService type
+type BeginPasskeyLoginService struct {
+	mock.Mock
+}
+
+type BeginPasskeyLoginService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BeginPasskeyLoginService) EXPECT() *BeginPasskeyLoginService_Expecter {
+	return &BeginPasskeyLoginService_Expecter{mock: &_m.Mock}
+}
+
+// BeginPasskeyLogin provides a mock function with given fields: ctx, now
+func (_m *BeginPasskeyLoginService) BeginPasskeyLogin(ctx context.Context, now time.Time) (*models.PasskeyRequestOptions, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 *models.PasskeyRequestOptions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (*models.PasskeyRequestOptions, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) *models.PasskeyRequestOptions); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PasskeyRequestOptions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BeginPasskeyLoginService_BeginPasskeyLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginPasskeyLogin'
+type BeginPasskeyLoginService_BeginPasskeyLogin_Call struct {
+	*mock.Call
+}
+
+// BeginPasskeyLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *BeginPasskeyLoginService_Expecter) BeginPasskeyLogin(ctx interface{}, now interface{}) *BeginPasskeyLoginService_BeginPasskeyLogin_Call {
+	return &BeginPasskeyLoginService_BeginPasskeyLogin_Call{Call: _e.mock.On("BeginPasskeyLogin", ctx, now)}
+}
+
+func (_c *BeginPasskeyLoginService_BeginPasskeyLogin_Call) Run(run func(ctx context.Context, now time.Time)) *BeginPasskeyLoginService_BeginPasskeyLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *BeginPasskeyLoginService_BeginPasskeyLogin_Call) Return(_a0 *models.PasskeyRequestOptions, _a1 error) *BeginPasskeyLoginService_BeginPasskeyLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BeginPasskeyLoginService_BeginPasskeyLogin_Call) RunAndReturn(run func(context.Context, time.Time) (*models.PasskeyRequestOptions, error)) *BeginPasskeyLoginService_BeginPasskeyLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBeginPasskeyLoginService creates a new instance of BeginPasskeyLoginService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBeginPasskeyLoginService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BeginPasskeyLoginService {
+	mock := &BeginPasskeyLoginService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// BeginPasskeyMFAService is an autogenerated mock type for the BeginPasskeyMFAService type
+type BeginPasskeyMFAService struct {
+	mock.Mock
+}
+
+type BeginPasskeyMFAService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BeginPasskeyMFAService) EXPECT() *BeginPasskeyMFAService_Expecter {
+	return &BeginPasskeyMFAService_Expecter{mock: &_m.Mock}
+}
+
+// BeginPasskeyMFA provides a mock function with given fields: ctx, challenge, client, now
+func (_m *BeginPasskeyMFAService) BeginPasskeyMFA(ctx context.Context, challenge string, client models.ClientInfo, now time.Time) (*models.PasskeyRequestOptions, error) {
+	ret := _m.Called(ctx, challenge, client, now)
+
+	var r0 *models.PasskeyRequestOptions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ClientInfo, time.Time) (*models.PasskeyRequestOptions, error)); ok {
+		return rf(ctx, challenge, client, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ClientInfo, time.Time) *models.PasskeyRequestOptions); ok {
+		r0 = rf(ctx, challenge, client, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PasskeyRequestOptions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.ClientInfo, time.Time) error); ok {
+		r1 = rf(ctx, challenge, client, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BeginPasskeyMFAService_BeginPasskeyMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginPasskeyMFA'
+type BeginPasskeyMFAService_BeginPasskeyMFA_Call struct {
+	*mock.Call
+}
+
+// BeginPasskeyMFA is a helper method to define mock.On call
+//   - ctx context.Context
+//   - challenge string
+//   - client models.ClientInfo
+//   - now time.Time
+func (_e *BeginPasskeyMFAService_Expecter) BeginPasskeyMFA(ctx interface{}, challenge interface{}, client interface{}, now interface{}) *BeginPasskeyMFAService_BeginPasskeyMFA_Call {
+	return &BeginPasskeyMFAService_BeginPasskeyMFA_Call{Call: _e.mock.On("BeginPasskeyMFA", ctx, challenge, client, now)}
+}
+
+func (_c *BeginPasskeyMFAService_BeginPasskeyMFA_Call) Run(run func(ctx context.Context, challenge string, client models.ClientInfo, now time.Time)) *BeginPasskeyMFAService_BeginPasskeyMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.ClientInfo), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *BeginPasskeyMFAService_BeginPasskeyMFA_Call) Return(_a0 *models.PasskeyRequestOptions, _a1 error) *BeginPasskeyMFAService_BeginPasskeyMFA_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BeginPasskeyMFAService_BeginPasskeyMFA_Call) RunAndReturn(run func(context.Context, string, models.ClientInfo, time.Time) (*models.PasskeyRequestOptions, error)) *BeginPasskeyMFAService_BeginPasskeyMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBeginPasskeyMFAService creates a new instance of BeginPasskeyMFAService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBeginPasskeyMFAService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BeginPasskeyMFAService {
+	mock := &BeginPasskeyMFAService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
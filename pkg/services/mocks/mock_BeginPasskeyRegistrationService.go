@@ -0,0 +1,97 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// BeginPasskeyRegistrationService is an autogenerated mock type for the BeginPasskeyRegistrationService type
+type BeginPasskeyRegistrationService struct {
+	mock.Mock
+}
+
+type BeginPasskeyRegistrationService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BeginPasskeyRegistrationService) EXPECT() *BeginPasskeyRegistrationService_Expecter {
+	return &BeginPasskeyRegistrationService_Expecter{mock: &_m.Mock}
+}
+
+// BeginPasskeyRegistration provides a mock function with given fields: ctx, tokenRaw, password, client, now
+func (_m *BeginPasskeyRegistrationService) BeginPasskeyRegistration(ctx context.Context, tokenRaw string, password string, client models.ClientInfo, now time.Time) (*models.PasskeyCreationOptions, error) {
+	ret := _m.Called(ctx, tokenRaw, password, client, now)
+
+	var r0 *models.PasskeyCreationOptions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.ClientInfo, time.Time) (*models.PasskeyCreationOptions, error)); ok {
+		return rf(ctx, tokenRaw, password, client, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.ClientInfo, time.Time) *models.PasskeyCreationOptions); ok {
+		r0 = rf(ctx, tokenRaw, password, client, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PasskeyCreationOptions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.ClientInfo, time.Time) error); ok {
+		r1 = rf(ctx, tokenRaw, password, client, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BeginPasskeyRegistrationService_BeginPasskeyRegistration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginPasskeyRegistration'
+type BeginPasskeyRegistrationService_BeginPasskeyRegistration_Call struct {
+	*mock.Call
+}
+
+// BeginPasskeyRegistration is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - password string
+//   - client models.ClientInfo
+//   - now time.Time
+func (_e *BeginPasskeyRegistrationService_Expecter) BeginPasskeyRegistration(ctx interface{}, tokenRaw interface{}, password interface{}, client interface{}, now interface{}) *BeginPasskeyRegistrationService_BeginPasskeyRegistration_Call {
+	return &BeginPasskeyRegistrationService_BeginPasskeyRegistration_Call{Call: _e.mock.On("BeginPasskeyRegistration", ctx, tokenRaw, password, client, now)}
+}
+
+func (_c *BeginPasskeyRegistrationService_BeginPasskeyRegistration_Call) Run(run func(ctx context.Context, tokenRaw string, password string, client models.ClientInfo, now time.Time)) *BeginPasskeyRegistrationService_BeginPasskeyRegistration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(models.ClientInfo), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *BeginPasskeyRegistrationService_BeginPasskeyRegistration_Call) Return(_a0 *models.PasskeyCreationOptions, _a1 error) *BeginPasskeyRegistrationService_BeginPasskeyRegistration_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BeginPasskeyRegistrationService_BeginPasskeyRegistration_Call) RunAndReturn(run func(context.Context, string, string, models.ClientInfo, time.Time) (*models.PasskeyCreationOptions, error)) *BeginPasskeyRegistrationService_BeginPasskeyRegistration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBeginPasskeyRegistrationService creates a new instance of BeginPasskeyRegistrationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBeginPasskeyRegistrationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BeginPasskeyRegistrationService {
+	mock := &BeginPasskeyRegistrationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,85 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// CancelAccountDeletionService is an autogenerated mock type for the CancelAccountDeletionService type
+type CancelAccountDeletionService struct {
+	mock.Mock
+}
+
+type CancelAccountDeletionService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CancelAccountDeletionService) EXPECT() *CancelAccountDeletionService_Expecter {
+	return &CancelAccountDeletionService_Expecter{mock: &_m.Mock}
+}
+
+// CancelAccountDeletion provides a mock function with given fields: ctx, id, code, now
+func (_m *CancelAccountDeletionService) CancelAccountDeletion(ctx context.Context, id uuid.UUID, code string, now time.Time) error {
+	ret := _m.Called(ctx, id, code, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) error); ok {
+		r0 = rf(ctx, id, code, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CancelAccountDeletionService_CancelAccountDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelAccountDeletion'
+type CancelAccountDeletionService_CancelAccountDeletion_Call struct {
+	*mock.Call
+}
+
+// CancelAccountDeletion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - code string
+//   - now time.Time
+func (_e *CancelAccountDeletionService_Expecter) CancelAccountDeletion(ctx interface{}, id interface{}, code interface{}, now interface{}) *CancelAccountDeletionService_CancelAccountDeletion_Call {
+	return &CancelAccountDeletionService_CancelAccountDeletion_Call{Call: _e.mock.On("CancelAccountDeletion", ctx, id, code, now)}
+}
+
+func (_c *CancelAccountDeletionService_CancelAccountDeletion_Call) Run(run func(ctx context.Context, id uuid.UUID, code string, now time.Time)) *CancelAccountDeletionService_CancelAccountDeletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CancelAccountDeletionService_CancelAccountDeletion_Call) Return(_a0 error) *CancelAccountDeletionService_CancelAccountDeletion_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CancelAccountDeletionService_CancelAccountDeletion_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, time.Time) error) *CancelAccountDeletionService_CancelAccountDeletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCancelAccountDeletionService creates a new instance of CancelAccountDeletionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCancelAccountDeletionService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CancelAccountDeletionService {
+	mock := &CancelAccountDeletionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
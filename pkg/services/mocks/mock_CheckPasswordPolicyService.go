@@ -0,0 +1,81 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/auth-service/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CheckPasswordPolicyService is an autogenerated mock type for the CheckPasswordPolicyService type
+type CheckPasswordPolicyService struct {
+	mock.Mock
+}
+
+type CheckPasswordPolicyService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CheckPasswordPolicyService) EXPECT() *CheckPasswordPolicyService_Expecter {
+	return &CheckPasswordPolicyService_Expecter{mock: &_m.Mock}
+}
+
+// CheckPasswordPolicy provides a mock function with given fields: ctx, password, user
+func (_m *CheckPasswordPolicyService) CheckPasswordPolicy(ctx context.Context, password string, user services.PasswordUserInfo) error {
+	ret := _m.Called(ctx, password, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, services.PasswordUserInfo) error); ok {
+		r0 = rf(ctx, password, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckPasswordPolicyService_CheckPasswordPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckPasswordPolicy'
+type CheckPasswordPolicyService_CheckPasswordPolicy_Call struct {
+	*mock.Call
+}
+
+// CheckPasswordPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - password string
+//   - user services.PasswordUserInfo
+func (_e *CheckPasswordPolicyService_Expecter) CheckPasswordPolicy(ctx interface{}, password interface{}, user interface{}) *CheckPasswordPolicyService_CheckPasswordPolicy_Call {
+	return &CheckPasswordPolicyService_CheckPasswordPolicy_Call{Call: _e.mock.On("CheckPasswordPolicy", ctx, password, user)}
+}
+
+func (_c *CheckPasswordPolicyService_CheckPasswordPolicy_Call) Run(run func(ctx context.Context, password string, user services.PasswordUserInfo)) *CheckPasswordPolicyService_CheckPasswordPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(services.PasswordUserInfo))
+	})
+	return _c
+}
+
+func (_c *CheckPasswordPolicyService_CheckPasswordPolicy_Call) Return(_a0 error) *CheckPasswordPolicyService_CheckPasswordPolicy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CheckPasswordPolicyService_CheckPasswordPolicy_Call) RunAndReturn(run func(context.Context, string, services.PasswordUserInfo) error) *CheckPasswordPolicyService_CheckPasswordPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCheckPasswordPolicyService creates a new instance of CheckPasswordPolicyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCheckPasswordPolicyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CheckPasswordPolicyService {
+	mock := &CheckPasswordPolicyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,86 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+	time "time"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	services "github.com/a-novel/auth-service/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CheckStepUpService is an autogenerated mock type for the CheckStepUpService type
+type CheckStepUpService struct {
+	mock.Mock
+}
+
+type CheckStepUpService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CheckStepUpService) EXPECT() *CheckStepUpService_Expecter {
+	return &CheckStepUpService_Expecter{mock: &_m.Mock}
+}
+
+// CheckStepUp provides a mock function with given fields: ctx, token, operation, password, client, now
+func (_m *CheckStepUpService) CheckStepUp(ctx context.Context, token *models.UserToken, operation services.SensitiveOperation, password string, client models.ClientInfo, now time.Time) error {
+	ret := _m.Called(ctx, token, operation, password, client, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserToken, services.SensitiveOperation, string, models.ClientInfo, time.Time) error); ok {
+		r0 = rf(ctx, token, operation, password, client, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckStepUpService_CheckStepUp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckStepUp'
+type CheckStepUpService_CheckStepUp_Call struct {
+	*mock.Call
+}
+
+// CheckStepUp is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *models.UserToken
+//   - operation services.SensitiveOperation
+//   - password string
+//   - client models.ClientInfo
+//   - now time.Time
+func (_e *CheckStepUpService_Expecter) CheckStepUp(ctx interface{}, token interface{}, operation interface{}, password interface{}, client interface{}, now interface{}) *CheckStepUpService_CheckStepUp_Call {
+	return &CheckStepUpService_CheckStepUp_Call{Call: _e.mock.On("CheckStepUp", ctx, token, operation, password, client, now)}
+}
+
+func (_c *CheckStepUpService_CheckStepUp_Call) Run(run func(ctx context.Context, token *models.UserToken, operation services.SensitiveOperation, password string, client models.ClientInfo, now time.Time)) *CheckStepUpService_CheckStepUp_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.UserToken), args[2].(services.SensitiveOperation), args[3].(string), args[4].(models.ClientInfo), args[5].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CheckStepUpService_CheckStepUp_Call) Return(_a0 error) *CheckStepUpService_CheckStepUp_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CheckStepUpService_CheckStepUp_Call) RunAndReturn(run func(context.Context, *models.UserToken, services.SensitiveOperation, string, models.ClientInfo, time.Time) error) *CheckStepUpService_CheckStepUp_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCheckStepUpService creates a new instance of CheckStepUpService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCheckStepUpService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CheckStepUpService {
+	mock := &CheckStepUpService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,83 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ConfirmTOTPService is an autogenerated mock type for the ConfirmTOTPService type
+type ConfirmTOTPService struct {
+	mock.Mock
+}
+
+type ConfirmTOTPService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ConfirmTOTPService) EXPECT() *ConfirmTOTPService_Expecter {
+	return &ConfirmTOTPService_Expecter{mock: &_m.Mock}
+}
+
+// ConfirmTOTP provides a mock function with given fields: ctx, tokenRaw, code, now
+func (_m *ConfirmTOTPService) ConfirmTOTP(ctx context.Context, tokenRaw string, code string, now time.Time) error {
+	ret := _m.Called(ctx, tokenRaw, code, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, tokenRaw, code, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfirmTOTPService_ConfirmTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmTOTP'
+type ConfirmTOTPService_ConfirmTOTP_Call struct {
+	*mock.Call
+}
+
+// ConfirmTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - code string
+//   - now time.Time
+func (_e *ConfirmTOTPService_Expecter) ConfirmTOTP(ctx interface{}, tokenRaw interface{}, code interface{}, now interface{}) *ConfirmTOTPService_ConfirmTOTP_Call {
+	return &ConfirmTOTPService_ConfirmTOTP_Call{Call: _e.mock.On("ConfirmTOTP", ctx, tokenRaw, code, now)}
+}
+
+func (_c *ConfirmTOTPService_ConfirmTOTP_Call) Run(run func(ctx context.Context, tokenRaw string, code string, now time.Time)) *ConfirmTOTPService_ConfirmTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ConfirmTOTPService_ConfirmTOTP_Call) Return(_a0 error) *ConfirmTOTPService_ConfirmTOTP_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ConfirmTOTPService_ConfirmTOTP_Call) RunAndReturn(run func(context.Context, string, string, time.Time) error) *ConfirmTOTPService_ConfirmTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewConfirmTOTPService creates a new instance of ConfirmTOTPService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewConfirmTOTPService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ConfirmTOTPService {
+	mock := &ConfirmTOTPService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
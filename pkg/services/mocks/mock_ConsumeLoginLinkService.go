@@ -0,0 +1,99 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// ConsumeLoginLinkService is an autogenerated mock type for the ConsumeLoginLinkService type
+type ConsumeLoginLinkService struct {
+	mock.Mock
+}
+
+type ConsumeLoginLinkService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ConsumeLoginLinkService) EXPECT() *ConsumeLoginLinkService_Expecter {
+	return &ConsumeLoginLinkService_Expecter{mock: &_m.Mock}
+}
+
+// ConsumeLoginLink provides a mock function with given fields: ctx, id, code, client, now
+func (_m *ConsumeLoginLinkService) ConsumeLoginLink(ctx context.Context, id uuid.UUID, code string, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error) {
+	ret := _m.Called(ctx, id, code, client, now)
+
+	var r0 *models.UserTokenStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, models.ClientInfo, time.Time) (*models.UserTokenStatus, error)); ok {
+		return rf(ctx, id, code, client, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, models.ClientInfo, time.Time) *models.UserTokenStatus); ok {
+		r0 = rf(ctx, id, code, client, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserTokenStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, models.ClientInfo, time.Time) error); ok {
+		r1 = rf(ctx, id, code, client, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConsumeLoginLinkService_ConsumeLoginLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeLoginLink'
+type ConsumeLoginLinkService_ConsumeLoginLink_Call struct {
+	*mock.Call
+}
+
+// ConsumeLoginLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - code string
+//   - client models.ClientInfo
+//   - now time.Time
+func (_e *ConsumeLoginLinkService_Expecter) ConsumeLoginLink(ctx interface{}, id interface{}, code interface{}, client interface{}, now interface{}) *ConsumeLoginLinkService_ConsumeLoginLink_Call {
+	return &ConsumeLoginLinkService_ConsumeLoginLink_Call{Call: _e.mock.On("ConsumeLoginLink", ctx, id, code, client, now)}
+}
+
+func (_c *ConsumeLoginLinkService_ConsumeLoginLink_Call) Run(run func(ctx context.Context, id uuid.UUID, code string, client models.ClientInfo, now time.Time)) *ConsumeLoginLinkService_ConsumeLoginLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(models.ClientInfo), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ConsumeLoginLinkService_ConsumeLoginLink_Call) Return(_a0 *models.UserTokenStatus, _a1 error) *ConsumeLoginLinkService_ConsumeLoginLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ConsumeLoginLinkService_ConsumeLoginLink_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, models.ClientInfo, time.Time) (*models.UserTokenStatus, error)) *ConsumeLoginLinkService_ConsumeLoginLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewConsumeLoginLinkService creates a new instance of ConsumeLoginLinkService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewConsumeLoginLinkService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ConsumeLoginLinkService {
+	mock := &ConsumeLoginLinkService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
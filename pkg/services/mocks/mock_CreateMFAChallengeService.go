@@ -0,0 +1,95 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// CreateMFAChallengeService is an autogenerated mock type for the CreateMFAChallengeService type
+type CreateMFAChallengeService struct {
+	mock.Mock
+}
+
+type CreateMFAChallengeService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CreateMFAChallengeService) EXPECT() *CreateMFAChallengeService_Expecter {
+	return &CreateMFAChallengeService_Expecter{mock: &_m.Mock}
+}
+
+// CreateMFAChallenge provides a mock function with given fields: ctx, userID, id, now
+func (_m *CreateMFAChallengeService) CreateMFAChallenge(ctx context.Context, userID uuid.UUID, id uuid.UUID, now time.Time) (string, error) {
+	ret := _m.Called(ctx, userID, id, now)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) (string, error)); ok {
+		return rf(ctx, userID, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) string); ok {
+		r0 = rf(ctx, userID, id, now)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, userID, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateMFAChallengeService_CreateMFAChallenge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMFAChallenge'
+type CreateMFAChallengeService_CreateMFAChallenge_Call struct {
+	*mock.Call
+}
+
+// CreateMFAChallenge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *CreateMFAChallengeService_Expecter) CreateMFAChallenge(ctx interface{}, userID interface{}, id interface{}, now interface{}) *CreateMFAChallengeService_CreateMFAChallenge_Call {
+	return &CreateMFAChallengeService_CreateMFAChallenge_Call{Call: _e.mock.On("CreateMFAChallenge", ctx, userID, id, now)}
+}
+
+func (_c *CreateMFAChallengeService_CreateMFAChallenge_Call) Run(run func(ctx context.Context, userID uuid.UUID, id uuid.UUID, now time.Time)) *CreateMFAChallengeService_CreateMFAChallenge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CreateMFAChallengeService_CreateMFAChallenge_Call) Return(_a0 string, _a1 error) *CreateMFAChallengeService_CreateMFAChallenge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CreateMFAChallengeService_CreateMFAChallenge_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, time.Time) (string, error)) *CreateMFAChallengeService_CreateMFAChallenge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCreateMFAChallengeService creates a new instance of CreateMFAChallengeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCreateMFAChallengeService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CreateMFAChallengeService {
+	mock := &CreateMFAChallengeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
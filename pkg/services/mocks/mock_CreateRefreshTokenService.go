@@ -0,0 +1,96 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// CreateRefreshTokenService is an autogenerated mock type for the CreateRefreshTokenService type
+type CreateRefreshTokenService struct {
+	mock.Mock
+}
+
+type CreateRefreshTokenService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CreateRefreshTokenService) EXPECT() *CreateRefreshTokenService_Expecter {
+	return &CreateRefreshTokenService_Expecter{mock: &_m.Mock}
+}
+
+// CreateRefreshToken provides a mock function with given fields: ctx, data, id, now
+func (_m *CreateRefreshTokenService) CreateRefreshToken(ctx context.Context, data models.UserTokenPayload, id uuid.UUID, now time.Time) (string, error) {
+	ret := _m.Called(ctx, data, id, now)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserTokenPayload, uuid.UUID, time.Time) (string, error)); ok {
+		return rf(ctx, data, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserTokenPayload, uuid.UUID, time.Time) string); ok {
+		r0 = rf(ctx, data, id, now)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UserTokenPayload, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, data, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRefreshTokenService_CreateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRefreshToken'
+type CreateRefreshTokenService_CreateRefreshToken_Call struct {
+	*mock.Call
+}
+
+// CreateRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data models.UserTokenPayload
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *CreateRefreshTokenService_Expecter) CreateRefreshToken(ctx interface{}, data interface{}, id interface{}, now interface{}) *CreateRefreshTokenService_CreateRefreshToken_Call {
+	return &CreateRefreshTokenService_CreateRefreshToken_Call{Call: _e.mock.On("CreateRefreshToken", ctx, data, id, now)}
+}
+
+func (_c *CreateRefreshTokenService_CreateRefreshToken_Call) Run(run func(ctx context.Context, data models.UserTokenPayload, id uuid.UUID, now time.Time)) *CreateRefreshTokenService_CreateRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.UserTokenPayload), args[2].(uuid.UUID), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CreateRefreshTokenService_CreateRefreshToken_Call) Return(_a0 string, _a1 error) *CreateRefreshTokenService_CreateRefreshToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CreateRefreshTokenService_CreateRefreshToken_Call) RunAndReturn(run func(context.Context, models.UserTokenPayload, uuid.UUID, time.Time) (string, error)) *CreateRefreshTokenService_CreateRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCreateRefreshTokenService creates a new instance of CreateRefreshTokenService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCreateRefreshTokenService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CreateRefreshTokenService {
+	mock := &CreateRefreshTokenService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,98 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// CreateSessionService is an autogenerated mock type for the CreateSessionService type
+type CreateSessionService struct {
+	mock.Mock
+}
+
+type CreateSessionService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CreateSessionService) EXPECT() *CreateSessionService_Expecter {
+	return &CreateSessionService_Expecter{mock: &_m.Mock}
+}
+
+// CreateSession provides a mock function with given fields: ctx, userID, client, now
+func (_m *CreateSessionService) CreateSession(ctx context.Context, userID uuid.UUID, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error) {
+	ret := _m.Called(ctx, userID, client, now)
+
+	var r0 *models.UserTokenStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.ClientInfo, time.Time) (*models.UserTokenStatus, error)); ok {
+		return rf(ctx, userID, client, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.ClientInfo, time.Time) *models.UserTokenStatus); ok {
+		r0 = rf(ctx, userID, client, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserTokenStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, models.ClientInfo, time.Time) error); ok {
+		r1 = rf(ctx, userID, client, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateSessionService_CreateSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSession'
+type CreateSessionService_CreateSession_Call struct {
+	*mock.Call
+}
+
+// CreateSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - client models.ClientInfo
+//   - now time.Time
+func (_e *CreateSessionService_Expecter) CreateSession(ctx interface{}, userID interface{}, client interface{}, now interface{}) *CreateSessionService_CreateSession_Call {
+	return &CreateSessionService_CreateSession_Call{Call: _e.mock.On("CreateSession", ctx, userID, client, now)}
+}
+
+func (_c *CreateSessionService_CreateSession_Call) Run(run func(ctx context.Context, userID uuid.UUID, client models.ClientInfo, now time.Time)) *CreateSessionService_CreateSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(models.ClientInfo), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CreateSessionService_CreateSession_Call) Return(_a0 *models.UserTokenStatus, _a1 error) *CreateSessionService_CreateSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CreateSessionService_CreateSession_Call) RunAndReturn(run func(context.Context, uuid.UUID, models.ClientInfo, time.Time) (*models.UserTokenStatus, error)) *CreateSessionService_CreateSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCreateSessionService creates a new instance of CreateSessionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCreateSessionService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CreateSessionService {
+	mock := &CreateSessionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
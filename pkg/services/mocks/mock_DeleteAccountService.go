@@ -0,0 +1,97 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// DeleteAccountService is an autogenerated mock type for the DeleteAccountService type
+type DeleteAccountService struct {
+	mock.Mock
+}
+
+type DeleteAccountService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DeleteAccountService) EXPECT() *DeleteAccountService_Expecter {
+	return &DeleteAccountService_Expecter{mock: &_m.Mock}
+}
+
+// DeleteAccount provides a mock function with given fields: ctx, tokenRaw, password, client, now
+func (_m *DeleteAccountService) DeleteAccount(ctx context.Context, tokenRaw string, password string, client models.ClientInfo, now time.Time) (func() error, error) {
+	ret := _m.Called(ctx, tokenRaw, password, client, now)
+
+	var r0 func() error
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.ClientInfo, time.Time) (func() error, error)); ok {
+		return rf(ctx, tokenRaw, password, client, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.ClientInfo, time.Time) func() error); ok {
+		r0 = rf(ctx, tokenRaw, password, client, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func() error)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.ClientInfo, time.Time) error); ok {
+		r1 = rf(ctx, tokenRaw, password, client, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAccountService_DeleteAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAccount'
+type DeleteAccountService_DeleteAccount_Call struct {
+	*mock.Call
+}
+
+// DeleteAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - password string
+//   - client models.ClientInfo
+//   - now time.Time
+func (_e *DeleteAccountService_Expecter) DeleteAccount(ctx interface{}, tokenRaw interface{}, password interface{}, client interface{}, now interface{}) *DeleteAccountService_DeleteAccount_Call {
+	return &DeleteAccountService_DeleteAccount_Call{Call: _e.mock.On("DeleteAccount", ctx, tokenRaw, password, client, now)}
+}
+
+func (_c *DeleteAccountService_DeleteAccount_Call) Run(run func(ctx context.Context, tokenRaw string, password string, client models.ClientInfo, now time.Time)) *DeleteAccountService_DeleteAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(models.ClientInfo), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *DeleteAccountService_DeleteAccount_Call) Return(_a0 func() error, _a1 error) *DeleteAccountService_DeleteAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DeleteAccountService_DeleteAccount_Call) RunAndReturn(run func(context.Context, string, string, models.ClientInfo, time.Time) (func() error, error)) *DeleteAccountService_DeleteAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDeleteAccountService creates a new instance of DeleteAccountService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeleteAccountService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeleteAccountService {
+	mock := &DeleteAccountService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,86 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// DisableTOTPService is an autogenerated mock type for the DisableTOTPService type
+type DisableTOTPService struct {
+	mock.Mock
+}
+
+type DisableTOTPService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DisableTOTPService) EXPECT() *DisableTOTPService_Expecter {
+	return &DisableTOTPService_Expecter{mock: &_m.Mock}
+}
+
+// DisableTOTP provides a mock function with given fields: ctx, tokenRaw, code, password, client, now
+func (_m *DisableTOTPService) DisableTOTP(ctx context.Context, tokenRaw string, code string, password string, client models.ClientInfo, now time.Time) error {
+	ret := _m.Called(ctx, tokenRaw, code, password, client, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, models.ClientInfo, time.Time) error); ok {
+		r0 = rf(ctx, tokenRaw, code, password, client, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisableTOTPService_DisableTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableTOTP'
+type DisableTOTPService_DisableTOTP_Call struct {
+	*mock.Call
+}
+
+// DisableTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - code string
+//   - password string
+//   - client models.ClientInfo
+//   - now time.Time
+func (_e *DisableTOTPService_Expecter) DisableTOTP(ctx interface{}, tokenRaw interface{}, code interface{}, password interface{}, client interface{}, now interface{}) *DisableTOTPService_DisableTOTP_Call {
+	return &DisableTOTPService_DisableTOTP_Call{Call: _e.mock.On("DisableTOTP", ctx, tokenRaw, code, password, client, now)}
+}
+
+func (_c *DisableTOTPService_DisableTOTP_Call) Run(run func(ctx context.Context, tokenRaw string, code string, password string, client models.ClientInfo, now time.Time)) *DisableTOTPService_DisableTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(models.ClientInfo), args[5].(time.Time))
+	})
+	return _c
+}
+
+func (_c *DisableTOTPService_DisableTOTP_Call) Return(_a0 error) *DisableTOTPService_DisableTOTP_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DisableTOTPService_DisableTOTP_Call) RunAndReturn(run func(context.Context, string, string, string, models.ClientInfo, time.Time) error) *DisableTOTPService_DisableTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDisableTOTPService creates a new instance of DisableTOTPService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDisableTOTPService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DisableTOTPService {
+	mock := &DisableTOTPService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// DownloadDataExportService is an autogenerated mock type for the DownloadDataExportService type
+type DownloadDataExportService struct {
+	mock.Mock
+}
+
+type DownloadDataExportService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DownloadDataExportService) EXPECT() *DownloadDataExportService_Expecter {
+	return &DownloadDataExportService_Expecter{mock: &_m.Mock}
+}
+
+// DownloadDataExport provides a mock function with given fields: ctx, id, code, now
+func (_m *DownloadDataExportService) DownloadDataExport(ctx context.Context, id uuid.UUID, code string, now time.Time) ([]byte, error) {
+	ret := _m.Called(ctx, id, code, now)
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) ([]byte, error)); ok {
+		return rf(ctx, id, code, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) []byte); ok {
+		r0 = rf(ctx, id, code, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, time.Time) error); ok {
+		r1 = rf(ctx, id, code, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DownloadDataExportService_DownloadDataExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DownloadDataExport'
+type DownloadDataExportService_DownloadDataExport_Call struct {
+	*mock.Call
+}
+
+// DownloadDataExport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - code string
+//   - now time.Time
+func (_e *DownloadDataExportService_Expecter) DownloadDataExport(ctx interface{}, id interface{}, code interface{}, now interface{}) *DownloadDataExportService_DownloadDataExport_Call {
+	return &DownloadDataExportService_DownloadDataExport_Call{Call: _e.mock.On("DownloadDataExport", ctx, id, code, now)}
+}
+
+func (_c *DownloadDataExportService_DownloadDataExport_Call) Run(run func(ctx context.Context, id uuid.UUID, code string, now time.Time)) *DownloadDataExportService_DownloadDataExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *DownloadDataExportService_DownloadDataExport_Call) Return(_a0 []byte, _a1 error) *DownloadDataExportService_DownloadDataExport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DownloadDataExportService_DownloadDataExport_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, time.Time) ([]byte, error)) *DownloadDataExportService_DownloadDataExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDownloadDataExportService creates a new instance of DownloadDataExportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDownloadDataExportService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DownloadDataExportService {
+	mock := &DownloadDataExportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
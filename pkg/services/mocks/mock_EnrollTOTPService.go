@@ -0,0 +1,97 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// EnrollTOTPService is an autogenerated mock type for the EnrollTOTPService type
+type EnrollTOTPService struct {
+	mock.Mock
+}
+
+type EnrollTOTPService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *EnrollTOTPService) EXPECT() *EnrollTOTPService_Expecter {
+	return &EnrollTOTPService_Expecter{mock: &_m.Mock}
+}
+
+// EnrollTOTP provides a mock function with given fields: ctx, tokenRaw, password, client, now
+func (_m *EnrollTOTPService) EnrollTOTP(ctx context.Context, tokenRaw string, password string, client models.ClientInfo, now time.Time) (*models.TOTPEnrollment, error) {
+	ret := _m.Called(ctx, tokenRaw, password, client, now)
+
+	var r0 *models.TOTPEnrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.ClientInfo, time.Time) (*models.TOTPEnrollment, error)); ok {
+		return rf(ctx, tokenRaw, password, client, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.ClientInfo, time.Time) *models.TOTPEnrollment); ok {
+		r0 = rf(ctx, tokenRaw, password, client, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TOTPEnrollment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.ClientInfo, time.Time) error); ok {
+		r1 = rf(ctx, tokenRaw, password, client, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnrollTOTPService_EnrollTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollTOTP'
+type EnrollTOTPService_EnrollTOTP_Call struct {
+	*mock.Call
+}
+
+// EnrollTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - password string
+//   - client models.ClientInfo
+//   - now time.Time
+func (_e *EnrollTOTPService_Expecter) EnrollTOTP(ctx interface{}, tokenRaw interface{}, password interface{}, client interface{}, now interface{}) *EnrollTOTPService_EnrollTOTP_Call {
+	return &EnrollTOTPService_EnrollTOTP_Call{Call: _e.mock.On("EnrollTOTP", ctx, tokenRaw, password, client, now)}
+}
+
+func (_c *EnrollTOTPService_EnrollTOTP_Call) Run(run func(ctx context.Context, tokenRaw string, password string, client models.ClientInfo, now time.Time)) *EnrollTOTPService_EnrollTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(models.ClientInfo), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *EnrollTOTPService_EnrollTOTP_Call) Return(_a0 *models.TOTPEnrollment, _a1 error) *EnrollTOTPService_EnrollTOTP_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EnrollTOTPService_EnrollTOTP_Call) RunAndReturn(run func(context.Context, string, string, models.ClientInfo, time.Time) (*models.TOTPEnrollment, error)) *EnrollTOTPService_EnrollTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewEnrollTOTPService creates a new instance of EnrollTOTPService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEnrollTOTPService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EnrollTOTPService {
+	mock := &EnrollTOTPService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,103 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ExportUserDataService is an autogenerated mock type for the ExportUserDataService type
+type ExportUserDataService struct {
+	mock.Mock
+}
+
+type ExportUserDataService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ExportUserDataService) EXPECT() *ExportUserDataService_Expecter {
+	return &ExportUserDataService_Expecter{mock: &_m.Mock}
+}
+
+// ExportUserData provides a mock function with given fields: ctx, tokenRaw, now
+func (_m *ExportUserDataService) ExportUserData(ctx context.Context, tokenRaw string, now time.Time) ([]byte, func() error, error) {
+	ret := _m.Called(ctx, tokenRaw, now)
+
+	var r0 []byte
+	var r1 func() error
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]byte, func() error, error)); ok {
+		return rf(ctx, tokenRaw, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []byte); ok {
+		r0 = rf(ctx, tokenRaw, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) func() error); ok {
+		r1 = rf(ctx, tokenRaw, now)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func() error)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Time) error); ok {
+		r2 = rf(ctx, tokenRaw, now)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ExportUserDataService_ExportUserData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportUserData'
+type ExportUserDataService_ExportUserData_Call struct {
+	*mock.Call
+}
+
+// ExportUserData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - now time.Time
+func (_e *ExportUserDataService_Expecter) ExportUserData(ctx interface{}, tokenRaw interface{}, now interface{}) *ExportUserDataService_ExportUserData_Call {
+	return &ExportUserDataService_ExportUserData_Call{Call: _e.mock.On("ExportUserData", ctx, tokenRaw, now)}
+}
+
+func (_c *ExportUserDataService_ExportUserData_Call) Run(run func(ctx context.Context, tokenRaw string, now time.Time)) *ExportUserDataService_ExportUserData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ExportUserDataService_ExportUserData_Call) Return(_a0 []byte, _a1 func() error, _a2 error) *ExportUserDataService_ExportUserData_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *ExportUserDataService_ExportUserData_Call) RunAndReturn(run func(context.Context, string, time.Time) ([]byte, func() error, error)) *ExportUserDataService_ExportUserData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewExportUserDataService creates a new instance of ExportUserDataService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExportUserDataService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExportUserDataService {
+	mock := &ExportUserDataService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// FinishPasskeyLoginService is an autogenerated mock type for the FinishPasskeyLoginService type
+type FinishPasskeyLoginService struct {
+	mock.Mock
+}
+
+type FinishPasskeyLoginService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FinishPasskeyLoginService) EXPECT() *FinishPasskeyLoginService_Expecter {
+	return &FinishPasskeyLoginService_Expecter{mock: &_m.Mock}
+}
+
+// FinishPasskeyLogin provides a mock function with given fields: ctx, form, client, now
+func (_m *FinishPasskeyLoginService) FinishPasskeyLogin(ctx context.Context, form models.PasskeyAssertionForm, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error) {
+	ret := _m.Called(ctx, form, client, now)
+
+	var r0 *models.UserTokenStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.PasskeyAssertionForm, models.ClientInfo, time.Time) (*models.UserTokenStatus, error)); ok {
+		return rf(ctx, form, client, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.PasskeyAssertionForm, models.ClientInfo, time.Time) *models.UserTokenStatus); ok {
+		r0 = rf(ctx, form, client, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserTokenStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.PasskeyAssertionForm, models.ClientInfo, time.Time) error); ok {
+		r1 = rf(ctx, form, client, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FinishPasskeyLoginService_FinishPasskeyLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishPasskeyLogin'
+type FinishPasskeyLoginService_FinishPasskeyLogin_Call struct {
+	*mock.Call
+}
+
+// FinishPasskeyLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - form models.PasskeyAssertionForm
+//   - client models.ClientInfo
+//   - now time.Time
+func (_e *FinishPasskeyLoginService_Expecter) FinishPasskeyLogin(ctx interface{}, form interface{}, client interface{}, now interface{}) *FinishPasskeyLoginService_FinishPasskeyLogin_Call {
+	return &FinishPasskeyLoginService_FinishPasskeyLogin_Call{Call: _e.mock.On("FinishPasskeyLogin", ctx, form, client, now)}
+}
+
+func (_c *FinishPasskeyLoginService_FinishPasskeyLogin_Call) Run(run func(ctx context.Context, form models.PasskeyAssertionForm, client models.ClientInfo, now time.Time)) *FinishPasskeyLoginService_FinishPasskeyLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.PasskeyAssertionForm), args[2].(models.ClientInfo), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *FinishPasskeyLoginService_FinishPasskeyLogin_Call) Return(_a0 *models.UserTokenStatus, _a1 error) *FinishPasskeyLoginService_FinishPasskeyLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FinishPasskeyLoginService_FinishPasskeyLogin_Call) RunAndReturn(run func(context.Context, models.PasskeyAssertionForm, models.ClientInfo, time.Time) (*models.UserTokenStatus, error)) *FinishPasskeyLoginService_FinishPasskeyLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFinishPasskeyLoginService creates a new instance of FinishPasskeyLoginService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFinishPasskeyLoginService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FinishPasskeyLoginService {
+	mock := &FinishPasskeyLoginService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// FinishPasskeyRegistrationService is an autogenerated mock type for the FinishPasskeyRegistrationService type
+type FinishPasskeyRegistrationService struct {
+	mock.Mock
+}
+
+type FinishPasskeyRegistrationService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FinishPasskeyRegistrationService) EXPECT() *FinishPasskeyRegistrationService_Expecter {
+	return &FinishPasskeyRegistrationService_Expecter{mock: &_m.Mock}
+}
+
+// FinishPasskeyRegistration provides a mock function with given fields: ctx, tokenRaw, form, now
+func (_m *FinishPasskeyRegistrationService) FinishPasskeyRegistration(ctx context.Context, tokenRaw string, form models.PasskeyAttestationForm, now time.Time) error {
+	ret := _m.Called(ctx, tokenRaw, form, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PasskeyAttestationForm, time.Time) error); ok {
+		r0 = rf(ctx, tokenRaw, form, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FinishPasskeyRegistrationService_FinishPasskeyRegistration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishPasskeyRegistration'
+type FinishPasskeyRegistrationService_FinishPasskeyRegistration_Call struct {
+	*mock.Call
+}
+
+// FinishPasskeyRegistration is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - form models.PasskeyAttestationForm
+//   - now time.Time
+func (_e *FinishPasskeyRegistrationService_Expecter) FinishPasskeyRegistration(ctx interface{}, tokenRaw interface{}, form interface{}, now interface{}) *FinishPasskeyRegistrationService_FinishPasskeyRegistration_Call {
+	return &FinishPasskeyRegistrationService_FinishPasskeyRegistration_Call{Call: _e.mock.On("FinishPasskeyRegistration", ctx, tokenRaw, form, now)}
+}
+
+func (_c *FinishPasskeyRegistrationService_FinishPasskeyRegistration_Call) Run(run func(ctx context.Context, tokenRaw string, form models.PasskeyAttestationForm, now time.Time)) *FinishPasskeyRegistrationService_FinishPasskeyRegistration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.PasskeyAttestationForm), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *FinishPasskeyRegistrationService_FinishPasskeyRegistration_Call) Return(_a0 error) *FinishPasskeyRegistrationService_FinishPasskeyRegistration_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FinishPasskeyRegistrationService_FinishPasskeyRegistration_Call) RunAndReturn(run func(context.Context, string, models.PasskeyAttestationForm, time.Time) error) *FinishPasskeyRegistrationService_FinishPasskeyRegistration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFinishPasskeyRegistrationService creates a new instance of FinishPasskeyRegistrationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFinishPasskeyRegistrationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FinishPasskeyRegistrationService {
+	mock := &FinishPasskeyRegistrationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
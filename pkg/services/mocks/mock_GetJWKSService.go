@@ -0,0 +1,91 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// GetJWKSService is an autogenerated mock type for the GetJWKSService type
+type GetJWKSService struct {
+	mock.Mock
+}
+
+type GetJWKSService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *GetJWKSService) EXPECT() *GetJWKSService_Expecter {
+	return &GetJWKSService_Expecter{mock: &_m.Mock}
+}
+
+// GetJWKS provides a mock function with given fields: ctx
+func (_m *GetJWKSService) GetJWKS(ctx context.Context) (*models.JSONWebKeySet, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *models.JSONWebKeySet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.JSONWebKeySet, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *models.JSONWebKeySet); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JSONWebKeySet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJWKSService_GetJWKS_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJWKS'
+type GetJWKSService_GetJWKS_Call struct {
+	*mock.Call
+}
+
+// GetJWKS is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *GetJWKSService_Expecter) GetJWKS(ctx interface{}) *GetJWKSService_GetJWKS_Call {
+	return &GetJWKSService_GetJWKS_Call{Call: _e.mock.On("GetJWKS", ctx)}
+}
+
+func (_c *GetJWKSService_GetJWKS_Call) Run(run func(ctx context.Context)) *GetJWKSService_GetJWKS_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *GetJWKSService_GetJWKS_Call) Return(_a0 *models.JSONWebKeySet, _a1 error) *GetJWKSService_GetJWKS_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GetJWKSService_GetJWKS_Call) RunAndReturn(run func(context.Context) (*models.JSONWebKeySet, error)) *GetJWKSService_GetJWKS_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewGetJWKSService creates a new instance of GetJWKSService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGetJWKSService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GetJWKSService {
+	mock := &GetJWKSService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
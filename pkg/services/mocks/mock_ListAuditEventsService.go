@@ -0,0 +1,99 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ListAuditEventsService is an autogenerated mock type for the ListAuditEventsService type
+type ListAuditEventsService struct {
+	mock.Mock
+}
+
+type ListAuditEventsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ListAuditEventsService) EXPECT() *ListAuditEventsService_Expecter {
+	return &ListAuditEventsService_Expecter{mock: &_m.Mock}
+}
+
+// ListAuditEvents provides a mock function with given fields: ctx, query
+func (_m *ListAuditEventsService) ListAuditEvents(ctx context.Context, query models.ListAuditEventsQuery) ([]*models.AuditEvent, int, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*models.AuditEvent
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ListAuditEventsQuery) ([]*models.AuditEvent, int, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ListAuditEventsQuery) []*models.AuditEvent); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AuditEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ListAuditEventsQuery) int); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, models.ListAuditEventsQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListAuditEventsService_ListAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuditEvents'
+type ListAuditEventsService_ListAuditEvents_Call struct {
+	*mock.Call
+}
+
+// ListAuditEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query models.ListAuditEventsQuery
+func (_e *ListAuditEventsService_Expecter) ListAuditEvents(ctx interface{}, query interface{}) *ListAuditEventsService_ListAuditEvents_Call {
+	return &ListAuditEventsService_ListAuditEvents_Call{Call: _e.mock.On("ListAuditEvents", ctx, query)}
+}
+
+func (_c *ListAuditEventsService_ListAuditEvents_Call) Run(run func(ctx context.Context, query models.ListAuditEventsQuery)) *ListAuditEventsService_ListAuditEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.ListAuditEventsQuery))
+	})
+	return _c
+}
+
+func (_c *ListAuditEventsService_ListAuditEvents_Call) Return(_a0 []*models.AuditEvent, _a1 int, _a2 error) *ListAuditEventsService_ListAuditEvents_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *ListAuditEventsService_ListAuditEvents_Call) RunAndReturn(run func(context.Context, models.ListAuditEventsQuery) ([]*models.AuditEvent, int, error)) *ListAuditEventsService_ListAuditEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewListAuditEventsService creates a new instance of ListAuditEventsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewListAuditEventsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ListAuditEventsService {
+	mock := &ListAuditEventsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
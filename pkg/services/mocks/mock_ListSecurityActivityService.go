@@ -0,0 +1,104 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ListSecurityActivityService is an autogenerated mock type for the ListSecurityActivityService type
+type ListSecurityActivityService struct {
+	mock.Mock
+}
+
+type ListSecurityActivityService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ListSecurityActivityService) EXPECT() *ListSecurityActivityService_Expecter {
+	return &ListSecurityActivityService_Expecter{mock: &_m.Mock}
+}
+
+// ListSecurityActivity provides a mock function with given fields: ctx, tokenRaw, limit, offset, now
+func (_m *ListSecurityActivityService) ListSecurityActivity(ctx context.Context, tokenRaw string, limit int, offset int, now time.Time) ([]*models.SecurityActivity, int, error) {
+	ret := _m.Called(ctx, tokenRaw, limit, offset, now)
+
+	var r0 []*models.SecurityActivity
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int, time.Time) ([]*models.SecurityActivity, int, error)); ok {
+		return rf(ctx, tokenRaw, limit, offset, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int, time.Time) []*models.SecurityActivity); ok {
+		r0 = rf(ctx, tokenRaw, limit, offset, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SecurityActivity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int, time.Time) int); ok {
+		r1 = rf(ctx, tokenRaw, limit, offset, now)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int, time.Time) error); ok {
+		r2 = rf(ctx, tokenRaw, limit, offset, now)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListSecurityActivityService_ListSecurityActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSecurityActivity'
+type ListSecurityActivityService_ListSecurityActivity_Call struct {
+	*mock.Call
+}
+
+// ListSecurityActivity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - limit int
+//   - offset int
+//   - now time.Time
+func (_e *ListSecurityActivityService_Expecter) ListSecurityActivity(ctx interface{}, tokenRaw interface{}, limit interface{}, offset interface{}, now interface{}) *ListSecurityActivityService_ListSecurityActivity_Call {
+	return &ListSecurityActivityService_ListSecurityActivity_Call{Call: _e.mock.On("ListSecurityActivity", ctx, tokenRaw, limit, offset, now)}
+}
+
+func (_c *ListSecurityActivityService_ListSecurityActivity_Call) Run(run func(ctx context.Context, tokenRaw string, limit int, offset int, now time.Time)) *ListSecurityActivityService_ListSecurityActivity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ListSecurityActivityService_ListSecurityActivity_Call) Return(_a0 []*models.SecurityActivity, _a1 int, _a2 error) *ListSecurityActivityService_ListSecurityActivity_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *ListSecurityActivityService_ListSecurityActivity_Call) RunAndReturn(run func(context.Context, string, int, int, time.Time) ([]*models.SecurityActivity, int, error)) *ListSecurityActivityService_ListSecurityActivity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewListSecurityActivityService creates a new instance of ListSecurityActivityService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewListSecurityActivityService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ListSecurityActivityService {
+	mock := &ListSecurityActivityService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ListSessionsService is an autogenerated mock type for the ListSessionsService type
+type ListSessionsService struct {
+	mock.Mock
+}
+
+type ListSessionsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ListSessionsService) EXPECT() *ListSessionsService_Expecter {
+	return &ListSessionsService_Expecter{mock: &_m.Mock}
+}
+
+// ListSessions provides a mock function with given fields: ctx, tokenRaw, now
+func (_m *ListSessionsService) ListSessions(ctx context.Context, tokenRaw string, now time.Time) ([]*models.Session, error) {
+	ret := _m.Called(ctx, tokenRaw, now)
+
+	var r0 []*models.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]*models.Session, error)); ok {
+		return rf(ctx, tokenRaw, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []*models.Session); ok {
+		r0 = rf(ctx, tokenRaw, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, tokenRaw, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSessionsService_ListSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSessions'
+type ListSessionsService_ListSessions_Call struct {
+	*mock.Call
+}
+
+// ListSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - now time.Time
+func (_e *ListSessionsService_Expecter) ListSessions(ctx interface{}, tokenRaw interface{}, now interface{}) *ListSessionsService_ListSessions_Call {
+	return &ListSessionsService_ListSessions_Call{Call: _e.mock.On("ListSessions", ctx, tokenRaw, now)}
+}
+
+func (_c *ListSessionsService_ListSessions_Call) Run(run func(ctx context.Context, tokenRaw string, now time.Time)) *ListSessionsService_ListSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ListSessionsService_ListSessions_Call) Return(_a0 []*models.Session, _a1 error) *ListSessionsService_ListSessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ListSessionsService_ListSessions_Call) RunAndReturn(run func(context.Context, string, time.Time) ([]*models.Session, error)) *ListSessionsService_ListSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewListSessionsService creates a new instance of ListSessionsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewListSessionsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ListSessionsService {
+	mock := &ListSessionsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,103 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ListSuspendedUsersService is an autogenerated mock type for the ListSuspendedUsersService type
+type ListSuspendedUsersService struct {
+	mock.Mock
+}
+
+type ListSuspendedUsersService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ListSuspendedUsersService) EXPECT() *ListSuspendedUsersService_Expecter {
+	return &ListSuspendedUsersService_Expecter{mock: &_m.Mock}
+}
+
+// ListSuspendedUsers provides a mock function with given fields: ctx, limit, offset, now
+func (_m *ListSuspendedUsersService) ListSuspendedUsers(ctx context.Context, limit int, offset int, now time.Time) ([]*models.Suspension, int, error) {
+	ret := _m.Called(ctx, limit, offset, now)
+
+	var r0 []*models.Suspension
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, time.Time) ([]*models.Suspension, int, error)); ok {
+		return rf(ctx, limit, offset, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, time.Time) []*models.Suspension); ok {
+		r0 = rf(ctx, limit, offset, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Suspension)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, time.Time) int); ok {
+		r1 = rf(ctx, limit, offset, now)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, time.Time) error); ok {
+		r2 = rf(ctx, limit, offset, now)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListSuspendedUsersService_ListSuspendedUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSuspendedUsers'
+type ListSuspendedUsersService_ListSuspendedUsers_Call struct {
+	*mock.Call
+}
+
+// ListSuspendedUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - offset int
+//   - now time.Time
+func (_e *ListSuspendedUsersService_Expecter) ListSuspendedUsers(ctx interface{}, limit interface{}, offset interface{}, now interface{}) *ListSuspendedUsersService_ListSuspendedUsers_Call {
+	return &ListSuspendedUsersService_ListSuspendedUsers_Call{Call: _e.mock.On("ListSuspendedUsers", ctx, limit, offset, now)}
+}
+
+func (_c *ListSuspendedUsersService_ListSuspendedUsers_Call) Run(run func(ctx context.Context, limit int, offset int, now time.Time)) *ListSuspendedUsersService_ListSuspendedUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ListSuspendedUsersService_ListSuspendedUsers_Call) Return(_a0 []*models.Suspension, _a1 int, _a2 error) *ListSuspendedUsersService_ListSuspendedUsers_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *ListSuspendedUsersService_ListSuspendedUsers_Call) RunAndReturn(run func(context.Context, int, int, time.Time) ([]*models.Suspension, int, error)) *ListSuspendedUsersService_ListSuspendedUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewListSuspendedUsersService creates a new instance of ListSuspendedUsersService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewListSuspendedUsersService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ListSuspendedUsersService {
+	mock := &ListSuspendedUsersService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
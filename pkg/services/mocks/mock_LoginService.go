@@ -24,25 +24,25 @@ func (_m *LoginService) EXPECT() *LoginService_Expecter {
 	return &LoginService_Expecter{mock: &_m.Mock}
 }
 
-// Login provides a mock function with given fields: ctx, email, password, now
-func (_m *LoginService) Login(ctx context.Context, email string, password string, now time.Time) (*models.UserTokenStatus, error) {
-	ret := _m.Called(ctx, email, password, now)
+// Login provides a mock function with given fields: ctx, email, password, client, now
+func (_m *LoginService) Login(ctx context.Context, email string, password string, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error) {
+	ret := _m.Called(ctx, email, password, client, now)
 
 	var r0 *models.UserTokenStatus
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (*models.UserTokenStatus, error)); ok {
-		return rf(ctx, email, password, now)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.ClientInfo, time.Time) (*models.UserTokenStatus, error)); ok {
+		return rf(ctx, email, password, client, now)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) *models.UserTokenStatus); ok {
-		r0 = rf(ctx, email, password, now)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.ClientInfo, time.Time) *models.UserTokenStatus); ok {
+		r0 = rf(ctx, email, password, client, now)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.UserTokenStatus)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
-		r1 = rf(ctx, email, password, now)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.ClientInfo, time.Time) error); ok {
+		r1 = rf(ctx, email, password, client, now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,14 +59,15 @@ type LoginService_Login_Call struct {
 //   - ctx context.Context
 //   - email string
 //   - password string
+//   - client models.ClientInfo
 //   - now time.Time
-func (_e *LoginService_Expecter) Login(ctx interface{}, email interface{}, password interface{}, now interface{}) *LoginService_Login_Call {
-	return &LoginService_Login_Call{Call: _e.mock.On("Login", ctx, email, password, now)}
+func (_e *LoginService_Expecter) Login(ctx interface{}, email interface{}, password interface{}, client interface{}, now interface{}) *LoginService_Login_Call {
+	return &LoginService_Login_Call{Call: _e.mock.On("Login", ctx, email, password, client, now)}
 }
 
-func (_c *LoginService_Login_Call) Run(run func(ctx context.Context, email string, password string, now time.Time)) *LoginService_Login_Call {
+func (_c *LoginService_Login_Call) Run(run func(ctx context.Context, email string, password string, client models.ClientInfo, now time.Time)) *LoginService_Login_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(models.ClientInfo), args[4].(time.Time))
 	})
 	return _c
 }
@@ -76,7 +77,7 @@ func (_c *LoginService_Login_Call) Return(_a0 *models.UserTokenStatus, _a1 error
 	return _c
 }
 
-func (_c *LoginService_Login_Call) RunAndReturn(run func(context.Context, string, string, time.Time) (*models.UserTokenStatus, error)) *LoginService_Login_Call {
+func (_c *LoginService_Login_Call) RunAndReturn(run func(context.Context, string, string, models.ClientInfo, time.Time) (*models.UserTokenStatus, error)) *LoginService_Login_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// LogoutService is an autogenerated mock type for the LogoutService type
+type LogoutService struct {
+	mock.Mock
+}
+
+type LogoutService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LogoutService) EXPECT() *LogoutService_Expecter {
+	return &LogoutService_Expecter{mock: &_m.Mock}
+}
+
+// Logout provides a mock function with given fields: ctx, tokenRaw, now
+func (_m *LogoutService) Logout(ctx context.Context, tokenRaw string, now time.Time) error {
+	ret := _m.Called(ctx, tokenRaw, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, tokenRaw, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LogoutService_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type LogoutService_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - now time.Time
+func (_e *LogoutService_Expecter) Logout(ctx interface{}, tokenRaw interface{}, now interface{}) *LogoutService_Logout_Call {
+	return &LogoutService_Logout_Call{Call: _e.mock.On("Logout", ctx, tokenRaw, now)}
+}
+
+func (_c *LogoutService_Logout_Call) Run(run func(ctx context.Context, tokenRaw string, now time.Time)) *LogoutService_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LogoutService_Logout_Call) Return(_a0 error) *LogoutService_Logout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LogoutService_Logout_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *LogoutService_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLogoutService creates a new instance of LogoutService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLogoutService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LogoutService {
+	mock := &LogoutService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,144 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// PasswordHasher is an autogenerated mock type for the PasswordHasher type
+type PasswordHasher struct {
+	mock.Mock
+}
+
+type PasswordHasher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PasswordHasher) EXPECT() *PasswordHasher_Expecter {
+	return &PasswordHasher_Expecter{mock: &_m.Mock}
+}
+
+// Hash provides a mock function with given fields: password
+func (_m *PasswordHasher) Hash(password string) (string, error) {
+	ret := _m.Called(password)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(password)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(password)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PasswordHasher_Hash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Hash'
+type PasswordHasher_Hash_Call struct {
+	*mock.Call
+}
+
+// Hash is a helper method to define mock.On call
+//   - password string
+func (_e *PasswordHasher_Expecter) Hash(password interface{}) *PasswordHasher_Hash_Call {
+	return &PasswordHasher_Hash_Call{Call: _e.mock.On("Hash", password)}
+}
+
+func (_c *PasswordHasher_Hash_Call) Run(run func(password string)) *PasswordHasher_Hash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *PasswordHasher_Hash_Call) Return(_a0 string, _a1 error) *PasswordHasher_Hash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PasswordHasher_Hash_Call) RunAndReturn(run func(string) (string, error)) *PasswordHasher_Hash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function with given fields: password, hashed
+func (_m *PasswordHasher) Verify(password string, hashed string) (bool, bool, error) {
+	ret := _m.Called(password, hashed)
+
+	var r0 bool
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string) (bool, bool, error)); ok {
+		return rf(password, hashed)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(password, hashed)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) bool); ok {
+		r1 = rf(password, hashed)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(password, hashed)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PasswordHasher_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type PasswordHasher_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - password string
+//   - hashed string
+func (_e *PasswordHasher_Expecter) Verify(password interface{}, hashed interface{}) *PasswordHasher_Verify_Call {
+	return &PasswordHasher_Verify_Call{Call: _e.mock.On("Verify", password, hashed)}
+}
+
+func (_c *PasswordHasher_Verify_Call) Run(run func(password string, hashed string)) *PasswordHasher_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PasswordHasher_Verify_Call) Return(ok bool, outdated bool, err error) *PasswordHasher_Verify_Call {
+	_c.Call.Return(ok, outdated, err)
+	return _c
+}
+
+func (_c *PasswordHasher_Verify_Call) RunAndReturn(run func(string, string) (bool, bool, error)) *PasswordHasher_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPasswordHasher creates a new instance of PasswordHasher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasswordHasher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasswordHasher {
+	mock := &PasswordHasher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
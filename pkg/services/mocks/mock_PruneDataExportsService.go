@@ -0,0 +1,81 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// PruneDataExportsService is an autogenerated mock type for the PruneDataExportsService type
+type PruneDataExportsService struct {
+	mock.Mock
+}
+
+type PruneDataExportsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PruneDataExportsService) EXPECT() *PruneDataExportsService_Expecter {
+	return &PruneDataExportsService_Expecter{mock: &_m.Mock}
+}
+
+// PruneDataExports provides a mock function with given fields: ctx, now
+func (_m *PruneDataExportsService) PruneDataExports(ctx context.Context, now time.Time) error {
+	ret := _m.Called(ctx, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PruneDataExportsService_PruneDataExports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneDataExports'
+type PruneDataExportsService_PruneDataExports_Call struct {
+	*mock.Call
+}
+
+// PruneDataExports is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *PruneDataExportsService_Expecter) PruneDataExports(ctx interface{}, now interface{}) *PruneDataExportsService_PruneDataExports_Call {
+	return &PruneDataExportsService_PruneDataExports_Call{Call: _e.mock.On("PruneDataExports", ctx, now)}
+}
+
+func (_c *PruneDataExportsService_PruneDataExports_Call) Run(run func(ctx context.Context, now time.Time)) *PruneDataExportsService_PruneDataExports_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PruneDataExportsService_PruneDataExports_Call) Return(_a0 error) *PruneDataExportsService_PruneDataExports_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PruneDataExportsService_PruneDataExports_Call) RunAndReturn(run func(context.Context, time.Time) error) *PruneDataExportsService_PruneDataExports_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPruneDataExportsService creates a new instance of PruneDataExportsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPruneDataExportsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PruneDataExportsService {
+	mock := &PruneDataExportsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,81 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// PruneLoginFailuresService is an autogenerated mock type for the PruneLoginFailuresService type
+type PruneLoginFailuresService struct {
+	mock.Mock
+}
+
+type PruneLoginFailuresService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PruneLoginFailuresService) EXPECT() *PruneLoginFailuresService_Expecter {
+	return &PruneLoginFailuresService_Expecter{mock: &_m.Mock}
+}
+
+// PruneLoginFailures provides a mock function with given fields: ctx, now
+func (_m *PruneLoginFailuresService) PruneLoginFailures(ctx context.Context, now time.Time) error {
+	ret := _m.Called(ctx, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PruneLoginFailuresService_PruneLoginFailures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneLoginFailures'
+type PruneLoginFailuresService_PruneLoginFailures_Call struct {
+	*mock.Call
+}
+
+// PruneLoginFailures is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *PruneLoginFailuresService_Expecter) PruneLoginFailures(ctx interface{}, now interface{}) *PruneLoginFailuresService_PruneLoginFailures_Call {
+	return &PruneLoginFailuresService_PruneLoginFailures_Call{Call: _e.mock.On("PruneLoginFailures", ctx, now)}
+}
+
+func (_c *PruneLoginFailuresService_PruneLoginFailures_Call) Run(run func(ctx context.Context, now time.Time)) *PruneLoginFailuresService_PruneLoginFailures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PruneLoginFailuresService_PruneLoginFailures_Call) Return(_a0 error) *PruneLoginFailuresService_PruneLoginFailures_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PruneLoginFailuresService_PruneLoginFailures_Call) RunAndReturn(run func(context.Context, time.Time) error) *PruneLoginFailuresService_PruneLoginFailures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPruneLoginFailuresService creates a new instance of PruneLoginFailuresService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPruneLoginFailuresService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PruneLoginFailuresService {
+	mock := &PruneLoginFailuresService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
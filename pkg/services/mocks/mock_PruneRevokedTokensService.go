@@ -0,0 +1,81 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// PruneRevokedTokensService is an autogenerated mock type for the PruneRevokedTokensService type
+type PruneRevokedTokensService struct {
+	mock.Mock
+}
+
+type PruneRevokedTokensService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PruneRevokedTokensService) EXPECT() *PruneRevokedTokensService_Expecter {
+	return &PruneRevokedTokensService_Expecter{mock: &_m.Mock}
+}
+
+// PruneRevokedTokens provides a mock function with given fields: ctx, now
+func (_m *PruneRevokedTokensService) PruneRevokedTokens(ctx context.Context, now time.Time) error {
+	ret := _m.Called(ctx, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PruneRevokedTokensService_PruneRevokedTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneRevokedTokens'
+type PruneRevokedTokensService_PruneRevokedTokens_Call struct {
+	*mock.Call
+}
+
+// PruneRevokedTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *PruneRevokedTokensService_Expecter) PruneRevokedTokens(ctx interface{}, now interface{}) *PruneRevokedTokensService_PruneRevokedTokens_Call {
+	return &PruneRevokedTokensService_PruneRevokedTokens_Call{Call: _e.mock.On("PruneRevokedTokens", ctx, now)}
+}
+
+func (_c *PruneRevokedTokensService_PruneRevokedTokens_Call) Run(run func(ctx context.Context, now time.Time)) *PruneRevokedTokensService_PruneRevokedTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PruneRevokedTokensService_PruneRevokedTokens_Call) Return(_a0 error) *PruneRevokedTokensService_PruneRevokedTokens_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PruneRevokedTokensService_PruneRevokedTokens_Call) RunAndReturn(run func(context.Context, time.Time) error) *PruneRevokedTokensService_PruneRevokedTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPruneRevokedTokensService creates a new instance of PruneRevokedTokensService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPruneRevokedTokensService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PruneRevokedTokensService {
+	mock := &PruneRevokedTokensService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
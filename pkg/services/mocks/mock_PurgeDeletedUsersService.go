@@ -0,0 +1,91 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// PurgeDeletedUsersService is an autogenerated mock type for the PurgeDeletedUsersService type
+type PurgeDeletedUsersService struct {
+	mock.Mock
+}
+
+type PurgeDeletedUsersService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PurgeDeletedUsersService) EXPECT() *PurgeDeletedUsersService_Expecter {
+	return &PurgeDeletedUsersService_Expecter{mock: &_m.Mock}
+}
+
+// PurgeDeletedUsers provides a mock function with given fields: ctx, now
+func (_m *PurgeDeletedUsersService) PurgeDeletedUsers(ctx context.Context, now time.Time) (int, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeletedUsersService_PurgeDeletedUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeletedUsers'
+type PurgeDeletedUsersService_PurgeDeletedUsers_Call struct {
+	*mock.Call
+}
+
+// PurgeDeletedUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *PurgeDeletedUsersService_Expecter) PurgeDeletedUsers(ctx interface{}, now interface{}) *PurgeDeletedUsersService_PurgeDeletedUsers_Call {
+	return &PurgeDeletedUsersService_PurgeDeletedUsers_Call{Call: _e.mock.On("PurgeDeletedUsers", ctx, now)}
+}
+
+func (_c *PurgeDeletedUsersService_PurgeDeletedUsers_Call) Run(run func(ctx context.Context, now time.Time)) *PurgeDeletedUsersService_PurgeDeletedUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PurgeDeletedUsersService_PurgeDeletedUsers_Call) Return(_a0 int, _a1 error) *PurgeDeletedUsersService_PurgeDeletedUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PurgeDeletedUsersService_PurgeDeletedUsers_Call) RunAndReturn(run func(context.Context, time.Time) (int, error)) *PurgeDeletedUsersService_PurgeDeletedUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPurgeDeletedUsersService creates a new instance of PurgeDeletedUsersService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPurgeDeletedUsersService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PurgeDeletedUsersService {
+	mock := &PurgeDeletedUsersService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
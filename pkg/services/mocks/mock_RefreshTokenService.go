@@ -0,0 +1,95 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RefreshTokenService is an autogenerated mock type for the RefreshTokenService type
+type RefreshTokenService struct {
+	mock.Mock
+}
+
+type RefreshTokenService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RefreshTokenService) EXPECT() *RefreshTokenService_Expecter {
+	return &RefreshTokenService_Expecter{mock: &_m.Mock}
+}
+
+// RefreshToken provides a mock function with given fields: ctx, refreshToken, now
+func (_m *RefreshTokenService) RefreshToken(ctx context.Context, refreshToken string, now time.Time) (*models.UserTokenStatus, error) {
+	ret := _m.Called(ctx, refreshToken, now)
+
+	var r0 *models.UserTokenStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*models.UserTokenStatus, error)); ok {
+		return rf(ctx, refreshToken, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *models.UserTokenStatus); ok {
+		r0 = rf(ctx, refreshToken, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserTokenStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, refreshToken, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshTokenService_RefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshToken'
+type RefreshTokenService_RefreshToken_Call struct {
+	*mock.Call
+}
+
+// RefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+//   - now time.Time
+func (_e *RefreshTokenService_Expecter) RefreshToken(ctx interface{}, refreshToken interface{}, now interface{}) *RefreshTokenService_RefreshToken_Call {
+	return &RefreshTokenService_RefreshToken_Call{Call: _e.mock.On("RefreshToken", ctx, refreshToken, now)}
+}
+
+func (_c *RefreshTokenService_RefreshToken_Call) Run(run func(ctx context.Context, refreshToken string, now time.Time)) *RefreshTokenService_RefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RefreshTokenService_RefreshToken_Call) Return(_a0 *models.UserTokenStatus, _a1 error) *RefreshTokenService_RefreshToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RefreshTokenService_RefreshToken_Call) RunAndReturn(run func(context.Context, string, time.Time) (*models.UserTokenStatus, error)) *RefreshTokenService_RefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRefreshTokenService creates a new instance of RefreshTokenService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRefreshTokenService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RefreshTokenService {
+	mock := &RefreshTokenService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -24,34 +24,34 @@ func (_m *RegisterService) EXPECT() *RegisterService_Expecter {
 	return &RegisterService_Expecter{mock: &_m.Mock}
 }
 
-// Register provides a mock function with given fields: ctx, form, now
-func (_m *RegisterService) Register(ctx context.Context, form models.RegisterForm, now time.Time) (*models.UserTokenStatus, func() error, error) {
-	ret := _m.Called(ctx, form, now)
+// Register provides a mock function with given fields: ctx, form, client, now
+func (_m *RegisterService) Register(ctx context.Context, form models.RegisterForm, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, func() error, error) {
+	ret := _m.Called(ctx, form, client, now)
 
 	var r0 *models.UserTokenStatus
 	var r1 func() error
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.RegisterForm, time.Time) (*models.UserTokenStatus, func() error, error)); ok {
-		return rf(ctx, form, now)
+	if rf, ok := ret.Get(0).(func(context.Context, models.RegisterForm, models.ClientInfo, time.Time) (*models.UserTokenStatus, func() error, error)); ok {
+		return rf(ctx, form, client, now)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, models.RegisterForm, time.Time) *models.UserTokenStatus); ok {
-		r0 = rf(ctx, form, now)
+	if rf, ok := ret.Get(0).(func(context.Context, models.RegisterForm, models.ClientInfo, time.Time) *models.UserTokenStatus); ok {
+		r0 = rf(ctx, form, client, now)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.UserTokenStatus)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, models.RegisterForm, time.Time) func() error); ok {
-		r1 = rf(ctx, form, now)
+	if rf, ok := ret.Get(1).(func(context.Context, models.RegisterForm, models.ClientInfo, time.Time) func() error); ok {
+		r1 = rf(ctx, form, client, now)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(func() error)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, models.RegisterForm, time.Time) error); ok {
-		r2 = rf(ctx, form, now)
+	if rf, ok := ret.Get(2).(func(context.Context, models.RegisterForm, models.ClientInfo, time.Time) error); ok {
+		r2 = rf(ctx, form, client, now)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -67,14 +67,15 @@ type RegisterService_Register_Call struct {
 // Register is a helper method to define mock.On call
 //   - ctx context.Context
 //   - form models.RegisterForm
+//   - client models.ClientInfo
 //   - now time.Time
-func (_e *RegisterService_Expecter) Register(ctx interface{}, form interface{}, now interface{}) *RegisterService_Register_Call {
-	return &RegisterService_Register_Call{Call: _e.mock.On("Register", ctx, form, now)}
+func (_e *RegisterService_Expecter) Register(ctx interface{}, form interface{}, client interface{}, now interface{}) *RegisterService_Register_Call {
+	return &RegisterService_Register_Call{Call: _e.mock.On("Register", ctx, form, client, now)}
 }
 
-func (_c *RegisterService_Register_Call) Run(run func(ctx context.Context, form models.RegisterForm, now time.Time)) *RegisterService_Register_Call {
+func (_c *RegisterService_Register_Call) Run(run func(ctx context.Context, form models.RegisterForm, client models.ClientInfo, now time.Time)) *RegisterService_Register_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(models.RegisterForm), args[2].(time.Time))
+		run(args[0].(context.Context), args[1].(models.RegisterForm), args[2].(models.ClientInfo), args[3].(time.Time))
 	})
 	return _c
 }
@@ -84,7 +85,7 @@ func (_c *RegisterService_Register_Call) Return(_a0 *models.UserTokenStatus, _a1
 	return _c
 }
 
-func (_c *RegisterService_Register_Call) RunAndReturn(run func(context.Context, models.RegisterForm, time.Time) (*models.UserTokenStatus, func() error, error)) *RegisterService_Register_Call {
+func (_c *RegisterService_Register_Call) RunAndReturn(run func(context.Context, models.RegisterForm, models.ClientInfo, time.Time) (*models.UserTokenStatus, func() error, error)) *RegisterService_Register_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,99 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// ReportEmailChangeService is an autogenerated mock type for the ReportEmailChangeService type
+type ReportEmailChangeService struct {
+	mock.Mock
+}
+
+type ReportEmailChangeService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReportEmailChangeService) EXPECT() *ReportEmailChangeService_Expecter {
+	return &ReportEmailChangeService_Expecter{mock: &_m.Mock}
+}
+
+// ReportEmailChange provides a mock function with given fields: ctx, id, code, client, now
+func (_m *ReportEmailChangeService) ReportEmailChange(ctx context.Context, id uuid.UUID, code string, client models.ClientInfo, now time.Time) (func() error, error) {
+	ret := _m.Called(ctx, id, code, client, now)
+
+	var r0 func() error
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, models.ClientInfo, time.Time) (func() error, error)); ok {
+		return rf(ctx, id, code, client, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, models.ClientInfo, time.Time) func() error); ok {
+		r0 = rf(ctx, id, code, client, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func() error)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, models.ClientInfo, time.Time) error); ok {
+		r1 = rf(ctx, id, code, client, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReportEmailChangeService_ReportEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReportEmailChange'
+type ReportEmailChangeService_ReportEmailChange_Call struct {
+	*mock.Call
+}
+
+// ReportEmailChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - code string
+//   - client models.ClientInfo
+//   - now time.Time
+func (_e *ReportEmailChangeService_Expecter) ReportEmailChange(ctx interface{}, id interface{}, code interface{}, client interface{}, now interface{}) *ReportEmailChangeService_ReportEmailChange_Call {
+	return &ReportEmailChangeService_ReportEmailChange_Call{Call: _e.mock.On("ReportEmailChange", ctx, id, code, client, now)}
+}
+
+func (_c *ReportEmailChangeService_ReportEmailChange_Call) Run(run func(ctx context.Context, id uuid.UUID, code string, client models.ClientInfo, now time.Time)) *ReportEmailChangeService_ReportEmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(models.ClientInfo), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ReportEmailChangeService_ReportEmailChange_Call) Return(_a0 func() error, _a1 error) *ReportEmailChangeService_ReportEmailChange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReportEmailChangeService_ReportEmailChange_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, models.ClientInfo, time.Time) (func() error, error)) *ReportEmailChangeService_ReportEmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReportEmailChangeService creates a new instance of ReportEmailChangeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReportEmailChangeService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReportEmailChangeService {
+	mock := &ReportEmailChangeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
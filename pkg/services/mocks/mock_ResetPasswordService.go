@@ -5,6 +5,7 @@ package servicesmocks
 import (
 	context "context"
 
+	models "github.com/a-novel/auth-service/pkg/models"
 	mock "github.com/stretchr/testify/mock"
 
 	time "time"
@@ -23,25 +24,25 @@ func (_m *ResetPasswordService) EXPECT() *ResetPasswordService_Expecter {
 	return &ResetPasswordService_Expecter{mock: &_m.Mock}
 }
 
-// ResetPassword provides a mock function with given fields: ctx, email, now
-func (_m *ResetPasswordService) ResetPassword(ctx context.Context, email string, now time.Time) (func() error, error) {
-	ret := _m.Called(ctx, email, now)
+// ResetPassword provides a mock function with given fields: ctx, email, client, now
+func (_m *ResetPasswordService) ResetPassword(ctx context.Context, email string, client models.ClientInfo, now time.Time) (func() error, error) {
+	ret := _m.Called(ctx, email, client, now)
 
 	var r0 func() error
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (func() error, error)); ok {
-		return rf(ctx, email, now)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ClientInfo, time.Time) (func() error, error)); ok {
+		return rf(ctx, email, client, now)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) func() error); ok {
-		r0 = rf(ctx, email, now)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ClientInfo, time.Time) func() error); ok {
+		r0 = rf(ctx, email, client, now)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(func() error)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
-		r1 = rf(ctx, email, now)
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.ClientInfo, time.Time) error); ok {
+		r1 = rf(ctx, email, client, now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -57,14 +58,15 @@ type ResetPasswordService_ResetPassword_Call struct {
 // ResetPassword is a helper method to define mock.On call
 //   - ctx context.Context
 //   - email string
+//   - client models.ClientInfo
 //   - now time.Time
-func (_e *ResetPasswordService_Expecter) ResetPassword(ctx interface{}, email interface{}, now interface{}) *ResetPasswordService_ResetPassword_Call {
-	return &ResetPasswordService_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, email, now)}
+func (_e *ResetPasswordService_Expecter) ResetPassword(ctx interface{}, email interface{}, client interface{}, now interface{}) *ResetPasswordService_ResetPassword_Call {
+	return &ResetPasswordService_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, email, client, now)}
 }
 
-func (_c *ResetPasswordService_ResetPassword_Call) Run(run func(ctx context.Context, email string, now time.Time)) *ResetPasswordService_ResetPassword_Call {
+func (_c *ResetPasswordService_ResetPassword_Call) Run(run func(ctx context.Context, email string, client models.ClientInfo, now time.Time)) *ResetPasswordService_ResetPassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+		run(args[0].(context.Context), args[1].(string), args[2].(models.ClientInfo), args[3].(time.Time))
 	})
 	return _c
 }
@@ -74,7 +76,7 @@ func (_c *ResetPasswordService_ResetPassword_Call) Return(_a0 func() error, _a1
 	return _c
 }
 
-func (_c *ResetPasswordService_ResetPassword_Call) RunAndReturn(run func(context.Context, string, time.Time) (func() error, error)) *ResetPasswordService_ResetPassword_Call {
+func (_c *ResetPasswordService_ResetPassword_Call) RunAndReturn(run func(context.Context, string, models.ClientInfo, time.Time) (func() error, error)) *ResetPasswordService_ResetPassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,88 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// RevokeSessionService is an autogenerated mock type for the RevokeSessionService type
+type RevokeSessionService struct {
+	mock.Mock
+}
+
+type RevokeSessionService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RevokeSessionService) EXPECT() *RevokeSessionService_Expecter {
+	return &RevokeSessionService_Expecter{mock: &_m.Mock}
+}
+
+// RevokeSession provides a mock function with given fields: ctx, tokenRaw, id, password, client, now
+func (_m *RevokeSessionService) RevokeSession(ctx context.Context, tokenRaw string, id uuid.UUID, password string, client models.ClientInfo, now time.Time) error {
+	ret := _m.Called(ctx, tokenRaw, id, password, client, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, string, models.ClientInfo, time.Time) error); ok {
+		r0 = rf(ctx, tokenRaw, id, password, client, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSessionService_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type RevokeSessionService_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - id uuid.UUID
+//   - password string
+//   - client models.ClientInfo
+//   - now time.Time
+func (_e *RevokeSessionService_Expecter) RevokeSession(ctx interface{}, tokenRaw interface{}, id interface{}, password interface{}, client interface{}, now interface{}) *RevokeSessionService_RevokeSession_Call {
+	return &RevokeSessionService_RevokeSession_Call{Call: _e.mock.On("RevokeSession", ctx, tokenRaw, id, password, client, now)}
+}
+
+func (_c *RevokeSessionService_RevokeSession_Call) Run(run func(ctx context.Context, tokenRaw string, id uuid.UUID, password string, client models.ClientInfo, now time.Time)) *RevokeSessionService_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(string), args[4].(models.ClientInfo), args[5].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RevokeSessionService_RevokeSession_Call) Return(_a0 error) *RevokeSessionService_RevokeSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RevokeSessionService_RevokeSession_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, string, models.ClientInfo, time.Time) error) *RevokeSessionService_RevokeSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRevokeSessionService creates a new instance of RevokeSessionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRevokeSessionService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RevokeSessionService {
+	mock := &RevokeSessionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RevokeSignatureKeyService is an autogenerated mock type for the RevokeSignatureKeyService type
+type RevokeSignatureKeyService struct {
+	mock.Mock
+}
+
+type RevokeSignatureKeyService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RevokeSignatureKeyService) EXPECT() *RevokeSignatureKeyService_Expecter {
+	return &RevokeSignatureKeyService_Expecter{mock: &_m.Mock}
+}
+
+// RevokeSignatureKey provides a mock function with given fields: ctx, name, actor, now
+func (_m *RevokeSignatureKeyService) RevokeSignatureKey(ctx context.Context, name string, actor string, now time.Time) (*models.RevokedSignatureKey, error) {
+	ret := _m.Called(ctx, name, actor, now)
+
+	var r0 *models.RevokedSignatureKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (*models.RevokedSignatureKey, error)); ok {
+		return rf(ctx, name, actor, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) *models.RevokedSignatureKey); ok {
+		r0 = rf(ctx, name, actor, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RevokedSignatureKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, name, actor, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeSignatureKeyService_RevokeSignatureKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSignatureKey'
+type RevokeSignatureKeyService_RevokeSignatureKey_Call struct {
+	*mock.Call
+}
+
+// RevokeSignatureKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - actor string
+//   - now time.Time
+func (_e *RevokeSignatureKeyService_Expecter) RevokeSignatureKey(ctx interface{}, name interface{}, actor interface{}, now interface{}) *RevokeSignatureKeyService_RevokeSignatureKey_Call {
+	return &RevokeSignatureKeyService_RevokeSignatureKey_Call{Call: _e.mock.On("RevokeSignatureKey", ctx, name, actor, now)}
+}
+
+func (_c *RevokeSignatureKeyService_RevokeSignatureKey_Call) Run(run func(ctx context.Context, name string, actor string, now time.Time)) *RevokeSignatureKeyService_RevokeSignatureKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RevokeSignatureKeyService_RevokeSignatureKey_Call) Return(_a0 *models.RevokedSignatureKey, _a1 error) *RevokeSignatureKeyService_RevokeSignatureKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RevokeSignatureKeyService_RevokeSignatureKey_Call) RunAndReturn(run func(context.Context, string, string, time.Time) (*models.RevokedSignatureKey, error)) *RevokeSignatureKeyService_RevokeSignatureKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRevokeSignatureKeyService creates a new instance of RevokeSignatureKeyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRevokeSignatureKeyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RevokeSignatureKeyService {
+	mock := &RevokeSignatureKeyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
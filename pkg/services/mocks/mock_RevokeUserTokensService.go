@@ -0,0 +1,84 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// RevokeUserTokensService is an autogenerated mock type for the RevokeUserTokensService type
+type RevokeUserTokensService struct {
+	mock.Mock
+}
+
+type RevokeUserTokensService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RevokeUserTokensService) EXPECT() *RevokeUserTokensService_Expecter {
+	return &RevokeUserTokensService_Expecter{mock: &_m.Mock}
+}
+
+// RevokeUserTokens provides a mock function with given fields: ctx, userID, now
+func (_m *RevokeUserTokensService) RevokeUserTokens(ctx context.Context, userID uuid.UUID, now time.Time) error {
+	ret := _m.Called(ctx, userID, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r0 = rf(ctx, userID, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeUserTokensService_RevokeUserTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeUserTokens'
+type RevokeUserTokensService_RevokeUserTokens_Call struct {
+	*mock.Call
+}
+
+// RevokeUserTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - now time.Time
+func (_e *RevokeUserTokensService_Expecter) RevokeUserTokens(ctx interface{}, userID interface{}, now interface{}) *RevokeUserTokensService_RevokeUserTokens_Call {
+	return &RevokeUserTokensService_RevokeUserTokens_Call{Call: _e.mock.On("RevokeUserTokens", ctx, userID, now)}
+}
+
+func (_c *RevokeUserTokensService_RevokeUserTokens_Call) Run(run func(ctx context.Context, userID uuid.UUID, now time.Time)) *RevokeUserTokensService_RevokeUserTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RevokeUserTokensService_RevokeUserTokens_Call) Return(_a0 error) *RevokeUserTokensService_RevokeUserTokens_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RevokeUserTokensService_RevokeUserTokens_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) error) *RevokeUserTokensService_RevokeUserTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRevokeUserTokensService creates a new instance of RevokeUserTokensService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRevokeUserTokensService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RevokeUserTokensService {
+	mock := &RevokeUserTokensService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -6,6 +6,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // RotateSecretKeysService is an autogenerated mock type for the RotateSecretKeysService type
@@ -21,13 +23,13 @@ func (_m *RotateSecretKeysService) EXPECT() *RotateSecretKeysService_Expecter {
 	return &RotateSecretKeysService_Expecter{mock: &_m.Mock}
 }
 
-// RotateSecretKeys provides a mock function with given fields: ctx
-func (_m *RotateSecretKeysService) RotateSecretKeys(ctx context.Context) error {
-	ret := _m.Called(ctx)
+// RotateSecretKeys provides a mock function with given fields: ctx, actor, now
+func (_m *RotateSecretKeysService) RotateSecretKeys(ctx context.Context, actor string, now time.Time) error {
+	ret := _m.Called(ctx, actor, now)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, actor, now)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -42,13 +44,15 @@ type RotateSecretKeysService_RotateSecretKeys_Call struct {
 
 // RotateSecretKeys is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *RotateSecretKeysService_Expecter) RotateSecretKeys(ctx interface{}) *RotateSecretKeysService_RotateSecretKeys_Call {
-	return &RotateSecretKeysService_RotateSecretKeys_Call{Call: _e.mock.On("RotateSecretKeys", ctx)}
+//   - actor string
+//   - now time.Time
+func (_e *RotateSecretKeysService_Expecter) RotateSecretKeys(ctx interface{}, actor interface{}, now interface{}) *RotateSecretKeysService_RotateSecretKeys_Call {
+	return &RotateSecretKeysService_RotateSecretKeys_Call{Call: _e.mock.On("RotateSecretKeys", ctx, actor, now)}
 }
 
-func (_c *RotateSecretKeysService_RotateSecretKeys_Call) Run(run func(ctx context.Context)) *RotateSecretKeysService_RotateSecretKeys_Call {
+func (_c *RotateSecretKeysService_RotateSecretKeys_Call) Run(run func(ctx context.Context, actor string, now time.Time)) *RotateSecretKeysService_RotateSecretKeys_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
 	})
 	return _c
 }
@@ -58,7 +62,7 @@ func (_c *RotateSecretKeysService_RotateSecretKeys_Call) Return(_a0 error) *Rota
 	return _c
 }
 
-func (_c *RotateSecretKeysService_RotateSecretKeys_Call) RunAndReturn(run func(context.Context) error) *RotateSecretKeysService_RotateSecretKeys_Call {
+func (_c *RotateSecretKeysService_RotateSecretKeys_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *RotateSecretKeysService_RotateSecretKeys_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,91 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ScheduleSecretKeysRotationService is an autogenerated mock type for the ScheduleSecretKeysRotationService type
+type ScheduleSecretKeysRotationService struct {
+	mock.Mock
+}
+
+type ScheduleSecretKeysRotationService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ScheduleSecretKeysRotationService) EXPECT() *ScheduleSecretKeysRotationService_Expecter {
+	return &ScheduleSecretKeysRotationService_Expecter{mock: &_m.Mock}
+}
+
+// ScheduleSecretKeysRotation provides a mock function with given fields: ctx, now
+func (_m *ScheduleSecretKeysRotationService) ScheduleSecretKeysRotation(ctx context.Context, now time.Time) (bool, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (bool, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) bool); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleSecretKeysRotationService_ScheduleSecretKeysRotation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleSecretKeysRotation'
+type ScheduleSecretKeysRotationService_ScheduleSecretKeysRotation_Call struct {
+	*mock.Call
+}
+
+// ScheduleSecretKeysRotation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *ScheduleSecretKeysRotationService_Expecter) ScheduleSecretKeysRotation(ctx interface{}, now interface{}) *ScheduleSecretKeysRotationService_ScheduleSecretKeysRotation_Call {
+	return &ScheduleSecretKeysRotationService_ScheduleSecretKeysRotation_Call{Call: _e.mock.On("ScheduleSecretKeysRotation", ctx, now)}
+}
+
+func (_c *ScheduleSecretKeysRotationService_ScheduleSecretKeysRotation_Call) Run(run func(ctx context.Context, now time.Time)) *ScheduleSecretKeysRotationService_ScheduleSecretKeysRotation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ScheduleSecretKeysRotationService_ScheduleSecretKeysRotation_Call) Return(_a0 bool, _a1 error) *ScheduleSecretKeysRotationService_ScheduleSecretKeysRotation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ScheduleSecretKeysRotationService_ScheduleSecretKeysRotation_Call) RunAndReturn(run func(context.Context, time.Time) (bool, error)) *ScheduleSecretKeysRotationService_ScheduleSecretKeysRotation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewScheduleSecretKeysRotationService creates a new instance of ScheduleSecretKeysRotationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewScheduleSecretKeysRotationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ScheduleSecretKeysRotationService {
+	mock := &ScheduleSecretKeysRotationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,6 +7,8 @@ import (
 
 	models "github.com/a-novel/auth-service/pkg/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // SearchService is an autogenerated mock type for the SearchService type
@@ -22,32 +24,32 @@ func (_m *SearchService) EXPECT() *SearchService_Expecter {
 	return &SearchService_Expecter{mock: &_m.Mock}
 }
 
-// Search provides a mock function with given fields: ctx, query, limit, offset
-func (_m *SearchService) Search(ctx context.Context, query string, limit int, offset int) ([]*models.UserPreview, int, error) {
-	ret := _m.Called(ctx, query, limit, offset)
+// Search provides a mock function with given fields: ctx, query, limit, offset, now
+func (_m *SearchService) Search(ctx context.Context, query string, limit int, offset int, now time.Time) ([]*models.UserPreview, int, error) {
+	ret := _m.Called(ctx, query, limit, offset, now)
 
 	var r0 []*models.UserPreview
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*models.UserPreview, int, error)); ok {
-		return rf(ctx, query, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int, time.Time) ([]*models.UserPreview, int, error)); ok {
+		return rf(ctx, query, limit, offset, now)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*models.UserPreview); ok {
-		r0 = rf(ctx, query, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int, time.Time) []*models.UserPreview); ok {
+		r0 = rf(ctx, query, limit, offset, now)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.UserPreview)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int); ok {
-		r1 = rf(ctx, query, limit, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int, time.Time) int); ok {
+		r1 = rf(ctx, query, limit, offset, now)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
-		r2 = rf(ctx, query, limit, offset)
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int, time.Time) error); ok {
+		r2 = rf(ctx, query, limit, offset, now)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -65,13 +67,14 @@ type SearchService_Search_Call struct {
 //   - query string
 //   - limit int
 //   - offset int
-func (_e *SearchService_Expecter) Search(ctx interface{}, query interface{}, limit interface{}, offset interface{}) *SearchService_Search_Call {
-	return &SearchService_Search_Call{Call: _e.mock.On("Search", ctx, query, limit, offset)}
+//   - now time.Time
+func (_e *SearchService_Expecter) Search(ctx interface{}, query interface{}, limit interface{}, offset interface{}, now interface{}) *SearchService_Search_Call {
+	return &SearchService_Search_Call{Call: _e.mock.On("Search", ctx, query, limit, offset, now)}
 }
 
-func (_c *SearchService_Search_Call) Run(run func(ctx context.Context, query string, limit int, offset int)) *SearchService_Search_Call {
+func (_c *SearchService_Search_Call) Run(run func(ctx context.Context, query string, limit int, offset int, now time.Time)) *SearchService_Search_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int), args[4].(time.Time))
 	})
 	return _c
 }
@@ -81,7 +84,7 @@ func (_c *SearchService_Search_Call) Return(_a0 []*models.UserPreview, _a1 int,
 	return _c
 }
 
-func (_c *SearchService_Search_Call) RunAndReturn(run func(context.Context, string, int, int) ([]*models.UserPreview, int, error)) *SearchService_Search_Call {
+func (_c *SearchService_Search_Call) RunAndReturn(run func(context.Context, string, int, int, time.Time) ([]*models.UserPreview, int, error)) *SearchService_Search_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// SendLoginLinkService is an autogenerated mock type for the SendLoginLinkService type
+type SendLoginLinkService struct {
+	mock.Mock
+}
+
+type SendLoginLinkService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SendLoginLinkService) EXPECT() *SendLoginLinkService_Expecter {
+	return &SendLoginLinkService_Expecter{mock: &_m.Mock}
+}
+
+// SendLoginLink provides a mock function with given fields: ctx, email, now
+func (_m *SendLoginLinkService) SendLoginLink(ctx context.Context, email string, now time.Time) (func() error, error) {
+	ret := _m.Called(ctx, email, now)
+
+	var r0 func() error
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (func() error, error)); ok {
+		return rf(ctx, email, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) func() error); ok {
+		r0 = rf(ctx, email, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func() error)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, email, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SendLoginLinkService_SendLoginLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendLoginLink'
+type SendLoginLinkService_SendLoginLink_Call struct {
+	*mock.Call
+}
+
+// SendLoginLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - now time.Time
+func (_e *SendLoginLinkService_Expecter) SendLoginLink(ctx interface{}, email interface{}, now interface{}) *SendLoginLinkService_SendLoginLink_Call {
+	return &SendLoginLinkService_SendLoginLink_Call{Call: _e.mock.On("SendLoginLink", ctx, email, now)}
+}
+
+func (_c *SendLoginLinkService_SendLoginLink_Call) Run(run func(ctx context.Context, email string, now time.Time)) *SendLoginLinkService_SendLoginLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *SendLoginLinkService_SendLoginLink_Call) Return(_a0 func() error, _a1 error) *SendLoginLinkService_SendLoginLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SendLoginLinkService_SendLoginLink_Call) RunAndReturn(run func(context.Context, string, time.Time) (func() error, error)) *SendLoginLinkService_SendLoginLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSendLoginLinkService creates a new instance of SendLoginLinkService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSendLoginLinkService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SendLoginLinkService {
+	mock := &SendLoginLinkService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,83 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/auth-service/pkg/services"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SendSecurityAlertService is an autogenerated mock type for the SendSecurityAlertService type
+type SendSecurityAlertService struct {
+	mock.Mock
+}
+
+type SendSecurityAlertService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SendSecurityAlertService) EXPECT() *SendSecurityAlertService_Expecter {
+	return &SendSecurityAlertService_Expecter{mock: &_m.Mock}
+}
+
+// SendSecurityAlert provides a mock function with given fields: ctx, userID, alert, data
+func (_m *SendSecurityAlertService) SendSecurityAlert(ctx context.Context, userID uuid.UUID, alert services.SecurityAlert, data map[string]interface{}) error {
+	ret := _m.Called(ctx, userID, alert, data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, services.SecurityAlert, map[string]interface{}) error); ok {
+		r0 = rf(ctx, userID, alert, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendSecurityAlertService_SendSecurityAlert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendSecurityAlert'
+type SendSecurityAlertService_SendSecurityAlert_Call struct {
+	*mock.Call
+}
+
+// SendSecurityAlert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - alert services.SecurityAlert
+//   - data map[string]interface{}
+func (_e *SendSecurityAlertService_Expecter) SendSecurityAlert(ctx interface{}, userID interface{}, alert interface{}, data interface{}) *SendSecurityAlertService_SendSecurityAlert_Call {
+	return &SendSecurityAlertService_SendSecurityAlert_Call{Call: _e.mock.On("SendSecurityAlert", ctx, userID, alert, data)}
+}
+
+func (_c *SendSecurityAlertService_SendSecurityAlert_Call) Run(run func(ctx context.Context, userID uuid.UUID, alert services.SecurityAlert, data map[string]interface{})) *SendSecurityAlertService_SendSecurityAlert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(services.SecurityAlert), args[3].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *SendSecurityAlertService_SendSecurityAlert_Call) Return(_a0 error) *SendSecurityAlertService_SendSecurityAlert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SendSecurityAlertService_SendSecurityAlert_Call) RunAndReturn(run func(context.Context, uuid.UUID, services.SecurityAlert, map[string]interface{}) error) *SendSecurityAlertService_SendSecurityAlert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSendSecurityAlertService creates a new instance of SendSecurityAlertService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSendSecurityAlertService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SendSecurityAlertService {
+	mock := &SendSecurityAlertService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// SuspendUserService is an autogenerated mock type for the SuspendUserService type
+type SuspendUserService struct {
+	mock.Mock
+}
+
+type SuspendUserService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SuspendUserService) EXPECT() *SuspendUserService_Expecter {
+	return &SuspendUserService_Expecter{mock: &_m.Mock}
+}
+
+// SuspendUser provides a mock function with given fields: ctx, form, actor, now
+func (_m *SuspendUserService) SuspendUser(ctx context.Context, form models.SuspendUserForm, actor string, now time.Time) (*models.Suspension, error) {
+	ret := _m.Called(ctx, form, actor, now)
+
+	var r0 *models.Suspension
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.SuspendUserForm, string, time.Time) (*models.Suspension, error)); ok {
+		return rf(ctx, form, actor, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.SuspendUserForm, string, time.Time) *models.Suspension); ok {
+		r0 = rf(ctx, form, actor, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Suspension)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.SuspendUserForm, string, time.Time) error); ok {
+		r1 = rf(ctx, form, actor, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SuspendUserService_SuspendUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuspendUser'
+type SuspendUserService_SuspendUser_Call struct {
+	*mock.Call
+}
+
+// SuspendUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - form models.SuspendUserForm
+//   - actor string
+//   - now time.Time
+func (_e *SuspendUserService_Expecter) SuspendUser(ctx interface{}, form interface{}, actor interface{}, now interface{}) *SuspendUserService_SuspendUser_Call {
+	return &SuspendUserService_SuspendUser_Call{Call: _e.mock.On("SuspendUser", ctx, form, actor, now)}
+}
+
+func (_c *SuspendUserService_SuspendUser_Call) Run(run func(ctx context.Context, form models.SuspendUserForm, actor string, now time.Time)) *SuspendUserService_SuspendUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.SuspendUserForm), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *SuspendUserService_SuspendUser_Call) Return(_a0 *models.Suspension, _a1 error) *SuspendUserService_SuspendUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SuspendUserService_SuspendUser_Call) RunAndReturn(run func(context.Context, models.SuspendUserForm, string, time.Time) (*models.Suspension, error)) *SuspendUserService_SuspendUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSuspendUserService creates a new instance of SuspendUserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSuspendUserService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SuspendUserService {
+	mock := &SuspendUserService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// UnlockAccountService is an autogenerated mock type for the UnlockAccountService type
+type UnlockAccountService struct {
+	mock.Mock
+}
+
+type UnlockAccountService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UnlockAccountService) EXPECT() *UnlockAccountService_Expecter {
+	return &UnlockAccountService_Expecter{mock: &_m.Mock}
+}
+
+// UnlockAccount provides a mock function with given fields: ctx, email, now
+func (_m *UnlockAccountService) UnlockAccount(ctx context.Context, email string, now time.Time) error {
+	ret := _m.Called(ctx, email, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, email, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnlockAccountService_UnlockAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlockAccount'
+type UnlockAccountService_UnlockAccount_Call struct {
+	*mock.Call
+}
+
+// UnlockAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - now time.Time
+func (_e *UnlockAccountService_Expecter) UnlockAccount(ctx interface{}, email interface{}, now interface{}) *UnlockAccountService_UnlockAccount_Call {
+	return &UnlockAccountService_UnlockAccount_Call{Call: _e.mock.On("UnlockAccount", ctx, email, now)}
+}
+
+func (_c *UnlockAccountService_UnlockAccount_Call) Run(run func(ctx context.Context, email string, now time.Time)) *UnlockAccountService_UnlockAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *UnlockAccountService_UnlockAccount_Call) Return(_a0 error) *UnlockAccountService_UnlockAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UnlockAccountService_UnlockAccount_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *UnlockAccountService_UnlockAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUnlockAccountService creates a new instance of UnlockAccountService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUnlockAccountService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UnlockAccountService {
+	mock := &UnlockAccountService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,85 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// UnsuspendUserService is an autogenerated mock type for the UnsuspendUserService type
+type UnsuspendUserService struct {
+	mock.Mock
+}
+
+type UnsuspendUserService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UnsuspendUserService) EXPECT() *UnsuspendUserService_Expecter {
+	return &UnsuspendUserService_Expecter{mock: &_m.Mock}
+}
+
+// UnsuspendUser provides a mock function with given fields: ctx, userID, actor, now
+func (_m *UnsuspendUserService) UnsuspendUser(ctx context.Context, userID uuid.UUID, actor string, now time.Time) error {
+	ret := _m.Called(ctx, userID, actor, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) error); ok {
+		r0 = rf(ctx, userID, actor, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnsuspendUserService_UnsuspendUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnsuspendUser'
+type UnsuspendUserService_UnsuspendUser_Call struct {
+	*mock.Call
+}
+
+// UnsuspendUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - actor string
+//   - now time.Time
+func (_e *UnsuspendUserService_Expecter) UnsuspendUser(ctx interface{}, userID interface{}, actor interface{}, now interface{}) *UnsuspendUserService_UnsuspendUser_Call {
+	return &UnsuspendUserService_UnsuspendUser_Call{Call: _e.mock.On("UnsuspendUser", ctx, userID, actor, now)}
+}
+
+func (_c *UnsuspendUserService_UnsuspendUser_Call) Run(run func(ctx context.Context, userID uuid.UUID, actor string, now time.Time)) *UnsuspendUserService_UnsuspendUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *UnsuspendUserService_UnsuspendUser_Call) Return(_a0 error) *UnsuspendUserService_UnsuspendUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UnsuspendUserService_UnsuspendUser_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, time.Time) error) *UnsuspendUserService_UnsuspendUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUnsuspendUserService creates a new instance of UnsuspendUserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUnsuspendUserService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UnsuspendUserService {
+	mock := &UnsuspendUserService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,6 +5,7 @@ package servicesmocks
 import (
 	context "context"
 
+	models "github.com/a-novel/auth-service/pkg/models"
 	mock "github.com/stretchr/testify/mock"
 
 	time "time"
@@ -23,25 +24,25 @@ func (_m *UpdateEmailService) EXPECT() *UpdateEmailService_Expecter {
 	return &UpdateEmailService_Expecter{mock: &_m.Mock}
 }
 
-// UpdateEmail provides a mock function with given fields: ctx, tokenRaw, newEmail, now
-func (_m *UpdateEmailService) UpdateEmail(ctx context.Context, tokenRaw string, newEmail string, now time.Time) (func() error, error) {
-	ret := _m.Called(ctx, tokenRaw, newEmail, now)
+// UpdateEmail provides a mock function with given fields: ctx, tokenRaw, newEmail, password, client, now
+func (_m *UpdateEmailService) UpdateEmail(ctx context.Context, tokenRaw string, newEmail string, password string, client models.ClientInfo, now time.Time) (func() error, error) {
+	ret := _m.Called(ctx, tokenRaw, newEmail, password, client, now)
 
 	var r0 func() error
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (func() error, error)); ok {
-		return rf(ctx, tokenRaw, newEmail, now)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, models.ClientInfo, time.Time) (func() error, error)); ok {
+		return rf(ctx, tokenRaw, newEmail, password, client, now)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) func() error); ok {
-		r0 = rf(ctx, tokenRaw, newEmail, now)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, models.ClientInfo, time.Time) func() error); ok {
+		r0 = rf(ctx, tokenRaw, newEmail, password, client, now)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(func() error)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
-		r1 = rf(ctx, tokenRaw, newEmail, now)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, models.ClientInfo, time.Time) error); ok {
+		r1 = rf(ctx, tokenRaw, newEmail, password, client, now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -58,14 +59,16 @@ type UpdateEmailService_UpdateEmail_Call struct {
 //   - ctx context.Context
 //   - tokenRaw string
 //   - newEmail string
+//   - password string
+//   - client models.ClientInfo
 //   - now time.Time
-func (_e *UpdateEmailService_Expecter) UpdateEmail(ctx interface{}, tokenRaw interface{}, newEmail interface{}, now interface{}) *UpdateEmailService_UpdateEmail_Call {
-	return &UpdateEmailService_UpdateEmail_Call{Call: _e.mock.On("UpdateEmail", ctx, tokenRaw, newEmail, now)}
+func (_e *UpdateEmailService_Expecter) UpdateEmail(ctx interface{}, tokenRaw interface{}, newEmail interface{}, password interface{}, client interface{}, now interface{}) *UpdateEmailService_UpdateEmail_Call {
+	return &UpdateEmailService_UpdateEmail_Call{Call: _e.mock.On("UpdateEmail", ctx, tokenRaw, newEmail, password, client, now)}
 }
 
-func (_c *UpdateEmailService_UpdateEmail_Call) Run(run func(ctx context.Context, tokenRaw string, newEmail string, now time.Time)) *UpdateEmailService_UpdateEmail_Call {
+func (_c *UpdateEmailService_UpdateEmail_Call) Run(run func(ctx context.Context, tokenRaw string, newEmail string, password string, client models.ClientInfo, now time.Time)) *UpdateEmailService_UpdateEmail_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(models.ClientInfo), args[5].(time.Time))
 	})
 	return _c
 }
@@ -75,7 +78,7 @@ func (_c *UpdateEmailService_UpdateEmail_Call) Return(_a0 func() error, _a1 erro
 	return _c
 }
 
-func (_c *UpdateEmailService_UpdateEmail_Call) RunAndReturn(run func(context.Context, string, string, time.Time) (func() error, error)) *UpdateEmailService_UpdateEmail_Call {
+func (_c *UpdateEmailService_UpdateEmail_Call) RunAndReturn(run func(context.Context, string, string, string, models.ClientInfo, time.Time) (func() error, error)) *UpdateEmailService_UpdateEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -24,13 +24,13 @@ func (_m *UpdatePasswordService) EXPECT() *UpdatePasswordService_Expecter {
 	return &UpdatePasswordService_Expecter{mock: &_m.Mock}
 }
 
-// UpdatePassword provides a mock function with given fields: ctx, form, now
-func (_m *UpdatePasswordService) UpdatePassword(ctx context.Context, form models.UpdatePasswordForm, now time.Time) error {
-	ret := _m.Called(ctx, form, now)
+// UpdatePassword provides a mock function with given fields: ctx, form, client, now
+func (_m *UpdatePasswordService) UpdatePassword(ctx context.Context, form models.UpdatePasswordForm, client models.ClientInfo, now time.Time) error {
+	ret := _m.Called(ctx, form, client, now)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UpdatePasswordForm, time.Time) error); ok {
-		r0 = rf(ctx, form, now)
+	if rf, ok := ret.Get(0).(func(context.Context, models.UpdatePasswordForm, models.ClientInfo, time.Time) error); ok {
+		r0 = rf(ctx, form, client, now)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -46,14 +46,15 @@ type UpdatePasswordService_UpdatePassword_Call struct {
 // UpdatePassword is a helper method to define mock.On call
 //   - ctx context.Context
 //   - form models.UpdatePasswordForm
+//   - client models.ClientInfo
 //   - now time.Time
-func (_e *UpdatePasswordService_Expecter) UpdatePassword(ctx interface{}, form interface{}, now interface{}) *UpdatePasswordService_UpdatePassword_Call {
-	return &UpdatePasswordService_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", ctx, form, now)}
+func (_e *UpdatePasswordService_Expecter) UpdatePassword(ctx interface{}, form interface{}, client interface{}, now interface{}) *UpdatePasswordService_UpdatePassword_Call {
+	return &UpdatePasswordService_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", ctx, form, client, now)}
 }
 
-func (_c *UpdatePasswordService_UpdatePassword_Call) Run(run func(ctx context.Context, form models.UpdatePasswordForm, now time.Time)) *UpdatePasswordService_UpdatePassword_Call {
+func (_c *UpdatePasswordService_UpdatePassword_Call) Run(run func(ctx context.Context, form models.UpdatePasswordForm, client models.ClientInfo, now time.Time)) *UpdatePasswordService_UpdatePassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(models.UpdatePasswordForm), args[2].(time.Time))
+		run(args[0].(context.Context), args[1].(models.UpdatePasswordForm), args[2].(models.ClientInfo), args[3].(time.Time))
 	})
 	return _c
 }
@@ -63,7 +64,7 @@ func (_c *UpdatePasswordService_UpdatePassword_Call) Return(_a0 error) *UpdatePa
 	return _c
 }
 
-func (_c *UpdatePasswordService_UpdatePassword_Call) RunAndReturn(run func(context.Context, models.UpdatePasswordForm, time.Time) error) *UpdatePasswordService_UpdatePassword_Call {
+func (_c *UpdatePasswordService_UpdatePassword_Call) RunAndReturn(run func(context.Context, models.UpdatePasswordForm, models.ClientInfo, time.Time) error) *UpdatePasswordService_UpdatePassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
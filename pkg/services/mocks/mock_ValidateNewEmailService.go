@@ -5,6 +5,7 @@ package servicesmocks
 import (
 	context "context"
 
+	models "github.com/a-novel/auth-service/pkg/models"
 	mock "github.com/stretchr/testify/mock"
 
 	time "time"
@@ -25,13 +26,13 @@ func (_m *ValidateNewEmailService) EXPECT() *ValidateNewEmailService_Expecter {
 	return &ValidateNewEmailService_Expecter{mock: &_m.Mock}
 }
 
-// ValidateNewEmail provides a mock function with given fields: ctx, id, code, now
-func (_m *ValidateNewEmailService) ValidateNewEmail(ctx context.Context, id uuid.UUID, code string, now time.Time) error {
-	ret := _m.Called(ctx, id, code, now)
+// ValidateNewEmail provides a mock function with given fields: ctx, id, code, client, now
+func (_m *ValidateNewEmailService) ValidateNewEmail(ctx context.Context, id uuid.UUID, code string, client models.ClientInfo, now time.Time) error {
+	ret := _m.Called(ctx, id, code, client, now)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) error); ok {
-		r0 = rf(ctx, id, code, now)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, models.ClientInfo, time.Time) error); ok {
+		r0 = rf(ctx, id, code, client, now)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -48,14 +49,15 @@ type ValidateNewEmailService_ValidateNewEmail_Call struct {
 //   - ctx context.Context
 //   - id uuid.UUID
 //   - code string
+//   - client models.ClientInfo
 //   - now time.Time
-func (_e *ValidateNewEmailService_Expecter) ValidateNewEmail(ctx interface{}, id interface{}, code interface{}, now interface{}) *ValidateNewEmailService_ValidateNewEmail_Call {
-	return &ValidateNewEmailService_ValidateNewEmail_Call{Call: _e.mock.On("ValidateNewEmail", ctx, id, code, now)}
+func (_e *ValidateNewEmailService_Expecter) ValidateNewEmail(ctx interface{}, id interface{}, code interface{}, client interface{}, now interface{}) *ValidateNewEmailService_ValidateNewEmail_Call {
+	return &ValidateNewEmailService_ValidateNewEmail_Call{Call: _e.mock.On("ValidateNewEmail", ctx, id, code, client, now)}
 }
 
-func (_c *ValidateNewEmailService_ValidateNewEmail_Call) Run(run func(ctx context.Context, id uuid.UUID, code string, now time.Time)) *ValidateNewEmailService_ValidateNewEmail_Call {
+func (_c *ValidateNewEmailService_ValidateNewEmail_Call) Run(run func(ctx context.Context, id uuid.UUID, code string, client models.ClientInfo, now time.Time)) *ValidateNewEmailService_ValidateNewEmail_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(time.Time))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(models.ClientInfo), args[4].(time.Time))
 	})
 	return _c
 }
@@ -65,7 +67,7 @@ func (_c *ValidateNewEmailService_ValidateNewEmail_Call) Return(_a0 error) *Vali
 	return _c
 }
 
-func (_c *ValidateNewEmailService_ValidateNewEmail_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, time.Time) error) *ValidateNewEmailService_ValidateNewEmail_Call {
+func (_c *ValidateNewEmailService_ValidateNewEmail_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, models.ClientInfo, time.Time) error) *ValidateNewEmailService_ValidateNewEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
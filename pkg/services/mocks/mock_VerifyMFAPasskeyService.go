@@ -0,0 +1,97 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// VerifyMFAPasskeyService is an autogenerated mock type for the VerifyMFAPasskeyService type
+type VerifyMFAPasskeyService struct {
+	mock.Mock
+}
+
+type VerifyMFAPasskeyService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *VerifyMFAPasskeyService) EXPECT() *VerifyMFAPasskeyService_Expecter {
+	return &VerifyMFAPasskeyService_Expecter{mock: &_m.Mock}
+}
+
+// VerifyMFAPasskey provides a mock function with given fields: ctx, challenge, form, client, now
+func (_m *VerifyMFAPasskeyService) VerifyMFAPasskey(ctx context.Context, challenge string, form models.PasskeyAssertionForm, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error) {
+	ret := _m.Called(ctx, challenge, form, client, now)
+
+	var r0 *models.UserTokenStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PasskeyAssertionForm, models.ClientInfo, time.Time) (*models.UserTokenStatus, error)); ok {
+		return rf(ctx, challenge, form, client, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PasskeyAssertionForm, models.ClientInfo, time.Time) *models.UserTokenStatus); ok {
+		r0 = rf(ctx, challenge, form, client, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserTokenStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.PasskeyAssertionForm, models.ClientInfo, time.Time) error); ok {
+		r1 = rf(ctx, challenge, form, client, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyMFAPasskeyService_VerifyMFAPasskey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyMFAPasskey'
+type VerifyMFAPasskeyService_VerifyMFAPasskey_Call struct {
+	*mock.Call
+}
+
+// VerifyMFAPasskey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - challenge string
+//   - form models.PasskeyAssertionForm
+//   - client models.ClientInfo
+//   - now time.Time
+func (_e *VerifyMFAPasskeyService_Expecter) VerifyMFAPasskey(ctx interface{}, challenge interface{}, form interface{}, client interface{}, now interface{}) *VerifyMFAPasskeyService_VerifyMFAPasskey_Call {
+	return &VerifyMFAPasskeyService_VerifyMFAPasskey_Call{Call: _e.mock.On("VerifyMFAPasskey", ctx, challenge, form, client, now)}
+}
+
+func (_c *VerifyMFAPasskeyService_VerifyMFAPasskey_Call) Run(run func(ctx context.Context, challenge string, form models.PasskeyAssertionForm, client models.ClientInfo, now time.Time)) *VerifyMFAPasskeyService_VerifyMFAPasskey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.PasskeyAssertionForm), args[3].(models.ClientInfo), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *VerifyMFAPasskeyService_VerifyMFAPasskey_Call) Return(_a0 *models.UserTokenStatus, _a1 error) *VerifyMFAPasskeyService_VerifyMFAPasskey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VerifyMFAPasskeyService_VerifyMFAPasskey_Call) RunAndReturn(run func(context.Context, string, models.PasskeyAssertionForm, models.ClientInfo, time.Time) (*models.UserTokenStatus, error)) *VerifyMFAPasskeyService_VerifyMFAPasskey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewVerifyMFAPasskeyService creates a new instance of VerifyMFAPasskeyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewVerifyMFAPasskeyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *VerifyMFAPasskeyService {
+	mock := &VerifyMFAPasskeyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
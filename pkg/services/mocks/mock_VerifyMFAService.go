@@ -0,0 +1,97 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/auth-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// VerifyMFAService is an autogenerated mock type for the VerifyMFAService type
+type VerifyMFAService struct {
+	mock.Mock
+}
+
+type VerifyMFAService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *VerifyMFAService) EXPECT() *VerifyMFAService_Expecter {
+	return &VerifyMFAService_Expecter{mock: &_m.Mock}
+}
+
+// VerifyMFA provides a mock function with given fields: ctx, challenge, code, client, now
+func (_m *VerifyMFAService) VerifyMFA(ctx context.Context, challenge string, code string, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error) {
+	ret := _m.Called(ctx, challenge, code, client, now)
+
+	var r0 *models.UserTokenStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.ClientInfo, time.Time) (*models.UserTokenStatus, error)); ok {
+		return rf(ctx, challenge, code, client, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.ClientInfo, time.Time) *models.UserTokenStatus); ok {
+		r0 = rf(ctx, challenge, code, client, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserTokenStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.ClientInfo, time.Time) error); ok {
+		r1 = rf(ctx, challenge, code, client, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyMFAService_VerifyMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyMFA'
+type VerifyMFAService_VerifyMFA_Call struct {
+	*mock.Call
+}
+
+// VerifyMFA is a helper method to define mock.On call
+//   - ctx context.Context
+//   - challenge string
+//   - code string
+//   - client models.ClientInfo
+//   - now time.Time
+func (_e *VerifyMFAService_Expecter) VerifyMFA(ctx interface{}, challenge interface{}, code interface{}, client interface{}, now interface{}) *VerifyMFAService_VerifyMFA_Call {
+	return &VerifyMFAService_VerifyMFA_Call{Call: _e.mock.On("VerifyMFA", ctx, challenge, code, client, now)}
+}
+
+func (_c *VerifyMFAService_VerifyMFA_Call) Run(run func(ctx context.Context, challenge string, code string, client models.ClientInfo, now time.Time)) *VerifyMFAService_VerifyMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(models.ClientInfo), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *VerifyMFAService_VerifyMFA_Call) Return(_a0 *models.UserTokenStatus, _a1 error) *VerifyMFAService_VerifyMFA_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VerifyMFAService_VerifyMFA_Call) RunAndReturn(run func(context.Context, string, string, models.ClientInfo, time.Time) (*models.UserTokenStatus, error)) *VerifyMFAService_VerifyMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewVerifyMFAService creates a new instance of VerifyMFAService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewVerifyMFAService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *VerifyMFAService {
+	mock := &VerifyMFAService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
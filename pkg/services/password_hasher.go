@@ -0,0 +1,177 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	goerrors "errors"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
+
+// Algorithms supported by the PasswordHasher.
+const (
+	PasswordAlgorithmArgon2id = "argon2id"
+	PasswordAlgorithmBcrypt   = "bcrypt"
+)
+
+const (
+	argon2idSaltLength = 16
+	argon2idKeyLength  = 32
+)
+
+// PasswordHashing configures how new passwords are hashed.
+type PasswordHashing struct {
+	// Algorithm is either PasswordAlgorithmArgon2id or PasswordAlgorithmBcrypt.
+	Algorithm  string
+	BcryptCost int
+	// Argon2idMemory is the amount of memory used to hash a password, in KiB.
+	Argon2idMemory  uint32
+	Argon2idTime    uint32
+	Argon2idThreads uint8
+}
+
+type PasswordHasher interface {
+	// Hash returns the encoded hash of a password. The encoded value holds the algorithm and parameters used, so it
+	// can still be verified after the configuration changes.
+	Hash(password string) (string, error)
+	// Verify checks a password against an encoded hash. When the password matches, outdated is true if the hash was
+	// not computed with the current algorithm and parameters, and should be replaced.
+	Verify(password string, hashed string) (ok bool, outdated bool, err error)
+}
+
+func NewPasswordHasher(params PasswordHashing) PasswordHasher {
+	return &passwordHasherImpl{params: params}
+}
+
+type passwordHasherImpl struct {
+	params PasswordHashing
+}
+
+func (hasher *passwordHasherImpl) Hash(password string) (string, error) {
+	switch hasher.params.Algorithm {
+	case PasswordAlgorithmArgon2id:
+		salt := make([]byte, argon2idSaltLength)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+
+		key := argon2.IDKey(
+			[]byte(password), salt,
+			hasher.params.Argon2idTime, hasher.params.Argon2idMemory, hasher.params.Argon2idThreads,
+			argon2idKeyLength,
+		)
+
+		return encodeArgon2id(argon2idHash{
+			memory:  hasher.params.Argon2idMemory,
+			time:    hasher.params.Argon2idTime,
+			threads: hasher.params.Argon2idThreads,
+			salt:    salt,
+			key:     key,
+		}), nil
+	case PasswordAlgorithmBcrypt:
+		hashed, err := bcrypt.GenerateFromPassword([]byte(password), hasher.params.BcryptCost)
+		if err != nil {
+			return "", err
+		}
+
+		return string(hashed), nil
+	default:
+		return "", goerrors.Join(ErrUnsupportedPasswordHash, fmt.Errorf("unknown algorithm %q", hasher.params.Algorithm))
+	}
+}
+
+func (hasher *passwordHasherImpl) Verify(password string, hashed string) (bool, bool, error) {
+	if strings.HasPrefix(hashed, "$"+PasswordAlgorithmArgon2id+"$") {
+		decoded, err := decodeArgon2id(hashed)
+		if err != nil {
+			return false, false, err
+		}
+
+		key := argon2.IDKey([]byte(password), decoded.salt, decoded.time, decoded.memory, decoded.threads, uint32(len(decoded.key)))
+		if subtle.ConstantTimeCompare(key, decoded.key) != 1 {
+			return false, false, nil
+		}
+
+		outdated := hasher.params.Algorithm != PasswordAlgorithmArgon2id ||
+			decoded.memory != hasher.params.Argon2idMemory ||
+			decoded.time != hasher.params.Argon2idTime ||
+			decoded.threads != hasher.params.Argon2idThreads ||
+			len(decoded.key) != argon2idKeyLength
+
+		return true, outdated, nil
+	}
+
+	// Any other format is expected to be bcrypt, which hashes are prefixed with their own version identifier.
+	if err := bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password)); err != nil {
+		if goerrors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, false, nil
+		}
+
+		return false, false, goerrors.Join(ErrUnsupportedPasswordHash, err)
+	}
+
+	cost, err := bcrypt.Cost([]byte(hashed))
+	if err != nil {
+		return false, false, goerrors.Join(ErrUnsupportedPasswordHash, err)
+	}
+
+	outdated := hasher.params.Algorithm != PasswordAlgorithmBcrypt || cost != hasher.params.BcryptCost
+
+	return true, outdated, nil
+}
+
+type argon2idHash struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+// encodeArgon2id formats an argon2id hash in the PHC string format, also used by the reference implementation:
+//
+//	$argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+func encodeArgon2id(hash argon2idHash) string {
+	return fmt.Sprintf(
+		"$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		PasswordAlgorithmArgon2id, argon2.Version, hash.memory, hash.time, hash.threads,
+		base64.RawStdEncoding.EncodeToString(hash.salt), base64.RawStdEncoding.EncodeToString(hash.key),
+	)
+}
+
+func decodeArgon2id(encoded string) (*argon2idHash, error) {
+	// The leading "$" produces an empty first part.
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return nil, goerrors.Join(ErrUnsupportedPasswordHash, fmt.Errorf("expected 6 parts, got %d", len(parts)))
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return nil, goerrors.Join(ErrUnsupportedPasswordHash, err)
+	}
+	if version != argon2.Version {
+		return nil, goerrors.Join(ErrUnsupportedPasswordHash, fmt.Errorf("unknown argon2 version %d", version))
+	}
+
+	hash := new(argon2idHash)
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &hash.memory, &hash.time, &hash.threads); err != nil {
+		return nil, goerrors.Join(ErrUnsupportedPasswordHash, err)
+	}
+
+	var err error
+	if hash.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, goerrors.Join(ErrUnsupportedPasswordHash, err)
+	}
+	if hash.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return nil, goerrors.Join(ErrUnsupportedPasswordHash, err)
+	}
+	if len(hash.key) == 0 || hash.time == 0 || hash.threads == 0 {
+		return nil, goerrors.Join(ErrUnsupportedPasswordHash, fmt.Errorf("invalid argon2id parameters"))
+	}
+
+	return hash, nil
+}
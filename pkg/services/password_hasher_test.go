@@ -0,0 +1,202 @@
+package services_test
+
+import (
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"testing"
+)
+
+func TestPasswordHasher(t *testing.T) {
+	argon2id := services.PasswordHashing{
+		Algorithm:       services.PasswordAlgorithmArgon2id,
+		Argon2idMemory:  64,
+		Argon2idTime:    1,
+		Argon2idThreads: 1,
+	}
+	bcryptMin := services.PasswordHashing{
+		Algorithm:  services.PasswordAlgorithmBcrypt,
+		BcryptCost: bcrypt.MinCost,
+	}
+
+	data := []struct {
+		name string
+
+		// hashWith is used to hash the password, then verifyWith checks it.
+		hashWith   services.PasswordHashing
+		verifyWith services.PasswordHashing
+		password   string
+		verify     string
+
+		expectPrefix   string
+		expectOK       bool
+		expectOutdated bool
+	}{
+		{
+			name:         "Argon2id",
+			hashWith:     argon2id,
+			verifyWith:   argon2id,
+			password:     password,
+			verify:       password,
+			expectPrefix: "$argon2id$v=19$m=64,t=1,p=1$",
+			expectOK:     true,
+		},
+		{
+			name:         "Argon2id/WrongPassword",
+			hashWith:     argon2id,
+			verifyWith:   argon2id,
+			password:     password,
+			verify:       "fake-password",
+			expectPrefix: "$argon2id$",
+		},
+		{
+			name:     "Argon2id/OutdatedMemory",
+			hashWith: argon2id,
+			verifyWith: services.PasswordHashing{
+				Algorithm:       services.PasswordAlgorithmArgon2id,
+				Argon2idMemory:  128,
+				Argon2idTime:    1,
+				Argon2idThreads: 1,
+			},
+			password:       password,
+			verify:         password,
+			expectPrefix:   "$argon2id$",
+			expectOK:       true,
+			expectOutdated: true,
+		},
+		{
+			name:           "Argon2id/OutdatedAlgorithm",
+			hashWith:       argon2id,
+			verifyWith:     bcryptMin,
+			password:       password,
+			verify:         password,
+			expectPrefix:   "$argon2id$",
+			expectOK:       true,
+			expectOutdated: true,
+		},
+		{
+			name:         "Bcrypt",
+			hashWith:     bcryptMin,
+			verifyWith:   bcryptMin,
+			password:     password,
+			verify:       password,
+			expectPrefix: "$2a$04$",
+			expectOK:     true,
+		},
+		{
+			name:         "Bcrypt/WrongPassword",
+			hashWith:     bcryptMin,
+			verifyWith:   bcryptMin,
+			password:     password,
+			verify:       "fake-password",
+			expectPrefix: "$2a$",
+		},
+		{
+			name:     "Bcrypt/OutdatedCost",
+			hashWith: bcryptMin,
+			verifyWith: services.PasswordHashing{
+				Algorithm:  services.PasswordAlgorithmBcrypt,
+				BcryptCost: bcrypt.MinCost + 1,
+			},
+			password:       password,
+			verify:         password,
+			expectPrefix:   "$2a$",
+			expectOK:       true,
+			expectOutdated: true,
+		},
+		{
+			name:           "Bcrypt/OutdatedAlgorithm",
+			hashWith:       bcryptMin,
+			verifyWith:     argon2id,
+			password:       password,
+			verify:         password,
+			expectPrefix:   "$2a$",
+			expectOK:       true,
+			expectOutdated: true,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			hashed, err := services.NewPasswordHasher(d.hashWith).Hash(d.password)
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(hashed, d.expectPrefix), hashed)
+
+			ok, outdated, err := services.NewPasswordHasher(d.verifyWith).Verify(d.verify, hashed)
+			require.NoError(t, err)
+			require.Equal(t, d.expectOK, ok)
+			require.Equal(t, d.expectOutdated, outdated)
+		})
+	}
+}
+
+func TestPasswordHasher_Salt(t *testing.T) {
+	hasher := services.NewPasswordHasher(services.PasswordHashing{
+		Algorithm:       services.PasswordAlgorithmArgon2id,
+		Argon2idMemory:  64,
+		Argon2idTime:    1,
+		Argon2idThreads: 1,
+	})
+
+	first, err := hasher.Hash(password)
+	require.NoError(t, err)
+	second, err := hasher.Hash(password)
+	require.NoError(t, err)
+
+	require.NotEqual(t, first, second)
+}
+
+func TestPasswordHasher_Errors(t *testing.T) {
+	t.Run("Hash/UnknownAlgorithm", func(t *testing.T) {
+		_, err := services.NewPasswordHasher(services.PasswordHashing{Algorithm: "md5"}).Hash(password)
+		require.ErrorIs(t, err, services.ErrUnsupportedPasswordHash)
+	})
+
+	hasher := services.NewPasswordHasher(services.PasswordHashing{
+		Algorithm:  services.PasswordAlgorithmBcrypt,
+		BcryptCost: bcrypt.MinCost,
+	})
+
+	data := []struct {
+		name string
+
+		hashed string
+	}{
+		{
+			name:   "Empty",
+			hashed: "",
+		},
+		{
+			name:   "UnknownFormat",
+			hashed: "$md5$c29tZXNhbHQ$aGFzaA",
+		},
+		{
+			name:   "Argon2id/MissingParts",
+			hashed: "$argon2id$v=19$m=64,t=1,p=1$c29tZXNhbHQ",
+		},
+		{
+			name:   "Argon2id/UnknownVersion",
+			hashed: "$argon2id$v=16$m=64,t=1,p=1$c29tZXNhbHQ$aGFzaA",
+		},
+		{
+			name:   "Argon2id/InvalidParameters",
+			hashed: "$argon2id$v=19$m=64,t=0,p=1$c29tZXNhbHQ$aGFzaA",
+		},
+		{
+			name:   "Argon2id/InvalidSalt",
+			hashed: "$argon2id$v=19$m=64,t=1,p=1$not base64$aGFzaA",
+		},
+		{
+			name:   "Argon2id/InvalidKey",
+			hashed: "$argon2id$v=19$m=64,t=1,p=1$c29tZXNhbHQ$not base64",
+		},
+	}
+
+	for _, d := range data {
+		t.Run("Verify/"+d.name, func(t *testing.T) {
+			_, _, err := hasher.Verify(password, d.hashed)
+			require.ErrorIs(t, err, services.ErrUnsupportedPasswordHash)
+		})
+	}
+}
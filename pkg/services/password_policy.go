@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"crypto/sha1"
+	goerrors "errors"
+	"fmt"
+	"github.com/a-novel/auth-service/pkg/dao"
+	goframework "github.com/a-novel/go-framework"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Names of the password policy rules, as reported by PasswordPolicyError.
+const (
+	PasswordRuleMinLength = "minLength"
+	PasswordRuleMaxLength = "maxLength"
+	PasswordRuleLowercase = "lowercase"
+	PasswordRuleUppercase = "uppercase"
+	PasswordRuleDigit     = "digit"
+	PasswordRuleSymbol    = "symbol"
+	PasswordRuleStrength  = "strength"
+	PasswordRuleUserInfo  = "userInfo"
+	PasswordRuleBreached  = "breached"
+)
+
+// Minimum length of a user information, for it to be searched in a password. Shorter values would reject too many
+// legit passwords.
+const minPasswordUserInfoLength = 3
+
+// PasswordPolicyError is returned when a password fails one or more rules of the policy. It matches
+// ErrInvalidPassword.
+type PasswordPolicyError struct {
+	// Rules lists the names of every failed rule, so they can be displayed to the user.
+	Rules []string
+}
+
+func (err *PasswordPolicyError) Error() string {
+	return fmt.Sprintf("%s, failed rules: %s", ErrInvalidPassword.Error(), strings.Join(err.Rules, ", "))
+}
+
+func (err *PasswordPolicyError) Unwrap() error {
+	return ErrInvalidPassword
+}
+
+// PasswordPolicy describes the rules a new password must follow.
+//
+// Length is counted in characters. MinStrength is compared with the score returned by EstimatePasswordStrength.
+// When RejectUserInfo is set, passwords that contain the email, name, slug or username of the user are rejected.
+type PasswordPolicy struct {
+	MinLength        int
+	MaxLength        int
+	RequireLowercase bool
+	RequireUppercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	MinStrength      int
+	RejectUserInfo   bool
+}
+
+// PasswordUserInfo holds the personal information a password should not contain.
+type PasswordUserInfo struct {
+	Email     string
+	FirstName string
+	LastName  string
+	Slug      string
+	Username  string
+}
+
+// values returns every lowercase information that is long enough to be searched in a password.
+func (info PasswordUserInfo) values() []string {
+	candidates := []string{info.Email, info.FirstName, info.LastName, info.Slug, info.Username}
+
+	if emailUser, _, ok := strings.Cut(info.Email, "@"); ok {
+		candidates = append(candidates, emailUser)
+	}
+	// Slugs are usually made of the user names, so their parts are as guessable as the full value.
+	candidates = append(candidates, strings.Split(info.Slug, "-")...)
+
+	values := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		if utf8.RuneCountInString(candidate) >= minPasswordUserInfoLength {
+			values = append(values, strings.ToLower(candidate))
+		}
+	}
+
+	return values
+}
+
+// EstimatePasswordStrength returns a score, from 0 (very weak) to 4 (very strong), that estimates how hard a password
+// is to guess by brute force.
+//
+// The estimation is based on the entropy of the password, computed from the variety of characters it uses. Repeated
+// characters and sequences (like "aaa" or "1234") barely add to the entropy.
+func EstimatePasswordStrength(password string) int {
+	var pool int
+	var hasLower, hasUpper, hasDigit, hasSymbol, hasOther bool
+
+	length := 0.0
+	previous := rune(-1)
+	for _, char := range password {
+		switch {
+		case char >= 'a' && char <= 'z':
+			hasLower = true
+		case char >= 'A' && char <= 'Z':
+			hasUpper = true
+		case char >= '0' && char <= '9':
+			hasDigit = true
+		case char < unicode.MaxASCII:
+			hasSymbol = true
+		default:
+			hasOther = true
+		}
+
+		if char == previous || char == previous+1 || char == previous-1 {
+			length += 0.25
+		} else {
+			length++
+		}
+
+		previous = char
+	}
+
+	for _, class := range []struct {
+		present bool
+		size    int
+	}{{hasLower, 26}, {hasUpper, 26}, {hasDigit, 10}, {hasSymbol, 33}, {hasOther, 100}} {
+		if class.present {
+			pool += class.size
+		}
+	}
+
+	if pool == 0 {
+		return 0
+	}
+
+	switch entropy := length * math.Log2(float64(pool)); {
+	case entropy < 28:
+		return 0
+	case entropy < 36:
+		return 1
+	case entropy < 60:
+		return 2
+	case entropy < 80:
+		return 3
+	default:
+		return 4
+	}
+}
+
+type CheckPasswordPolicyService interface {
+	// CheckPasswordPolicy ensures a new password follows the policy. If it does not, the returned error holds a
+	// PasswordPolicyError, that lists every failed rule.
+	CheckPasswordPolicy(ctx context.Context, password string, user PasswordUserInfo) error
+}
+
+// NewCheckPasswordPolicyService creates a new password policy checker. When breachedPasswordsDAO is nil, passwords
+// are not checked against known data breaches.
+func NewCheckPasswordPolicyService(
+	breachedPasswordsDAO dao.BreachedPasswordsRepository,
+	policy PasswordPolicy,
+) CheckPasswordPolicyService {
+	return &checkPasswordPolicyServiceImpl{
+		breachedPasswordsDAO: breachedPasswordsDAO,
+		policy:               policy,
+	}
+}
+
+type checkPasswordPolicyServiceImpl struct {
+	breachedPasswordsDAO dao.BreachedPasswordsRepository
+	policy               PasswordPolicy
+}
+
+func (s *checkPasswordPolicyServiceImpl) CheckPasswordPolicy(ctx context.Context, password string, user PasswordUserInfo) error {
+	var rules []string
+
+	length := utf8.RuneCountInString(password)
+	if length < s.policy.MinLength {
+		rules = append(rules, PasswordRuleMinLength)
+	}
+	if s.policy.MaxLength > 0 && length > s.policy.MaxLength {
+		rules = append(rules, PasswordRuleMaxLength)
+	}
+
+	if s.policy.RequireLowercase && !strings.ContainsFunc(password, unicode.IsLower) {
+		rules = append(rules, PasswordRuleLowercase)
+	}
+	if s.policy.RequireUppercase && !strings.ContainsFunc(password, unicode.IsUpper) {
+		rules = append(rules, PasswordRuleUppercase)
+	}
+	if s.policy.RequireDigit && !strings.ContainsFunc(password, unicode.IsDigit) {
+		rules = append(rules, PasswordRuleDigit)
+	}
+	if s.policy.RequireSymbol && !strings.ContainsFunc(password, isPasswordSymbol) {
+		rules = append(rules, PasswordRuleSymbol)
+	}
+
+	if EstimatePasswordStrength(password) < s.policy.MinStrength {
+		rules = append(rules, PasswordRuleStrength)
+	}
+
+	if s.policy.RejectUserInfo {
+		lowerPassword := strings.ToLower(password)
+		for _, value := range user.values() {
+			if strings.Contains(lowerPassword, value) {
+				rules = append(rules, PasswordRuleUserInfo)
+				break
+			}
+		}
+	}
+
+	if s.breachedPasswordsDAO != nil {
+		breached, err := s.breachedPasswordsDAO.IsBreached(ctx, sha1.Sum([]byte(password)))
+		if err != nil {
+			return goerrors.Join(ErrCheckBreachedPassword, err)
+		}
+		if breached {
+			rules = append(rules, PasswordRuleBreached)
+		}
+	}
+
+	if len(rules) > 0 {
+		return goerrors.Join(goframework.ErrInvalidEntity, &PasswordPolicyError{Rules: rules})
+	}
+
+	return nil
+}
+
+func isPasswordSymbol(char rune) bool {
+	return !unicode.IsLetter(char) && !unicode.IsDigit(char) && !unicode.IsSpace(char)
+}
@@ -0,0 +1,262 @@
+package services_test
+
+import (
+	"context"
+	"crypto/sha1"
+	goerrors "errors"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/services"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestEstimatePasswordStrength(t *testing.T) {
+	data := []struct {
+		name string
+
+		password string
+
+		expect int
+	}{
+		{
+			name:     "Empty",
+			password: "",
+			expect:   0,
+		},
+		{
+			name:     "Repeated",
+			password: "aaaaaaaaaaaaaaaa",
+			expect:   0,
+		},
+		{
+			name:     "Sequence",
+			password: "abcdefghijklmnop",
+			expect:   0,
+		},
+		{
+			name:     "DigitsSequence",
+			password: "12345678",
+			expect:   0,
+		},
+		{
+			name:     "ShortLowercase",
+			password: "password",
+			expect:   1,
+		},
+		{
+			name:     "Lowercase",
+			password: "dragonfly",
+			expect:   2,
+		},
+		{
+			name:     "Mixed",
+			password: "Tr0ub4dour&3",
+			expect:   3,
+		},
+		{
+			name:     "Passphrase",
+			password: "correct horse battery staple",
+			expect:   4,
+		},
+		{
+			name:     "NonASCII",
+			password: "Écrivain-2024!",
+			expect:   4,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			require.Equal(t, d.expect, services.EstimatePasswordStrength(d.password))
+		})
+	}
+}
+
+func TestCheckPasswordPolicy(t *testing.T) {
+	user := services.PasswordUserInfo{
+		Email:     "elon.musk@domain.com",
+		FirstName: "Elon",
+		LastName:  "Musk",
+		Slug:      "elon-musk",
+		Username:  "the-boss",
+	}
+
+	defaultPolicy := services.PasswordPolicy{
+		MinLength:      10,
+		MaxLength:      64,
+		MinStrength:    2,
+		RejectUserInfo: true,
+	}
+
+	data := []struct {
+		name string
+
+		password string
+		policy   services.PasswordPolicy
+
+		shouldCallIsBreached bool
+		isBreached           bool
+		isBreachedErr        error
+
+		expectRules []string
+		expectErr   error
+	}{
+		{
+			name:                 "Success",
+			password:             "correct horse battery staple",
+			policy:               defaultPolicy,
+			shouldCallIsBreached: true,
+		},
+		{
+			name:     "Success/CompositionRules",
+			password: "Tr0ub4dour&3",
+			policy: services.PasswordPolicy{
+				MinLength:        10,
+				RequireLowercase: true,
+				RequireUppercase: true,
+				RequireDigit:     true,
+				RequireSymbol:    true,
+			},
+			shouldCallIsBreached: true,
+		},
+		{
+			name:                 "Error/TooShort",
+			password:             "Tr0ub4d&3",
+			policy:               defaultPolicy,
+			shouldCallIsBreached: true,
+			expectRules:          []string{services.PasswordRuleMinLength},
+			expectErr:            goframework.ErrInvalidEntity,
+		},
+		{
+			name:                 "Error/TooLong",
+			password:             strings.Repeat("correct horse battery staple ", 3),
+			policy:               defaultPolicy,
+			shouldCallIsBreached: true,
+			expectRules:          []string{services.PasswordRuleMaxLength},
+			expectErr:            goframework.ErrInvalidEntity,
+		},
+		{
+			name:     "Error/CompositionRules",
+			password: "correct horse battery staple",
+			policy: services.PasswordPolicy{
+				RequireLowercase: true,
+				RequireUppercase: true,
+				RequireDigit:     true,
+				RequireSymbol:    true,
+			},
+			shouldCallIsBreached: true,
+			expectRules: []string{
+				services.PasswordRuleUppercase,
+				services.PasswordRuleDigit,
+				services.PasswordRuleSymbol,
+			},
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name:                 "Error/TooWeak",
+			password:             "aaaaaaaaaaaaaaaa",
+			policy:               defaultPolicy,
+			shouldCallIsBreached: true,
+			expectRules:          []string{services.PasswordRuleStrength},
+			expectErr:            goframework.ErrInvalidEntity,
+		},
+		{
+			name:                 "Error/ContainsName",
+			password:             "i am MUSK, the rocket man",
+			policy:               defaultPolicy,
+			shouldCallIsBreached: true,
+			expectRules:          []string{services.PasswordRuleUserInfo},
+			expectErr:            goframework.ErrInvalidEntity,
+		},
+		{
+			name:                 "Error/ContainsEmailUser",
+			password:             "my mail is elon.musk at home",
+			policy:               defaultPolicy,
+			shouldCallIsBreached: true,
+			expectRules:          []string{services.PasswordRuleUserInfo},
+			expectErr:            goframework.ErrInvalidEntity,
+		},
+		{
+			name:                 "Error/ContainsUsername",
+			password:             "who is the-boss now?",
+			policy:               defaultPolicy,
+			shouldCallIsBreached: true,
+			expectRules:          []string{services.PasswordRuleUserInfo},
+			expectErr:            goframework.ErrInvalidEntity,
+		},
+		{
+			name:     "Success/UserInfoAllowed",
+			password: "i am MUSK, the rocket man",
+			policy: services.PasswordPolicy{
+				MinLength:   10,
+				MinStrength: 2,
+			},
+			shouldCallIsBreached: true,
+		},
+		{
+			name:                 "Error/Breached",
+			password:             "correct horse battery staple",
+			policy:               defaultPolicy,
+			shouldCallIsBreached: true,
+			isBreached:           true,
+			expectRules:          []string{services.PasswordRuleBreached},
+			expectErr:            goframework.ErrInvalidEntity,
+		},
+		{
+			name:                 "Error/SeveralRules",
+			password:             "musk",
+			policy:               defaultPolicy,
+			shouldCallIsBreached: true,
+			isBreached:           true,
+			expectRules: []string{
+				services.PasswordRuleMinLength,
+				services.PasswordRuleStrength,
+				services.PasswordRuleUserInfo,
+				services.PasswordRuleBreached,
+			},
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name:                 "Error/IsBreachedFailure",
+			password:             "correct horse battery staple",
+			policy:               defaultPolicy,
+			shouldCallIsBreached: true,
+			isBreachedErr:        fooErr,
+			expectErr:            fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			breachedPasswordsDAO := daomocks.NewBreachedPasswordsRepository(t)
+
+			if d.shouldCallIsBreached {
+				breachedPasswordsDAO.
+					On("IsBreached", context.Background(), sha1.Sum([]byte(d.password))).
+					Return(d.isBreached, d.isBreachedErr)
+			}
+
+			service := services.NewCheckPasswordPolicyService(breachedPasswordsDAO, d.policy)
+			err := service.CheckPasswordPolicy(context.Background(), d.password, user)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			if d.expectRules != nil {
+				var policyErr *services.PasswordPolicyError
+				require.True(t, goerrors.As(err, &policyErr))
+				require.Equal(t, d.expectRules, policyErr.Rules)
+				require.ErrorIs(t, err, services.ErrInvalidPassword)
+			}
+
+			breachedPasswordsDAO.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCheckPasswordPolicy_NoBreachedPasswords(t *testing.T) {
+	service := services.NewCheckPasswordPolicyService(nil, services.PasswordPolicy{MinLength: 10})
+
+	require.NoError(t, service.CheckPasswordPolicy(context.Background(), "correct horse battery staple", services.PasswordUserInfo{}))
+}
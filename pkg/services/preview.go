@@ -5,6 +5,7 @@ import (
 	goerrors "errors"
 	"github.com/a-novel/auth-service/pkg/dao"
 	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/bunovel"
 	"github.com/samber/lo"
 )
 
@@ -13,16 +14,22 @@ type PreviewService interface {
 	Preview(ctx context.Context, slug string) (*models.UserPreview, error)
 }
 
-func NewPreviewService(profileDAO dao.ProfileRepository, identityDAO dao.IdentityRepository) PreviewService {
+func NewPreviewService(
+	credentialsDAO dao.CredentialsRepository,
+	profileDAO dao.ProfileRepository,
+	identityDAO dao.IdentityRepository,
+) PreviewService {
 	return &previewServiceImpl{
-		profileDAO:  profileDAO,
-		identityDAO: identityDAO,
+		credentialsDAO: credentialsDAO,
+		profileDAO:     profileDAO,
+		identityDAO:    identityDAO,
 	}
 }
 
 type previewServiceImpl struct {
-	profileDAO  dao.ProfileRepository
-	identityDAO dao.IdentityRepository
+	credentialsDAO dao.CredentialsRepository
+	profileDAO     dao.ProfileRepository
+	identityDAO    dao.IdentityRepository
 }
 
 func (s *previewServiceImpl) Preview(ctx context.Context, slug string) (*models.UserPreview, error) {
@@ -31,6 +38,15 @@ func (s *previewServiceImpl) Preview(ctx context.Context, slug string) (*models.
 		return nil, goerrors.Join(ErrGetProfileBySlug, err)
 	}
 
+	credentials, err := s.credentialsDAO.GetCredentials(ctx, profile.ID)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetCredentials, err)
+	}
+	// Deleted accounts are hidden until they are purged.
+	if credentials.DeletedAt != nil {
+		return nil, goerrors.Join(bunovel.ErrNotFound, ErrAccountDeleted)
+	}
+
 	identity, err := s.identityDAO.GetIdentity(ctx, profile.ID)
 	if err != nil {
 		return nil, goerrors.Join(ErrGetIdentity, err)
@@ -23,6 +23,10 @@ func TestPreview(t *testing.T) {
 		profileDAO           *dao.ProfileModel
 		profileDAOErr        error
 
+		shouldCallCredentialsDAO bool
+		credentialsDAO           *dao.CredentialsModel
+		credentialsDAOErr        error
+
 		shouldCallIdentityDAO bool
 		identityDAO           *dao.IdentityModel
 		identityDAOErr        error
@@ -40,7 +44,9 @@ func TestPreview(t *testing.T) {
 					Slug: "slug",
 				},
 			},
-			shouldCallIdentityDAO: true,
+			shouldCallCredentialsDAO: true,
+			credentialsDAO:           &dao.CredentialsModel{},
+			shouldCallIdentityDAO:    true,
 			identityDAO: &dao.IdentityModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
 				IdentityModelCore: dao.IdentityModelCore{
@@ -69,7 +75,9 @@ func TestPreview(t *testing.T) {
 					Slug:     "slug",
 				},
 			},
-			shouldCallIdentityDAO: true,
+			shouldCallCredentialsDAO: true,
+			credentialsDAO:           &dao.CredentialsModel{},
+			shouldCallIdentityDAO:    true,
 			identityDAO: &dao.IdentityModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
 				IdentityModelCore: dao.IdentityModelCore{
@@ -96,9 +104,41 @@ func TestPreview(t *testing.T) {
 					Slug: "slug",
 				},
 			},
-			shouldCallIdentityDAO: true,
-			identityDAOErr:        fooErr,
-			expectErr:             fooErr,
+			shouldCallCredentialsDAO: true,
+			credentialsDAO:           &dao.CredentialsModel{},
+			shouldCallIdentityDAO:    true,
+			identityDAOErr:           fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                 "Error/AccountDeleted",
+			slug:                 "slug",
+			shouldCallProfileDAO: true,
+			profileDAO: &dao.ProfileModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
+				ProfileModelCore: dao.ProfileModelCore{
+					Slug: "slug",
+				},
+			},
+			shouldCallCredentialsDAO: true,
+			credentialsDAO: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{DeletedAt: &baseTime},
+			},
+			expectErr: bunovel.ErrNotFound,
+		},
+		{
+			name:                 "Error/CredentialsDAOFailure",
+			slug:                 "slug",
+			shouldCallProfileDAO: true,
+			profileDAO: &dao.ProfileModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
+				ProfileModelCore: dao.ProfileModelCore{
+					Slug: "slug",
+				},
+			},
+			shouldCallCredentialsDAO: true,
+			credentialsDAOErr:        fooErr,
+			expectErr:                fooErr,
 		},
 		{
 			name:                 "Error/ProfileDAOFailure",
@@ -111,6 +151,7 @@ func TestPreview(t *testing.T) {
 
 	for _, d := range data {
 		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
 			profileDAO := daomocks.NewProfileRepository(t)
 			identityDAO := daomocks.NewIdentityRepository(t)
 
@@ -120,18 +161,25 @@ func TestPreview(t *testing.T) {
 					Return(d.profileDAO, d.profileDAOErr)
 			}
 
+			if d.shouldCallCredentialsDAO {
+				credentialsDAO.
+					On("GetCredentials", context.Background(), d.profileDAO.ID).
+					Return(d.credentialsDAO, d.credentialsDAOErr)
+			}
+
 			if d.shouldCallIdentityDAO {
 				identityDAO.
 					On("GetIdentity", context.Background(), d.profileDAO.ID).
 					Return(d.identityDAO, d.identityDAOErr)
 			}
 
-			service := services.NewPreviewService(profileDAO, identityDAO)
+			service := services.NewPreviewService(credentialsDAO, profileDAO, identityDAO)
 			res, err := service.Preview(context.Background(), d.slug)
 
 			require.ErrorIs(t, err, d.expectErr)
 			require.Equal(t, d.expect, res)
 
+			credentialsDAO.AssertExpectations(t)
 			profileDAO.AssertExpectations(t)
 			identityDAO.AssertExpectations(t)
 		})
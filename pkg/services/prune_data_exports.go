@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"time"
+)
+
+type PruneDataExportsService interface {
+	// PruneDataExports removes the personal data archives that can no longer be downloaded.
+	PruneDataExports(ctx context.Context, now time.Time) error
+}
+
+func NewPruneDataExportsService(dataExportsDAO dao.DataExportsRepository) PruneDataExportsService {
+	return &pruneDataExportsServiceImpl{
+		dataExportsDAO: dataExportsDAO,
+	}
+}
+
+type pruneDataExportsServiceImpl struct {
+	dataExportsDAO dao.DataExportsRepository
+}
+
+func (s *pruneDataExportsServiceImpl) PruneDataExports(ctx context.Context, now time.Time) error {
+	if err := s.dataExportsDAO.Prune(ctx, now); err != nil {
+		return goerrors.Join(ErrPruneDataExports, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"time"
+)
+
+type PruneLoginFailuresService interface {
+	// PruneLoginFailures removes the failed login attempts that no longer affect the login throttle.
+	PruneLoginFailures(ctx context.Context, now time.Time) error
+}
+
+func NewPruneLoginFailuresService(loginFailuresDAO dao.LoginFailuresRepository, retention time.Duration) PruneLoginFailuresService {
+	return &pruneLoginFailuresServiceImpl{
+		loginFailuresDAO: loginFailuresDAO,
+		retention:        retention,
+	}
+}
+
+type pruneLoginFailuresServiceImpl struct {
+	loginFailuresDAO dao.LoginFailuresRepository
+	retention        time.Duration
+}
+
+func (s *pruneLoginFailuresServiceImpl) PruneLoginFailures(ctx context.Context, now time.Time) error {
+	if err := s.loginFailuresDAO.Prune(ctx, now.Add(-s.retention)); err != nil {
+		return goerrors.Join(ErrPruneLoginFailures, err)
+	}
+
+	return nil
+}
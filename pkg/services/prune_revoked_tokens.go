@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"time"
+)
+
+type PruneRevokedTokensService interface {
+	// PruneRevokedTokens removes the revocations of tokens that have expired since.
+	PruneRevokedTokens(ctx context.Context, now time.Time) error
+}
+
+func NewPruneRevokedTokensService(revokedTokensDAO dao.RevokedTokensRepository) PruneRevokedTokensService {
+	return &pruneRevokedTokensServiceImpl{
+		revokedTokensDAO: revokedTokensDAO,
+	}
+}
+
+type pruneRevokedTokensServiceImpl struct {
+	revokedTokensDAO dao.RevokedTokensRepository
+}
+
+func (s *pruneRevokedTokensServiceImpl) PruneRevokedTokens(ctx context.Context, now time.Time) error {
+	if err := s.revokedTokensDAO.Prune(ctx, now); err != nil {
+		return goerrors.Join(ErrPruneRevokedTokens, err)
+	}
+
+	return nil
+}
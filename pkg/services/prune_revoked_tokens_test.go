@@ -0,0 +1,48 @@
+package services_test
+
+import (
+	"context"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPruneRevokedTokens(t *testing.T) {
+	data := []struct {
+		name string
+
+		now time.Time
+
+		pruneErr error
+
+		expectErr error
+	}{
+		{
+			name: "Success",
+			now:  baseTime,
+		},
+		{
+			name:      "Error/DAOFailure",
+			now:       baseTime,
+			pruneErr:  fooErr,
+			expectErr: fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			revokedTokensDAO := daomocks.NewRevokedTokensRepository(t)
+
+			revokedTokensDAO.On("Prune", context.Background(), d.now).Return(d.pruneErr)
+
+			service := services.NewPruneRevokedTokensService(revokedTokensDAO)
+			err := service.PruneRevokedTokens(context.Background(), d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			revokedTokensDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	apiclients "github.com/a-novel/go-apis/clients"
+	"time"
+)
+
+type PurgeDeletedUsersService interface {
+	// PurgeDeletedUsers permanently removes the accounts deleted for longer than the grace period, and returns the
+	// number of purged accounts. The permissions are only revoked for the accounts actually purged, so an account
+	// restored in the meantime keeps them. If revoking fails, the permissions remain on an id that no longer exists.
+	PurgeDeletedUsers(ctx context.Context, now time.Time) (int, error)
+}
+
+func NewPurgeDeletedUsersService(
+	credentialsDAO dao.CredentialsRepository,
+	userDAO dao.UserRepository,
+	permissionsClient apiclients.PermissionsClient,
+	gracePeriod time.Duration,
+) PurgeDeletedUsersService {
+	return &purgeDeletedUsersServiceImpl{
+		credentialsDAO:    credentialsDAO,
+		userDAO:           userDAO,
+		permissionsClient: permissionsClient,
+		gracePeriod:       gracePeriod,
+	}
+}
+
+type purgeDeletedUsersServiceImpl struct {
+	credentialsDAO    dao.CredentialsRepository
+	userDAO           dao.UserRepository
+	permissionsClient apiclients.PermissionsClient
+	gracePeriod       time.Duration
+}
+
+func (s *purgeDeletedUsersServiceImpl) PurgeDeletedUsers(ctx context.Context, now time.Time) (int, error) {
+	ids, err := s.credentialsDAO.ListDeleted(ctx, now.Add(-s.gracePeriod))
+	if err != nil {
+		return 0, goerrors.Join(ErrListDeletedUsers, err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	// Accounts restored since they were listed are ignored by the purge.
+	purged, err := s.userDAO.Purge(ctx, ids)
+	if err != nil {
+		return 0, goerrors.Join(ErrPurgeUsers, err)
+	}
+
+	for _, id := range purged {
+		err = s.permissionsClient.SetUserPermissions(ctx, apiclients.SetUserPermissionsForm{
+			UserID:      id,
+			UnsetFields: []string{apiclients.FieldValidatedAccount},
+		})
+		if err != nil {
+			return len(purged), goerrors.Join(ErrUpdateUserPermissions, err)
+		}
+	}
+
+	return len(purged), nil
+}
@@ -0,0 +1,123 @@
+package services_test
+
+import (
+	"context"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/services"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPurgeDeletedUsers(t *testing.T) {
+	gracePeriod := 30 * 24 * time.Hour
+
+	data := []struct {
+		name string
+
+		now time.Time
+
+		listDeleted    []uuid.UUID
+		listDeletedErr error
+
+		shouldCallPurge bool
+		purge           []uuid.UUID
+		purgeErr        error
+
+		shouldCallPermissionsClientWith []uuid.UUID
+		permissionsClientErr            error
+
+		expect    int
+		expectErr error
+	}{
+		{
+			name:                            "Success",
+			now:                             baseTime,
+			listDeleted:                     []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2)},
+			shouldCallPurge:                 true,
+			purge:                           []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2)},
+			shouldCallPermissionsClientWith: []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2)},
+			expect:                          2,
+		},
+		{
+			// User 2 canceled the deletion after the list: their permissions are kept.
+			name:                            "Success/RestoredConcurrently",
+			now:                             baseTime,
+			listDeleted:                     []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2)},
+			shouldCallPurge:                 true,
+			purge:                           []uuid.UUID{goframework.NumberUUID(1)},
+			shouldCallPermissionsClientWith: []uuid.UUID{goframework.NumberUUID(1)},
+			expect:                          1,
+		},
+		{
+			name: "Success/NoDeletedUsers",
+			now:  baseTime,
+		},
+		{
+			name:                            "Error/PermissionsClientFailure",
+			now:                             baseTime,
+			listDeleted:                     []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2)},
+			shouldCallPurge:                 true,
+			purge:                           []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2)},
+			shouldCallPermissionsClientWith: []uuid.UUID{goframework.NumberUUID(1)},
+			permissionsClientErr:            fooErr,
+			expect:                          2,
+			expectErr:                       fooErr,
+		},
+		{
+			name:            "Error/PurgeFailure",
+			now:             baseTime,
+			listDeleted:     []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2)},
+			shouldCallPurge: true,
+			purgeErr:        fooErr,
+			expectErr:       fooErr,
+		},
+		{
+			name:           "Error/ListDeletedFailure",
+			now:            baseTime,
+			listDeletedErr: fooErr,
+			expectErr:      fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			userDAO := daomocks.NewUserRepository(t)
+			permissionsClient := apiclientsmocks.NewPermissionsClient(t)
+
+			credentialsDAO.
+				On("ListDeleted", context.Background(), d.now.Add(-gracePeriod)).
+				Return(d.listDeleted, d.listDeletedErr)
+
+			if d.shouldCallPurge {
+				userDAO.
+					On("Purge", context.Background(), d.listDeleted).
+					Return(d.purge, d.purgeErr)
+			}
+
+			for _, id := range d.shouldCallPermissionsClientWith {
+				permissionsClient.
+					On("SetUserPermissions", context.Background(), apiclients.SetUserPermissionsForm{
+						UserID:      id,
+						UnsetFields: []string{apiclients.FieldValidatedAccount},
+					}).
+					Return(d.permissionsClientErr)
+			}
+
+			service := services.NewPurgeDeletedUsersService(credentialsDAO, userDAO, permissionsClient, gracePeriod)
+			res, err := service.PurgeDeletedUsers(context.Background(), d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			credentialsDAO.AssertExpectations(t)
+			userDAO.AssertExpectations(t)
+			permissionsClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+type RefreshTokenService interface {
+	// RefreshToken exchanges a refresh token for a new access token, and a new refresh token. Each refresh token can
+	// only be used once: presenting a used token again revokes the whole family, as it means the token was stolen.
+	// Tokens of deleted, locked or suspended accounts are rejected, without being used.
+	RefreshToken(ctx context.Context, refreshToken string, now time.Time) (*models.UserTokenStatus, error)
+}
+
+func NewRefreshTokenService(
+	refreshTokensDAO dao.RefreshTokensRepository,
+	credentialsDAO dao.CredentialsRepository,
+	generateTokenService GenerateTokenService,
+	createRefreshTokenService CreateRefreshTokenService,
+) RefreshTokenService {
+	return &refreshTokenServiceImpl{
+		refreshTokensDAO:          refreshTokensDAO,
+		credentialsDAO:            credentialsDAO,
+		GenerateTokenService:      generateTokenService,
+		CreateRefreshTokenService: createRefreshTokenService,
+	}
+}
+
+type refreshTokenServiceImpl struct {
+	refreshTokensDAO dao.RefreshTokensRepository
+	credentialsDAO   dao.CredentialsRepository
+	GenerateTokenService
+	CreateRefreshTokenService
+}
+
+func (s *refreshTokenServiceImpl) revokeFamily(ctx context.Context, familyID uuid.UUID, now time.Time) error {
+	if err := s.refreshTokensDAO.RevokeFamily(ctx, familyID, now); err != nil {
+		return goerrors.Join(ErrRevokeTokenFamily, err)
+	}
+
+	return goerrors.Join(goframework.ErrInvalidCredentials, ErrRefreshTokenReused)
+}
+
+func (s *refreshTokenServiceImpl) RefreshToken(ctx context.Context, refreshToken string, now time.Time) (*models.UserTokenStatus, error) {
+	rawID, code, ok := strings.Cut(refreshToken, ".")
+	if !ok || code == "" {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidRefreshToken)
+	}
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidRefreshToken, err)
+	}
+
+	token, err := s.refreshTokensDAO.GetRefreshToken(ctx, id)
+	if err != nil {
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidRefreshToken, err)
+		}
+
+		return nil, goerrors.Join(ErrGetRefreshToken, err)
+	}
+
+	ok, err = goframework.VerifyCode(code, token.TokenHashed)
+	if err != nil {
+		return nil, goerrors.Join(ErrVerifyValidationCode, err)
+	}
+	if !ok {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidRefreshToken)
+	}
+
+	if token.RevokedAt != nil || !token.ExpiresAt.After(now) {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidRefreshToken)
+	}
+
+	// The token was already exchanged, so at least 2 clients know its value.
+	if token.UsedAt != nil {
+		return nil, s.revokeFamily(ctx, token.FamilyID, now)
+	}
+
+	credentials, err := s.credentialsDAO.GetCredentials(ctx, token.UserID)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetCredentials, err)
+	}
+
+	// The credentials of the user changed since the session was opened, so it must end.
+	if credentials.SecurityStamp != token.SecurityStamp {
+		if err := s.refreshTokensDAO.RevokeFamily(ctx, token.FamilyID, now); err != nil {
+			return nil, goerrors.Join(ErrRevokeTokenFamily, err)
+		}
+
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidRefreshToken)
+	}
+
+	// The session outlives the token, so the state of the account is checked again, like when it was created.
+	if credentials.DeletedAt != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrAccountDeleted)
+	}
+	if credentials.LockedAt != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrAccountLocked)
+	}
+	if credentials.Suspended(now) {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrAccountSuspended)
+	}
+
+	if _, err = s.refreshTokensDAO.Use(ctx, id, now); err != nil {
+		// Another request used the token in the meantime.
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return nil, s.revokeFamily(ctx, token.FamilyID, now)
+		}
+
+		return nil, goerrors.Join(ErrUseRefreshToken, err)
+	}
+
+	payload := models.UserTokenPayload{
+		ID:            token.UserID,
+		FamilyID:      token.FamilyID,
+		SecurityStamp: token.SecurityStamp,
+		AuthTime:      token.AuthTime,
+	}
+
+	status, err := s.GenerateToken(ctx, payload, uuid.New(), now)
+	if err != nil {
+		return nil, goerrors.Join(ErrGenerateToken, err)
+	}
+
+	status.RefreshToken, err = s.CreateRefreshToken(ctx, payload, uuid.New(), now)
+	if err != nil {
+		return nil, goerrors.Join(ErrCreateRefreshToken, err)
+	}
+
+	return status, nil
+}
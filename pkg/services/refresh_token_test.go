@@ -0,0 +1,494 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRefreshToken(t *testing.T) {
+	refreshToken := goframework.NumberUUID(1).String() + "." + publicValidationCode
+
+	data := []struct {
+		name string
+
+		refreshToken string
+		now          time.Time
+
+		shouldCallGet bool
+		get           *dao.RefreshTokenModel
+		getErr        error
+
+		shouldCallGetCredentials bool
+		securityStamp            uuid.UUID
+		deletedAt                *time.Time
+		lockedAt                 *time.Time
+		suspendedAt              *time.Time
+		getCredentialsErr        error
+
+		shouldCallRevokeFamily bool
+		revokeFamilyErr        error
+
+		shouldCallUse bool
+		useErr        error
+
+		shouldCallGenerateToken bool
+		generateTokenStatus     *models.UserTokenStatus
+		generateTokenErr        error
+
+		shouldCallCreateRefreshToken bool
+		createRefreshToken           string
+		createRefreshTokenErr        error
+
+		expect    *models.UserTokenStatus
+		expectErr error
+	}{
+		{
+			name:          "Success",
+			refreshToken:  refreshToken,
+			now:           baseTime,
+			shouldCallGet: true,
+			get: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:      goframework.NumberUUID(100),
+					UserID:        goframework.NumberUUID(10),
+					TokenHashed:   privateValidationCode,
+					ExpiresAt:     baseTime.Add(time.Hour),
+					SecurityStamp: goframework.NumberUUID(20),
+					AuthTime:      baseTime.Add(-time.Hour),
+				},
+			},
+			shouldCallGetCredentials: true,
+			securityStamp:            goframework.NumberUUID(20),
+			shouldCallUse:            true,
+			shouldCallGenerateToken:  true,
+			generateTokenStatus: &models.UserTokenStatus{
+				OK:       true,
+				TokenRaw: "access-token",
+			},
+			shouldCallCreateRefreshToken: true,
+			createRefreshToken:           "refresh-token",
+			expect: &models.UserTokenStatus{
+				OK:           true,
+				TokenRaw:     "access-token",
+				RefreshToken: "refresh-token",
+			},
+		},
+		{
+			name:          "Error/CreateRefreshTokenFailure",
+			refreshToken:  refreshToken,
+			now:           baseTime,
+			shouldCallGet: true,
+			get: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:      goframework.NumberUUID(100),
+					UserID:        goframework.NumberUUID(10),
+					TokenHashed:   privateValidationCode,
+					ExpiresAt:     baseTime.Add(time.Hour),
+					SecurityStamp: goframework.NumberUUID(20),
+					AuthTime:      baseTime.Add(-time.Hour),
+				},
+			},
+			shouldCallGetCredentials: true,
+			securityStamp:            goframework.NumberUUID(20),
+			shouldCallUse:            true,
+			shouldCallGenerateToken:  true,
+			generateTokenStatus: &models.UserTokenStatus{
+				OK:       true,
+				TokenRaw: "access-token",
+			},
+			shouldCallCreateRefreshToken: true,
+			createRefreshTokenErr:        fooErr,
+			expectErr:                    fooErr,
+		},
+		{
+			name:          "Error/GenerateTokenFailure",
+			refreshToken:  refreshToken,
+			now:           baseTime,
+			shouldCallGet: true,
+			get: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:      goframework.NumberUUID(100),
+					UserID:        goframework.NumberUUID(10),
+					TokenHashed:   privateValidationCode,
+					ExpiresAt:     baseTime.Add(time.Hour),
+					SecurityStamp: goframework.NumberUUID(20),
+					AuthTime:      baseTime.Add(-time.Hour),
+				},
+			},
+			shouldCallGetCredentials: true,
+			securityStamp:            goframework.NumberUUID(20),
+			shouldCallUse:            true,
+			shouldCallGenerateToken:  true,
+			generateTokenErr:         fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:          "Error/UseFailure",
+			refreshToken:  refreshToken,
+			now:           baseTime,
+			shouldCallGet: true,
+			get: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:      goframework.NumberUUID(100),
+					UserID:        goframework.NumberUUID(10),
+					TokenHashed:   privateValidationCode,
+					ExpiresAt:     baseTime.Add(time.Hour),
+					SecurityStamp: goframework.NumberUUID(20),
+					AuthTime:      baseTime.Add(-time.Hour),
+				},
+			},
+			shouldCallGetCredentials: true,
+			securityStamp:            goframework.NumberUUID(20),
+			shouldCallUse:            true,
+			useErr:                   fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			// The token was used by a concurrent request.
+			name:          "Error/UseConflict",
+			refreshToken:  refreshToken,
+			now:           baseTime,
+			shouldCallGet: true,
+			get: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:      goframework.NumberUUID(100),
+					UserID:        goframework.NumberUUID(10),
+					TokenHashed:   privateValidationCode,
+					ExpiresAt:     baseTime.Add(time.Hour),
+					SecurityStamp: goframework.NumberUUID(20),
+					AuthTime:      baseTime.Add(-time.Hour),
+				},
+			},
+			shouldCallGetCredentials: true,
+			securityStamp:            goframework.NumberUUID(20),
+			shouldCallUse:            true,
+			useErr:                   bunovel.ErrNotFound,
+			shouldCallRevokeFamily:   true,
+			expectErr:                services.ErrRefreshTokenReused,
+		},
+		{
+			name:          "Error/AccountDeleted",
+			refreshToken:  refreshToken,
+			now:           baseTime,
+			shouldCallGet: true,
+			get: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:      goframework.NumberUUID(100),
+					UserID:        goframework.NumberUUID(10),
+					TokenHashed:   privateValidationCode,
+					ExpiresAt:     baseTime.Add(time.Hour),
+					SecurityStamp: goframework.NumberUUID(20),
+				},
+			},
+			shouldCallGetCredentials: true,
+			securityStamp:            goframework.NumberUUID(20),
+			deletedAt:                &updateTime,
+			expectErr:                services.ErrAccountDeleted,
+		},
+		{
+			name:          "Error/AccountLocked",
+			refreshToken:  refreshToken,
+			now:           baseTime,
+			shouldCallGet: true,
+			get: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:      goframework.NumberUUID(100),
+					UserID:        goframework.NumberUUID(10),
+					TokenHashed:   privateValidationCode,
+					ExpiresAt:     baseTime.Add(time.Hour),
+					SecurityStamp: goframework.NumberUUID(20),
+				},
+			},
+			shouldCallGetCredentials: true,
+			securityStamp:            goframework.NumberUUID(20),
+			lockedAt:                 &updateTime,
+			expectErr:                services.ErrAccountLocked,
+		},
+		{
+			name:          "Error/AccountSuspended",
+			refreshToken:  refreshToken,
+			now:           baseTime,
+			shouldCallGet: true,
+			get: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:      goframework.NumberUUID(100),
+					UserID:        goframework.NumberUUID(10),
+					TokenHashed:   privateValidationCode,
+					ExpiresAt:     baseTime.Add(time.Hour),
+					SecurityStamp: goframework.NumberUUID(20),
+				},
+			},
+			shouldCallGetCredentials: true,
+			securityStamp:            goframework.NumberUUID(20),
+			suspendedAt:              &updateTime,
+			expectErr:                services.ErrAccountSuspended,
+		},
+		{
+			name:          "Error/SecurityStampChanged",
+			refreshToken:  refreshToken,
+			now:           baseTime,
+			shouldCallGet: true,
+			get: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:      goframework.NumberUUID(100),
+					UserID:        goframework.NumberUUID(10),
+					TokenHashed:   privateValidationCode,
+					ExpiresAt:     baseTime.Add(time.Hour),
+					SecurityStamp: goframework.NumberUUID(20),
+					AuthTime:      baseTime.Add(-time.Hour),
+				},
+			},
+			shouldCallGetCredentials: true,
+			securityStamp:            goframework.NumberUUID(21),
+			shouldCallRevokeFamily:   true,
+			expectErr:                services.ErrInvalidRefreshToken,
+		},
+		{
+			name:          "Error/SecurityStampChanged/RevokeFamilyFailure",
+			refreshToken:  refreshToken,
+			now:           baseTime,
+			shouldCallGet: true,
+			get: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:    goframework.NumberUUID(100),
+					UserID:      goframework.NumberUUID(10),
+					TokenHashed: privateValidationCode,
+					ExpiresAt:   baseTime.Add(time.Hour),
+				},
+			},
+			shouldCallGetCredentials: true,
+			securityStamp:            goframework.NumberUUID(21),
+			shouldCallRevokeFamily:   true,
+			revokeFamilyErr:          fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:          "Error/GetCredentialsFailure",
+			refreshToken:  refreshToken,
+			now:           baseTime,
+			shouldCallGet: true,
+			get: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:    goframework.NumberUUID(100),
+					UserID:      goframework.NumberUUID(10),
+					TokenHashed: privateValidationCode,
+					ExpiresAt:   baseTime.Add(time.Hour),
+				},
+			},
+			shouldCallGetCredentials: true,
+			getCredentialsErr:        fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:          "Error/Reused",
+			refreshToken:  refreshToken,
+			now:           baseTime,
+			shouldCallGet: true,
+			get: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:    goframework.NumberUUID(100),
+					UserID:      goframework.NumberUUID(10),
+					TokenHashed: privateValidationCode,
+					ExpiresAt:   baseTime.Add(time.Hour),
+					UsedAt:      &baseTime,
+				},
+			},
+			shouldCallRevokeFamily: true,
+			expectErr:              goframework.ErrInvalidCredentials,
+		},
+		{
+			name:          "Error/Reused/RevokeFamilyFailure",
+			refreshToken:  refreshToken,
+			now:           baseTime,
+			shouldCallGet: true,
+			get: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:    goframework.NumberUUID(100),
+					UserID:      goframework.NumberUUID(10),
+					TokenHashed: privateValidationCode,
+					ExpiresAt:   baseTime.Add(time.Hour),
+					UsedAt:      &baseTime,
+				},
+			},
+			shouldCallRevokeFamily: true,
+			revokeFamilyErr:        fooErr,
+			expectErr:              fooErr,
+		},
+		{
+			name:          "Error/Revoked",
+			refreshToken:  refreshToken,
+			now:           baseTime,
+			shouldCallGet: true,
+			get: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:    goframework.NumberUUID(100),
+					UserID:      goframework.NumberUUID(10),
+					TokenHashed: privateValidationCode,
+					ExpiresAt:   baseTime.Add(time.Hour),
+					RevokedAt:   &baseTime,
+				},
+			},
+			expectErr: goframework.ErrInvalidCredentials,
+		},
+		{
+			name:          "Error/Expired",
+			refreshToken:  refreshToken,
+			now:           baseTime.Add(time.Hour),
+			shouldCallGet: true,
+			get: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:    goframework.NumberUUID(100),
+					UserID:      goframework.NumberUUID(10),
+					TokenHashed: privateValidationCode,
+					ExpiresAt:   baseTime.Add(time.Hour),
+				},
+			},
+			expectErr: goframework.ErrInvalidCredentials,
+		},
+		{
+			name:          "Error/WrongCode",
+			refreshToken:  goframework.NumberUUID(1).String() + ".fake-code",
+			now:           baseTime,
+			shouldCallGet: true,
+			get: &dao.RefreshTokenModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				RefreshTokenModelCore: dao.RefreshTokenModelCore{
+					FamilyID:    goframework.NumberUUID(100),
+					UserID:      goframework.NumberUUID(10),
+					TokenHashed: privateValidationCode,
+					ExpiresAt:   baseTime.Add(time.Hour),
+				},
+			},
+			expectErr: goframework.ErrInvalidCredentials,
+		},
+		{
+			name:          "Error/NotFound",
+			refreshToken:  refreshToken,
+			now:           baseTime,
+			shouldCallGet: true,
+			getErr:        bunovel.ErrNotFound,
+			expectErr:     goframework.ErrInvalidCredentials,
+		},
+		{
+			name:          "Error/GetFailure",
+			refreshToken:  refreshToken,
+			now:           baseTime,
+			shouldCallGet: true,
+			getErr:        fooErr,
+			expectErr:     fooErr,
+		},
+		{
+			name:         "Error/InvalidID",
+			refreshToken: "not-an-id." + publicValidationCode,
+			now:          baseTime,
+			expectErr:    goframework.ErrInvalidEntity,
+		},
+		{
+			name:         "Error/NoCode",
+			refreshToken: goframework.NumberUUID(1).String(),
+			now:          baseTime,
+			expectErr:    goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			refreshTokensDAO := daomocks.NewRefreshTokensRepository(t)
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			generateTokenService := servicesmocks.NewGenerateTokenService(t)
+			createRefreshTokenService := servicesmocks.NewCreateRefreshTokenService(t)
+
+			if d.shouldCallGet {
+				refreshTokensDAO.
+					On("GetRefreshToken", context.Background(), goframework.NumberUUID(1)).
+					Return(d.get, d.getErr)
+			}
+
+			if d.shouldCallGetCredentials {
+				credentialsDAO.
+					On("GetCredentials", context.Background(), d.get.UserID).
+					Return(&dao.CredentialsModel{
+						CredentialsModelCore: dao.CredentialsModelCore{
+							SecurityStamp: d.securityStamp,
+							DeletedAt:     d.deletedAt,
+							LockedAt:      d.lockedAt,
+							SuspendedAt:   d.suspendedAt,
+						},
+					}, d.getCredentialsErr)
+			}
+
+			if d.shouldCallRevokeFamily {
+				refreshTokensDAO.
+					On("RevokeFamily", context.Background(), d.get.FamilyID, d.now).
+					Return(d.revokeFamilyErr)
+			}
+
+			if d.shouldCallUse {
+				refreshTokensDAO.
+					On("Use", context.Background(), goframework.NumberUUID(1), d.now).
+					Return(nil, d.useErr)
+			}
+
+			if d.shouldCallGenerateToken {
+				generateTokenService.
+					On("GenerateToken", context.Background(), models.UserTokenPayload{
+						ID:            d.get.UserID,
+						FamilyID:      d.get.FamilyID,
+						SecurityStamp: d.get.SecurityStamp,
+						AuthTime:      d.get.AuthTime,
+					}, mock.Anything, d.now).
+					Return(d.generateTokenStatus, d.generateTokenErr)
+			}
+
+			if d.shouldCallCreateRefreshToken {
+				createRefreshTokenService.
+					On("CreateRefreshToken", context.Background(), models.UserTokenPayload{
+						ID:            d.get.UserID,
+						FamilyID:      d.get.FamilyID,
+						SecurityStamp: d.get.SecurityStamp,
+						AuthTime:      d.get.AuthTime,
+					}, mock.Anything, d.now).
+					Return(d.createRefreshToken, d.createRefreshTokenErr)
+			}
+
+			service := services.NewRefreshTokenService(
+				refreshTokensDAO, credentialsDAO, generateTokenService, createRefreshTokenService,
+			)
+			res, err := service.RefreshToken(context.Background(), d.refreshToken, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			refreshTokensDAO.AssertExpectations(t)
+			credentialsDAO.AssertExpectations(t)
+			generateTokenService.AssertExpectations(t)
+			createRefreshTokenService.AssertExpectations(t)
+		})
+	}
+}
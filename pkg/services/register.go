@@ -10,13 +10,12 @@ import (
 	sendgridproxy "github.com/a-novel/sendgrid-proxy"
 	"github.com/google/uuid"
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
-	"golang.org/x/crypto/bcrypt"
 	"time"
 )
 
 type RegisterService interface {
 	// Register creates a new user in the database, and return an initial token for it.
-	Register(ctx context.Context, form models.RegisterForm, now time.Time) (*models.UserTokenStatus, func() error, error)
+	Register(ctx context.Context, form models.RegisterForm, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, func() error, error)
 }
 
 func NewRegisterService(
@@ -25,19 +24,23 @@ func NewRegisterService(
 	userDAO dao.UserRepository,
 	mailer sendgridproxy.Mailer,
 	generateValidationCode func() (string, string, error),
-	generateTokenService GenerateTokenService,
+	createSessionService CreateSessionService,
+	checkPasswordPolicyService CheckPasswordPolicyService,
+	passwordHasher PasswordHasher,
 	validateEmailLink string,
 	validateEmailTemplate string,
 ) RegisterService {
 	return &registerServiceImpl{
-		credentialsDAO:         credentialsDAO,
-		profileDAO:             profileDAO,
-		userDAO:                userDAO,
-		mailer:                 mailer,
-		generateValidationCode: generateValidationCode,
-		GenerateTokenService:   generateTokenService,
-		validateEmailTemplate:  validateEmailTemplate,
-		validateEmailLink:      validateEmailLink,
+		credentialsDAO:             credentialsDAO,
+		profileDAO:                 profileDAO,
+		userDAO:                    userDAO,
+		mailer:                     mailer,
+		generateValidationCode:     generateValidationCode,
+		CreateSessionService:       createSessionService,
+		CheckPasswordPolicyService: checkPasswordPolicyService,
+		passwordHasher:             passwordHasher,
+		validateEmailTemplate:      validateEmailTemplate,
+		validateEmailLink:          validateEmailLink,
 	}
 }
 
@@ -47,13 +50,15 @@ type registerServiceImpl struct {
 	userDAO                dao.UserRepository
 	mailer                 sendgridproxy.Mailer
 	generateValidationCode func() (string, string, error)
-	GenerateTokenService
+	CreateSessionService
+	CheckPasswordPolicyService
+	passwordHasher PasswordHasher
 
 	validateEmailTemplate string
 	validateEmailLink     string
 }
 
-func (s *registerServiceImpl) Register(ctx context.Context, form models.RegisterForm, now time.Time) (*models.UserTokenStatus, func() error, error) {
+func (s *registerServiceImpl) Register(ctx context.Context, form models.RegisterForm, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, func() error, error) {
 	if err := goframework.CheckMinMax(form.Email, MinEmailLength, MaxEmailLength); err != nil {
 		return nil, nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidEmail, err)
 	}
@@ -101,6 +106,17 @@ func (s *registerServiceImpl) Register(ctx context.Context, form models.Register
 		return nil, nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidAge, err)
 	}
 
+	err = s.CheckPasswordPolicy(ctx, form.Password, PasswordUserInfo{
+		Email:     form.Email,
+		FirstName: form.FirstName,
+		LastName:  form.LastName,
+		Slug:      form.Slug,
+		Username:  form.Username,
+	})
+	if err != nil {
+		return nil, nil, goerrors.Join(ErrCheckPasswordPolicy, err)
+	}
+
 	emailExists, err := s.credentialsDAO.EmailExists(ctx, daoEmail)
 	if err != nil {
 		return nil, nil, goerrors.Join(ErrEmailExists, err)
@@ -124,22 +140,17 @@ func (s *registerServiceImpl) Register(ctx context.Context, form models.Register
 		return nil, nil, goerrors.Join(ErrGenerateValidationCode, err)
 	}
 	daoEmail.Validation = privateValidationCode
+	daoEmail.ValidationIssuedAt = &now
 
-	passwordHashed, err := bcrypt.GenerateFromPassword([]byte(form.Password), bcrypt.DefaultCost)
+	passwordHashed, err := s.passwordHasher.Hash(form.Password)
 	if err != nil {
 		return nil, nil, goerrors.Join(ErrHashPassword, err)
 	}
 
-	userID := uuid.New()
-	token, err := s.GenerateToken(ctx, models.UserTokenPayload{ID: userID}, uuid.New(), now)
-	if err != nil {
-		return nil, nil, goerrors.Join(ErrGenerateToken, err)
-	}
-
 	user, err := s.userDAO.Create(ctx, &dao.UserModelCore{
 		Credentials: dao.CredentialsModelCore{
 			Email:    daoEmail,
-			Password: dao.Password{Hashed: string(passwordHashed)},
+			Password: dao.Password{Hashed: passwordHashed},
 		},
 		Identity: dao.IdentityModelCore{
 			FirstName: form.FirstName,
@@ -151,11 +162,16 @@ func (s *registerServiceImpl) Register(ctx context.Context, form models.Register
 			Username: form.Username,
 			Slug:     form.Slug,
 		},
-	}, userID, now)
+	}, uuid.New(), now)
 	if err != nil {
 		return nil, nil, goerrors.Join(ErrCreateUser, err)
 	}
 
+	token, err := s.CreateSession(ctx, user.ID, client, now)
+	if err != nil {
+		return nil, nil, goerrors.Join(ErrCreateSession, err)
+	}
+
 	// Perform heavy load, post registration tasks in the background, after response has been sent back to the user.
 	deferred := func() error {
 		to := mail.NewEmail(user.Identity.FirstName, form.Email)
@@ -2,6 +2,7 @@ package services_test
 
 import (
 	"context"
+	goerrors "errors"
 	"github.com/a-novel/auth-service/pkg/dao"
 	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
 	"github.com/a-novel/auth-service/pkg/models"
@@ -19,6 +20,8 @@ import (
 )
 
 func TestRegister(t *testing.T) {
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
 	data := []struct {
 		name string
 
@@ -31,6 +34,9 @@ func TestRegister(t *testing.T) {
 		privateValidationCode     string
 		generateValidationCodeErr error
 
+		shouldCallCheckPasswordPolicy bool
+		checkPasswordPolicyErr        error
+
 		shouldCallEmailExists bool
 		emailExists           bool
 		emailExistsErr        error
@@ -39,14 +45,14 @@ func TestRegister(t *testing.T) {
 		slugExists           bool
 		slugExistsErr        error
 
-		shouldCallGenerateToken bool
-		generateTokenStatus     *models.UserTokenStatus
-		generateTokenErr        error
-
 		shouldCallCreateUser bool
 		createUser           *dao.UserModel
 		createUserErr        error
 
+		shouldCallCreateSession bool
+		createSession           *models.UserTokenStatus
+		createSessionErr        error
+
 		shouldCallMailer          bool
 		shouldCallMailerWithEmail *mail.Email
 		shouldCallMailerWithData  map[string]interface{}
@@ -68,23 +74,17 @@ func TestRegister(t *testing.T) {
 				Birthday:  baseTime.Add(-20 * timeYear), // 20 Yo
 				Slug:      "slug",
 			},
-			now:                     baseTime,
-			validateEmailTemplate:   "validate-email-template",
-			validateEmailLink:       "validate-email-link",
-			publicValidationCode:    "public-validation-code",
-			privateValidationCode:   "private-validation-code",
-			shouldCallEmailExists:   true,
-			emailExists:             false,
-			shouldCallSlugExists:    true,
-			slugExists:              false,
-			shouldCallGenerateToken: true,
-			generateTokenStatus: &models.UserTokenStatus{
-				OK: true,
-				Token: &models.UserToken{
-					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
-				},
-			},
-			shouldCallCreateUser: true,
+			now:                           baseTime,
+			validateEmailTemplate:         "validate-email-template",
+			validateEmailLink:             "validate-email-link",
+			publicValidationCode:          "public-validation-code",
+			privateValidationCode:         "private-validation-code",
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallEmailExists:         true,
+			emailExists:                   false,
+			shouldCallSlugExists:          true,
+			slugExists:                    false,
+			shouldCallCreateUser:          true,
 			createUser: &dao.UserModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
 				UserModelCore: dao.UserModelCore{
@@ -103,6 +103,14 @@ func TestRegister(t *testing.T) {
 					},
 				},
 			},
+			shouldCallCreateSession: true,
+			createSession: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+				RefreshToken: "refresh-token",
+			},
 			shouldCallMailer:          true,
 			shouldCallMailerWithEmail: mail.NewEmail("name", "user@domain.com"),
 			shouldCallMailerWithData: map[string]interface{}{
@@ -114,6 +122,7 @@ func TestRegister(t *testing.T) {
 				Token: &models.UserToken{
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
+				RefreshToken: "refresh-token",
 			},
 			expectDeferred: true,
 		},
@@ -129,23 +138,17 @@ func TestRegister(t *testing.T) {
 				Slug:      "slug",
 				Username:  "my username",
 			},
-			now:                     baseTime,
-			validateEmailTemplate:   "validate-email-template",
-			validateEmailLink:       "validate-email-link",
-			publicValidationCode:    "public-validation-code",
-			privateValidationCode:   "private-validation-code",
-			shouldCallEmailExists:   true,
-			emailExists:             false,
-			shouldCallSlugExists:    true,
-			slugExists:              false,
-			shouldCallGenerateToken: true,
-			generateTokenStatus: &models.UserTokenStatus{
-				OK: true,
-				Token: &models.UserToken{
-					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
-				},
-			},
-			shouldCallCreateUser: true,
+			now:                           baseTime,
+			validateEmailTemplate:         "validate-email-template",
+			validateEmailLink:             "validate-email-link",
+			publicValidationCode:          "public-validation-code",
+			privateValidationCode:         "private-validation-code",
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallEmailExists:         true,
+			emailExists:                   false,
+			shouldCallSlugExists:          true,
+			slugExists:                    false,
+			shouldCallCreateUser:          true,
 			createUser: &dao.UserModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
 				UserModelCore: dao.UserModelCore{
@@ -165,6 +168,14 @@ func TestRegister(t *testing.T) {
 					},
 				},
 			},
+			shouldCallCreateSession: true,
+			createSession: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+				RefreshToken: "refresh-token",
+			},
 			shouldCallMailer:          true,
 			shouldCallMailerWithEmail: mail.NewEmail("name", "user@domain.com"),
 			shouldCallMailerWithData: map[string]interface{}{
@@ -176,6 +187,7 @@ func TestRegister(t *testing.T) {
 				Token: &models.UserToken{
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
+				RefreshToken: "refresh-token",
 			},
 			expectDeferred: true,
 		},
@@ -190,23 +202,17 @@ func TestRegister(t *testing.T) {
 				Birthday:  baseTime.Add(-20 * timeYear), // 20 Yo
 				Slug:      "slug",
 			},
-			now:                     baseTime,
-			validateEmailTemplate:   "validate-email-template",
-			validateEmailLink:       "validate-email-link",
-			publicValidationCode:    "public-validation-code",
-			privateValidationCode:   "private-validation-code",
-			shouldCallEmailExists:   true,
-			emailExists:             false,
-			shouldCallSlugExists:    true,
-			slugExists:              false,
-			shouldCallGenerateToken: true,
-			generateTokenStatus: &models.UserTokenStatus{
-				OK: true,
-				Token: &models.UserToken{
-					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
-				},
-			},
-			shouldCallCreateUser: true,
+			now:                           baseTime,
+			validateEmailTemplate:         "validate-email-template",
+			validateEmailLink:             "validate-email-link",
+			publicValidationCode:          "public-validation-code",
+			privateValidationCode:         "private-validation-code",
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallEmailExists:         true,
+			emailExists:                   false,
+			shouldCallSlugExists:          true,
+			slugExists:                    false,
+			shouldCallCreateUser:          true,
 			createUser: &dao.UserModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
 				UserModelCore: dao.UserModelCore{
@@ -225,6 +231,14 @@ func TestRegister(t *testing.T) {
 					},
 				},
 			},
+			shouldCallCreateSession: true,
+			createSession: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+				RefreshToken: "refresh-token",
+			},
 			shouldCallMailer:          true,
 			shouldCallMailerWithEmail: mail.NewEmail("name", "user@domain.com"),
 			shouldCallMailerWithData: map[string]interface{}{
@@ -237,12 +251,13 @@ func TestRegister(t *testing.T) {
 				Token: &models.UserToken{
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
+				RefreshToken: "refresh-token",
 			},
 			expectDeferred:    true,
 			expectDeferredErr: fooErr,
 		},
 		{
-			name: "Error/CreateUserFailure",
+			name: "Error/CreateSessionFailure",
 			form: models.RegisterForm{
 				Email:     "user@domain.com",
 				Password:  "password",
@@ -252,28 +267,26 @@ func TestRegister(t *testing.T) {
 				Birthday:  baseTime.Add(-20 * timeYear), // 20 Yo
 				Slug:      "slug",
 			},
-			now:                     baseTime,
-			validateEmailTemplate:   "validate-email-template",
-			validateEmailLink:       "validate-email-link",
-			publicValidationCode:    "public-validation-code",
-			privateValidationCode:   "private-validation-code",
-			shouldCallEmailExists:   true,
-			emailExists:             false,
-			shouldCallSlugExists:    true,
-			slugExists:              false,
-			shouldCallGenerateToken: true,
-			generateTokenStatus: &models.UserTokenStatus{
-				OK: true,
-				Token: &models.UserToken{
-					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
-				},
+			now:                           baseTime,
+			validateEmailTemplate:         "validate-email-template",
+			validateEmailLink:             "validate-email-link",
+			publicValidationCode:          "public-validation-code",
+			privateValidationCode:         "private-validation-code",
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallEmailExists:         true,
+			emailExists:                   false,
+			shouldCallSlugExists:          true,
+			slugExists:                    false,
+			shouldCallCreateUser:          true,
+			createUser: &dao.UserModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
 			},
-			shouldCallCreateUser: true,
-			createUserErr:        fooErr,
-			expectErr:            fooErr,
+			shouldCallCreateSession: true,
+			createSessionErr:        fooErr,
+			expectErr:               fooErr,
 		},
 		{
-			name: "Error/GenerateTokenFailure",
+			name: "Error/CreateUserFailure",
 			form: models.RegisterForm{
 				Email:     "user@domain.com",
 				Password:  "password",
@@ -283,18 +296,19 @@ func TestRegister(t *testing.T) {
 				Birthday:  baseTime.Add(-20 * timeYear), // 20 Yo
 				Slug:      "slug",
 			},
-			now:                     baseTime,
-			validateEmailTemplate:   "validate-email-template",
-			validateEmailLink:       "validate-email-link",
-			publicValidationCode:    "public-validation-code",
-			privateValidationCode:   "private-validation-code",
-			shouldCallEmailExists:   true,
-			emailExists:             false,
-			shouldCallSlugExists:    true,
-			slugExists:              false,
-			shouldCallGenerateToken: true,
-			generateTokenErr:        fooErr,
-			expectErr:               fooErr,
+			now:                           baseTime,
+			validateEmailTemplate:         "validate-email-template",
+			validateEmailLink:             "validate-email-link",
+			publicValidationCode:          "public-validation-code",
+			privateValidationCode:         "private-validation-code",
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallEmailExists:         true,
+			emailExists:                   false,
+			shouldCallSlugExists:          true,
+			slugExists:                    false,
+			shouldCallCreateUser:          true,
+			createUserErr:                 fooErr,
+			expectErr:                     fooErr,
 		},
 		{
 			name: "Error/GenerateValidationCodeFailure",
@@ -307,15 +321,16 @@ func TestRegister(t *testing.T) {
 				Birthday:  baseTime.Add(-20 * timeYear), // 20 Yo
 				Slug:      "slug",
 			},
-			now:                       baseTime,
-			validateEmailTemplate:     "validate-email-template",
-			validateEmailLink:         "validate-email-link",
-			generateValidationCodeErr: fooErr,
-			shouldCallEmailExists:     true,
-			emailExists:               false,
-			shouldCallSlugExists:      true,
-			slugExists:                false,
-			expectErr:                 fooErr,
+			now:                           baseTime,
+			validateEmailTemplate:         "validate-email-template",
+			validateEmailLink:             "validate-email-link",
+			generateValidationCodeErr:     fooErr,
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallEmailExists:         true,
+			emailExists:                   false,
+			shouldCallSlugExists:          true,
+			slugExists:                    false,
+			expectErr:                     fooErr,
 		},
 		{
 			name: "Error/SlugExists",
@@ -328,14 +343,15 @@ func TestRegister(t *testing.T) {
 				Birthday:  baseTime.Add(-20 * timeYear), // 20 Yo
 				Slug:      "slug",
 			},
-			now:                   baseTime,
-			validateEmailTemplate: "validate-email-template",
-			validateEmailLink:     "validate-email-link",
-			shouldCallEmailExists: true,
-			emailExists:           false,
-			shouldCallSlugExists:  true,
-			slugExists:            true,
-			expectErr:             services.ErrTaken,
+			now:                           baseTime,
+			validateEmailTemplate:         "validate-email-template",
+			validateEmailLink:             "validate-email-link",
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallEmailExists:         true,
+			emailExists:                   false,
+			shouldCallSlugExists:          true,
+			slugExists:                    true,
+			expectErr:                     services.ErrTaken,
 		},
 		{
 			name: "Error/SlugCheckFailure",
@@ -348,14 +364,15 @@ func TestRegister(t *testing.T) {
 				Birthday:  baseTime.Add(-20 * timeYear), // 20 Yo
 				Slug:      "slug",
 			},
-			now:                   baseTime,
-			validateEmailTemplate: "validate-email-template",
-			validateEmailLink:     "validate-email-link",
-			shouldCallEmailExists: true,
-			emailExists:           false,
-			shouldCallSlugExists:  true,
-			slugExistsErr:         fooErr,
-			expectErr:             fooErr,
+			now:                           baseTime,
+			validateEmailTemplate:         "validate-email-template",
+			validateEmailLink:             "validate-email-link",
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallEmailExists:         true,
+			emailExists:                   false,
+			shouldCallSlugExists:          true,
+			slugExistsErr:                 fooErr,
+			expectErr:                     fooErr,
 		},
 		{
 			name: "Error/EmailExists",
@@ -368,12 +385,13 @@ func TestRegister(t *testing.T) {
 				Birthday:  baseTime.Add(-20 * timeYear), // 20 Yo
 				Slug:      "slug",
 			},
-			now:                   baseTime,
-			validateEmailTemplate: "validate-email-template",
-			validateEmailLink:     "validate-email-link",
-			shouldCallEmailExists: true,
-			emailExists:           true,
-			expectErr:             services.ErrTaken,
+			now:                           baseTime,
+			validateEmailTemplate:         "validate-email-template",
+			validateEmailLink:             "validate-email-link",
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallEmailExists:         true,
+			emailExists:                   true,
+			expectErr:                     services.ErrTaken,
 		},
 		{
 			name: "Error/EmailCheckFailure",
@@ -386,12 +404,52 @@ func TestRegister(t *testing.T) {
 				Birthday:  baseTime.Add(-20 * timeYear), // 20 Yo
 				Slug:      "slug",
 			},
-			now:                   baseTime,
-			validateEmailTemplate: "validate-email-template",
-			validateEmailLink:     "validate-email-link",
-			shouldCallEmailExists: true,
-			emailExistsErr:        fooErr,
-			expectErr:             fooErr,
+			now:                           baseTime,
+			validateEmailTemplate:         "validate-email-template",
+			validateEmailLink:             "validate-email-link",
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallEmailExists:         true,
+			emailExistsErr:                fooErr,
+			expectErr:                     fooErr,
+		},
+		{
+			name: "Error/PasswordPolicy",
+			form: models.RegisterForm{
+				Email:     "user@domain.com",
+				Password:  "password",
+				FirstName: "name",
+				LastName:  "last-name",
+				Sex:       models.SexMale,
+				Birthday:  baseTime.Add(-20 * timeYear), // 20 Yo
+				Slug:      "slug",
+			},
+			now:                           baseTime,
+			validateEmailTemplate:         "validate-email-template",
+			validateEmailLink:             "validate-email-link",
+			shouldCallCheckPasswordPolicy: true,
+			checkPasswordPolicyErr: goerrors.Join(
+				goframework.ErrInvalidEntity,
+				&services.PasswordPolicyError{Rules: []string{services.PasswordRuleStrength}},
+			),
+			expectErr: services.ErrInvalidPassword,
+		},
+		{
+			name: "Error/CheckPasswordPolicyFailure",
+			form: models.RegisterForm{
+				Email:     "user@domain.com",
+				Password:  "password",
+				FirstName: "name",
+				LastName:  "last-name",
+				Sex:       models.SexMale,
+				Birthday:  baseTime.Add(-20 * timeYear), // 20 Yo
+				Slug:      "slug",
+			},
+			now:                           baseTime,
+			validateEmailTemplate:         "validate-email-template",
+			validateEmailLink:             "validate-email-link",
+			shouldCallCheckPasswordPolicy: true,
+			checkPasswordPolicyErr:        fooErr,
+			expectErr:                     fooErr,
 		},
 		{
 			name: "Error/UserTooYoung",
@@ -749,7 +807,8 @@ func TestRegister(t *testing.T) {
 			profileDAO := daomocks.NewProfileRepository(t)
 			userDAO := daomocks.NewUserRepository(t)
 			mailerService := sendgridproxy.NewMockMailer(t)
-			generateTokenService := servicesmocks.NewGenerateTokenService(t)
+			createSessionService := servicesmocks.NewCreateSessionService(t)
+			checkPasswordPolicyService := servicesmocks.NewCheckPasswordPolicyService(t)
 
 			generateLink := func() (string, string, error) {
 				return d.publicValidationCode, d.privateValidationCode, d.generateValidationCodeErr
@@ -761,6 +820,18 @@ func TestRegister(t *testing.T) {
 					Return(d.mailerErr)
 			}
 
+			if d.shouldCallCheckPasswordPolicy {
+				checkPasswordPolicyService.
+					On("CheckPasswordPolicy", context.Background(), d.form.Password, services.PasswordUserInfo{
+						Email:     d.form.Email,
+						FirstName: d.form.FirstName,
+						LastName:  d.form.LastName,
+						Slug:      d.form.Slug,
+						Username:  d.form.Username,
+					}).
+					Return(d.checkPasswordPolicyErr)
+			}
+
 			if d.shouldCallEmailExists {
 				credentialsDAO.
 					On("EmailExists", context.Background(), mock.Anything).
@@ -773,20 +844,20 @@ func TestRegister(t *testing.T) {
 					Return(d.slugExists, d.slugExistsErr)
 			}
 
-			if d.shouldCallGenerateToken {
-				generateTokenService.
-					On("GenerateToken", context.Background(), mock.Anything, mock.Anything, d.now).
-					Return(d.generateTokenStatus, d.generateTokenErr)
-			}
-
 			if d.shouldCallCreateUser {
 				userDAO.
 					On("Create", context.Background(), mock.Anything, mock.Anything, d.now).
 					Return(d.createUser, d.createUserErr)
 			}
 
-			service := services.NewRegisterService(credentialsDAO, profileDAO, userDAO, mailerService, generateLink, generateTokenService, d.validateEmailLink, d.validateEmailTemplate)
-			res, deferred, err := service.Register(context.Background(), d.form, d.now)
+			if d.shouldCallCreateSession {
+				createSessionService.
+					On("CreateSession", context.Background(), d.createUser.ID, client, d.now).
+					Return(d.createSession, d.createSessionErr)
+			}
+
+			service := services.NewRegisterService(credentialsDAO, profileDAO, userDAO, mailerService, generateLink, createSessionService, checkPasswordPolicyService, passwordHasher, d.validateEmailLink, d.validateEmailTemplate)
+			res, deferred, err := service.Register(context.Background(), d.form, client, d.now)
 
 			require.ErrorIs(t, err, d.expectErr)
 			require.Equal(t, d.expect, res)
@@ -802,7 +873,8 @@ func TestRegister(t *testing.T) {
 			profileDAO.AssertExpectations(t)
 			userDAO.AssertExpectations(t)
 			mailerService.AssertExpectations(t)
-			generateTokenService.AssertExpectations(t)
+			createSessionService.AssertExpectations(t)
+			checkPasswordPolicyService.AssertExpectations(t)
 		})
 	}
 }
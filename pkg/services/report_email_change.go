@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"time"
+)
+
+type ReportEmailChangeService interface {
+	// ReportEmailChange is called from the link sent to the current address of a user, when a new email is
+	// requested. It cancels the pending email, locks the account, and starts a password reset on the current address.
+	ReportEmailChange(ctx context.Context, id uuid.UUID, code string, client models.ClientInfo, now time.Time) (func() error, error)
+}
+
+func NewReportEmailChangeService(
+	credentialsDAO dao.CredentialsRepository,
+	resetPasswordService ResetPasswordService,
+) ReportEmailChangeService {
+	return &reportEmailChangeServiceImpl{
+		credentialsDAO:       credentialsDAO,
+		ResetPasswordService: resetPasswordService,
+	}
+}
+
+type reportEmailChangeServiceImpl struct {
+	credentialsDAO dao.CredentialsRepository
+	ResetPasswordService
+}
+
+func (s *reportEmailChangeServiceImpl) ReportEmailChange(ctx context.Context, id uuid.UUID, code string, client models.ClientInfo, now time.Time) (func() error, error) {
+	credentials, err := s.credentialsDAO.GetCredentials(ctx, id)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetCredentials, err)
+	}
+
+	// The change was already validated or canceled.
+	if credentials.NewEmailReportCode == "" {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrMissingPendingValidation)
+	}
+	ok, err := goframework.VerifyCode(code, credentials.NewEmailReportCode)
+	if err != nil {
+		return nil, goerrors.Join(ErrVerifyValidationCode, err)
+	}
+	if !ok {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidValidationCode)
+	}
+
+	err = s.credentialsDAO.RunInTx(ctx, func(ctx context.Context, txClient dao.CredentialsRepository) error {
+		if _, err := txClient.CancelNewEmail(ctx, id, now); err != nil {
+			return goerrors.Join(ErrCancelNewEmail, err)
+		}
+
+		// Whoever requested the change is logged out, and cannot log in again until the password is reset.
+		if _, err := txClient.Lock(ctx, uuid.New(), id, now); err != nil {
+			return goerrors.Join(ErrLockAccount, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deferred, err := s.ResetPassword(ctx, credentials.Email.String(), client, now)
+	if err != nil {
+		return nil, goerrors.Join(ErrResetPassword, err)
+	}
+
+	return deferred, nil
+}
@@ -0,0 +1,204 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestReportEmailChange(t *testing.T) {
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
+	data := []struct {
+		name string
+
+		id   uuid.UUID
+		code string
+		now  time.Time
+
+		dao    *dao.CredentialsModel
+		daoErr error
+
+		shouldCallCancelNewEmail bool
+		cancelNewEmailErr        error
+
+		shouldCallLock bool
+		lockErr        error
+
+		shouldCallResetPassword bool
+		resetPasswordErr        error
+
+		expectErr      error
+		expectDeferred bool
+	}{
+		{
+			name: "Success",
+			id:   goframework.NumberUUID(1),
+			code: publicValidationCode,
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:              dao.Email{User: "user", Domain: "domain.com"},
+					NewEmail:           dao.Email{User: "new-user", Domain: "domain.com", Validation: "new-email-code"},
+					NewEmailReportCode: privateValidationCode,
+				},
+			},
+			shouldCallCancelNewEmail: true,
+			shouldCallLock:           true,
+			shouldCallResetPassword:  true,
+			expectDeferred:           true,
+		},
+		{
+			name: "Error/ResetPasswordFailure",
+			id:   goframework.NumberUUID(1),
+			code: publicValidationCode,
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:              dao.Email{User: "user", Domain: "domain.com"},
+					NewEmail:           dao.Email{User: "new-user", Domain: "domain.com", Validation: "new-email-code"},
+					NewEmailReportCode: privateValidationCode,
+				},
+			},
+			shouldCallCancelNewEmail: true,
+			shouldCallLock:           true,
+			shouldCallResetPassword:  true,
+			resetPasswordErr:         fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name: "Error/LockFailure",
+			id:   goframework.NumberUUID(1),
+			code: publicValidationCode,
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:              dao.Email{User: "user", Domain: "domain.com"},
+					NewEmail:           dao.Email{User: "new-user", Domain: "domain.com", Validation: "new-email-code"},
+					NewEmailReportCode: privateValidationCode,
+				},
+			},
+			shouldCallCancelNewEmail: true,
+			shouldCallLock:           true,
+			lockErr:                  fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name: "Error/CancelNewEmailFailure",
+			id:   goframework.NumberUUID(1),
+			code: publicValidationCode,
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:              dao.Email{User: "user", Domain: "domain.com"},
+					NewEmail:           dao.Email{User: "new-user", Domain: "domain.com", Validation: "new-email-code"},
+					NewEmailReportCode: privateValidationCode,
+				},
+			},
+			shouldCallCancelNewEmail: true,
+			cancelNewEmailErr:        fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name: "Error/WrongCode",
+			id:   goframework.NumberUUID(1),
+			code: "fake-code",
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:              dao.Email{User: "user", Domain: "domain.com"},
+					NewEmail:           dao.Email{User: "new-user", Domain: "domain.com", Validation: "new-email-code"},
+					NewEmailReportCode: privateValidationCode,
+				},
+			},
+			expectErr: services.ErrInvalidValidationCode,
+		},
+		{
+			name: "Error/NoPendingChange",
+			id:   goframework.NumberUUID(1),
+			code: publicValidationCode,
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain.com"},
+				},
+			},
+			expectErr: services.ErrMissingPendingValidation,
+		},
+		{
+			name:      "Error/DAOFailure",
+			id:        goframework.NumberUUID(1),
+			code:      publicValidationCode,
+			now:       baseTime,
+			daoErr:    fooErr,
+			expectErr: fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			resetPasswordService := servicesmocks.NewResetPasswordService(t)
+
+			credentialsDAO.
+				On("GetCredentials", context.Background(), d.id).
+				Return(d.dao, d.daoErr)
+
+			if d.shouldCallCancelNewEmail {
+				credentialsDAO.
+					On("CancelNewEmail", context.Background(), d.id, d.now).
+					Return(nil, d.cancelNewEmailErr)
+
+				// Execute the actual method, but call the mocks inside of it.
+				txCall := credentialsDAO.On("RunInTx", context.Background(), mock.Anything)
+				txCall.Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context, dao.CredentialsRepository) error)
+					txCall.ReturnArguments = []interface{}{fn(context.Background(), credentialsDAO)}
+				})
+			}
+
+			if d.shouldCallLock {
+				credentialsDAO.
+					On("Lock", context.Background(), mock.MatchedBy(func(stamp uuid.UUID) bool {
+						return stamp != uuid.Nil
+					}), d.id, d.now).
+					Return(nil, d.lockErr)
+			}
+
+			if d.shouldCallResetPassword {
+				var deferred func() error
+				if d.resetPasswordErr == nil {
+					deferred = func() error { return nil }
+				}
+
+				resetPasswordService.
+					On("ResetPassword", context.Background(), "user@domain.com", client, d.now).
+					Return(deferred, d.resetPasswordErr)
+			}
+
+			service := services.NewReportEmailChangeService(credentialsDAO, resetPasswordService)
+			deferred, err := service.ReportEmailChange(context.Background(), d.id, d.code, client, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			if d.expectDeferred {
+				require.NotNil(t, deferred)
+				require.NoError(t, deferred())
+			} else {
+				require.Nil(t, deferred)
+			}
+
+			credentialsDAO.AssertExpectations(t)
+			resetPasswordService.AssertExpectations(t)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	goerrors "errors"
 	"fmt"
 	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
 	goframework "github.com/a-novel/go-framework"
 	sendgridproxy "github.com/a-novel/sendgrid-proxy"
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
@@ -12,7 +13,7 @@ import (
 )
 
 type ResetPasswordService interface {
-	ResetPassword(ctx context.Context, email string, now time.Time) (func() error, error)
+	ResetPassword(ctx context.Context, email string, client models.ClientInfo, now time.Time) (func() error, error)
 }
 
 func NewResetPasswordService(
@@ -43,7 +44,7 @@ type resetPasswordServiceImpl struct {
 	passwordResetTemplate string
 }
 
-func (s *resetPasswordServiceImpl) ResetPassword(ctx context.Context, email string, now time.Time) (func() error, error) {
+func (s *resetPasswordServiceImpl) ResetPassword(ctx context.Context, email string, client models.ClientInfo, now time.Time) (func() error, error) {
 	daoEmail, err := dao.ParseEmail(email)
 	if err != nil {
 		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidEmail, err)
@@ -54,9 +55,17 @@ func (s *resetPasswordServiceImpl) ResetPassword(ctx context.Context, email stri
 		return nil, goerrors.Join(ErrGenerateValidationCode, err)
 	}
 
-	credentials, err := s.credentialsDAO.ResetPassword(ctx, privateValidationCode, daoEmail, now)
+	var credentials *dao.CredentialsModel
+	err = s.credentialsDAO.RunInTx(ctx, func(ctx context.Context, txClient dao.CredentialsRepository) error {
+		credentials, err = txClient.ResetPassword(ctx, privateValidationCode, daoEmail, now)
+		if err != nil {
+			return goerrors.Join(ErrResetPassword, err)
+		}
+
+		return recordAuditEvent(ctx, txClient, newAuditEvent(dao.AuditEventPasswordResetRequested, credentials.ID, client), now)
+	})
 	if err != nil {
-		return nil, goerrors.Join(ErrResetPassword, err)
+		return nil, err
 	}
 
 	identity, err := s.identityDAO.GetIdentity(ctx, credentials.ID)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/a-novel/auth-service/pkg/dao"
 	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
 	"github.com/a-novel/auth-service/pkg/services"
 	"github.com/a-novel/bunovel"
 	goframework "github.com/a-novel/go-framework"
@@ -16,6 +17,8 @@ import (
 )
 
 func TestResetPassword(t *testing.T) {
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
 	data := []struct {
 		name string
 
@@ -36,6 +39,9 @@ func TestResetPassword(t *testing.T) {
 		credentialsDAO           *dao.CredentialsModel
 		credentialsDAOErr        error
 
+		shouldCallRecord bool
+		recordErr        error
+
 		shouldCallIdentityDAO bool
 		identityDAO           *dao.IdentityModel
 		identityDAOErr        error
@@ -66,6 +72,7 @@ func TestResetPassword(t *testing.T) {
 					Email: dao.Email{User: "user", Domain: "domain.com"},
 				},
 			},
+			shouldCallRecord:      true,
 			shouldCallIdentityDAO: true,
 			identityDAO: &dao.IdentityModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
@@ -98,6 +105,7 @@ func TestResetPassword(t *testing.T) {
 					Email: dao.Email{User: "user", Domain: "domain.com"},
 				},
 			},
+			shouldCallRecord:      true,
 			shouldCallIdentityDAO: true,
 			identityDAO: &dao.IdentityModel{
 				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
@@ -132,10 +140,32 @@ func TestResetPassword(t *testing.T) {
 					Email: dao.Email{User: "user", Domain: "domain.com"},
 				},
 			},
+			shouldCallRecord:      true,
 			shouldCallIdentityDAO: true,
 			identityDAOErr:        fooErr,
 			expectErr:             fooErr,
 		},
+		{
+			name:                     "Error/RecordAuditEventFailure",
+			email:                    "user@domain.com",
+			now:                      baseTime,
+			passwordResetLink:        "password-reset-link",
+			passwordResetTemplate:    "password-reset-template",
+			updatePasswordLink:       "update-password-link",
+			updatePasswordTemplate:   "update-password-template",
+			publicValidationCode:     "public-validation-code",
+			privateValidationCode:    "private-validation-code",
+			shouldCallCredentialsDAO: true,
+			credentialsDAO: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain.com"},
+				},
+			},
+			shouldCallRecord: true,
+			recordErr:        fooErr,
+			expectErr:        fooErr,
+		},
 		{
 			name:                     "Error/CredentialsDAOFailure",
 			email:                    "user@domain.com",
@@ -185,6 +215,24 @@ func TestResetPassword(t *testing.T) {
 				credentialsDAO.
 					On("ResetPassword", context.Background(), d.privateValidationCode, mock.Anything, d.now).
 					Return(d.credentialsDAO, d.credentialsDAOErr)
+
+				// Execute the actual method, but call the mocks inside of it.
+				txCall := credentialsDAO.On("RunInTx", context.Background(), mock.Anything)
+				txCall.Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context, dao.CredentialsRepository) error)
+					txCall.ReturnArguments = []interface{}{fn(context.Background(), credentialsDAO)}
+				})
+			}
+
+			if d.shouldCallRecord {
+				credentialsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:      dao.AuditEventPasswordResetRequested,
+						UserID:    d.credentialsDAO.ID,
+						IP:        client.IP,
+						UserAgent: client.UserAgent,
+					}, mock.Anything, d.now).
+					Return(nil, d.recordErr)
 			}
 
 			if d.shouldCallIdentityDAO {
@@ -200,7 +248,7 @@ func TestResetPassword(t *testing.T) {
 			}
 
 			service := services.NewResetPasswordService(credentialsDAO, identityDAO, mailerService, generateLink, d.updatePasswordLink, d.updatePasswordTemplate)
-			deferred, err := service.ResetPassword(context.Background(), d.email, d.now)
+			deferred, err := service.ResetPassword(context.Background(), d.email, client, d.now)
 
 			require.ErrorIs(t, err, d.expectErr)
 
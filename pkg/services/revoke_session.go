@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"time"
+)
+
+type RevokeSessionService interface {
+	// RevokeSession ends one of the sessions of the user who owns the token. The refresh token family of the session
+	// is revoked, and the access tokens it issued are rejected on introspection. The password is required if the user
+	// did not authenticate recently.
+	RevokeSession(ctx context.Context, tokenRaw string, id uuid.UUID, password string, client models.ClientInfo, now time.Time) error
+}
+
+func NewRevokeSessionService(
+	sessionsDAO dao.SessionsRepository,
+	refreshTokensDAO dao.RefreshTokensRepository,
+	introspectTokenService IntrospectTokenService,
+	checkStepUpService CheckStepUpService,
+) RevokeSessionService {
+	return &revokeSessionServiceImpl{
+		sessionsDAO:            sessionsDAO,
+		refreshTokensDAO:       refreshTokensDAO,
+		IntrospectTokenService: introspectTokenService,
+		CheckStepUpService:     checkStepUpService,
+	}
+}
+
+type revokeSessionServiceImpl struct {
+	sessionsDAO      dao.SessionsRepository
+	refreshTokensDAO dao.RefreshTokensRepository
+	IntrospectTokenService
+	CheckStepUpService
+}
+
+func (s *revokeSessionServiceImpl) RevokeSession(ctx context.Context, tokenRaw string, id uuid.UUID, password string, client models.ClientInfo, now time.Time) error {
+	token, err := s.IntrospectToken(ctx, tokenRaw, now, false)
+	if err != nil {
+		return goerrors.Join(ErrIntrospectToken, err)
+	}
+	if !token.OK {
+		return goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
+	}
+
+	if err := s.CheckStepUp(ctx, token.Token, SensitiveOperationRevokeSession, password, client, now); err != nil {
+		return goerrors.Join(ErrCheckStepUp, err)
+	}
+
+	session, err := s.sessionsDAO.Revoke(ctx, id, token.Token.Payload.ID, now)
+	if err != nil {
+		return goerrors.Join(ErrRevokeSession, err)
+	}
+
+	if err := s.refreshTokensDAO.RevokeFamily(ctx, session.FamilyID, now); err != nil {
+		return goerrors.Join(ErrRevokeTokenFamily, err)
+	}
+
+	return nil
+}
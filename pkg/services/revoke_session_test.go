@@ -0,0 +1,181 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRevokeSession(t *testing.T) {
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		password string
+		id       uuid.UUID
+		now      time.Time
+
+		introspectTokenResp *models.UserTokenStatus
+		introspectTokenErr  error
+
+		shouldCallCheckStepUp bool
+		checkStepUpErr        error
+
+		shouldCallRevoke bool
+		revoke           *dao.SessionModel
+		revokeErr        error
+
+		shouldCallRevokeFamily bool
+		revokeFamilyErr        error
+
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			tokenRaw: "string-token",
+			id:       goframework.NumberUUID(10),
+			now:      baseTime,
+			introspectTokenResp: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallCheckStepUp: true,
+			shouldCallRevoke:      true,
+			revoke: &dao.SessionModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, &baseTime),
+				SessionModelCore: dao.SessionModelCore{
+					FamilyID:  goframework.NumberUUID(200),
+					UserID:    goframework.NumberUUID(1),
+					RevokedAt: &baseTime,
+				},
+			},
+			shouldCallRevokeFamily: true,
+		},
+		{
+			name:     "Error/RevokeFamilyFailure",
+			tokenRaw: "string-token",
+			id:       goframework.NumberUUID(10),
+			now:      baseTime,
+			introspectTokenResp: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallCheckStepUp: true,
+			shouldCallRevoke:      true,
+			revoke: &dao.SessionModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, &baseTime),
+				SessionModelCore: dao.SessionModelCore{
+					FamilyID:  goframework.NumberUUID(200),
+					UserID:    goframework.NumberUUID(1),
+					RevokedAt: &baseTime,
+				},
+			},
+			shouldCallRevokeFamily: true,
+			revokeFamilyErr:        fooErr,
+			expectErr:              fooErr,
+		},
+		{
+			name:     "Error/RevokeFailure",
+			tokenRaw: "string-token",
+			id:       goframework.NumberUUID(10),
+			now:      baseTime,
+			introspectTokenResp: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallCheckStepUp: true,
+			shouldCallRevoke:      true,
+			revokeErr:             bunovel.ErrNotFound,
+			expectErr:             bunovel.ErrNotFound,
+		},
+		{
+			name:               "Error/IntrospectTokenFailure",
+			tokenRaw:           "string-token",
+			id:                 goframework.NumberUUID(10),
+			now:                baseTime,
+			introspectTokenErr: fooErr,
+			expectErr:          fooErr,
+		},
+		{
+			name:     "Error/CheckStepUpFailure",
+			tokenRaw: "string-token",
+			id:       goframework.NumberUUID(10),
+			now:      baseTime,
+			introspectTokenResp: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), FamilyID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallCheckStepUp: true,
+			checkStepUpErr:        fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:                "Error/InvalidToken",
+			tokenRaw:            "string-token",
+			id:                  goframework.NumberUUID(10),
+			now:                 baseTime,
+			introspectTokenResp: &models.UserTokenStatus{OK: false},
+			expectErr:           goframework.ErrInvalidCredentials,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			sessionsDAO := daomocks.NewSessionsRepository(t)
+			refreshTokensDAO := daomocks.NewRefreshTokensRepository(t)
+			introspectTokenService := servicesmocks.NewIntrospectTokenService(t)
+			checkStepUpService := servicesmocks.NewCheckStepUpService(t)
+
+			introspectTokenService.
+				On("IntrospectToken", context.Background(), d.tokenRaw, d.now, false).
+				Return(d.introspectTokenResp, d.introspectTokenErr)
+
+			if d.shouldCallCheckStepUp {
+				checkStepUpService.
+					On("CheckStepUp", context.Background(), d.introspectTokenResp.Token, services.SensitiveOperationRevokeSession, d.password, client, d.now).
+					Return(d.checkStepUpErr)
+			}
+
+			if d.shouldCallRevoke {
+				sessionsDAO.
+					On("Revoke", context.Background(), d.id, d.introspectTokenResp.Token.Payload.ID, d.now).
+					Return(d.revoke, d.revokeErr)
+			}
+
+			if d.shouldCallRevokeFamily {
+				refreshTokensDAO.
+					On("RevokeFamily", context.Background(), d.revoke.FamilyID, d.now).
+					Return(d.revokeFamilyErr)
+			}
+
+			service := services.NewRevokeSessionService(sessionsDAO, refreshTokensDAO, introspectTokenService, checkStepUpService)
+			err := service.RevokeSession(context.Background(), d.tokenRaw, d.id, d.password, client, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			sessionsDAO.AssertExpectations(t)
+			refreshTokensDAO.AssertExpectations(t)
+			introspectTokenService.AssertExpectations(t)
+			checkStepUpService.AssertExpectations(t)
+		})
+	}
+}
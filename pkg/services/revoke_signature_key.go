@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"strings"
+	"time"
+)
+
+type RevokeSignatureKeyService interface {
+	// RevokeSignatureKey stops trusting the given signature key, for signing and verifying tokens alike, on every
+	// instance. If no active key remains, a new one is rotated in right away, so tokens can still be issued.
+	//
+	// Sessions holding a token signed with the key are not revoked: they get a new token with their refresh token.
+	// The number of such sessions is returned, to decide whether users should be forced to log in again. The actor
+	// is recorded in the audit log.
+	RevokeSignatureKey(ctx context.Context, name string, actor string, now time.Time) (*models.RevokedSignatureKey, error)
+}
+
+func NewRevokeSignatureKeyService(
+	secretKeysDAO dao.SecretKeysRepository,
+	revokedSignatureKeysDAO dao.RevokedSignatureKeysRepository,
+	sessionsDAO dao.SessionsRepository,
+	auditEventsDAO dao.AuditEventsRepository,
+	rotateSecretKeysService RotateSecretKeysService,
+) RevokeSignatureKeyService {
+	return &revokeSignatureKeyServiceImpl{
+		secretKeysDAO:           secretKeysDAO,
+		revokedSignatureKeysDAO: revokedSignatureKeysDAO,
+		sessionsDAO:             sessionsDAO,
+		auditEventsDAO:          auditEventsDAO,
+		RotateSecretKeysService: rotateSecretKeysService,
+	}
+}
+
+type revokeSignatureKeyServiceImpl struct {
+	secretKeysDAO           dao.SecretKeysRepository
+	revokedSignatureKeysDAO dao.RevokedSignatureKeysRepository
+	sessionsDAO             dao.SessionsRepository
+	auditEventsDAO          dao.AuditEventsRepository
+	RotateSecretKeysService
+}
+
+func (s *revokeSignatureKeyServiceImpl) RevokeSignatureKey(ctx context.Context, name string, actor string, now time.Time) (*models.RevokedSignatureKey, error) {
+	// Names are used as file paths by some repositories.
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSignatureKey)
+	}
+
+	key, err := s.secretKeysDAO.Read(ctx, name)
+	if err != nil {
+		return nil, goerrors.Join(ErrReadSignatureKey, err)
+	}
+
+	revoked, err := s.revokedSignatureKeysDAO.Revoke(ctx, key.KeyID(), key.Name, now)
+	if err != nil {
+		return nil, goerrors.Join(ErrRevokeSignatureKey, err)
+	}
+
+	err = recordAuditEvent(ctx, s.auditEventsDAO, &dao.AuditEventModelCore{
+		Kind:    dao.AuditEventKeyRevoked,
+		Actor:   actor,
+		Details: map[string]string{"key": revoked.Name, "kid": revoked.KeyID},
+	}, now)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &models.RevokedSignatureKey{
+		Name:      revoked.Name,
+		KID:       revoked.KeyID,
+		RevokedAt: revoked.CreatedAt,
+	}
+
+	// Revoked keys are no longer listed.
+	keys, err := s.secretKeysDAO.List(ctx)
+	if err != nil {
+		return nil, goerrors.Join(ErrListSignatureKeys, err)
+	}
+
+	if !lo.ContainsBy(keys, func(item *dao.SecretKeyModel) bool { return item.IsActive(now) }) {
+		if err = s.RotateSecretKeys(ctx, actor, now); err != nil {
+			return nil, goerrors.Join(ErrRotateSignatureKeys, err)
+		}
+
+		output.Rotated = true
+	}
+
+	output.LiveSessions, err = s.sessionsDAO.CountLiveSessionsByKey(ctx, revoked.KeyID, now)
+	if err != nil {
+		return nil, goerrors.Join(ErrCountSessions, err)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,293 @@
+package services_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRevokeSignatureKey(t *testing.T) {
+	revokedKey := &dao.SecretKeyModel{Name: "key-0", Key: MockedSecretKeys[0]}
+
+	data := []struct {
+		name string
+
+		keyName string
+		actor   string
+		now     time.Time
+
+		shouldCallRead bool
+		read           *dao.SecretKeyModel
+		readErr        error
+
+		shouldCallRevoke bool
+		revoke           *dao.RevokedSignatureKeyModel
+		revokeErr        error
+
+		shouldCallRecord bool
+		recordErr        error
+
+		shouldCallList bool
+		list           []*dao.SecretKeyModel
+		listErr        error
+
+		shouldCallRotate bool
+		rotateErr        error
+
+		shouldCallCount bool
+		count           int
+		countErr        error
+
+		expect    *models.RevokedSignatureKey
+		expectErr error
+	}{
+		{
+			name:             "Success",
+			keyName:          "key-0",
+			actor:            "admin",
+			now:              baseTime,
+			shouldCallRead:   true,
+			read:             revokedKey,
+			shouldCallRevoke: true,
+			revoke:           &dao.RevokedSignatureKeyModel{KeyID: revokedKey.KeyID(), CreatedAt: baseTime, Name: "key-0"},
+			shouldCallRecord: true,
+			shouldCallList:   true,
+			list: []*dao.SecretKeyModel{
+				{Name: "key-1", Key: MockedSecretKeys[1], Date: baseTime.Add(-time.Hour), ActivatesAt: baseTime.Add(-time.Hour)},
+			},
+			shouldCallCount: true,
+			count:           12,
+			expect: &models.RevokedSignatureKey{
+				Name:         "key-0",
+				KID:          revokedKey.KeyID(),
+				RevokedAt:    baseTime,
+				LiveSessions: 12,
+			},
+		},
+		{
+			name:             "Success/RotateLastActiveKey",
+			keyName:          "key-0",
+			actor:            "admin",
+			now:              baseTime,
+			shouldCallRead:   true,
+			read:             revokedKey,
+			shouldCallRevoke: true,
+			revoke:           &dao.RevokedSignatureKeyModel{KeyID: revokedKey.KeyID(), CreatedAt: baseTime, Name: "key-0"},
+			shouldCallRecord: true,
+			shouldCallList:   true,
+			list: []*dao.SecretKeyModel{
+				// Not active yet.
+				{Name: "key-1", Key: MockedSecretKeys[1], Date: baseTime, ActivatesAt: baseTime.Add(time.Hour)},
+			},
+			shouldCallRotate: true,
+			shouldCallCount:  true,
+			count:            12,
+			expect: &models.RevokedSignatureKey{
+				Name:         "key-0",
+				KID:          revokedKey.KeyID(),
+				RevokedAt:    baseTime,
+				LiveSessions: 12,
+				Rotated:      true,
+			},
+		},
+		{
+			name:             "Error/CountFailure",
+			keyName:          "key-0",
+			actor:            "admin",
+			now:              baseTime,
+			shouldCallRead:   true,
+			read:             revokedKey,
+			shouldCallRevoke: true,
+			revoke:           &dao.RevokedSignatureKeyModel{KeyID: revokedKey.KeyID(), CreatedAt: baseTime, Name: "key-0"},
+			shouldCallRecord: true,
+			shouldCallList:   true,
+			list: []*dao.SecretKeyModel{
+				{Name: "key-1", Key: MockedSecretKeys[1], Date: baseTime.Add(-time.Hour), ActivatesAt: baseTime.Add(-time.Hour)},
+			},
+			shouldCallCount: true,
+			countErr:        fooErr,
+			expectErr:       fooErr,
+		},
+		{
+			name:             "Error/RotateFailure",
+			keyName:          "key-0",
+			actor:            "admin",
+			now:              baseTime,
+			shouldCallRead:   true,
+			read:             revokedKey,
+			shouldCallRevoke: true,
+			revoke:           &dao.RevokedSignatureKeyModel{KeyID: revokedKey.KeyID(), CreatedAt: baseTime, Name: "key-0"},
+			shouldCallRecord: true,
+			shouldCallList:   true,
+			shouldCallRotate: true,
+			rotateErr:        fooErr,
+			expectErr:        fooErr,
+		},
+		{
+			name:             "Error/ListFailure",
+			keyName:          "key-0",
+			actor:            "admin",
+			now:              baseTime,
+			shouldCallRead:   true,
+			read:             revokedKey,
+			shouldCallRevoke: true,
+			revoke:           &dao.RevokedSignatureKeyModel{KeyID: revokedKey.KeyID(), CreatedAt: baseTime, Name: "key-0"},
+			shouldCallRecord: true,
+			shouldCallList:   true,
+			listErr:          fooErr,
+			expectErr:        fooErr,
+		},
+		{
+			name:             "Error/RecordAuditEventFailure",
+			keyName:          "key-0",
+			actor:            "admin",
+			now:              baseTime,
+			shouldCallRead:   true,
+			read:             revokedKey,
+			shouldCallRevoke: true,
+			revoke:           &dao.RevokedSignatureKeyModel{KeyID: revokedKey.KeyID(), CreatedAt: baseTime, Name: "key-0"},
+			shouldCallRecord: true,
+			recordErr:        fooErr,
+			expectErr:        fooErr,
+		},
+		{
+			name:             "Error/RevokeFailure",
+			keyName:          "key-0",
+			actor:            "admin",
+			now:              baseTime,
+			shouldCallRead:   true,
+			read:             revokedKey,
+			shouldCallRevoke: true,
+			revokeErr:        fooErr,
+			expectErr:        fooErr,
+		},
+		{
+			name:           "Error/NotFound",
+			keyName:        "key-0",
+			now:            baseTime,
+			shouldCallRead: true,
+			readErr:        bunovel.ErrNotFound,
+			expectErr:      bunovel.ErrNotFound,
+		},
+		{
+			name:      "Error/NoName",
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name:      "Error/InvalidName",
+			keyName:   "../key-0",
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			secretKeysDAO := daomocks.NewSecretKeysRepository(t)
+			revokedSignatureKeysDAO := daomocks.NewRevokedSignatureKeysRepository(t)
+			sessionsDAO := daomocks.NewSessionsRepository(t)
+			auditEventsDAO := daomocks.NewAuditEventsRepository(t)
+			rotateSecretKeysService := servicesmocks.NewRotateSecretKeysService(t)
+
+			if d.shouldCallRead {
+				secretKeysDAO.On("Read", context.Background(), d.keyName).Return(d.read, d.readErr)
+			}
+
+			if d.shouldCallRevoke {
+				revokedSignatureKeysDAO.
+					On("Revoke", context.Background(), d.read.KeyID(), d.read.Name, d.now).
+					Return(d.revoke, d.revokeErr)
+			}
+
+			if d.shouldCallRecord {
+				auditEventsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:    dao.AuditEventKeyRevoked,
+						Actor:   d.actor,
+						Details: map[string]string{"key": d.revoke.Name, "kid": d.revoke.KeyID},
+					}, mock.Anything, d.now).
+					Return(nil, d.recordErr)
+			}
+
+			if d.shouldCallList {
+				secretKeysDAO.On("List", context.Background()).Return(d.list, d.listErr)
+			}
+
+			if d.shouldCallRotate {
+				rotateSecretKeysService.On("RotateSecretKeys", context.Background(), d.actor, d.now).Return(d.rotateErr)
+			}
+
+			if d.shouldCallCount {
+				sessionsDAO.On("CountLiveSessionsByKey", context.Background(), d.read.KeyID(), d.now).Return(d.count, d.countErr)
+			}
+
+			service := services.NewRevokeSignatureKeyService(secretKeysDAO, revokedSignatureKeysDAO, sessionsDAO, auditEventsDAO, rotateSecretKeysService)
+			res, err := service.RevokeSignatureKey(context.Background(), d.keyName, d.actor, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			secretKeysDAO.AssertExpectations(t)
+			revokedSignatureKeysDAO.AssertExpectations(t)
+			sessionsDAO.AssertExpectations(t)
+			auditEventsDAO.AssertExpectations(t)
+			rotateSecretKeysService.AssertExpectations(t)
+		})
+	}
+}
+
+// The replacement of the last active key must be activated right away, even though the revoked key is still stored.
+func TestRevokeSignatureKey_RotateLastActiveKeyActivatesNow(t *testing.T) {
+	ctx := context.Background()
+	now := baseTime
+
+	source := dao.NewFileSystemSecretKeysRepository(t.TempDir(), "foo")
+	_, err := source.Write(ctx, MockedSecretKeys[0], "key-0", now.Add(-time.Hour), now.Add(-time.Hour))
+	require.NoError(t, err)
+
+	var revoked []*dao.RevokedSignatureKeyModel
+
+	revokedSignatureKeysDAO := daomocks.NewRevokedSignatureKeysRepository(t)
+	revokedSignatureKeysDAO.
+		On("List", ctx).
+		Return(func(context.Context) ([]*dao.RevokedSignatureKeyModel, error) { return revoked, nil })
+	revokedSignatureKeysDAO.
+		On("Revoke", ctx, mock.Anything, "key-0", now).
+		Run(func(args mock.Arguments) {
+			revoked = append(revoked, &dao.RevokedSignatureKeyModel{KeyID: args.String(1), CreatedAt: now, Name: "key-0"})
+		}).
+		Return(func(_ context.Context, keyID, name string, _ time.Time) (*dao.RevokedSignatureKeyModel, error) {
+			return &dao.RevokedSignatureKeyModel{KeyID: keyID, CreatedAt: now, Name: name}, nil
+		})
+
+	sessionsDAO := daomocks.NewSessionsRepository(t)
+	sessionsDAO.On("CountLiveSessionsByKey", ctx, mock.Anything, now).Return(0, nil)
+
+	auditEventsDAO := daomocks.NewAuditEventsRepository(t)
+	auditEventsDAO.On("RecordAuditEvent", ctx, mock.Anything, mock.Anything, now).Return(nil, nil)
+
+	secretKeysDAO := dao.NewRevocationFilteredSecretKeysRepository(source, revokedSignatureKeysDAO)
+	keyGen := func() (ed25519.PrivateKey, error) { return MockedSecretKeys[1], nil }
+	rotateSecretKeysService := services.NewRotateSecretKeysService(secretKeysDAO, auditEventsDAO, keyGen, 8, 4*time.Hour)
+
+	service := services.NewRevokeSignatureKeyService(secretKeysDAO, revokedSignatureKeysDAO, sessionsDAO, auditEventsDAO, rotateSecretKeysService)
+	res, err := service.RevokeSignatureKey(ctx, "key-0", "admin", now)
+	require.NoError(t, err)
+	require.True(t, res.Rotated)
+
+	keys, err := secretKeysDAO.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.True(t, keys[0].ActivatesAt.Equal(now))
+}
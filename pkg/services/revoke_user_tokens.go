@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"time"
+)
+
+type RevokeUserTokensService interface {
+	// RevokeUserTokens revokes every token issued to the given user so far, including refresh tokens. The user has
+	// to log in again to get new tokens. The security stamp of the user is rotated in the process.
+	RevokeUserTokens(ctx context.Context, userID uuid.UUID, now time.Time) error
+}
+
+func NewRevokeUserTokensService(
+	credentialsDAO dao.CredentialsRepository,
+	revokedTokensDAO dao.RevokedTokensRepository,
+	refreshTokensDAO dao.RefreshTokensRepository,
+	tokenTTL time.Duration,
+) RevokeUserTokensService {
+	return &revokeUserTokensServiceImpl{
+		credentialsDAO:   credentialsDAO,
+		revokedTokensDAO: revokedTokensDAO,
+		refreshTokensDAO: refreshTokensDAO,
+		tokenTTL:         tokenTTL,
+	}
+}
+
+type revokeUserTokensServiceImpl struct {
+	credentialsDAO   dao.CredentialsRepository
+	revokedTokensDAO dao.RevokedTokensRepository
+	refreshTokensDAO dao.RefreshTokensRepository
+	tokenTTL         time.Duration
+}
+
+func (s *revokeUserTokensServiceImpl) RevokeUserTokens(ctx context.Context, userID uuid.UUID, now time.Time) error {
+	if userID == uuid.Nil {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidUserID)
+	}
+
+	// A new stamp is enough to reject every token of the user. Tokens are still revoked below, so sessions are
+	// closed and the revocation does not depend on a credentials lookup.
+	if _, err := s.credentialsDAO.RotateSecurityStamp(ctx, uuid.New(), userID, now); err != nil {
+		return goerrors.Join(ErrRotateSecurityStamp, err)
+	}
+
+	// Revoke refresh tokens first, so no new access token can be issued once the others are revoked.
+	if err := s.refreshTokensDAO.RevokeUser(ctx, userID, now); err != nil {
+		return goerrors.Join(ErrRevokeUserTokens, err)
+	}
+
+	// Every token issued before now expires within one TTL, after which the revocation is no longer needed.
+	if _, err := s.revokedTokensDAO.RevokeUser(ctx, userID, now.Add(s.tokenTTL), now); err != nil {
+		return goerrors.Join(ErrRevokeUserTokens, err)
+	}
+
+	return nil
+}
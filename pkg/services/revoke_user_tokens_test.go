@@ -0,0 +1,127 @@
+package services_test
+
+import (
+	"context"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRevokeUserTokens(t *testing.T) {
+	data := []struct {
+		name string
+
+		userID   uuid.UUID
+		now      time.Time
+		tokenTTL time.Duration
+
+		shouldCallRotateSecurityStamp bool
+		rotateSecurityStampErr        error
+
+		shouldCallRevokeRefreshTokens bool
+		revokeRefreshTokensErr        error
+
+		shouldCallRevokeUser bool
+		revokeUserErr        error
+
+		expectErr error
+	}{
+		{
+			name:                          "Success",
+			userID:                        goframework.NumberUUID(1),
+			now:                           baseTime,
+			tokenTTL:                      time.Hour,
+			shouldCallRotateSecurityStamp: true,
+			shouldCallRevokeRefreshTokens: true,
+			shouldCallRevokeUser:          true,
+		},
+		{
+			name:                          "Error/RevokeUserFailure",
+			userID:                        goframework.NumberUUID(1),
+			now:                           baseTime,
+			tokenTTL:                      time.Hour,
+			shouldCallRotateSecurityStamp: true,
+			shouldCallRevokeRefreshTokens: true,
+			shouldCallRevokeUser:          true,
+			revokeUserErr:                 fooErr,
+			expectErr:                     fooErr,
+		},
+		{
+			name:                          "Error/RevokeRefreshTokensFailure",
+			userID:                        goframework.NumberUUID(1),
+			now:                           baseTime,
+			tokenTTL:                      time.Hour,
+			shouldCallRotateSecurityStamp: true,
+			shouldCallRevokeRefreshTokens: true,
+			revokeRefreshTokensErr:        fooErr,
+			expectErr:                     fooErr,
+		},
+		{
+			name:                          "Error/UserNotFound",
+			userID:                        goframework.NumberUUID(1),
+			now:                           baseTime,
+			tokenTTL:                      time.Hour,
+			shouldCallRotateSecurityStamp: true,
+			rotateSecurityStampErr:        bunovel.ErrNotFound,
+			expectErr:                     bunovel.ErrNotFound,
+		},
+		{
+			name:                          "Error/RotateSecurityStampFailure",
+			userID:                        goframework.NumberUUID(1),
+			now:                           baseTime,
+			tokenTTL:                      time.Hour,
+			shouldCallRotateSecurityStamp: true,
+			rotateSecurityStampErr:        fooErr,
+			expectErr:                     fooErr,
+		},
+		{
+			name:      "Error/NoUserID",
+			now:       baseTime,
+			tokenTTL:  time.Hour,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			revokedTokensDAO := daomocks.NewRevokedTokensRepository(t)
+			refreshTokensDAO := daomocks.NewRefreshTokensRepository(t)
+
+			if d.shouldCallRotateSecurityStamp {
+				credentialsDAO.
+					On("RotateSecurityStamp", context.Background(), mock.MatchedBy(func(stamp uuid.UUID) bool {
+						return stamp != uuid.Nil
+					}), d.userID, d.now).
+					Return(nil, d.rotateSecurityStampErr)
+			}
+
+			if d.shouldCallRevokeRefreshTokens {
+				refreshTokensDAO.
+					On("RevokeUser", context.Background(), d.userID, d.now).
+					Return(d.revokeRefreshTokensErr)
+			}
+
+			if d.shouldCallRevokeUser {
+				revokedTokensDAO.
+					On("RevokeUser", context.Background(), d.userID, d.now.Add(d.tokenTTL), d.now).
+					Return(nil, d.revokeUserErr)
+			}
+
+			service := services.NewRevokeUserTokensService(credentialsDAO, revokedTokensDAO, refreshTokensDAO, d.tokenTTL)
+			err := service.RevokeUserTokens(context.Background(), d.userID, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			credentialsDAO.AssertExpectations(t)
+			revokedTokensDAO.AssertExpectations(t)
+			refreshTokensDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -6,52 +6,89 @@ import (
 	goerrors "errors"
 	"github.com/a-novel/auth-service/pkg/dao"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"time"
 )
 
 type RotateSecretKeysService interface {
-	RotateSecretKeys(ctx context.Context) error
+	// RotateSecretKeys publishes a new signature key, and removes the oldest ones. The new key only becomes active
+	// after the activation delay, so every instance has the time to load it before it is used to sign tokens. If no
+	// key is currently active, the new key is activated right away. The actor is recorded in the audit log.
+	RotateSecretKeys(ctx context.Context, actor string, now time.Time) error
 }
 
 func NewRotateSecretKeysService(
 	secretKeysDAO dao.SecretKeysRepository,
+	auditEventsDAO dao.AuditEventsRepository,
 	keyGen func() (ed25519.PrivateKey, error),
 	maxBackups int,
+	activationDelay time.Duration,
 ) RotateSecretKeysService {
 	return &rotateSecretKeysServiceImpl{
-		secretKeysDAO: secretKeysDAO,
-		keyGen:        keyGen,
-		maxBackups:    maxBackups,
+		secretKeysDAO:   secretKeysDAO,
+		auditEventsDAO:  auditEventsDAO,
+		keyGen:          keyGen,
+		maxBackups:      maxBackups,
+		activationDelay: activationDelay,
 	}
 }
 
 type rotateSecretKeysServiceImpl struct {
-	secretKeysDAO dao.SecretKeysRepository
-	keyGen        func() (ed25519.PrivateKey, error)
-	maxBackups    int
+	secretKeysDAO   dao.SecretKeysRepository
+	auditEventsDAO  dao.AuditEventsRepository
+	keyGen          func() (ed25519.PrivateKey, error)
+	maxBackups      int
+	activationDelay time.Duration
 }
 
-func (s *rotateSecretKeysServiceImpl) RotateSecretKeys(ctx context.Context) error {
+func (s *rotateSecretKeysServiceImpl) RotateSecretKeys(ctx context.Context, actor string, now time.Time) error {
 	newKey, err := s.keyGen()
 	if err != nil {
 		return goerrors.Join(ErrGenerateSignatureKey, err)
 	}
 
-	if _, err := s.secretKeysDAO.Write(ctx, newKey, uuid.NewString()); err != nil {
-		return goerrors.Join(ErrWriteSignatureKey, err)
-	}
+	newKeyName := uuid.NewString()
 
-	keys, err := s.secretKeysDAO.List(ctx)
-	if err != nil {
-		return goerrors.Join(ErrListSignatureKeys, err)
-	}
+	// Rotate and trim in a single transaction, so concurrent rotations do not remove each other's keys.
+	err = s.secretKeysDAO.RunInTx(ctx, func(ctx context.Context, txRepository dao.SecretKeysRepository) error {
+		currentKeys, err := txRepository.List(ctx)
+		if err != nil {
+			return goerrors.Join(ErrListSignatureKeys, err)
+		}
+
+		activatesAt := now.Add(s.activationDelay)
+		// Without an active key, no token can be issued, so there is no point in waiting.
+		if !lo.ContainsBy(currentKeys, func(item *dao.SecretKeyModel) bool { return item.IsActive(now) }) {
+			activatesAt = now
+		}
 
-	if len(keys) > s.maxBackups {
-		for _, extraKey := range keys[s.maxBackups:] {
-			if err = s.secretKeysDAO.Delete(ctx, extraKey.Name); err != nil {
-				return goerrors.Join(ErrDeleteSignatureKey, err)
+		if _, err := txRepository.Write(ctx, newKey, newKeyName, now, activatesAt); err != nil {
+			return goerrors.Join(ErrWriteSignatureKey, err)
+		}
+
+		keys, err := txRepository.List(ctx)
+		if err != nil {
+			return goerrors.Join(ErrListSignatureKeys, err)
+		}
+
+		if len(keys) > s.maxBackups {
+			for _, extraKey := range keys[s.maxBackups:] {
+				if err = txRepository.Delete(ctx, extraKey.Name); err != nil {
+					return goerrors.Join(ErrDeleteSignatureKey, err)
+				}
 			}
 		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	return nil
+	// Keys are not saved in the main database, so the event is recorded once the rotation is committed.
+	return recordAuditEvent(ctx, s.auditEventsDAO, &dao.AuditEventModelCore{
+		Kind:    dao.AuditEventKeysRotated,
+		Actor:   actor,
+		Details: map[string]string{"key": newKeyName},
+	}, now)
 }
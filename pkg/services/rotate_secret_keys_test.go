@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestRotateSecretKeys(t *testing.T) {
@@ -20,13 +21,20 @@ func TestRotateSecretKeys(t *testing.T) {
 	data := []struct {
 		name string
 
-		maxBackups int
+		maxBackups      int
+		activationDelay time.Duration
+		now             time.Time
 
 		keyGen    ed25519.PrivateKey
 		keyGenErr error
 
-		shouldCallWrite bool
-		writeErr        error
+		shouldCallListCurrent bool
+		listCurrent           []*dao.SecretKeyModel
+		listCurrentErr        error
+
+		shouldCallWrite       bool
+		shouldWriteActivation time.Time
+		writeErr              error
 
 		shouldCallList bool
 		list           []*dao.SecretKeyModel
@@ -35,14 +43,22 @@ func TestRotateSecretKeys(t *testing.T) {
 		shouldCallDelete bool
 		deleteCalls      []deleteCall
 
+		shouldCallRecord bool
+		recordErr        error
+
 		expectErr error
 	}{
 		{
-			name:            "Success/NoInitialKeys",
-			maxBackups:      3,
-			keyGen:          MockedSecretKeys[0],
-			shouldCallWrite: true,
-			shouldCallList:  true,
+			name:                  "Success/NoInitialKeys",
+			shouldCallRecord:      true,
+			maxBackups:            3,
+			activationDelay:       time.Hour,
+			now:                   baseTime,
+			keyGen:                MockedSecretKeys[0],
+			shouldCallListCurrent: true,
+			shouldCallWrite:       true,
+			shouldWriteActivation: baseTime,
+			shouldCallList:        true,
 			list: []*dao.SecretKeyModel{
 				{
 					Name: "key-0",
@@ -51,11 +67,24 @@ func TestRotateSecretKeys(t *testing.T) {
 			},
 		},
 		{
-			name:            "Success",
-			maxBackups:      3,
-			keyGen:          MockedSecretKeys[0],
-			shouldCallWrite: true,
-			shouldCallList:  true,
+			name:                  "Success",
+			shouldCallRecord:      true,
+			maxBackups:            3,
+			activationDelay:       time.Hour,
+			now:                   baseTime,
+			keyGen:                MockedSecretKeys[0],
+			shouldCallListCurrent: true,
+			listCurrent: []*dao.SecretKeyModel{
+				{
+					Name:        "key-1",
+					Key:         MockedSecretKeys[1],
+					Date:        baseTime.Add(-time.Hour),
+					ActivatesAt: baseTime.Add(-time.Hour),
+				},
+			},
+			shouldCallWrite:       true,
+			shouldWriteActivation: baseTime.Add(time.Hour),
+			shouldCallList:        true,
 			list: []*dao.SecretKeyModel{
 				{
 					Name: "key-0",
@@ -72,11 +101,54 @@ func TestRotateSecretKeys(t *testing.T) {
 			},
 		},
 		{
-			name:            "Success/TooMuchKeys",
-			maxBackups:      2,
-			keyGen:          MockedSecretKeys[0],
-			shouldCallWrite: true,
-			shouldCallList:  true,
+			name:                  "Success/NoActiveKey",
+			shouldCallRecord:      true,
+			maxBackups:            3,
+			activationDelay:       time.Hour,
+			now:                   baseTime,
+			keyGen:                MockedSecretKeys[0],
+			shouldCallListCurrent: true,
+			listCurrent: []*dao.SecretKeyModel{
+				{
+					Name:        "key-1",
+					Key:         MockedSecretKeys[1],
+					Date:        baseTime.Add(-time.Minute),
+					ActivatesAt: baseTime.Add(time.Minute),
+				},
+			},
+			shouldCallWrite:       true,
+			shouldWriteActivation: baseTime,
+			shouldCallList:        true,
+			list: []*dao.SecretKeyModel{
+				{
+					Name: "key-0",
+					Key:  MockedSecretKeys[0],
+				},
+				{
+					Name: "key-1",
+					Key:  MockedSecretKeys[1],
+				},
+			},
+		},
+		{
+			name:                  "Success/TooMuchKeys",
+			shouldCallRecord:      true,
+			maxBackups:            2,
+			activationDelay:       time.Hour,
+			now:                   baseTime,
+			keyGen:                MockedSecretKeys[0],
+			shouldCallListCurrent: true,
+			listCurrent: []*dao.SecretKeyModel{
+				{
+					Name:        "key-1",
+					Key:         MockedSecretKeys[1],
+					Date:        baseTime.Add(-time.Hour),
+					ActivatesAt: baseTime.Add(-time.Hour),
+				},
+			},
+			shouldCallWrite:       true,
+			shouldWriteActivation: baseTime.Add(time.Hour),
+			shouldCallList:        true,
 			list: []*dao.SecretKeyModel{
 				{
 					Name: "key-0",
@@ -102,11 +174,43 @@ func TestRotateSecretKeys(t *testing.T) {
 			},
 		},
 		{
-			name:            "Error/DeleteKeyFailure",
-			maxBackups:      2,
-			keyGen:          MockedSecretKeys[0],
-			shouldCallWrite: true,
-			shouldCallList:  true,
+			name:                  "Error/RecordAuditEventFailure",
+			maxBackups:            3,
+			activationDelay:       time.Hour,
+			now:                   baseTime,
+			keyGen:                MockedSecretKeys[0],
+			shouldCallListCurrent: true,
+			shouldCallWrite:       true,
+			shouldWriteActivation: baseTime,
+			shouldCallList:        true,
+			list: []*dao.SecretKeyModel{
+				{
+					Name: "key-0",
+					Key:  MockedSecretKeys[0],
+				},
+			},
+			shouldCallRecord: true,
+			recordErr:        fooErr,
+			expectErr:        fooErr,
+		},
+		{
+			name:                  "Error/DeleteKeyFailure",
+			maxBackups:            2,
+			activationDelay:       time.Hour,
+			now:                   baseTime,
+			keyGen:                MockedSecretKeys[0],
+			shouldCallListCurrent: true,
+			listCurrent: []*dao.SecretKeyModel{
+				{
+					Name:        "key-1",
+					Key:         MockedSecretKeys[1],
+					Date:        baseTime.Add(-time.Hour),
+					ActivatesAt: baseTime.Add(-time.Hour),
+				},
+			},
+			shouldCallWrite:       true,
+			shouldWriteActivation: baseTime.Add(time.Hour),
+			shouldCallList:        true,
 			list: []*dao.SecretKeyModel{
 				{
 					Name: "key-0",
@@ -133,44 +237,101 @@ func TestRotateSecretKeys(t *testing.T) {
 			expectErr: fooErr,
 		},
 		{
-			name:            "Error/ListFailure",
-			maxBackups:      3,
-			keyGen:          MockedSecretKeys[0],
-			shouldCallWrite: true,
-			shouldCallList:  true,
-			listErr:         fooErr,
-			expectErr:       fooErr,
+			name:                  "Error/ListFailure",
+			maxBackups:            3,
+			activationDelay:       time.Hour,
+			now:                   baseTime,
+			keyGen:                MockedSecretKeys[0],
+			shouldCallListCurrent: true,
+			listCurrent: []*dao.SecretKeyModel{
+				{
+					Name:        "key-1",
+					Key:         MockedSecretKeys[1],
+					Date:        baseTime.Add(-time.Hour),
+					ActivatesAt: baseTime.Add(-time.Hour),
+				},
+			},
+			shouldCallWrite:       true,
+			shouldWriteActivation: baseTime.Add(time.Hour),
+			shouldCallList:        true,
+			listErr:               fooErr,
+			expectErr:             fooErr,
 		},
 		{
-			name:            "Error/WriteFailure",
-			maxBackups:      3,
-			keyGen:          MockedSecretKeys[0],
-			shouldCallWrite: true,
-			writeErr:        fooErr,
-			expectErr:       fooErr,
+			name:                  "Error/WriteFailure",
+			maxBackups:            3,
+			activationDelay:       time.Hour,
+			now:                   baseTime,
+			keyGen:                MockedSecretKeys[0],
+			shouldCallListCurrent: true,
+			listCurrent: []*dao.SecretKeyModel{
+				{
+					Name:        "key-1",
+					Key:         MockedSecretKeys[1],
+					Date:        baseTime.Add(-time.Hour),
+					ActivatesAt: baseTime.Add(-time.Hour),
+				},
+			},
+			shouldCallWrite:       true,
+			shouldWriteActivation: baseTime.Add(time.Hour),
+			writeErr:              fooErr,
+			expectErr:             fooErr,
 		},
 		{
-			name:       "Error/KeyGenFailure",
-			maxBackups: 3,
-			keyGenErr:  fooErr,
-			expectErr:  fooErr,
+			name:                  "Error/ListCurrentFailure",
+			maxBackups:            3,
+			activationDelay:       time.Hour,
+			now:                   baseTime,
+			keyGen:                MockedSecretKeys[0],
+			shouldCallListCurrent: true,
+			listCurrentErr:        fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:            "Error/KeyGenFailure",
+			maxBackups:      3,
+			activationDelay: time.Hour,
+			now:             baseTime,
+			keyGenErr:       fooErr,
+			expectErr:       fooErr,
 		},
 	}
 
 	for _, d := range data {
 		t.Run(d.name, func(t *testing.T) {
 			secretKeysDAO := daomocks.NewSecretKeysRepository(t)
+			auditEventsDAO := daomocks.NewAuditEventsRepository(t)
 
 			keyGen := func() (ed25519.PrivateKey, error) {
 				return d.keyGen, d.keyGenErr
 			}
 
+			if d.shouldCallListCurrent {
+				// Execute the actual method, but call the mocks inside of it.
+				txCall := secretKeysDAO.On("RunInTx", context.Background(), mock.Anything)
+				txCall.Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context, dao.SecretKeysRepository) error)
+					txCall.ReturnArguments = []interface{}{fn(context.Background(), secretKeysDAO)}
+				})
+
+				secretKeysDAO.On("List", context.Background()).Return(d.listCurrent, d.listCurrentErr).Once()
+			}
+
+			// The name of the new key is generated by the service, so it is captured when written, then checked
+			// against the audit event.
+			var keyName string
+
 			if d.shouldCallWrite {
-				secretKeysDAO.On("Write", context.Background(), d.keyGen, mock.Anything).Return(nil, d.writeErr)
+				secretKeysDAO.
+					On("Write", context.Background(), d.keyGen, mock.MatchedBy(func(name string) bool {
+						keyName = name
+						return name != ""
+					}), d.now, d.shouldWriteActivation).
+					Return(nil, d.writeErr)
 			}
 
 			if d.shouldCallList {
-				secretKeysDAO.On("List", context.Background()).Return(d.list, d.listErr)
+				secretKeysDAO.On("List", context.Background()).Return(d.list, d.listErr).Once()
 			}
 
 			if d.shouldCallDelete {
@@ -179,12 +340,23 @@ func TestRotateSecretKeys(t *testing.T) {
 				}
 			}
 
-			service := services.NewRotateSecretKeysService(secretKeysDAO, keyGen, d.maxBackups)
-			err := service.RotateSecretKeys(context.Background())
+			if d.shouldCallRecord {
+				auditEventsDAO.
+					On("RecordAuditEvent", context.Background(), mock.MatchedBy(func(core *dao.AuditEventModelCore) bool {
+						return core.Kind == dao.AuditEventKeysRotated &&
+							core.Actor == "admin" &&
+							core.Details["key"] == keyName
+					}), mock.Anything, d.now).
+					Return(nil, d.recordErr)
+			}
+
+			service := services.NewRotateSecretKeysService(secretKeysDAO, auditEventsDAO, keyGen, d.maxBackups, d.activationDelay)
+			err := service.RotateSecretKeys(context.Background(), "admin", d.now)
 
 			require.ErrorIs(t, err, d.expectErr)
 
 			secretKeysDAO.AssertExpectations(t)
+			auditEventsDAO.AssertExpectations(t)
 		})
 	}
 }
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"time"
+)
+
+// secretKeysRotationLock is held while the rotation scheduler decides whether to rotate the keys.
+const secretKeysRotationLock = "secret_keys_rotation"
+
+type ScheduleSecretKeysRotationService interface {
+	// ScheduleSecretKeysRotation rotates the secret keys if the most recent one was published more than the
+	// rotation interval ago. It returns true if a rotation happened.
+	//
+	// Because the decision is based on the stored keys, it can safely run on a timer, on any number of instances,
+	// and survive restarts. Instances take a lock before reading the keys, so once one of them rotated, the others
+	// see the new key and skip.
+	ScheduleSecretKeysRotation(ctx context.Context, now time.Time) (bool, error)
+}
+
+func NewScheduleSecretKeysRotationService(
+	secretKeysDAO dao.SecretKeysRepository,
+	locksDAO dao.LocksRepository,
+	rotateSecretKeysService RotateSecretKeysService,
+	rotationInterval time.Duration,
+) ScheduleSecretKeysRotationService {
+	return &scheduleSecretKeysRotationServiceImpl{
+		secretKeysDAO:           secretKeysDAO,
+		locksDAO:                locksDAO,
+		RotateSecretKeysService: rotateSecretKeysService,
+		rotationInterval:        rotationInterval,
+	}
+}
+
+type scheduleSecretKeysRotationServiceImpl struct {
+	secretKeysDAO dao.SecretKeysRepository
+	locksDAO      dao.LocksRepository
+	RotateSecretKeysService
+	rotationInterval time.Duration
+}
+
+// listStoredKeys reads the keys from the storage, rather than the ones kept in memory, that may predate a rotation
+// made by another instance.
+func (s *scheduleSecretKeysRotationServiceImpl) listStoredKeys(ctx context.Context) ([]*dao.SecretKeyModel, error) {
+	var keys []*dao.SecretKeyModel
+
+	err := s.secretKeysDAO.RunInTx(ctx, func(ctx context.Context, txRepository dao.SecretKeysRepository) error {
+		var err error
+		keys, err = txRepository.List(ctx)
+		return err
+	})
+
+	return keys, err
+}
+
+func (s *scheduleSecretKeysRotationServiceImpl) ScheduleSecretKeysRotation(ctx context.Context, now time.Time) (bool, error) {
+	var rotated bool
+
+	err := s.locksDAO.RunLocked(ctx, secretKeysRotationLock, func(ctx context.Context) error {
+		keys, err := s.listStoredKeys(ctx)
+		if err != nil {
+			return goerrors.Join(ErrListSignatureKeys, err)
+		}
+
+		// Keys are sorted from the most recent.
+		if len(keys) > 0 && keys[0].Date.Add(s.rotationInterval).After(now) {
+			return nil
+		}
+
+		if err := s.RotateSecretKeys(ctx, dao.AuditActorSystem, now); err != nil {
+			return goerrors.Join(ErrRotateSignatureKeys, err)
+		}
+
+		rotated = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rotated, nil
+}
@@ -0,0 +1,133 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestScheduleSecretKeysRotation(t *testing.T) {
+	data := []struct {
+		name string
+
+		rotationInterval time.Duration
+		now              time.Time
+
+		lockErr error
+
+		shouldCallList bool
+		list           []*dao.SecretKeyModel
+		listErr        error
+
+		shouldCallRotate bool
+		rotateErr        error
+
+		expect    bool
+		expectErr error
+	}{
+		{
+			name:             "Success/NotDue",
+			rotationInterval: 12 * time.Hour,
+			now:              baseTime,
+			shouldCallList:   true,
+			list: []*dao.SecretKeyModel{
+				{Name: "key-0", Key: MockedSecretKeys[0], Date: baseTime.Add(-11 * time.Hour)},
+				{Name: "key-1", Key: MockedSecretKeys[1], Date: baseTime.Add(-23 * time.Hour)},
+			},
+		},
+		{
+			name:             "Success/Due",
+			rotationInterval: 12 * time.Hour,
+			now:              baseTime,
+			shouldCallList:   true,
+			list: []*dao.SecretKeyModel{
+				{Name: "key-0", Key: MockedSecretKeys[0], Date: baseTime.Add(-12 * time.Hour)},
+				{Name: "key-1", Key: MockedSecretKeys[1], Date: baseTime.Add(-24 * time.Hour)},
+			},
+			shouldCallRotate: true,
+			expect:           true,
+		},
+		{
+			name:             "Success/NoKeys",
+			rotationInterval: 12 * time.Hour,
+			now:              baseTime,
+			shouldCallList:   true,
+			shouldCallRotate: true,
+			expect:           true,
+		},
+		{
+			name:             "Error/RotateFailure",
+			rotationInterval: 12 * time.Hour,
+			now:              baseTime,
+			shouldCallList:   true,
+			shouldCallRotate: true,
+			rotateErr:        fooErr,
+			expectErr:        fooErr,
+		},
+		{
+			name:             "Error/ListFailure",
+			rotationInterval: 12 * time.Hour,
+			now:              baseTime,
+			shouldCallList:   true,
+			listErr:          fooErr,
+			expectErr:        fooErr,
+		},
+		{
+			name:             "Error/LockFailure",
+			rotationInterval: 12 * time.Hour,
+			now:              baseTime,
+			lockErr:          fooErr,
+			expectErr:        fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			secretKeysDAO := daomocks.NewSecretKeysRepository(t)
+			locksDAO := daomocks.NewLocksRepository(t)
+			rotateSecretKeysService := servicesmocks.NewRotateSecretKeysService(t)
+
+			// Execute the actual method, but call the mocks inside of it.
+			lockCall := locksDAO.On("RunLocked", context.Background(), "secret_keys_rotation", mock.Anything)
+			lockCall.Run(func(args mock.Arguments) {
+				if d.lockErr != nil {
+					lockCall.ReturnArguments = []interface{}{d.lockErr}
+					return
+				}
+
+				fn := args.Get(2).(func(context.Context) error)
+				lockCall.ReturnArguments = []interface{}{fn(context.Background())}
+			})
+
+			if d.shouldCallList {
+				secretKeysDAO.On("List", context.Background()).Return(d.list, d.listErr)
+
+				txCall := secretKeysDAO.On("RunInTx", context.Background(), mock.Anything)
+				txCall.Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context, dao.SecretKeysRepository) error)
+					txCall.ReturnArguments = []interface{}{fn(context.Background(), secretKeysDAO)}
+				})
+			}
+
+			if d.shouldCallRotate {
+				rotateSecretKeysService.On("RotateSecretKeys", context.Background(), dao.AuditActorSystem, d.now).Return(d.rotateErr)
+			}
+
+			service := services.NewScheduleSecretKeysRotationService(secretKeysDAO, locksDAO, rotateSecretKeysService, d.rotationInterval)
+			res, err := service.ScheduleSecretKeysRotation(context.Background(), d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			secretKeysDAO.AssertExpectations(t)
+			locksDAO.AssertExpectations(t)
+			rotateSecretKeysService.AssertExpectations(t)
+		})
+	}
+}
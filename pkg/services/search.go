@@ -7,6 +7,7 @@ import (
 	"github.com/a-novel/auth-service/pkg/models"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/samber/lo"
+	"time"
 )
 
 const (
@@ -14,7 +15,8 @@ const (
 )
 
 type SearchService interface {
-	Search(ctx context.Context, query string, limit int, offset int) ([]*models.UserPreview, int, error)
+	// Search looks for users by name, username or slug. Suspended users are not returned.
+	Search(ctx context.Context, query string, limit int, offset int, now time.Time) ([]*models.UserPreview, int, error)
 }
 
 func NewSearchService(userDAO dao.UserRepository) SearchService {
@@ -27,12 +29,12 @@ type searchServiceImpl struct {
 	userDAO dao.UserRepository
 }
 
-func (s *searchServiceImpl) Search(ctx context.Context, query string, limit int, offset int) ([]*models.UserPreview, int, error) {
+func (s *searchServiceImpl) Search(ctx context.Context, query string, limit int, offset int, now time.Time) ([]*models.UserPreview, int, error) {
 	if err := goframework.CheckMinMax(limit, 1, MaxUserSearchLimit); err != nil {
 		return nil, 0, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSearchLimit, err)
 	}
 
-	users, total, err := s.userDAO.Search(ctx, query, limit, offset)
+	users, total, err := s.userDAO.Search(ctx, query, limit, offset, now)
 	if err != nil {
 		return nil, 0, goerrors.Join(ErrSearchUsers, err)
 	}
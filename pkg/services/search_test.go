@@ -120,12 +120,12 @@ func TestSearch(t *testing.T) {
 
 			if d.shouldCallUserDAO {
 				userDAO.
-					On("Search", context.Background(), d.query, d.limit, d.offset).
+					On("Search", context.Background(), d.query, d.limit, d.offset, baseTime).
 					Return(d.userDAO, d.userDAOCount, d.userDAOErr)
 			}
 
 			service := services.NewSearchService(userDAO)
-			users, total, err := service.Search(context.Background(), d.query, d.limit, d.offset)
+			users, total, err := service.Search(context.Background(), d.query, d.limit, d.offset, baseTime)
 
 			require.ErrorIs(t, err, d.expectErr)
 			require.Equal(t, d.expectCount, total)
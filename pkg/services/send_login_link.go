@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	sendgridproxy "github.com/a-novel/sendgrid-proxy"
+	"github.com/google/uuid"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+	"time"
+)
+
+type SendLoginLinkService interface {
+	// SendLoginLink emails the user a single-use link, that is exchanged for a session with
+	// ConsumeLoginLinkService. The returned function sends the email.
+	//
+	// Unknown emails do not fail, so the response does not reveal whether an account exists. In this case, the
+	// returned function is nil.
+	SendLoginLink(ctx context.Context, email string, now time.Time) (func() error, error)
+}
+
+func NewSendLoginLinkService(
+	credentialsDAO dao.CredentialsRepository,
+	identityDAO dao.IdentityRepository,
+	loginLinksDAO dao.LoginLinksRepository,
+	mailer sendgridproxy.Mailer,
+	generateLoginCode func() (string, string, error),
+	ttl time.Duration,
+	loginLink string,
+	loginLinkTemplate string,
+) SendLoginLinkService {
+	return &sendLoginLinkServiceImpl{
+		credentialsDAO:    credentialsDAO,
+		identityDAO:       identityDAO,
+		loginLinksDAO:     loginLinksDAO,
+		mailer:            mailer,
+		generateLoginCode: generateLoginCode,
+		ttl:               ttl,
+		loginLink:         loginLink,
+		loginLinkTemplate: loginLinkTemplate,
+	}
+}
+
+type sendLoginLinkServiceImpl struct {
+	credentialsDAO    dao.CredentialsRepository
+	identityDAO       dao.IdentityRepository
+	loginLinksDAO     dao.LoginLinksRepository
+	mailer            sendgridproxy.Mailer
+	generateLoginCode func() (string, string, error)
+	ttl               time.Duration
+
+	loginLink         string
+	loginLinkTemplate string
+}
+
+func (s *sendLoginLinkServiceImpl) SendLoginLink(ctx context.Context, email string, now time.Time) (func() error, error) {
+	if err := goframework.CheckMinMax(email, MinEmailLength, MaxEmailLength); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidEmail, err)
+	}
+
+	daoEmail, err := dao.ParseEmail(email)
+	if err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidEmail, err)
+	}
+
+	credentials, err := s.credentialsDAO.GetCredentialsByEmail(ctx, daoEmail)
+	if err != nil {
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return nil, nil
+		}
+
+		return nil, goerrors.Join(ErrGetCredentialsByEmail, err)
+	}
+
+	identity, err := s.identityDAO.GetIdentity(ctx, credentials.ID)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetIdentity, err)
+	}
+
+	publicCode, privateCode, err := s.generateLoginCode()
+	if err != nil {
+		return nil, goerrors.Join(ErrGenerateValidationCode, err)
+	}
+
+	link, err := s.loginLinksDAO.Create(ctx, &dao.LoginLinkModelCore{
+		UserID:     credentials.ID,
+		CodeHashed: privateCode,
+		ExpiresAt:  now.Add(s.ttl),
+	}, uuid.New(), now)
+	if err != nil {
+		return nil, goerrors.Join(ErrCreateLoginLink, err)
+	}
+
+	deferred := func() error {
+		to := mail.NewEmail(identity.FirstName, credentials.Email.String())
+		templateData := map[string]interface{}{
+			"name":       identity.FirstName,
+			"login_link": fmt.Sprintf("%s?id=%s&code=%s", s.loginLink, link.ID, publicCode),
+		}
+
+		if err := s.mailer.Send(ctx, to, s.loginLinkTemplate, templateData); err != nil {
+			return goerrors.Join(ErrSendLoginLinkEmail, err)
+		}
+
+		return nil
+	}
+
+	return deferred, nil
+}
@@ -0,0 +1,199 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	sendgridproxy "github.com/a-novel/sendgrid-proxy"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSendLoginLink(t *testing.T) {
+	credentials := &dao.CredentialsModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		CredentialsModelCore: dao.CredentialsModelCore{
+			Email: dao.Email{User: "user", Domain: "domain.com"},
+		},
+	}
+
+	identity := &dao.IdentityModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		IdentityModelCore: dao.IdentityModelCore{
+			FirstName: "name",
+		},
+	}
+
+	data := []struct {
+		name string
+
+		email string
+		now   time.Time
+
+		shouldCallGetCredentials bool
+		getCredentials           *dao.CredentialsModel
+		getCredentialsErr        error
+
+		shouldCallGetIdentity bool
+		getIdentityErr        error
+
+		generateLoginCodeErr error
+
+		shouldCallCreate bool
+		createErr        error
+
+		shouldCallMailer bool
+		mailerErr        error
+
+		expectErr         error
+		expectDeferred    bool
+		expectDeferredErr error
+	}{
+		{
+			name:                     "Success",
+			email:                    "user@domain.com",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallGetIdentity:    true,
+			shouldCallCreate:         true,
+			shouldCallMailer:         true,
+			expectDeferred:           true,
+		},
+		{
+			name:                     "Success/UnknownEmail",
+			email:                    "user@domain.com",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentialsErr:        bunovel.ErrNotFound,
+		},
+		{
+			name:                     "Error/MailerFailure",
+			email:                    "user@domain.com",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallGetIdentity:    true,
+			shouldCallCreate:         true,
+			shouldCallMailer:         true,
+			mailerErr:                fooErr,
+			expectDeferred:           true,
+			expectDeferredErr:        fooErr,
+		},
+		{
+			name:                     "Error/CreateFailure",
+			email:                    "user@domain.com",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallGetIdentity:    true,
+			shouldCallCreate:         true,
+			createErr:                fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/GenerateLoginCodeFailure",
+			email:                    "user@domain.com",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallGetIdentity:    true,
+			generateLoginCodeErr:     fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/GetIdentityFailure",
+			email:                    "user@domain.com",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallGetIdentity:    true,
+			getIdentityErr:           fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/GetCredentialsFailure",
+			email:                    "user@domain.com",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentialsErr:        fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:      "Error/InvalidEmail",
+			email:     "user",
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			identityDAO := daomocks.NewIdentityRepository(t)
+			loginLinksDAO := daomocks.NewLoginLinksRepository(t)
+			mailerService := sendgridproxy.NewMockMailer(t)
+
+			generateLoginCode := func() (string, string, error) {
+				return publicValidationCode, privateValidationCode, d.generateLoginCodeErr
+			}
+
+			if d.shouldCallGetCredentials {
+				credentialsDAO.
+					On("GetCredentialsByEmail", context.Background(), dao.Email{User: "user", Domain: "domain.com"}).
+					Return(d.getCredentials, d.getCredentialsErr)
+			}
+
+			if d.shouldCallGetIdentity {
+				identityDAO.
+					On("GetIdentity", context.Background(), goframework.NumberUUID(1)).
+					Return(identity, d.getIdentityErr)
+			}
+
+			if d.shouldCallCreate {
+				loginLinksDAO.
+					On("Create", context.Background(), &dao.LoginLinkModelCore{
+						UserID:     goframework.NumberUUID(1),
+						CodeHashed: privateValidationCode,
+						ExpiresAt:  d.now.Add(15 * time.Minute),
+					}, mock.Anything, d.now).
+					Return(&dao.LoginLinkModel{Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), d.now, nil)}, d.createErr)
+			}
+
+			if d.shouldCallMailer {
+				mailerService.
+					On("Send", context.Background(), mail.NewEmail("name", "user@domain.com"), "login-link-template", map[string]interface{}{
+						"name":       "name",
+						"login_link": "login-link?id=02020202-0202-0202-0202-020202020202&code=" + publicValidationCode,
+					}).
+					Return(d.mailerErr)
+			}
+
+			service := services.NewSendLoginLinkService(
+				credentialsDAO, identityDAO, loginLinksDAO, mailerService, generateLoginCode, 15*time.Minute,
+				"login-link", "login-link-template",
+			)
+			deferred, err := service.SendLoginLink(context.Background(), d.email, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			if d.expectDeferred {
+				require.NotNil(t, deferred)
+				require.ErrorIs(t, deferred(), d.expectDeferredErr)
+			} else {
+				require.Nil(t, deferred)
+			}
+
+			credentialsDAO.AssertExpectations(t)
+			identityDAO.AssertExpectations(t)
+			loginLinksDAO.AssertExpectations(t)
+			mailerService.AssertExpectations(t)
+		})
+	}
+}
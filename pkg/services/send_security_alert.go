@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	sendgridproxy "github.com/a-novel/sendgrid-proxy"
+	"github.com/google/uuid"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+// SecurityAlert identifies a sensitive event on an account, that its owner is notified about.
+type SecurityAlert string
+
+const (
+	// SecurityAlertPasswordChanged is sent once the password of the user has been updated or reset.
+	SecurityAlertPasswordChanged SecurityAlert = "passwordChanged"
+	// SecurityAlertEmailChangeRequested is sent to the current address when a new email is requested. It carries a
+	// link to report the change.
+	SecurityAlertEmailChangeRequested SecurityAlert = "emailChangeRequested"
+	// SecurityAlertNewDevice is sent when a session is opened from a device the user never logged in from.
+	SecurityAlertNewDevice SecurityAlert = "newDevice"
+)
+
+// SecurityAlertTemplates maps each alert to the mailer template used to send it. Alerts without a template are not
+// sent.
+type SecurityAlertTemplates map[SecurityAlert]string
+
+type SendSecurityAlertService interface {
+	// SendSecurityAlert emails the given alert to the primary address of the user. The data is passed to the
+	// template, along with the name of the user.
+	SendSecurityAlert(ctx context.Context, userID uuid.UUID, alert SecurityAlert, data map[string]interface{}) error
+}
+
+func NewSendSecurityAlertService(
+	credentialsDAO dao.CredentialsRepository,
+	identityDAO dao.IdentityRepository,
+	mailer sendgridproxy.Mailer,
+	templates SecurityAlertTemplates,
+) SendSecurityAlertService {
+	return &sendSecurityAlertServiceImpl{
+		credentialsDAO: credentialsDAO,
+		identityDAO:    identityDAO,
+		mailer:         mailer,
+		templates:      templates,
+	}
+}
+
+type sendSecurityAlertServiceImpl struct {
+	credentialsDAO dao.CredentialsRepository
+	identityDAO    dao.IdentityRepository
+	mailer         sendgridproxy.Mailer
+	templates      SecurityAlertTemplates
+}
+
+func (s *sendSecurityAlertServiceImpl) SendSecurityAlert(ctx context.Context, userID uuid.UUID, alert SecurityAlert, data map[string]interface{}) error {
+	template := s.templates[alert]
+	if template == "" {
+		return nil
+	}
+
+	credentials, err := s.credentialsDAO.GetCredentials(ctx, userID)
+	if err != nil {
+		return goerrors.Join(ErrGetCredentials, err)
+	}
+
+	identity, err := s.identityDAO.GetIdentity(ctx, userID)
+	if err != nil {
+		return goerrors.Join(ErrGetIdentity, err)
+	}
+
+	templateData := map[string]interface{}{"name": identity.FirstName}
+	for key, value := range data {
+		templateData[key] = value
+	}
+
+	to := mail.NewEmail(identity.FirstName, credentials.Email.String())
+	if err := s.mailer.Send(ctx, to, template, templateData); err != nil {
+		return goerrors.Join(ErrSendSecurityAlert, err)
+	}
+
+	return nil
+}
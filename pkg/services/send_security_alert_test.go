@@ -0,0 +1,160 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	sendgridproxy "github.com/a-novel/sendgrid-proxy"
+	"github.com/google/uuid"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSendSecurityAlert(t *testing.T) {
+	templates := services.SecurityAlertTemplates{
+		services.SecurityAlertPasswordChanged: "password-changed-template",
+	}
+
+	data := []struct {
+		name string
+
+		userID uuid.UUID
+		alert  services.SecurityAlert
+		data   map[string]interface{}
+
+		shouldCallCredentialsDAO bool
+		credentialsDAO           *dao.CredentialsModel
+		credentialsDAOErr        error
+
+		shouldCallIdentityDAO bool
+		identityDAO           *dao.IdentityModel
+		identityDAOErr        error
+
+		shouldCallMailer          bool
+		shouldCallMailerWithEmail *mail.Email
+		shouldCallMailerWithData  map[string]interface{}
+		mailerErr                 error
+
+		expectErr error
+	}{
+		{
+			name:                     "Success",
+			userID:                   goframework.NumberUUID(1),
+			alert:                    services.SecurityAlertPasswordChanged,
+			data:                     map[string]interface{}{"foo": "bar"},
+			shouldCallCredentialsDAO: true,
+			credentialsDAO: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain.com"},
+				},
+			},
+			shouldCallIdentityDAO: true,
+			identityDAO: &dao.IdentityModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
+				IdentityModelCore: dao.IdentityModelCore{
+					FirstName: "name",
+				},
+			},
+			shouldCallMailer:          true,
+			shouldCallMailerWithEmail: mail.NewEmail("name", "user@domain.com"),
+			shouldCallMailerWithData: map[string]interface{}{
+				"name": "name",
+				"foo":  "bar",
+			},
+		},
+		{
+			name:   "Success/NoTemplate",
+			userID: goframework.NumberUUID(1),
+			alert:  services.SecurityAlertNewDevice,
+		},
+		{
+			name:                     "Error/MailerFailure",
+			userID:                   goframework.NumberUUID(1),
+			alert:                    services.SecurityAlertPasswordChanged,
+			shouldCallCredentialsDAO: true,
+			credentialsDAO: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain.com"},
+				},
+			},
+			shouldCallIdentityDAO: true,
+			identityDAO: &dao.IdentityModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
+				IdentityModelCore: dao.IdentityModelCore{
+					FirstName: "name",
+				},
+			},
+			shouldCallMailer:          true,
+			shouldCallMailerWithEmail: mail.NewEmail("name", "user@domain.com"),
+			shouldCallMailerWithData: map[string]interface{}{
+				"name": "name",
+			},
+			mailerErr: fooErr,
+			expectErr: services.ErrSendSecurityAlert,
+		},
+		{
+			name:                     "Error/IdentityDAOFailure",
+			userID:                   goframework.NumberUUID(1),
+			alert:                    services.SecurityAlertPasswordChanged,
+			shouldCallCredentialsDAO: true,
+			credentialsDAO: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain.com"},
+				},
+			},
+			shouldCallIdentityDAO: true,
+			identityDAOErr:        fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:                     "Error/CredentialsDAOFailure",
+			userID:                   goframework.NumberUUID(1),
+			alert:                    services.SecurityAlertPasswordChanged,
+			shouldCallCredentialsDAO: true,
+			credentialsDAOErr:        fooErr,
+			expectErr:                fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			identityDAO := daomocks.NewIdentityRepository(t)
+			mailerService := sendgridproxy.NewMockMailer(t)
+
+			if d.shouldCallCredentialsDAO {
+				credentialsDAO.
+					On("GetCredentials", context.Background(), d.userID).
+					Return(d.credentialsDAO, d.credentialsDAOErr)
+			}
+
+			if d.shouldCallIdentityDAO {
+				identityDAO.
+					On("GetIdentity", context.Background(), d.userID).
+					Return(d.identityDAO, d.identityDAOErr)
+			}
+
+			if d.shouldCallMailer {
+				mailerService.
+					On("Send", context.Background(), d.shouldCallMailerWithEmail, templates[d.alert], d.shouldCallMailerWithData).
+					Return(d.mailerErr)
+			}
+
+			service := services.NewSendSecurityAlertService(credentialsDAO, identityDAO, mailerService, templates)
+			err := service.SendSecurityAlert(context.Background(), d.userID, d.alert, d.data)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			credentialsDAO.AssertExpectations(t)
+			identityDAO.AssertExpectations(t)
+			mailerService.AssertExpectations(t)
+		})
+	}
+}
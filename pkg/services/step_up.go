@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	"time"
+)
+
+// SensitiveOperation identifies an operation that can be used to take over an account, and thus requires the user
+// to have authenticated recently.
+type SensitiveOperation string
+
+const (
+	// SensitiveOperationUpdateEmail is the request of a new email.
+	SensitiveOperationUpdateEmail SensitiveOperation = "updateEmail"
+	// SensitiveOperationUpdateSecondFactor covers the changes made to the second factors of the user: enabling or
+	// disabling two-factor authentication, and registering passkeys.
+	SensitiveOperationUpdateSecondFactor SensitiveOperation = "updateSecondFactor"
+	// SensitiveOperationRevokeSession is the revocation of a session from another device.
+	SensitiveOperationRevokeSession SensitiveOperation = "revokeSession"
+	// SensitiveOperationDeleteAccount is the deletion of the account. It has no threshold, so the password is always
+	// required.
+	SensitiveOperationDeleteAccount SensitiveOperation = "deleteAccount"
+)
+
+// StepUpThresholds sets, for each sensitive operation, the maximum time since the user authenticated for the
+// operation to be allowed without their password.
+type StepUpThresholds map[SensitiveOperation]time.Duration
+
+type CheckStepUpService interface {
+	// CheckStepUp allows a sensitive operation with the given token if its owner authenticated recently enough,
+	// or if they provide their current password. Operations without a threshold always require the password.
+	//
+	// Passwords are checked like a login: wrong passwords are recorded as failures of the account, and once the
+	// throttle is reached, a TooManyAttemptsError is returned without checking the password. A right password does
+	// not clear the failures, as it does not prove the second factor of the user.
+	CheckStepUp(ctx context.Context, token *models.UserToken, operation SensitiveOperation, password string, client models.ClientInfo, now time.Time) error
+}
+
+func NewCheckStepUpService(
+	credentialsDAO dao.CredentialsRepository,
+	loginFailuresDAO dao.LoginFailuresRepository,
+	auditEventsDAO dao.AuditEventsRepository,
+	passwordHasher PasswordHasher,
+	thresholds StepUpThresholds,
+	throttle LoginThrottle,
+) CheckStepUpService {
+	return &checkStepUpServiceImpl{
+		credentialsDAO:   credentialsDAO,
+		loginFailuresDAO: loginFailuresDAO,
+		auditEventsDAO:   auditEventsDAO,
+		passwordHasher:   passwordHasher,
+		thresholds:       thresholds,
+		throttle:         throttle,
+	}
+}
+
+type checkStepUpServiceImpl struct {
+	credentialsDAO   dao.CredentialsRepository
+	loginFailuresDAO dao.LoginFailuresRepository
+	auditEventsDAO   dao.AuditEventsRepository
+	passwordHasher   PasswordHasher
+	thresholds       StepUpThresholds
+	throttle         LoginThrottle
+}
+
+func (s *checkStepUpServiceImpl) CheckStepUp(ctx context.Context, token *models.UserToken, operation SensitiveOperation, password string, client models.ClientInfo, now time.Time) error {
+	if password == "" {
+		// Legacy tokens have no authentication time, so they always require the password.
+		threshold, ok := s.thresholds[operation]
+		if ok && !token.Payload.AuthTime.IsZero() && now.Sub(token.Payload.AuthTime) <= threshold {
+			return nil
+		}
+
+		return goerrors.Join(goframework.ErrInvalidCredentials, ErrStepUpRequired)
+	}
+
+	credentials, err := s.credentialsDAO.GetCredentials(ctx, token.Payload.ID)
+	if err != nil {
+		return goerrors.Join(ErrGetCredentials, err)
+	}
+
+	account := loginAccount(credentials.Email)
+	if err := checkLoginThrottle(ctx, s.loginFailuresDAO, s.throttle, account, client.IP, now); err != nil {
+		return err
+	}
+
+	ok, _, err := s.passwordHasher.Verify(password, credentials.Password.Hashed)
+	if err != nil {
+		return goerrors.Join(ErrCheckPassword, err)
+	}
+	if !ok {
+		err := recordLoginFailure(
+			ctx, s.loginFailuresDAO, s.auditEventsDAO, account, credentials.ID, loginFactorPassword, client, now,
+		)
+		if err != nil {
+			return err
+		}
+
+		return goerrors.Join(goframework.ErrInvalidCredentials, ErrWrongPassword)
+	}
+
+	return nil
+}
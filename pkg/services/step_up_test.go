@@ -0,0 +1,233 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCheckStepUp(t *testing.T) {
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
+	thresholds := services.StepUpThresholds{
+		services.SensitiveOperationUpdateEmail: 10 * time.Minute,
+	}
+
+	data := []struct {
+		name string
+
+		authTime  time.Time
+		operation services.SensitiveOperation
+		password  string
+		now       time.Time
+
+		shouldCallGetCredentials bool
+		getCredentialsErr        error
+
+		shouldCallCheckThrottle bool
+		getAccountFailures      *dao.LoginFailuresSummaryModel
+		getAccountFailuresErr   error
+
+		shouldCallRecordFailure bool
+		recordFailureErr        error
+
+		shouldCallRecordAuditEvent bool
+		recordAuditEventErr        error
+
+		expectErr error
+	}{
+		{
+			name:      "Success/RecentAuthentication",
+			authTime:  baseTime.Add(-5 * time.Minute),
+			operation: services.SensitiveOperationUpdateEmail,
+			now:       baseTime,
+		},
+		{
+			name:      "Success/AuthenticationAtThreshold",
+			authTime:  baseTime.Add(-10 * time.Minute),
+			operation: services.SensitiveOperationUpdateEmail,
+			now:       baseTime,
+		},
+		{
+			name:                     "Success/Password",
+			authTime:                 baseTime.Add(-time.Hour),
+			operation:                services.SensitiveOperationUpdateEmail,
+			password:                 password,
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			shouldCallCheckThrottle:  true,
+		},
+		{
+			name:                     "Success/PasswordWithoutThreshold",
+			authTime:                 baseTime,
+			operation:                services.SensitiveOperationRevokeSession,
+			password:                 password,
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			shouldCallCheckThrottle:  true,
+		},
+		{
+			name:      "Error/AuthenticationTooOld",
+			authTime:  baseTime.Add(-time.Hour),
+			operation: services.SensitiveOperationUpdateEmail,
+			now:       baseTime,
+			expectErr: services.ErrStepUpRequired,
+		},
+		{
+			name:      "Error/NoAuthenticationTime",
+			operation: services.SensitiveOperationUpdateEmail,
+			now:       baseTime,
+			expectErr: services.ErrStepUpRequired,
+		},
+		{
+			name:      "Error/NoThreshold",
+			authTime:  baseTime,
+			operation: services.SensitiveOperationRevokeSession,
+			now:       baseTime,
+			expectErr: services.ErrStepUpRequired,
+		},
+		{
+			name:                       "Error/WrongPassword",
+			authTime:                   baseTime,
+			operation:                  services.SensitiveOperationUpdateEmail,
+			password:                   "fake-password",
+			now:                        baseTime,
+			shouldCallGetCredentials:   true,
+			shouldCallCheckThrottle:    true,
+			shouldCallRecordFailure:    true,
+			shouldCallRecordAuditEvent: true,
+			expectErr:                  services.ErrWrongPassword,
+		},
+		{
+			name:                       "Error/RecordAuditEventFailure",
+			authTime:                   baseTime,
+			operation:                  services.SensitiveOperationUpdateEmail,
+			password:                   "fake-password",
+			now:                        baseTime,
+			shouldCallGetCredentials:   true,
+			shouldCallCheckThrottle:    true,
+			shouldCallRecordFailure:    true,
+			shouldCallRecordAuditEvent: true,
+			recordAuditEventErr:        fooErr,
+			expectErr:                  fooErr,
+		},
+		{
+			name:                     "Error/RecordFailureFailure",
+			authTime:                 baseTime,
+			operation:                services.SensitiveOperationUpdateEmail,
+			password:                 "fake-password",
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			shouldCallCheckThrottle:  true,
+			shouldCallRecordFailure:  true,
+			recordFailureErr:         fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/TooManyAttempts",
+			authTime:                 baseTime,
+			operation:                services.SensitiveOperationUpdateEmail,
+			password:                 password,
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			shouldCallCheckThrottle:  true,
+			getAccountFailures:       &dao.LoginFailuresSummaryModel{Count: 3, FirstAt: baseTime, LastAt: baseTime},
+			expectErr:                services.ErrTooManyAttempts,
+		},
+		{
+			name:                     "Error/GetLoginFailuresFailure",
+			authTime:                 baseTime,
+			operation:                services.SensitiveOperationUpdateEmail,
+			password:                 password,
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			shouldCallCheckThrottle:  true,
+			getAccountFailuresErr:    fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/GetCredentialsFailure",
+			authTime:                 baseTime,
+			operation:                services.SensitiveOperationUpdateEmail,
+			password:                 password,
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentialsErr:        fooErr,
+			expectErr:                fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			loginFailuresDAO := daomocks.NewLoginFailuresRepository(t)
+			auditEventsDAO := daomocks.NewAuditEventsRepository(t)
+
+			token := &models.UserToken{
+				Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1), AuthTime: d.authTime},
+			}
+
+			if d.shouldCallGetCredentials {
+				credentialsDAO.
+					On("GetCredentials", context.Background(), goframework.NumberUUID(1)).
+					Return(&dao.CredentialsModel{
+						Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+						CredentialsModelCore: dao.CredentialsModelCore{
+							Email:    dao.Email{User: "User", Domain: "domain.com"},
+							Password: dao.Password{Hashed: passwordEncrypted},
+						},
+					}, d.getCredentialsErr)
+			}
+
+			if d.shouldCallCheckThrottle {
+				loginFailuresDAO.
+					On("GetIPFailures", context.Background(), client.IP, d.now.Add(-loginThrottle.IPWindow)).
+					Return(&dao.LoginFailuresSummaryModel{}, nil)
+				loginFailuresDAO.
+					On("GetAccountFailures", context.Background(), "user@domain.com", d.now.Add(-loginThrottle.AccountWindow)).
+					Return(lo.Ternary(d.getAccountFailures != nil, d.getAccountFailures, &dao.LoginFailuresSummaryModel{}), d.getAccountFailuresErr)
+			}
+
+			if d.shouldCallRecordFailure {
+				loginFailuresDAO.
+					On("Record", context.Background(), &dao.LoginFailureModelCore{
+						Account: "user@domain.com",
+						IP:      client.IP,
+					}, mock.Anything, d.now).
+					Return(nil, d.recordFailureErr)
+			}
+
+			if d.shouldCallRecordAuditEvent {
+				auditEventsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:      dao.AuditEventLoginFailed,
+						UserID:    goframework.NumberUUID(1),
+						IP:        client.IP,
+						UserAgent: client.UserAgent,
+						Details:   map[string]string{"email": "user@domain.com", "factor": "password"},
+					}, mock.Anything, d.now).
+					Return(nil, d.recordAuditEventErr)
+			}
+
+			service := services.NewCheckStepUpService(
+				credentialsDAO, loginFailuresDAO, auditEventsDAO, passwordHasher, thresholds, loginThrottle,
+			)
+			err := service.CheckStepUp(context.Background(), token, d.operation, d.password, client, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			credentialsDAO.AssertExpectations(t)
+			loginFailuresDAO.AssertExpectations(t)
+			auditEventsDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"time"
+)
+
+type SuspendUserService interface {
+	// SuspendUser prevents a user from logging in, rejects their tokens and hides them from searches. The actor is the
+	// identity of the internal caller, recorded with the suspension.
+	SuspendUser(ctx context.Context, form models.SuspendUserForm, actor string, now time.Time) (*models.Suspension, error)
+}
+
+func NewSuspendUserService(credentialsDAO dao.CredentialsRepository) SuspendUserService {
+	return &suspendUserServiceImpl{
+		credentialsDAO: credentialsDAO,
+	}
+}
+
+type suspendUserServiceImpl struct {
+	credentialsDAO dao.CredentialsRepository
+}
+
+func (s *suspendUserServiceImpl) SuspendUser(
+	ctx context.Context, form models.SuspendUserForm, actor string, now time.Time,
+) (*models.Suspension, error) {
+	if form.UserID == uuid.Nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidUserID)
+	}
+	if err := goframework.CheckMinMax(form.Reason, 1, MaxSuspensionReasonLength); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSuspensionReason, err)
+	}
+	if form.Until != nil && !form.Until.After(now) {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSuspensionEnd)
+	}
+
+	credentials, err := s.credentialsDAO.Suspend(ctx, form.Reason, actor, form.Until, form.UserID, now)
+	if err != nil {
+		return nil, goerrors.Join(ErrSuspendUser, err)
+	}
+
+	return suspensionFromCredentials(credentials), nil
+}
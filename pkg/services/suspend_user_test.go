@@ -0,0 +1,172 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuspendUser(t *testing.T) {
+	data := []struct {
+		name string
+
+		form  models.SuspendUserForm
+		actor string
+		now   time.Time
+
+		shouldCallSuspend bool
+		suspendData       *dao.CredentialsModel
+		suspendErr        error
+
+		expect    *models.Suspension
+		expectErr error
+	}{
+		{
+			name: "Success",
+			form: models.SuspendUserForm{
+				UserID: goframework.NumberUUID(1),
+				Reason: "spam",
+				Until:  lo.ToPtr(baseTime.Add(24 * time.Hour)),
+			},
+			actor:             "moderation",
+			now:               baseTime,
+			shouldCallSuspend: true,
+			suspendData: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:            dao.Email{User: "user", Domain: "domain.com"},
+					SuspendedAt:      &baseTime,
+					SuspendedUntil:   lo.ToPtr(baseTime.Add(24 * time.Hour)),
+					SuspensionReason: "spam",
+					SuspendedBy:      "moderation",
+				},
+			},
+			expect: &models.Suspension{
+				UserID:         goframework.NumberUUID(1),
+				Email:          "user@domain.com",
+				Reason:         "spam",
+				SuspendedBy:    "moderation",
+				SuspendedAt:    baseTime,
+				SuspendedUntil: lo.ToPtr(baseTime.Add(24 * time.Hour)),
+			},
+		},
+		{
+			name: "Success/NoExpiration",
+			form: models.SuspendUserForm{
+				UserID: goframework.NumberUUID(1),
+				Reason: "spam",
+			},
+			actor:             "moderation",
+			now:               baseTime,
+			shouldCallSuspend: true,
+			suspendData: &dao.CredentialsModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &baseTime),
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:            dao.Email{User: "user", Domain: "domain.com"},
+					SuspendedAt:      &baseTime,
+					SuspensionReason: "spam",
+					SuspendedBy:      "moderation",
+				},
+			},
+			expect: &models.Suspension{
+				UserID:      goframework.NumberUUID(1),
+				Email:       "user@domain.com",
+				Reason:      "spam",
+				SuspendedBy: "moderation",
+				SuspendedAt: baseTime,
+			},
+		},
+		{
+			name: "Error/NotFound",
+			form: models.SuspendUserForm{
+				UserID: goframework.NumberUUID(1),
+				Reason: "spam",
+			},
+			actor:             "moderation",
+			now:               baseTime,
+			shouldCallSuspend: true,
+			suspendErr:        bunovel.ErrNotFound,
+			expectErr:         bunovel.ErrNotFound,
+		},
+		{
+			name: "Error/DAOFailure",
+			form: models.SuspendUserForm{
+				UserID: goframework.NumberUUID(1),
+				Reason: "spam",
+			},
+			actor:             "moderation",
+			now:               baseTime,
+			shouldCallSuspend: true,
+			suspendErr:        fooErr,
+			expectErr:         fooErr,
+		},
+		{
+			name: "Error/UntilInThePast",
+			form: models.SuspendUserForm{
+				UserID: goframework.NumberUUID(1),
+				Reason: "spam",
+				Until:  lo.ToPtr(baseTime.Add(-time.Hour)),
+			},
+			actor:     "moderation",
+			now:       baseTime,
+			expectErr: services.ErrInvalidSuspensionEnd,
+		},
+		{
+			name: "Error/NoReason",
+			form: models.SuspendUserForm{
+				UserID: goframework.NumberUUID(1),
+			},
+			actor:     "moderation",
+			now:       baseTime,
+			expectErr: services.ErrInvalidSuspensionReason,
+		},
+		{
+			name: "Error/ReasonTooLong",
+			form: models.SuspendUserForm{
+				UserID: goframework.NumberUUID(1),
+				Reason: strings.Repeat("a", services.MaxSuspensionReasonLength+1),
+			},
+			actor:     "moderation",
+			now:       baseTime,
+			expectErr: services.ErrInvalidSuspensionReason,
+		},
+		{
+			name: "Error/NoUserID",
+			form: models.SuspendUserForm{
+				Reason: "spam",
+			},
+			actor:     "moderation",
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+
+			if d.shouldCallSuspend {
+				credentialsDAO.
+					On("Suspend", context.Background(), d.form.Reason, d.actor, d.form.Until, d.form.UserID, d.now).
+					Return(d.suspendData, d.suspendErr)
+			}
+
+			service := services.NewSuspendUserService(credentialsDAO)
+			res, err := service.SuspendUser(context.Background(), d.form, d.actor, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			credentialsDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"github.com/a-novel/auth-service/pkg/dao"
 	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/bunovel"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/google/uuid"
 	"github.com/samber/lo"
@@ -16,50 +17,206 @@ import (
 	"time"
 )
 
+const (
+	// TokenType is the "typ" value of the JOSE header, for every token issued by the service.
+	TokenType = "JWT"
+)
+
+// jwtHeader is the JOSE header of a token, as described in RFC 7515.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// jwtClaims is the claims set of a token, as described in RFC 7519. Time values are expressed in seconds since epoch.
+type jwtClaims struct {
+	IAT int64  `json:"iat"`
+	EXP int64  `json:"exp"`
+	NBF int64  `json:"nbf"`
+	JTI string `json:"jti"`
+	SUB string `json:"sub"`
+	ISS string `json:"iss"`
+	AUD string `json:"aud"`
+	// FID is the refresh token family of the token. This claim is private to the service.
+	FID string `json:"fid,omitempty"`
+	// SST is the security stamp of the user when the token was issued. This claim is private to the service.
+	SST string `json:"sst,omitempty"`
+	// AuthTime is the date the user last authenticated for the session of the token, as described in OpenID Connect.
+	AuthTime int64 `json:"auth_time,omitempty"`
+}
+
+func newJWTClaims(source *models.UserToken) jwtClaims {
+	claims := jwtClaims{
+		IAT: source.Header.IAT.Unix(),
+		EXP: source.Header.EXP.Unix(),
+		// The token is valid as soon as it is issued.
+		NBF: source.Header.IAT.Unix(),
+		JTI: source.Header.ID.String(),
+		SUB: source.Payload.ID.String(),
+		ISS: source.Header.Issuer,
+		AUD: source.Header.Audience,
+	}
+
+	if source.Payload.FamilyID != uuid.Nil {
+		claims.FID = source.Payload.FamilyID.String()
+	}
+	if source.Payload.SecurityStamp != uuid.Nil {
+		claims.SST = source.Payload.SecurityStamp.String()
+	}
+	if !source.Payload.AuthTime.IsZero() {
+		claims.AuthTime = source.Payload.AuthTime.Unix()
+	}
+
+	return claims
+}
+
+// toUserToken converts the claims back to a token. It fails if the identifiers are not valid UUIDs.
+func (claims jwtClaims) toUserToken(kid string) (*models.UserToken, error) {
+	id, err := uuid.Parse(claims.JTI)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(claims.SUB)
+	if err != nil {
+		return nil, err
+	}
+
+	var familyID uuid.UUID
+	if claims.FID != "" {
+		if familyID, err = uuid.Parse(claims.FID); err != nil {
+			return nil, err
+		}
+	}
+
+	var securityStamp uuid.UUID
+	if claims.SST != "" {
+		if securityStamp, err = uuid.Parse(claims.SST); err != nil {
+			return nil, err
+		}
+	}
+
+	var authTime time.Time
+	if claims.AuthTime != 0 {
+		authTime = time.Unix(claims.AuthTime, 0).UTC()
+	}
+
+	return &models.UserToken{
+		Header: models.UserTokenHeader{
+			IAT:      time.Unix(claims.IAT, 0).UTC(),
+			EXP:      time.Unix(claims.EXP, 0).UTC(),
+			ID:       id,
+			Issuer:   claims.ISS,
+			Audience: claims.AUD,
+			KeyID:    kid,
+		},
+		Payload: models.UserTokenPayload{ID: userID, FamilyID: familyID, SecurityStamp: securityStamp, AuthTime: authTime},
+	}, nil
+}
+
 type GenerateTokenService interface {
 	GenerateToken(ctx context.Context, data models.UserTokenPayload, id uuid.UUID, now time.Time) (*models.UserTokenStatus, error)
 }
 
-func NewGenerateTokenService(secretKeysDAO dao.SecretKeysRepository, tokenTTL time.Duration) GenerateTokenService {
+// NewGenerateTokenService creates a service to issue tokens. Tokens issued for a refresh token family are recorded on
+// the matching session, so the sessions that depend on a given signature key can be found.
+func NewGenerateTokenService(
+	secretKeysDAO dao.SecretKeysRepository,
+	sessionsDAO dao.SessionsRepository,
+	tokenTTL time.Duration,
+	issuer string,
+	audience string,
+) GenerateTokenService {
 	return &generateTokenServiceImpl{
 		secretKeysDAO: secretKeysDAO,
+		sessionsDAO:   sessionsDAO,
 		tokenTTL:      tokenTTL,
+		issuer:        issuer,
+		audience:      audience,
 	}
 }
 
 type generateTokenServiceImpl struct {
 	secretKeysDAO dao.SecretKeysRepository
+	sessionsDAO   dao.SessionsRepository
 	tokenTTL      time.Duration
+	issuer        string
+	audience      string
 }
 
-func (s *generateTokenServiceImpl) GenerateToken(ctx context.Context, data models.UserTokenPayload, id uuid.UUID, now time.Time) (*models.UserTokenStatus, error) {
+// findActiveKey returns the key used to sign new tokens, or nil if no key is active yet.
+func (s *generateTokenServiceImpl) findActiveKey(ctx context.Context, now time.Time) (*dao.SecretKeyModel, error) {
 	signatureKeys, err := s.secretKeysDAO.List(ctx)
 	if err != nil {
 		return nil, goerrors.Join(ErrListSignatureKeys, err)
 	}
-	if len(signatureKeys) == 0 {
+
+	// Keys are sorted from the most recent, so this picks the latest key that every instance had the time to load.
+	signatureKey, _ := lo.Find(signatureKeys, func(item *dao.SecretKeyModel) bool {
+		return item.IsActive(now)
+	})
+
+	return signatureKey, nil
+}
+
+func (s *generateTokenServiceImpl) GenerateToken(ctx context.Context, data models.UserTokenPayload, id uuid.UUID, now time.Time) (*models.UserTokenStatus, error) {
+	signatureKey, err := s.findActiveKey(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if signatureKey == nil {
+		// The keys in memory may predate a rotation made by another instance (for example, after the active key
+		// was revoked). Reloads are rate limited, so this cannot hammer the storage.
+		if err = s.secretKeysDAO.Refresh(ctx); err != nil {
+			return nil, goerrors.Join(ErrListSignatureKeys, err)
+		}
+
+		if signatureKey, err = s.findActiveKey(ctx, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if signatureKey == nil {
 		return nil, ErrMissingSignatureKeys
 	}
+	// JWT time claims have a precision of one second, so we truncate the dates to get a consistent token value.
+	now = now.Truncate(time.Second)
 
 	source := models.UserToken{
-		Header:  models.UserTokenHeader{IAT: now, EXP: now.Add(s.tokenTTL), ID: id},
+		Header: models.UserTokenHeader{
+			IAT:      now,
+			EXP:      now.Add(s.tokenTTL),
+			ID:       id,
+			Issuer:   s.issuer,
+			Audience: s.audience,
+			KeyID:    signatureKey.KeyID(),
+		},
 		Payload: data,
 	}
 
-	mrshHeader, err := json.Marshal(source.Header)
+	mrshHeader, err := json.Marshal(jwtHeader{Alg: JWKAlgorithmEdDSA, Typ: TokenType, Kid: source.Header.KeyID})
 	if err != nil {
 		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidTokenHeader, err)
 	}
 	header := base64.RawURLEncoding.EncodeToString(mrshHeader)
 
-	mrshPayload, err := json.Marshal(source.Payload)
+	mrshPayload, err := json.Marshal(newJWTClaims(&source))
 	if err != nil {
 		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidTokenPayload, err)
 	}
 	payload := base64.RawURLEncoding.EncodeToString(mrshPayload)
 
 	unsigned := fmt.Sprintf("%s.%s", header, payload)
-	signature := base64.RawURLEncoding.EncodeToString(ed25519.Sign(signatureKeys[0].Key, []byte(unsigned)))
+	signature := base64.RawURLEncoding.EncodeToString(ed25519.Sign(signatureKey.Key, []byte(unsigned)))
+
+	if data.FamilyID != uuid.Nil {
+		err = s.sessionsDAO.UpdateToken(ctx, data.FamilyID, source.Header.KeyID, source.Header.EXP, now)
+		if err != nil {
+			return nil, goerrors.Join(ErrUpdateSessionToken, err)
+		}
+	}
 
 	return &models.UserTokenStatus{
 		OK:       true,
@@ -72,14 +229,39 @@ type GetTokenStatusService interface {
 	GetTokenStatus(ctx context.Context, token string, now time.Time) (*models.UserTokenStatus, error)
 }
 
-func NewGetTokenStatusService(secretKeysDAO dao.SecretKeysRepository) GetTokenStatusService {
+// NewGetTokenStatusService creates a service to verify tokens. Only tokens from the given issuer and for the given
+// audience are accepted.
+//
+// The acceptLegacy flag enables a transition mode, where tokens issued in the legacy format (without a JOSE header)
+// are still accepted until they expire.
+//
+// Tokens are also revoked once the security stamp of their owner no longer matches the one they were issued with, and
+// rejected with a distinct status while their owner is suspended.
+func NewGetTokenStatusService(
+	secretKeysDAO dao.SecretKeysRepository,
+	revokedTokensDAO dao.RevokedTokensRepository,
+	credentialsDAO dao.CredentialsRepository,
+	issuer string,
+	audience string,
+	acceptLegacy bool,
+) GetTokenStatusService {
 	return &getTokenStatusServiceImpl{
-		secretKeysDAO: secretKeysDAO,
+		secretKeysDAO:    secretKeysDAO,
+		revokedTokensDAO: revokedTokensDAO,
+		credentialsDAO:   credentialsDAO,
+		issuer:           issuer,
+		audience:         audience,
+		acceptLegacy:     acceptLegacy,
 	}
 }
 
 type getTokenStatusServiceImpl struct {
-	secretKeysDAO dao.SecretKeysRepository
+	secretKeysDAO    dao.SecretKeysRepository
+	revokedTokensDAO dao.RevokedTokensRepository
+	credentialsDAO   dao.CredentialsRepository
+	issuer           string
+	audience         string
+	acceptLegacy     bool
 }
 
 func (s *getTokenStatusServiceImpl) splitToken(token string) (string, string, string, error) {
@@ -114,7 +296,48 @@ func (s *getTokenStatusServiceImpl) decodeToken(header, payload, signature strin
 	return decodedHeader, decodedPayload, decodedSignature, nil
 }
 
-func (s *getTokenStatusServiceImpl) validateToken(ctx context.Context, header, payload string, decodedSignature []byte) error {
+// validateToken looks for the key referenced by the kid header, and uses it to verify the token signature.
+func (s *getTokenStatusServiceImpl) validateToken(ctx context.Context, kid, header, payload string, decodedSignature []byte) error {
+	keys, err := s.secretKeysDAO.List(ctx)
+	if err != nil {
+		return goerrors.Join(ErrListSignatureKeys, err)
+	}
+
+	findKey := func(item *dao.SecretKeyModel) bool {
+		return item.KeyID() == kid
+	}
+
+	signatureKey, ok := lo.Find(keys, findKey)
+	if !ok {
+		// The key may have been issued after the keys were last loaded.
+		if err := s.secretKeysDAO.Refresh(ctx); err != nil {
+			return goerrors.Join(ErrRefreshSignatureKeys, err)
+		}
+
+		if keys, err = s.secretKeysDAO.List(ctx); err != nil {
+			return goerrors.Join(ErrListSignatureKeys, err)
+		}
+
+		if signatureKey, ok = lo.Find(keys, findKey); !ok {
+			return goerrors.Join(goframework.ErrInvalidCredentials, ErrUnknownSignatureKey)
+		}
+	}
+
+	if !ed25519.Verify(
+		// We know for sure the public type is correct, because we read it from the private key.
+		signatureKey.Key.Public().(ed25519.PublicKey),
+		[]byte(fmt.Sprintf("%s.%s", header, payload)),
+		decodedSignature,
+	) {
+		return goerrors.Join(goframework.ErrInvalidCredentials, ErrNoSignatureMatch)
+	}
+
+	return nil
+}
+
+// validateLegacyToken verifies the signature of a token issued in the legacy format. Those tokens do not reference
+// the key used to sign them, so every key has to be tried.
+func (s *getTokenStatusServiceImpl) validateLegacyToken(ctx context.Context, header, payload string, decodedSignature []byte) error {
 	keys, err := s.secretKeysDAO.List(ctx)
 	if err != nil {
 		return goerrors.Join(ErrListSignatureKeys, err)
@@ -136,6 +359,34 @@ func (s *getTokenStatusServiceImpl) validateToken(ctx context.Context, header, p
 	return nil
 }
 
+func (s *getTokenStatusServiceImpl) getLegacyTokenStatus(
+	ctx context.Context, status *models.UserTokenStatus, header, payload string,
+	decodedHeader, decodedPayload, decodedSignature []byte,
+) (*models.UserTokenStatus, error) {
+	if err := s.validateLegacyToken(ctx, header, payload, decodedSignature); err != nil {
+		if goerrors.Is(err, goframework.ErrInvalidCredentials) {
+			status.Expired = true
+		} else {
+			return nil, goerrors.Join(ErrValidateToken, err)
+		}
+	}
+
+	parsedToken := new(models.UserToken)
+
+	if err := json.Unmarshal(decodedHeader, &parsedToken.Header); err != nil {
+		status.Malformed = true
+		return status, nil
+	}
+	if err := json.Unmarshal(decodedPayload, &parsedToken.Payload); err != nil {
+		status.Malformed = true
+		return status, nil
+	}
+
+	status.Token = parsedToken
+
+	return status, nil
+}
+
 func (s *getTokenStatusServiceImpl) GetTokenStatus(ctx context.Context, token string, now time.Time) (*models.UserTokenStatus, error) {
 	status := &models.UserTokenStatus{TokenRaw: token}
 
@@ -165,43 +416,103 @@ func (s *getTokenStatusServiceImpl) GetTokenStatus(ctx context.Context, token st
 		return nil, err
 	}
 
-	if err := s.validateToken(ctx, header, payload, decodedSignature); err != nil {
-		if goerrors.Is(err, goframework.ErrInvalidCredentials) {
-			status.Expired = true
-		} else {
-			return nil, goerrors.Join(ErrValidateToken, err)
-		}
-	}
-
-	parsedToken := new(models.UserToken)
-
-	if err := json.Unmarshal(decodedHeader, &parsedToken.Header); err != nil {
-		status.Malformed = true
-		return status, nil
-	}
-	if err := json.Unmarshal(decodedPayload, &parsedToken.Payload); err != nil {
+	parsedHeader := new(jwtHeader)
+	if err := json.Unmarshal(decodedHeader, parsedHeader); err != nil {
 		status.Malformed = true
 		return status, nil
 	}
 
-	status.Token = parsedToken
+	// Legacy tokens have no JOSE header.
+	if parsedHeader.Alg == "" && s.acceptLegacy {
+		status, err = s.getLegacyTokenStatus(ctx, status, header, payload, decodedHeader, decodedPayload, decodedSignature)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Never trust the algorithm from the header: only accept the one used by the service.
+		if parsedHeader.Alg != JWKAlgorithmEdDSA || parsedHeader.Kid == "" {
+			status.Malformed = true
+			return status, nil
+		}
+
+		if err := s.validateToken(ctx, parsedHeader.Kid, header, payload, decodedSignature); err != nil {
+			if goerrors.Is(err, goframework.ErrInvalidCredentials) {
+				status.Expired = true
+			} else {
+				return nil, goerrors.Join(ErrValidateToken, err)
+			}
+		}
+
+		claims := new(jwtClaims)
+		if err := json.Unmarshal(decodedPayload, claims); err != nil {
+			status.Malformed = true
+			return status, nil
+		}
 
-	if !status.Expired {
-		if parsedToken.Header.ID == uuid.Nil {
+		status.Token, err = claims.toUserToken(parsedHeader.Kid)
+		if err != nil {
 			status.Malformed = true
 			return status, nil
 		}
-		if parsedToken.Header.IAT.After(now) {
+
+		if claims.ISS != s.issuer || claims.AUD != s.audience {
+			status.Malformed = true
+			return status, nil
+		}
+
+		if !status.Expired && time.Unix(claims.NBF, 0).After(now) {
 			status.NotIssued = true
 			return status, nil
 		}
-		if parsedToken.Header.EXP.Before(now) {
-			status.Expired = true
+	}
+
+	if status.Malformed || status.Expired {
+		return status, nil
+	}
+
+	if status.Token.Header.ID == uuid.Nil {
+		status.Malformed = true
+		return status, nil
+	}
+	if status.Token.Header.IAT.After(now) {
+		status.NotIssued = true
+		return status, nil
+	}
+	if status.Token.Header.EXP.Before(now) {
+		status.Expired = true
+		return status, nil
+	}
+
+	// Only look for a revocation once the token is known to be valid otherwise, as it requires a database call.
+	revoked, err := s.revokedTokensDAO.IsRevoked(ctx, status.Token.Header.ID, status.Token.Payload.ID, status.Token.Header.IAT)
+	if err != nil {
+		return nil, goerrors.Join(ErrCheckTokenRevocation, err)
+	}
+	if revoked {
+		status.Revoked = true
+		return status, nil
+	}
+
+	credentials, err := s.credentialsDAO.GetCredentials(ctx, status.Token.Payload.ID)
+	if err != nil {
+		// Tokens of a user that no longer exists cannot be used.
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			status.Revoked = true
 			return status, nil
 		}
 
-		status.OK = true
+		return nil, goerrors.Join(ErrGetCredentials, err)
 	}
+	if credentials.SecurityStamp != status.Token.Payload.SecurityStamp {
+		status.Revoked = true
+		return status, nil
+	}
+	if credentials.Suspended(now) {
+		status.Suspended = true
+		return status, nil
+	}
+
+	status.OK = true
 
 	return status, nil
 }
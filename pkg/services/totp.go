@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	goerrors "errors"
+	"fmt"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"golang.org/x/crypto/bcrypt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// TOTPDigits is the length of the codes generated by authenticators.
+	TOTPDigits = 6
+	// TOTPPeriod is the lifetime of a code.
+	TOTPPeriod = 30 * time.Second
+	// TOTPSkew is the number of periods accepted before and after the current one, to account for clock drift
+	// between the server and the device of the user.
+	TOTPSkew = 1
+	// TOTPSecretSize is the length of the secret, in bytes, as recommended by RFC 4226 for HMAC-SHA1.
+	TOTPSecretSize = 20
+	// RecoveryCodesCount is the number of recovery codes issued on enrollment.
+	RecoveryCodesCount = 10
+	// RecoveryCodeLength is the number of characters in a recovery code, separators excluded.
+	RecoveryCodeLength = 10
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret returns a new random secret, encoded in base32 as expected by authenticators.
+func GenerateTOTPSecret() (string, error) {
+	secret := make([]byte, TOTPSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// GenerateRecoveryCode returns a new recovery code, in a readable form, and its hashed value. Codes are verified
+// with goframework.VerifyCode, once normalized with normalizeSecondFactorCode.
+func GenerateRecoveryCode() (string, string, error) {
+	random := make([]byte, RecoveryCodeLength)
+	if _, err := rand.Read(random); err != nil {
+		return "", "", err
+	}
+
+	code := strings.ToLower(totpEncoding.EncodeToString(random))[:RecoveryCodeLength]
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.MinCost)
+	if err != nil {
+		return "", "", err
+	}
+
+	half := RecoveryCodeLength / 2
+	return code[:half] + "-" + code[half:], string(hashed), nil
+}
+
+// TOTPStep returns the time step of the given date.
+func TOTPStep(now time.Time) int64 {
+	return now.Unix() / int64(TOTPPeriod/time.Second)
+}
+
+// TOTPCode computes the code of a secret for the given time step, as described in RFC 6238.
+func TOTPCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation, from RFC 4226.
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < TOTPDigits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", TOTPDigits, value%modulo), nil
+}
+
+// TOTPURI returns the URI to register a secret in an authenticator, usually shown as a QR code.
+func TOTPURI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprintf("%d", TOTPDigits))
+	query.Set("period", fmt.Sprintf("%d", int(TOTPPeriod/time.Second)))
+
+	return (&url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}).String()
+}
+
+// matchTOTP looks for the time step, around the given date, that generates the code. It returns false if no step
+// matches.
+func matchTOTP(secret, code string, now time.Time) (int64, bool, error) {
+	current := TOTPStep(now)
+
+	for step := current - TOTPSkew; step <= current+TOTPSkew; step++ {
+		expected, err := TOTPCode(secret, step)
+		if err != nil {
+			return 0, false, err
+		}
+
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return step, true, nil
+		}
+	}
+
+	return 0, false, nil
+}
+
+// normalizeSecondFactorCode removes the separators users may type in a code.
+func normalizeSecondFactorCode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(code))
+}
+
+// isTOTPCode returns true if the code looks like a TOTP code, rather than a recovery code.
+func isTOTPCode(code string) bool {
+	if len(code) != TOTPDigits {
+		return false
+	}
+
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkSecondFactor verifies a TOTP or recovery code against the confirmed secret of a user. Accepted codes are
+// consumed, so they cannot be used again. It returns an error that matches goframework.ErrInvalidCredentials if the
+// code is wrong.
+func checkSecondFactor(ctx context.Context, totpDAO dao.TOTPRepository, totp *dao.TOTPModel, code string, now time.Time) error {
+	code = normalizeSecondFactorCode(code)
+	if code == "" {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSecondFactorCode)
+	}
+
+	if isTOTPCode(code) {
+		step, ok, err := matchTOTP(totp.Secret, code, now)
+		if err != nil {
+			return goerrors.Join(ErrCheckSecondFactor, err)
+		}
+		if !ok {
+			return goerrors.Join(goframework.ErrInvalidCredentials, ErrWrongSecondFactorCode)
+		}
+
+		if err := totpDAO.UseStep(ctx, totp.ID, step, now); err != nil {
+			// A code from this step, or a later one, was already accepted.
+			if goerrors.Is(err, bunovel.ErrNotFound) {
+				return goerrors.Join(goframework.ErrInvalidCredentials, ErrWrongSecondFactorCode)
+			}
+
+			return goerrors.Join(ErrUseSecondFactorCode, err)
+		}
+
+		return nil
+	}
+
+	recoveryCodes, err := totpDAO.ListRecoveryCodes(ctx, totp.ID)
+	if err != nil {
+		return goerrors.Join(ErrListRecoveryCodes, err)
+	}
+
+	for _, recoveryCode := range recoveryCodes {
+		ok, err := goframework.VerifyCode(code, recoveryCode.CodeHashed)
+		if err != nil {
+			return goerrors.Join(ErrCheckSecondFactor, err)
+		}
+		if !ok {
+			continue
+		}
+
+		if err := totpDAO.UseRecoveryCode(ctx, recoveryCode.ID, now); err != nil {
+			// Another request used the code in the meantime.
+			if goerrors.Is(err, bunovel.ErrNotFound) {
+				return goerrors.Join(goframework.ErrInvalidCredentials, ErrWrongSecondFactorCode)
+			}
+
+			return goerrors.Join(ErrUseSecondFactorCode, err)
+		}
+
+		return nil
+	}
+
+	return goerrors.Join(goframework.ErrInvalidCredentials, ErrWrongSecondFactorCode)
+}
@@ -0,0 +1,119 @@
+package services_test
+
+import (
+	"encoding/base32"
+	"github.com/a-novel/auth-service/pkg/services"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+// totpSecret is the secret used by the test vectors of RFC 6238, encoded in base32.
+const totpSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func mustTOTPCode(now time.Time) string {
+	code, err := services.TOTPCode(totpSecret, services.TOTPStep(now))
+	if err != nil {
+		panic(err)
+	}
+
+	return code
+}
+
+func TestTOTPCode(t *testing.T) {
+	data := []struct {
+		name string
+
+		secret string
+		now    time.Time
+
+		expect    string
+		expectErr bool
+	}{
+		{
+			name:   "Success/RFC6238/59",
+			secret: totpSecret,
+			now:    time.Unix(59, 0),
+			expect: "287082",
+		},
+		{
+			name:   "Success/RFC6238/1111111109",
+			secret: totpSecret,
+			now:    time.Unix(1111111109, 0),
+			expect: "081804",
+		},
+		{
+			name:   "Success/RFC6238/1234567890",
+			secret: totpSecret,
+			now:    time.Unix(1234567890, 0),
+			expect: "005924",
+		},
+		{
+			name:   "Success/RFC6238/2000000000",
+			secret: totpSecret,
+			now:    time.Unix(2000000000, 0),
+			expect: "279037",
+		},
+		{
+			name:   "Success/LowerCaseSecret",
+			secret: strings.ToLower(totpSecret),
+			now:    time.Unix(59, 0),
+			expect: "287082",
+		},
+		{
+			name:      "Error/InvalidSecret",
+			secret:    "not base32!",
+			now:       time.Unix(59, 0),
+			expectErr: true,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			res, err := services.TOTPCode(d.secret, services.TOTPStep(d.now))
+
+			if d.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, d.expect, res)
+		})
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := services.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	require.NoError(t, err)
+	require.Len(t, decoded, services.TOTPSecretSize)
+
+	other, err := services.GenerateTOTPSecret()
+	require.NoError(t, err)
+	require.NotEqual(t, secret, other)
+}
+
+func TestGenerateRecoveryCode(t *testing.T) {
+	code, hashed, err := services.GenerateRecoveryCode()
+	require.NoError(t, err)
+
+	require.Len(t, code, services.RecoveryCodeLength+1)
+	require.Equal(t, "-", code[services.RecoveryCodeLength/2:services.RecoveryCodeLength/2+1])
+
+	ok, err := goframework.VerifyCode(strings.ReplaceAll(code, "-", ""), hashed)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestTOTPURI(t *testing.T) {
+	require.Equal(
+		t,
+		"otpauth://totp/Agora:user@domain.com?algorithm=SHA1&digits=6&issuer=Agora&period=30&secret="+totpSecret,
+		services.TOTPURI("Agora", "user@domain.com", totpSecret),
+	)
+}
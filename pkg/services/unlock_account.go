@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	goframework "github.com/a-novel/go-framework"
+	"time"
+)
+
+type UnlockAccountService interface {
+	// UnlockAccount clears the failed login attempts on an account, so its owner can log in again right away. The
+	// failures still count toward the limit of the client IPs that made them.
+	UnlockAccount(ctx context.Context, email string, now time.Time) error
+}
+
+func NewUnlockAccountService(loginFailuresDAO dao.LoginFailuresRepository) UnlockAccountService {
+	return &unlockAccountServiceImpl{
+		loginFailuresDAO: loginFailuresDAO,
+	}
+}
+
+type unlockAccountServiceImpl struct {
+	loginFailuresDAO dao.LoginFailuresRepository
+}
+
+func (s *unlockAccountServiceImpl) UnlockAccount(ctx context.Context, email string, now time.Time) error {
+	if err := goframework.CheckMinMax(email, MinEmailLength, MaxEmailLength); err != nil {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidEmail, err)
+	}
+
+	daoEmail, err := dao.ParseEmail(email)
+	if err != nil {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidEmail, err)
+	}
+
+	if err := s.loginFailuresDAO.ClearAccount(ctx, loginAccount(daoEmail), now); err != nil {
+		return goerrors.Join(ErrClearLoginFailures, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package services_test
+
+import (
+	"context"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/services"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestUnlockAccount(t *testing.T) {
+	data := []struct {
+		name string
+
+		email string
+		now   time.Time
+
+		shouldCallDAO     bool
+		shouldCallDAOWith string
+		daoErr            error
+
+		expectErr error
+	}{
+		{
+			name:              "Success",
+			email:             "User@Domain.com",
+			now:               baseTime,
+			shouldCallDAO:     true,
+			shouldCallDAOWith: "user@domain.com",
+		},
+		{
+			name:              "Error/DAOFailure",
+			email:             "user@domain.com",
+			now:               baseTime,
+			shouldCallDAO:     true,
+			shouldCallDAOWith: "user@domain.com",
+			daoErr:            fooErr,
+			expectErr:         fooErr,
+		},
+		{
+			name:      "Error/InvalidEmail",
+			email:     "userdomain.com",
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name:      "Error/EmptyEmail",
+			email:     "",
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			loginFailuresDAO := daomocks.NewLoginFailuresRepository(t)
+
+			if d.shouldCallDAO {
+				loginFailuresDAO.On("ClearAccount", context.Background(), d.shouldCallDAOWith, d.now).Return(d.daoErr)
+			}
+
+			service := services.NewUnlockAccountService(loginFailuresDAO)
+			err := service.UnlockAccount(context.Background(), d.email, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			loginFailuresDAO.AssertExpectations(t)
+		})
+	}
+}
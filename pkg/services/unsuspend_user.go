@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"time"
+)
+
+type UnsuspendUserService interface {
+	// UnsuspendUser lifts the suspension of a user. The actor is the identity of the internal caller, recorded with
+	// the event.
+	UnsuspendUser(ctx context.Context, userID uuid.UUID, actor string, now time.Time) error
+}
+
+func NewUnsuspendUserService(credentialsDAO dao.CredentialsRepository) UnsuspendUserService {
+	return &unsuspendUserServiceImpl{
+		credentialsDAO: credentialsDAO,
+	}
+}
+
+type unsuspendUserServiceImpl struct {
+	credentialsDAO dao.CredentialsRepository
+}
+
+func (s *unsuspendUserServiceImpl) UnsuspendUser(ctx context.Context, userID uuid.UUID, actor string, now time.Time) error {
+	if userID == uuid.Nil {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidUserID)
+	}
+
+	if _, err := s.credentialsDAO.Unsuspend(ctx, actor, userID, now); err != nil {
+		return goerrors.Join(ErrUnsuspendUser, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package services_test
+
+import (
+	"context"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/services"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestUnsuspendUser(t *testing.T) {
+	data := []struct {
+		name string
+
+		userID uuid.UUID
+		actor  string
+		now    time.Time
+
+		shouldCallUnsuspend bool
+		unsuspendErr        error
+
+		expectErr error
+	}{
+		{
+			name:                "Success",
+			userID:              goframework.NumberUUID(1),
+			actor:               "moderation",
+			now:                 baseTime,
+			shouldCallUnsuspend: true,
+		},
+		{
+			name:                "Error/NotSuspended",
+			userID:              goframework.NumberUUID(1),
+			actor:               "moderation",
+			now:                 baseTime,
+			shouldCallUnsuspend: true,
+			unsuspendErr:        bunovel.ErrNotFound,
+			expectErr:           bunovel.ErrNotFound,
+		},
+		{
+			name:                "Error/DAOFailure",
+			userID:              goframework.NumberUUID(1),
+			actor:               "moderation",
+			now:                 baseTime,
+			shouldCallUnsuspend: true,
+			unsuspendErr:        fooErr,
+			expectErr:           fooErr,
+		},
+		{
+			name:      "Error/NoUserID",
+			actor:     "moderation",
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+
+			if d.shouldCallUnsuspend {
+				credentialsDAO.
+					On("Unsuspend", context.Background(), d.actor, d.userID, d.now).
+					Return(nil, d.unsuspendErr)
+			}
+
+			service := services.NewUnsuspendUserService(credentialsDAO)
+			err := service.UnsuspendUser(context.Background(), d.userID, d.actor, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			credentialsDAO.AssertExpectations(t)
+		})
+	}
+}
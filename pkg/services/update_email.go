@@ -5,6 +5,7 @@ import (
 	goerrors "errors"
 	"fmt"
 	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
 	goframework "github.com/a-novel/go-framework"
 	sendgridproxy "github.com/a-novel/sendgrid-proxy"
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
@@ -12,7 +13,9 @@ import (
 )
 
 type UpdateEmailService interface {
-	UpdateEmail(ctx context.Context, tokenRaw, newEmail string, now time.Time) (func() error, error)
+	// UpdateEmail requests a new email for the user. The password is required if the user did not authenticate
+	// recently.
+	UpdateEmail(ctx context.Context, tokenRaw, newEmail, password string, client models.ClientInfo, now time.Time) (func() error, error)
 }
 
 func NewUpdateEmailService(
@@ -21,8 +24,11 @@ func NewUpdateEmailService(
 	mailer sendgridproxy.Mailer,
 	generateValidationLink func() (string, string, error),
 	introspectTokenService IntrospectTokenService,
+	checkStepUpService CheckStepUpService,
+	sendSecurityAlertService SendSecurityAlertService,
 	validateNewEmailLink string,
 	validateNewEmailTemplate string,
+	reportEmailChangeLink string,
 ) UpdateEmailService {
 	return &updateEmailServiceImpl{
 		credentialsDAO:           credentialsDAO,
@@ -30,8 +36,11 @@ func NewUpdateEmailService(
 		mailer:                   mailer,
 		generateValidationLink:   generateValidationLink,
 		IntrospectTokenService:   introspectTokenService,
+		CheckStepUpService:       checkStepUpService,
+		SendSecurityAlertService: sendSecurityAlertService,
 		validateNewEmailLink:     validateNewEmailLink,
 		validateNewEmailTemplate: validateNewEmailTemplate,
+		reportEmailChangeLink:    reportEmailChangeLink,
 	}
 }
 
@@ -41,12 +50,15 @@ type updateEmailServiceImpl struct {
 	mailer                 sendgridproxy.Mailer
 	generateValidationLink func() (string, string, error)
 	IntrospectTokenService
+	CheckStepUpService
+	SendSecurityAlertService
 
 	validateNewEmailLink     string
 	validateNewEmailTemplate string
+	reportEmailChangeLink    string
 }
 
-func (s *updateEmailServiceImpl) UpdateEmail(ctx context.Context, tokenRaw, newEmail string, now time.Time) (func() error, error) {
+func (s *updateEmailServiceImpl) UpdateEmail(ctx context.Context, tokenRaw, newEmail, password string, client models.ClientInfo, now time.Time) (func() error, error) {
 	token, err := s.IntrospectToken(ctx, tokenRaw, now, false)
 	if err != nil {
 		return nil, goerrors.Join(ErrIntrospectToken, err)
@@ -55,6 +67,10 @@ func (s *updateEmailServiceImpl) UpdateEmail(ctx context.Context, tokenRaw, newE
 		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
 	}
 
+	if err := s.CheckStepUp(ctx, token.Token, SensitiveOperationUpdateEmail, password, client, now); err != nil {
+		return nil, goerrors.Join(ErrCheckStepUp, err)
+	}
+
 	newDAOEmail, err := dao.ParseEmail(newEmail)
 	if err != nil {
 		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidEmail, err)
@@ -73,8 +89,22 @@ func (s *updateEmailServiceImpl) UpdateEmail(ctx context.Context, tokenRaw, newE
 		return nil, goerrors.Join(ErrGenerateValidationCode, err)
 	}
 
-	if _, err := s.credentialsDAO.UpdateEmail(ctx, newDAOEmail, privateValidationCode, token.Token.Payload.ID, now); err != nil {
-		return nil, goerrors.Join(ErrUpdateEmail, err)
+	// The report code is sent to the current address, so its owner can cancel a change they did not request.
+	publicReportCode, privateReportCode, err := s.generateValidationLink()
+	if err != nil {
+		return nil, goerrors.Join(ErrGenerateValidationCode, err)
+	}
+
+	err = s.credentialsDAO.RunInTx(ctx, func(ctx context.Context, txClient dao.CredentialsRepository) error {
+		_, err := txClient.UpdateEmail(ctx, newDAOEmail, privateValidationCode, privateReportCode, token.Token.Payload.ID, now)
+		if err != nil {
+			return goerrors.Join(ErrUpdateEmail, err)
+		}
+
+		return recordAuditEvent(ctx, txClient, newAuditEvent(dao.AuditEventEmailChangeRequested, token.Token.Payload.ID, client), now)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	identity, err := s.identityDAO.GetIdentity(ctx, token.Token.Payload.ID)
@@ -93,6 +123,14 @@ func (s *updateEmailServiceImpl) UpdateEmail(ctx context.Context, tokenRaw, newE
 			return goerrors.Join(ErrSendValidationEmail, err)
 		}
 
+		err := s.SendSecurityAlert(ctx, token.Token.Payload.ID, SecurityAlertEmailChangeRequested, map[string]interface{}{
+			"new_email":   newEmail,
+			"report_link": fmt.Sprintf("%s?id=%s&code=%s", s.reportEmailChangeLink, token.Token.Payload.ID, publicReportCode),
+		})
+		if err != nil {
+			return goerrors.Join(ErrSendSecurityAlert, err)
+		}
+
 		return nil
 	}
 
@@ -17,19 +17,26 @@ import (
 )
 
 func TestUpdateEmail(t *testing.T) {
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
 	data := []struct {
 		name string
 
 		validateEmailLink     string
 		validateEmailTemplate string
+		reportEmailChangeLink string
 
 		tokenRaw string
+		password string
 		newEmail string
 		now      time.Time
 
 		introspectToken    *models.UserTokenStatus
 		introspectTokenErr error
 
+		shouldCallCheckStepUp bool
+		checkStepUpErr        error
+
 		shouldCallEmailExists bool
 		emailExists           bool
 		emailExistsErr        error
@@ -41,6 +48,9 @@ func TestUpdateEmail(t *testing.T) {
 		shouldCallUpdateEmail bool
 		updateEmailErr        error
 
+		shouldCallRecord bool
+		recordErr        error
+
 		shouldCallIdentityDAO bool
 		identityDAO           *dao.IdentityModel
 		identityDAOErr        error
@@ -50,6 +60,10 @@ func TestUpdateEmail(t *testing.T) {
 		shouldCallMailerWithData  map[string]interface{}
 		mailerErr                 error
 
+		shouldCallSendSecurityAlert     bool
+		shouldCallSendSecurityAlertData map[string]interface{}
+		sendSecurityAlertErr            error
+
 		expectErr         error
 		expectDeferred    bool
 		expectDeferredErr error
@@ -58,6 +72,7 @@ func TestUpdateEmail(t *testing.T) {
 			name:                  "Success",
 			validateEmailTemplate: "validate-email-template",
 			validateEmailLink:     "validate-email-link",
+			reportEmailChangeLink: "report-email-change-link",
 			tokenRaw:              "string-token",
 			newEmail:              "new-user@domain.com",
 			now:                   baseTime,
@@ -67,11 +82,13 @@ func TestUpdateEmail(t *testing.T) {
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
 			},
+			shouldCallCheckStepUp: true,
 			shouldCallEmailExists: true,
 			emailExists:           false,
 			publicValidationCode:  "public-validation-code",
 			privateValidationCode: "private-validation-code",
 			shouldCallUpdateEmail: true,
+			shouldCallRecord:      true,
 			shouldCallIdentityDAO: true,
 			identityDAO: &dao.IdentityModel{
 				IdentityModelCore: dao.IdentityModelCore{
@@ -84,12 +101,60 @@ func TestUpdateEmail(t *testing.T) {
 				"name":            "name",
 				"validation_link": "validate-email-link?id=01010101-0101-0101-0101-010101010101&code=public-validation-code",
 			},
+			shouldCallSendSecurityAlert: true,
+			shouldCallSendSecurityAlertData: map[string]interface{}{
+				"new_email":   "new-user@domain.com",
+				"report_link": "report-email-change-link?id=01010101-0101-0101-0101-010101010101&code=public-validation-code",
+			},
 			expectDeferred: true,
 		},
+		{
+			name:                  "Error/SendSecurityAlertFailure",
+			validateEmailTemplate: "validate-email-template",
+			validateEmailLink:     "validate-email-link",
+			reportEmailChangeLink: "report-email-change-link",
+			tokenRaw:              "string-token",
+			newEmail:              "new-user@domain.com",
+			now:                   baseTime,
+			introspectToken: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+			shouldCallCheckStepUp: true,
+			shouldCallEmailExists: true,
+			emailExists:           false,
+			publicValidationCode:  "public-validation-code",
+			privateValidationCode: "private-validation-code",
+			shouldCallUpdateEmail: true,
+			shouldCallRecord:      true,
+			shouldCallIdentityDAO: true,
+			identityDAO: &dao.IdentityModel{
+				IdentityModelCore: dao.IdentityModelCore{
+					FirstName: "name",
+				},
+			},
+			shouldCallMailer:          true,
+			shouldCallMailerWithEmail: mail.NewEmail("name", "new-user@domain.com"),
+			shouldCallMailerWithData: map[string]interface{}{
+				"name":            "name",
+				"validation_link": "validate-email-link?id=01010101-0101-0101-0101-010101010101&code=public-validation-code",
+			},
+			shouldCallSendSecurityAlert: true,
+			shouldCallSendSecurityAlertData: map[string]interface{}{
+				"new_email":   "new-user@domain.com",
+				"report_link": "report-email-change-link?id=01010101-0101-0101-0101-010101010101&code=public-validation-code",
+			},
+			sendSecurityAlertErr: fooErr,
+			expectDeferred:       true,
+			expectDeferredErr:    fooErr,
+		},
 		{
 			name:                  "Error/SendingEmailFailure",
 			validateEmailTemplate: "validate-email-template",
 			validateEmailLink:     "validate-email-link",
+			reportEmailChangeLink: "report-email-change-link",
 			tokenRaw:              "string-token",
 			newEmail:              "new-user@domain.com",
 			now:                   baseTime,
@@ -99,11 +164,13 @@ func TestUpdateEmail(t *testing.T) {
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
 			},
+			shouldCallCheckStepUp: true,
 			shouldCallEmailExists: true,
 			emailExists:           false,
 			publicValidationCode:  "public-validation-code",
 			privateValidationCode: "private-validation-code",
 			shouldCallUpdateEmail: true,
+			shouldCallRecord:      true,
 			shouldCallIdentityDAO: true,
 			identityDAO: &dao.IdentityModel{
 				IdentityModelCore: dao.IdentityModelCore{
@@ -124,6 +191,7 @@ func TestUpdateEmail(t *testing.T) {
 			name:                  "Error/IdentityDAOFailure",
 			validateEmailTemplate: "validate-email-template",
 			validateEmailLink:     "validate-email-link",
+			reportEmailChangeLink: "report-email-change-link",
 			tokenRaw:              "string-token",
 			newEmail:              "new-user@domain.com",
 			now:                   baseTime,
@@ -133,19 +201,46 @@ func TestUpdateEmail(t *testing.T) {
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
 			},
+			shouldCallCheckStepUp: true,
 			shouldCallEmailExists: true,
 			emailExists:           false,
 			publicValidationCode:  "public-validation-code",
 			privateValidationCode: "private-validation-code",
 			shouldCallUpdateEmail: true,
+			shouldCallRecord:      true,
 			shouldCallIdentityDAO: true,
 			identityDAOErr:        fooErr,
 			expectErr:             fooErr,
 		},
+		{
+			name:                  "Error/RecordAuditEventFailure",
+			validateEmailTemplate: "validate-email-template",
+			validateEmailLink:     "validate-email-link",
+			reportEmailChangeLink: "report-email-change-link",
+			tokenRaw:              "string-token",
+			newEmail:              "new-user@domain.com",
+			now:                   baseTime,
+			introspectToken: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+			shouldCallCheckStepUp: true,
+			shouldCallEmailExists: true,
+			emailExists:           false,
+			publicValidationCode:  "public-validation-code",
+			privateValidationCode: "private-validation-code",
+			shouldCallUpdateEmail: true,
+			shouldCallRecord:      true,
+			recordErr:             fooErr,
+			expectErr:             fooErr,
+		},
 		{
 			name:                  "Error/UpdateEmailFailure",
 			validateEmailTemplate: "validate-email-template",
 			validateEmailLink:     "validate-email-link",
+			reportEmailChangeLink: "report-email-change-link",
 			tokenRaw:              "string-token",
 			newEmail:              "new-user@domain.com",
 			now:                   baseTime,
@@ -155,6 +250,7 @@ func TestUpdateEmail(t *testing.T) {
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
 			},
+			shouldCallCheckStepUp: true,
 			shouldCallEmailExists: true,
 			emailExists:           false,
 			publicValidationCode:  "public-validation-code",
@@ -167,6 +263,7 @@ func TestUpdateEmail(t *testing.T) {
 			name:                  "Error/GenerateValidationCodeFailure",
 			validateEmailTemplate: "validate-email-template",
 			validateEmailLink:     "validate-email-link",
+			reportEmailChangeLink: "report-email-change-link",
 			tokenRaw:              "string-token",
 			newEmail:              "new-user@domain.com",
 			now:                   baseTime,
@@ -176,6 +273,7 @@ func TestUpdateEmail(t *testing.T) {
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
 			},
+			shouldCallCheckStepUp:     true,
 			shouldCallEmailExists:     true,
 			emailExists:               false,
 			generateValidationCodeErr: fooErr,
@@ -185,6 +283,7 @@ func TestUpdateEmail(t *testing.T) {
 			name:                  "Error/EmailAlreadyTaken",
 			validateEmailTemplate: "validate-email-template",
 			validateEmailLink:     "validate-email-link",
+			reportEmailChangeLink: "report-email-change-link",
 			tokenRaw:              "string-token",
 			newEmail:              "new-user@domain.com",
 			now:                   baseTime,
@@ -194,6 +293,7 @@ func TestUpdateEmail(t *testing.T) {
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
 			},
+			shouldCallCheckStepUp: true,
 			shouldCallEmailExists: true,
 			emailExists:           true,
 			expectErr:             services.ErrTaken,
@@ -202,6 +302,7 @@ func TestUpdateEmail(t *testing.T) {
 			name:                  "Error/EmailExistsFailure",
 			validateEmailTemplate: "validate-email-template",
 			validateEmailLink:     "validate-email-link",
+			reportEmailChangeLink: "report-email-change-link",
 			tokenRaw:              "string-token",
 			newEmail:              "new-user@domain.com",
 			now:                   baseTime,
@@ -211,6 +312,7 @@ func TestUpdateEmail(t *testing.T) {
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
 			},
+			shouldCallCheckStepUp: true,
 			shouldCallEmailExists: true,
 			emailExistsErr:        fooErr,
 			expectErr:             fooErr,
@@ -219,6 +321,7 @@ func TestUpdateEmail(t *testing.T) {
 			name:                  "Error/InvalidEmail",
 			validateEmailTemplate: "validate-email-template",
 			validateEmailLink:     "validate-email-link",
+			reportEmailChangeLink: "report-email-change-link",
 			tokenRaw:              "string-token",
 			newEmail:              "new-userdomain.com",
 			now:                   baseTime,
@@ -228,13 +331,32 @@ func TestUpdateEmail(t *testing.T) {
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
 			},
-			expectErr: goframework.ErrInvalidEntity,
+			shouldCallCheckStepUp: true,
+			expectErr:             goframework.ErrInvalidEntity,
 		},
 		{
 			name:                  "Error/NoEmail",
 			validateEmailTemplate: "validate-email-template",
 			validateEmailLink:     "validate-email-link",
+			reportEmailChangeLink: "report-email-change-link",
+			tokenRaw:              "string-token",
+			now:                   baseTime,
+			introspectToken: &models.UserTokenStatus{
+				OK: true,
+				Token: &models.UserToken{
+					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
+				},
+			},
+			shouldCallCheckStepUp: true,
+			expectErr:             goframework.ErrInvalidEntity,
+		},
+		{
+			name:                  "Error/CheckStepUpFailure",
+			validateEmailTemplate: "validate-email-template",
+			validateEmailLink:     "validate-email-link",
+			reportEmailChangeLink: "report-email-change-link",
 			tokenRaw:              "string-token",
+			newEmail:              "new-user@domain.com",
 			now:                   baseTime,
 			introspectToken: &models.UserTokenStatus{
 				OK: true,
@@ -242,12 +364,15 @@ func TestUpdateEmail(t *testing.T) {
 					Payload: models.UserTokenPayload{ID: goframework.NumberUUID(1)},
 				},
 			},
-			expectErr: goframework.ErrInvalidEntity,
+			shouldCallCheckStepUp: true,
+			checkStepUpErr:        fooErr,
+			expectErr:             fooErr,
 		},
 		{
 			name:                  "Error/InvalidToken",
 			validateEmailTemplate: "validate-email-template",
 			validateEmailLink:     "validate-email-link",
+			reportEmailChangeLink: "report-email-change-link",
 			tokenRaw:              "string-token",
 			newEmail:              "new-userdomain.com",
 			now:                   baseTime,
@@ -263,6 +388,7 @@ func TestUpdateEmail(t *testing.T) {
 			name:                  "Error/IntrospectTokenFailure",
 			validateEmailTemplate: "validate-email-template",
 			validateEmailLink:     "validate-email-link",
+			reportEmailChangeLink: "report-email-change-link",
 			tokenRaw:              "string-token",
 			newEmail:              "new-userdomain.com",
 			now:                   baseTime,
@@ -277,6 +403,8 @@ func TestUpdateEmail(t *testing.T) {
 			identityDAO := daomocks.NewIdentityRepository(t)
 			mailerService := sendgridproxy.NewMockMailer(t)
 			introspectTokenService := servicesmocks.NewIntrospectTokenService(t)
+			checkStepUpService := servicesmocks.NewCheckStepUpService(t)
+			sendSecurityAlertService := servicesmocks.NewSendSecurityAlertService(t)
 
 			generateLink := func() (string, string, error) {
 				return d.publicValidationCode, d.privateValidationCode, d.generateValidationCodeErr
@@ -286,6 +414,12 @@ func TestUpdateEmail(t *testing.T) {
 				On("IntrospectToken", context.Background(), d.tokenRaw, d.now, false).
 				Return(d.introspectToken, d.introspectTokenErr)
 
+			if d.shouldCallCheckStepUp {
+				checkStepUpService.
+					On("CheckStepUp", context.Background(), d.introspectToken.Token, services.SensitiveOperationUpdateEmail, d.password, client, d.now).
+					Return(d.checkStepUpErr)
+			}
+
 			if d.shouldCallEmailExists {
 				credentialsDAO.
 					On("EmailExists", context.Background(), mock.Anything).
@@ -294,8 +428,26 @@ func TestUpdateEmail(t *testing.T) {
 
 			if d.shouldCallUpdateEmail {
 				credentialsDAO.
-					On("UpdateEmail", context.Background(), mock.Anything, d.privateValidationCode, d.introspectToken.Token.Payload.ID, d.now).
+					On("UpdateEmail", context.Background(), mock.Anything, d.privateValidationCode, d.privateValidationCode, d.introspectToken.Token.Payload.ID, d.now).
 					Return(nil, d.updateEmailErr)
+
+				// Execute the actual method, but call the mocks inside of it.
+				txCall := credentialsDAO.On("RunInTx", context.Background(), mock.Anything)
+				txCall.Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context, dao.CredentialsRepository) error)
+					txCall.ReturnArguments = []interface{}{fn(context.Background(), credentialsDAO)}
+				})
+			}
+
+			if d.shouldCallRecord {
+				credentialsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:      dao.AuditEventEmailChangeRequested,
+						UserID:    d.introspectToken.Token.Payload.ID,
+						IP:        client.IP,
+						UserAgent: client.UserAgent,
+					}, mock.Anything, d.now).
+					Return(nil, d.recordErr)
 			}
 
 			if d.shouldCallIdentityDAO {
@@ -310,16 +462,31 @@ func TestUpdateEmail(t *testing.T) {
 					Return(d.mailerErr)
 			}
 
+			if d.shouldCallSendSecurityAlert {
+				sendSecurityAlertService.
+					On(
+						"SendSecurityAlert",
+						context.Background(),
+						d.introspectToken.Token.Payload.ID,
+						services.SecurityAlertEmailChangeRequested,
+						d.shouldCallSendSecurityAlertData,
+					).
+					Return(d.sendSecurityAlertErr)
+			}
+
 			service := services.NewUpdateEmailService(
 				credentialsDAO,
 				identityDAO,
 				mailerService,
 				generateLink,
 				introspectTokenService,
+				checkStepUpService,
+				sendSecurityAlertService,
 				d.validateEmailLink,
 				d.validateEmailTemplate,
+				d.reportEmailChangeLink,
 			)
-			deferred, err := service.UpdateEmail(context.Background(), d.tokenRaw, d.newEmail, d.now)
+			deferred, err := service.UpdateEmail(context.Background(), d.tokenRaw, d.newEmail, d.password, client, d.now)
 
 			require.ErrorIs(t, err, d.expectErr)
 
@@ -334,6 +501,8 @@ func TestUpdateEmail(t *testing.T) {
 			identityDAO.AssertExpectations(t)
 			mailerService.AssertExpectations(t)
 			introspectTokenService.AssertExpectations(t)
+			checkStepUpService.AssertExpectations(t)
+			sendSecurityAlertService.AssertExpectations(t)
 		})
 	}
 }
@@ -5,26 +5,57 @@ import (
 	goerrors "errors"
 	"github.com/a-novel/auth-service/pkg/dao"
 	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/bunovel"
 	goframework "github.com/a-novel/go-framework"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"time"
 )
 
 type UpdatePasswordService interface {
-	UpdatePassword(ctx context.Context, form models.UpdatePasswordForm, now time.Time) error
+	UpdatePassword(ctx context.Context, form models.UpdatePasswordForm, client models.ClientInfo, now time.Time) error
 }
 
-func NewUpdatePasswordService(credentialsDAO dao.CredentialsRepository) UpdatePasswordService {
+func NewUpdatePasswordService(
+	credentialsDAO dao.CredentialsRepository,
+	identityDAO dao.IdentityRepository,
+	profileDAO dao.ProfileRepository,
+	loginFailuresDAO dao.LoginFailuresRepository,
+	auditEventsDAO dao.AuditEventsRepository,
+	checkPasswordPolicyService CheckPasswordPolicyService,
+	sendSecurityAlertService SendSecurityAlertService,
+	passwordHasher PasswordHasher,
+	resetTTL time.Duration,
+	throttle LoginThrottle,
+) UpdatePasswordService {
 	return &updatePasswordServiceImpl{
-		credentialsDAO: credentialsDAO,
+		credentialsDAO:             credentialsDAO,
+		identityDAO:                identityDAO,
+		profileDAO:                 profileDAO,
+		loginFailuresDAO:           loginFailuresDAO,
+		auditEventsDAO:             auditEventsDAO,
+		CheckPasswordPolicyService: checkPasswordPolicyService,
+		SendSecurityAlertService:   sendSecurityAlertService,
+		passwordHasher:             passwordHasher,
+		resetTTL:                   resetTTL,
+		throttle:                   throttle,
 	}
 }
 
 type updatePasswordServiceImpl struct {
-	credentialsDAO dao.CredentialsRepository
+	credentialsDAO   dao.CredentialsRepository
+	identityDAO      dao.IdentityRepository
+	profileDAO       dao.ProfileRepository
+	loginFailuresDAO dao.LoginFailuresRepository
+	auditEventsDAO   dao.AuditEventsRepository
+	CheckPasswordPolicyService
+	SendSecurityAlertService
+	passwordHasher PasswordHasher
+	resetTTL       time.Duration
+	throttle       LoginThrottle
 }
 
-func (s *updatePasswordServiceImpl) UpdatePassword(ctx context.Context, form models.UpdatePasswordForm, now time.Time) error {
+func (s *updatePasswordServiceImpl) UpdatePassword(ctx context.Context, form models.UpdatePasswordForm, client models.ClientInfo, now time.Time) error {
 	if err := goframework.CheckMinMax(form.NewPassword, MinPasswordLength, MaxPasswordLength); err != nil {
 		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPassword, err)
 	}
@@ -37,6 +68,9 @@ func (s *updatePasswordServiceImpl) UpdatePassword(ctx context.Context, form mod
 		return goerrors.Join(ErrGetCredentials, err)
 	}
 
+	// The verified reset code, if any. It is consumed with the update of the password.
+	var resetCode string
+
 	if form.Code != "" {
 		if credentials.Password.Validation == "" {
 			return goerrors.Join(goframework.ErrInvalidCredentials, ErrMissingPendingValidation)
@@ -49,25 +83,93 @@ func (s *updatePasswordServiceImpl) UpdatePassword(ctx context.Context, form mod
 		if !ok {
 			return goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidValidationCode)
 		}
+		if validationCodeExpired(credentials.Password.ValidationIssuedAt, s.resetTTL, now) {
+			return goerrors.Join(goframework.ErrInvalidCredentials, ErrValidationCodeExpired)
+		}
+
+		resetCode = credentials.Password.Validation
 	} else {
-		err = bcrypt.CompareHashAndPassword([]byte(credentials.Password.Hashed), []byte(form.OldPassword))
+		// A locked account can only be recovered through a password reset.
+		if credentials.LockedAt != nil {
+			return goerrors.Join(goframework.ErrInvalidCredentials, ErrAccountLocked)
+		}
+
+		account := loginAccount(credentials.Email)
+		if err := checkLoginThrottle(ctx, s.loginFailuresDAO, s.throttle, account, client.IP, now); err != nil {
+			return err
+		}
+
+		ok, _, err := s.passwordHasher.Verify(form.OldPassword, credentials.Password.Hashed)
 		if err != nil {
-			if err == bcrypt.ErrMismatchedHashAndPassword {
-				return goerrors.Join(goframework.ErrInvalidCredentials, ErrWrongPassword)
+			return goerrors.Join(ErrCheckPassword, err)
+		}
+		if !ok {
+			err := recordLoginFailure(
+				ctx, s.loginFailuresDAO, s.auditEventsDAO, account, form.ID, loginFactorPassword, client, now,
+			)
+			if err != nil {
+				return err
 			}
 
-			return goerrors.Join(ErrCheckPassword, err)
+			return goerrors.Join(goframework.ErrInvalidCredentials, ErrWrongPassword)
 		}
 	}
 
-	passwordHashed, err := bcrypt.GenerateFromPassword([]byte(form.NewPassword), bcrypt.DefaultCost)
+	// The policy is only checked once the user is authenticated, so it cannot be used to probe personal information.
+	identity, err := s.identityDAO.GetIdentity(ctx, form.ID)
+	if err != nil {
+		return goerrors.Join(ErrGetIdentity, err)
+	}
+
+	profile, err := s.profileDAO.GetProfile(ctx, form.ID)
+	if err != nil {
+		return goerrors.Join(ErrGetProfile, err)
+	}
+
+	err = s.CheckPasswordPolicy(ctx, form.NewPassword, PasswordUserInfo{
+		Email:     credentials.Email.String(),
+		FirstName: identity.FirstName,
+		LastName:  identity.LastName,
+		Slug:      profile.Slug,
+		Username:  profile.Username,
+	})
+	if err != nil {
+		return goerrors.Join(ErrCheckPasswordPolicy, err)
+	}
+
+	passwordHashed, err := s.passwordHasher.Hash(form.NewPassword)
 	if err != nil {
 		return goerrors.Join(ErrHashPassword, err)
 	}
 
-	if _, err := s.credentialsDAO.UpdatePassword(ctx, string(passwordHashed), form.ID, now); err != nil {
-		return goerrors.Join(ErrUpdatePassword, err)
+	event := newAuditEvent(dao.AuditEventPasswordChanged, form.ID, client)
+	event.Details = map[string]string{"method": lo.Ternary(form.Code != "", "reset", "password")}
+
+	err = s.credentialsDAO.RunInTx(ctx, func(ctx context.Context, txClient dao.CredentialsRepository) error {
+		// A new security stamp revokes every token issued with the previous password. The reset code is consumed
+		// by the update, so concurrent requests cannot both use it.
+		_, err := txClient.UpdatePassword(ctx, passwordHashed, resetCode, uuid.New(), form.ID, now)
+		if goerrors.Is(err, bunovel.ErrNotFound) && resetCode != "" {
+			return goerrors.Join(goframework.ErrInvalidCredentials, ErrMissingPendingValidation, err)
+		}
+		if err != nil {
+			return goerrors.Join(ErrUpdatePassword, err)
+		}
+
+		if credentials.LockedAt != nil {
+			if _, err := txClient.Unlock(ctx, form.ID, now); err != nil {
+				return goerrors.Join(ErrUnlockAccount, err)
+			}
+		}
+
+		return recordAuditEvent(ctx, txClient, event, now)
+	})
+	if err != nil {
+		return err
 	}
 
+	// The password is already updated, so a failure to notify the user must not be reported as a failed update.
+	_ = s.SendSecurityAlert(ctx, form.ID, SecurityAlertPasswordChanged, nil)
+
 	return nil
 }
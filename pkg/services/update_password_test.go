@@ -2,11 +2,16 @@ package services_test
 
 import (
 	"context"
+	goerrors "errors"
 	"github.com/a-novel/auth-service/pkg/dao"
 	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
 	"github.com/a-novel/auth-service/pkg/models"
 	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
 	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"strings"
@@ -15,6 +20,19 @@ import (
 )
 
 func TestUpdatePassword(t *testing.T) {
+	resetTTL := time.Hour
+	issuedAt := baseTime.Add(-10 * time.Minute)
+	expiredAt := baseTime.Add(-resetTTL)
+
+	identity := &dao.IdentityModel{
+		IdentityModelCore: dao.IdentityModelCore{FirstName: "Elon", LastName: "Musk"},
+	}
+	profile := &dao.ProfileModel{
+		ProfileModelCore: dao.ProfileModelCore{Slug: "elon-musk", Username: "elonmusk"},
+	}
+
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
 	data := []struct {
 		name string
 
@@ -25,9 +43,37 @@ func TestUpdatePassword(t *testing.T) {
 		getCredentials           *dao.CredentialsModel
 		getCredentialsErr        error
 
+		shouldCallCheckThrottle bool
+		getAccountFailures      *dao.LoginFailuresSummaryModel
+		getAccountFailuresErr   error
+
+		shouldCallRecordFailure bool
+		recordFailureErr        error
+
+		shouldCallRecordAuditEvent bool
+		recordAuditEventErr        error
+
+		shouldCallGetIdentity bool
+		getIdentityErr        error
+
+		shouldCallGetProfile bool
+		getProfileErr        error
+
+		shouldCallCheckPasswordPolicy bool
+		checkPasswordPolicyErr        error
+
 		shouldCallUpdateCredentials bool
 		updateCredentialsErr        error
 
+		shouldCallUnlock bool
+		unlockErr        error
+
+		shouldCallRecord bool
+		recordErr        error
+
+		shouldCallSendSecurityAlert bool
+		sendSecurityAlertErr        error
+
 		expectErr error
 	}{
 		{
@@ -41,10 +87,17 @@ func TestUpdatePassword(t *testing.T) {
 			shouldCallGetCredentials: true,
 			getCredentials: &dao.CredentialsModel{
 				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
 					Password: dao.Password{Hashed: passwordEncrypted},
 				},
 			},
-			shouldCallUpdateCredentials: true,
+			shouldCallCheckThrottle:       true,
+			shouldCallGetIdentity:         true,
+			shouldCallGetProfile:          true,
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallUpdateCredentials:   true,
+			shouldCallRecord:              true,
+			shouldCallSendSecurityAlert:   true,
 		},
 		{
 			name: "Success/ValidationCode",
@@ -57,10 +110,112 @@ func TestUpdatePassword(t *testing.T) {
 			shouldCallGetCredentials: true,
 			getCredentials: &dao.CredentialsModel{
 				CredentialsModelCore: dao.CredentialsModelCore{
-					Password: dao.Password{Hashed: passwordEncrypted, Validation: privateValidationCode},
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
+					Password: dao.Password{Hashed: passwordEncrypted, Validation: privateValidationCode, ValidationIssuedAt: &issuedAt},
+				},
+			},
+			shouldCallGetIdentity:         true,
+			shouldCallGetProfile:          true,
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallUpdateCredentials:   true,
+			shouldCallRecord:              true,
+			shouldCallSendSecurityAlert:   true,
+		},
+		{
+			name: "Success/UnlockAccount",
+			form: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				NewPassword: "new-secure-password",
+				Code:        publicValidationCode,
+			},
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
+					Password: dao.Password{Hashed: passwordEncrypted, Validation: privateValidationCode, ValidationIssuedAt: &issuedAt},
+					LockedAt: &issuedAt,
+				},
+			},
+			shouldCallGetIdentity:         true,
+			shouldCallGetProfile:          true,
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallUpdateCredentials:   true,
+			shouldCallUnlock:              true,
+			shouldCallRecord:              true,
+			shouldCallSendSecurityAlert:   true,
+		},
+		{
+			name: "Success/SendSecurityAlertFailure",
+			form: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				NewPassword: "new-secure-password",
+				OldPassword: password,
+			},
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
+					Password: dao.Password{Hashed: passwordEncrypted},
+				},
+			},
+			shouldCallCheckThrottle:       true,
+			shouldCallGetIdentity:         true,
+			shouldCallGetProfile:          true,
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallUpdateCredentials:   true,
+			shouldCallRecord:              true,
+			shouldCallSendSecurityAlert:   true,
+			sendSecurityAlertErr:          fooErr,
+		},
+		{
+			name: "Error/RecordAuditEventFailure",
+			form: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				NewPassword: "new-secure-password",
+				OldPassword: password,
+			},
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
+					Password: dao.Password{Hashed: passwordEncrypted},
+				},
+			},
+			shouldCallCheckThrottle:       true,
+			shouldCallGetIdentity:         true,
+			shouldCallGetProfile:          true,
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallUpdateCredentials:   true,
+			shouldCallRecord:              true,
+			recordErr:                     fooErr,
+			expectErr:                     fooErr,
+		},
+		{
+			name: "Error/UnlockFailure",
+			form: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				NewPassword: "new-secure-password",
+				Code:        publicValidationCode,
+			},
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
+					Password: dao.Password{Hashed: passwordEncrypted, Validation: privateValidationCode, ValidationIssuedAt: &issuedAt},
+					LockedAt: &issuedAt,
 				},
 			},
-			shouldCallUpdateCredentials: true,
+			shouldCallGetIdentity:         true,
+			shouldCallGetProfile:          true,
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallUpdateCredentials:   true,
+			shouldCallUnlock:              true,
+			unlockErr:                     fooErr,
+			expectErr:                     fooErr,
 		},
 		{
 			name: "Error/UpdatePasswordFailure",
@@ -73,12 +228,146 @@ func TestUpdatePassword(t *testing.T) {
 			shouldCallGetCredentials: true,
 			getCredentials: &dao.CredentialsModel{
 				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
 					Password: dao.Password{Hashed: passwordEncrypted},
 				},
 			},
-			shouldCallUpdateCredentials: true,
-			updateCredentialsErr:        fooErr,
-			expectErr:                   fooErr,
+			shouldCallCheckThrottle:       true,
+			shouldCallGetIdentity:         true,
+			shouldCallGetProfile:          true,
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallUpdateCredentials:   true,
+			updateCredentialsErr:          fooErr,
+			expectErr:                     fooErr,
+		},
+		{
+			// The code was used by a concurrent request.
+			name: "Error/ValidationCodeAlreadyUsed",
+			form: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				NewPassword: "new-secure-password",
+				Code:        publicValidationCode,
+			},
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
+					Password: dao.Password{Hashed: passwordEncrypted, Validation: privateValidationCode, ValidationIssuedAt: &issuedAt},
+				},
+			},
+			shouldCallGetIdentity:         true,
+			shouldCallGetProfile:          true,
+			shouldCallCheckPasswordPolicy: true,
+			shouldCallUpdateCredentials:   true,
+			updateCredentialsErr:          bunovel.ErrNotFound,
+			expectErr:                     services.ErrMissingPendingValidation,
+		},
+		{
+			name: "Error/PasswordPolicy",
+			form: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				NewPassword: "new-secure-password",
+				OldPassword: password,
+			},
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
+					Password: dao.Password{Hashed: passwordEncrypted},
+				},
+			},
+			shouldCallCheckThrottle:       true,
+			shouldCallGetIdentity:         true,
+			shouldCallGetProfile:          true,
+			shouldCallCheckPasswordPolicy: true,
+			checkPasswordPolicyErr: goerrors.Join(
+				goframework.ErrInvalidEntity,
+				&services.PasswordPolicyError{Rules: []string{services.PasswordRuleUserInfo}},
+			),
+			expectErr: services.ErrInvalidPassword,
+		},
+		{
+			name: "Error/CheckPasswordPolicyFailure",
+			form: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				NewPassword: "new-secure-password",
+				OldPassword: password,
+			},
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
+					Password: dao.Password{Hashed: passwordEncrypted},
+				},
+			},
+			shouldCallCheckThrottle:       true,
+			shouldCallGetIdentity:         true,
+			shouldCallGetProfile:          true,
+			shouldCallCheckPasswordPolicy: true,
+			checkPasswordPolicyErr:        fooErr,
+			expectErr:                     fooErr,
+		},
+		{
+			name: "Error/GetProfileFailure",
+			form: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				NewPassword: "new-secure-password",
+				OldPassword: password,
+			},
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
+					Password: dao.Password{Hashed: passwordEncrypted},
+				},
+			},
+			shouldCallCheckThrottle: true,
+			shouldCallGetIdentity:   true,
+			shouldCallGetProfile:    true,
+			getProfileErr:           fooErr,
+			expectErr:               fooErr,
+		},
+		{
+			name: "Error/GetIdentityFailure",
+			form: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				NewPassword: "new-secure-password",
+				OldPassword: password,
+			},
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
+					Password: dao.Password{Hashed: passwordEncrypted},
+				},
+			},
+			shouldCallCheckThrottle: true,
+			shouldCallGetIdentity:   true,
+			getIdentityErr:          fooErr,
+			expectErr:               fooErr,
+		},
+		{
+			name: "Error/AccountLocked",
+			form: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				NewPassword: "new-secure-password",
+				OldPassword: password,
+			},
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
+					Password: dao.Password{Hashed: passwordEncrypted},
+					LockedAt: &issuedAt,
+				},
+			},
+			expectErr: services.ErrAccountLocked,
 		},
 		{
 			name: "Error/WrongPassword",
@@ -91,10 +380,93 @@ func TestUpdatePassword(t *testing.T) {
 			shouldCallGetCredentials: true,
 			getCredentials: &dao.CredentialsModel{
 				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
 					Password: dao.Password{Hashed: passwordEncrypted},
 				},
 			},
-			expectErr: goframework.ErrInvalidCredentials,
+			shouldCallCheckThrottle:    true,
+			shouldCallRecordFailure:    true,
+			shouldCallRecordAuditEvent: true,
+			expectErr:                  services.ErrWrongPassword,
+		},
+		{
+			name: "Error/RecordLoginFailedEventFailure",
+			form: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				NewPassword: "new-secure-password",
+				OldPassword: "fake-password",
+			},
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
+					Password: dao.Password{Hashed: passwordEncrypted},
+				},
+			},
+			shouldCallCheckThrottle:    true,
+			shouldCallRecordFailure:    true,
+			shouldCallRecordAuditEvent: true,
+			recordAuditEventErr:        fooErr,
+			expectErr:                  fooErr,
+		},
+		{
+			name: "Error/RecordFailureFailure",
+			form: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				NewPassword: "new-secure-password",
+				OldPassword: "fake-password",
+			},
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
+					Password: dao.Password{Hashed: passwordEncrypted},
+				},
+			},
+			shouldCallCheckThrottle: true,
+			shouldCallRecordFailure: true,
+			recordFailureErr:        fooErr,
+			expectErr:               fooErr,
+		},
+		{
+			name: "Error/TooManyAttempts",
+			form: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				NewPassword: "new-secure-password",
+				OldPassword: password,
+			},
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
+					Password: dao.Password{Hashed: passwordEncrypted},
+				},
+			},
+			shouldCallCheckThrottle: true,
+			getAccountFailures:      &dao.LoginFailuresSummaryModel{Count: 3, FirstAt: baseTime, LastAt: baseTime},
+			expectErr:               services.ErrTooManyAttempts,
+		},
+		{
+			name: "Error/GetLoginFailuresFailure",
+			form: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				NewPassword: "new-secure-password",
+				OldPassword: password,
+			},
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
+			getCredentials: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain.com"},
+					Password: dao.Password{Hashed: passwordEncrypted},
+				},
+			},
+			shouldCallCheckThrottle: true,
+			getAccountFailuresErr:   fooErr,
+			expectErr:               fooErr,
 		},
 		{
 			name: "Error/WrongValidationCode",
@@ -107,7 +479,7 @@ func TestUpdatePassword(t *testing.T) {
 			shouldCallGetCredentials: true,
 			getCredentials: &dao.CredentialsModel{
 				CredentialsModelCore: dao.CredentialsModelCore{
-					Password: dao.Password{Hashed: passwordEncrypted, Validation: privateValidationCode},
+					Password: dao.Password{Hashed: passwordEncrypted, Validation: privateValidationCode, ValidationIssuedAt: &issuedAt},
 				},
 			},
 			expectErr: goframework.ErrInvalidCredentials,
@@ -121,6 +493,22 @@ func TestUpdatePassword(t *testing.T) {
 			},
 			now:                      baseTime,
 			shouldCallGetCredentials: true,
+			getCredentials: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Password: dao.Password{Hashed: passwordEncrypted, Validation: privateValidationCode, ValidationIssuedAt: &expiredAt},
+				},
+			},
+			expectErr: services.ErrValidationCodeExpired,
+		},
+		{
+			name: "Error/NoPendingReset",
+			form: models.UpdatePasswordForm{
+				ID:          goframework.NumberUUID(1),
+				NewPassword: "new-secure-password",
+				Code:        publicValidationCode,
+			},
+			now:                      baseTime,
+			shouldCallGetCredentials: true,
 			getCredentials: &dao.CredentialsModel{
 				CredentialsModelCore: dao.CredentialsModelCore{
 					Password: dao.Password{Hashed: passwordEncrypted},
@@ -171,6 +559,12 @@ func TestUpdatePassword(t *testing.T) {
 	for _, d := range data {
 		t.Run(d.name, func(t *testing.T) {
 			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			identityDAO := daomocks.NewIdentityRepository(t)
+			profileDAO := daomocks.NewProfileRepository(t)
+			loginFailuresDAO := daomocks.NewLoginFailuresRepository(t)
+			auditEventsDAO := daomocks.NewAuditEventsRepository(t)
+			checkPasswordPolicyService := servicesmocks.NewCheckPasswordPolicyService(t)
+			sendSecurityAlertService := servicesmocks.NewSendSecurityAlertService(t)
 
 			if d.shouldCallGetCredentials {
 				credentialsDAO.
@@ -178,18 +572,127 @@ func TestUpdatePassword(t *testing.T) {
 					Return(d.getCredentials, d.getCredentialsErr)
 			}
 
+			if d.shouldCallCheckThrottle {
+				loginFailuresDAO.
+					On("GetIPFailures", context.Background(), client.IP, d.now.Add(-loginThrottle.IPWindow)).
+					Return(&dao.LoginFailuresSummaryModel{}, nil)
+				loginFailuresDAO.
+					On("GetAccountFailures", context.Background(), "user@domain.com", d.now.Add(-loginThrottle.AccountWindow)).
+					Return(lo.Ternary(d.getAccountFailures != nil, d.getAccountFailures, &dao.LoginFailuresSummaryModel{}), d.getAccountFailuresErr)
+			}
+
+			if d.shouldCallRecordFailure {
+				loginFailuresDAO.
+					On("Record", context.Background(), &dao.LoginFailureModelCore{
+						Account: "user@domain.com",
+						IP:      client.IP,
+					}, mock.Anything, d.now).
+					Return(nil, d.recordFailureErr)
+			}
+
+			if d.shouldCallRecordAuditEvent {
+				auditEventsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:      dao.AuditEventLoginFailed,
+						UserID:    d.form.ID,
+						IP:        client.IP,
+						UserAgent: client.UserAgent,
+						Details:   map[string]string{"email": "user@domain.com", "factor": "password"},
+					}, mock.Anything, d.now).
+					Return(nil, d.recordAuditEventErr)
+			}
+
+			if d.shouldCallGetIdentity {
+				identityDAO.
+					On("GetIdentity", context.Background(), d.form.ID).
+					Return(identity, d.getIdentityErr)
+			}
+
+			if d.shouldCallGetProfile {
+				profileDAO.
+					On("GetProfile", context.Background(), d.form.ID).
+					Return(profile, d.getProfileErr)
+			}
+
+			if d.shouldCallCheckPasswordPolicy {
+				checkPasswordPolicyService.
+					On("CheckPasswordPolicy", context.Background(), d.form.NewPassword, services.PasswordUserInfo{
+						Email:     "user@domain.com",
+						FirstName: "Elon",
+						LastName:  "Musk",
+						Slug:      "elon-musk",
+						Username:  "elonmusk",
+					}).
+					Return(d.checkPasswordPolicyErr)
+			}
+
 			if d.shouldCallUpdateCredentials {
 				credentialsDAO.
-					On("UpdatePassword", context.Background(), mock.Anything, d.form.ID, d.now).
+					On("UpdatePassword", context.Background(), mock.Anything, lo.Ternary(d.form.Code != "", privateValidationCode, ""), mock.MatchedBy(func(stamp uuid.UUID) bool {
+						return stamp != uuid.Nil
+					}), d.form.ID, d.now).
 					Return(nil, d.updateCredentialsErr)
+
+				// Execute the actual method, but call the mocks inside of it.
+				txCall := credentialsDAO.On("RunInTx", context.Background(), mock.Anything)
+				txCall.Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context, dao.CredentialsRepository) error)
+					txCall.ReturnArguments = []interface{}{fn(context.Background(), credentialsDAO)}
+				})
+			}
+
+			if d.shouldCallUnlock {
+				credentialsDAO.
+					On("Unlock", context.Background(), d.form.ID, d.now).
+					Return(nil, d.unlockErr)
+			}
+
+			if d.shouldCallRecord {
+				method := "password"
+				if d.form.Code != "" {
+					method = "reset"
+				}
+
+				credentialsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:      dao.AuditEventPasswordChanged,
+						UserID:    d.form.ID,
+						IP:        client.IP,
+						UserAgent: client.UserAgent,
+						Details:   map[string]string{"method": method},
+					}, mock.Anything, d.now).
+					Return(nil, d.recordErr)
+			}
+
+			if d.shouldCallSendSecurityAlert {
+				sendSecurityAlertService.
+					On("SendSecurityAlert", context.Background(), d.form.ID, services.SecurityAlertPasswordChanged, map[string]interface{}(nil)).
+					Return(d.sendSecurityAlertErr)
 			}
 
-			service := services.NewUpdatePasswordService(credentialsDAO)
-			err := service.UpdatePassword(context.Background(), d.form, d.now)
+			service := services.NewUpdatePasswordService(
+				credentialsDAO,
+				identityDAO,
+				profileDAO,
+				loginFailuresDAO,
+				auditEventsDAO,
+				checkPasswordPolicyService,
+				sendSecurityAlertService,
+				passwordHasher,
+				resetTTL,
+				loginThrottle,
+			)
+			err := service.UpdatePassword(context.Background(), d.form, client, d.now)
 
 			require.ErrorIs(t, err, d.expectErr)
 
 			credentialsDAO.AssertExpectations(t)
+			identityDAO.AssertExpectations(t)
+			profileDAO.AssertExpectations(t)
+			loginFailuresDAO.AssertExpectations(t)
+			auditEventsDAO.AssertExpectations(t)
+			checkPasswordPolicyService.AssertExpectations(t)
+			sendSecurityAlertService.AssertExpectations(t)
 		})
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/a-novel/auth-service/pkg/models"
 	"github.com/a-novel/bunovel"
 	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
 	"time"
 )
 
@@ -35,6 +36,23 @@ func (s *updateProfileServiceImpl) UpdateProfile(ctx context.Context, tokenRaw s
 		return goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
 	}
 
+	if err := checkProfileForm(ctx, s.profileDAO, form, token.Token.Payload.ID); err != nil {
+		return err
+	}
+
+	if _, err := s.profileDAO.Update(ctx, &dao.ProfileModelCore{
+		Slug:     form.Slug,
+		Username: form.Username,
+	}, token.Token.Payload.ID, now); err != nil {
+		return goerrors.Join(ErrUpdateProfile, err)
+	}
+
+	return nil
+}
+
+// checkProfileForm validates the profile fields of the given user, and makes sure the slug is not used by anyone
+// else.
+func checkProfileForm(ctx context.Context, profileDAO dao.ProfileRepository, form models.UpdateProfileForm, userID uuid.UUID) error {
 	if err := goframework.CheckMinMax(form.Slug, 1, MaxSlugLength); err != nil {
 		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSlug, err)
 	}
@@ -53,20 +71,13 @@ func (s *updateProfileServiceImpl) UpdateProfile(ctx context.Context, tokenRaw s
 
 	// We don't use slugExist here, because the user may want to update other fields and keep its slug. To check if
 	// slug is available, we must also validate it is taken by a different user than the one performing the update.
-	profileWithSameSlug, err := s.profileDAO.GetProfileBySlug(ctx, form.Slug)
+	profileWithSameSlug, err := profileDAO.GetProfileBySlug(ctx, form.Slug)
 	if err != nil && !goerrors.Is(err, bunovel.ErrNotFound) {
 		return goerrors.Join(ErrSlugExists, err)
 	}
-	if profileWithSameSlug != nil && profileWithSameSlug.ID != token.Token.Payload.ID {
+	if profileWithSameSlug != nil && profileWithSameSlug.ID != userID {
 		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSlug, ErrTaken)
 	}
 
-	if _, err := s.profileDAO.Update(ctx, &dao.ProfileModelCore{
-		Slug:     form.Slug,
-		Username: form.Username,
-	}, token.Token.Payload.ID, now); err != nil {
-		return goerrors.Join(ErrUpdateProfile, err)
-	}
-
 	return nil
 }
@@ -1,69 +1,172 @@
 package services
 
 import (
+	"context"
 	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/google/uuid"
 	"regexp"
 	"time"
 )
 
 var (
-	ErrTaken            = goerrors.New("this value is already used by another user")
-	ErrNoSignatureMatch = goerrors.New("no secret key match the current token signature")
-	ErrWrongPassword    = goerrors.New("wrong password")
+	ErrTaken                   = goerrors.New("this value is already used by another user")
+	ErrNoSignatureMatch        = goerrors.New("no secret key match the current token signature")
+	ErrUnknownSignatureKey     = goerrors.New("the token references an unknown signature key")
+	ErrWrongPassword           = goerrors.New("wrong password")
+	ErrRefreshTokenReused      = goerrors.New("the refresh token has already been used")
+	ErrTooManyAttempts         = goerrors.New("too many failed attempts")
+	ErrTOTPAlreadyEnabled      = goerrors.New("two-factor authentication is already enabled")
+	ErrTOTPNotEnrolled         = goerrors.New("two-factor authentication is not enrolled")
+	ErrWrongSecondFactorCode   = goerrors.New("wrong second factor code")
+	ErrPasskeyRegistered       = goerrors.New("this passkey is already registered")
+	ErrPasskeyRejected         = goerrors.New("the passkey could not be verified")
+	ErrUnsupportedPasswordHash = goerrors.New("unsupported password hash")
+	ErrValidationCodeExpired   = goerrors.New("the validation code has expired")
+	ErrAccountLocked           = goerrors.New("the account is locked")
+	ErrStepUpRequired          = goerrors.New("a recent authentication is required")
+	ErrAccountDeleted          = goerrors.New("the account is deleted")
+	ErrAccountSuspended        = goerrors.New("the account is suspended")
 
 	ErrMissingSignatureKeys      = goerrors.New("no signature key provided")
 	ErrMissingPasswordValidation = goerrors.New("you must provide either a code or an old password")
 	ErrMissingPendingValidation  = goerrors.New("no pending validation found on the user")
 
-	ErrInvalidToken          = goerrors.New("(data) invalid token")
-	ErrInvalidEmail          = goerrors.New("(data) invalid email")
-	ErrInvalidPassword       = goerrors.New("(data) invalid password")
-	ErrInvalidFirstName      = goerrors.New("(data) invalid first name")
-	ErrInvalidLastName       = goerrors.New("(data) invalid last name")
-	ErrInvalidSlug           = goerrors.New("(data) invalid slug")
-	ErrInvalidUsername       = goerrors.New("(data) invalid username")
-	ErrInvalidSex            = goerrors.New("(data) invalid sex")
-	ErrInvalidAge            = goerrors.New("(data) invalid age")
-	ErrInvalidSearchLimit    = goerrors.New("(data) invalid search limit")
-	ErrInvalidTokenHeader    = goerrors.New("(data) invalid token header")
-	ErrInvalidTokenPayload   = goerrors.New("(data) invalid token payload")
-	ErrInvalidTokenSignature = goerrors.New("(data) invalid token signature")
-	ErrInvalidValidationCode = goerrors.New("(data) invalid validation code")
+	ErrInvalidToken             = goerrors.New("(data) invalid token")
+	ErrInvalidEmail             = goerrors.New("(data) invalid email")
+	ErrInvalidPassword          = goerrors.New("(data) invalid password")
+	ErrInvalidFirstName         = goerrors.New("(data) invalid first name")
+	ErrInvalidLastName          = goerrors.New("(data) invalid last name")
+	ErrInvalidSlug              = goerrors.New("(data) invalid slug")
+	ErrInvalidUsername          = goerrors.New("(data) invalid username")
+	ErrInvalidSex               = goerrors.New("(data) invalid sex")
+	ErrInvalidAge               = goerrors.New("(data) invalid age")
+	ErrInvalidSearchLimit       = goerrors.New("(data) invalid search limit")
+	ErrInvalidTokenHeader       = goerrors.New("(data) invalid token header")
+	ErrInvalidTokenPayload      = goerrors.New("(data) invalid token payload")
+	ErrInvalidTokenSignature    = goerrors.New("(data) invalid token signature")
+	ErrInvalidValidationCode    = goerrors.New("(data) invalid validation code")
+	ErrInvalidRefreshToken      = goerrors.New("(data) invalid refresh token")
+	ErrInvalidUserID            = goerrors.New("(data) invalid user id")
+	ErrInvalidSignatureKey      = goerrors.New("(data) invalid signature key")
+	ErrInvalidSecondFactorCode  = goerrors.New("(data) invalid second factor code")
+	ErrInvalidMFAChallenge      = goerrors.New("(data) invalid mfa challenge")
+	ErrInvalidPasskey           = goerrors.New("(data) invalid passkey")
+	ErrInvalidWebAuthnChallenge = goerrors.New("(data) invalid webauthn challenge")
+	ErrInvalidLoginLink         = goerrors.New("(data) invalid login link")
+	ErrInvalidDataExport        = goerrors.New("(data) invalid data export")
+	ErrInvalidSuspensionReason  = goerrors.New("(data) invalid suspension reason")
+	ErrInvalidSuspensionEnd     = goerrors.New("(data) invalid suspension end date")
+	ErrInvalidAuditPeriod       = goerrors.New("(data) invalid audit period")
+	ErrInvalidUserLookup        = goerrors.New("(data) exactly one of id, email or slug is required")
 
 	ErrIntrospectToken       = goerrors.New("(dep) failed to introspect token")
+	ErrRotateSignatureKeys   = goerrors.New("(dep) failed to rotate signature keys")
 	ErrCheckPassword         = goerrors.New("(dep) failed to check password")
 	ErrValidateToken         = goerrors.New("(dep) failed to validate token")
 	ErrVerifyValidationCode  = goerrors.New("(dep) failed to verify validation code")
 	ErrUpdateUserPermissions = goerrors.New("(dep) failed to update user permissions")
+	ErrCheckSecondFactor     = goerrors.New("(dep) failed to check second factor")
+	ErrCheckPasswordPolicy   = goerrors.New("(dep) failed to check password policy")
+	ErrCheckStepUp           = goerrors.New("(dep) failed to check step-up authentication")
 
-	ErrCancelNewEmail           = goerrors.New("(dao) failed to cancel new email")
-	ErrEmailExists              = goerrors.New("(dao) failed to check if email exists")
-	ErrGetProfileBySlug         = goerrors.New("(dao) failed to retrieve profile by slug")
-	ErrGetTokenStatus           = goerrors.New("(dao) failed to get token status")
-	ErrListUsers                = goerrors.New("(dao) failed to list users")
-	ErrGetCredentialsByEmail    = goerrors.New("(dao) failed to retrieve credentials by email")
-	ErrGenerateToken            = goerrors.New("(dao) failed to generate token")
-	ErrGetIdentity              = goerrors.New("(dao) failed to get identity")
-	ErrGetCredentials           = goerrors.New("(dao) failed to get credentials")
-	ErrGetProfile               = goerrors.New("(dao) failed to get profile")
-	ErrSlugExists               = goerrors.New("(dao) failed to check if slug exists")
-	ErrGenerateValidationCode   = goerrors.New("(dao) failed to generate validation code")
-	ErrHashPassword             = goerrors.New("(dao) failed to hash password")
-	ErrCreateUser               = goerrors.New("(dao) failed to create user")
-	ErrSendValidationEmail      = goerrors.New("(dao) failed to send validation email")
-	ErrUpdateEmailValidation    = goerrors.New("(dao) failed to update email validation")
-	ErrUpdateNewEmailValidation = goerrors.New("(dao) failed to update new email validation")
-	ErrResetPassword            = goerrors.New("(dao) failed to reset password")
-	ErrGenerateSignatureKey     = goerrors.New("(dao) failed to generate signature key")
-	ErrWriteSignatureKey        = goerrors.New("(dao) failed to write signature key")
-	ErrListSignatureKeys        = goerrors.New("(dao) failed to list signature keys")
-	ErrDeleteSignatureKey       = goerrors.New("(dao) failed to delete signature key")
-	ErrSearchUsers              = goerrors.New("(dao) failed to search users")
-	ErrUpdateEmail              = goerrors.New("(dao) failed to update email")
-	ErrUpdateIdentity           = goerrors.New("(dao) failed to update identity")
-	ErrUpdatePassword           = goerrors.New("(dao) failed to update password")
-	ErrUpdateProfile            = goerrors.New("(dao) failed to update profile")
-	ErrValidateEmail            = goerrors.New("(dao) failed to validate email")
+	ErrCancelNewEmail            = goerrors.New("(dao) failed to cancel new email")
+	ErrEmailExists               = goerrors.New("(dao) failed to check if email exists")
+	ErrGetProfileBySlug          = goerrors.New("(dao) failed to retrieve profile by slug")
+	ErrGetTokenStatus            = goerrors.New("(dao) failed to get token status")
+	ErrListUsers                 = goerrors.New("(dao) failed to list users")
+	ErrGetCredentialsByEmail     = goerrors.New("(dao) failed to retrieve credentials by email")
+	ErrGenerateToken             = goerrors.New("(dao) failed to generate token")
+	ErrGetIdentity               = goerrors.New("(dao) failed to get identity")
+	ErrGetCredentials            = goerrors.New("(dao) failed to get credentials")
+	ErrGetProfile                = goerrors.New("(dao) failed to get profile")
+	ErrSlugExists                = goerrors.New("(dao) failed to check if slug exists")
+	ErrGenerateValidationCode    = goerrors.New("(dao) failed to generate validation code")
+	ErrHashPassword              = goerrors.New("(dao) failed to hash password")
+	ErrCreateUser                = goerrors.New("(dao) failed to create user")
+	ErrSendValidationEmail       = goerrors.New("(dao) failed to send validation email")
+	ErrUpdateEmailValidation     = goerrors.New("(dao) failed to update email validation")
+	ErrUpdateNewEmailValidation  = goerrors.New("(dao) failed to update new email validation")
+	ErrResetPassword             = goerrors.New("(dao) failed to reset password")
+	ErrGenerateSignatureKey      = goerrors.New("(dao) failed to generate signature key")
+	ErrWriteSignatureKey         = goerrors.New("(dao) failed to write signature key")
+	ErrListSignatureKeys         = goerrors.New("(dao) failed to list signature keys")
+	ErrDeleteSignatureKey        = goerrors.New("(dao) failed to delete signature key")
+	ErrRefreshSignatureKeys      = goerrors.New("(dao) failed to refresh signature keys")
+	ErrSearchUsers               = goerrors.New("(dao) failed to search users")
+	ErrUpdateEmail               = goerrors.New("(dao) failed to update email")
+	ErrUpdateIdentity            = goerrors.New("(dao) failed to update identity")
+	ErrUpdatePassword            = goerrors.New("(dao) failed to update password")
+	ErrUpdateProfile             = goerrors.New("(dao) failed to update profile")
+	ErrValidateEmail             = goerrors.New("(dao) failed to validate email")
+	ErrCreateRefreshToken        = goerrors.New("(dao) failed to create refresh token")
+	ErrGetRefreshToken           = goerrors.New("(dao) failed to get refresh token")
+	ErrUseRefreshToken           = goerrors.New("(dao) failed to use refresh token")
+	ErrRevokeTokenFamily         = goerrors.New("(dao) failed to revoke token family")
+	ErrCheckTokenFamily          = goerrors.New("(dao) failed to check token family")
+	ErrCheckTokenRevocation      = goerrors.New("(dao) failed to check token revocation")
+	ErrRevokeToken               = goerrors.New("(dao) failed to revoke token")
+	ErrRevokeUserTokens          = goerrors.New("(dao) failed to revoke user tokens")
+	ErrPruneRevokedTokens        = goerrors.New("(dao) failed to prune revoked tokens")
+	ErrCreateSession             = goerrors.New("(dao) failed to create session")
+	ErrGetSession                = goerrors.New("(dao) failed to get session")
+	ErrListSessions              = goerrors.New("(dao) failed to list sessions")
+	ErrRevokeSession             = goerrors.New("(dao) failed to revoke session")
+	ErrUpdateSessionLastSeen     = goerrors.New("(dao) failed to update session last seen date")
+	ErrUpdateSessionToken        = goerrors.New("(dao) failed to update session token")
+	ErrRevokeSignatureKey        = goerrors.New("(dao) failed to revoke signature key")
+	ErrReadSignatureKey          = goerrors.New("(dao) failed to read signature key")
+	ErrCountSessions             = goerrors.New("(dao) failed to count sessions")
+	ErrGetLoginFailures          = goerrors.New("(dao) failed to get login failures")
+	ErrRecordLoginFailure        = goerrors.New("(dao) failed to record login failure")
+	ErrClearLoginFailures        = goerrors.New("(dao) failed to clear login failures")
+	ErrPruneLoginFailures        = goerrors.New("(dao) failed to prune login failures")
+	ErrGenerateTOTPSecret        = goerrors.New("(dao) failed to generate totp secret")
+	ErrGenerateRecoveryCode      = goerrors.New("(dao) failed to generate recovery code")
+	ErrEnrollTOTP                = goerrors.New("(dao) failed to enroll totp")
+	ErrGetTOTP                   = goerrors.New("(dao) failed to get totp")
+	ErrConfirmTOTP               = goerrors.New("(dao) failed to confirm totp")
+	ErrDeleteTOTP                = goerrors.New("(dao) failed to delete totp")
+	ErrUseSecondFactorCode       = goerrors.New("(dao) failed to use second factor code")
+	ErrListRecoveryCodes         = goerrors.New("(dao) failed to list recovery codes")
+	ErrCreateMFAChallenge        = goerrors.New("(dao) failed to create mfa challenge")
+	ErrGetMFAChallenge           = goerrors.New("(dao) failed to get mfa challenge")
+	ErrUseMFAChallenge           = goerrors.New("(dao) failed to use mfa challenge")
+	ErrFailMFAChallenge          = goerrors.New("(dao) failed to record mfa challenge failure")
+	ErrGenerateWebAuthnChallenge = goerrors.New("(dao) failed to generate webauthn challenge")
+	ErrCreateWebAuthnChallenge   = goerrors.New("(dao) failed to create webauthn challenge")
+	ErrUseWebAuthnChallenge      = goerrors.New("(dao) failed to use webauthn challenge")
+	ErrCreatePasskey             = goerrors.New("(dao) failed to create passkey")
+	ErrGetPasskey                = goerrors.New("(dao) failed to get passkey")
+	ErrListPasskeys              = goerrors.New("(dao) failed to list passkeys")
+	ErrUsePasskey                = goerrors.New("(dao) failed to use passkey")
+	ErrCreateLoginLink           = goerrors.New("(dao) failed to create login link")
+	ErrGetLoginLink              = goerrors.New("(dao) failed to get login link")
+	ErrUseLoginLink              = goerrors.New("(dao) failed to use login link")
+	ErrSendLoginLinkEmail        = goerrors.New("(dao) failed to send login link email")
+	ErrCheckBreachedPassword     = goerrors.New("(dao) failed to check breached password")
+	ErrRotateSecurityStamp       = goerrors.New("(dao) failed to rotate security stamp")
+	ErrSendSecurityAlert         = goerrors.New("(dao) failed to send security alert")
+	ErrLockAccount               = goerrors.New("(dao) failed to lock account")
+	ErrUnlockAccount             = goerrors.New("(dao) failed to unlock account")
+	ErrListUserAgents            = goerrors.New("(dao) failed to list user agents")
+	ErrDeleteAccount             = goerrors.New("(dao) failed to delete account")
+	ErrCancelAccountDeletion     = goerrors.New("(dao) failed to cancel account deletion")
+	ErrSendDeletionEmail         = goerrors.New("(dao) failed to send deletion email")
+	ErrListDeletedUsers          = goerrors.New("(dao) failed to list deleted users")
+	ErrPurgeUsers                = goerrors.New("(dao) failed to purge users")
+	ErrBuildDataExport           = goerrors.New("(dao) failed to build data export")
+	ErrCreateDataExport          = goerrors.New("(dao) failed to create data export")
+	ErrGetDataExport             = goerrors.New("(dao) failed to get data export")
+	ErrUseDataExport             = goerrors.New("(dao) failed to use data export")
+	ErrPruneDataExports          = goerrors.New("(dao) failed to prune data exports")
+	ErrSendDataExportEmail       = goerrors.New("(dao) failed to send data export email")
+	ErrSuspendUser               = goerrors.New("(dao) failed to suspend user")
+	ErrUnsuspendUser             = goerrors.New("(dao) failed to unsuspend user")
+	ErrRecordAuditEvent          = goerrors.New("(dao) failed to record audit event")
+	ErrListSuspendedUsers        = goerrors.New("(dao) failed to list suspended users")
+	ErrListAuditEvents           = goerrors.New("(dao) failed to list audit events")
 
 	usernameRegexp = regexp.MustCompile(`^[\p{L}\p{N}\p{P}]+( ([\p{L}\p{N}\p{P}]+))*$`)
 	slugRegexp     = regexp.MustCompile(`^[a-z\d]+(-[a-z\d]+)*$`)
@@ -71,17 +174,27 @@ var (
 )
 
 const (
-	MinEmailLength    = 3
-	MaxEmailLength    = 128
-	MinPasswordLength = 2
-	MaxPasswordLength = 256
-	MaxSlugLength     = 64
-	MaxNameLength     = 32
-	MaxUsernameLength = 64
-	MinAge            = 16
-	MaxAge            = 150
+	MinEmailLength            = 3
+	MaxEmailLength            = 128
+	MinPasswordLength         = 2
+	MaxPasswordLength         = 256
+	MaxSlugLength             = 64
+	MaxNameLength             = 32
+	MaxUsernameLength         = 64
+	MaxSuspensionReasonLength = 1024
+	MinAge                    = 16
+	MaxAge                    = 150
+
+	MaxUserAgentLength = 512
+	MaxIPLength        = 64
 )
 
+// validationCodeExpired returns true if a validation code, issued at the given time, is no longer valid. Codes without
+// an issue time are considered expired.
+func validationCodeExpired(issuedAt *time.Time, ttl time.Duration, now time.Time) bool {
+	return issuedAt == nil || !now.Before(issuedAt.Add(ttl))
+}
+
 func getUserAge(birthday, now time.Time) int {
 	return now.In(birthday.Location()).AddDate(
 		-birthday.Year(),
@@ -93,3 +206,35 @@ func getUserAge(birthday, now time.Time) int {
 		-birthday.Day()+1,
 	).Year()
 }
+
+// truncate cuts a string to the given number of runes. It is used for informative values sent by the client, that
+// should not fail a request because they are too long.
+func truncate(src string, max int) string {
+	runes := []rune(src)
+	if len(runes) <= max {
+		return src
+	}
+
+	return string(runes[:max])
+}
+
+// newAuditEvent creates an audit event about the given user, performed from the given device. Actions performed by
+// the user themselves have no actor.
+func newAuditEvent(kind dao.AuditEventKind, userID uuid.UUID, client models.ClientInfo) *dao.AuditEventModelCore {
+	return &dao.AuditEventModelCore{
+		Kind:      kind,
+		UserID:    userID,
+		IP:        truncate(client.IP, MaxIPLength),
+		UserAgent: truncate(client.UserAgent, MaxUserAgentLength),
+	}
+}
+
+// recordAuditEvent appends an event to the security audit log. When the recorder is bound to a transaction, the event
+// is only saved if the transaction is committed.
+func recordAuditEvent(ctx context.Context, recorder dao.AuditRecorder, event *dao.AuditEventModelCore, now time.Time) error {
+	if _, err := recorder.RecordAuditEvent(ctx, event, uuid.New(), now); err != nil {
+		return goerrors.Join(ErrRecordAuditEvent, err)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/ed25519"
 	"crypto/x509"
 	"fmt"
+	"github.com/a-novel/auth-service/pkg/services"
 	goframework "github.com/a-novel/go-framework"
 	"golang.org/x/crypto/bcrypt"
 	"time"
@@ -24,6 +25,25 @@ var (
 
 	publicValidationCode  string
 	privateValidationCode string
+
+	recoveryCode       string
+	recoveryCodeHashed string
+
+	// passwordHasher matches the parameters of passwordEncrypted, so it is never outdated.
+	passwordHasher = services.NewPasswordHasher(services.PasswordHashing{
+		Algorithm:  services.PasswordAlgorithmBcrypt,
+		BcryptCost: bcrypt.DefaultCost,
+	})
+
+	// loginThrottle is used by the services that check the secrets of a user.
+	loginThrottle = services.LoginThrottle{
+		FreeFailures:  3,
+		BaseLockout:   time.Minute,
+		MaxLockout:    10 * time.Minute,
+		AccountWindow: 24 * time.Hour,
+		IPMaxFailures: 20,
+		IPWindow:      time.Hour,
+	}
 )
 
 func init() {
@@ -38,6 +58,11 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+
+	recoveryCode, recoveryCodeHashed, err = services.GenerateRecoveryCode()
+	if err != nil {
+		panic(err)
+	}
 }
 
 func keyFromBytes(b []byte) ed25519.PrivateKey {
@@ -4,6 +4,7 @@ import (
 	"context"
 	goerrors "errors"
 	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
 	apiclients "github.com/a-novel/go-apis/clients"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/google/uuid"
@@ -11,25 +12,28 @@ import (
 )
 
 type ValidateEmailService interface {
-	ValidateEmail(ctx context.Context, id uuid.UUID, code string, now time.Time) error
+	ValidateEmail(ctx context.Context, id uuid.UUID, code string, client models.ClientInfo, now time.Time) error
 }
 
 func NewValidateEmailService(
 	credentialsDAO dao.CredentialsRepository,
 	permissionsClient apiclients.PermissionsClient,
+	ttl time.Duration,
 ) ValidateEmailService {
 	return &validateEmailServiceImpl{
 		credentialsDAO:    credentialsDAO,
 		permissionsClient: permissionsClient,
+		ttl:               ttl,
 	}
 }
 
 type validateEmailServiceImpl struct {
 	credentialsDAO    dao.CredentialsRepository
 	permissionsClient apiclients.PermissionsClient
+	ttl               time.Duration
 }
 
-func (s *validateEmailServiceImpl) ValidateEmail(ctx context.Context, id uuid.UUID, code string, now time.Time) error {
+func (s *validateEmailServiceImpl) ValidateEmail(ctx context.Context, id uuid.UUID, code string, client models.ClientInfo, now time.Time) error {
 	credentials, err := s.credentialsDAO.GetCredentials(ctx, id)
 	if err != nil {
 		return goerrors.Join(ErrGetCredentials, err)
@@ -46,14 +50,35 @@ func (s *validateEmailServiceImpl) ValidateEmail(ctx context.Context, id uuid.UU
 	if !ok {
 		return goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidValidationCode)
 	}
+	if validationCodeExpired(credentials.Email.ValidationIssuedAt, s.ttl, now) {
+		return goerrors.Join(goframework.ErrInvalidCredentials, ErrValidationCodeExpired)
+	}
 
-	err = s.credentialsDAO.RunInTx(ctx, func(ctx context.Context, txClient dao.CredentialsRepository) error {
-		_, err = txClient.ValidateEmail(ctx, id, now)
-		if err != nil {
+	return validateEmail(
+		ctx, s.credentialsDAO, s.permissionsClient, id, credentials.Email.Validation,
+		newAuditEvent(dao.AuditEventEmailValidated, id, client),
+		now,
+	)
+}
+
+// validateEmail consumes the pending validation code of the main email of a user, and grants them the permissions of
+// a validated account. The permissions are updated and the audit event is recorded within the transaction, so the
+// email is not marked as validated if either fails.
+func validateEmail(
+	ctx context.Context,
+	credentialsDAO dao.CredentialsRepository,
+	permissionsClient apiclients.PermissionsClient,
+	id uuid.UUID,
+	code string,
+	event *dao.AuditEventModelCore,
+	now time.Time,
+) error {
+	return credentialsDAO.RunInTx(ctx, func(ctx context.Context, txClient dao.CredentialsRepository) error {
+		if _, err := txClient.ValidateEmail(ctx, id, code, now); err != nil {
 			return goerrors.Join(ErrValidateEmail, err)
 		}
 
-		err = s.permissionsClient.SetUserPermissions(ctx, apiclients.SetUserPermissionsForm{
+		err := permissionsClient.SetUserPermissions(ctx, apiclients.SetUserPermissionsForm{
 			UserID:    id,
 			SetFields: []string{apiclients.FieldValidatedAccount},
 		})
@@ -61,11 +86,6 @@ func (s *validateEmailServiceImpl) ValidateEmail(ctx context.Context, id uuid.UU
 			return goerrors.Join(ErrUpdateUserPermissions, err)
 		}
 
-		return nil
+		return recordAuditEvent(ctx, txClient, event, now)
 	})
-	if err != nil {
-		return err
-	}
-
-	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/a-novel/auth-service/pkg/dao"
 	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
 	"github.com/a-novel/auth-service/pkg/services"
 	apiclients "github.com/a-novel/go-apis/clients"
 	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
@@ -16,6 +17,12 @@ import (
 )
 
 func TestValidateEmail(t *testing.T) {
+	ttl := 24 * time.Hour
+	issuedAt := baseTime.Add(-time.Hour)
+	expiredAt := baseTime.Add(-ttl)
+
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
 	data := []struct {
 		name string
 
@@ -32,6 +39,9 @@ func TestValidateEmail(t *testing.T) {
 		shouldCallUpdate bool
 		updateErr        error
 
+		shouldCallRecord bool
+		recordErr        error
+
 		expectErr error
 	}{
 		{
@@ -41,11 +51,12 @@ func TestValidateEmail(t *testing.T) {
 			now:  baseTime,
 			dao: &dao.CredentialsModel{
 				CredentialsModelCore: dao.CredentialsModelCore{
-					Email: dao.Email{User: "user", Domain: "domain", Validation: privateValidationCode},
+					Email: dao.Email{User: "user", Domain: "domain", Validation: privateValidationCode, ValidationIssuedAt: &issuedAt},
 				},
 			},
 			shouldCallUpdate:            true,
 			shouldCallPermissionsClient: true,
+			shouldCallRecord:            true,
 		},
 		{
 			name: "Error/NoPendingValidation",
@@ -66,13 +77,25 @@ func TestValidateEmail(t *testing.T) {
 			now:  baseTime,
 			dao: &dao.CredentialsModel{
 				CredentialsModelCore: dao.CredentialsModelCore{
-					Email: dao.Email{User: "user", Domain: "domain", Validation: privateValidationCode},
+					Email: dao.Email{User: "user", Domain: "domain", Validation: privateValidationCode, ValidationIssuedAt: &issuedAt},
 				},
 			},
 			expectErr: goframework.ErrInvalidCredentials,
 		},
 		{
-			name: "Error/UpdateFailure",
+			name: "Error/CodeExpired",
+			id:   goframework.NumberUUID(1),
+			code: publicValidationCode,
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain", Validation: privateValidationCode, ValidationIssuedAt: &expiredAt},
+				},
+			},
+			expectErr: services.ErrValidationCodeExpired,
+		},
+		{
+			name: "Error/CodeWithoutIssueTime",
 			id:   goframework.NumberUUID(1),
 			code: publicValidationCode,
 			now:  baseTime,
@@ -81,6 +104,18 @@ func TestValidateEmail(t *testing.T) {
 					Email: dao.Email{User: "user", Domain: "domain", Validation: privateValidationCode},
 				},
 			},
+			expectErr: services.ErrValidationCodeExpired,
+		},
+		{
+			name: "Error/UpdateFailure",
+			id:   goframework.NumberUUID(1),
+			code: publicValidationCode,
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain", Validation: privateValidationCode, ValidationIssuedAt: &issuedAt},
+				},
+			},
 			shouldCallUpdate: true,
 			updateErr:        fooErr,
 			expectErr:        fooErr,
@@ -93,6 +128,22 @@ func TestValidateEmail(t *testing.T) {
 			daoErr:    fooErr,
 			expectErr: fooErr,
 		},
+		{
+			name: "Error/RecordAuditEventFailure",
+			id:   goframework.NumberUUID(1),
+			code: publicValidationCode,
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email: dao.Email{User: "user", Domain: "domain", Validation: privateValidationCode, ValidationIssuedAt: &issuedAt},
+				},
+			},
+			shouldCallUpdate:            true,
+			shouldCallPermissionsClient: true,
+			shouldCallRecord:            true,
+			recordErr:                   fooErr,
+			expectErr:                   fooErr,
+		},
 		{
 			name: "Error/UpdatePermissionsFailure",
 			id:   goframework.NumberUUID(1),
@@ -100,7 +151,7 @@ func TestValidateEmail(t *testing.T) {
 			now:  baseTime,
 			dao: &dao.CredentialsModel{
 				CredentialsModelCore: dao.CredentialsModelCore{
-					Email: dao.Email{User: "user", Domain: "domain", Validation: privateValidationCode},
+					Email: dao.Email{User: "user", Domain: "domain", Validation: privateValidationCode, ValidationIssuedAt: &issuedAt},
 				},
 			},
 			shouldCallUpdate:            true,
@@ -121,7 +172,7 @@ func TestValidateEmail(t *testing.T) {
 
 			if d.shouldCallUpdate {
 				credentialsDAO.
-					On("ValidateEmail", context.Background(), d.id, d.now).
+					On("ValidateEmail", context.Background(), d.id, privateValidationCode, d.now).
 					Return(nil, d.updateErr)
 
 				// Execute the actual method, but call the mocks inside of it.
@@ -141,8 +192,19 @@ func TestValidateEmail(t *testing.T) {
 					Return(d.permissionsClientErr)
 			}
 
-			service := services.NewValidateEmailService(credentialsDAO, permissionsClient)
-			err := service.ValidateEmail(context.Background(), d.id, d.code, d.now)
+			if d.shouldCallRecord {
+				credentialsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:      dao.AuditEventEmailValidated,
+						UserID:    d.id,
+						IP:        client.IP,
+						UserAgent: client.UserAgent,
+					}, mock.Anything, d.now).
+					Return(nil, d.recordErr)
+			}
+
+			service := services.NewValidateEmailService(credentialsDAO, permissionsClient, ttl)
+			err := service.ValidateEmail(context.Background(), d.id, d.code, client, d.now)
 
 			require.ErrorIs(t, err, d.expectErr)
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	goerrors "errors"
 	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
 	apiclients "github.com/a-novel/go-apis/clients"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/google/uuid"
@@ -11,25 +12,28 @@ import (
 )
 
 type ValidateNewEmailService interface {
-	ValidateNewEmail(ctx context.Context, id uuid.UUID, code string, now time.Time) error
+	ValidateNewEmail(ctx context.Context, id uuid.UUID, code string, client models.ClientInfo, now time.Time) error
 }
 
 func NewValidateNewEmailService(
 	credentialsDAO dao.CredentialsRepository,
 	permissionsClient apiclients.PermissionsClient,
+	ttl time.Duration,
 ) ValidateNewEmailService {
 	return &validateNewEmailServiceImpl{
 		credentialsDAO:    credentialsDAO,
 		permissionsClient: permissionsClient,
+		ttl:               ttl,
 	}
 }
 
 type validateNewEmailServiceImpl struct {
 	credentialsDAO    dao.CredentialsRepository
 	permissionsClient apiclients.PermissionsClient
+	ttl               time.Duration
 }
 
-func (s *validateNewEmailServiceImpl) ValidateNewEmail(ctx context.Context, id uuid.UUID, code string, now time.Time) error {
+func (s *validateNewEmailServiceImpl) ValidateNewEmail(ctx context.Context, id uuid.UUID, code string, client models.ClientInfo, now time.Time) error {
 	credentials, err := s.credentialsDAO.GetCredentials(ctx, id)
 	if err != nil {
 		return goerrors.Join(ErrGetCredentials, err)
@@ -46,9 +50,13 @@ func (s *validateNewEmailServiceImpl) ValidateNewEmail(ctx context.Context, id u
 	if !ok {
 		return goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidValidationCode)
 	}
+	if validationCodeExpired(credentials.NewEmail.ValidationIssuedAt, s.ttl, now) {
+		return goerrors.Join(goframework.ErrInvalidCredentials, ErrValidationCodeExpired)
+	}
 
 	err = s.credentialsDAO.RunInTx(ctx, func(ctx context.Context, txClient dao.CredentialsRepository) error {
-		_, err = txClient.ValidateNewEmail(ctx, id, now)
+		// The primary email changed, so tokens issued before are revoked with a new security stamp.
+		_, err = txClient.ValidateNewEmail(ctx, id, credentials.NewEmail.Validation, uuid.New(), now)
 		if err != nil {
 			return goerrors.Join(ErrValidateEmail, err)
 		}
@@ -61,7 +69,7 @@ func (s *validateNewEmailServiceImpl) ValidateNewEmail(ctx context.Context, id u
 			return goerrors.Join(ErrUpdateUserPermissions, err)
 		}
 
-		return nil
+		return recordAuditEvent(ctx, txClient, newAuditEvent(dao.AuditEventEmailChanged, id, client), now)
 	})
 	if err != nil {
 		return err
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/a-novel/auth-service/pkg/dao"
 	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
 	"github.com/a-novel/auth-service/pkg/services"
 	apiclients "github.com/a-novel/go-apis/clients"
 	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
@@ -16,6 +17,12 @@ import (
 )
 
 func TestValidateNewEmail(t *testing.T) {
+	ttl := 24 * time.Hour
+	issuedAt := baseTime.Add(-time.Hour)
+	expiredAt := baseTime.Add(-ttl)
+
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
 	data := []struct {
 		name string
 
@@ -32,6 +39,9 @@ func TestValidateNewEmail(t *testing.T) {
 		shouldCallUpdate bool
 		updateErr        error
 
+		shouldCallRecord bool
+		recordErr        error
+
 		expectErr error
 	}{
 		{
@@ -42,11 +52,12 @@ func TestValidateNewEmail(t *testing.T) {
 			dao: &dao.CredentialsModel{
 				CredentialsModelCore: dao.CredentialsModelCore{
 					Email:    dao.Email{User: "user", Domain: "domain"},
-					NewEmail: dao.Email{User: "new-user", Domain: "domain", Validation: privateValidationCode},
+					NewEmail: dao.Email{User: "new-user", Domain: "domain", Validation: privateValidationCode, ValidationIssuedAt: &issuedAt},
 				},
 			},
 			shouldCallUpdate:            true,
 			shouldCallPermissionsClient: true,
+			shouldCallRecord:            true,
 		},
 		{
 			name: "Error/NoPendingValidation",
@@ -68,13 +79,26 @@ func TestValidateNewEmail(t *testing.T) {
 			dao: &dao.CredentialsModel{
 				CredentialsModelCore: dao.CredentialsModelCore{
 					Email:    dao.Email{User: "user", Domain: "domain"},
-					NewEmail: dao.Email{User: "new-user", Domain: "domain", Validation: privateValidationCode},
+					NewEmail: dao.Email{User: "new-user", Domain: "domain", Validation: privateValidationCode, ValidationIssuedAt: &issuedAt},
 				},
 			},
 			expectErr: goframework.ErrInvalidCredentials,
 		},
 		{
-			name: "Error/UpdateFailure",
+			name: "Error/CodeExpired",
+			id:   goframework.NumberUUID(1),
+			code: publicValidationCode,
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain"},
+					NewEmail: dao.Email{User: "new-user", Domain: "domain", Validation: privateValidationCode, ValidationIssuedAt: &expiredAt},
+				},
+			},
+			expectErr: services.ErrValidationCodeExpired,
+		},
+		{
+			name: "Error/CodeWithoutIssueTime",
 			id:   goframework.NumberUUID(1),
 			code: publicValidationCode,
 			now:  baseTime,
@@ -84,6 +108,19 @@ func TestValidateNewEmail(t *testing.T) {
 					NewEmail: dao.Email{User: "new-user", Domain: "domain", Validation: privateValidationCode},
 				},
 			},
+			expectErr: services.ErrValidationCodeExpired,
+		},
+		{
+			name: "Error/UpdateFailure",
+			id:   goframework.NumberUUID(1),
+			code: publicValidationCode,
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain"},
+					NewEmail: dao.Email{User: "new-user", Domain: "domain", Validation: privateValidationCode, ValidationIssuedAt: &issuedAt},
+				},
+			},
 			shouldCallUpdate: true,
 			updateErr:        fooErr,
 			expectErr:        fooErr,
@@ -96,6 +133,23 @@ func TestValidateNewEmail(t *testing.T) {
 			daoErr:    fooErr,
 			expectErr: fooErr,
 		},
+		{
+			name: "Error/RecordAuditEventFailure",
+			id:   goframework.NumberUUID(1),
+			code: publicValidationCode,
+			now:  baseTime,
+			dao: &dao.CredentialsModel{
+				CredentialsModelCore: dao.CredentialsModelCore{
+					Email:    dao.Email{User: "user", Domain: "domain"},
+					NewEmail: dao.Email{User: "new-user", Domain: "domain", Validation: privateValidationCode, ValidationIssuedAt: &issuedAt},
+				},
+			},
+			shouldCallUpdate:            true,
+			shouldCallPermissionsClient: true,
+			shouldCallRecord:            true,
+			recordErr:                   fooErr,
+			expectErr:                   fooErr,
+		},
 		{
 			name: "Error/UpdatePermissionsFailure",
 			id:   goframework.NumberUUID(1),
@@ -104,7 +158,7 @@ func TestValidateNewEmail(t *testing.T) {
 			dao: &dao.CredentialsModel{
 				CredentialsModelCore: dao.CredentialsModelCore{
 					Email:    dao.Email{User: "user", Domain: "domain"},
-					NewEmail: dao.Email{User: "new-user", Domain: "domain", Validation: privateValidationCode},
+					NewEmail: dao.Email{User: "new-user", Domain: "domain", Validation: privateValidationCode, ValidationIssuedAt: &issuedAt},
 				},
 			},
 			shouldCallUpdate:            true,
@@ -125,7 +179,9 @@ func TestValidateNewEmail(t *testing.T) {
 
 			if d.shouldCallUpdate {
 				credentialsDAO.
-					On("ValidateNewEmail", context.Background(), d.id, d.now).
+					On("ValidateNewEmail", context.Background(), d.id, privateValidationCode, mock.MatchedBy(func(stamp uuid.UUID) bool {
+						return stamp != uuid.Nil
+					}), d.now).
 					Return(nil, d.updateErr)
 
 				// Execute the actual method, but call the mocks inside of it.
@@ -145,8 +201,19 @@ func TestValidateNewEmail(t *testing.T) {
 					Return(d.permissionsClientErr)
 			}
 
-			service := services.NewValidateNewEmailService(credentialsDAO, permissionsClient)
-			err := service.ValidateNewEmail(context.Background(), d.id, d.code, d.now)
+			if d.shouldCallRecord {
+				credentialsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:      dao.AuditEventEmailChanged,
+						UserID:    d.id,
+						IP:        client.IP,
+						UserAgent: client.UserAgent,
+					}, mock.Anything, d.now).
+					Return(nil, d.recordErr)
+			}
+
+			service := services.NewValidateNewEmailService(credentialsDAO, permissionsClient, ttl)
+			err := service.ValidateNewEmail(context.Background(), d.id, d.code, client, d.now)
 
 			require.ErrorIs(t, err, d.expectErr)
 
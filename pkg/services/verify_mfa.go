@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// MaxMFAChallengeFailures is the number of wrong codes after which a challenge is rejected. The user has to log in
+// again. Wrong codes are also recorded as login failures of the account, so logging in again does not reset the
+// throttle.
+const MaxMFAChallengeFailures = 5
+
+type VerifyMFAService interface {
+	// VerifyMFA exchanges a challenge issued by the login, along with a TOTP or recovery code, for a new session.
+	//
+	// Wrong codes are throttled along with the failed logins of the account. Once a limit is reached, a
+	// TooManyAttemptsError is returned, without checking the code.
+	VerifyMFA(ctx context.Context, challenge string, code string, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error)
+}
+
+func NewVerifyMFAService(
+	mfaChallengesDAO dao.MFAChallengesRepository,
+	totpDAO dao.TOTPRepository,
+	credentialsDAO dao.CredentialsRepository,
+	loginFailuresDAO dao.LoginFailuresRepository,
+	auditEventsDAO dao.AuditEventsRepository,
+	createSessionService CreateSessionService,
+	throttle LoginThrottle,
+) VerifyMFAService {
+	return &verifyMFAServiceImpl{
+		mfaChallengesDAO:     mfaChallengesDAO,
+		totpDAO:              totpDAO,
+		credentialsDAO:       credentialsDAO,
+		loginFailuresDAO:     loginFailuresDAO,
+		auditEventsDAO:       auditEventsDAO,
+		CreateSessionService: createSessionService,
+		throttle:             throttle,
+	}
+}
+
+type verifyMFAServiceImpl struct {
+	mfaChallengesDAO dao.MFAChallengesRepository
+	totpDAO          dao.TOTPRepository
+	credentialsDAO   dao.CredentialsRepository
+	loginFailuresDAO dao.LoginFailuresRepository
+	auditEventsDAO   dao.AuditEventsRepository
+	CreateSessionService
+	throttle LoginThrottle
+}
+
+// requireMFA returns true if the user has a second factor, and must pass an MFA challenge before getting a session.
+func requireMFA(ctx context.Context, totpDAO dao.TOTPRepository, passkeysDAO dao.PasskeysRepository, userID uuid.UUID) (bool, error) {
+	totp, err := totpDAO.Get(ctx, userID)
+	if err != nil && !goerrors.Is(err, bunovel.ErrNotFound) {
+		return false, goerrors.Join(ErrGetTOTP, err)
+	}
+
+	if totp != nil && totp.ConfirmedAt != nil {
+		return true, nil
+	}
+
+	passkeys, err := passkeysDAO.ListUserPasskeys(ctx, userID)
+	if err != nil {
+		return false, goerrors.Join(ErrListPasskeys, err)
+	}
+
+	return len(passkeys) > 0, nil
+}
+
+// getMFAChallenge reads a challenge issued by the login, and checks it can still be used. It also checks the login
+// throttle of the user, and returns the account their failures are recorded under.
+func getMFAChallenge(
+	ctx context.Context,
+	mfaChallengesDAO dao.MFAChallengesRepository,
+	credentialsDAO dao.CredentialsRepository,
+	loginFailuresDAO dao.LoginFailuresRepository,
+	throttle LoginThrottle,
+	challenge string,
+	client models.ClientInfo,
+	now time.Time,
+) (*dao.MFAChallengeModel, string, error) {
+	rawID, challengeCode, ok := strings.Cut(challenge, ".")
+	if !ok || challengeCode == "" {
+		return nil, "", goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidMFAChallenge)
+	}
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return nil, "", goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidMFAChallenge, err)
+	}
+
+	model, err := mfaChallengesDAO.Get(ctx, id)
+	if err != nil {
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return nil, "", goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidMFAChallenge, err)
+		}
+
+		return nil, "", goerrors.Join(ErrGetMFAChallenge, err)
+	}
+
+	ok, err = goframework.VerifyCode(challengeCode, model.TokenHashed)
+	if err != nil {
+		return nil, "", goerrors.Join(ErrVerifyValidationCode, err)
+	}
+	if !ok {
+		return nil, "", goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidMFAChallenge)
+	}
+
+	if model.UsedAt != nil || !model.ExpiresAt.After(now) || model.Failures >= MaxMFAChallengeFailures {
+		return nil, "", goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidMFAChallenge)
+	}
+
+	credentials, err := credentialsDAO.GetCredentials(ctx, model.UserID)
+	if err != nil {
+		// The account was purged since the challenge was issued.
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return nil, "", goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidMFAChallenge, err)
+		}
+
+		return nil, "", goerrors.Join(ErrGetCredentials, err)
+	}
+
+	account := loginAccount(credentials.Email)
+	if err := checkLoginThrottle(ctx, loginFailuresDAO, throttle, account, client.IP, now); err != nil {
+		return nil, "", err
+	}
+
+	return model, account, nil
+}
+
+// useMFAChallenge consumes a challenge once the second factor is verified, clears the login failures of the account,
+// and opens the session of the user.
+func useMFAChallenge(
+	ctx context.Context,
+	mfaChallengesDAO dao.MFAChallengesRepository,
+	loginFailuresDAO dao.LoginFailuresRepository,
+	createSessionService CreateSessionService,
+	model *dao.MFAChallengeModel,
+	account string,
+	client models.ClientInfo,
+	now time.Time,
+) (*models.UserTokenStatus, error) {
+	if _, err := mfaChallengesDAO.Use(ctx, model.ID, now); err != nil {
+		// Another request used the challenge in the meantime.
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidMFAChallenge, err)
+		}
+
+		return nil, goerrors.Join(ErrUseMFAChallenge, err)
+	}
+
+	if err := loginFailuresDAO.ClearAccount(ctx, account, now); err != nil {
+		return nil, goerrors.Join(ErrClearLoginFailures, err)
+	}
+
+	status, err := createSessionService.CreateSession(ctx, model.UserID, client, now)
+	if err != nil {
+		return nil, goerrors.Join(ErrCreateSession, err)
+	}
+
+	return status, nil
+}
+
+func (s *verifyMFAServiceImpl) VerifyMFA(ctx context.Context, challenge string, code string, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error) {
+	model, account, err := getMFAChallenge(
+		ctx, s.mfaChallengesDAO, s.credentialsDAO, s.loginFailuresDAO, s.throttle, challenge, client, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	totp, err := s.totpDAO.Get(ctx, model.UserID)
+	if err != nil {
+		// Two-factor authentication was disabled since the challenge was issued.
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidMFAChallenge, err)
+		}
+
+		return nil, goerrors.Join(ErrGetTOTP, err)
+	}
+	if totp.ConfirmedAt == nil {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidMFAChallenge)
+	}
+
+	if err := checkSecondFactor(ctx, s.totpDAO, totp, code, now); err != nil {
+		if goerrors.Is(err, goframework.ErrInvalidCredentials) {
+			if _, failErr := s.mfaChallengesDAO.Fail(ctx, model.ID, now); failErr != nil {
+				return nil, goerrors.Join(ErrFailMFAChallenge, failErr)
+			}
+
+			failErr := recordLoginFailure(
+				ctx, s.loginFailuresDAO, s.auditEventsDAO, account, model.UserID, loginFactorTOTP, client, now,
+			)
+			if failErr != nil {
+				return nil, failErr
+			}
+		}
+
+		return nil, err
+	}
+
+	return useMFAChallenge(
+		ctx, s.mfaChallengesDAO, s.loginFailuresDAO, s.CreateSessionService, model, account, client, now,
+	)
+}
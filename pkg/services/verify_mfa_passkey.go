@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	goframework "github.com/a-novel/go-framework"
+	"time"
+)
+
+type VerifyMFAPasskeyService interface {
+	// VerifyMFAPasskey exchanges a challenge issued by the login, along with the response of the authenticator to the
+	// options returned by BeginPasskeyMFAService, for a new session.
+	//
+	// Rejected assertions are throttled along with the failed logins of the account, like VerifyMFAService.
+	VerifyMFAPasskey(ctx context.Context, challenge string, form models.PasskeyAssertionForm, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error)
+}
+
+func NewVerifyMFAPasskeyService(
+	mfaChallengesDAO dao.MFAChallengesRepository,
+	webAuthnChallengesDAO dao.WebAuthnChallengesRepository,
+	passkeysDAO dao.PasskeysRepository,
+	credentialsDAO dao.CredentialsRepository,
+	loginFailuresDAO dao.LoginFailuresRepository,
+	auditEventsDAO dao.AuditEventsRepository,
+	createSessionService CreateSessionService,
+	rp WebAuthnRelyingParty,
+	throttle LoginThrottle,
+) VerifyMFAPasskeyService {
+	return &verifyMFAPasskeyServiceImpl{
+		mfaChallengesDAO:      mfaChallengesDAO,
+		webAuthnChallengesDAO: webAuthnChallengesDAO,
+		passkeysDAO:           passkeysDAO,
+		credentialsDAO:        credentialsDAO,
+		loginFailuresDAO:      loginFailuresDAO,
+		auditEventsDAO:        auditEventsDAO,
+		CreateSessionService:  createSessionService,
+		rp:                    rp,
+		throttle:              throttle,
+	}
+}
+
+type verifyMFAPasskeyServiceImpl struct {
+	mfaChallengesDAO      dao.MFAChallengesRepository
+	webAuthnChallengesDAO dao.WebAuthnChallengesRepository
+	passkeysDAO           dao.PasskeysRepository
+	credentialsDAO        dao.CredentialsRepository
+	loginFailuresDAO      dao.LoginFailuresRepository
+	auditEventsDAO        dao.AuditEventsRepository
+	CreateSessionService
+	rp       WebAuthnRelyingParty
+	throttle LoginThrottle
+}
+
+func (s *verifyMFAPasskeyServiceImpl) VerifyMFAPasskey(ctx context.Context, challenge string, form models.PasskeyAssertionForm, client models.ClientInfo, now time.Time) (*models.UserTokenStatus, error) {
+	model, account, err := getMFAChallenge(
+		ctx, s.mfaChallengesDAO, s.credentialsDAO, s.loginFailuresDAO, s.throttle, challenge, client, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	passkey, err := verifyPasskeyAssertion(
+		ctx, s.webAuthnChallengesDAO, s.passkeysDAO, s.rp, form, dao.WebAuthnCeremonyMFA, now,
+	)
+	if err == nil && passkey.UserID != model.UserID {
+		err = goerrors.Join(goframework.ErrInvalidCredentials, ErrPasskeyRejected)
+	}
+	if err != nil {
+		if goerrors.Is(err, goframework.ErrInvalidCredentials) {
+			if _, failErr := s.mfaChallengesDAO.Fail(ctx, model.ID, now); failErr != nil {
+				return nil, goerrors.Join(ErrFailMFAChallenge, failErr)
+			}
+
+			failErr := recordLoginFailure(
+				ctx, s.loginFailuresDAO, s.auditEventsDAO, account, model.UserID, loginFactorPasskey, client, now,
+			)
+			if failErr != nil {
+				return nil, failErr
+			}
+		}
+
+		return nil, err
+	}
+
+	return useMFAChallenge(
+		ctx, s.mfaChallengesDAO, s.loginFailuresDAO, s.CreateSessionService, model, account, client, now,
+	)
+}
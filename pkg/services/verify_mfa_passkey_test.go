@@ -0,0 +1,434 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestVerifyMFAPasskey(t *testing.T) {
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
+	challenge := goframework.NumberUUID(1).String() + "." + publicValidationCode
+
+	validChallenge := &dao.MFAChallengeModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		MFAChallengeModelCore: dao.MFAChallengeModelCore{
+			UserID:      goframework.NumberUUID(10),
+			TokenHashed: privateValidationCode,
+			ExpiresAt:   baseTime.Add(5 * time.Minute),
+		},
+	}
+
+	credentials := &dao.CredentialsModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, nil),
+		CredentialsModelCore: dao.CredentialsModelCore{
+			Email: dao.Email{User: "User", Domain: "domain.com"},
+		},
+	}
+
+	validWebAuthnChallenge := webAuthnChallengeModel(3, dao.WebAuthnCeremonyMFA, lo.ToPtr(goframework.NumberUUID(10)))
+
+	withPasskeyOwner := func(userID int) *dao.PasskeyModel {
+		model := passkeyModel(1)
+		model.UserID = goframework.NumberUUID(userID)
+		return model
+	}
+
+	data := []struct {
+		name string
+
+		challenge string
+		form      models.PasskeyAssertionForm
+		now       time.Time
+
+		shouldCallGetChallenge bool
+		getChallenge           *dao.MFAChallengeModel
+		getChallengeErr        error
+
+		shouldCallGetCredentials bool
+		getCredentials           *dao.CredentialsModel
+		getCredentialsErr        error
+
+		shouldCallCheckThrottle bool
+		getAccountFailures      *dao.LoginFailuresSummaryModel
+
+		shouldCallUseWebAuthnChallenge bool
+		useWebAuthnChallenge           *dao.WebAuthnChallengeModel
+		useWebAuthnChallengeErr        error
+
+		shouldCallGetPasskey bool
+		getPasskey           *dao.PasskeyModel
+
+		shouldCallUsePasskey bool
+		usePasskey           *dao.PasskeyModel
+
+		shouldCallFail bool
+		failErr        error
+
+		shouldCallRecordFailure bool
+		recordFailureErr        error
+
+		shouldCallRecordAuditEvent bool
+		recordAuditEventErr        error
+
+		shouldCallUse bool
+		useErr        error
+
+		shouldCallClearAccount bool
+		clearAccountErr        error
+
+		shouldCallCreateSession bool
+		createSession           *models.UserTokenStatus
+		createSessionErr        error
+
+		expect    *models.UserTokenStatus
+		expectErr error
+	}{
+		{
+			name:                           "Success",
+			challenge:                      challenge,
+			form:                           passkeyMFA,
+			now:                            baseTime,
+			shouldCallGetChallenge:         true,
+			shouldCallGetCredentials:       true,
+			getCredentials:                 credentials,
+			shouldCallCheckThrottle:        true,
+			getChallenge:                   validChallenge,
+			shouldCallUseWebAuthnChallenge: true,
+			useWebAuthnChallenge:           validWebAuthnChallenge,
+			shouldCallGetPasskey:           true,
+			getPasskey:                     passkeyModel(1),
+			shouldCallUsePasskey:           true,
+			usePasskey:                     passkeyModel(2),
+			shouldCallUse:                  true,
+			shouldCallClearAccount:         true,
+			shouldCallCreateSession:        true,
+			createSession:                  &models.UserTokenStatus{OK: true, RefreshToken: "refresh-token"},
+			expect:                         &models.UserTokenStatus{OK: true, RefreshToken: "refresh-token"},
+		},
+		{
+			name:                           "Error/CreateSessionFailure",
+			challenge:                      challenge,
+			form:                           passkeyMFA,
+			now:                            baseTime,
+			shouldCallGetChallenge:         true,
+			shouldCallGetCredentials:       true,
+			getCredentials:                 credentials,
+			shouldCallCheckThrottle:        true,
+			getChallenge:                   validChallenge,
+			shouldCallUseWebAuthnChallenge: true,
+			useWebAuthnChallenge:           validWebAuthnChallenge,
+			shouldCallGetPasskey:           true,
+			getPasskey:                     passkeyModel(1),
+			shouldCallUsePasskey:           true,
+			usePasskey:                     passkeyModel(2),
+			shouldCallUse:                  true,
+			shouldCallClearAccount:         true,
+			shouldCallCreateSession:        true,
+			createSessionErr:               fooErr,
+			expectErr:                      fooErr,
+		},
+		{
+			name:                           "Error/UsedConcurrently",
+			challenge:                      challenge,
+			form:                           passkeyMFA,
+			now:                            baseTime,
+			shouldCallGetChallenge:         true,
+			shouldCallGetCredentials:       true,
+			getCredentials:                 credentials,
+			shouldCallCheckThrottle:        true,
+			getChallenge:                   validChallenge,
+			shouldCallUseWebAuthnChallenge: true,
+			useWebAuthnChallenge:           validWebAuthnChallenge,
+			shouldCallGetPasskey:           true,
+			getPasskey:                     passkeyModel(1),
+			shouldCallUsePasskey:           true,
+			usePasskey:                     passkeyModel(2),
+			shouldCallUse:                  true,
+			useErr:                         bunovel.ErrNotFound,
+			expectErr:                      goframework.ErrInvalidCredentials,
+		},
+		{
+			// Another user passes their own second factor, started from a different login.
+			name:                           "Error/PasskeyOfAnotherUser",
+			challenge:                      challenge,
+			form:                           passkeyMFA,
+			now:                            baseTime,
+			shouldCallGetChallenge:         true,
+			shouldCallGetCredentials:       true,
+			getCredentials:                 credentials,
+			shouldCallCheckThrottle:        true,
+			getChallenge:                   validChallenge,
+			shouldCallUseWebAuthnChallenge: true,
+			useWebAuthnChallenge:           webAuthnChallengeModel(3, dao.WebAuthnCeremonyMFA, lo.ToPtr(goframework.NumberUUID(20))),
+			shouldCallGetPasskey:           true,
+			getPasskey:                     withPasskeyOwner(20),
+			shouldCallUsePasskey:           true,
+			usePasskey:                     withPasskeyOwner(20),
+			shouldCallFail:                 true,
+			shouldCallRecordFailure:        true,
+			shouldCallRecordAuditEvent:     true,
+			expectErr:                      services.ErrPasskeyRejected,
+		},
+		{
+			name:                           "Error/WebAuthnChallengeOfAnotherUser",
+			challenge:                      challenge,
+			form:                           passkeyMFA,
+			now:                            baseTime,
+			shouldCallGetChallenge:         true,
+			shouldCallGetCredentials:       true,
+			getCredentials:                 credentials,
+			shouldCallCheckThrottle:        true,
+			getChallenge:                   validChallenge,
+			shouldCallUseWebAuthnChallenge: true,
+			useWebAuthnChallenge:           validWebAuthnChallenge,
+			shouldCallGetPasskey:           true,
+			getPasskey:                     withPasskeyOwner(20),
+			shouldCallFail:                 true,
+			shouldCallRecordFailure:        true,
+			shouldCallRecordAuditEvent:     true,
+			expectErr:                      services.ErrPasskeyRejected,
+		},
+		{
+			name:                           "Error/SignCountRegression",
+			challenge:                      challenge,
+			form:                           passkeyMFA,
+			now:                            baseTime,
+			shouldCallGetChallenge:         true,
+			shouldCallGetCredentials:       true,
+			getCredentials:                 credentials,
+			shouldCallCheckThrottle:        true,
+			getChallenge:                   validChallenge,
+			shouldCallUseWebAuthnChallenge: true,
+			useWebAuthnChallenge:           validWebAuthnChallenge,
+			shouldCallGetPasskey:           true,
+			getPasskey:                     passkeyModel(2),
+			shouldCallFail:                 true,
+			shouldCallRecordFailure:        true,
+			shouldCallRecordAuditEvent:     true,
+			expectErr:                      services.ErrPasskeyRejected,
+		},
+		{
+			name:                           "Error/FailFailure",
+			challenge:                      challenge,
+			form:                           passkeyMFA,
+			now:                            baseTime,
+			shouldCallGetChallenge:         true,
+			shouldCallGetCredentials:       true,
+			getCredentials:                 credentials,
+			shouldCallCheckThrottle:        true,
+			getChallenge:                   validChallenge,
+			shouldCallUseWebAuthnChallenge: true,
+			useWebAuthnChallenge:           validWebAuthnChallenge,
+			shouldCallGetPasskey:           true,
+			getPasskey:                     passkeyModel(2),
+			shouldCallFail:                 true,
+			failErr:                        fooErr,
+			expectErr:                      fooErr,
+		},
+		{
+			name:                           "Error/LoginChallenge",
+			challenge:                      challenge,
+			form:                           passkeyMFA,
+			now:                            baseTime,
+			shouldCallGetChallenge:         true,
+			shouldCallGetCredentials:       true,
+			getCredentials:                 credentials,
+			shouldCallCheckThrottle:        true,
+			getChallenge:                   validChallenge,
+			shouldCallUseWebAuthnChallenge: true,
+			useWebAuthnChallenge:           webAuthnChallengeModel(3, dao.WebAuthnCeremonyLogin, nil),
+			shouldCallFail:                 true,
+			shouldCallRecordFailure:        true,
+			shouldCallRecordAuditEvent:     true,
+			expectErr:                      services.ErrInvalidWebAuthnChallenge,
+		},
+		{
+			name:                           "Error/UseWebAuthnChallengeFailure",
+			challenge:                      challenge,
+			form:                           passkeyMFA,
+			now:                            baseTime,
+			shouldCallGetChallenge:         true,
+			shouldCallGetCredentials:       true,
+			getCredentials:                 credentials,
+			shouldCallCheckThrottle:        true,
+			getChallenge:                   validChallenge,
+			shouldCallUseWebAuthnChallenge: true,
+			useWebAuthnChallengeErr:        fooErr,
+			expectErr:                      fooErr,
+		},
+		{
+			name:                     "Error/TooManyAttempts",
+			challenge:                challenge,
+			form:                     passkeyMFA,
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			getChallenge:             validChallenge,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			getAccountFailures:       &dao.LoginFailuresSummaryModel{Count: 3, FirstAt: baseTime, LastAt: baseTime},
+			expectErr:                services.ErrTooManyAttempts,
+		},
+		{
+			name:                     "Error/GetCredentialsFailure",
+			challenge:                challenge,
+			form:                     passkeyMFA,
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			getChallenge:             validChallenge,
+			shouldCallGetCredentials: true,
+			getCredentialsErr:        fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                   "Error/ChallengeExpired",
+			challenge:              challenge,
+			form:                   passkeyMFA,
+			now:                    baseTime.Add(10 * time.Minute),
+			shouldCallGetChallenge: true,
+			getChallenge:           validChallenge,
+			expectErr:              services.ErrInvalidMFAChallenge,
+		},
+		{
+			name:                   "Error/GetChallengeFailure",
+			challenge:              challenge,
+			form:                   passkeyMFA,
+			now:                    baseTime,
+			shouldCallGetChallenge: true,
+			getChallengeErr:        fooErr,
+			expectErr:              fooErr,
+		},
+		{
+			name:      "Error/InvalidChallenge",
+			challenge: "foo",
+			form:      passkeyMFA,
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			mfaChallengesDAO := daomocks.NewMFAChallengesRepository(t)
+			webAuthnChallengesDAO := daomocks.NewWebAuthnChallengesRepository(t)
+			passkeysDAO := daomocks.NewPasskeysRepository(t)
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			loginFailuresDAO := daomocks.NewLoginFailuresRepository(t)
+			auditEventsDAO := daomocks.NewAuditEventsRepository(t)
+			createSessionService := servicesmocks.NewCreateSessionService(t)
+
+			if d.shouldCallGetChallenge {
+				mfaChallengesDAO.
+					On("Get", context.Background(), goframework.NumberUUID(1)).
+					Return(d.getChallenge, d.getChallengeErr)
+			}
+
+			if d.shouldCallGetCredentials {
+				credentialsDAO.
+					On("GetCredentials", context.Background(), goframework.NumberUUID(10)).
+					Return(d.getCredentials, d.getCredentialsErr)
+			}
+
+			if d.shouldCallCheckThrottle {
+				loginFailuresDAO.
+					On("GetIPFailures", context.Background(), client.IP, d.now.Add(-loginThrottle.IPWindow)).
+					Return(&dao.LoginFailuresSummaryModel{}, nil)
+				loginFailuresDAO.
+					On("GetAccountFailures", context.Background(), "user@domain.com", d.now.Add(-loginThrottle.AccountWindow)).
+					Return(lo.Ternary(d.getAccountFailures != nil, d.getAccountFailures, &dao.LoginFailuresSummaryModel{}), nil)
+			}
+
+			if d.shouldCallUseWebAuthnChallenge {
+				webAuthnChallengesDAO.
+					On("Use", context.Background(), goframework.NumberUUID(3), d.now).
+					Return(d.useWebAuthnChallenge, d.useWebAuthnChallengeErr)
+			}
+
+			if d.shouldCallGetPasskey {
+				passkeysDAO.
+					On("GetByCredentialID", context.Background(), mustDecodeWebAuthn(passkeyCredentialID)).
+					Return(d.getPasskey, nil)
+			}
+
+			if d.shouldCallUsePasskey {
+				passkeysDAO.
+					On("Use", context.Background(), goframework.NumberUUID(100), uint32(2), d.now).
+					Return(d.usePasskey, nil)
+			}
+
+			if d.shouldCallFail {
+				mfaChallengesDAO.
+					On("Fail", context.Background(), goframework.NumberUUID(1), d.now).
+					Return(nil, d.failErr)
+			}
+
+			if d.shouldCallRecordFailure {
+				loginFailuresDAO.
+					On("Record", context.Background(), &dao.LoginFailureModelCore{
+						Account: "user@domain.com",
+						IP:      client.IP,
+					}, mock.Anything, d.now).
+					Return(nil, d.recordFailureErr)
+			}
+
+			if d.shouldCallRecordAuditEvent {
+				auditEventsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:      dao.AuditEventLoginFailed,
+						UserID:    goframework.NumberUUID(10),
+						IP:        client.IP,
+						UserAgent: client.UserAgent,
+						Details:   map[string]string{"email": "user@domain.com", "factor": "passkey"},
+					}, mock.Anything, d.now).
+					Return(nil, d.recordAuditEventErr)
+			}
+
+			if d.shouldCallUse {
+				mfaChallengesDAO.
+					On("Use", context.Background(), goframework.NumberUUID(1), d.now).
+					Return(nil, d.useErr)
+			}
+
+			if d.shouldCallClearAccount {
+				loginFailuresDAO.
+					On("ClearAccount", context.Background(), "user@domain.com", d.now).
+					Return(d.clearAccountErr)
+			}
+
+			if d.shouldCallCreateSession {
+				createSessionService.
+					On("CreateSession", context.Background(), goframework.NumberUUID(10), client, d.now).
+					Return(d.createSession, d.createSessionErr)
+			}
+
+			service := services.NewVerifyMFAPasskeyService(
+				mfaChallengesDAO, webAuthnChallengesDAO, passkeysDAO, credentialsDAO, loginFailuresDAO, auditEventsDAO,
+				createSessionService, webAuthnRP, loginThrottle,
+			)
+			res, err := service.VerifyMFAPasskey(context.Background(), d.challenge, d.form, client, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			mfaChallengesDAO.AssertExpectations(t)
+			webAuthnChallengesDAO.AssertExpectations(t)
+			passkeysDAO.AssertExpectations(t)
+			credentialsDAO.AssertExpectations(t)
+			loginFailuresDAO.AssertExpectations(t)
+			auditEventsDAO.AssertExpectations(t)
+			createSessionService.AssertExpectations(t)
+		})
+	}
+}
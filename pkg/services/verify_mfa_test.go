@@ -0,0 +1,536 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestVerifyMFA(t *testing.T) {
+	client := models.ClientInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1"}
+
+	challenge := goframework.NumberUUID(1).String() + "." + publicValidationCode
+
+	validChallenge := &dao.MFAChallengeModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		MFAChallengeModelCore: dao.MFAChallengeModelCore{
+			UserID:      goframework.NumberUUID(10),
+			TokenHashed: privateValidationCode,
+			ExpiresAt:   baseTime.Add(5 * time.Minute),
+		},
+	}
+
+	credentials := &dao.CredentialsModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, nil),
+		CredentialsModelCore: dao.CredentialsModelCore{
+			Email: dao.Email{User: "User", Domain: "domain.com"},
+		},
+	}
+
+	confirmed := &dao.TOTPModel{
+		Metadata:      bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, nil),
+		TOTPModelCore: dao.TOTPModelCore{Secret: totpSecret, ConfirmedAt: &baseTime},
+	}
+
+	data := []struct {
+		name string
+
+		challenge string
+		code      string
+		now       time.Time
+
+		shouldCallGetChallenge bool
+		getChallenge           *dao.MFAChallengeModel
+		getChallengeErr        error
+
+		shouldCallGetCredentials bool
+		getCredentials           *dao.CredentialsModel
+		getCredentialsErr        error
+
+		shouldCallCheckThrottle bool
+		getAccountFailures      *dao.LoginFailuresSummaryModel
+
+		shouldCallGetTOTP bool
+		getTOTP           *dao.TOTPModel
+		getTOTPErr        error
+
+		shouldCallUseStep bool
+		useStepErr        error
+
+		shouldCallFail bool
+		failErr        error
+
+		shouldCallRecordFailure bool
+		recordFailureErr        error
+
+		shouldCallRecordAuditEvent bool
+		recordAuditEventErr        error
+
+		shouldCallUse bool
+		useErr        error
+
+		shouldCallClearAccount bool
+		clearAccountErr        error
+
+		shouldCallCreateSession bool
+		createSession           *models.UserTokenStatus
+		createSessionErr        error
+
+		expect    *models.UserTokenStatus
+		expectErr error
+	}{
+		{
+			name:                     "Success",
+			challenge:                challenge,
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			getChallenge:             validChallenge,
+			shouldCallGetTOTP:        true,
+			getTOTP:                  confirmed,
+			shouldCallUseStep:        true,
+			shouldCallUse:            true,
+			shouldCallClearAccount:   true,
+			shouldCallCreateSession:  true,
+			createSession:            &models.UserTokenStatus{OK: true, RefreshToken: "refresh-token"},
+			expect:                   &models.UserTokenStatus{OK: true, RefreshToken: "refresh-token"},
+		},
+		{
+			name:                     "Error/CreateSessionFailure",
+			challenge:                challenge,
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			getChallenge:             validChallenge,
+			shouldCallGetTOTP:        true,
+			getTOTP:                  confirmed,
+			shouldCallUseStep:        true,
+			shouldCallUse:            true,
+			shouldCallClearAccount:   true,
+			shouldCallCreateSession:  true,
+			createSessionErr:         fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/UsedConcurrently",
+			challenge:                challenge,
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			getChallenge:             validChallenge,
+			shouldCallGetTOTP:        true,
+			getTOTP:                  confirmed,
+			shouldCallUseStep:        true,
+			shouldCallUse:            true,
+			useErr:                   bunovel.ErrNotFound,
+			expectErr:                goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                     "Error/UseFailure",
+			challenge:                challenge,
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			getChallenge:             validChallenge,
+			shouldCallGetTOTP:        true,
+			getTOTP:                  confirmed,
+			shouldCallUseStep:        true,
+			shouldCallUse:            true,
+			useErr:                   fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                       "Error/WrongCode",
+			challenge:                  challenge,
+			code:                       mustTOTPCode(baseTime.Add(-2 * services.TOTPPeriod)),
+			now:                        baseTime,
+			shouldCallGetChallenge:     true,
+			shouldCallGetCredentials:   true,
+			getCredentials:             credentials,
+			shouldCallCheckThrottle:    true,
+			getChallenge:               validChallenge,
+			shouldCallGetTOTP:          true,
+			getTOTP:                    confirmed,
+			shouldCallFail:             true,
+			shouldCallRecordFailure:    true,
+			shouldCallRecordAuditEvent: true,
+			expectErr:                  services.ErrWrongSecondFactorCode,
+		},
+		{
+			name:                     "Error/FailFailure",
+			challenge:                challenge,
+			code:                     mustTOTPCode(baseTime.Add(-2 * services.TOTPPeriod)),
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			getChallenge:             validChallenge,
+			shouldCallGetTOTP:        true,
+			getTOTP:                  confirmed,
+			shouldCallFail:           true,
+			failErr:                  fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/UseStepFailure",
+			challenge:                challenge,
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			getChallenge:             validChallenge,
+			shouldCallGetTOTP:        true,
+			getTOTP:                  confirmed,
+			shouldCallUseStep:        true,
+			useStepErr:               fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/ClearAccountFailure",
+			challenge:                challenge,
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			getChallenge:             validChallenge,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			shouldCallGetTOTP:        true,
+			getTOTP:                  confirmed,
+			shouldCallUseStep:        true,
+			shouldCallUse:            true,
+			shouldCallClearAccount:   true,
+			clearAccountErr:          fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/RecordFailureFailure",
+			challenge:                challenge,
+			code:                     mustTOTPCode(baseTime.Add(-2 * services.TOTPPeriod)),
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			getChallenge:             validChallenge,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			shouldCallGetTOTP:        true,
+			getTOTP:                  confirmed,
+			shouldCallFail:           true,
+			shouldCallRecordFailure:  true,
+			recordFailureErr:         fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                       "Error/RecordAuditEventFailure",
+			challenge:                  challenge,
+			code:                       mustTOTPCode(baseTime.Add(-2 * services.TOTPPeriod)),
+			now:                        baseTime,
+			shouldCallGetChallenge:     true,
+			getChallenge:               validChallenge,
+			shouldCallGetCredentials:   true,
+			getCredentials:             credentials,
+			shouldCallCheckThrottle:    true,
+			shouldCallGetTOTP:          true,
+			getTOTP:                    confirmed,
+			shouldCallFail:             true,
+			shouldCallRecordFailure:    true,
+			shouldCallRecordAuditEvent: true,
+			recordAuditEventErr:        fooErr,
+			expectErr:                  fooErr,
+		},
+		{
+			name:                     "Error/TooManyAttempts",
+			challenge:                challenge,
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			getChallenge:             validChallenge,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			// Failures of the password and of the second factor are counted together.
+			getAccountFailures: &dao.LoginFailuresSummaryModel{Count: 3, FirstAt: baseTime, LastAt: baseTime},
+			expectErr:          services.ErrTooManyAttempts,
+		},
+		{
+			name:                     "Error/UserNotFound",
+			challenge:                challenge,
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			getChallenge:             validChallenge,
+			shouldCallGetCredentials: true,
+			getCredentialsErr:        bunovel.ErrNotFound,
+			expectErr:                services.ErrInvalidMFAChallenge,
+		},
+		{
+			name:                     "Error/GetCredentialsFailure",
+			challenge:                challenge,
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			getChallenge:             validChallenge,
+			shouldCallGetCredentials: true,
+			getCredentialsErr:        fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                     "Error/TOTPNotConfirmed",
+			challenge:                challenge,
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			getChallenge:             validChallenge,
+			shouldCallGetTOTP:        true,
+			getTOTP: &dao.TOTPModel{
+				Metadata:      bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, nil),
+				TOTPModelCore: dao.TOTPModelCore{Secret: totpSecret},
+			},
+			expectErr: goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                     "Error/TOTPDisabled",
+			challenge:                challenge,
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			getChallenge:             validChallenge,
+			shouldCallGetTOTP:        true,
+			getTOTPErr:               bunovel.ErrNotFound,
+			expectErr:                goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                     "Error/GetTOTPFailure",
+			challenge:                challenge,
+			code:                     mustTOTPCode(baseTime),
+			now:                      baseTime,
+			shouldCallGetChallenge:   true,
+			shouldCallGetCredentials: true,
+			getCredentials:           credentials,
+			shouldCallCheckThrottle:  true,
+			getChallenge:             validChallenge,
+			shouldCallGetTOTP:        true,
+			getTOTPErr:               fooErr,
+			expectErr:                fooErr,
+		},
+		{
+			name:                   "Error/ChallengeExpired",
+			challenge:              challenge,
+			code:                   mustTOTPCode(baseTime.Add(5 * time.Minute)),
+			now:                    baseTime.Add(5 * time.Minute),
+			shouldCallGetChallenge: true,
+			getChallenge:           validChallenge,
+			expectErr:              services.ErrInvalidMFAChallenge,
+		},
+		{
+			name:                   "Error/ChallengeUsed",
+			challenge:              challenge,
+			code:                   mustTOTPCode(baseTime),
+			now:                    baseTime,
+			shouldCallGetChallenge: true,
+			getChallenge: &dao.MFAChallengeModel{
+				Metadata: validChallenge.Metadata,
+				MFAChallengeModelCore: dao.MFAChallengeModelCore{
+					UserID:      goframework.NumberUUID(10),
+					TokenHashed: privateValidationCode,
+					ExpiresAt:   baseTime.Add(5 * time.Minute),
+					UsedAt:      &baseTime,
+				},
+			},
+			expectErr: services.ErrInvalidMFAChallenge,
+		},
+		{
+			name:                   "Error/TooManyFailures",
+			challenge:              challenge,
+			code:                   mustTOTPCode(baseTime),
+			now:                    baseTime,
+			shouldCallGetChallenge: true,
+			getChallenge: &dao.MFAChallengeModel{
+				Metadata: validChallenge.Metadata,
+				MFAChallengeModelCore: dao.MFAChallengeModelCore{
+					UserID:      goframework.NumberUUID(10),
+					TokenHashed: privateValidationCode,
+					ExpiresAt:   baseTime.Add(5 * time.Minute),
+					Failures:    services.MaxMFAChallengeFailures,
+				},
+			},
+			expectErr: services.ErrInvalidMFAChallenge,
+		},
+		{
+			name:                   "Error/WrongChallengeCode",
+			challenge:              goframework.NumberUUID(1).String() + ".fake-code",
+			code:                   mustTOTPCode(baseTime),
+			now:                    baseTime,
+			shouldCallGetChallenge: true,
+			getChallenge:           validChallenge,
+			expectErr:              goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                   "Error/ChallengeNotFound",
+			challenge:              challenge,
+			code:                   mustTOTPCode(baseTime),
+			now:                    baseTime,
+			shouldCallGetChallenge: true,
+			getChallengeErr:        bunovel.ErrNotFound,
+			expectErr:              goframework.ErrInvalidCredentials,
+		},
+		{
+			name:                   "Error/GetChallengeFailure",
+			challenge:              challenge,
+			code:                   mustTOTPCode(baseTime),
+			now:                    baseTime,
+			shouldCallGetChallenge: true,
+			getChallengeErr:        fooErr,
+			expectErr:              fooErr,
+		},
+		{
+			name:      "Error/InvalidChallengeID",
+			challenge: "not-an-id." + publicValidationCode,
+			code:      mustTOTPCode(baseTime),
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name:      "Error/InvalidChallenge",
+			challenge: "challenge",
+			code:      mustTOTPCode(baseTime),
+			now:       baseTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			mfaChallengesDAO := daomocks.NewMFAChallengesRepository(t)
+			totpDAO := daomocks.NewTOTPRepository(t)
+			credentialsDAO := daomocks.NewCredentialsRepository(t)
+			loginFailuresDAO := daomocks.NewLoginFailuresRepository(t)
+			auditEventsDAO := daomocks.NewAuditEventsRepository(t)
+			createSessionService := servicesmocks.NewCreateSessionService(t)
+
+			if d.shouldCallGetChallenge {
+				mfaChallengesDAO.
+					On("Get", context.Background(), goframework.NumberUUID(1)).
+					Return(d.getChallenge, d.getChallengeErr)
+			}
+
+			if d.shouldCallGetCredentials {
+				credentialsDAO.
+					On("GetCredentials", context.Background(), goframework.NumberUUID(10)).
+					Return(d.getCredentials, d.getCredentialsErr)
+			}
+
+			if d.shouldCallCheckThrottle {
+				loginFailuresDAO.
+					On("GetIPFailures", context.Background(), client.IP, d.now.Add(-loginThrottle.IPWindow)).
+					Return(&dao.LoginFailuresSummaryModel{}, nil)
+				loginFailuresDAO.
+					On("GetAccountFailures", context.Background(), "user@domain.com", d.now.Add(-loginThrottle.AccountWindow)).
+					Return(lo.Ternary(d.getAccountFailures != nil, d.getAccountFailures, &dao.LoginFailuresSummaryModel{}), nil)
+			}
+
+			if d.shouldCallGetTOTP {
+				totpDAO.
+					On("Get", context.Background(), goframework.NumberUUID(10)).
+					Return(d.getTOTP, d.getTOTPErr)
+			}
+
+			if d.shouldCallUseStep {
+				totpDAO.
+					On("UseStep", context.Background(), goframework.NumberUUID(10), services.TOTPStep(d.now), d.now).
+					Return(d.useStepErr)
+			}
+
+			if d.shouldCallFail {
+				mfaChallengesDAO.
+					On("Fail", context.Background(), goframework.NumberUUID(1), d.now).
+					Return(nil, d.failErr)
+			}
+
+			if d.shouldCallRecordFailure {
+				loginFailuresDAO.
+					On("Record", context.Background(), &dao.LoginFailureModelCore{
+						Account: "user@domain.com",
+						IP:      client.IP,
+					}, mock.Anything, d.now).
+					Return(nil, d.recordFailureErr)
+			}
+
+			if d.shouldCallRecordAuditEvent {
+				auditEventsDAO.
+					On("RecordAuditEvent", context.Background(), &dao.AuditEventModelCore{
+						Kind:      dao.AuditEventLoginFailed,
+						UserID:    goframework.NumberUUID(10),
+						IP:        client.IP,
+						UserAgent: client.UserAgent,
+						Details:   map[string]string{"email": "user@domain.com", "factor": "totp"},
+					}, mock.Anything, d.now).
+					Return(nil, d.recordAuditEventErr)
+			}
+
+			if d.shouldCallUse {
+				mfaChallengesDAO.
+					On("Use", context.Background(), goframework.NumberUUID(1), d.now).
+					Return(nil, d.useErr)
+			}
+
+			if d.shouldCallClearAccount {
+				loginFailuresDAO.
+					On("ClearAccount", context.Background(), "user@domain.com", d.now).
+					Return(d.clearAccountErr)
+			}
+
+			if d.shouldCallCreateSession {
+				createSessionService.
+					On("CreateSession", context.Background(), goframework.NumberUUID(10), client, d.now).
+					Return(d.createSession, d.createSessionErr)
+			}
+
+			service := services.NewVerifyMFAService(
+				mfaChallengesDAO, totpDAO, credentialsDAO, loginFailuresDAO, auditEventsDAO, createSessionService, loginThrottle,
+			)
+			res, err := service.VerifyMFA(context.Background(), d.challenge, d.code, client, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			mfaChallengesDAO.AssertExpectations(t)
+			totpDAO.AssertExpectations(t)
+			credentialsDAO.AssertExpectations(t)
+			loginFailuresDAO.AssertExpectations(t)
+			auditEventsDAO.AssertExpectations(t)
+			createSessionService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,478 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"github.com/a-novel/auth-service/pkg/dao"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/ugorji/go/codec"
+	"math/big"
+	"time"
+)
+
+const (
+	// WebAuthnChallengeSize is the number of random bytes in a challenge. The ID of the challenge is prepended to
+	// them, so it can be retrieved from the client data.
+	WebAuthnChallengeSize = 32
+	// MaxPasskeyCredentialIDLength is the maximum length of a credential ID, as defined by the WebAuthn specification.
+	MaxPasskeyCredentialIDLength = 1023
+)
+
+// COSE algorithm identifiers, from the IANA registry.
+const (
+	coseAlgES256 = -7
+	coseAlgEdDSA = -8
+	coseAlgRS256 = -257
+)
+
+// COSE key parameters, from RFC 9053.
+const (
+	coseKeyKty     = 1
+	coseKeyAlg     = 3
+	coseKeyCrv     = -1
+	coseKeyX       = -2
+	coseKeyY       = -3
+	coseKeyRSAN    = -1
+	coseKeyRSAE    = -2
+	coseKtyOKP     = 1
+	coseKtyEC2     = 2
+	coseKtyRSA     = 3
+	coseCrvP256    = 1
+	coseCrvEd25519 = 6
+)
+
+// Authenticator data flags.
+const (
+	authenticatorFlagUserPresent  = 0x01
+	authenticatorFlagUserVerified = 0x04
+	authenticatorFlagAttestedData = 0x40
+)
+
+const (
+	webAuthnTypeCreate = "webauthn.create"
+	webAuthnTypeGet    = "webauthn.get"
+
+	webAuthnPublicKey = "public-key"
+)
+
+// WebAuthnSupportedAlgorithms are the COSE algorithms accepted for passkeys, in order of preference.
+var WebAuthnSupportedAlgorithms = []int{coseAlgES256, coseAlgEdDSA, coseAlgRS256}
+
+var (
+	webAuthnEncoding = base64.RawURLEncoding
+	cborHandle       = new(codec.CborHandle)
+)
+
+// WebAuthnRelyingParty describes the service to the authenticators.
+type WebAuthnRelyingParty struct {
+	// ID is the domain passkeys are bound to. It must be the host of the frontend, or one of its parent domains.
+	ID string
+	// Name is shown by authenticators when a passkey is registered.
+	Name string
+	// Origins are the frontend URLs allowed to run the ceremonies. "*" allows any origin, for development.
+	Origins []string
+	// ChallengeTTL is the time given to the user to complete a ceremony.
+	ChallengeTTL time.Duration
+}
+
+func (rp WebAuthnRelyingParty) checkOrigin(origin string) bool {
+	return lo.Contains(rp.Origins, "*") || lo.Contains(rp.Origins, origin)
+}
+
+// webAuthnClientData is the data signed by the authenticator, along with the authenticator data.
+type webAuthnClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// authenticatorData is the binary structure returned by authenticators, as described in the WebAuthn specification.
+type authenticatorData struct {
+	RPIDHash  []byte
+	Flags     byte
+	SignCount uint32
+	// CredentialID and PublicKey are only set when a new credential is registered.
+	CredentialID []byte
+	PublicKey    []byte
+}
+
+type attestationObject struct {
+	Fmt      string `codec:"fmt"`
+	AuthData []byte `codec:"authData"`
+	// AttStmt is not verified: the service does not request attestation, so it trusts passkeys regardless of the
+	// model of the authenticator.
+	AttStmt map[string]interface{} `codec:"attStmt"`
+}
+
+// newWebAuthnChallenge generates a challenge, that starts with the ID used to store it.
+func newWebAuthnChallenge(id uuid.UUID) ([]byte, error) {
+	random := make([]byte, WebAuthnChallengeSize)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+
+	return append(id[:], random...), nil
+}
+
+// createWebAuthnChallenge stores a new challenge for the given ceremony, and returns its encoded value.
+func createWebAuthnChallenge(
+	ctx context.Context,
+	challengesDAO dao.WebAuthnChallengesRepository,
+	ceremony string,
+	userID *uuid.UUID,
+	ttl time.Duration,
+	now time.Time,
+) (string, error) {
+	id := uuid.New()
+
+	challenge, err := newWebAuthnChallenge(id)
+	if err != nil {
+		return "", goerrors.Join(ErrGenerateWebAuthnChallenge, err)
+	}
+
+	_, err = challengesDAO.Create(ctx, &dao.WebAuthnChallengeModelCore{
+		UserID:    userID,
+		Ceremony:  ceremony,
+		Challenge: challenge,
+		ExpiresAt: now.Add(ttl),
+	}, id, now)
+	if err != nil {
+		return "", goerrors.Join(ErrCreateWebAuthnChallenge, err)
+	}
+
+	return webAuthnEncoding.EncodeToString(challenge), nil
+}
+
+// useWebAuthnChallenge checks the client data of a ceremony, and consumes the challenge it signs. A challenge can only
+// be used once, even if the ceremony fails afterward.
+func useWebAuthnChallenge(
+	ctx context.Context,
+	challengesDAO dao.WebAuthnChallengesRepository,
+	rp WebAuthnRelyingParty,
+	clientDataJSON []byte,
+	clientDataType string,
+	ceremony string,
+	now time.Time,
+) (*dao.WebAuthnChallengeModel, error) {
+	clientData := new(webAuthnClientData)
+	if err := json.Unmarshal(clientDataJSON, clientData); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey, err)
+	}
+
+	challenge, err := webAuthnEncoding.DecodeString(clientData.Challenge)
+	if err != nil || len(challenge) != len(uuid.UUID{})+WebAuthnChallengeSize {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidWebAuthnChallenge, err)
+	}
+
+	id, err := uuid.FromBytes(challenge[:len(uuid.UUID{})])
+	if err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidWebAuthnChallenge, err)
+	}
+
+	model, err := challengesDAO.Use(ctx, id, now)
+	if err != nil {
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidWebAuthnChallenge, err)
+		}
+
+		return nil, goerrors.Join(ErrUseWebAuthnChallenge, err)
+	}
+
+	if subtle.ConstantTimeCompare(model.Challenge, challenge) != 1 ||
+		model.Ceremony != ceremony ||
+		!model.ExpiresAt.After(now) {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidWebAuthnChallenge)
+	}
+
+	if clientData.Type != clientDataType || !rp.checkOrigin(clientData.Origin) {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrPasskeyRejected)
+	}
+
+	return model, nil
+}
+
+// parseAuthenticatorData decodes the authenticator data, and checks it was issued for the relying party, with user
+// verification.
+func parseAuthenticatorData(rp WebAuthnRelyingParty, data []byte) (*authenticatorData, error) {
+	// rpIdHash (32) + flags (1) + signCount (4).
+	if len(data) < 37 {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey)
+	}
+
+	output := &authenticatorData{
+		RPIDHash:  data[:32],
+		Flags:     data[32],
+		SignCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if subtle.ConstantTimeCompare(output.RPIDHash, rpIDHash[:]) != 1 {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrPasskeyRejected)
+	}
+
+	// Passkeys replace the password, so the authenticator must verify the user (biometrics, PIN...), not only their
+	// presence.
+	if output.Flags&authenticatorFlagUserPresent == 0 || output.Flags&authenticatorFlagUserVerified == 0 {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrPasskeyRejected)
+	}
+
+	if output.Flags&authenticatorFlagAttestedData == 0 {
+		return output, nil
+	}
+
+	// aaguid (16) + credentialIdLength (2).
+	rest := data[37:]
+	if len(rest) < 18 {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey)
+	}
+
+	credentialIDLength := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if credentialIDLength > MaxPasskeyCredentialIDLength || len(rest) < credentialIDLength {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey)
+	}
+
+	output.CredentialID = rest[:credentialIDLength]
+	rest = rest[credentialIDLength:]
+
+	// The public key is followed by optional extensions, so its length is only known once decoded.
+	var publicKey interface{}
+	decoder := codec.NewDecoderBytes(rest, cborHandle)
+	if err := decoder.Decode(&publicKey); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey, err)
+	}
+
+	output.PublicKey = rest[:decoder.NumBytesRead()]
+
+	return output, nil
+}
+
+// parseAttestationObject decodes the attestation object returned on registration.
+func parseAttestationObject(rp WebAuthnRelyingParty, data []byte) (*authenticatorData, error) {
+	attestation := new(attestationObject)
+	if err := codec.NewDecoderBytes(data, cborHandle).Decode(attestation); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey, err)
+	}
+
+	authData, err := parseAuthenticatorData(rp, attestation.AuthData)
+	if err != nil {
+		return nil, err
+	}
+
+	if authData.CredentialID == nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey)
+	}
+
+	return authData, nil
+}
+
+func coseInt(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case uint64:
+		return int64(v), v <= 1<<63-1
+	default:
+		return 0, false
+	}
+}
+
+func coseBytes(value interface{}) []byte {
+	v, _ := value.([]byte)
+	return v
+}
+
+// parseCOSEKey decodes a COSE encoded public key. It returns the algorithm the key must be used with.
+func parseCOSEKey(data []byte) (crypto.PublicKey, int64, error) {
+	var raw map[int64]interface{}
+	if err := codec.NewDecoderBytes(data, cborHandle).Decode(&raw); err != nil {
+		return nil, 0, err
+	}
+
+	kty, _ := coseInt(raw[coseKeyKty])
+	alg, _ := coseInt(raw[coseKeyAlg])
+	crv, _ := coseInt(raw[coseKeyCrv])
+
+	switch {
+	case kty == coseKtyEC2 && alg == coseAlgES256 && crv == coseCrvP256:
+		x, y := coseBytes(raw[coseKeyX]), coseBytes(raw[coseKeyY])
+		if len(x) != 32 || len(y) != 32 {
+			return nil, 0, fmt.Errorf("invalid P-256 coordinates")
+		}
+
+		// Check the point is on the curve.
+		if _, err := ecdh.P256().NewPublicKey(append(append([]byte{0x04}, x...), y...)); err != nil {
+			return nil, 0, err
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, alg, nil
+	case kty == coseKtyOKP && alg == coseAlgEdDSA && crv == coseCrvEd25519:
+		x := coseBytes(raw[coseKeyX])
+		if len(x) != ed25519.PublicKeySize {
+			return nil, 0, fmt.Errorf("invalid ed25519 key")
+		}
+
+		return ed25519.PublicKey(x), alg, nil
+	case kty == coseKtyRSA && alg == coseAlgRS256:
+		n, e := coseBytes(raw[coseKeyRSAN]), coseBytes(raw[coseKeyRSAE])
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, 0, fmt.Errorf("invalid rsa key")
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, alg, nil
+	default:
+		return nil, 0, fmt.Errorf("unsupported key type %d with algorithm %d", kty, alg)
+	}
+}
+
+// verifyWebAuthnSignature checks the signature of an assertion, made over the authenticator data and the hash of the
+// client data.
+func verifyWebAuthnSignature(publicKey []byte, authData, clientDataJSON, signature []byte) error {
+	key, alg, err := parseCOSEKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	message := append(append([]byte{}, authData...), clientDataHash[:]...)
+	digest := sha256.Sum256(message)
+
+	var ok bool
+	switch alg {
+	case coseAlgES256:
+		ok = ecdsa.VerifyASN1(key.(*ecdsa.PublicKey), digest[:], signature)
+	case coseAlgEdDSA:
+		ok = ed25519.Verify(key.(ed25519.PublicKey), message, signature)
+	case coseAlgRS256:
+		ok = rsa.VerifyPKCS1v15(key.(*rsa.PublicKey), crypto.SHA256, digest[:], signature) == nil
+	}
+
+	if !ok {
+		return fmt.Errorf("signature mismatch")
+	}
+
+	return nil
+}
+
+// verifyPasskeyAssertion runs the checks of an authentication ceremony, and returns the passkey that signed it. When
+// the challenge was issued for a given user, the passkey must belong to them. The signature counter of the passkey is
+// updated.
+func verifyPasskeyAssertion(
+	ctx context.Context,
+	challengesDAO dao.WebAuthnChallengesRepository,
+	passkeysDAO dao.PasskeysRepository,
+	rp WebAuthnRelyingParty,
+	form models.PasskeyAssertionForm,
+	ceremony string,
+	now time.Time,
+) (*dao.PasskeyModel, error) {
+	if form.Type != webAuthnPublicKey {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey)
+	}
+
+	credentialID, err := webAuthnEncoding.DecodeString(form.RawID)
+	if err != nil || len(credentialID) == 0 || len(credentialID) > MaxPasskeyCredentialIDLength {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey, err)
+	}
+
+	clientDataJSON, err := webAuthnEncoding.DecodeString(form.Response.ClientDataJSON)
+	if err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey, err)
+	}
+
+	rawAuthData, err := webAuthnEncoding.DecodeString(form.Response.AuthenticatorData)
+	if err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey, err)
+	}
+
+	signature, err := webAuthnEncoding.DecodeString(form.Response.Signature)
+	if err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey, err)
+	}
+
+	userHandle, err := webAuthnEncoding.DecodeString(form.Response.UserHandle)
+	if err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPasskey, err)
+	}
+
+	challenge, err := useWebAuthnChallenge(ctx, challengesDAO, rp, clientDataJSON, webAuthnTypeGet, ceremony, now)
+	if err != nil {
+		return nil, err
+	}
+
+	passkey, err := passkeysDAO.GetByCredentialID(ctx, credentialID)
+	if err != nil {
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrPasskeyRejected, err)
+		}
+
+		return nil, goerrors.Join(ErrGetPasskey, err)
+	}
+
+	if challenge.UserID != nil && *challenge.UserID != passkey.UserID {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrPasskeyRejected)
+	}
+
+	// The user handle is optional, but must match the owner of the passkey when present.
+	if len(userHandle) > 0 && !bytes.Equal(userHandle, passkey.UserID[:]) {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrPasskeyRejected)
+	}
+
+	authData, err := parseAuthenticatorData(rp, rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyWebAuthnSignature(passkey.PublicKey, rawAuthData, clientDataJSON, signature); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrPasskeyRejected, err)
+	}
+
+	// Authenticators that keep a counter increment it on each use. A counter that goes back may indicate a cloned
+	// authenticator.
+	if (authData.SignCount != 0 || passkey.SignCount != 0) && authData.SignCount <= passkey.SignCount {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrPasskeyRejected)
+	}
+
+	passkey, err = passkeysDAO.Use(ctx, passkey.ID, authData.SignCount, now)
+	if err != nil {
+		return nil, goerrors.Join(ErrUsePasskey, err)
+	}
+
+	return passkey, nil
+}
+
+// passkeyDescriptors lists the passkeys of a user, in the format expected by authenticators.
+func passkeyDescriptors(passkeys []*dao.PasskeyModel) []models.PasskeyCredentialDescriptor {
+	return lo.Map(passkeys, func(item *dao.PasskeyModel, _ int) models.PasskeyCredentialDescriptor {
+		return models.PasskeyCredentialDescriptor{
+			Type:       webAuthnPublicKey,
+			ID:         webAuthnEncoding.EncodeToString(item.CredentialID),
+			Transports: item.Transports,
+		}
+	})
+}
@@ -0,0 +1,189 @@
+package services_test
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/a-novel/auth-service/pkg/dao"
+	daomocks "github.com/a-novel/auth-service/pkg/dao/mocks"
+	"github.com/a-novel/auth-service/pkg/models"
+	"github.com/a-novel/auth-service/pkg/services"
+	servicesmocks "github.com/a-novel/auth-service/pkg/services/mocks"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// The ceremonies below were recorded from a software authenticator, with an ES256 key, for the relying party
+// webAuthnRP. Each challenge starts with its ID: goframework.NumberUUID(1) for the registration, NumberUUID(2) for
+// the passwordless login, and NumberUUID(3) for the second factor. The passkey belongs to NumberUUID(10).
+var webAuthnRP = services.WebAuthnRelyingParty{
+	ID:           "agoradesecrivains.fr",
+	Name:         "Agora des écrivains",
+	Origins:      []string{"https://agoradesecrivains.fr"},
+	ChallengeTTL: 5 * time.Minute,
+}
+
+var (
+	passkeyCredentialID = "GHAr_rxfnj27QkwFmwZ5ww"
+	passkeyPublicKey    = "pSJYIErfM5l72DFOe0AGAfR3ChjLgkOA04OhMnaFpN0INVPqIVggC9_GPmrMpZwp52PjrPmggYgYRa_5OzfD07BUD3tBiLAgAQECAyY"
+	passkeyUserHandle   = "EBAQEBAQEBAQEBAQEBAQEA"
+
+	passkeyRegistration = models.PasskeyAttestationForm{
+		ID:    passkeyCredentialID,
+		RawID: passkeyCredentialID,
+		Type:  "public-key",
+		Response: models.PasskeyAttestationResponse{
+			ClientDataJSON:    "eyJjaGFsbGVuZ2UiOiJBUUVCQVFFQkFRRUJBUUVCQVFFQkFSQVJFaE1VRlJZWEdCa2FHeHdkSGg4Z0lTSWpKQ1VtSnlncEtpc3NMUzR2IiwiY3Jvc3NPcmlnaW4iOmZhbHNlLCJvcmlnaW4iOiJodHRwczovL2Fnb3JhZGVzZWNyaXZhaW5zLmZyIiwidHlwZSI6IndlYmF1dGhuLmNyZWF0ZSJ9",
+			AttestationObject: "o2dhdHRTdG10oGhhdXRoRGF0YViU2ZzojRHD9rSo_BsMooZWiG2aQ2sLCHMVLgvWvjSKxdVFAAAAAAAAAAAAAAAAAAAAAAAAAAAAEBhwK_68X549u0JMBZsGecOlIlggSt8zmXvYMU57QAYB9HcKGMuCQ4DTg6EydoWk3Qg1U-ohWCAL38Y-asylnCnnY-Os-aCBiBhFr_k7N8PTsFQPe0GIsCABAQIDJmNmbXRkbm9uZQ",
+			Transports:        []string{"internal", "hybrid", "internal", "teleport"},
+		},
+	}
+
+	passkeyLogin = models.PasskeyAssertionForm{
+		ID:    passkeyCredentialID,
+		RawID: passkeyCredentialID,
+		Type:  "public-key",
+		Response: models.PasskeyAssertionResponse{
+			ClientDataJSON:    "eyJjaGFsbGVuZ2UiOiJBZ0lDQWdJQ0FnSUNBZ0lDQWdJQ0FpQWhJaU1rSlNZbktDa3FLeXd0TGk4d01USXpORFUyTnpnNU9qczhQVDRfIiwiY3Jvc3NPcmlnaW4iOmZhbHNlLCJvcmlnaW4iOiJodHRwczovL2Fnb3JhZGVzZWNyaXZhaW5zLmZyIiwidHlwZSI6IndlYmF1dGhuLmdldCJ9",
+			AuthenticatorData: "2ZzojRHD9rSo_BsMooZWiG2aQ2sLCHMVLgvWvjSKxdUFAAAAAQ",
+			Signature:         "MEYCIQCbq5KoC1nkvnzIoiWYNs1hQy04EdVW4ncgUTArsX3NeAIhAJd4pE-V2CS59ux2m4VF_V8E4DH2dxlSzFBCIcwscA7P",
+			UserHandle:        passkeyUserHandle,
+		},
+	}
+
+	passkeyMFA = models.PasskeyAssertionForm{
+		ID:    passkeyCredentialID,
+		RawID: passkeyCredentialID,
+		Type:  "public-key",
+		Response: models.PasskeyAssertionResponse{
+			ClientDataJSON:    "eyJjaGFsbGVuZ2UiOiJBd01EQXdNREF3TURBd01EQXdNREF6QXhNak0wTlRZM09EazZPenc5UGo5QVFVSkRSRVZHUjBoSlNrdE1UVTVQIiwiY3Jvc3NPcmlnaW4iOmZhbHNlLCJvcmlnaW4iOiJodHRwczovL2Fnb3JhZGVzZWNyaXZhaW5zLmZyIiwidHlwZSI6IndlYmF1dGhuLmdldCJ9",
+			AuthenticatorData: "2ZzojRHD9rSo_BsMooZWiG2aQ2sLCHMVLgvWvjSKxdUFAAAAAg",
+			Signature:         "MEYCIQCL5TzseacQyd8gXwvIn2zsCvrh2Na3H2tCs9z61ZY7ogIhAPjt93eQD-fGyWtxOcCkbJD9LTCHodKD7rxCiX1q9-Kq",
+		},
+	}
+)
+
+func mustDecodeWebAuthn(src string) []byte {
+	output, err := base64.RawURLEncoding.DecodeString(src)
+	if err != nil {
+		panic(err)
+	}
+
+	return output
+}
+
+func encodeWebAuthn(src []byte) string {
+	return base64.RawURLEncoding.EncodeToString(src)
+}
+
+// webAuthnChallenge returns the challenge used to record the ceremony with the given ID.
+func webAuthnChallenge(id int) []byte {
+	uid := goframework.NumberUUID(id)
+	random := make([]byte, services.WebAuthnChallengeSize)
+	for i := range random {
+		random[i] = byte(id*16 + i)
+	}
+
+	return append(uid[:], random...)
+}
+
+func webAuthnChallengeModel(id int, ceremony string, userID *uuid.UUID) *dao.WebAuthnChallengeModel {
+	return &dao.WebAuthnChallengeModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(id), baseTime, &baseTime),
+		WebAuthnChallengeModelCore: dao.WebAuthnChallengeModelCore{
+			UserID:    userID,
+			Ceremony:  ceremony,
+			Challenge: webAuthnChallenge(id),
+			ExpiresAt: baseTime.Add(5 * time.Minute),
+			UsedAt:    &baseTime,
+		},
+	}
+}
+
+func passkeyModel(signCount uint32) *dao.PasskeyModel {
+	return &dao.PasskeyModel{
+		Metadata: bunovel.NewMetadata(goframework.NumberUUID(100), baseTime, nil),
+		PasskeyModelCore: dao.PasskeyModelCore{
+			UserID:       goframework.NumberUUID(10),
+			CredentialID: mustDecodeWebAuthn(passkeyCredentialID),
+			PublicKey:    mustDecodeWebAuthn(passkeyPublicKey),
+			SignCount:    signCount,
+			Transports:   []string{"internal", "hybrid"},
+		},
+	}
+}
+
+// TestPasskeyCeremonies registers the recorded passkey, then logs in with it, using the stored values.
+func TestPasskeyCeremonies(t *testing.T) {
+	webAuthnChallengesDAO := daomocks.NewWebAuthnChallengesRepository(t)
+	passkeysDAO := daomocks.NewPasskeysRepository(t)
+	introspectTokenService := servicesmocks.NewIntrospectTokenService(t)
+	createSessionService := servicesmocks.NewCreateSessionService(t)
+
+	introspectTokenService.
+		On("IntrospectToken", context.Background(), "string-token", baseTime, false).
+		Return(&models.UserTokenStatus{
+			OK:    true,
+			Token: &models.UserToken{Payload: models.UserTokenPayload{ID: goframework.NumberUUID(10)}},
+		}, nil)
+
+	webAuthnChallengesDAO.
+		On("Use", context.Background(), goframework.NumberUUID(1), baseTime).
+		Return(webAuthnChallengeModel(1, dao.WebAuthnCeremonyRegistration, lo.ToPtr(goframework.NumberUUID(10))), nil)
+
+	var stored *dao.PasskeyModel
+	passkeysDAO.
+		On("Create", context.Background(), mock.Anything, mock.Anything, baseTime).
+		Run(func(args mock.Arguments) {
+			stored = &dao.PasskeyModel{
+				Metadata:         bunovel.NewMetadata(args.Get(2).(uuid.UUID), baseTime, nil),
+				PasskeyModelCore: *args.Get(1).(*dao.PasskeyModelCore),
+			}
+		}).
+		Return(nil, nil)
+
+	registrationService := services.NewFinishPasskeyRegistrationService(
+		webAuthnChallengesDAO, passkeysDAO, introspectTokenService, webAuthnRP,
+	)
+	require.NoError(t, registrationService.FinishPasskeyRegistration(context.Background(), "string-token", passkeyRegistration, baseTime))
+
+	require.NotNil(t, stored)
+	require.Equal(t, goframework.NumberUUID(10), stored.UserID)
+	require.Equal(t, mustDecodeWebAuthn(passkeyCredentialID), stored.CredentialID)
+	require.Equal(t, mustDecodeWebAuthn(passkeyPublicKey), stored.PublicKey)
+	require.Equal(t, uint32(0), stored.SignCount)
+	require.Equal(t, []string{"internal", "hybrid"}, stored.Transports)
+
+	loginTime := baseTime.Add(time.Minute)
+
+	webAuthnChallengesDAO.
+		On("Use", context.Background(), goframework.NumberUUID(2), loginTime).
+		Return(webAuthnChallengeModel(2, dao.WebAuthnCeremonyLogin, nil), nil)
+
+	passkeysDAO.
+		On("GetByCredentialID", context.Background(), mustDecodeWebAuthn(passkeyCredentialID)).
+		Return(stored, nil)
+
+	passkeysDAO.
+		On("Use", context.Background(), stored.ID, uint32(1), loginTime).
+		Return(stored, nil)
+
+	createSessionService.
+		On("CreateSession", context.Background(), goframework.NumberUUID(10), models.ClientInfo{}, loginTime).
+		Return(&models.UserTokenStatus{OK: true, RefreshToken: "refresh-token"}, nil)
+
+	loginService := services.NewFinishPasskeyLoginService(webAuthnChallengesDAO, passkeysDAO, createSessionService, webAuthnRP)
+	res, err := loginService.FinishPasskeyLogin(context.Background(), passkeyLogin, models.ClientInfo{}, loginTime)
+	require.NoError(t, err)
+	require.Equal(t, &models.UserTokenStatus{OK: true, RefreshToken: "refresh-token"}, res)
+
+	webAuthnChallengesDAO.AssertExpectations(t)
+	passkeysDAO.AssertExpectations(t)
+	introspectTokenService.AssertExpectations(t)
+	createSessionService.AssertExpectations(t)
+}